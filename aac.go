@@ -2,6 +2,8 @@
 // Ported from FAAD2: ~/dev/faad2/
 package aac
 
+import "github.com/llehouerou/go-aac/internal/syntax"
+
 // ObjectType represents an AAC audio object type.
 // Source: ~/dev/faad2/include/neaacdec.h:74-83
 type ObjectType uint8
@@ -44,6 +46,15 @@ const (
 	OutputFormatDouble OutputFormat = 5 // 64-bit float
 )
 
+// ByteOrder selects the byte order of DecodeBytes output.
+type ByteOrder uint8
+
+// Byte Orders.
+const (
+	ByteOrderLittleEndian ByteOrder = 0 // WAV, most sound APIs
+	ByteOrderBigEndian    ByteOrder = 1 // AIFF, network order
+)
+
 // ChannelPosition represents the spatial position of an audio channel.
 // Source: ~/dev/faad2/include/neaacdec.h:113-123
 type ChannelPosition uint8
@@ -74,6 +85,18 @@ const (
 	SBRNoneUpsampled SBRSignalling = 3 // No SBR but upsampled
 )
 
+// SBRPresence reports whether a stream carries SBR (FrameInfo.SBRPresence).
+// Its values are those of sbrPresentFlag in an AudioSpecificConfig, with
+// -1 when the stream does not signal it.
+type SBRPresence int8
+
+// SBR presence values.
+const (
+	SBRUnknown    SBRPresence = -1 // Not signalled; SBR data may still appear
+	SBRNotPresent SBRPresence = 0  // No SBR
+	SBRPresent    SBRPresence = 1  // Signalled, or seen in the frames
+)
+
 // MinStreamSize is the minimum bytes per channel that should be available.
 // Source: ~/dev/faad2/include/neaacdec.h:135
 const MinStreamSize = 768 // 6144 bits/channel
@@ -91,19 +114,114 @@ const (
 	CapabilityER   Capability = 1 << 4 // Can decode Error Resilient
 )
 
-// version is the library version string.
-const version = "1.0.0"
+// LibraryVersion is the library version string. It is a constant so
+// that callers can compare against it at compile time; Version returns
+// the same value.
+const LibraryVersion = "1.0.0"
 
 // Version returns the library version string.
 // Ported from: NeAACDecGetVersion() in ~/dev/faad2/libfaad/decoder.c:71-87
 func Version() string {
-	return version
+	return LibraryVersion
 }
 
-// GetCapabilities returns a bitmask of supported decoder capabilities.
+// GetCapabilities returns a bitmask of supported decoder capabilities:
+// the object types whose syntax is supported and whose element decoder
+// and filter bank are linked.
 // Ported from: NeAACDecGetCapabilities() in ~/dev/faad2/libfaad/decoder.c:96-120
 func GetCapabilities() Capability {
-	return CapabilityLC | CapabilityMain | CapabilityLTP | CapabilityLD | CapabilityER
+	var caps Capability
+	for _, c := range []struct {
+		ot  ObjectType
+		cap Capability
+	}{
+		{ObjectTypeLC, CapabilityLC},
+		{ObjectTypeMain, CapabilityMain},
+		{ObjectTypeLTP, CapabilityLTP},
+		{ObjectTypeLD, CapabilityLD},
+		{ObjectTypeERLC, CapabilityER},
+	} {
+		if decodableOT(c.ot) {
+			caps |= c.cap
+		}
+	}
+	return caps
+}
+
+// decodableOT reports whether this build decodes objectType: its syntax
+// is supported and the decoders it needs are linked.
+func decodableOT(objectType ObjectType) bool {
+	return canDecodeOT(objectType) && elementDecoderFactory != nil && filterBankFactory != nil
+}
+
+// Features describes what this build of the library supports, so callers
+// can feature-detect across versions with evolving support instead of
+// probing with streams. Unlike Capability it also covers extensions,
+// container formats and output formats that FAAD2's capability mask does
+// not report. It describes the library, not a stream: Decoder.Capabilities
+// reports the stream a decoder was initialized for.
+type Features struct {
+	// Object types, matching GetCapabilities
+	HasLC   bool
+	HasMain bool
+	HasLTP  bool
+	HasLD   bool
+	HasER   bool
+
+	// ObjectTypes are all the decodable audio object types, in ascending
+	// order, including those GetCapabilities has no bit for.
+	ObjectTypes []ObjectType
+
+	// Extensions
+	HasSBR bool // Spectral Band Replication (HE-AAC)
+	HasPS  bool // Parametric Stereo (HE-AACv2)
+
+	// Containers besides ADTS and raw streams configured with Init2
+	HasADIF bool
+	HasLATM bool
+
+	MaxChannels   int            // Most channels of a stream, and of Config.ForceChannels
+	OutputFormats []OutputFormat // Valid values of Config.OutputFormat
+}
+
+// GetFeatures returns the features supported by this build. SBR and PS
+// are not reported: the SBR payloads are parsed, but neither is
+// reconstructed.
+func GetFeatures() Features {
+	caps := GetCapabilities()
+	var objectTypes []ObjectType
+	for ot := ObjectTypeMain; ot <= ObjectTypeDRMERLC; ot++ {
+		if decodableOT(ot) {
+			objectTypes = append(objectTypes, ot)
+		}
+	}
+	return Features{
+		HasLC:       caps&CapabilityLC != 0,
+		HasMain:     caps&CapabilityMain != 0,
+		HasLTP:      caps&CapabilityLTP != 0,
+		HasLD:       caps&CapabilityLD != 0,
+		HasER:       caps&CapabilityER != 0,
+		ObjectTypes: objectTypes,
+		HasADIF:     true,
+		HasLATM:     true,
+		MaxChannels: maxChannels,
+		OutputFormats: []OutputFormat{
+			OutputFormat16Bit,
+			OutputFormat24Bit,
+			OutputFormat32Bit,
+			OutputFormatFloat,
+			OutputFormatDouble,
+		},
+	}
+}
+
+// NoiseGenerator is a noise source for perceptual noise substitution
+// (PNS), replacing FAAD2's random number generator. Fill overwrites spec
+// with the noise of one band whose energy is set by scaleFactor: the
+// default source produces random values with a total energy of
+// 2^(scaleFactor/2), i.e. an amplitude of 2^(scaleFactor/4).
+type NoiseGenerator interface {
+	Fill(spec []float64, scaleFactor int)
 }
 
 // Config contains decoder configuration options.
@@ -115,6 +233,174 @@ type Config struct {
 	DownMatrix              bool         // Downmix multichannel to stereo
 	UseOldADTSFormat        bool         // Use old ADTS format
 	DontUpSampleImplicitSBR bool         // Don't upsample implicit SBR
+
+	// ParallelChannels reconstructs the spectra of the independent
+	// elements of a frame (SCE, CPE, LFE) concurrently, on up to
+	// GOMAXPROCS goroutines; the filter bank still runs in bitstream
+	// order. PNS noise then comes from per-element generators, so output
+	// stays deterministic but is not bit-identical to sequential decoding
+	// for streams using PNS.
+	ParallelChannels bool
+
+	// ForceChannels fixes the number of output channels regardless of
+	// the stream, for consumers that cannot follow channel count changes
+	// across a playlist. ForceChannelsMode selects how the decoded
+	// channels are adapted. 0 outputs the decoded channels; a non-zero
+	// value supersedes DownMatrix.
+	ForceChannels     uint8
+	ForceChannelsMode ForceChannelsMode
+
+	// DownmixMono mixes multichannel streams down to a single front
+	// center channel, for voice and feature extraction pipelines. Stereo
+	// is folded at -3 dB and 3.0/5.x through the DownMatrix stereo mix;
+	// the exact matrix is documented on output.DownmixToMono. It
+	// supersedes DownMatrix, and ForceChannels supersedes it.
+	DownmixMono bool
+
+	// IncludeLFE mixes the LFE channel into both outputs of the 5.1 and
+	// 7.1 to stereo mix of DownMatrix and ForceChannels, at LFEGain relative to
+	// the front channels; FAAD2 leaves it out, as does the default. A zero
+	// LFEGain selects output.DefaultLFEGain (-10 dB). Before mixing, the
+	// LFE goes through a second-order Butterworth lowpass at LFECutoff Hz,
+	// which keeps the coding noise above the LFE band out of the mix; its
+	// state carries across frames and is cleared by Reset. A zero
+	// LFECutoff selects output.DefaultLFECutoff (120 Hz) and a negative
+	// one mixes the LFE unfiltered. The LFE is mixed in float32, so it
+	// takes precedence over HighPrecisionDownmix.
+	IncludeLFE bool
+	LFEGain    float32
+	LFECutoff  float32
+
+	// MaxChannels caps the channels a frame may decode to, bounding the
+	// per-channel buffers for memory-constrained targets. Decode returns
+	// ErrChannelLimitExceeded for a frame with more channel elements
+	// before allocating any for the excess channels. 0 means the default
+	// of 64, the most the decoder supports.
+	MaxChannels uint8
+
+	// Float32Spectra reconstructs spectra in float32 instead of float64,
+	// halving the memory of the spectral buffers for embedded and WASM
+	// targets. The FFT and filter bank already run in float32. The output
+	// differs from the float64 default by less than one 16-bit LSB.
+	Float32Spectra bool
+
+	// SourceBitDepth is the effective bit depth of the source material,
+	// for OutputFormat32Bit. FAAD2 scales 32-bit output by 65536 as if all
+	// 32 bits were significant; a non-zero SourceBitDepth (8-32) instead
+	// rounds samples to that many bits, left-justified in the int32, so a
+	// 24-bit source comes out as its 24-bit values shifted left by 8.
+	// 0 keeps the FAAD2 scaling.
+	SourceBitDepth uint8
+
+	// HighPrecisionDownmix computes the DownMatrix 5.1 to stereo mix in
+	// float64 for OutputFormatDouble. FAAD2 mixes in float32 for every
+	// format, so by default double output carries float32 rounding of the
+	// matrix sums. A matrix mixdown signalled by a PCE, the 7.1 mix and
+	// ForceChannels are always mixed in float32.
+	HighPrecisionDownmix bool
+
+	// Dither adds dither noise to OutputFormat16Bit samples before they
+	// are rounded, which FAAD2 does not. The noise comes from a generator
+	// of the decoder started from DitherSeed at Init, Init2 and Reset, so
+	// dithered output is reproducible. DitherNone keeps FAAD2's output.
+	Dither     DitherMode
+	DitherSeed uint32
+
+	// ComputeTonality measures the spectral flatness of every channel
+	// after reconstruction and reports it in FrameInfo.Tonality, for
+	// adaptive post-processing such as choosing dither.
+	ComputeTonality bool
+
+	// FrameStats reports in FrameInfo.Elements and FrameInfo.ChannelTools
+	// the size of every syntax element of a frame and the tools each
+	// channel was coded with, for encoder tuning and debugging.
+	FrameStats bool
+
+	// Strict rejects bitstream values that FAAD2 clamps or tolerates,
+	// for validating encoder output: a TNS filter order above 20
+	// (ErrTNSOrderOutOfRange), a global_gain other than the first
+	// spectral scalefactor (ErrGlobalGainMismatch), and PNS energies or
+	// intensity positions beyond the +-120 clamp
+	// (ErrScalefactorOutOfRange). A max_sfb above the band count and
+	// spectral scalefactors outside 0-255 are errors in every mode.
+	Strict bool
+
+	// Metering measures the peak, clipped sample count and per-channel
+	// RMS level of every frame of output into FrameInfo.Meter, for
+	// mastering and loudness tools.
+	Metering bool
+
+	// NoiseGenerator, when set, generates PNS noise instead of FAAD2's
+	// generator, e.g. a silent or fixed-pattern source so that noise bands
+	// are reproducible across decoder implementations. Nil keeps the
+	// FAAD2 generator. With ParallelChannels it is called concurrently.
+	NoiseGenerator NoiseGenerator
+
+	// AllowLayoutChange lets a program config element at the start of a
+	// frame switch the stream to a different channel layout. By default a
+	// refreshed PCE must repeat the layout the decoder was configured
+	// with, and Decode returns ErrLayoutChanged otherwise.
+	AllowLayoutChange bool
+
+	// VerifyCRC checks the crc_check of protected ADTS frames, which
+	// FAAD2 skips, and makes Decode return ErrADTSCRCMismatch for a frame
+	// that fails it. The CRC covers the header and the leading bits of
+	// each element, 192 per channel element and 128 for the second
+	// channel of a CPE. Frames carrying several raw_data_blocks are not
+	// checked.
+	VerifyCRC bool
+
+	// ConcealErrors makes Decode conceal a frame that fails to decode
+	// instead of returning the error: it returns a frame of silence or a
+	// faded repeat of the previous frame's output, reports the error in
+	// FrameInfo.Error and advances past the bad frame. See ConcealMode.
+	ConcealErrors ConcealMode
+
+	// DRC applies the dynamic range control data that streams carry in
+	// fill elements, scaled as selected. FAAD2 always applies it, with
+	// Cut and Boost of 1; nil leaves the dynamic range untouched.
+	DRC *DRCControl
+
+	// Gapless, when set, trims the encoder delay from the start of the
+	// output and the padding from its end, for seamless playback of
+	// consecutive tracks. FrameInfo.TrimmedSamples reports the samples
+	// removed from each frame.
+	Gapless *GaplessInfo
+
+	// Planar makes Decode return one slice per output channel ([][]int16,
+	// [][]float32, ...) instead of interleaved samples. FrameInfo.Samples
+	// still counts the samples of all channels.
+	Planar bool
+
+	// ChannelOrder selects the order of the output channels. OrderWAV
+	// reorders them for WAV files and most audio APIs, and
+	// FrameInfo.ChannelPosition then lists the positions in that order.
+	// Mixdowns to stereo or mono are not affected.
+	ChannelOrder ChannelOrder
+
+	// ByteOrder is the byte order of the samples returned by DecodeBytes.
+	// The zero value is little-endian.
+	ByteOrder ByteOrder
+
+	// PoolBuffers takes the per-frame spectral buffers and the channel
+	// buffers from pools shared by all decoders with it set, and returns
+	// the channel buffers to them on Close, cutting allocations for
+	// servers decoding many streams. Use DecodeInto to also decode into a
+	// reused output buffer.
+	PoolBuffers bool
+
+	// MDCTTap, when set, receives for every IMDCT the pre-twiddled
+	// coefficients handed to the inverse FFT (interleaved re/im).
+	// It is called once per long block and eight times per short
+	// sequence; coeffs is reused and must be copied to be retained.
+	MDCTTap func(channel int, coeffs []float32)
+
+	// SpectrumCallback, when set, receives the reconstructed spectrum of
+	// every output channel, after TNS and DRC and right before the
+	// inverse filter bank: frameLength dequantized MDCT coefficients, in
+	// window order for eight short sequences. spec is only valid during
+	// the call and must be copied to be retained.
+	SpectrumCallback func(channel uint8, spec []float64)
 }
 
 // FrameInfo contains information about a decoded frame.
@@ -126,9 +412,19 @@ type FrameInfo struct {
 	Error         Error  // Error code (0 = no error)
 	SampleRate    uint32 // Output sample rate
 
-	// SBR status: 0=off, 1=upsampled, 2=downsampled, 3=off but upsampled
+	// SBR status: 0=off, 1=upsampled, 2=downsampled, 3=off but upsampled.
+	// Always SBRNone, as the SBR high band is not reconstructed; see
+	// SBRPresence.
 	SBR SBRSignalling
 
+	// SBRPresence reports whether the stream carries SBR: signalled
+	// explicitly by its AudioSpecificConfig (hierarchically, by object
+	// type 5 or 29, or backward compatibly, after the core config), or
+	// implicitly, by SBR data in its frames. Until SBR data is seen, a
+	// stream without explicit signalling is SBRUnknown at core rates up to
+	// 24 kHz, where SBR would double the rate, and SBRNotPresent above.
+	SBRPresence SBRPresence
+
 	ObjectType ObjectType // MPEG-4 ObjectType
 	HeaderType HeaderType // AAC header type (RAW, ADIF, ADTS, LATM)
 
@@ -139,31 +435,35 @@ type FrameInfo struct {
 	NumLFEChannels   uint8
 	ChannelPosition  [64]ChannelPosition
 
-	// Parametric Stereo: 0=off, 1=on
+	// Parametric Stereo: 0=off, 1=on. Always 0, as PS data is not
+	// decoded; see PSPresent.
 	PS uint8
+
+	// PSPresent reports that the AudioSpecificConfig signals Parametric
+	// Stereo (HE-AACv2), by object type 29 or a psPresentFlag. PS reports
+	// decoded PS data.
+	PSPresent bool
+
+	// Tonality holds the spectral flatness of each decoded channel, from
+	// about 0 (tonal) to 1 (noise-like). Nil unless Config.ComputeTonality
+	// is set.
+	Tonality []float32
+
+	// Elements lists the syntax elements of the frame in bitstream order,
+	// and ChannelTools the window sequence and tools of each decoded
+	// channel. Nil unless Config.FrameStats is set.
+	Elements     []ElementStats
+	ChannelTools []ChannelTools
+
+	// Meter holds the output levels of the frame. Nil unless
+	// Config.Metering is set.
+	Meter *Meter
+
+	// TrimmedSamples is the number of samples, over all channels, that
+	// Config.Gapless removed from this frame's output.
+	TrimmedSamples uint32
 }
 
 // AudioSpecificConfig contains the MP4 AudioSpecificConfig data.
 // Source: ~/dev/faad2/include/neaacdec.h:140-161
-type AudioSpecificConfig struct {
-	// Audio Specific Info
-	ObjectTypeIndex        uint8
-	SamplingFrequencyIndex uint8
-	SamplingFrequency      uint32
-	ChannelsConfiguration  uint8
-
-	// GA Specific Info
-	FrameLengthFlag                  bool
-	DependsOnCoreCoder               bool
-	CoreCoderDelay                   uint16
-	ExtensionFlag                    bool
-	AACSectionDataResilienceFlag     bool
-	AACScalefactorDataResilienceFlag bool
-	AACSpectralDataResilienceFlag    bool
-	EPConfig                         uint8
-
-	// SBR extension
-	SBRPresentFlag  int8
-	ForceUpSampling bool
-	DownSampledSBR  bool
-}
+type AudioSpecificConfig = syntax.AudioSpecificConfig
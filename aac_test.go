@@ -1,6 +1,9 @@
 package aac
 
-import "testing"
+import (
+	"slices"
+	"testing"
+)
 
 // TestObjectTypeConstants verifies object type values match FAAD2.
 // Source: ~/dev/faad2/include/neaacdec.h:74-83
@@ -149,6 +152,79 @@ func TestVersion(t *testing.T) {
 	if version == "" {
 		t.Error("Version() returned empty string")
 	}
+	if version != LibraryVersion {
+		t.Errorf("Version() = %q, want LibraryVersion %q", version, LibraryVersion)
+	}
+}
+
+func TestGetFeatures(t *testing.T) {
+	f := GetFeatures()
+	caps := GetCapabilities()
+
+	objectTypes := []struct {
+		name string
+		has  bool
+		cap  Capability
+	}{
+		{"LC", f.HasLC, CapabilityLC},
+		{"Main", f.HasMain, CapabilityMain},
+		{"LTP", f.HasLTP, CapabilityLTP},
+		{"LD", f.HasLD, CapabilityLD},
+		{"ER", f.HasER, CapabilityER},
+	}
+	for _, ot := range objectTypes {
+		if ot.has != (caps&ot.cap != 0) {
+			t.Errorf("Has%s = %v, disagrees with GetCapabilities", ot.name, ot.has)
+		}
+	}
+
+	if !f.HasADIF {
+		t.Error("HasADIF should be set, ADIF streams are decoded")
+	}
+	if !f.HasLATM {
+		t.Error("HasLATM should be set, LOAS/LATM streams are decoded")
+	}
+	if f.HasSBR || f.HasPS {
+		t.Error("SBR and PS are not implemented yet")
+	}
+
+	for _, ot := range []ObjectType{ObjectTypeLC, ObjectTypeMain, ObjectTypeLTP, ObjectTypeLD} {
+		if !slices.Contains(f.ObjectTypes, ot) {
+			t.Errorf("ObjectTypes %v lacks %d", f.ObjectTypes, ot)
+		}
+	}
+	if slices.Contains(f.ObjectTypes, ObjectTypeHEAAC) {
+		t.Error("ObjectTypes lists the SBR object type, which is signalling only")
+	}
+	if !slices.IsSorted(f.ObjectTypes) {
+		t.Errorf("ObjectTypes %v not in ascending order", f.ObjectTypes)
+	}
+	if f.MaxChannels != maxChannels {
+		t.Errorf("MaxChannels = %d, want %d", f.MaxChannels, maxChannels)
+	}
+	for _, format := range f.OutputFormats {
+		if err := validateConfig(&Config{OutputFormat: format}); err != nil {
+			t.Errorf("output format %d rejected by SetConfig: %v", format, err)
+		}
+	}
+	if len(f.OutputFormats) != 5 {
+		t.Errorf("%d output formats, want 5", len(f.OutputFormats))
+	}
+}
+
+// TestGetFeatures_Linked checks that the features follow the decoders
+// actually linked: nothing decodes without an element decoder.
+func TestGetFeatures_Linked(t *testing.T) {
+	saved := elementDecoderFactory
+	defer func() { elementDecoderFactory = saved }()
+
+	elementDecoderFactory = nil
+	if caps := GetCapabilities(); caps != 0 {
+		t.Errorf("GetCapabilities() = %#x without an element decoder, want 0", caps)
+	}
+	if f := GetFeatures(); len(f.ObjectTypes) != 0 || f.HasLC {
+		t.Errorf("GetFeatures() lists %v without an element decoder", f.ObjectTypes)
+	}
 }
 
 func TestGetCapabilities(t *testing.T) {
@@ -0,0 +1,53 @@
+// adif.go
+package aac
+
+import (
+	"errors"
+
+	"github.com/llehouerou/go-aac/internal/bits"
+	"github.com/llehouerou/go-aac/internal/syntax"
+)
+
+// parseADIFHeader parses an ADIF header, including its "ADIF" magic, with
+// the syntax package and maps its errors to decoder error codes.
+//
+// Ported from: get_adif_header() in ~/dev/faad2/libfaad/syntax.c:2400-2446
+func parseADIFHeader(r *bits.Reader) (*syntax.ADIFHeader, error) {
+	if !syntax.CheckADIFMagic(r) {
+		return nil, ErrNoHeaderDetected
+	}
+	h, err := syntax.ParseADIF(r)
+	if errors.Is(err, syntax.ErrADIFBitstream) {
+		return nil, ErrInputBufferTooSmall
+	}
+	return h, pceError(err)
+}
+
+// parseProgramConfig parses a program config element with the syntax
+// package, returning ErrProgramConfigElement for one with more channels
+// than the decoder supports.
+//
+// Ported from: program_config_element() in ~/dev/faad2/libfaad/syntax.c:174-323
+func parseProgramConfig(r *bits.Reader) (*syntax.ProgramConfig, error) {
+	pce, err := syntax.ParsePCE(r)
+	if err != nil {
+		return nil, pceError(err)
+	}
+	return pce, nil
+}
+
+// pceError maps the errors of syntax.ParsePCE to decoder error codes.
+func pceError(err error) error {
+	if errors.Is(err, syntax.ErrTooManyChannels) {
+		return ErrProgramConfigElement
+	}
+	return err
+}
+
+// samePCELayout reports whether p and o describe the same channel layout.
+func samePCELayout(p, o *syntax.ProgramConfig) bool {
+	return p.NumFrontChannels == o.NumFrontChannels &&
+		p.NumSideChannels == o.NumSideChannels &&
+		p.NumBackChannels == o.NumBackChannels &&
+		p.NumLFEChannels == o.NumLFEChannels
+}
@@ -0,0 +1,235 @@
+// adif_test.go
+package aac
+
+import (
+	"io"
+	"os"
+	"slices"
+	"testing"
+)
+
+// adifBitWriter packs MSB-first bit fields for building ADIF fixtures.
+type adifBitWriter struct {
+	buf    []byte
+	bitPos int
+}
+
+func (w *adifBitWriter) writeBits(val uint32, n int) {
+	for i := n - 1; i >= 0; i-- {
+		if w.bitPos%8 == 0 {
+			w.buf = append(w.buf, 0)
+		}
+		if (val>>i)&1 == 1 {
+			w.buf[len(w.buf)-1] |= 1 << (7 - w.bitPos%8)
+		}
+		w.bitPos++
+	}
+}
+
+func (w *adifBitWriter) byteAlign() {
+	w.bitPos = (w.bitPos + 7) &^ 7
+}
+
+// buildADIFHeader returns an ADIF header with numPCE stereo LC 44100 Hz
+// program config elements, the first carrying the given comment.
+func buildADIFHeader(constantRate bool, numPCE int, comment string) []byte {
+	return buildADIFHeaderLayout(constantRate, numPCE, comment, true)
+}
+
+// buildADIFHeaderLayout is buildADIFHeader with a front channel element
+// that is a CPE (stereo) or an SCE (mono).
+func buildADIFHeaderLayout(constantRate bool, numPCE int, comment string, cpe bool) []byte {
+	w := &adifBitWriter{}
+	for _, c := range "ADIF" {
+		w.writeBits(uint32(c), 8)
+	}
+	w.writeBits(0, 1) // copyright_id_present
+	w.writeBits(0, 1) // original_copy
+	w.writeBits(0, 1) // home
+	if constantRate {
+		w.writeBits(0, 1)
+	} else {
+		w.writeBits(1, 1)
+	}
+	w.writeBits(128000, 23)          // bitrate
+	w.writeBits(uint32(numPCE-1), 4) // num_program_config_elements
+
+	for i := 0; i < numPCE; i++ {
+		if constantRate {
+			w.writeBits(0, 20) // adif_buffer_fullness
+		}
+		w.writeBits(0, 4) // element_instance_tag
+		w.writeBits(1, 2) // object_type (LC)
+		w.writeBits(4, 4) // sf_index (44100)
+		w.writeBits(1, 4) // num_front_channel_elements
+		w.writeBits(0, 4) // num_side_channel_elements
+		w.writeBits(0, 4) // num_back_channel_elements
+		w.writeBits(0, 2) // num_lfe_channel_elements
+		w.writeBits(0, 3) // num_assoc_data_elements
+		w.writeBits(0, 4) // num_valid_cc_elements
+		w.writeBits(0, 3) // mono/stereo/matrix mixdown absent
+		if cpe {
+			w.writeBits(1, 1) // front_element_is_cpe
+		} else {
+			w.writeBits(0, 1)
+		}
+		w.writeBits(0, 4) // front_element_tag_select
+		w.byteAlign()
+
+		text := ""
+		if i == 0 {
+			text = comment
+		}
+		w.writeBits(uint32(len(text)), 8)
+		for _, c := range []byte(text) {
+			w.writeBits(uint32(c), 8)
+		}
+	}
+	return w.buf
+}
+
+// buildADIFStream returns an ADIF header followed by numBlocks
+// raw_data_blocks holding only ID_END.
+func buildADIFStream(numBlocks int) []byte {
+	data := buildADIFHeader(false, 1, "")
+	for i := 0; i < numBlocks; i++ {
+		data = append(data, 0xE0) // ID_END (111) + byte alignment
+	}
+	return data
+}
+
+func TestDecoder_Init_ADIF(t *testing.T) {
+	tests := []struct {
+		name         string
+		constantRate bool
+		numPCE       int
+		comment      string
+	}{
+		{"variable rate", false, 1, ""},
+		{"constant rate", true, 1, ""},
+		{"multiple PCEs with comment", true, 3, "go-aac"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			header := buildADIFHeader(tt.constantRate, tt.numPCE, tt.comment)
+			data := append(append([]byte(nil), header...), 0xE0)
+
+			d := NewDecoder()
+			result, err := d.Init(data)
+			if err != nil {
+				t.Fatalf("Init failed: %v", err)
+			}
+			if result.BytesRead != uint32(len(header)) {
+				t.Errorf("BytesRead: got %d, want %d", result.BytesRead, len(header))
+			}
+			if result.SampleRate != 44100 {
+				t.Errorf("SampleRate: got %d, want 44100", result.SampleRate)
+			}
+			if result.Channels != 2 {
+				t.Errorf("Channels: got %d, want 2", result.Channels)
+			}
+			if ObjectType(d.objectType) != ObjectTypeLC {
+				t.Errorf("objectType: got %d, want LC", d.objectType)
+			}
+			if !d.adifHeaderPresent || d.adtsHeaderPresent {
+				t.Error("only adifHeaderPresent should be set")
+			}
+		})
+	}
+}
+
+func TestDecoder_Init_ADIF_Truncated(t *testing.T) {
+	header := buildADIFHeader(false, 1, "")
+
+	d := NewDecoder()
+	_, err := d.Init(header[:len(header)-2])
+	if err != ErrInputBufferTooSmall {
+		t.Errorf("expected ErrInputBufferTooSmall, got %v", err)
+	}
+}
+
+func TestDecoder_Decode_ADIFBlocks(t *testing.T) {
+	data := buildADIFStream(3)
+
+	d := NewDecoder()
+	result, err := d.Init(data)
+	if err != nil {
+		t.Fatalf("Init failed: %v", err)
+	}
+
+	// Blocks follow each other without headers
+	pos := int(result.BytesRead)
+	blocks := 0
+	for pos < len(data) {
+		_, info, err := d.Decode(data[pos:])
+		if err != nil {
+			t.Fatalf("block %d: Decode failed: %v", blocks, err)
+		}
+		if info.HeaderType != HeaderTypeADIF {
+			t.Errorf("block %d: HeaderType: got %v, want ADIF", blocks, info.HeaderType)
+		}
+		if info.BytesConsumed != 1 {
+			t.Fatalf("block %d: BytesConsumed: got %d, want 1", blocks, info.BytesConsumed)
+		}
+		pos += int(info.BytesConsumed)
+		blocks++
+	}
+	if blocks != 3 {
+		t.Errorf("decoded %d blocks, want 3", blocks)
+	}
+}
+
+// TestDecode_ADIFFrames decodes the raw_data_blocks of sine1k.aac behind
+// an ADIF header, and checks them against the ADTS decode.
+func TestDecode_ADIFFrames(t *testing.T) {
+	data, err := os.ReadFile("testdata/sine1k.aac")
+	if err != nil {
+		t.Skipf("sine1k.aac not available: %v", err)
+	}
+	ref := NewDecoder()
+	if _, err := ref.Init(data); err != nil {
+		t.Fatalf("Init ADTS: %v", err)
+	}
+	want, _ := decodeAll(t, ref, data)
+
+	adif := buildADIFHeaderLayout(false, 1, "", false)
+	for _, p := range adtsPayloads(t, data) {
+		adif = append(adif, p...)
+	}
+
+	d := NewDecoder()
+	result, err := d.Init(adif)
+	if err != nil {
+		t.Fatalf("Init ADIF: %v", err)
+	}
+	if result.SampleRate != 44100 || result.Channels != 1 {
+		t.Errorf("Init = %+v, want 44100 Hz mono", result)
+	}
+
+	got, types := decodeAll(t, d, adif[result.BytesRead:])
+	if len(got) != len(want) {
+		t.Fatalf("%d frames, want %d", len(got), len(want))
+	}
+	nonSilent := false
+	for i := range want {
+		if types[i] != HeaderTypeADIF {
+			t.Fatalf("frame %d: HeaderType %v, want ADIF", i, types[i])
+		}
+		if !slices.Equal(got[i], want[i]) {
+			t.Fatalf("frame %d differs from the ADTS decode", i)
+		}
+		nonSilent = nonSilent || slices.ContainsFunc(got[i], func(s int16) bool { return s != 0 })
+	}
+	if !nonSilent {
+		t.Error("the ADIF decode is silent")
+	}
+}
+
+func TestDecodeFile_ADIF(t *testing.T) {
+	path := writeFixture(t, buildADIFStream(4))
+
+	if err := DecodeFile(path, io.Discard, NewDecoder().Config()); err != nil {
+		t.Fatalf("DecodeFile failed: %v", err)
+	}
+}
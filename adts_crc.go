@@ -0,0 +1,126 @@
+// adts_crc.go
+package aac
+
+// adtsCRCPoly is the CRC-16 generator x^16 + x^15 + x^2 + 1 of ISO/IEC
+// 11172-3 subclause 2.4.3.1, which adts_error_check() refers to.
+const adtsCRCPoly = 0x8005
+
+// Bits of a channel element protected by crc_check, counted from the bit
+// after its id_syn_ele: the first channel of a CPE, with the common
+// ics_info and M/S mask, and SCE, LFE and CCE elements are protected over
+// their first 192 bits, and the second channel of a CPE over its first
+// 128 bits.
+//
+// Source: ISO/IEC 13818-7 error detection (also ISO/IEC 14496-3 1.A.3.2.1)
+const (
+	crcElementBits       = 192
+	crcSecondChannelBits = 128
+)
+
+// adtsCRC updates crc with bits [from, to) of buf, most significant bit
+// first.
+func adtsCRC(crc uint16, buf []byte, from, to uint32) uint16 {
+	for i := from; i < to; i++ {
+		crc = adtsCRCBit(crc, uint16(buf[i/8]>>(7-i%8))&1)
+	}
+	return crc
+}
+
+// adtsCRCBit updates crc with one bit.
+func adtsCRCBit(crc, bit uint16) uint16 {
+	msb := crc >> 15
+	crc <<= 1
+	if msb^bit == 1 {
+		crc ^= adtsCRCPoly
+	}
+	return crc
+}
+
+// crcRegion is a span of a raw_data_block protected by crc_check: the
+// length bits from start, of which the bits at or past end, beyond the
+// element, are counted as zeros. Positions are those of the bit reader.
+type crcRegion struct {
+	start, end, length uint32
+}
+
+// crcOpenEnd is the end of a region of an element not parsed yet, which
+// verifyCRC bounds by the end of the frame.
+const crcOpenEnd = ^uint32(0)
+
+// openCRCElement notes, while d.collectCRC is set, the bits protected by
+// crc_check of the element whose id_syn_ele starts at start: all of its
+// id_syn_ele and of a PCE, the leading bits of the channel elements and
+// none of a FIL element. Until closeCRCElement, the element is taken to
+// run to the end of the frame, so that a frame whose parsing fails can
+// still be checked.
+func (d *Decoder) openCRCElement(id elementID, start uint32) {
+	if !d.collectCRC {
+		return
+	}
+	body := start + uint32(lenSEID)
+	d.crcRegions = append(d.crcRegions, crcRegion{start, body, uint32(lenSEID)})
+	switch id {
+	case idSCE, idLFE, idCCE, idCPE:
+		d.crcRegions = append(d.crcRegions, crcRegion{body, crcOpenEnd, crcElementBits})
+	case idPCE:
+		d.crcRegions = append(d.crcRegions, crcRegion{body, crcOpenEnd, 0})
+	}
+}
+
+// closeCRCElement bounds the regions of the element opened last, which
+// ended at end. second is where the second channel of a CPE started.
+func (d *Decoder) closeCRCElement(id elementID, end, second uint32) {
+	if !d.collectCRC {
+		return
+	}
+	last := &d.crcRegions[len(d.crcRegions)-1]
+	switch id {
+	case idSCE, idLFE, idCCE:
+		last.end = end
+	case idCPE:
+		if second < last.start || second > end {
+			second = end
+		}
+		last.end = second
+		d.crcRegions = append(d.crcRegions, crcRegion{second, end, crcSecondChannelBits})
+	case idPCE:
+		last.end = end
+		last.length = end - last.start
+	}
+}
+
+// verifyCRC checks the crc_check of the frame starting in buffer against
+// the fixed and variable headers and the regions of its raw_data_block
+// noted by openCRCElement. The CRC register starts with all bits set.
+//
+// With several raw_data_blocks, crc_check protects only the header and
+// each block carries its own CRC; such frames, like unprotected ones,
+// are accepted unchecked.
+func (h *adtsFrameHeader) verifyCRC(buffer []byte, regions []crcRegion) error {
+	if !h.crcChecked() {
+		return nil
+	}
+	end := h.syncOffset + uint32(h.FrameLength)
+	if end > uint32(len(buffer)) {
+		return ErrInputBufferTooSmall
+	}
+
+	start := h.syncOffset * 8
+	crc := adtsCRC(0xFFFF, buffer, start, start+h.headerBits)
+	for _, reg := range regions {
+		to := max(reg.start, min(reg.start+reg.length, reg.end, end*8))
+		crc = adtsCRC(crc, buffer, reg.start, to)
+		for range reg.start + reg.length - to {
+			crc = adtsCRCBit(crc, 0)
+		}
+	}
+	if crc != h.CRCCheck {
+		return ErrADTSCRCMismatch
+	}
+	return nil
+}
+
+// crcChecked reports whether verifyCRC checks the frame's crc_check.
+func (h *adtsFrameHeader) crcChecked() bool {
+	return h.CRCPresent && h.NumBlocks == 0
+}
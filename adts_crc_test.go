@@ -0,0 +1,190 @@
+// adts_crc_test.go
+package aac
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"testing"
+)
+
+// protectADTSFrame rewrites an unprotected ADTS frame of one
+// raw_data_block with a crc_check, computed independently of verifyCRC
+// over the 7 header bytes, every id_syn_ele and the first 192 bits of
+// each SCE, zero-padded. The element sizes come from FrameStats.
+func protectADTSFrame(t *testing.T, frame []byte) []byte {
+	t.Helper()
+	d := NewDecoder()
+	cfg := d.Config()
+	cfg.FrameStats = true
+	d.SetConfiguration(cfg)
+	if _, err := d.Init(frame); err != nil {
+		t.Fatalf("Init: %v", err)
+	}
+	_, info, err := d.Decode(frame)
+	if err != nil {
+		t.Fatalf("Decode: %v", err)
+	}
+
+	hdr := bytes.Clone(frame[:7])
+	payload := frame[7:]
+	hdr[1] &^= 0x01 // protection_absent = 0
+
+	frameLen := len(frame) + 2
+	hdr[3] = hdr[3]&0xFC | byte(frameLen>>11)
+	hdr[4] = byte(frameLen >> 3)
+	hdr[5] = hdr[5]&0x1F | byte(frameLen<<5)
+
+	crc := uint16(0xFFFF)
+	update := func(buf []byte, from, n, avail int) {
+		for i := from; i < from+n; i++ {
+			var bit uint16
+			if i < from+avail {
+				bit = uint16(buf[i/8]>>(7-i%8)) & 1
+			}
+			if (crc>>15)^bit == 1 {
+				crc = crc<<1 ^ 0x8005
+			} else {
+				crc <<= 1
+			}
+		}
+	}
+	update(hdr, 0, 56, 56)
+	pos := 0
+	for _, el := range info.Elements {
+		update(payload, pos, 3, 3) // id_syn_ele
+		switch el.Type {
+		case ElementSCE:
+			update(payload, pos+3, 192, min(192, int(el.Bits)-3))
+		case ElementFIL:
+		default:
+			t.Fatalf("protectADTSFrame: unexpected element %d", el.Type)
+		}
+		pos += int(el.Bits)
+	}
+	update(payload, pos, 3, 3) // ID_END
+
+	out := append(hdr, byte(crc>>8), byte(crc))
+	return append(out, payload...)
+}
+
+func TestADTSCRC_CheckValue(t *testing.T) {
+	// CRC-16 with this generator and an all-ones register (CRC-16/CMS)
+	data := []byte("123456789")
+	if got := adtsCRC(0xFFFF, data, 0, uint32(len(data))*8); got != 0xAEE7 {
+		t.Errorf("adtsCRC = %#04x, want 0xaee7", got)
+	}
+}
+
+// TestVerifyCRC_SineFrames checks the protected regions on frames of
+// sine1k.aac: a flipped bit of the header or within the first 192 bits
+// of the SCE fails the check, one past them does not.
+func TestVerifyCRC_SineFrames(t *testing.T) {
+	data, err := os.ReadFile("testdata/sine1k.aac")
+	if err != nil {
+		t.Skipf("sine1k.aac not available: %v", err)
+	}
+
+	decode := func(frame []byte) error {
+		d := NewDecoder()
+		cfg := d.Config()
+		cfg.VerifyCRC = true
+		d.SetConfiguration(cfg)
+		if _, err := d.Init(frame); err != nil {
+			t.Fatalf("Init: %v", err)
+		}
+		_, _, err := d.Decode(frame)
+		return err
+	}
+	for off, n := 0, 0; off+7 <= len(data) && n < 8; n++ {
+		frameLen := int(data[off+3]&0x03)<<11 | int(data[off+4])<<3 | int(data[off+5]>>5)
+		frame := protectADTSFrame(t, data[off:off+frameLen])
+		off += frameLen
+
+		if err := decode(frame); err != nil {
+			t.Fatalf("frame %d: Decode = %v, want nil", n, err)
+		}
+
+		// The ADTS header, and the element_instance_tag of the SCE
+		for _, bit := range []int{20, 9*8 + 3} {
+			corrupt := bytes.Clone(frame)
+			corrupt[bit/8] ^= 0x80 >> (bit % 8)
+			if err := decode(corrupt); !errors.Is(err, ErrADTSCRCMismatch) {
+				t.Errorf("frame %d bit %d: Decode = %v, want ErrADTSCRCMismatch", n, bit, err)
+			}
+		}
+
+		// The byte alignment after ID_END
+		if last := len(frame)*8 - 1; frame[len(frame)-1]&1 == 0 {
+			corrupt := bytes.Clone(frame)
+			corrupt[last/8] ^= 0x01
+			if err := decode(corrupt); err != nil {
+				t.Errorf("frame %d bit %d: Decode = %v, want nil outside the protected bits", n, last, err)
+			}
+		}
+	}
+}
+
+// TestVerifyCRC_Reference checks the crc_check of every frame of a
+// CRC-protected stereo stream written by fdkaac, made by
+// testdata/generate.go, which tests its CPE regions.
+func TestVerifyCRC_Reference(t *testing.T) {
+	data, err := os.ReadFile("testdata/generated/crc/sine1k_stereo.aac")
+	if err != nil {
+		t.Skipf("CRC-protected stream not available (go run testdata/generate.go): %v", err)
+	}
+	d := NewDecoder()
+	cfg := d.Config()
+	cfg.VerifyCRC = true
+	d.SetConfiguration(cfg)
+	if _, err := d.Init(data); err != nil {
+		t.Fatalf("Init: %v", err)
+	}
+	frames := 0
+	for offset := 0; offset < len(data); frames++ {
+		if data[offset+1]&0x01 != 0 {
+			t.Fatalf("frame %d: protection_absent is set", frames)
+		}
+		_, info, err := d.Decode(data[offset:])
+		if err != nil {
+			t.Fatalf("frame %d: %v", frames, err)
+		}
+		offset += int(info.BytesConsumed)
+	}
+	if frames == 0 {
+		t.Fatal("no frames decoded")
+	}
+}
+
+func TestDecode_VerifyCRC(t *testing.T) {
+	frame := protectADTSFrame(t, adtsEmptyFrame)
+	corrupt := bytes.Clone(frame)
+	corrupt[8] ^= 0x01 // crc_check
+
+	tests := []struct {
+		name      string
+		frame     []byte
+		verifyCRC bool
+		wantErr   error
+	}{
+		{"intact frame", frame, true, nil},
+		{"corrupt crc_check", corrupt, true, ErrADTSCRCMismatch},
+		{"corrupt crc_check, not verified", corrupt, false, nil},
+		{"truncated frame", frame[:len(frame)-1], true, ErrInputBufferTooSmall},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := NewDecoder()
+			cfg := d.Config()
+			cfg.VerifyCRC = tt.verifyCRC
+			d.SetConfiguration(cfg)
+			if _, err := d.Init(frame); err != nil {
+				t.Fatalf("Init: %v", err)
+			}
+			_, _, err := d.Decode(tt.frame)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Decode error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
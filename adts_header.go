@@ -0,0 +1,79 @@
+// adts_header.go
+package aac
+
+// ADTSConfig describes the ADTS header written by BuildADTSHeader.
+//
+// The flag fields map one-to-one onto the header bits of the same name, so
+// a header parsed from an existing stream can be rebuilt without losing
+// its copyright and originality information.
+type ADTSConfig struct {
+	ObjectType           ObjectType // Main, LC, SSR or LTP, not LTP with MPEG2 (profile = object type - 1)
+	SFIndex              uint8      // 4 bits: sample frequency index
+	ChannelConfiguration uint8      // 3 bits: channel config
+	MPEG2                bool       // id bit: true for MPEG-2, false for MPEG-4
+
+	PrivateBit       bool
+	Original         bool // original_copy
+	Home             bool
+	CopyrightIDBit   bool // one bit of the 72-bit copyright identifier
+	CopyrightIDStart bool // set on the frame carrying the first identifier bit
+
+	// BufferFullness is the 11-bit adts_buffer_fullness field;
+	// 0x7FF signals a variable bitrate stream.
+	BufferFullness uint16
+	// NumRawDataBlocks is the number of raw_data_block()s in the frame
+	// minus one (2 bits).
+	NumRawDataBlocks uint8
+}
+
+// adtsMaxFrameLength is the largest value of the 13-bit aac_frame_length.
+const adtsMaxFrameLength = 1<<13 - 1
+
+// BuildADTSHeader returns the 7-byte ADTS header for a frame carrying
+// payloadLen bytes of raw data. The header is written without CRC
+// (protection_absent = 1).
+//
+// Field layout follows adts_fixed_header() and adts_variable_header() in
+// ~/dev/faad2/libfaad/syntax.c:2484-2528.
+func BuildADTSHeader(cfg ADTSConfig, payloadLen int) ([]byte, error) {
+	if cfg.ObjectType < ObjectTypeMain || cfg.ObjectType > ObjectTypeLTP {
+		return nil, ErrUnsupportedObjectType
+	}
+	if cfg.MPEG2 && cfg.ObjectType == ObjectTypeLTP {
+		// MPEG-2 AAC has no LTP; its profile 3 is reserved
+		return nil, ErrUnsupportedObjectType
+	}
+	if getSampleRate(cfg.SFIndex) == 0 {
+		return nil, ErrInvalidSampleRate
+	}
+	if cfg.ChannelConfiguration > 7 || cfg.NumRawDataBlocks > 3 || cfg.BufferFullness > 0x7FF {
+		return nil, ErrBitstreamValueNotAllowed
+	}
+	frameLength := payloadLen + adtsFixedHeaderSize
+	if payloadLen < 0 || frameLength > adtsMaxFrameLength {
+		return nil, ErrADTSFrameTooLong
+	}
+
+	b := func(v bool) byte {
+		if v {
+			return 1
+		}
+		return 0
+	}
+	profile := byte(cfg.ObjectType - 1)
+
+	hdr := make([]byte, adtsFixedHeaderSize)
+	// syncword(12) id(1) layer(2) protection_absent(1)
+	hdr[0] = 0xFF
+	hdr[1] = 0xF0 | b(cfg.MPEG2)<<3 | 0x01
+	// profile(2) sf_index(4) private_bit(1) channel_configuration(3)
+	// original(1) home(1) copyright_id_bit(1) copyright_id_start(1)
+	hdr[2] = profile<<6 | cfg.SFIndex<<2 | b(cfg.PrivateBit)<<1 | cfg.ChannelConfiguration>>2
+	hdr[3] = (cfg.ChannelConfiguration&0x03)<<6 | b(cfg.Original)<<5 | b(cfg.Home)<<4 |
+		b(cfg.CopyrightIDBit)<<3 | b(cfg.CopyrightIDStart)<<2 | byte(frameLength>>11)&0x03
+	// aac_frame_length(13) adts_buffer_fullness(11) no_raw_data_blocks_in_frame(2)
+	hdr[4] = byte(frameLength >> 3)
+	hdr[5] = byte(frameLength&0x07)<<5 | byte(cfg.BufferFullness>>6)
+	hdr[6] = byte(cfg.BufferFullness&0x3F)<<2 | cfg.NumRawDataBlocks
+	return hdr, nil
+}
@@ -0,0 +1,125 @@
+// adts_header_test.go
+package aac
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/llehouerou/go-aac/internal/bits"
+)
+
+func TestBuildADTSHeader_EmptyFrame(t *testing.T) {
+	// Same header as adtsEmptyFrame: LC, 44.1 kHz, stereo, VBR, 8 bytes
+	hdr, err := BuildADTSHeader(ADTSConfig{
+		ObjectType:           ObjectTypeLC,
+		SFIndex:              4,
+		ChannelConfiguration: 2,
+		BufferFullness:       0x7FF,
+	}, 1)
+	if err != nil {
+		t.Fatalf("BuildADTSHeader: %v", err)
+	}
+	if !bytes.Equal(hdr, adtsEmptyFrame[:7]) {
+		t.Errorf("header = % X, want % X", hdr, adtsEmptyFrame[:7])
+	}
+}
+
+func TestBuildADTSHeader_CopyrightRoundTrip(t *testing.T) {
+	cfg := ADTSConfig{
+		ObjectType:           ObjectTypeLC,
+		SFIndex:              3,
+		ChannelConfiguration: 6,
+		MPEG2:                true,
+		PrivateBit:           true,
+		Original:             true,
+		Home:                 true,
+		CopyrightIDBit:       true,
+		CopyrightIDStart:     true,
+		BufferFullness:       0x155,
+		NumRawDataBlocks:     0,
+	}
+	hdr, err := BuildADTSHeader(cfg, 300)
+	if err != nil {
+		t.Fatalf("BuildADTSHeader: %v", err)
+	}
+
+	h, err := parseADTSFrameHeader(bits.NewReader(hdr), false)
+	if err != nil {
+		t.Fatalf("parseADTSFrameHeader: %v", err)
+	}
+	if !h.CopyrightIDBit || !h.CopyrightIDStart {
+		t.Errorf("copyright flags lost: bit=%v start=%v", h.CopyrightIDBit, h.CopyrightIDStart)
+	}
+	if h.FrameLength != 307 {
+		t.Errorf("FrameLength = %d, want 307", h.FrameLength)
+	}
+	if got := h.adtsConfig(); got != cfg {
+		t.Errorf("round trip:\n got %+v\nwant %+v", got, cfg)
+	}
+}
+
+func TestBuildADTSHeader_SingleFlags(t *testing.T) {
+	// Each flag must land on its own bit without disturbing its neighbours
+	tests := []struct {
+		name  string
+		cfg   ADTSConfig
+		check func(*adtsFrameHeader) bool
+	}{
+		{"original", ADTSConfig{Original: true}, func(h *adtsFrameHeader) bool { return h.Original }},
+		{"home", ADTSConfig{Home: true}, func(h *adtsFrameHeader) bool { return h.Home }},
+		{"copyright_id_bit", ADTSConfig{CopyrightIDBit: true}, func(h *adtsFrameHeader) bool { return h.CopyrightIDBit }},
+		{"copyright_id_start", ADTSConfig{CopyrightIDStart: true}, func(h *adtsFrameHeader) bool { return h.CopyrightIDStart }},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.cfg.ObjectType = ObjectTypeLC
+			tt.cfg.SFIndex = 4
+			tt.cfg.ChannelConfiguration = 2
+			hdr, err := BuildADTSHeader(tt.cfg, 100)
+			if err != nil {
+				t.Fatalf("BuildADTSHeader: %v", err)
+			}
+			h, err := parseADTSFrameHeader(bits.NewReader(hdr), false)
+			if err != nil {
+				t.Fatalf("parseADTSFrameHeader: %v", err)
+			}
+			if !tt.check(h) {
+				t.Errorf("%s not set after round trip", tt.name)
+			}
+			if got := h.adtsConfig(); got != tt.cfg {
+				t.Errorf("round trip:\n got %+v\nwant %+v", got, tt.cfg)
+			}
+		})
+	}
+}
+
+func TestBuildADTSHeader_Errors(t *testing.T) {
+	valid := ADTSConfig{ObjectType: ObjectTypeLC, SFIndex: 4, ChannelConfiguration: 2}
+	tests := []struct {
+		name       string
+		mutate     func(*ADTSConfig)
+		payloadLen int
+		want       error
+	}{
+		{"HE-AAC profile", func(c *ADTSConfig) { c.ObjectType = ObjectTypeHEAAC }, 10, ErrUnsupportedObjectType},
+		{"reserved sf index", func(c *ADTSConfig) { c.SFIndex = 13 }, 10, ErrInvalidSampleRate},
+		{"channel config", func(c *ADTSConfig) { c.ChannelConfiguration = 8 }, 10, ErrBitstreamValueNotAllowed},
+		{"raw data blocks", func(c *ADTSConfig) { c.NumRawDataBlocks = 4 }, 10, ErrBitstreamValueNotAllowed},
+		{"frame too long", func(*ADTSConfig) {}, adtsMaxFrameLength - 6, ErrADTSFrameTooLong},
+		{"negative payload", func(*ADTSConfig) {}, -1, ErrADTSFrameTooLong},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := valid
+			tt.mutate(&cfg)
+			if _, err := BuildADTSHeader(cfg, tt.payloadLen); err != tt.want {
+				t.Errorf("got %v, want %v", err, tt.want)
+			}
+		})
+	}
+
+	// The largest frame still fits
+	if _, err := BuildADTSHeader(valid, adtsMaxFrameLength-7); err != nil {
+		t.Errorf("max frame: %v", err)
+	}
+}
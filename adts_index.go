@@ -0,0 +1,93 @@
+// adts_index.go
+package aac
+
+import (
+	"bytes"
+	"sort"
+)
+
+// adtsSeekPreroll is the number of frames SeekToSample starts decoding
+// ahead of the frame holding the target: the first frame decoded after
+// Reset is muted, and the next one lacks the window shape of its
+// predecessor.
+const adtsSeekPreroll = 2
+
+// FrameOffset locates one ADTS frame of a stream indexed by
+// BuildADTSIndex.
+type FrameOffset struct {
+	Offset int64  // Byte offset of the frame's ADTS header
+	Length uint32 // Frame size in bytes, header included
+	Sample uint64 // Position of the frame's first sample, per channel
+}
+
+// BuildADTSIndex walks the ADTS frames of data, which holds a whole
+// stream, and returns the byte offset and sample position of each. ADTS
+// has no global index, so this is what seeking by time needs.
+//
+// Frames are found the way DecodeFile frames a stream: a leading ID3v2
+// tag, bytes before a syncword and ID3v1 trailers are skipped, and a
+// truncated final frame is left out. Each frame holds
+// no_raw_data_blocks_in_frame+1 blocks of FrameLength samples per
+// channel; a decoder not yet initialized assumes 1024.
+func (d *Decoder) BuildADTSIndex(data []byte) ([]FrameOffset, error) {
+	if d == nil {
+		return nil, ErrNilDecoder
+	}
+	if data == nil {
+		return nil, ErrNilBuffer
+	}
+	frameLen := uint64(d.frameLength)
+	if frameLen == 0 {
+		frameLen = 1024
+	}
+
+	var index []FrameOffset
+	var sample uint64
+	pos := id3v2Size(data)
+	for pos+adtsFixedHeaderSize <= len(data) {
+		// ID3v1 trailer ("TAG" + 125 bytes)
+		if bytes.HasPrefix(data[pos:], []byte("TAG")) {
+			pos += 128
+			continue
+		}
+		if !isADTSSync(data, pos) {
+			pos++
+			continue
+		}
+
+		length := int(data[pos+3]&0x03)<<11 | int(data[pos+4])<<3 | int(data[pos+5]>>5)
+		if !isADTSSync(data, pos+length) {
+			if next := findADTSSync(data, pos, pos+length); next > pos {
+				length = next - pos
+			}
+		}
+		if pos+length > len(data) {
+			break
+		}
+
+		blocks := uint64(data[pos+6]&0x03) + 1
+		index = append(index, FrameOffset{Offset: int64(pos), Length: uint32(length), Sample: sample})
+		sample += frameLen * blocks
+		pos += length
+	}
+	if len(index) == 0 {
+		return nil, ErrNoHeaderDetected
+	}
+	return index, nil
+}
+
+// SeekToSample returns the byte offset at which to begin decoding to
+// reach sample position target, per channel, of a stream indexed by
+// BuildADTSIndex. The offset is that of a frame a few frames before the
+// one holding target, so that the overlap is primed by the time target
+// is output: call Reset, then decode from the offset, the frame there
+// starting at the Sample of the index entry with that Offset. A target
+// past the end of the stream seeks to its last frame.
+func SeekToSample(index []FrameOffset, target uint64) int64 {
+	if len(index) == 0 {
+		return 0
+	}
+	// Last frame starting at or before target
+	i := sort.Search(len(index), func(i int) bool { return index[i].Sample > target }) - 1
+	return index[max(i-adtsSeekPreroll, 0)].Offset
+}
@@ -0,0 +1,133 @@
+// adts_index_test.go
+package aac
+
+import (
+	"errors"
+	"os"
+	"testing"
+)
+
+func TestBuildADTSIndex_Blocks(t *testing.T) {
+	// Frames of 1 to 4 raw_data_blocks, after an ID3v2 tag and junk
+	stream := []byte{'I', 'D', '3', 4, 0, 0, 0, 0, 0, 3, 1, 2, 3, 0x12, 0x34}
+	var want []int // Blocks per frame
+	for _, blocks := range []uint8{0, 3, 1, 2, 0} {
+		payload := make([]byte, 20+int(blocks))
+		header, err := BuildADTSHeader(ADTSConfig{
+			ObjectType:           ObjectTypeLC,
+			SFIndex:              4,
+			ChannelConfiguration: 2,
+			BufferFullness:       0x7FF,
+			NumRawDataBlocks:     blocks,
+		}, len(payload))
+		if err != nil {
+			t.Fatalf("BuildADTSHeader: %v", err)
+		}
+		stream = append(append(stream, header...), payload...)
+		want = append(want, int(blocks)+1)
+	}
+	// Truncated final frame
+	full := len(stream)
+	stream = append(stream, stream[15:25]...)
+
+	index, err := NewDecoder().BuildADTSIndex(stream)
+	if err != nil {
+		t.Fatalf("BuildADTSIndex: %v", err)
+	}
+	if len(index) != len(want) {
+		t.Fatalf("%d frames indexed, want %d", len(index), len(want))
+	}
+	if index[0].Offset != 15 {
+		t.Errorf("first frame at %d, want 15", index[0].Offset)
+	}
+	var sample uint64
+	for i, f := range index {
+		if f.Sample != sample {
+			t.Errorf("frame %d: Sample = %d, want %d", i, f.Sample, sample)
+		}
+		if i > 0 && f.Offset != index[i-1].Offset+int64(index[i-1].Length) {
+			t.Errorf("frame %d: Offset %d does not follow the previous frame", i, f.Offset)
+		}
+		sample += 1024 * uint64(want[i])
+	}
+	if last := index[len(index)-1]; last.Offset+int64(last.Length) != int64(full) {
+		t.Errorf("last frame ends at %d, want %d", last.Offset+int64(last.Length), full)
+	}
+
+	if _, err := NewDecoder().BuildADTSIndex([]byte("no frames here")); !errors.Is(err, ErrNoHeaderDetected) {
+		t.Errorf("BuildADTSIndex(garbage) error = %v, want ErrNoHeaderDetected", err)
+	}
+}
+
+func TestSeekToSample(t *testing.T) {
+	data, err := os.ReadFile("testdata/sine1k.aac")
+	if err != nil {
+		t.Skipf("sine1k.aac not available: %v", err)
+	}
+	d := NewDecoder()
+	if _, err := d.Init(data); err != nil {
+		t.Fatalf("Init: %v", err)
+	}
+	index, err := d.BuildADTSIndex(data)
+	if err != nil {
+		t.Fatalf("BuildADTSIndex: %v", err)
+	}
+	frames, _ := decodeAll(t, d, data)
+	if len(index) != len(frames) {
+		t.Fatalf("%d frames indexed, %d decoded", len(index), len(frames))
+	}
+	for i, f := range index {
+		if f.Sample != uint64(i)*uint64(d.FrameLength()) {
+			t.Fatalf("frame %d: Sample = %d, want %d", i, f.Sample, i*int(d.FrameLength()))
+		}
+	}
+
+	for _, tc := range []struct {
+		target uint64
+		frame  int
+	}{
+		{0, 0},
+		{1500, 0},
+		{3 * 1024, 1},
+		{5*1024 + 7, 3},
+		{1 << 40, len(index) - 3},
+	} {
+		if got := SeekToSample(index, tc.target); got != index[tc.frame].Offset {
+			t.Errorf("SeekToSample(%d) = %d, want %d (frame %d)", tc.target, got, index[tc.frame].Offset, tc.frame)
+		}
+	}
+	if got := SeekToSample(nil, 10); got != 0 {
+		t.Errorf("SeekToSample(nil) = %d, want 0", got)
+	}
+
+	// Decoding from the seek offset reproduces the target frame
+	target := 6 * uint64(d.FrameLength())
+	pos := SeekToSample(index, target)
+	d.Reset()
+	for i := range index {
+		if index[i].Offset < pos {
+			continue
+		}
+		samples, info, err := d.Decode(data[index[i].Offset:])
+		if err != nil {
+			t.Fatalf("frame %d: Decode: %v", i, err)
+		}
+		if info.BytesConsumed != index[i].Length {
+			t.Errorf("frame %d: consumed %d bytes, indexed %d", i, info.BytesConsumed, index[i].Length)
+		}
+		if index[i].Sample == target {
+			// Within one LSB: the PNS noise generator restarts at Reset
+			// instead of carrying on from the frames skipped
+			got, _ := samples.([]int16)
+			if len(got) != len(frames[i]) {
+				t.Fatalf("%d samples at the seek target, want %d", len(got), len(frames[i]))
+			}
+			for k, v := range got {
+				if diff := int(v) - int(frames[i][k]); diff < -1 || diff > 1 {
+					t.Fatalf("sample %d at the seek target = %d, full decode gives %d", k, v, frames[i][k])
+				}
+			}
+			break
+		}
+	}
+}
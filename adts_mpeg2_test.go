@@ -0,0 +1,158 @@
+// adts_mpeg2_test.go
+package aac_test
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"slices"
+	"testing"
+
+	"github.com/llehouerou/go-aac"
+)
+
+// decodeADTSFrames decodes every frame of an ADTS stream as int16 PCM with
+// PNS bands silenced, returning the frame information alongside.
+func decodeADTSFrames(t *testing.T, data []byte, cfg aac.Config) ([][]int16, []*aac.FrameInfo) {
+	t.Helper()
+	d := aac.NewDecoder()
+	cfg.NoiseGenerator = silentNoise{}
+	d.SetConfiguration(cfg)
+	if _, err := d.Init(data); err != nil {
+		t.Fatalf("Init: %v", err)
+	}
+	var frames [][]int16
+	var infos []*aac.FrameInfo
+	for offset := 0; offset < len(data); {
+		samples, info, err := d.Decode(data[offset:])
+		if err != nil {
+			t.Fatalf("frame %d: %v", len(frames), err)
+		}
+		offset += int(info.BytesConsumed)
+		frames = append(frames, samples.([]int16))
+		infos = append(infos, info)
+	}
+	return frames, infos
+}
+
+// TestDecode_MPEG2ADTS decodes sine1k.aac with SBR fill elements added,
+// with the id bit of every header set to MPEG-2, where the SBR data is
+// skipped rather than signalling HE-AAC.
+func TestDecode_MPEG2ADTS(t *testing.T) {
+	data, err := os.ReadFile("testdata/sine1k.aac")
+	if err != nil {
+		t.Skipf("sine1k.aac not available: %v", err)
+	}
+	plain := remuxMono(t, data, 1, func(w *elementBitWriter, f *monoFrame) {
+		w.copyBits(f.payload, f.sceStart, f.icsEnd)
+	})
+	mpeg2 := remuxMono(t, data, 1, func(w *elementBitWriter, f *monoFrame) {
+		w.copyBits(f.payload, f.sceStart, f.icsEnd)
+		w.writeBits(6, 3)  // ID_FIL
+		w.writeBits(2, 4)  // count
+		w.writeBits(13, 4) // EXT_SBR_DATA, no sbr_header()
+		w.writeBits(0, 12)
+	})
+	for pos := 0; pos < len(mpeg2); {
+		mpeg2[pos+1] |= 0x08 // id
+		pos += int(mpeg2[pos+3]&3)<<11 | int(mpeg2[pos+4])<<3 | int(mpeg2[pos+5])>>5
+	}
+
+	cfg := aac.NewDecoder().Config()
+	want, _ := decodeADTSFrames(t, plain, cfg)
+	got, infos := decodeADTSFrames(t, mpeg2, cfg)
+	if len(got) != len(want) {
+		t.Fatalf("decoded %d frames, want %d", len(got), len(want))
+	}
+	for i, info := range infos {
+		if info.ObjectType != aac.ObjectTypeLC || info.SampleRate != 44100 {
+			t.Errorf("frame %d: object type %v at %d Hz, want LC at 44100 Hz", i, info.ObjectType, info.SampleRate)
+		}
+		if info.SBR != aac.SBRNone || info.SBRPresence != aac.SBRNotPresent {
+			t.Errorf("frame %d: SBR %v, presence %v; want none", i, info.SBR, info.SBRPresence)
+		}
+		if !slices.Equal(got[i], want[i]) {
+			t.Errorf("frame %d differs from the stream without SBR data", i)
+		}
+	}
+
+	// Profile 3 is LTP in MPEG-4 but reserved in MPEG-2
+	header, err := aac.BuildADTSHeader(aac.ADTSConfig{
+		ObjectType:           aac.ObjectTypeLTP,
+		SFIndex:              4,
+		ChannelConfiguration: 1,
+		BufferFullness:       0x7FF,
+	}, 8)
+	if err != nil {
+		t.Fatalf("BuildADTSHeader: %v", err)
+	}
+	header[1] |= 0x08
+	if _, err := aac.NewDecoder().Init(header); !errors.Is(err, aac.ErrBitstreamValueNotAllowed) {
+		t.Errorf("Init of MPEG-2 profile 3: %v, want ErrBitstreamValueNotAllowed", err)
+	}
+	if _, err := aac.BuildADTSHeader(aac.ADTSConfig{
+		ObjectType: aac.ObjectTypeLTP,
+		SFIndex:    4,
+		MPEG2:      true,
+	}, 8); !errors.Is(err, aac.ErrUnsupportedObjectType) {
+		t.Errorf("BuildADTSHeader of MPEG-2 LTP: %v, want ErrUnsupportedObjectType", err)
+	}
+}
+
+// TestDecode_OldADTSFormat rewrites sine1k.aac with headers of the format
+// before corrigendum 14496-3:2002, which carry a 2-bit emphasis field, and
+// decodes it with Config.UseOldADTSFormat.
+func TestDecode_OldADTSFormat(t *testing.T) {
+	data, err := os.ReadFile("testdata/sine1k.aac")
+	if err != nil {
+		t.Skipf("sine1k.aac not available: %v", err)
+	}
+	var old []byte
+	var lengths []int
+	for pos := 0; pos < len(data); {
+		frameLen := int(data[pos+3]&3)<<11 | int(data[pos+4])<<3 | int(data[pos+5])>>5
+		frame := data[pos : pos+frameLen]
+		pos += frameLen
+
+		// The emphasis field follows the home bit, and the 58-bit header
+		// leaves the raw_data_block unaligned
+		w := &elementBitWriter{}
+		w.copyBits(frame, 0, 28)
+		w.writeBits(2, 2) // emphasis
+		w.copyBits(frame, 28, 30)
+		w.writeBits(uint32(frameLen+1), 13)
+		w.copyBits(frame, 43, len(frame)*8)
+		old = append(old, w.buf...)
+		lengths = append(lengths, len(w.buf))
+	}
+
+	cfg := aac.NewDecoder().Config()
+	want, _ := decodeADTSFrames(t, data, cfg)
+	cfg.UseOldADTSFormat = true
+	got, infos := decodeADTSFrames(t, old, cfg)
+	if len(got) != len(want) {
+		t.Fatalf("decoded %d frames, want %d", len(got), len(want))
+	}
+	for i := range want {
+		if int(infos[i].BytesConsumed) != lengths[i] {
+			t.Errorf("frame %d: consumed %d bytes, want %d", i, infos[i].BytesConsumed, lengths[i])
+		}
+		if !slices.Equal(got[i], want[i]) {
+			t.Errorf("frame %d differs from the current header format", i)
+		}
+	}
+
+	// Framing a file also reads the moved aac_frame_length
+	var wantWAV, gotWAV bytes.Buffer
+	if err := aac.DecodeToWAV(data, &wantWAV, aac.WAVOptions{}); err != nil {
+		t.Fatalf("DecodeToWAV: %v", err)
+	}
+	oldCfg := aac.NewDecoder().Config()
+	oldCfg.UseOldADTSFormat = true
+	if err := aac.DecodeToWAV(old, &gotWAV, aac.WAVOptions{Config: &oldCfg}); err != nil {
+		t.Fatalf("DecodeToWAV, old format: %v", err)
+	}
+	if !bytes.Equal(gotWAV.Bytes(), wantWAV.Bytes()) {
+		t.Errorf("old format WAV differs: %d bytes, want %d", gotWAV.Len(), wantWAV.Len())
+	}
+}
@@ -0,0 +1,54 @@
+// buffer_pool.go
+package aac
+
+import "sync"
+
+// Buffer pools shared by the decoders with Config.PoolBuffers set: the
+// quantized spectra of each frame, and the channel buffers of closed
+// decoders. They hold *[]T so that Put does not allocate a slice header.
+var (
+	int16Pool   sync.Pool
+	float32Pool sync.Pool
+)
+
+// getInt16 returns a zeroed slice of n samples, from int16Pool with
+// Config.PoolBuffers.
+func (d *Decoder) getInt16(n int) []int16 {
+	if d.config.PoolBuffers {
+		if p, ok := int16Pool.Get().(*[]int16); ok && cap(*p) >= n {
+			s := (*p)[:n]
+			clear(s)
+			return s
+		}
+	}
+	return make([]int16, n)
+}
+
+// putInt16 returns s to int16Pool with Config.PoolBuffers. s must not be
+// used afterwards.
+func (d *Decoder) putInt16(s []int16) {
+	if d.config.PoolBuffers && s != nil {
+		int16Pool.Put(&s)
+	}
+}
+
+// getFloat32 returns a zeroed slice of n samples, from float32Pool with
+// Config.PoolBuffers.
+func (d *Decoder) getFloat32(n int) []float32 {
+	if d.config.PoolBuffers {
+		if p, ok := float32Pool.Get().(*[]float32); ok && cap(*p) >= n {
+			s := (*p)[:n]
+			clear(s)
+			return s
+		}
+	}
+	return make([]float32, n)
+}
+
+// putFloat32 returns s to float32Pool with Config.PoolBuffers. s must not
+// be used afterwards.
+func (d *Decoder) putFloat32(s []float32) {
+	if d.config.PoolBuffers && s != nil {
+		float32Pool.Put(&s)
+	}
+}
@@ -0,0 +1,102 @@
+// buffer_pool_test.go
+package aac
+
+import (
+	"testing"
+)
+
+// poolFrames returns raw mono frames, with a coupling channel, for
+// ascCCE.
+func poolFrames(t testing.TB) [][]byte {
+	t.Helper()
+	quads := [][2][4]int16{
+		{{1, 0, 0, 0}, {0, -1, 0, 0}},
+		{{0, 0, 1, 0}, {1, 0, 0, 1}},
+		{{0, 1, 0, 0}, {0, 0, 0, -1}},
+	}
+	frames := make([][]byte, len(quads))
+	for f, q := range quads {
+		frames[f] = cceFrame(t, q, quads[(f+1)%len(quads)], true, false)
+	}
+	return frames
+}
+
+func TestDecoder_DecodeInto(t *testing.T) {
+	frames := poolFrames(t)
+	want := decodeCCEFrames(t, frames)
+
+	d := NewDecoder()
+	defer d.Close()
+	cfg := d.Config()
+	cfg.PoolBuffers = true
+	cfg.OutputFormat = OutputFormatFloat // overridden by DecodeInto
+	d.SetConfiguration(cfg)
+	if _, err := d.Init2(ascCCE); err != nil {
+		t.Fatalf("Init2: %v", err)
+	}
+
+	out := make([]int16, 1024)
+	for f, frame := range frames {
+		info, err := d.DecodeInto(frame, out)
+		if err != nil {
+			t.Fatalf("frame %d: DecodeInto: %v", f, err)
+		}
+		if int(info.Samples) > len(want[f]) {
+			t.Fatalf("frame %d: %d samples, want at most %d", f, info.Samples, len(want[f]))
+		}
+		// The first frame is muted
+		if f > 0 && int(info.Samples) != len(want[f]) {
+			t.Fatalf("frame %d: %d samples, want %d", f, info.Samples, len(want[f]))
+		}
+		for i := range int(info.Samples) {
+			if out[i] != want[f][i] {
+				t.Fatalf("frame %d sample %d = %d, want %d", f, i, out[i], want[f][i])
+			}
+		}
+	}
+	if d.Config().OutputFormat != OutputFormatFloat {
+		t.Error("DecodeInto changed the output format")
+	}
+
+	if _, err := d.DecodeInto(frames[0], make([]int16, 100)); err != ErrOutputBufferTooSmall {
+		t.Errorf("short buffer: %v, want ErrOutputBufferTooSmall", err)
+	}
+}
+
+func BenchmarkDecode(b *testing.B) {
+	benchmarkDecode(b, false)
+}
+
+func BenchmarkDecodeInto_Pooled(b *testing.B) {
+	benchmarkDecode(b, true)
+}
+
+// benchmarkDecode decodes mono frames with Decode or, with pooled, with
+// DecodeInto and Config.PoolBuffers.
+func benchmarkDecode(b *testing.B, pooled bool) {
+	frames := poolFrames(b)
+	d := NewDecoder()
+	defer d.Close()
+	cfg := d.Config()
+	cfg.PoolBuffers = pooled
+	d.SetConfiguration(cfg)
+	if _, err := d.Init2(ascCCE); err != nil {
+		b.Fatalf("Init2: %v", err)
+	}
+	out := make([]int16, 1024)
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := range b.N {
+		frame := frames[i%len(frames)]
+		var err error
+		if pooled {
+			_, err = d.DecodeInto(frame, out)
+		} else {
+			_, _, err = d.Decode(frame)
+		}
+		if err != nil {
+			b.Fatal(err)
+		}
+	}
+}
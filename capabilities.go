@@ -0,0 +1,99 @@
+// capabilities.go
+package aac
+
+// StreamCapabilities summarizes the configuration and coding tools of the
+// stream the decoder was initialized for.
+//
+// The configuration fields are valid once Init or Init2 succeeded. The tool
+// flags are only meaningful when FeaturesKnown is true, i.e. after the
+// first frame has been decoded; from then on they accumulate, so a flag is
+// set if any frame decoded so far used the tool.
+type StreamCapabilities struct {
+	// Valid after Init/Init2
+	ObjectType  ObjectType
+	SampleRate  uint32
+	Channels    uint8  // channel_configuration (0 = defined by a PCE)
+	FrameLength uint16 // samples per channel per frame
+	HeaderType  HeaderType
+
+	// FeaturesKnown is set once a frame has been decoded.
+	FeaturesKnown bool
+
+	// Valid when FeaturesKnown
+	SBR        bool // Spectral Band Replication
+	PS         bool // Parametric Stereo
+	TNS        bool // Temporal Noise Shaping
+	LTP        bool // Long Term Prediction
+	Prediction bool // MAIN profile intra-channel prediction
+}
+
+// streamFeature is a bitmask of coding tools seen in decoded frames.
+type streamFeature uint8
+
+const (
+	featureSBR streamFeature = 1 << iota
+	featurePS
+	featureTNS
+	featureLTP
+	featurePrediction
+
+	// featureDecoded marks that at least one frame was decoded since Init.
+	featureDecoded
+)
+
+// Capabilities returns a summary of the current stream. See
+// StreamCapabilities for which fields are valid before the first frame.
+func (d *Decoder) Capabilities() StreamCapabilities {
+	c := StreamCapabilities{
+		ObjectType:    ObjectType(d.objectType),
+		SampleRate:    d.SampleRate(),
+		Channels:      d.channelConfiguration,
+		FrameLength:   d.frameLength,
+		HeaderType:    d.headerType(),
+		FeaturesKnown: d.features&featureDecoded != 0,
+	}
+	c.SBR = d.features&featureSBR != 0
+	c.PS = d.features&featurePS != 0
+	c.TNS = d.features&featureTNS != 0
+	c.LTP = d.features&featureLTP != 0
+	c.Prediction = d.features&featurePrediction != 0
+	return c
+}
+
+// headerType returns the transport format detected at initialization.
+func (d *Decoder) headerType() HeaderType {
+	switch {
+	case d.adtsHeaderPresent:
+		return HeaderTypeADTS
+	case d.adifHeaderPresent:
+		return HeaderTypeADIF
+	case d.latmHeaderPresent:
+		return HeaderTypeLATM
+	default:
+		return HeaderTypeRAW
+	}
+}
+
+// noteFrameFeatures records a decoded frame and its SBR and PS status.
+func (d *Decoder) noteFrameFeatures(info *FrameInfo) {
+	d.features |= featureDecoded
+	if info.SBRPresence == SBRPresent {
+		d.features |= featureSBR
+	}
+	if info.PS != 0 {
+		d.features |= featurePS
+	}
+}
+
+// noteICSFeatures records the tools used by one individual channel stream.
+func (d *Decoder) noteICSFeatures(tns, ltp, prediction bool) {
+	if tns {
+		d.features |= featureTNS
+	}
+	if ltp {
+		d.features |= featureLTP
+	}
+	if prediction {
+		d.features |= featurePrediction
+	}
+}
@@ -0,0 +1,77 @@
+// capabilities_test.go
+package aac
+
+import "testing"
+
+func TestDecoder_Capabilities_AfterInit(t *testing.T) {
+	d := NewDecoder()
+	if _, err := d.Init(adtsEmptyFrame); err != nil {
+		t.Fatalf("Init failed: %v", err)
+	}
+
+	c := d.Capabilities()
+	if c.ObjectType != ObjectTypeLC {
+		t.Errorf("ObjectType: got %v, want LC", c.ObjectType)
+	}
+	if c.SampleRate != 44100 {
+		t.Errorf("SampleRate: got %d, want 44100", c.SampleRate)
+	}
+	if c.Channels != 2 {
+		t.Errorf("Channels: got %d, want 2", c.Channels)
+	}
+	if c.FrameLength != 1024 {
+		t.Errorf("FrameLength: got %d, want 1024", c.FrameLength)
+	}
+	if c.HeaderType != HeaderTypeADTS {
+		t.Errorf("HeaderType: got %v, want ADTS", c.HeaderType)
+	}
+	if c.FeaturesKnown {
+		t.Error("FeaturesKnown should be false before the first frame")
+	}
+}
+
+func TestDecoder_Capabilities_AfterFirstFrame(t *testing.T) {
+	d := NewDecoder()
+	if _, err := d.Init(adtsEmptyFrame); err != nil {
+		t.Fatalf("Init failed: %v", err)
+	}
+	if _, _, err := d.Decode(adtsEmptyFrame); err != nil {
+		t.Fatalf("Decode failed: %v", err)
+	}
+
+	c := d.Capabilities()
+	if !c.FeaturesKnown {
+		t.Fatal("FeaturesKnown should be true after a decoded frame")
+	}
+	if c.SBR || c.PS || c.TNS || c.LTP || c.Prediction {
+		t.Errorf("no tools expected for an empty frame, got %+v", c)
+	}
+}
+
+func TestDecoder_Capabilities_AccumulatesFeatures(t *testing.T) {
+	d := NewDecoder()
+	if _, err := d.Init2([]byte{0x12, 0x10}); err != nil {
+		t.Fatalf("Init2 failed: %v", err)
+	}
+
+	d.noteFrameFeatures(&FrameInfo{SBRPresence: SBRPresent, PS: 1})
+	d.noteICSFeatures(true, false, false)
+	d.noteFrameFeatures(&FrameInfo{})
+	d.noteICSFeatures(false, true, true)
+
+	c := d.Capabilities()
+	if c.HeaderType != HeaderTypeRAW {
+		t.Errorf("HeaderType: got %v, want RAW", c.HeaderType)
+	}
+	if !c.FeaturesKnown || !c.SBR || !c.PS || !c.TNS || !c.LTP || !c.Prediction {
+		t.Errorf("expected all tools to accumulate, got %+v", c)
+	}
+
+	// Re-initializing starts over
+	if _, err := d.Init2([]byte{0x12, 0x10}); err != nil {
+		t.Fatalf("Init2 failed: %v", err)
+	}
+	if c := d.Capabilities(); c.FeaturesKnown || c.SBR || c.TNS {
+		t.Errorf("features should reset on Init2, got %+v", c)
+	}
+}
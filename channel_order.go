@@ -0,0 +1,99 @@
+// channel_order.go
+package aac
+
+import "slices"
+
+// ChannelOrder selects the order of the output channels (Config.ChannelOrder).
+type ChannelOrder uint8
+
+const (
+	// OrderAAC outputs the channels in the order of the bitstream
+	// elements, as FAAD2 does: C, L, R, Ls, Rs, LFE for 5.1.
+	OrderAAC ChannelOrder = iota
+
+	// OrderWAV outputs the channels in the WAVE_FORMAT_EXTENSIBLE
+	// (SMPTE) order most audio APIs expect: L, R, C, LFE, Ls, Rs for 5.1
+	// and L, R, C, LFE, Lb, Rb, Ls, Rs for 7.1. Channels without a known
+	// position come last, in bitstream order.
+	OrderWAV
+)
+
+// wavChannelRank returns the rank of pos in the WAVE_FORMAT_EXTENSIBLE
+// channel mask order.
+func wavChannelRank(pos ChannelPosition) int {
+	switch pos {
+	case ChannelFrontLeft:
+		return 0
+	case ChannelFrontRight:
+		return 1
+	case ChannelFrontCenter:
+		return 2
+	case ChannelLFE:
+		return 3
+	case ChannelBackLeft:
+		return 4
+	case ChannelBackRight:
+		return 5
+	case ChannelBackCenter:
+		return 6
+	case ChannelSideLeft:
+		return 7
+	case ChannelSideRight:
+		return 8
+	default:
+		return 9
+	}
+}
+
+// wavChannelMap returns, for each output channel in WAV order, the index
+// of the channel of positions feeding it.
+func wavChannelMap(positions []ChannelPosition) []uint8 {
+	channelMap := make([]uint8, len(positions))
+	for i := range channelMap {
+		channelMap[i] = uint8(i)
+	}
+	slices.SortStableFunc(channelMap, func(a, b uint8) int {
+		return wavChannelRank(positions[a]) - wavChannelRank(positions[b])
+	})
+	return channelMap
+}
+
+// applyChannelOrder sets up Config.ChannelOrder for the frame: it records
+// in d.channelOrder which channel feeds each output channel, or nil when
+// the order is unchanged, and reorders info.ChannelPosition to match.
+func (d *Decoder) applyChannelOrder(info *FrameInfo, outputChannels uint8) {
+	d.channelOrder = nil
+	if d.config.ChannelOrder != OrderWAV || outputChannels < 2 {
+		return
+	}
+
+	positions := info.ChannelPosition[:outputChannels]
+	channelMap := wavChannelMap(positions)
+	for i, ch := range channelMap {
+		if int(ch) != i {
+			d.channelOrder = channelMap
+			break
+		}
+	}
+	if d.channelOrder == nil {
+		return
+	}
+
+	reordered := make([]ChannelPosition, len(positions))
+	for i, ch := range channelMap {
+		reordered[i] = positions[ch]
+	}
+	copy(positions, reordered)
+}
+
+// orderSources reorders the output sources by d.channelOrder.
+func (d *Decoder) orderSources(sources [][]float32) [][]float32 {
+	if len(d.channelOrder) != len(sources) {
+		return sources
+	}
+	ordered := make([][]float32, len(sources))
+	for i, ch := range d.channelOrder {
+		ordered[i] = sources[ch]
+	}
+	return ordered
+}
@@ -0,0 +1,62 @@
+// channel_order_test.go
+package aac
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestChannelOrder_WAV5_1(t *testing.T) {
+	d := newForceChannelsDecoder(t, 6, 0, ForceChannelsMix)
+	d.config.ChannelOrder = OrderWAV
+
+	var info FrameInfo
+	d.createChannelConfig(&info)
+	d.applyChannelOrder(&info, 6)
+
+	wantPositions := []ChannelPosition{
+		ChannelFrontLeft, ChannelFrontRight, ChannelFrontCenter,
+		ChannelLFE, ChannelBackLeft, ChannelBackRight,
+	}
+	if got := info.ChannelPosition[:6]; !slices.Equal(got, wantPositions) {
+		t.Errorf("positions = %v, want %v", got, wantPositions)
+	}
+
+	// Channels hold 1000*(ch+1) in C, L, R, Ls, Rs, LFE order
+	got := firstFrame(t, d, 6)
+	want := []int16{2000, 3000, 1000, 6000, 4000, 5000}
+	if !slices.Equal(got, want) {
+		t.Errorf("first sample = %v, want %v", got, want)
+	}
+}
+
+func TestChannelOrder_AACUnchanged(t *testing.T) {
+	d := newForceChannelsDecoder(t, 6, 0, ForceChannelsMix)
+
+	var info FrameInfo
+	d.createChannelConfig(&info)
+	d.applyChannelOrder(&info, 6)
+
+	if d.channelOrder != nil || info.ChannelPosition[0] != ChannelFrontCenter {
+		t.Errorf("OrderAAC reordered channels: %v", d.channelOrder)
+	}
+	if got, want := firstFrame(t, d, 6), []int16{1000, 2000, 3000, 4000, 5000, 6000}; !slices.Equal(got, want) {
+		t.Errorf("first sample = %v, want %v", got, want)
+	}
+}
+
+func TestChannelOrder_WAV71(t *testing.T) {
+	d := newForceChannelsDecoder(t, 8, 0, ForceChannelsMix)
+	d.channelConfiguration = 7
+	d.config.ChannelOrder = OrderWAV
+
+	var info FrameInfo
+	d.createChannelConfig(&info)
+	d.applyChannelOrder(&info, 8)
+
+	// 7.1 C, L, R, Ls, Rs, Lb, Rb, LFE to L, R, C, LFE, Lb, Rb, Ls, Rs
+	want := []int16{2000, 3000, 1000, 8000, 6000, 7000, 4000, 5000}
+	if got := firstFrame(t, d, 8); !slices.Equal(got, want) {
+		t.Errorf("first sample = %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,207 @@
+// conceal.go
+package aac
+
+import "errors"
+
+// ConcealMode selects how Decode conceals a frame that fails to decode
+// (Config.ConcealErrors).
+type ConcealMode uint8
+
+const (
+	// ConcealNone returns the decoding error, as FAAD2 does.
+	ConcealNone ConcealMode = iota
+
+	// ConcealSilence outputs a frame of silence in place of the bad one.
+	ConcealSilence
+
+	// ConcealRepeat repeats the output of the last good frame, faded out
+	// over the frame. Further bad frames in a row are silent.
+	ConcealRepeat
+)
+
+// concealState holds what concealment needs from the last good frame.
+type concealState struct {
+	samples any       // Copy of the last good frame's output
+	info    FrameInfo // Frame information of the last good frame
+	valid   bool      // A good frame was decoded since Init
+	run     int       // Frames concealed since the last good frame
+}
+
+// keepForConcealment records the output of a good frame, copying samples
+// into buffers reused from frame to frame.
+func (d *Decoder) keepForConcealment(samples any, info *FrameInfo) {
+	c := &d.conceal
+	c.run = 0
+	if samples == nil {
+		return
+	}
+	c.info = *info
+	c.valid = true
+	switch s := samples.(type) {
+	case []int16:
+		c.samples = keepPCM(c.samples, s)
+	case []int32:
+		c.samples = keepPCM(c.samples, s)
+	case []float32:
+		c.samples = keepPCM(c.samples, s)
+	case []float64:
+		c.samples = keepPCM(c.samples, s)
+	case [][]int16:
+		c.samples = keepPlanarPCM(c.samples, s)
+	case [][]int32:
+		c.samples = keepPlanarPCM(c.samples, s)
+	case [][]float32:
+		c.samples = keepPlanarPCM(c.samples, s)
+	case [][]float64:
+		c.samples = keepPlanarPCM(c.samples, s)
+	default:
+		c.valid = false
+	}
+}
+
+// concealFrame returns the output and frame information standing in for
+// the frame at the start of buffer, which failed to decode with err.
+// Before the first good frame it outputs no samples.
+func (d *Decoder) concealFrame(buffer []byte, err error) (any, *FrameInfo) {
+	c := &d.conceal
+	info := &FrameInfo{}
+	if c.valid {
+		*info = c.info
+		info.TrimmedSamples = 0
+		info.Elements, info.ChannelTools = nil, nil
+	}
+	info.Error = concealedError(err)
+	info.BytesConsumed = d.concealedFrameSize(buffer)
+	if !c.valid {
+		return nil, info
+	}
+
+	fade := d.config.ConcealErrors == ConcealRepeat && c.run == 0
+	c.run++
+	channels := max(int(info.Channels), 1)
+	var samples any
+	var n int
+	switch s := c.samples.(type) {
+	case []int16:
+		samples, n = concealPCM(s, channels, fade), len(s)
+	case []int32:
+		samples, n = concealPCM(s, channels, fade), len(s)
+	case []float32:
+		samples, n = concealPCM(s, channels, fade), len(s)
+	case []float64:
+		samples, n = concealPCM(s, channels, fade), len(s)
+	case [][]int16:
+		samples, n = concealPlanarPCM(s, fade), len(s)*planarLen(s)
+	case [][]int32:
+		samples, n = concealPlanarPCM(s, fade), len(s)*planarLen(s)
+	case [][]float32:
+		samples, n = concealPlanarPCM(s, fade), len(s)*planarLen(s)
+	case [][]float64:
+		samples, n = concealPlanarPCM(s, fade), len(s)*planarLen(s)
+	}
+	// Samples also counts them after a muted first frame
+	info.Samples = uint32(n)
+	d.reportMeter(samples, info)
+	return samples, info
+}
+
+// planarLen returns the samples per channel of planar output.
+func planarLen[T pcmSample](s [][]T) int {
+	if len(s) == 0 {
+		return 0
+	}
+	return len(s[0])
+}
+
+// concealedError returns the Error code reported for err. Errors of the
+// internal parsers carry no code and are reported as
+// ErrBitstreamValueNotAllowed.
+func concealedError(err error) Error {
+	var code Error
+	if errors.As(err, &code) {
+		return code
+	}
+	return ErrBitstreamValueNotAllowed
+}
+
+// concealedFrameSize returns the bytes of buffer taken by a frame that
+// failed to decode: up to the next ADTS syncword, or the declared length
+// of a LOAS frame. Raw and ADIF frames have no boundaries in the stream,
+// so the rest of buffer is taken.
+func (d *Decoder) concealedFrameSize(buffer []byte) uint32 {
+	switch {
+	case d.adtsHeaderPresent:
+		// The declared frame length, when the next frame starts there
+		if isADTSSync(buffer, 0) && len(buffer) >= adtsFixedHeaderSize {
+			end := adtsFrameLength(buffer, d.config.UseOldADTSFormat)
+			if end == len(buffer) || isADTSSync(buffer, end) {
+				return uint32(end)
+			}
+		}
+		for off := 1; off < len(buffer); off++ {
+			if isADTSSync(buffer, off) {
+				return uint32(off)
+			}
+		}
+	case d.latmHeaderPresent && d.latmMuxConfigPresent:
+		// 11 bits syncword 0x2B7, 13 bits audioMuxLengthBytes
+		if len(buffer) >= 3 && buffer[0] == 0x56 && buffer[1]&0xE0 == 0xE0 {
+			size := 3 + (int(buffer[1]&0x1F)<<8 | int(buffer[2]))
+			return uint32(min(size, len(buffer)))
+		}
+	}
+	return uint32(len(buffer))
+}
+
+// pcmSample is a sample type of the interleaved and planar output.
+type pcmSample interface {
+	~int16 | ~int32 | ~float32 | ~float64
+}
+
+// keepPCM copies src into the slice held by dst when it has the same
+// type and room enough, and into a new slice otherwise.
+func keepPCM[T pcmSample](dst any, src []T) []T {
+	buf, _ := dst.([]T)
+	return append(buf[:0], src...)
+}
+
+// keepPlanarPCM is keepPCM for planar output.
+func keepPlanarPCM[T pcmSample](dst any, src [][]T) [][]T {
+	buf, _ := dst.([][]T)
+	if len(buf) != len(src) {
+		buf = make([][]T, len(src))
+	}
+	for ch := range src {
+		buf[ch] = append(buf[ch][:0], src[ch]...)
+	}
+	return buf
+}
+
+// concealPCM returns a frame of silence shaped like the interleaved frame
+// src or, with fade, src faded out linearly over the frame.
+func concealPCM[T pcmSample](src []T, channels int, fade bool) []T {
+	out := make([]T, len(src))
+	if !fade {
+		return out
+	}
+	frames := len(src) / channels
+	for i, v := range src {
+		out[i] = T(float64(v) * fadeGain(i/channels, frames))
+	}
+	return out
+}
+
+// concealPlanarPCM is concealPCM for planar output.
+func concealPlanarPCM[T pcmSample](src [][]T, fade bool) [][]T {
+	out := make([][]T, len(src))
+	for ch, s := range src {
+		out[ch] = concealPCM(s, 1, fade)
+	}
+	return out
+}
+
+// fadeGain returns the gain of sample i of a linear fade-out over n
+// samples.
+func fadeGain(i, n int) float64 {
+	return float64(n-i) / float64(n+1)
+}
@@ -0,0 +1,155 @@
+// conceal_test.go
+package aac
+
+import (
+	"os"
+	"testing"
+)
+
+// concealStream returns the first four ADTS frames of sine1k.aac with a
+// corrupt frame, whose ics_info has ics_reserved_bit set, after the third.
+func concealStream(t *testing.T) []byte {
+	t.Helper()
+	data, err := os.ReadFile("testdata/sine1k.aac")
+	if err != nil {
+		t.Skipf("sine1k.aac not available: %v", err)
+	}
+	var frames [][]byte
+	for pos := 0; len(frames) < 4; {
+		size := int(data[pos+3]&3)<<11 | int(data[pos+4])<<3 | int(data[pos+5])>>5
+		frames = append(frames, data[pos:pos+size])
+		pos += size
+	}
+
+	// ID_SCE, element_instance_tag 0, global_gain 0, ics_reserved_bit 1
+	payload := []byte{0x00, 0x01, 0x00, 0x00}
+	header, err := BuildADTSHeader(ADTSConfig{
+		ObjectType:           ObjectTypeLC,
+		SFIndex:              4,
+		ChannelConfiguration: 1,
+		BufferFullness:       0x7FF,
+	}, len(payload))
+	if err != nil {
+		t.Fatalf("BuildADTSHeader: %v", err)
+	}
+	corrupt := append(header, payload...)
+
+	var stream []byte
+	for i, f := range frames {
+		stream = append(stream, f...)
+		if i == 2 {
+			stream = append(stream, corrupt...)
+		}
+	}
+	return stream
+}
+
+func TestDecode_ConcealErrors(t *testing.T) {
+	stream := concealStream(t)
+
+	// Without concealment the corrupt frame fails
+	d := NewDecoder()
+	if _, err := d.Init(stream); err != nil {
+		t.Fatalf("Init: %v", err)
+	}
+	pos := 0
+	for range 3 {
+		_, info, err := d.Decode(stream[pos:])
+		if err != nil {
+			t.Fatalf("Decode: %v", err)
+		}
+		pos += int(info.BytesConsumed)
+	}
+	if _, _, err := d.Decode(stream[pos:]); err == nil {
+		t.Fatal("corrupt frame decoded without error")
+	}
+
+	for _, mode := range []ConcealMode{ConcealSilence, ConcealRepeat} {
+		d := NewDecoder()
+		cfg := d.Config()
+		cfg.ConcealErrors = mode
+		d.SetConfiguration(cfg)
+		if _, err := d.Init(stream); err != nil {
+			t.Fatalf("mode %d: Init: %v", mode, err)
+		}
+
+		var out [][]int16
+		var infos []*FrameInfo
+		for pos := 0; pos < len(stream); {
+			samples, info, err := d.Decode(stream[pos:])
+			if err != nil {
+				t.Fatalf("mode %d frame %d: Decode: %v", mode, len(out), err)
+			}
+			s, _ := samples.([]int16)
+			out = append(out, s)
+			infos = append(infos, info)
+			pos += int(info.BytesConsumed)
+		}
+		if len(out) != 5 {
+			t.Fatalf("mode %d: %d frames, want 5", mode, len(out))
+		}
+
+		// Output stays continuous: every frame after the muted first one
+		// has a full frame of samples
+		for i := 1; i < len(out); i++ {
+			if int(infos[i].Samples) != 1024 || len(out[i]) != 1024 {
+				t.Errorf("mode %d frame %d: %d samples (%d reported), want 1024",
+					mode, i, len(out[i]), infos[i].Samples)
+			}
+		}
+		for i, info := range infos {
+			concealed := i == 3
+			if (info.Error != ErrNone) != concealed {
+				t.Errorf("mode %d frame %d: Error = %v", mode, i, info.Error)
+			}
+		}
+		if infos[3].Channels != 1 || infos[3].SampleRate != 44100 {
+			t.Errorf("mode %d: concealed frame has %d channels at %d Hz", mode, infos[3].Channels, infos[3].SampleRate)
+		}
+
+		prev, got := out[2], out[3]
+		for i, v := range got {
+			want := int16(0)
+			if mode == ConcealRepeat {
+				want = int16(float64(prev[i]) * fadeGain(i, len(prev)))
+			}
+			if v != want {
+				t.Fatalf("mode %d: concealed sample %d = %d, want %d", mode, i, v, want)
+			}
+		}
+		if mode == ConcealRepeat && got[0] == 0 && prev[0] != 0 {
+			t.Errorf("repeat starts silent")
+		}
+	}
+}
+
+func TestConcealFrame_RunsAndPlanar(t *testing.T) {
+	d := NewDecoder()
+	d.config.ConcealErrors = ConcealRepeat
+	d.keepForConcealment([][]float32{{1, 1, 1}, {-1, -1, -1}}, &FrameInfo{Channels: 2, Samples: 6})
+
+	// The first concealed frame fades, the next ones are silent
+	samples, info := d.concealFrame([]byte{0}, ErrInputBufferTooSmall)
+	planar := samples.([][]float32)
+	if info.Error != ErrInputBufferTooSmall || info.Samples != 6 || info.BytesConsumed != 1 {
+		t.Errorf("info: Error %v, Samples %d, BytesConsumed %d", info.Error, info.Samples, info.BytesConsumed)
+	}
+	if planar[0][0] != 0.75 || planar[1][2] != -0.25 {
+		t.Errorf("faded repeat = %v", planar)
+	}
+	samples, _ = d.concealFrame([]byte{0}, ErrInputBufferTooSmall)
+	for _, ch := range samples.([][]float32) {
+		for _, v := range ch {
+			if v != 0 {
+				t.Fatalf("second concealed frame = %v, want silence", samples)
+			}
+		}
+	}
+
+	// Before any good frame there is nothing to repeat
+	d.conceal = concealState{}
+	samples, info = d.concealFrame([]byte{0, 0}, ErrInputBufferTooSmall)
+	if samples != nil || info.Samples != 0 || info.BytesConsumed != 2 {
+		t.Errorf("no good frame: samples %v, info %+v", samples, info)
+	}
+}
@@ -2,9 +2,11 @@
 package aac
 
 import (
-	"fmt"
+	"bytes"
+	"slices"
 
 	"github.com/llehouerou/go-aac/internal/bits"
+	"github.com/llehouerou/go-aac/internal/output"
 )
 
 // Decode decodes one AAC frame and returns PCM samples.
@@ -19,11 +21,15 @@ import (
 //
 // The decoder must be initialized with Init() or Init2() before calling Decode().
 // Each call to Decode() processes exactly one frame. For ADTS streams, the ADTS
-// header is parsed automatically. For raw AAC, the caller must provide frame
-// boundaries.
+// header is parsed automatically, and for LATM streams the LOAS frame or
+// AudioMuxElement. For raw AAC, the caller must provide frame boundaries.
 //
 // Note: The first frame returns zero samples due to the overlap-add delay.
-// This matches FAAD2 behavior (decoder.c:1204-1206).
+// This matches FAAD2 behavior (decoder.c:1204-1206), which leaves the
+// first AAC-LD frame unmuted since LD encoders give a lower delay.
+//
+// With Config.ConcealErrors, a frame that fails to decode is concealed
+// and its error reported in FrameInfo.Error instead.
 //
 // Ported from: aac_frame_decode() in ~/dev/faad2/libfaad/decoder.c:848-1255
 func (d *Decoder) Decode(buffer []byte) (interface{}, *FrameInfo, error) {
@@ -39,6 +45,21 @@ func (d *Decoder) Decode(buffer []byte) (interface{}, *FrameInfo, error) {
 		return nil, nil, ErrBufferTooSmall
 	}
 
+	samples, info, err := d.decodeFrame(buffer)
+	if d.config.ConcealErrors == ConcealNone {
+		return samples, info, err
+	}
+	if err != nil {
+		samples, info = d.concealFrame(buffer, err)
+		return samples, info, nil
+	}
+	d.keepForConcealment(samples, info)
+	return samples, info, nil
+}
+
+// decodeFrame decodes the frame at the start of buffer for Decode.
+func (d *Decoder) decodeFrame(buffer []byte) (interface{}, *FrameInfo, error) {
+
 	// Initialize FrameInfo
 	info := &FrameInfo{}
 
@@ -54,19 +75,32 @@ func (d *Decoder) Decode(buffer []byte) (interface{}, *FrameInfo, error) {
 	// This replaces the boolean marker set by initFilterBank() with the actual filter bank
 	d.ensureFilterBank()
 
-	// Initialize bitstream reader
+	// Initialize bitstream reader, reusing the decoder's
 	// Ported from: decoder.c:914-917
-	r := bits.NewReader(buffer)
+	r := &d.reader
+	r.Reset(buffer)
 
 	// Parse ADTS header if present
 	// Ported from: decoder.c:965-977
 	// Note: We use parseADTSFrameHeader (local version) to avoid import cycle with syntax package.
+	var adts *adtsFrameHeader
+	var latmFrameSize uint32
 	if d.adtsHeaderPresent {
-		_, err := parseADTSFrameHeader(r, d.config.UseOldADTSFormat)
+		var err error
+		adts, err = parseADTSFrameHeader(r, d.config.UseOldADTSFormat)
 		if err != nil {
 			return nil, nil, err
 		}
+		d.collectCRC = d.config.VerifyCRC && adts.crcChecked()
 		info.HeaderType = HeaderTypeADTS
+	} else if d.latmHeaderPresent {
+		payload, size, err := d.latmPayload(buffer)
+		if err != nil {
+			return nil, nil, err
+		}
+		r.Reset(payload)
+		latmFrameSize = size
+		info.HeaderType = HeaderTypeLATM
 	} else if d.adifHeaderPresent {
 		info.HeaderType = HeaderTypeADIF
 	} else {
@@ -75,7 +109,16 @@ func (d *Decoder) Decode(buffer []byte) (interface{}, *FrameInfo, error) {
 
 	// Parse raw_data_block
 	// Ported from: decoder.c:990
+	// A frame failing crc_check is reported as such, rather than by the
+	// parsing error its corruption may cause
+	d.crcRegions = d.crcRegions[:0]
 	rdbResult, err := d.parseRawDataBlock(r)
+	if d.collectCRC {
+		d.collectCRC = false
+		if err := adts.verifyCRC(buffer, d.crcRegions); err != nil {
+			return nil, nil, err
+		}
+	}
 	if err != nil {
 		return nil, nil, err
 	}
@@ -83,11 +126,22 @@ func (d *Decoder) Decode(buffer []byte) (interface{}, *FrameInfo, error) {
 	// Update frame state
 	d.frChannels = rdbResult.numChannels
 	d.frChEle = rdbResult.numElements
+	d.hasLFE = rdbResult.hasLFE
+	d.noteImplicitSBR(rdbResult.sbrPresent)
+	d.setSampleRateInfo(info)
+	info.Elements = rdbResult.elements
+	info.ChannelTools = rdbResult.tools
 
 	// Calculate bytes consumed
 	// Ported from: decoder.c:1022-1023
 	bitsConsumed := r.GetProcessedBits()
 	info.BytesConsumed = (bitsConsumed + 7) / 8
+	if adts != nil {
+		info.BytesConsumed = adts.frameEnd(info.BytesConsumed, buffer)
+	}
+	if latmFrameSize != 0 {
+		info.BytesConsumed = latmFrameSize
+	}
 
 	// Validate channel count
 	// Ported from: decoder.c:1014-1019
@@ -96,6 +150,7 @@ func (d *Decoder) Decode(buffer []byte) (interface{}, *FrameInfo, error) {
 			return nil, nil, ErrInvalidNumChannels
 		}
 		// Zero channels means empty frame (only ID_END)
+		d.noteFrameFeatures(info)
 		d.frame++
 		info.Channels = 0
 		return nil, info, nil
@@ -110,46 +165,58 @@ func (d *Decoder) Decode(buffer []byte) (interface{}, *FrameInfo, error) {
 	// Determine output channels (downmix if configured)
 	// Ported from: decoder.c:1056-1061
 	outputChannels := rdbResult.numChannels
-	if (outputChannels == 5 || outputChannels == 6) && d.config.DownMatrix {
+	if d.config.ForceChannels != 0 {
+		// ForceChannels supersedes DownMatrix
+		if d.config.ForceChannels > maxChannels {
+			return nil, nil, ErrInvalidNumChannels
+		}
+		d.downMatrix = false
+		outputChannels = d.config.ForceChannels
+	} else if d.config.DownmixMono {
+		d.downMatrix = false
+		outputChannels = 1
+	} else if d.stereoMixable(outputChannels) && d.config.DownMatrix {
 		d.downMatrix = true
 		outputChannels = 2
 	}
 
 	// Create channel configuration
 	d.createChannelConfig(info)
+	d.applyChannelOrder(info, outputChannels)
+	d.reportTonality(info, rdbResult.numChannels)
 
 	// Populate FrameInfo
 	// Ported from: decoder.c:1075-1083
 	info.Samples = uint32(d.frameLength) * uint32(outputChannels)
 	info.Channels = outputChannels
-	info.SampleRate = getSampleRate(d.sfIndex)
 	info.ObjectType = ObjectType(d.objectType)
-	info.SBR = SBRNone
 
-	// TODO: Process each element (SCE, CPE, LFE) when parsing is implemented
-	// For each SCE: d.reconstructSCE() -> d.applyFilterBank()
-	// For each CPE: d.reconstructCPE() -> d.applyFilterBank() (x2)
-	// For each LFE: d.reconstructSCE() -> d.applyFilterBank()
+	// SCE, CPE and LFE elements are reconstructed by parseRawDataBlock,
+	// as it reaches them or, with d.config.ParallelChannels, together in
+	// reconstructDeferred. With d.config.ComputeTonality, each
+	// reconstructed channel records its spectral flatness on the way.
 
 	// Generate PCM output
 	samples := d.generatePCMOutput(outputChannels)
 
 	// Post-decode processing
+	d.noteFrameFeatures(info)
 	d.postSeekResetFlag = false
 	d.frame++
 
-	// Mute first frame (overlap-add delay)
+	// Mute first frame (overlap-add delay), except for AAC-LD
 	// Ported from: decoder.c:1204-1206
-	if d.frame <= 1 {
+	if d.frame <= 1 && ObjectType(d.objectType) != ObjectTypeLD {
 		info.Samples = 0
 	}
 
+	samples = d.trimGapless(samples, info)
+	d.reportMeter(samples, info)
 	return samples, info, nil
 }
 
 // ensureFilterBank initializes the filter bank if not already done.
-// Uses lazy initialization to avoid import cycles. The filter bank factory
-// must be registered by the filterbank package during its init().
+// The filter bank is created by filterBankFactory.
 //
 // This method checks if fb is the boolean marker (true) set by initFilterBank()
 // and replaces it with an actual filter bank instance created by the factory.
@@ -159,9 +226,9 @@ func (d *Decoder) ensureFilterBank() {
 		return
 	}
 
-	// Use the registered factory to create the filter bank
+	// Use the factory to create the filter bank
 	if filterBankFactory != nil {
-		d.fb = filterBankFactory(d.frameLength)
+		d.fb = filterBankFactory(d.filterBankLength())
 	}
 }
 
@@ -174,11 +241,118 @@ type adtsFrameHeader struct {
 	Profile              uint8 // 2 bits: object type - 1
 	SFIndex              uint8 // 4 bits: sample frequency index
 	ChannelConfiguration uint8 // 3 bits: channel config
+	ID                   uint8 // 1 bit: 0=MPEG-4, 1=MPEG-2
+	PrivateBit           bool
+	Original             bool
+	Home                 bool
+	Emphasis             uint8 // 2 bits: old format MPEG-4 headers only
 	// Variable header
-	FrameLength    uint16 // 13 bits: total frame bytes including header
-	BufferFullness uint16 // 11 bits: buffer fullness
-	NumBlocks      uint8  // 2 bits: number of raw_data_block - 1
-	CRCPresent     bool   // true if CRC is present
+	CopyrightIDBit   bool
+	CopyrightIDStart bool
+	FrameLength      uint16 // 13 bits: total frame bytes including header
+	BufferFullness   uint16 // 11 bits: buffer fullness
+	NumBlocks        uint8  // 2 bits: number of raw_data_block - 1
+	CRCPresent       bool   // true if CRC is present
+	CRCCheck         uint16 // crc_check, if CRCPresent
+
+	syncOffset uint32 // Bytes skipped before the syncword
+	headerBits uint32 // Header bits covered by the CRC, without crc_check
+}
+
+// frameEnd returns the number of buffer bytes the frame spans, given the
+// bytes the raw_data_block parse consumed. FAAD2 reports only the parsed
+// bytes, which leaves a caller advancing by BytesConsumed desynchronized
+// when FrameLength declares trailing padding that no fill element
+// accounts for. The declared length wins when it extends past the parse,
+// clamped to the buffer for truncated input.
+//
+// Spliced or badly muxed streams may place the next syncword a few bytes
+// before or after the declared end. When buffer holds a syncword within
+// adtsResyncWindow bytes of it, the frame ends there instead, so that
+// advancing by BytesConsumed lands on the next frame.
+func (h *adtsFrameHeader) frameEnd(parsed uint32, buffer []byte) uint32 {
+	if h.FrameLength < adtsFixedHeaderSize {
+		// Invalid frame_length, trust the parse
+		return parsed
+	}
+	bufferLen := uint32(len(buffer))
+	end := h.syncOffset + uint32(h.FrameLength)
+	if next := findADTSSync(buffer, int(h.syncOffset), int(end)); next >= 0 {
+		end = uint32(next)
+	}
+	if end > bufferLen {
+		end = bufferLen
+	}
+	return max(parsed, end)
+}
+
+// adtsFrameLength returns the aac_frame_length of the ADTS header at the
+// start of hdr, which holds at least adtsFixedHeaderSize bytes. In the old
+// header format, an MPEG-4 header carries the 2-bit emphasis field ahead
+// of the variable header, which moves the field 2 bits later.
+func adtsFrameLength(hdr []byte, oldFormat bool) int {
+	if oldFormat && hdr[1]&0x08 == 0 {
+		return int(hdr[4])<<5 | int(hdr[5]>>3)
+	}
+	return int(hdr[3]&0x03)<<11 | int(hdr[4])<<3 | int(hdr[5]>>5)
+}
+
+// adtsResyncWindow is how many bytes before or after a frame's declared
+// end the next syncword is searched for.
+const adtsResyncWindow = 32
+
+// isADTSSync reports whether buf holds a syncword followed by layer 0 at
+// offset off, with a plausible aac_frame_length when the header is
+// complete.
+func isADTSSync(buf []byte, off int) bool {
+	if off < 0 || off+1 >= len(buf) || buf[off] != 0xFF || buf[off+1]&0xF6 != 0xF0 {
+		return false
+	}
+	if off+adtsFixedHeaderSize > len(buf) {
+		return true
+	}
+	frameLength := int(buf[off+3]&0x03)<<11 | int(buf[off+4])<<3 | int(buf[off+5]>>5)
+	return frameLength >= adtsFixedHeaderSize
+}
+
+// findADTSSync returns the offset in buf of the syncword closest to
+// expected, the declared end of the frame starting at start, searching
+// adtsResyncWindow bytes either side. An exact match is preferred. The
+// search never reaches back into the frame's own header, and is skipped
+// when buf ends at expected or continues with an ID3v1 trailer, as the
+// frame is then the last one. It returns -1 if no syncword is found.
+func findADTSSync(buf []byte, start, expected int) int {
+	if expected >= len(buf) || bytes.HasPrefix(buf[expected:], []byte("TAG")) {
+		return -1
+	}
+	lowest := start + adtsFixedHeaderSize
+	for d := 0; d <= adtsResyncWindow; d++ {
+		if off := expected + d; isADTSSync(buf, off) {
+			return off
+		}
+		if off := expected - d; d > 0 && off >= lowest && isADTSSync(buf, off) {
+			return off
+		}
+	}
+	return -1
+}
+
+// adtsConfig returns the configuration that rebuilds this header with
+// BuildADTSHeader, preserving the copyright and originality flags.
+func (h *adtsFrameHeader) adtsConfig() ADTSConfig {
+	return ADTSConfig{
+		ObjectType:           ObjectType(h.Profile + 1),
+		SFIndex:              h.SFIndex,
+		ChannelConfiguration: h.ChannelConfiguration,
+		MPEG2:                h.ID == 1,
+		PrivateBit:           h.PrivateBit,
+		Original:             h.Original,
+		Home:                 h.Home,
+		CopyrightIDBit:       h.CopyrightIDBit,
+		CopyrightIDStart:     h.CopyrightIDStart,
+		BufferFullness:       h.BufferFullness,
+		NumRawDataBlocks:     h.NumBlocks,
+	}
 }
 
 // parseADTSFrameHeader parses a complete ADTS frame header.
@@ -201,38 +375,51 @@ func parseADTSFrameHeader(r *bits.Reader, oldFormat bool) (*adtsFrameHeader, err
 			protectionAbsent := r.Get1Bit() == 1
 			profile := uint8(r.GetBits(2))
 			sfIndex := uint8(r.GetBits(4))
-			r.FlushBits(1) // private_bit
+			privateBit := r.Get1Bit() == 1
 			chanConfig := uint8(r.GetBits(3))
-			r.FlushBits(1) // original
-			r.FlushBits(1) // home
+			original := r.Get1Bit() == 1
+			home := r.Get1Bit() == 1
 
 			// Old ADTS format (removed in corrigendum 14496-3:2002)
+			var emphasis uint8
 			if oldFormat && id == 0 {
-				r.FlushBits(2) // emphasis
+				emphasis = uint8(r.GetBits(2))
 			}
 
 			// Parse variable header (28 bits)
 			// Ported from: adts_variable_header() in ~/dev/faad2/libfaad/syntax.c:2517-2528
-			r.FlushBits(1) // copyright_id_bit
-			r.FlushBits(1) // copyright_id_start
+			copyrightIDBit := r.Get1Bit() == 1
+			copyrightIDStart := r.Get1Bit() == 1
 			frameLength := uint16(r.GetBits(13))
 			bufferFullness := uint16(r.GetBits(11))
 			numBlocks := uint8(r.GetBits(2))
 
 			// Parse error check (CRC if present)
 			// Ported from: adts_error_check() in ~/dev/faad2/libfaad/syntax.c:2532-2538
+			headerBits := r.GetProcessedBits() - uint32(i)*8
+			var crcCheck uint16
 			if !protectionAbsent {
-				r.FlushBits(16) // crc_check
+				crcCheck = uint16(r.GetBits(16))
 			}
 
 			return &adtsFrameHeader{
 				Profile:              profile,
 				SFIndex:              sfIndex,
 				ChannelConfiguration: chanConfig,
+				ID:                   id,
+				PrivateBit:           privateBit,
+				Original:             original,
+				Home:                 home,
+				Emphasis:             emphasis,
+				CopyrightIDBit:       copyrightIDBit,
+				CopyrightIDStart:     copyrightIDStart,
 				FrameLength:          frameLength,
 				BufferFullness:       bufferFullness,
 				NumBlocks:            numBlocks,
 				CRCPresent:           !protectionAbsent,
+				CRCCheck:             crcCheck,
+				syncOffset:           uint32(i),
+				headerBits:           headerBits,
 			}, nil
 		}
 		r.FlushBits(8)
@@ -267,6 +454,16 @@ type rawDataBlockResult struct {
 	numElements  uint8     // Number of elements parsed (fr_ch_ele)
 	firstElement elementID // First syntax element type (first_syn_ele)
 	hasLFE       bool      // True if LFE element present (has_lfe)
+	sbrPresent   bool      // True if a fill element carried SBR data
+
+	// Per-element and per-channel statistics, kept when
+	// Config.FrameStats is set
+	elements []ElementStats
+	tools    []ChannelTools
+
+	// Where the second channel of the last CPE started, which bounds its
+	// ADTS CRC regions
+	secondChannelStart uint32
 }
 
 // parseRawDataBlock parses a raw_data_block() from the bitstream.
@@ -274,8 +471,13 @@ type rawDataBlockResult struct {
 // Local version to avoid import cycles with the syntax package.
 //
 // The function reads syntax elements in a loop until ID_END (0x7) is
-// encountered. Currently, only ID_END is handled; other element types
-// will be added as the decoder implementation progresses.
+// encountered. Currently, SCE, CPE, LFE, CCE, PCE, FIL and END are handled;
+// other element types will be added as the decoder implementation
+// progresses. Elements are reconstructed as they are parsed, so a coupling
+// channel element only applies to the target elements that follow it in
+// the raw_data_block.
+// Error resilient object types have no element IDs: their elements follow
+// the channel configuration, see parseERRawDataBlock.
 //
 // Ported from: raw_data_block() in ~/dev/faad2/libfaad/syntax.c:449-648
 func (d *Decoder) parseRawDataBlock(r *bits.Reader) (*rawDataBlockResult, error) {
@@ -283,11 +485,42 @@ func (d *Decoder) parseRawDataBlock(r *bits.Reader) (*rawDataBlockResult, error)
 		firstElement: invalidElementID,
 	}
 
+	// Elements left deferred by an error are dropped
+	defer d.discardDeferred()
+
+	if d.objectType >= erObjectStart {
+		if err := d.parseERRawDataBlock(r, result); err != nil {
+			return nil, err
+		}
+		if err := d.reconstructDeferred(); err != nil {
+			return nil, err
+		}
+		r.ByteAlign()
+		return result, nil
+	}
+
+	// Coupling channels apply within their raw_data_block
+	d.independentCCEs = d.independentCCEs[:0]
+	if c, ok := d.elements.(couplingDecoder); ok {
+		c.ClearCouplings()
+	}
+
 	// Main parsing loop
 	// Ported from: syntax.c:465-544
 	for {
+		start := r.GetProcessedBits()
+
 		// Read element ID (3 bits)
 		idSynEle := elementID(r.GetBits(lenSEID))
+		d.openCRCElement(idSynEle, start)
+
+		// Elements other than SCE, CPE and LFE depend on the channels
+		// before them being reconstructed, or change how they are
+		if idSynEle != idSCE && idSynEle != idCPE && idSynEle != idLFE {
+			if err := d.reconstructDeferred(); err != nil {
+				return nil, err
+			}
+		}
 
 		if idSynEle == idEND {
 			break
@@ -302,63 +535,31 @@ func (d *Decoder) parseRawDataBlock(r *bits.Reader) (*rawDataBlockResult, error)
 		switch idSynEle {
 		case idSCE:
 			// Single Channel Element
-			// Ported from: single_lfe_channel_element() in ~/dev/faad2/libfaad/syntax.c:652-696
-			//
-			// SCE contains:
-			// - element_instance_tag (4 bits)
-			// - individual_channel_stream() which includes:
-			//   - ics_info()
-			//   - section_data()
-			//   - scale_factor_data()
-			//   - pulse_data() (optional)
-			//   - tns_data() (optional)
-			//   - gain_control_data() (optional, SSR only)
-			//   - spectral_data()
-			//
-			// After parsing, reconstruct_single_channel() is called.
-
-			// Increment channel count (SCE = 1 channel)
-			result.numChannels++
-
-			// Skip element_instance_tag for now (4 bits)
-			// TODO: Parse full SCE with individual_channel_stream
-			_ = r.GetBits(4) // element_instance_tag
-
-			// For now, return error - full ICS parsing not yet implemented
-			// This requires huffman decoding and spectral data parsing
-			return nil, ErrMaxBitstreamElements
+			// Ported from: decode_sce_lfe() in ~/dev/faad2/libfaad/syntax.c:351-390
+			if err := d.decodeSCE(r, result, false); err != nil {
+				return nil, err
+			}
 
 		case idCPE:
 			// Channel Pair Element (stereo)
-			// Ported from: channel_pair_element() in ~/dev/faad2/libfaad/syntax.c:698-796
-			//
-			// CPE contains:
-			// - element_instance_tag (4 bits)
-			// - common_window flag (1 bit)
-			// - if common_window: ics_info() and ms_mask
-			// - individual_channel_stream() for each channel
-			// - spectral_data() for each channel
-			//
-			// After parsing, reconstruct_channel_pair() is called.
-
-			// Increment channel count (CPE = 2 channels)
-			result.numChannels += 2
-
-			// Skip element_instance_tag for now (4 bits)
-			// TODO: Parse full CPE with individual_channel_stream for both channels
-			_ = r.GetBits(4) // element_instance_tag
-
-			// For now, return error - full ICS parsing not yet implemented
-			return nil, fmt.Errorf("CPE parsing not yet implemented")
+			// Ported from: decode_cpe() in ~/dev/faad2/libfaad/syntax.c:392-447
+			if err := d.decodeCPE(r, result); err != nil {
+				return nil, err
+			}
 
 		case idLFE:
-			// TODO: Parse LFE Channel Element
-			result.hasLFE = true
-			return nil, ErrMaxBitstreamElements
+			// LFE Channel Element, parsed like an SCE
+			// Ported from: decode_sce_lfe() in ~/dev/faad2/libfaad/syntax.c:351-390
+			if err := d.decodeSCE(r, result, true); err != nil {
+				return nil, err
+			}
 
 		case idCCE:
-			// TODO: Parse Coupling Channel Element
-			return nil, ErrChannelCouplingNotImpl
+			// Coupling Channel Element
+			// Ported from: coupling_channel_element() in ~/dev/faad2/libfaad/syntax.c:987-1076
+			if err := d.decodeCCE(r); err != nil {
+				return nil, err
+			}
 
 		case idDSE:
 			// TODO: Parse Data Stream Element
@@ -369,16 +570,32 @@ func (d *Decoder) parseRawDataBlock(r *bits.Reader) (*rawDataBlockResult, error)
 			if result.numElements != 1 {
 				return nil, ErrPCENotFirst
 			}
-			// TODO: Parse Program Config Element
-			return nil, ErrProgramConfigElement
+			pce, err := parseProgramConfig(r)
+			if err != nil {
+				return nil, err
+			}
+			if err := d.applyFramePCE(pce); err != nil {
+				return nil, err
+			}
 
 		case idFIL:
-			// TODO: Parse Fill Element
-			return nil, ErrMaxBitstreamElements
+			if d.drc == nil {
+				d.drc = newDRCInfo()
+			}
+			// MPEG-2 AAC has no SBR, so SBR payloads are skipped
+			sbr := d.sbrDecoder
+			if d.mpeg2 {
+				sbr = nil
+			}
+			if parseFillElement(r, d.drc, sbr) {
+				result.sbrPresent = true
+			}
 
 		default:
 			return nil, ErrMaxBitstreamElements
 		}
+		d.noteElement(result, idSynEle, start, r)
+		d.closeCRCElement(idSynEle, r.GetProcessedBits(), result.secondChannelStart)
 	}
 
 	// Byte align after parsing
@@ -388,12 +605,115 @@ func (d *Decoder) parseRawDataBlock(r *bits.Reader) (*rawDataBlockResult, error)
 	return result, nil
 }
 
+// erElementOrder lists, per channelConfiguration, the elements of an ER
+// raw_data_block, which carries no element IDs.
+// Ported from: raw_data_block() Table 262 in ~/dev/faad2/libfaad/syntax.c:546-640
+var erElementOrder = [8][]elementID{
+	1: {idSCE},
+	2: {idCPE},
+	3: {idSCE, idCPE},
+	4: {idSCE, idCPE, idSCE},
+	5: {idSCE, idCPE, idCPE},
+	6: {idSCE, idCPE, idCPE, idLFE},
+	7: {idSCE, idCPE, idCPE, idCPE, idLFE},
+}
+
+// parseERRawDataBlock parses the elements of an error resilient
+// raw_data_block, in the fixed order of the channel configuration.
+// FAAD2 leaves the trailing extension payloads unparsed and so does this.
+//
+// Ported from: raw_data_block() ERROR_RESIL section in ~/dev/faad2/libfaad/syntax.c:546-647
+func (d *Decoder) parseERRawDataBlock(r *bits.Reader, result *rawDataBlockResult) error {
+	if int(d.channelConfiguration) >= len(erElementOrder) || erElementOrder[d.channelConfiguration] == nil {
+		return ErrChannelConfigNotAllowed
+	}
+	for _, id := range erElementOrder[d.channelConfiguration] {
+		result.numElements++
+		if result.firstElement == invalidElementID {
+			result.firstElement = id
+		}
+
+		start := r.GetProcessedBits()
+		var err error
+		switch id {
+		case idSCE:
+			err = d.decodeSCE(r, result, false)
+		case idCPE:
+			err = d.decodeCPE(r, result)
+		case idLFE:
+			err = d.decodeSCE(r, result, true)
+		}
+		if err != nil {
+			return err
+		}
+		d.noteElement(result, id, start, r)
+	}
+	return nil
+}
+
+// decodeSCE parses and reconstructs a single channel element, or an LFE
+// element when lfe is set, on the next free channel of result.
+//
+// Ported from: decode_sce_lfe() in ~/dev/faad2/libfaad/syntax.c:351-390
+func (d *Decoder) decodeSCE(r *bits.Reader, result *rawDataBlockResult, lfe bool) error {
+	channel := result.numChannels
+	if channel >= maxChannels {
+		return ErrInvalidNumChannels
+	}
+	if channel >= d.channelLimit() {
+		return ErrChannelLimitExceeded
+	}
+	sce, err := d.parseSCE(r, channel, lfe)
+	if err != nil {
+		return err
+	}
+	result.numChannels++
+	if lfe {
+		result.hasLFE = true
+	}
+	d.noteChannelTools(result, sce.element, 0)
+	if d.deferReconstruction() {
+		d.deferred = append(d.deferred, deferredElement{sce: sce})
+		return nil
+	}
+	defer d.putInt16(sce.SpecData)
+	return d.reconstructSCE(sce, channel)
+}
+
+// decodeCPE parses and reconstructs a channel pair element on the next
+// two free channels of result.
+//
+// Ported from: decode_cpe() in ~/dev/faad2/libfaad/syntax.c:392-447
+func (d *Decoder) decodeCPE(r *bits.Reader, result *rawDataBlockResult) error {
+	channel := result.numChannels
+	if channel+1 >= maxChannels {
+		return ErrInvalidNumChannels
+	}
+	if channel+2 > d.channelLimit() {
+		return ErrChannelLimitExceeded
+	}
+	cpe, err := d.parseCPE(r, channel)
+	if err != nil {
+		return err
+	}
+	if l, ok := d.elements.(pairLocator); ok {
+		result.secondChannelStart = l.SecondChannelStart(cpe.element)
+	}
+	result.numChannels += 2
+	d.noteChannelTools(result, cpe.element, 0)
+	d.noteChannelTools(result, cpe.element, 1)
+	if d.deferReconstruction() {
+		d.deferred = append(d.deferred, deferredElement{cpe: cpe})
+		return nil
+	}
+	defer d.putInt16(cpe.SpecData1)
+	defer d.putInt16(cpe.SpecData2)
+	return d.reconstructCPE(cpe, channel)
+}
+
 // sceParseResult holds the parsed data from a Single Channel Element.
-// This structure will be populated when full SCE parsing is implemented.
 //
 // Ported from: single_lfe_channel_element() local variables in ~/dev/faad2/libfaad/syntax.c:652-666
-//
-//nolint:unused // Infrastructure for future SCE decoding
 type sceParseResult struct {
 	// ElementInstanceTag is the element instance tag (4 bits)
 	ElementInstanceTag uint8
@@ -409,12 +729,131 @@ type sceParseResult struct {
 
 	// SpecData holds the quantized spectral coefficients (1024 values)
 	SpecData []int16
+
+	// LFE is set for an LFE element, which coupling channels do not
+	// target
+	LFE bool
+
+	// element is the parsed element handed back to the element decoder
+	// for reconstruction (*syntax.Element)
+	element any
+}
+
+// channelElementDecoder is the element decoder as used for
+// single channel and channel pair elements. The actual type is
+// *spectrum.ElementDecoder.
+type channelElementDecoder interface {
+	ParseSCE(r *bits.Reader, channel uint8, quant []int16) (element any, tag uint8, err error)
+	ParseLFE(r *bits.Reader, channel uint8, quant []int16) (element any, tag uint8, err error)
+	ParseCPE(r *bits.Reader, channel uint8, quant1, quant2 []int16) (element any, tag uint8, err error)
+	Window(element any, index int) (windowSequence, windowShape uint8)
+	Stereo(element any) (commonWindow bool, msMaskPresent uint8)
+	ReconstructSCE(element any, quant []int16, spec []float32, windowShapePrev uint8) error
+	ReconstructCPE(element any, quant1, quant2 []int16, spec1, spec2 []float32, windowShapePrev1, windowShapePrev2 uint8) error
+}
+
+// pairLocator is implemented by element decoders that report where the
+// second channel of a parsed channel pair element starts, which bounds
+// its ADTS CRC regions.
+type pairLocator interface {
+	SecondChannelStart(element any) uint32
+}
+
+// resilienceSetter is implemented by element decoders that parse the
+// error resilience tools of ER object types.
+type resilienceSetter interface {
+	SetResilience(sectionData, scalefactorData, spectralData bool)
+}
+
+// elementDecoder returns the element decoder for the current stream,
+// creating it with elementDecoderFactory on first use. It returns nil
+// when RegisterElementDecoderFactory removed the factory.
+func (d *Decoder) elementDecoder() channelElementDecoder {
+	if d.elements == nil {
+		d.elements = d.newElementDecoder()
+	}
+	dec, _ := d.elements.(channelElementDecoder)
+	return dec
+}
+
+// newElementDecoder creates an element decoder for the current stream
+// with elementDecoderFactory, or returns nil when the factory was removed.
+func (d *Decoder) newElementDecoder() any {
+	if elementDecoderFactory == nil {
+		return nil
+	}
+	e := elementDecoderFactory(d.sfIndex, d.frameLength, ObjectType(d.objectType), &d.config)
+	if r, ok := e.(resilienceSetter); ok && d.objectType >= erObjectStart {
+		r.SetResilience(d.aacSectionDataResilienceFlag, d.aacScalefactorDataResilienceFlag,
+			d.aacSpectralDataResilienceFlag)
+	}
+	return e
+}
+
+// parseSCE parses a single_lfe_channel_element() for the given channel,
+// as an LFE element when lfe is set.
+//
+// Ported from: single_lfe_channel_element() in ~/dev/faad2/libfaad/syntax.c:652-696
+func (d *Decoder) parseSCE(r *bits.Reader, channel uint8, lfe bool) (*sceParseResult, error) {
+	dec := d.elementDecoder()
+	if dec == nil {
+		// Element decoding needs an element decoder factory
+		return nil, ErrMaxBitstreamElements
+	}
+
+	sce := &sceParseResult{
+		Channel:  channel,
+		SpecData: d.getInt16(int(d.frameLength)),
+		LFE:      lfe,
+	}
+	parse := dec.ParseSCE
+	if lfe {
+		parse = dec.ParseLFE
+	}
+	var err error
+	sce.element, sce.ElementInstanceTag, err = parse(r, channel, sce.SpecData)
+	if err != nil {
+		return nil, err
+	}
+	if err := d.checkStrict(sce.element); err != nil {
+		return nil, err
+	}
+	sce.WindowSequence, sce.WindowShape = dec.Window(sce.element, 0)
+	return sce, nil
+}
+
+// parseCPE parses a channel_pair_element() whose channels start at
+// channel.
+//
+// Ported from: channel_pair_element() in ~/dev/faad2/libfaad/syntax.c:698-826
+func (d *Decoder) parseCPE(r *bits.Reader, channel uint8) (*cpeParseResult, error) {
+	dec := d.elementDecoder()
+	if dec == nil {
+		return nil, ErrMaxBitstreamElements
+	}
+
+	cpe := &cpeParseResult{
+		Channel1:  channel,
+		Channel2:  channel + 1,
+		SpecData1: d.getInt16(int(d.frameLength)),
+		SpecData2: d.getInt16(int(d.frameLength)),
+	}
+	var err error
+	cpe.element, cpe.ElementInstanceTag, err = dec.ParseCPE(r, channel, cpe.SpecData1, cpe.SpecData2)
+	if err != nil {
+		return nil, err
+	}
+	if err := d.checkStrict(cpe.element); err != nil {
+		return nil, err
+	}
+	cpe.WindowSequence1, cpe.WindowShape1 = dec.Window(cpe.element, 0)
+	cpe.WindowSequence2, cpe.WindowShape2 = dec.Window(cpe.element, 1)
+	cpe.CommonWindow, cpe.MSMaskPresent = dec.Stereo(cpe.element)
+	return cpe, nil
 }
 
 // cpeParseResult holds parsed data from a Channel Pair Element.
 // Ported from: channel_pair_element() in ~/dev/faad2/libfaad/syntax.c:698-796
-//
-//nolint:unused // Infrastructure for future CPE decoding
 type cpeParseResult struct {
 	ElementInstanceTag uint8   // element_instance_tag (4 bits)
 	CommonWindow       bool    // common_window flag
@@ -427,10 +866,11 @@ type cpeParseResult struct {
 	MSMaskPresent      uint8   // ms_mask_present (0=off, 1=some, 2=all)
 	SpecData1          []int16 // quantized spectral coefficients channel 1
 	SpecData2          []int16 // quantized spectral coefficients channel 2
+
+	element any // parsed element for the element decoder (*syntax.Element)
 }
 
 // reconstructSCE performs spectral reconstruction for a single channel element.
-// This method will be called after SCE parsing is implemented.
 //
 // The reconstruction pipeline includes:
 // 1. Inverse quantization (|x|^(4/3))
@@ -441,13 +881,14 @@ type cpeParseResult struct {
 // 6. TNS decode (temporal noise shaping)
 // 7. Filter bank (IMDCT)
 //
+// Steps 1-6 run in spectrum.ReconstructSingleChannel through the element
+// decoder, step 7 in applyFilterBank.
+//
 // Parameters:
 //   - sce: Parsed SCE data including spectral coefficients
 //   - channel: Channel index for output buffer
 //
 // Ported from: reconstruct_single_channel() in ~/dev/faad2/libfaad/specrec.c:905-1129
-//
-//nolint:unused // Infrastructure for future SCE decoding
 func (d *Decoder) reconstructSCE(sce *sceParseResult, channel uint8) error {
 	// Verify channel is valid
 	// Ported from: specrec.c:960-962
@@ -455,6 +896,12 @@ func (d *Decoder) reconstructSCE(sce *sceParseResult, channel uint8) error {
 		return ErrInvalidNumChannels
 	}
 
+	// Allocate the channel on first use
+	// Ported from: allocate_single_channel() in ~/dev/faad2/libfaad/specrec.c:700-760
+	if err := d.allocateChannelBuffers(channel + 1); err != nil {
+		return err
+	}
+
 	// Verify buffer is allocated
 	// Ported from: specrec.c:961-966 (sanity check for CVE-2018-20199, CVE-2018-20360)
 	if d.timeOut[channel] == nil {
@@ -464,14 +911,40 @@ func (d *Decoder) reconstructSCE(sce *sceParseResult, channel uint8) error {
 		return ErrArrayIndexOutOfRange
 	}
 
-	// TODO: Call spectrum.ReconstructSingleChannel when syntax parsing is complete.
-	// The import cycle between aac and spectrum packages needs to be resolved first.
-	// For now, just update window shape state for the frame.
-	//
-	// The full pipeline will be:
-	// 1. spectrum.ReconstructSingleChannel(quantData, specData, cfg)
-	// 2. filterbank.IFilterBank(..., specData, timeOut, fbIntermed, ...)
-	// 3. LTP state update (if LTP profile)
+	dec := d.elementDecoder()
+	if dec == nil {
+		return ErrMaxBitstreamElements
+	}
+	if len(d.specBuf) != 2*int(d.frameLength) {
+		d.specBuf = make([]float32, 2*int(d.frameLength))
+	}
+	spec := d.specBuf[:d.frameLength]
+	if err := dec.ReconstructSCE(sce.element, sce.SpecData, spec, d.windowShapePrev[channel]); err != nil {
+		return err
+	}
+	return d.finishSCE(sce, channel, spec)
+}
+
+// finishSCE takes the reconstructed spectrum of a single channel element
+// through DRC and the filter bank, and updates the channel's state for the
+// next frame.
+func (d *Decoder) finishSCE(sce *sceParseResult, channel uint8, spec []float32) error {
+	d.measureTonality(sce.element, 0, channel, spec)
+
+	// Ported from: specrec.c:1022-1030
+	d.applyDRC(spec, channel)
+
+	// Ported from: specrec.c:1040-1050
+	if err := d.inverseFilterBank(spec, channel, sce.WindowSequence, sce.WindowShape); err != nil {
+		return err
+	}
+
+	// Ported from: lt_update_state() call in reconstruct_single_channel(), specrec.c
+	d.updateLTPState(channel)
+
+	if !sce.LFE {
+		d.applyIndependentCouplings(false, sce.ElementInstanceTag, 0, channel)
+	}
 
 	// Save window shape for next frame
 	// Ported from: specrec.c:1055
@@ -481,7 +954,6 @@ func (d *Decoder) reconstructSCE(sce *sceParseResult, channel uint8) error {
 }
 
 // reconstructCPE performs spectral reconstruction for a channel pair element.
-// This method will be called after CPE parsing is implemented.
 //
 // The reconstruction pipeline is:
 // 1. Dequantize spectral coefficients (apply_scalefactors + quant_to_spec)
@@ -491,15 +963,22 @@ func (d *Decoder) reconstructSCE(sce *sceParseResult, channel uint8) error {
 // 5. Apply TNS (Temporal Noise Shaping) if enabled
 // 6. Apply filterbank (IMDCT + windowing + overlap-add)
 //
-// Ported from: reconstruct_channel_pair() in ~/dev/faad2/libfaad/specrec.c:1131-1323
+// Steps 1-5 run in spectrum.ReconstructChannelPair through the element
+// decoder.
 //
-//nolint:unused // Infrastructure for future CPE decoding
+// Ported from: reconstruct_channel_pair() in ~/dev/faad2/libfaad/specrec.c:1131-1323
 func (d *Decoder) reconstructCPE(cpe *cpeParseResult, channelBase uint8) error {
 	// Verify both channels are valid
 	if channelBase+1 >= maxChannels {
 		return ErrInvalidNumChannels
 	}
 
+	// Allocate the pair on first use
+	// Ported from: allocate_channel_pair() in ~/dev/faad2/libfaad/specrec.c:762-850
+	if err := d.allocateChannelBuffers(channelBase + 2); err != nil {
+		return err
+	}
+
 	// Verify buffers are allocated for both channels
 	// Security: Matches FAAD2 checks for CVE-2018-20199, CVE-2018-20360
 	for ch := uint8(0); ch < 2; ch++ {
@@ -512,10 +991,45 @@ func (d *Decoder) reconstructCPE(cpe *cpeParseResult, channelBase uint8) error {
 		}
 	}
 
-	// TODO: When spectrum package is connected (via factory pattern like filterbank):
-	// 1. spectrum.ReconstructChannelPair(specData1, specData2, ...)
-	// 2. filterbank.IFilterBank() for each channel
-	// 3. Update LTP state if Main profile
+	dec := d.elementDecoder()
+	if dec == nil {
+		return ErrMaxBitstreamElements
+	}
+	if len(d.specBuf) != 2*int(d.frameLength) {
+		d.specBuf = make([]float32, 2*int(d.frameLength))
+	}
+	spec1, spec2 := d.specBuf[:d.frameLength], d.specBuf[d.frameLength:]
+	if err := dec.ReconstructCPE(cpe.element, cpe.SpecData1, cpe.SpecData2, spec1, spec2,
+		d.windowShapePrev[channelBase], d.windowShapePrev[channelBase+1]); err != nil {
+		return err
+	}
+	return d.finishCPE(cpe, channelBase, spec1, spec2)
+}
+
+// finishCPE takes the reconstructed spectra of a channel pair element
+// through DRC and the filter bank, and updates the channels' state for the
+// next frame.
+func (d *Decoder) finishCPE(cpe *cpeParseResult, channelBase uint8, spec1, spec2 []float32) error {
+	d.measureTonality(cpe.element, 0, channelBase, spec1)
+	d.measureTonality(cpe.element, 1, channelBase+1, spec2)
+
+	d.applyDRC(spec1, channelBase)
+	d.applyDRC(spec2, channelBase+1)
+
+	// Ported from: specrec.c:1290-1300
+	if err := d.inverseFilterBank(spec1, channelBase, cpe.WindowSequence1, cpe.WindowShape1); err != nil {
+		return err
+	}
+	if err := d.inverseFilterBank(spec2, channelBase+1, cpe.WindowSequence2, cpe.WindowShape2); err != nil {
+		return err
+	}
+
+	// Ported from: lt_update_state() calls in reconstruct_channel_pair(), specrec.c
+	d.updateLTPState(channelBase)
+	d.updateLTPState(channelBase + 1)
+
+	d.applyIndependentCouplings(true, cpe.ElementInstanceTag, 0, channelBase)
+	d.applyIndependentCouplings(true, cpe.ElementInstanceTag, 1, channelBase+1)
 
 	// Update window shapes for next frame
 	// Ported from: specrec.c:1312-1313
@@ -525,6 +1039,137 @@ func (d *Decoder) reconstructCPE(cpe *cpeParseResult, channelBase uint8) error {
 	return nil
 }
 
+// couplingDecoder is implemented by element decoders that decode
+// coupling channel elements. Dependently switched coupling channels are
+// kept by the element decoder and added to the spectra of the elements
+// reconstructed after them; independently switched ones go through the
+// filter bank here and are added to the time output of their targets.
+type couplingDecoder interface {
+	ParseCCE(r *bits.Reader, quant []int16) (element any, tag uint8, err error)
+	ReconstructCCE(element any, quant []int16, spec []float32, windowShapePrev uint8) error
+	ClearCouplings()
+	IndependentCoupling(element any) bool
+	IndependentCouplingGain(element any, targetIsCPE bool, tag uint8, channel int) float32
+}
+
+// independentCCE is an independently switched coupling channel of the
+// current raw_data_block and its filter bank output.
+type independentCCE struct {
+	element any
+	timeOut []float32
+}
+
+// decodeCCE parses and reconstructs a coupling channel element. Its
+// gains are applied to the target elements that follow it in the
+// raw_data_block.
+//
+// Ported from: coupling_channel_element() in ~/dev/faad2/libfaad/syntax.c:987-1076
+func (d *Decoder) decodeCCE(r *bits.Reader) error {
+	if d.elementDecoder() == nil {
+		return ErrMaxBitstreamElements
+	}
+	dec, ok := d.elements.(couplingDecoder)
+	if !ok {
+		return ErrChannelCouplingNotImpl
+	}
+
+	quant := d.getInt16(int(d.frameLength))
+	defer d.putInt16(quant)
+	element, tag, err := dec.ParseCCE(r, quant)
+	if err != nil {
+		return err
+	}
+	if err := d.checkStrict(element); err != nil {
+		return err
+	}
+	if len(d.specBuf) != 2*int(d.frameLength) {
+		d.specBuf = make([]float32, 2*int(d.frameLength))
+	}
+	spec := d.specBuf[:d.frameLength]
+	if err := dec.ReconstructCCE(element, quant, spec, d.cceWindowShapePrev[tag]); err != nil {
+		return err
+	}
+
+	windowSequence, windowShape := d.elementDecoder().Window(element, 0)
+	if dec.IndependentCoupling(element) {
+		if err := d.cceFilterBank(spec, tag, windowSequence, windowShape); err != nil {
+			return err
+		}
+		d.independentCCEs = append(d.independentCCEs, independentCCE{element, d.cceTimeOut[tag]})
+	}
+	d.cceWindowShapePrev[tag] = windowShape
+	return nil
+}
+
+// applyIndependentCouplings adds the independently switched coupling
+// channels of the current raw_data_block that target channel index of
+// the element with instance tag tag to the time output of channel.
+func (d *Decoder) applyIndependentCouplings(targetIsCPE bool, tag uint8, index int, channel uint8) {
+	dec, ok := d.elements.(couplingDecoder)
+	if !ok {
+		return
+	}
+	out := d.timeOut[channel]
+	for _, c := range d.independentCCEs {
+		gain := dec.IndependentCouplingGain(c.element, targetIsCPE, tag, index)
+		if gain == 0 {
+			continue
+		}
+		for i := range min(len(out), len(c.timeOut)) {
+			out[i] += gain * c.timeOut[i]
+		}
+	}
+}
+
+// ltpStateUpdater is implemented by element decoders keeping Long Term
+// Prediction history, which grows with every frame of filter bank output.
+type ltpStateUpdater interface {
+	UpdateLTPState(channel uint8, timeOut, overlap []float32)
+}
+
+// updateLTPState hands the channel's time output and overlap of the
+// current frame to the element decoder's LTP history.
+func (d *Decoder) updateLTPState(channel uint8) {
+	if u, ok := d.elements.(ltpStateUpdater); ok {
+		u.UpdateLTPState(channel, d.timeOut[channel], d.fbIntermed[channel])
+	}
+}
+
+// tonalitySource is implemented by element decoders that measure the
+// spectral flatness of reconstructed channels.
+type tonalitySource interface {
+	SpectralFlatness(element any, index int, spec []float32) float32
+}
+
+// measureTonality records the spectral flatness of spec, the reconstructed
+// channel index of element, as that of channel when ComputeTonality is
+// set.
+func (d *Decoder) measureTonality(element any, index int, channel uint8, spec []float32) {
+	if !d.config.ComputeTonality {
+		return
+	}
+	if s, ok := d.elements.(tonalitySource); ok {
+		d.setChannelTonality(channel, s.SpectralFlatness(element, index, spec))
+	}
+}
+
+// setChannelTonality records the spectral flatness of a reconstructed
+// channel for FrameInfo.Tonality.
+func (d *Decoder) setChannelTonality(channel uint8, flatness float32) {
+	if d.config.ComputeTonality && channel < maxChannels {
+		d.tonality[channel] = flatness
+	}
+}
+
+// reportTonality copies the per-channel spectral flatness of the frame
+// into info when ComputeTonality is set.
+func (d *Decoder) reportTonality(info *FrameInfo, numChannels uint8) {
+	if !d.config.ComputeTonality {
+		return
+	}
+	info.Tonality = append([]float32(nil), d.tonality[:numChannels]...)
+}
+
 // generatePCMOutput converts time-domain samples to PCM format.
 //
 // Parameters:
@@ -534,36 +1179,25 @@ func (d *Decoder) reconstructCPE(cpe *cpeParseResult, channelBase uint8) error {
 // The returned type depends on the format:
 //   - OutputFormat16Bit: []int16
 //   - OutputFormat24Bit: []int32 (packed 24-bit in 32-bit container)
-//   - OutputFormat32Bit: []int32
+//   - OutputFormat32Bit: []int32 (left-justified to Config.SourceBitDepth
+//     bits by output.ToPCM32BitLeftJustified when set)
 //   - OutputFormatFloat: []float32
 //   - OutputFormatDouble: []float64
 //
 // Ported from: output_to_PCM() call in ~/dev/faad2/libfaad/decoder.c:1188-1189
-//
-//nolint:unused // Infrastructure for future decoding
 func (d *Decoder) generatePCMOutput(outputChannels uint8) interface{} {
-	// For now, just convert float32 to int16 directly
-	// This will be replaced with proper output package integration
-
-	samples := make([]int16, int(d.frameLength)*int(outputChannels))
-
-	for ch := uint8(0); ch < outputChannels; ch++ {
-		if d.timeOut[ch] == nil {
-			continue
-		}
-		for i := 0; i < int(d.frameLength); i++ {
-			sample := d.timeOut[ch][i]
-			// Clip and convert to int16
-			if sample > 32767.0 {
-				sample = 32767.0
-			} else if sample < -32768.0 {
-				sample = -32768.0
-			}
-			// Interleave: sample[i*numCh + ch]
-			samples[i*int(outputChannels)+int(ch)] = int16(sample)
-		}
+	// Only 16-bit output is dithered
+	dither := d.dither.source(d.config.Dither)
+	var samples any
+	front := d.timeOut[:5]
+	if d.preciseDownmix() && !slices.ContainsFunc(front, func(s []float32) bool { return s == nil }) {
+		samples = output.OutputToPCMFloat64HighPrecision(front, []uint8{0, 1, 2, 3, 4}, 2, d.frameLength, true, false)
+	} else {
+		samples = convertPCM(d.orderSources(d.outputSources(outputChannels)), d.frameLength, &d.config, d.pcmDst, dither)
+	}
+	if d.config.Planar {
+		return planarPCM(samples, int(outputChannels))
 	}
-
 	return samples
 }
 
@@ -580,8 +1214,6 @@ func (d *Decoder) generatePCMOutput(outputChannels uint8) interface{} {
 //	7: C, L, R, Ls, Rs, Lrs, Rrs, LFE (7.1)
 //
 // Ported from: create_channel_config() in ~/dev/faad2/libfaad/decoder.c:598-819
-//
-//nolint:unused
 func (d *Decoder) createChannelConfig(info *FrameInfo) {
 	info.NumFrontChannels = 0
 	info.NumSideChannels = 0
@@ -593,6 +1225,12 @@ func (d *Decoder) createChannelConfig(info *FrameInfo) {
 		info.ChannelPosition[i] = ChannelUnknown
 	}
 
+	if d.monoMixdown() {
+		info.NumFrontChannels = 1
+		info.ChannelPosition[0] = ChannelFrontCenter
+		return
+	}
+
 	// Handle downmix to stereo
 	if d.downMatrix {
 		info.NumFrontChannels = 2
@@ -601,8 +1239,11 @@ func (d *Decoder) createChannelConfig(info *FrameInfo) {
 		return
 	}
 
-	// TODO: Handle PCE-based channel config when pceSet is true
-	// For now, only standard channel configurations are supported
+	if d.pceSet && d.channelConfiguration == 0 {
+		d.pceChannelConfig(info)
+		d.applyForcedLayout(info)
+		return
+	}
 
 	// Standard channel configurations
 	switch d.channelConfiguration {
@@ -658,9 +1299,16 @@ func (d *Decoder) createChannelConfig(info *FrameInfo) {
 		info.ChannelPosition[7] = ChannelLFE
 	default:
 		// Configuration 0 or >7: channels defined by elements in bitstream
-		// TODO: Implement fallback channel config based on fr_channels and has_lfe
-		// For now, leave channel positions as ChannelUnknown
+		// TODO: Implement fallback front/back positions based on fr_channels
+		// For now, only the LFE channel, which FAAD2 places last, is positioned
+		// Ported from: create_channel_config() in ~/dev/faad2/libfaad/decoder.c:800-815
+		if d.hasLFE && d.frChannels > 0 {
+			info.NumLFEChannels = 1
+			info.ChannelPosition[d.frChannels-1] = ChannelLFE
+		}
 	}
+
+	d.applyForcedLayout(info)
 }
 
 // DecodeInt16 decodes one AAC frame and returns int16 PCM samples.
@@ -686,7 +1334,7 @@ func (d *Decoder) DecodeInt16(frame []byte) ([]int16, error) {
 	// Type assert to []int16
 	int16Samples, ok := samples.([]int16)
 	if !ok {
-		// For non-16bit output formats, return nil
+		// For non-16bit or planar output, return nil
 		// Users should use Decode() directly for other formats
 		return nil, nil
 	}
@@ -694,20 +1342,22 @@ func (d *Decoder) DecodeInt16(frame []byte) ([]int16, error) {
 	return int16Samples, nil
 }
 
+// decodeInterleaved decodes one AAC frame with Decode, but to interleaved
+// samples in format whatever Config.OutputFormat and Config.Planar are.
+// The configuration is restored before it returns.
+func (d *Decoder) decodeInterleaved(frame []byte, format OutputFormat) (any, *FrameInfo, error) {
+	originalFormat, originalPlanar := d.config.OutputFormat, d.config.Planar
+	d.config.OutputFormat, d.config.Planar = format, false
+	defer func() { d.config.OutputFormat, d.config.Planar = originalFormat, originalPlanar }()
+	return d.Decode(frame)
+}
+
 // DecodeFloat decodes one AAC frame and returns float32 PCM samples.
 // This is a convenience wrapper around Decode() with float output format.
 //
 // Ported from: NeAACDecDecode() with FAAD_FMT_FLOAT
 func (d *Decoder) DecodeFloat(buffer []byte) ([]float32, *FrameInfo, error) {
-	// Temporarily set output format to float
-	originalFormat := d.config.OutputFormat
-	d.config.OutputFormat = OutputFormatFloat
-
-	samples, info, err := d.Decode(buffer)
-
-	// Restore original format
-	d.config.OutputFormat = originalFormat
-
+	samples, info, err := d.decodeInterleaved(buffer, OutputFormatFloat)
 	if err != nil || samples == nil {
 		return nil, info, err
 	}
@@ -739,6 +1389,103 @@ func (d *Decoder) DecodeFloat32(frame []byte) ([]float32, error) {
 	return samples, nil
 }
 
+// DecodeInto decodes one AAC frame into out as interleaved 16-bit PCM,
+// whatever Config.OutputFormat and Config.Planar are, and returns its
+// frame information: the frame's samples are out[:info.Samples]. Reusing
+// out across frames, together with Config.PoolBuffers, avoids the
+// per-frame allocations of Decode. It returns ErrOutputBufferTooSmall,
+// with the frame decoded, when out cannot hold its samples.
+func (d *Decoder) DecodeInto(frame []byte, out []int16) (*FrameInfo, error) {
+	if d == nil {
+		return nil, ErrNilDecoder
+	}
+
+	d.pcmDst = out
+	samples, info, err := d.decodeInterleaved(frame, OutputFormat16Bit)
+	d.pcmDst = nil
+
+	if err != nil || info == nil {
+		return info, err
+	}
+	pcm, _ := samples.([]int16)
+	n := min(int(info.Samples), len(pcm))
+	if n > len(out) {
+		return info, ErrOutputBufferTooSmall
+	}
+	// A no-op when the samples were converted into out
+	copy(out, pcm[:n])
+	return info, nil
+}
+
+// DecodeBytes decodes one AAC frame and returns its interleaved PCM
+// samples packed into bytes in Config.ByteOrder, ready to be written to
+// a WAV file or a socket.
+//
+// Samples are in Config.OutputFormat: 2 bytes for 16-bit, 3 bytes for
+// 24-bit, 4 bytes for 32-bit and float, 8 bytes for double. Planar is
+// ignored. Like DecodeFrom, it returns nil bytes for a frame without
+// samples.
+func (d *Decoder) DecodeBytes(frame []byte) ([]byte, *FrameInfo, error) {
+	if d == nil {
+		return nil, nil, ErrNilDecoder
+	}
+
+	samples, info, err := d.decodeInterleaved(frame, d.config.OutputFormat)
+	if err != nil || info == nil || info.Samples == 0 {
+		return nil, info, err
+	}
+
+	return packPCM(samples, d.config.OutputFormat == OutputFormat24Bit, d.config.ByteOrder), info, nil
+}
+
+// mdctTapper is implemented by filter banks that can expose their
+// IMDCT input (see Config.MDCTTap).
+type mdctTapper interface {
+	SetMDCTTap(tap func(coeffs []float32))
+}
+
+// inverseFilterBank turns a channel's spectrum into its time output with
+// applyFilterBank, first handing the spectrum to Config.SpectrumCallback,
+// if set.
+func (d *Decoder) inverseFilterBank(
+	specData []float32,
+	channel uint8,
+	windowSequence uint8,
+	windowShape uint8,
+) error {
+	if cb := d.config.SpectrumCallback; cb != nil {
+		if len(d.spectrumTap) != len(specData) {
+			d.spectrumTap = make([]float64, len(specData))
+		}
+		for i, v := range specData {
+			d.spectrumTap[i] = float64(v)
+		}
+		cb(channel, d.spectrumTap)
+	}
+
+	return d.applyFilterBank(specData, channel, windowSequence, windowShape)
+}
+
+// cceFilterBank runs the inverse filter bank on the spectrum of the
+// independently switched coupling channel with instance tag tag, into
+// d.cceTimeOut[tag] with the overlap of the previous frame's coupling
+// channel of that tag.
+func (d *Decoder) cceFilterBank(specData []float32, tag uint8, windowSequence, windowShape uint8) error {
+	fb, ok := d.fb.(interface {
+		IFilterBank(windowSequence, windowShape, windowShapePrev uint8, freqIn, timeOut, overlap []float32)
+	})
+	if !ok {
+		return ErrNilDecoder
+	}
+	if len(d.cceTimeOut[tag]) != int(d.frameLength) {
+		d.cceTimeOut[tag] = make([]float32, d.frameLength)
+		d.cceOverlap[tag] = make([]float32, d.frameLength)
+	}
+	fb.IFilterBank(windowSequence, windowShape, d.cceWindowShapePrev[tag],
+		specData, d.cceTimeOut[tag], d.cceOverlap[tag])
+	return nil
+}
+
 // applyFilterBank applies the inverse filter bank (IMDCT + windowing + overlap-add).
 //
 // Parameters:
@@ -752,9 +1499,10 @@ func (d *Decoder) DecodeFloat32(frame []byte) ([]float32, error) {
 // 2. Windowing with overlap-add
 // 3. Writes output to d.timeOut[channel]
 //
-// Ported from: ifilter_bank() in ~/dev/faad2/libfaad/filtbank.c
+// AAC-LD streams have no window sequences; they go through IFilterBankLD
+// with the LD (sine or low-overlap) windows and windowSequence is ignored.
 //
-//nolint:unused // Infrastructure for future decoding
+// Ported from: ifilter_bank() in ~/dev/faad2/libfaad/filtbank.c
 func (d *Decoder) applyFilterBank(
 	specData []float32,
 	channel uint8,
@@ -767,7 +1515,7 @@ func (d *Decoder) applyFilterBank(
 	}
 
 	// Type-assert to access IFilterBank method
-	// The actual type is *filterbank.FilterBank registered via factory
+	// The actual type is *filterbank.FilterBank made by filterBankFactory
 	// WindowSequence is defined as uint8 in internal/syntax/constants.go
 	type filterBankInterface interface {
 		IFilterBank(
@@ -780,10 +1528,48 @@ func (d *Decoder) applyFilterBank(
 		)
 	}
 
+	// AAC-LD has a single long window sequence with its own windows
+	type ldFilterBankInterface interface {
+		IFilterBankLD(
+			windowShape uint8,
+			windowShapePrev uint8,
+			freqIn []float32,
+			timeOut []float32,
+			overlap []float32,
+		)
+	}
+
 	fb, ok := d.fb.(filterBankInterface)
 	if !ok {
 		return ErrNilDecoder // Filter bank not properly initialized
 	}
+	var ldFB ldFilterBankInterface
+	if ObjectType(d.objectType) == ObjectTypeLD {
+		if ldFB, ok = d.fb.(ldFilterBankInterface); !ok {
+			return ErrNilDecoder // Filter bank lacks the LD transform
+		}
+	}
+
+	// Route the pre-IFFT coefficients to the tap for this channel only
+	if tap := d.config.MDCTTap; tap != nil {
+		if tapper, ok := d.fb.(mdctTapper); ok {
+			tapper.SetMDCTTap(func(coeffs []float32) {
+				tap(int(channel), coeffs)
+			})
+			defer tapper.SetMDCTTap(nil)
+		}
+	}
+
+	if ldFB != nil {
+		ldFB.IFilterBankLD(
+			windowShape,
+			d.windowShapePrev[channel],
+			specData,
+			d.timeOut[channel],
+			d.fbIntermed[channel],
+		)
+		return nil
+	}
 
 	// Apply inverse filter bank
 	fb.IFilterBank(
@@ -0,0 +1,155 @@
+// decode_960_test.go
+package aac
+
+import (
+	"math"
+	"testing"
+)
+
+// writePNSStream writes an individual_channel_stream whose first maxSFB
+// bands are all perceptual noise substitution bands of equal energy, the
+// simplest channel stream with a non-silent output: noise bands carry no
+// spectral data and their scale factor deltas of 0 are single bits.
+func writePNSStream(w *adifBitWriter, windowSequence uint32, maxSFB uint32) {
+	const noiseHCB = 13
+
+	w.writeBits(150, 8) // global_gain
+
+	// ics_info
+	w.writeBits(0, 1) // ics_reserved_bit
+	w.writeBits(windowSequence, 2)
+	w.writeBits(1, 1) // window_shape: KBD
+	if windowSequence == 2 {
+		w.writeBits(maxSFB, 4)
+		w.writeBits(0x7F, 7) // scale_factor_grouping: one group of eight
+	} else {
+		w.writeBits(maxSFB, 6)
+		w.writeBits(0, 1) // predictor_data_present
+	}
+
+	// section_data: a single noise section, its length escaped with
+	// all-ones values
+	w.writeBits(noiseHCB, 4)
+	lenBits, escape := 5, uint32(31)
+	if windowSequence == 2 {
+		lenBits, escape = 3, 7
+	}
+	n := maxSFB
+	for ; n >= escape; n -= escape {
+		w.writeBits(escape, lenBits)
+	}
+	w.writeBits(n, lenBits)
+
+	// scale_factor_data: a 9-bit PCM energy, then Huffman deltas of 0
+	w.writeBits(256, 9)
+	for range maxSFB - 1 {
+		w.writeBits(0, 1)
+	}
+
+	w.writeBits(0, 1) // pulse_data_present
+	w.writeBits(0, 1) // tns_data_present
+	w.writeBits(0, 1) // gain_control_data_present
+}
+
+// pns960Frames returns raw stereo frames of 960-sample AAC LC at 48 kHz,
+// cycling through the long, long start, eight short and long stop window
+// sequences.
+func pns960Frames(count int) [][]byte {
+	sequences := []uint32{0, 1, 2, 3}
+	frames := make([][]byte, count)
+	for i := range frames {
+		seq := sequences[i%len(sequences)]
+		maxSFB := uint32(40)
+		if seq == 2 {
+			maxSFB = 12
+		}
+
+		w := &adifBitWriter{}
+		w.writeBits(1, 3) // ID_CPE
+		w.writeBits(0, 4) // element_instance_tag
+		w.writeBits(0, 1) // common_window
+		writePNSStream(w, seq, maxSFB)
+		writePNSStream(w, seq, maxSFB)
+		w.writeBits(7, 3) // ID_END
+		w.byteAlign()
+		frames[i] = w.buf
+	}
+	return frames
+}
+
+// asc960 is an AudioSpecificConfig for stereo AAC LC at 48 kHz with
+// frameLengthFlag set.
+var asc960 = []byte{0x11, 0x94}
+
+func TestDecode_960SampleFrames(t *testing.T) {
+	d := NewDecoder()
+	defer d.Close()
+	if _, err := d.Init2(asc960); err != nil {
+		t.Fatalf("Init2: %v", err)
+	}
+	if got := d.FrameLength(); got != 960 {
+		t.Fatalf("FrameLength() = %d, want 960", got)
+	}
+
+	var energy float64
+	for i, frame := range pns960Frames(8) {
+		samples, info, err := d.Decode(frame)
+		if err != nil {
+			t.Fatalf("frame %d: Decode: %v", i, err)
+		}
+		if info.BytesConsumed != uint32(len(frame)) {
+			t.Errorf("frame %d: consumed %d bytes, want %d", i, info.BytesConsumed, len(frame))
+		}
+		if i == 0 {
+			// The first frame only primes the overlap
+			continue
+		}
+		pcm, _ := samples.([]int16)
+		if info.Samples != 960*2 || len(pcm) != 960*2 {
+			t.Fatalf("frame %d: %d samples (%d returned), want 1920", i, info.Samples, len(pcm))
+		}
+		for _, s := range pcm {
+			energy += float64(s) * float64(s)
+		}
+	}
+	if rms := math.Sqrt(energy / (7 * 1920)); rms < 50 {
+		t.Errorf("decoded noise RMS %.2f, want an audible signal", rms)
+	}
+}
+
+func TestInit2_FrameLengthFlag(t *testing.T) {
+	tests := []struct {
+		name string
+		asc  []byte
+		want uint16
+	}{
+		{"LC 1024", sine1kASC, 1024},
+		{"LC 960", asc960, 960},
+		// ER AAC LD, 48 kHz stereo: 512, or 480 with frameLengthFlag
+		{"LD 512", []byte{0xB9, 0x90, 0x00}, 512},
+		{"LD 480", []byte{0xB9, 0x94, 0x00}, 480},
+	}
+	for _, tt := range tests {
+		d := NewDecoder()
+		if _, err := d.Init2(tt.asc); err != nil {
+			t.Fatalf("%s: Init2: %v", tt.name, err)
+		}
+		if got := d.FrameLength(); got != tt.want {
+			t.Errorf("%s: FrameLength() = %d, want %d", tt.name, got, tt.want)
+		}
+		d.Close()
+	}
+
+	// A later ADTS initialization goes back to 1024-sample frames
+	d := NewDecoder()
+	defer d.Close()
+	if _, err := d.Init2(asc960); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := d.Init([]byte{0xFF, 0xF1, 0x50, 0x80, 0x02, 0x1F, 0xFC}); err != nil {
+		t.Fatalf("Init: %v", err)
+	}
+	if got := d.FrameLength(); got != 1024 {
+		t.Errorf("FrameLength() after ADTS Init = %d, want 1024", got)
+	}
+}
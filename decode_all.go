@@ -0,0 +1,57 @@
+// decode_all.go
+package aac
+
+import (
+	"bufio"
+	"bytes"
+	"time"
+)
+
+// StreamInfo describes a stream decoded by DecodeAll.
+type StreamInfo struct {
+	SampleRate   uint32        // Output sample rate in Hz
+	Channels     uint8         // Output channels
+	Frames       int           // Frames with output; the muted first frame is not counted
+	TotalSamples uint64        // Samples over all channels
+	Duration     time.Duration // Duration of the decoded PCM
+}
+
+// DecodeAll decodes a whole ADTS or ADIF stream held in memory and
+// returns its interleaved 16-bit PCM. A leading ID3v2 tag is skipped and
+// the muted first frame, which only primes the overlap-add, is dropped.
+//
+// The returned StreamInfo reports the sample rate and channels of the
+// first frame with output; a stream whose layout changes midway yields
+// PCM interleaved with varying channel counts.
+func DecodeAll(data []byte) ([]int16, *StreamInfo, error) {
+	cfg := NewDecoder().Config()
+	cfg.OutputFormat = OutputFormat16Bit
+
+	var pcm []int16
+	info := &StreamInfo{}
+	err := decodeFrames(bufio.NewReader(bytes.NewReader(data)), cfg, func(samples any, fi *FrameInfo) error {
+		// The muted first frame returns a buffer but reports no samples
+		s, _ := samples.([]int16)
+		s = s[:min(len(s), int(fi.Samples))]
+		if len(s) == 0 {
+			return nil
+		}
+		if info.Frames == 0 {
+			info.SampleRate = fi.SampleRate
+			info.Channels = fi.Channels
+		}
+		info.Frames++
+		pcm = append(pcm, s...)
+		return nil
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	info.TotalSamples = uint64(len(pcm))
+	if info.SampleRate > 0 && info.Channels > 0 {
+		perChannel := info.TotalSamples / uint64(info.Channels)
+		info.Duration = time.Duration(perChannel) * time.Second / time.Duration(info.SampleRate)
+	}
+	return pcm, info, nil
+}
@@ -0,0 +1,62 @@
+// decode_all_test.go
+package aac
+
+import (
+	"errors"
+	"os"
+	"slices"
+	"testing"
+	"time"
+)
+
+func TestDecodeAll(t *testing.T) {
+	data, err := os.ReadFile("testdata/sine1k.aac")
+	if err != nil {
+		t.Skipf("sine1k.aac not available: %v", err)
+	}
+
+	pcm, info, err := DecodeAll(data)
+	if err != nil {
+		t.Fatalf("DecodeAll: %v", err)
+	}
+
+	// Same samples as decoding frame by frame, less the muted first frame
+	d := NewDecoder()
+	if _, err := d.Init(data); err != nil {
+		t.Fatalf("Init: %v", err)
+	}
+	frames, _ := decodeAll(t, d, data)
+	if len(frames) < 3 {
+		t.Fatalf("only %d frames in sine1k.aac", len(frames))
+	}
+	if want := slices.Concat(frames[1:]...); !slices.Equal(pcm, want) {
+		t.Errorf("DecodeAll returned %d samples, frame by frame gives %d or they differ", len(pcm), len(want))
+	}
+
+	if info.SampleRate != 44100 || info.Channels != 1 {
+		t.Errorf("info: %d Hz, %d channels; want 44100 Hz mono", info.SampleRate, info.Channels)
+	}
+	if info.Frames != len(frames)-1 {
+		t.Errorf("info.Frames = %d, want %d", info.Frames, len(frames)-1)
+	}
+	if info.TotalSamples != uint64(len(pcm)) || len(pcm) != info.Frames*1024 {
+		t.Errorf("info.TotalSamples = %d for %d samples in %d frames", info.TotalSamples, len(pcm), info.Frames)
+	}
+	if want := time.Duration(len(pcm)) * time.Second / 44100; info.Duration != want {
+		t.Errorf("info.Duration = %v, want %v", info.Duration, want)
+	}
+
+	// A leading ID3v2 tag is skipped
+	tag := []byte{'I', 'D', '3', 4, 0, 0, 0, 0, 0, 2, 0, 0}
+	tagged, _, err := DecodeAll(append(tag, data...))
+	if err != nil {
+		t.Fatalf("DecodeAll with ID3v2: %v", err)
+	}
+	if !slices.Equal(tagged, pcm) {
+		t.Error("ID3v2 tag changed the output")
+	}
+
+	if _, _, err := DecodeAll([]byte("not an aac stream")); !errors.Is(err, ErrNoHeaderDetected) {
+		t.Errorf("DecodeAll(garbage) error = %v, want ErrNoHeaderDetected", err)
+	}
+}
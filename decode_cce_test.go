@@ -0,0 +1,165 @@
+// decode_cce_test.go
+package aac
+
+import (
+	"testing"
+)
+
+// ascCCE is an AudioSpecificConfig for mono AAC LC at 44.1 kHz.
+var ascCCE = []byte{0x12, 0x08}
+
+// writeQuadStream writes an individual_channel_stream whose first two
+// bands, the first eight bins at 44.1 kHz, hold the given codebook 1
+// quads at a global gain of 184, using the sine window.
+func writeQuadStream(t testing.TB, w *adifBitWriter, quads [2][4]int16) {
+	t.Helper()
+	w.writeBits(184, 8) // global_gain
+
+	// ics_info
+	w.writeBits(0, 1) // ics_reserved_bit
+	w.writeBits(0, 2) // window_sequence: ONLY_LONG_SEQUENCE
+	w.writeBits(0, 1) // window_shape: sine
+	w.writeBits(2, 6) // max_sfb
+	w.writeBits(0, 1) // predictor_data_present
+
+	// section_data: codebook 1 over both bands
+	w.writeBits(1, 4)
+	w.writeBits(2, 5)
+
+	// scale_factor_data: both bands at global_gain
+	w.writeBits(0, 1)
+	w.writeBits(0, 1)
+
+	w.writeBits(0, 1) // pulse_data_present
+	w.writeBits(0, 1) // tns_data_present
+	w.writeBits(0, 1) // gain_control_data_present
+
+	for _, q := range quads {
+		code, length := quadCodeword(t, q)
+		w.writeBits(code, length)
+	}
+}
+
+// cceFrame returns a raw mono frame holding an SCE with instance tag 0
+// and the target quads. With coupled set, the SCE follows a coupling
+// channel element holding the coupling quads, which targets an absent
+// SCE with tag 5 through the unity gain list and the SCE with tag 0
+// through a common gain of 0.5. The coupling channel is independently
+// switched if independent is set, and dependently switched, before TNS,
+// otherwise.
+func cceFrame(t testing.TB, target, coupling [2][4]int16, coupled, independent bool) []byte {
+	t.Helper()
+	w := &adifBitWriter{}
+	if coupled {
+		w.writeBits(2, 3) // ID_CCE
+		w.writeBits(0, 4) // element_instance_tag
+		if independent {
+			w.writeBits(1, 1) // ind_sw_cce_flag
+		} else {
+			w.writeBits(0, 1)
+		}
+		w.writeBits(1, 3) // num_coupled_elements: two targets
+		w.writeBits(0, 1) // cc_target_is_cpe
+		w.writeBits(5, 4) // cc_target_tag_select
+		w.writeBits(0, 1) // cc_target_is_cpe
+		w.writeBits(0, 4) // cc_target_tag_select
+		w.writeBits(0, 1) // cc_domain: before TNS
+		w.writeBits(0, 1) // gain_element_sign
+		w.writeBits(3, 2) // gain_element_scale: steps of 2
+
+		writeQuadStream(t, w, coupling)
+
+		// The second gain element list, for the SCE with tag 0
+		if !independent {
+			w.writeBits(1, 1) // common_gain_element_present
+		}
+		w.writeBits(0xA, 4) // common_gain_element: delta +1, gain 2^-1
+	}
+
+	w.writeBits(0, 3) // ID_SCE
+	w.writeBits(0, 4) // element_instance_tag
+	writeQuadStream(t, w, target)
+
+	w.writeBits(7, 3) // ID_END
+	w.byteAlign()
+	return w.buf
+}
+
+// decodeCCEFrames decodes frames with a new mono LC decoder and returns
+// the PCM of each.
+func decodeCCEFrames(t *testing.T, frames [][]byte) [][]int16 {
+	t.Helper()
+	d := NewDecoder()
+	defer d.Close()
+	if _, err := d.Init2(ascCCE); err != nil {
+		t.Fatalf("Init2: %v", err)
+	}
+
+	out := make([][]int16, len(frames))
+	for f, frame := range frames {
+		samples, _, err := d.Decode(frame)
+		if err != nil {
+			t.Fatalf("frame %d: Decode: %v", f, err)
+		}
+		out[f], _ = samples.([]int16)
+	}
+	return out
+}
+
+// TestDecode_CCEGain decodes a channel with a coupling channel added at a
+// gain of 0.5, and checks the output against the channel and the coupling
+// payload decoded as separate channels.
+func TestDecode_CCEGain(t *testing.T) {
+	targets := [][2][4]int16{
+		{{1, 0, 0, 0}, {0, -1, 0, 0}},
+		{{0, 0, 1, 0}, {1, 0, 0, 1}},
+		{{0, 1, 0, 0}, {0, 0, 0, -1}},
+	}
+	couplings := [][2][4]int16{
+		{{0, 0, 1, 0}, {1, 0, 0, 0}},
+		{{-1, 0, 0, 0}, {0, 1, 0, 0}},
+		{{0, 0, 0, 1}, {0, 0, -1, 0}},
+	}
+
+	frames := func(coupled, independent bool) [][]byte {
+		out := make([][]byte, len(targets))
+		for f := range targets {
+			out[f] = cceFrame(t, targets[f], couplings[f], coupled, independent)
+		}
+		return out
+	}
+	alone := decodeCCEFrames(t, frames(false, false))
+	payloads := make([][]byte, len(couplings))
+	for f := range couplings {
+		payloads[f] = cceFrame(t, couplings[f], couplings[f], false, false)
+	}
+	payload := decodeCCEFrames(t, payloads)
+
+	for _, tc := range []struct {
+		name        string
+		independent bool
+	}{
+		{"dependent", false},
+		{"independent", true},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			got := decodeCCEFrames(t, frames(true, tc.independent))
+			var energy float64
+			for f := range got {
+				if len(got[f]) != len(alone[f]) {
+					t.Fatalf("frame %d: %d samples, want %d", f, len(got[f]), len(alone[f]))
+				}
+				for i, s := range got[f] {
+					want := float64(alone[f][i]) + 0.5*float64(payload[f][i])
+					if d := float64(s) - want; d > 2 || d < -2 {
+						t.Fatalf("frame %d sample %d = %d, want %.1f", f, i, s, want)
+					}
+					energy += float64(payload[f][i]) * float64(payload[f][i])
+				}
+			}
+			if energy == 0 {
+				t.Fatal("coupling payload is silent")
+			}
+		})
+	}
+}
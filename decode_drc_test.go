@@ -0,0 +1,104 @@
+// decode_drc_test.go
+package aac_test
+
+import (
+	"os"
+	"testing"
+
+	"github.com/llehouerou/go-aac"
+)
+
+// writeDRCElement appends a fill element carrying a single band
+// dynamic_range_info() that compresses by ctl quarter dB steps, excluding
+// the channels set in the 7-bit excludeMask (MSB is channel 0).
+func writeDRCElement(w *elementBitWriter, excludeMask uint8, ctl uint8) {
+	w.writeBits(6, 3) // ID_FIL
+	count := uint32(2)
+	if excludeMask != 0 {
+		count++
+	}
+	w.writeBits(count, 4)
+	w.writeBits(11, 4) // EXT_DYNAMIC_RANGE
+	w.writeBits(0, 1)  // pce_tag_present
+	if excludeMask != 0 {
+		w.writeBits(1, 1)
+		w.writeBits(uint32(excludeMask), 7)
+		w.writeBits(0, 1) // additional_excluded_chns
+	} else {
+		w.writeBits(0, 1)
+	}
+	w.writeBits(0, 1) // drc_bands_present
+	w.writeBits(0, 1) // prog_ref_level_present
+	w.writeBits(1, 1) // dyn_rng_sgn: compress
+	w.writeBits(uint32(ctl), 7)
+}
+
+// decodeFramesDRC decodes an ADTS stream like decodeFrames, applying
+// stream DRC with the given control.
+func decodeFramesDRC(t *testing.T, data []byte, ctrl *aac.DRCControl) [][]int16 {
+	t.Helper()
+	d := aac.NewDecoder()
+	cfg := d.Config()
+	cfg.NoiseGenerator = silentNoise{}
+	cfg.DRC = ctrl
+	d.SetConfiguration(cfg)
+	if _, err := d.Init(data); err != nil {
+		t.Fatalf("Init: %v", err)
+	}
+	var frames [][]int16
+	for offset := 0; offset < len(data); {
+		samples, info, err := d.Decode(data[offset:])
+		if err != nil {
+			t.Fatalf("frame %d: %v", len(frames), err)
+		}
+		offset += int(info.BytesConsumed)
+		frames = append(frames, samples.([]int16))
+	}
+	return frames
+}
+
+func TestDecode_DRC(t *testing.T) {
+	data, err := os.ReadFile("testdata/sine1k.aac")
+	if err != nil {
+		t.Skipf("sine1k.aac not available: %v", err)
+	}
+	mono := decodeFrames(t, data, 1)
+
+	t.Run("single band", func(t *testing.T) {
+		drcData := remuxMono(t, data, 1, func(w *elementBitWriter, f *monoFrame) {
+			writeDRCElement(w, 0, 24)
+			w.copyBits(f.payload, f.sceStart, f.icsEnd)
+		})
+
+		// Without DRCControl the DRC data has no effect
+		plain := decodeFramesDRC(t, drcData, nil)
+		// Compressing by 6 dB halves the output
+		compressed := decodeFramesDRC(t, drcData, &aac.DRCControl{Cut: 1})
+		for f := range mono {
+			for i, m := range mono[f] {
+				if plain[f][i] != m {
+					t.Fatalf("frame %d sample %d: got %d without DRCControl, want %d", f, i, plain[f][i], m)
+				}
+				if abs(2*int(compressed[f][i])-int(m)) > 2 {
+					t.Fatalf("frame %d sample %d: got %d, want %d/2", f, i, compressed[f][i], m)
+				}
+			}
+		}
+	})
+
+	t.Run("excluded channel", func(t *testing.T) {
+		stereoData := remuxMono(t, data, 2, func(w *elementBitWriter, f *monoFrame) {
+			writeDRCElement(w, 0x20, 24) // exclude channel 1
+			writeCPE(w, f, 0, cpeLayout{})
+		})
+		stereo := decodeFramesDRC(t, stereoData, &aac.DRCControl{Cut: 1})
+		for f := range mono {
+			for i, m := range mono[f] {
+				l, r := stereo[f][2*i], stereo[f][2*i+1]
+				if abs(2*int(l)-int(m)) > 2 || r != m {
+					t.Fatalf("frame %d sample %d: got (%d, %d), want (%d/2, %d)", f, i, l, r, m, m)
+				}
+			}
+		}
+	})
+}
@@ -0,0 +1,238 @@
+// decode_file.go
+package aac
+
+import (
+	"bufio"
+	"encoding/binary"
+	"errors"
+	"io"
+	"os"
+)
+
+// adtsFixedHeaderSize is the size in bytes of an ADTS header without CRC.
+const adtsFixedHeaderSize = 7
+
+// adifWindowSize is the number of bytes handed to the decoder per ADIF
+// raw_data_block. It matches FAAD2's input buffer of FAAD_MIN_STREAMSIZE
+// bytes for each of MAX_CHANNELS channels, which bounds a block.
+const adifWindowSize = 768 * 64
+
+// DecodeFile decodes an AAC file and writes interleaved PCM to out.
+//
+// The file is streamed frame by frame, so memory use stays bounded by a
+// single ADTS frame (or ADIF decode window) plus one frame of PCM
+// regardless of the file size. A leading ID3v2 tag and ID3v1 trailers are
+// skipped. Samples are written little-endian in the sample format
+// selected by cfg.OutputFormat.
+//
+// ADTS and ADIF streams can be framed from a file; headerless streams
+// are reported with ErrNoHeaderDetected.
+func DecodeFile(path string, out io.Writer, cfg Config) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	return decodeStream(bufio.NewReader(f), out, cfg)
+}
+
+// decodeStream is the io.Reader-based core of DecodeFile.
+func decodeStream(br *bufio.Reader, out io.Writer, cfg Config) error {
+	return decodeFrames(br, cfg, func(samples any, info *FrameInfo) error {
+		// The muted first frame returns a buffer but reports no samples
+		if info.Samples == 0 {
+			return nil
+		}
+		return binary.Write(out, binary.LittleEndian, samples)
+	})
+}
+
+// decodeFrames decodes the ADTS or ADIF stream read from br with a
+// decoder configured with cfg, and hands every frame that returns samples
+// to emit.
+func decodeFrames(br *bufio.Reader, cfg Config, emit func(samples any, info *FrameInfo) error) error {
+	if err := skipID3v2(br); err != nil {
+		return err
+	}
+
+	d := NewDecoder()
+	d.SetConfiguration(cfg)
+	defer d.Close()
+
+	if magic, _ := br.Peek(4); string(magic) == "ADIF" {
+		return decodeADIFStream(br, d, emit)
+	}
+
+	initialized := false
+	for {
+		frame, err := readADTSFrame(br, cfg.UseOldADTSFormat)
+		if err == io.EOF {
+			if !initialized {
+				return ErrNoHeaderDetected
+			}
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		if !initialized {
+			if _, err := d.Init(frame); err != nil {
+				return err
+			}
+			initialized = true
+		}
+
+		samples, info, err := d.Decode(frame)
+		if err != nil {
+			return err
+		}
+		if samples == nil {
+			continue
+		}
+		if err := emit(samples, info); err != nil {
+			return err
+		}
+	}
+}
+
+// decodeADIFStream decodes an ADIF stream. ADIF has no per-frame headers
+// to stream on, so after the header the raw_data_blocks are decoded
+// back to back, each from a window of upcoming bytes, until EOF.
+func decodeADIFStream(br *bufio.Reader, d *Decoder, emit func(samples any, info *FrameInfo) error) error {
+	br = bufio.NewReaderSize(br, adifWindowSize)
+
+	header, err := br.Peek(adifWindowSize)
+	if err != nil && err != io.EOF {
+		return err
+	}
+	result, err := d.Init(header)
+	if err != nil {
+		return err
+	}
+	if _, err := br.Discard(int(result.BytesRead)); err != nil {
+		return err
+	}
+
+	for {
+		block, err := br.Peek(adifWindowSize)
+		if len(block) == 0 {
+			if err == io.EOF {
+				return nil
+			}
+			return err
+		}
+
+		samples, info, err := d.Decode(block)
+		if err != nil {
+			return err
+		}
+		if info.BytesConsumed == 0 {
+			// Would never advance
+			return ErrInputBufferTooSmall
+		}
+		if _, err := br.Discard(int(info.BytesConsumed)); err != nil {
+			return err
+		}
+		if samples == nil {
+			continue
+		}
+		if err := emit(samples, info); err != nil {
+			return err
+		}
+	}
+}
+
+// skipID3v2 discards an ID3v2 tag at the start of the stream, if any.
+func skipID3v2(br *bufio.Reader) error {
+	hdr, err := br.Peek(10)
+	size := id3v2Size(hdr)
+	if err != nil || size == 0 {
+		// Short streams are left for the framing loop to report.
+		return nil
+	}
+
+	_, err = br.Discard(size)
+	if errors.Is(err, io.EOF) {
+		return io.ErrUnexpectedEOF
+	}
+	return err
+}
+
+// id3v2Size returns the size in bytes of the ID3v2 tag starting hdr, or
+// 0 if hdr does not start with one. The tag size is a 28-bit syncsafe
+// integer following the 6-byte prefix, and a footer of 10 more bytes is
+// present when flag bit 4 is set.
+func id3v2Size(hdr []byte) int {
+	if len(hdr) < 10 || hdr[0] != 'I' || hdr[1] != 'D' || hdr[2] != '3' {
+		return 0
+	}
+	size := int(hdr[6]&0x7f)<<21 | int(hdr[7]&0x7f)<<14 |
+		int(hdr[8]&0x7f)<<7 | int(hdr[9]&0x7f)
+	size += 10
+	if hdr[5]&0x10 != 0 {
+		size += 10
+	}
+	return size
+}
+
+// readADTSFrame returns the next complete ADTS frame from the stream,
+// including its header. Bytes before a syncword and ID3v1 trailers are
+// skipped. When the next syncword sits a few bytes off the declared
+// aac_frame_length, the frame is cut at that syncword instead, so that a
+// frame misplaced by splicing is not lost. io.EOF is returned once no
+// further frame is available. oldFormat selects the header format of
+// Config.UseOldADTSFormat.
+func readADTSFrame(br *bufio.Reader, oldFormat bool) ([]byte, error) {
+	for {
+		hdr, err := br.Peek(adtsFixedHeaderSize)
+		if err != nil {
+			// Includes trailing bytes shorter than a header
+			return nil, err
+		}
+
+		// ID3v1 trailer ("TAG" + 125 bytes)
+		if hdr[0] == 'T' && hdr[1] == 'A' && hdr[2] == 'G' {
+			if _, err := br.Discard(128); err != nil {
+				return nil, io.EOF
+			}
+			continue
+		}
+
+		// Syncword 0xFFF followed by layer 0
+		if hdr[0] != 0xFF || hdr[1]&0xF6 != 0xF0 {
+			if _, err := br.Discard(1); err != nil {
+				return nil, err
+			}
+			continue
+		}
+
+		frameLength := adtsFrameLength(hdr, oldFormat)
+		if frameLength < adtsFixedHeaderSize {
+			if _, err := br.Discard(1); err != nil {
+				return nil, err
+			}
+			continue
+		}
+
+		// Resync on the following frame if it is close to, but not at,
+		// the declared end. The window is bounded by the bufio buffer.
+		ahead, _ := br.Peek(min(frameLength+adtsResyncWindow+2, br.Size()))
+		if !isADTSSync(ahead, frameLength) {
+			if next := findADTSSync(ahead, 0, frameLength); next > 0 {
+				frameLength = next
+			}
+		}
+
+		frame := make([]byte, frameLength)
+		if _, err := io.ReadFull(br, frame); err != nil {
+			if err == io.ErrUnexpectedEOF {
+				// Truncated final frame
+				return nil, io.EOF
+			}
+			return nil, err
+		}
+		return frame, nil
+	}
+}
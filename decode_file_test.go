@@ -0,0 +1,242 @@
+// decode_file_test.go
+package aac
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+)
+
+// adtsEmptyFrame is an 8-byte ADTS frame (LC, 44100 Hz, stereo)
+// whose raw_data_block holds only ID_END.
+var adtsEmptyFrame = []byte{0xFF, 0xF1, 0x50, 0x80, 0x01, 0x1F, 0xFC, 0xE0}
+
+// buildMultiFrameFixture returns numFrames ADTS frames wrapped in an
+// ID3v2 tag and followed by an ID3v1 trailer.
+func buildMultiFrameFixture(numFrames int) []byte {
+	var buf bytes.Buffer
+
+	// ID3v2.4 header with a 20-byte body (syncsafe size)
+	buf.Write([]byte{'I', 'D', '3', 4, 0, 0, 0, 0, 0, 20})
+	buf.Write(make([]byte, 20))
+
+	for i := 0; i < numFrames; i++ {
+		buf.Write(adtsEmptyFrame)
+	}
+
+	id3v1 := make([]byte, 128)
+	copy(id3v1, "TAG")
+	buf.Write(id3v1)
+	return buf.Bytes()
+}
+
+func writeFixture(t *testing.T, data []byte) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "fixture.aac")
+	if err := os.WriteFile(path, data, 0o600); err != nil {
+		t.Fatalf("failed to write fixture: %v", err)
+	}
+	return path
+}
+
+func TestReadADTSFrame_MultiFrame(t *testing.T) {
+	br := bufio.NewReader(bytes.NewReader(buildMultiFrameFixture(5)))
+	if err := skipID3v2(br); err != nil {
+		t.Fatalf("skipID3v2 failed: %v", err)
+	}
+
+	frames := 0
+	for {
+		frame, err := readADTSFrame(br, false)
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			t.Fatalf("readADTSFrame failed: %v", err)
+		}
+		if !bytes.Equal(frame, adtsEmptyFrame) {
+			t.Errorf("frame %d: got %x, want %x", frames, frame, adtsEmptyFrame)
+		}
+		frames++
+	}
+
+	if frames != 5 {
+		t.Errorf("frames: got %d, want 5", frames)
+	}
+}
+
+func TestReadADTSFrame_SkipsGarbage(t *testing.T) {
+	data := append([]byte{0x00, 0x12, 0xFF}, adtsEmptyFrame...)
+	br := bufio.NewReader(bytes.NewReader(data))
+
+	frame, err := readADTSFrame(br, false)
+	if err != nil {
+		t.Fatalf("readADTSFrame failed: %v", err)
+	}
+	if !bytes.Equal(frame, adtsEmptyFrame) {
+		t.Errorf("got %x, want %x", frame, adtsEmptyFrame)
+	}
+}
+
+// withADTSFrameLength returns a copy of frame with its aac_frame_length
+// set to n.
+func withADTSFrameLength(frame []byte, n int) []byte {
+	f := append([]byte(nil), frame...)
+	f[3] = f[3]&^0x03 | byte(n>>11)&0x03
+	f[4] = byte(n >> 3)
+	f[5] = f[5]&0x1F | byte(n&0x07)<<5
+	return f
+}
+
+// buildMisalignedStream returns four empty ADTS frames where the first
+// declares one byte more than it holds and the second is followed by a
+// stray byte, with the frame lengths a tolerant reader should find.
+func buildMisalignedStream() ([]byte, []int) {
+	var buf bytes.Buffer
+	buf.Write(withADTSFrameLength(adtsEmptyFrame, len(adtsEmptyFrame)+1))
+	buf.Write(adtsEmptyFrame)
+	buf.WriteByte(0x00)
+	buf.Write(adtsEmptyFrame)
+	buf.Write(adtsEmptyFrame)
+	return buf.Bytes(), []int{8, 9, 8, 8}
+}
+
+func TestReadADTSFrame_Resync(t *testing.T) {
+	data, wantLens := buildMisalignedStream()
+	br := bufio.NewReader(bytes.NewReader(data))
+
+	var lens []int
+	for {
+		frame, err := readADTSFrame(br, false)
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			t.Fatalf("readADTSFrame failed: %v", err)
+		}
+		lens = append(lens, len(frame))
+	}
+
+	if len(lens) != len(wantLens) {
+		t.Fatalf("frame lengths: got %v, want %v", lens, wantLens)
+	}
+	for i := range lens {
+		if lens[i] != wantLens[i] {
+			t.Errorf("frame %d: length %d, want %d", i, lens[i], wantLens[i])
+		}
+	}
+}
+
+func TestDecoder_Decode_ADTSResync(t *testing.T) {
+	data, wantLens := buildMisalignedStream()
+
+	d := NewDecoder()
+	if _, err := d.Init(data); err != nil {
+		t.Fatalf("Init failed: %v", err)
+	}
+	pos := 0
+	for i, want := range wantLens {
+		_, info, err := d.Decode(data[pos:])
+		if err != nil {
+			t.Fatalf("frame %d: Decode failed: %v", i, err)
+		}
+		if info.BytesConsumed != uint32(want) {
+			t.Errorf("frame %d: BytesConsumed: got %d, want %d", i, info.BytesConsumed, want)
+		}
+		pos += int(info.BytesConsumed)
+	}
+	if pos != len(data) {
+		t.Errorf("advanced %d bytes, want %d", pos, len(data))
+	}
+}
+
+func TestReadADTSFrame_LastFrameNotResynced(t *testing.T) {
+	// A syncword-like pattern inside the last frame must not cut it short
+	frame := append([]byte(nil), adtsEmptyFrame...)
+	frame = append(frame, 0xFF, 0xF1, 0x50, 0x80, 0x01, 0x1F, 0xFC, 0x00)
+	frame = withADTSFrameLength(frame, len(frame))
+	data := append(append([]byte(nil), frame...), make([]byte, 128)...)
+	copy(data[len(frame):], "TAG")
+
+	br := bufio.NewReader(bytes.NewReader(data))
+	got, err := readADTSFrame(br, false)
+	if err != nil {
+		t.Fatalf("readADTSFrame failed: %v", err)
+	}
+	if !bytes.Equal(got, frame) {
+		t.Errorf("got %x, want %x", got, frame)
+	}
+}
+
+func TestDecodeFile_MultiFrame(t *testing.T) {
+	data, err := os.ReadFile("testdata/sine1k.aac")
+	if err != nil {
+		t.Skipf("sine1k.aac not available: %v", err)
+	}
+	// Wrap the stream in the ID3v2 tag and ID3v1 trailer of the fixture
+	fixture := buildMultiFrameFixture(0)
+	file := append(append(append([]byte(nil), fixture[:30]...), data...), fixture[30:]...)
+	path := writeFixture(t, file)
+
+	var out bytes.Buffer
+	cfg := NewDecoder().Config()
+	if err := DecodeFile(path, &out, cfg); err != nil {
+		t.Fatalf("DecodeFile failed: %v", err)
+	}
+
+	// Decoding the same bytes frame by frame gives the same PCM
+	d := NewDecoder()
+	if _, err := d.Init(data); err != nil {
+		t.Fatalf("Init failed: %v", err)
+	}
+	var want []int16
+	for offset := 0; offset < len(data); {
+		samples, info, err := d.Decode(data[offset:])
+		if err != nil {
+			t.Fatalf("Decode at %d failed: %v", offset, err)
+		}
+		offset += int(info.BytesConsumed)
+		want = append(want, samples.([]int16)[:info.Samples]...)
+	}
+	if len(want) == 0 {
+		t.Fatal("Decode returned no samples")
+	}
+
+	if out.Len() != 2*len(want) {
+		t.Fatalf("output length: got %d samples, want %d", out.Len()/2, len(want))
+	}
+	got := make([]int16, len(want))
+	if err := binary.Read(&out, binary.LittleEndian, got); err != nil {
+		t.Fatalf("reading output: %v", err)
+	}
+	// A window of the third frame, for a readable first difference
+	for i := 2048; i < 2048+256; i++ {
+		if got[i] != want[i] {
+			t.Fatalf("sample %d: got %d, want %d", i, got[i], want[i])
+		}
+	}
+	if !slices.Equal(got, want) {
+		t.Error("DecodeFile output differs from Decode")
+	}
+}
+
+func TestDecodeFile_NoHeader(t *testing.T) {
+	path := writeFixture(t, bytes.Repeat([]byte{0x21, 0x10}, 64))
+
+	err := DecodeFile(path, io.Discard, NewDecoder().Config())
+	if err != ErrNoHeaderDetected {
+		t.Errorf("expected ErrNoHeaderDetected, got %v", err)
+	}
+}
+
+func TestDecodeFile_Missing(t *testing.T) {
+	err := DecodeFile(filepath.Join(t.TempDir(), "missing.aac"), io.Discard, NewDecoder().Config())
+	if !os.IsNotExist(err) {
+		t.Errorf("expected not-exist error, got %v", err)
+	}
+}
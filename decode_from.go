@@ -0,0 +1,65 @@
+// decode_from.go
+package aac
+
+import (
+	"bufio"
+	"io"
+)
+
+// DecodeFrom reads the next ADTS frame from r and decodes it to 16-bit
+// PCM, so that callers streaming from a file or the network do not need
+// to frame the stream themselves.
+//
+// The first call after NewDecoder or Reset binds the decoder to r, and
+// later calls read that stream whatever reader they are passed: its
+// internal buffer may hold bytes read past the current frame, so r
+// should only be read through DecodeFrom from then on. Call Reset after
+// seeking r, or to continue with another reader.
+//
+// The decoder is initialized from the first frame unless Init was called
+// before. A leading ID3v2 tag, bytes before a syncword and ID3v1
+// trailers are skipped, and a frame that fails to decode is dropped in
+// favor of the next one. As with Decode, the first frame has no samples
+// (Samples is 0 in the FrameInfo). io.EOF is returned at the end of the
+// stream, including when it ends with a truncated frame.
+func (d *Decoder) DecodeFrom(r io.Reader) ([]int16, *FrameInfo, error) {
+	if d == nil {
+		return nil, nil, ErrNilDecoder
+	}
+	if r == nil {
+		return nil, nil, ErrNilBuffer
+	}
+
+	if d.srcBuf == nil {
+		d.srcBuf = bufio.NewReader(r)
+		if err := skipID3v2(d.srcBuf); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	for {
+		frame, err := readADTSFrame(d.srcBuf, d.config.UseOldADTSFormat)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		if !d.adtsHeaderPresent {
+			if _, err := d.Init(frame); err != nil {
+				// Not a decodable stream header, try the next frame
+				continue
+			}
+		}
+
+		samples, info, err := d.decodeInterleaved(frame, OutputFormat16Bit)
+		if err != nil {
+			// Corrupt frame, resync on the next one
+			continue
+		}
+
+		pcm, _ := samples.([]int16)
+		if info.Samples == 0 {
+			pcm = nil
+		}
+		return pcm, info, nil
+	}
+}
@@ -0,0 +1,178 @@
+// decode_from_test.go
+package aac_test
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"os"
+	"slices"
+	"testing"
+
+	"github.com/llehouerou/go-aac"
+)
+
+// chunkReader returns the data in reads of at most the given sizes,
+// cycling through them.
+type chunkReader struct {
+	data  []byte
+	sizes []int
+	n     int
+}
+
+func (c *chunkReader) Read(p []byte) (int, error) {
+	if len(c.data) == 0 {
+		return 0, io.EOF
+	}
+	size := min(c.sizes[c.n%len(c.sizes)], len(p), len(c.data))
+	c.n++
+	copy(p, c.data[:size])
+	c.data = c.data[size:]
+	return size, nil
+}
+
+// decodeAllFrom decodes r with DecodeFrom until it fails, returning the
+// non-empty frames and the terminating error.
+func decodeAllFrom(t *testing.T, r io.Reader) ([][]int16, error) {
+	t.Helper()
+	d := aac.NewDecoder()
+	cfg := d.Config()
+	cfg.NoiseGenerator = silentNoise{}
+	d.SetConfiguration(cfg)
+	var frames [][]int16
+	for {
+		samples, info, err := d.DecodeFrom(r)
+		if err != nil {
+			return frames, err
+		}
+		if info == nil {
+			t.Fatal("DecodeFrom returned nil FrameInfo")
+		}
+		if info.Samples == 0 {
+			continue
+		}
+		if len(samples) != int(info.Samples) {
+			t.Fatalf("got %d samples, FrameInfo reports %d", len(samples), info.Samples)
+		}
+		frames = append(frames, samples)
+	}
+}
+
+func TestDecodeFrom_ChunkedStream(t *testing.T) {
+	data, err := os.ReadFile("testdata/sine1k.aac")
+	if err != nil {
+		t.Skipf("sine1k.aac not available: %v", err)
+	}
+	want := decodeFrames(t, data, 1)[1:] // first frame is muted
+
+	got, err := decodeAllFrom(t, &chunkReader{data: data, sizes: []int{7, 13, 1, 301}})
+	if !errors.Is(err, io.EOF) {
+		t.Fatalf("DecodeFrom error = %v, want io.EOF", err)
+	}
+	if len(got) != len(want) {
+		t.Fatalf("decoded %d frames, want %d", len(got), len(want))
+	}
+	for i := range want {
+		if !slices.Equal(got[i], want[i]) {
+			t.Fatalf("frame %d differs from Decode", i)
+		}
+	}
+}
+
+func TestDecodeFrom_SkipsCorruptFrame(t *testing.T) {
+	data, err := os.ReadFile("testdata/sine1k.aac")
+	if err != nil {
+		t.Skipf("sine1k.aac not available: %v", err)
+	}
+	frames := splitMonoFrames(t, data)
+	if len(frames) < 4 {
+		t.Fatalf("only %d frames in test stream", len(frames))
+	}
+
+	// Replace the SCE of one frame with a coupling channel element, which
+	// Decode rejects
+	const corrupt = 2
+	var stream []byte
+	offset := 0
+	for i, f := range frames {
+		frameLen := 7 + len(f.payload)
+		frame := bytes.Clone(data[offset : offset+frameLen])
+		offset += frameLen
+		if i == corrupt {
+			w := &elementBitWriter{}
+			w.copyBits(f.payload, 0, f.sceStart)
+			w.writeBits(2, 3) // ID_CCE
+			w.copyBits(f.payload, f.sceStart+3, len(f.payload)*8)
+			copy(frame[7:], w.buf)
+		}
+		stream = append(stream, frame...)
+	}
+	// Garbage and a truncated frame at the end
+	stream = append(stream, 0x00, 0x12, 0xFF)
+	stream = append(stream, data[:20]...)
+
+	got, err := decodeAllFrom(t, &chunkReader{data: stream, sizes: []int{11}})
+	if !errors.Is(err, io.EOF) {
+		t.Fatalf("DecodeFrom error = %v, want io.EOF", err)
+	}
+	// All frames but the muted first one and the corrupt one
+	if want := len(frames) - 2; len(got) != want {
+		t.Fatalf("decoded %d frames, want %d", len(got), want)
+	}
+}
+
+func TestDecodeFrom_NilReader(t *testing.T) {
+	d := aac.NewDecoder()
+	if _, _, err := d.DecodeFrom(nil); !errors.Is(err, aac.ErrNilBuffer) {
+		t.Fatalf("DecodeFrom(nil) error = %v, want ErrNilBuffer", err)
+	}
+}
+
+// valueReader is a reader of a type that cannot be compared with ==.
+type valueReader struct {
+	r     *chunkReader
+	sizes []int
+}
+
+func (v valueReader) Read(p []byte) (int, error) { return v.r.Read(p) }
+
+func TestDecodeFrom_BoundReader(t *testing.T) {
+	data, err := os.ReadFile("testdata/sine1k.aac")
+	if err != nil {
+		t.Skipf("sine1k.aac not available: %v", err)
+	}
+	want := decodeFrames(t, data, 1)[1:]
+
+	// A reader of a non-comparable type is read until the end
+	sizes := []int{5, 97}
+	got, err := decodeAllFrom(t, valueReader{&chunkReader{data: data, sizes: sizes}, sizes})
+	if !errors.Is(err, io.EOF) {
+		t.Fatalf("DecodeFrom error = %v, want io.EOF", err)
+	}
+	if len(got) != len(want) {
+		t.Fatalf("decoded %d frames, want %d", len(got), len(want))
+	}
+
+	// Later calls read the bound stream until Reset binds another reader
+	d := aac.NewDecoder()
+	first := &chunkReader{data: data, sizes: []int{64}}
+	for range 3 {
+		if _, _, err := d.DecodeFrom(first); err != nil {
+			t.Fatalf("DecodeFrom: %v", err)
+		}
+	}
+	other := &chunkReader{data: data, sizes: []int{64}}
+	if _, _, err := d.DecodeFrom(other); err != nil {
+		t.Fatalf("DecodeFrom with another reader: %v", err)
+	}
+	if len(other.data) != len(data) {
+		t.Error("another reader was read before Reset")
+	}
+	d.Reset()
+	if _, _, err := d.DecodeFrom(other); err != nil {
+		t.Fatalf("DecodeFrom after Reset: %v", err)
+	}
+	if len(other.data) == len(data) {
+		t.Error("the reader passed after Reset was not read")
+	}
+}
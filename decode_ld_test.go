@@ -0,0 +1,184 @@
+// decode_ld_test.go
+package aac
+
+import (
+	"math"
+	"strings"
+	"testing"
+
+	"github.com/llehouerou/go-aac/internal/bits"
+	"github.com/llehouerou/go-aac/internal/huffman"
+)
+
+// ascLD is an AudioSpecificConfig for mono ER AAC LD at 48 kHz with
+// 512-sample frames.
+var ascLD = []byte{0xB9, 0x88, 0x00}
+
+// quadCodeword returns the codebook 1 codeword decoding to quad, found by
+// trying every codeword up to the longest length of the codebook.
+func quadCodeword(t testing.TB, quad [4]int16) (code uint32, length int) {
+	t.Helper()
+	for length = 1; length <= 16; length++ {
+		for code = 0; code < 1<<length; code++ {
+			v := code << (32 - length)
+			buf := []byte{byte(v >> 24), byte(v >> 16), byte(v >> 8), byte(v), 0, 0, 0, 0}
+			r := bits.NewReader(buf)
+			var sp [4]int16
+			if huffman.SpectralData(1, r, sp[:]) != nil || int(r.GetProcessedBits()) != length {
+				continue
+			}
+			if sp == quad {
+				return code, length
+			}
+		}
+	}
+	t.Fatalf("no codebook 1 codeword for %v", quad)
+	return 0, 0
+}
+
+// ldFrame returns a raw ER AAC LD mono frame: a single channel element
+// without element ID whose first eight bins, two scale factor bands at
+// 48 kHz, hold the given codebook 1 quads, using the sine window.
+func ldFrame(t *testing.T, globalGain uint32, quads [2][4]int16) []byte {
+	t.Helper()
+	w := &adifBitWriter{}
+	w.writeBits(0, 4) // element_instance_tag
+	w.writeBits(globalGain, 8)
+
+	// ics_info
+	w.writeBits(0, 1) // ics_reserved_bit
+	w.writeBits(0, 2) // window_sequence: ONLY_LONG_SEQUENCE
+	w.writeBits(0, 1) // window_shape: sine
+	w.writeBits(2, 6) // max_sfb
+	w.writeBits(0, 1) // predictor_data_present
+
+	// section_data: codebook 1 over both bands
+	w.writeBits(1, 4)
+	w.writeBits(2, 5)
+
+	// scale_factor_data: both bands at global_gain
+	w.writeBits(0, 1)
+	w.writeBits(0, 1)
+
+	w.writeBits(0, 1) // pulse_data_present
+	w.writeBits(0, 1) // tns_data_present
+	w.writeBits(0, 1) // gain_control_data_present
+
+	for _, q := range quads {
+		code, length := quadCodeword(t, q)
+		w.writeBits(code, length)
+	}
+	w.byteAlign()
+	return w.buf
+}
+
+// ldReference returns the output of an AAC-LD sine window filter bank for
+// consecutive frames of spectral coefficients, computed with the direct
+// IMDCT formula x[n] = 2/N sum X[k] cos(2pi/N (n+n0)(k+1/2)).
+func ldReference(spectra [][]float64, frameLen int) [][]float64 {
+	n := 2 * frameLen
+	n0 := (float64(frameLen) + 1) / 2
+	window := make([]float64, frameLen)
+	for i := range window {
+		window[i] = math.Sin(math.Pi / float64(n) * (float64(i) + 0.5))
+	}
+
+	overlap := make([]float64, frameLen)
+	out := make([][]float64, len(spectra))
+	for f, spec := range spectra {
+		transf := make([]float64, n)
+		for i := range transf {
+			var sum float64
+			for k, x := range spec {
+				sum += x * math.Cos(2*math.Pi/float64(n)*(float64(i)+n0)*(float64(k)+0.5))
+			}
+			transf[i] = 2 / float64(n) * sum
+		}
+		out[f] = make([]float64, frameLen)
+		for i := range out[f] {
+			out[f][i] = overlap[i] + transf[i]*window[i]
+			overlap[i] = transf[frameLen+i] * window[frameLen-1-i]
+		}
+	}
+	return out
+}
+
+func TestDecode_LD(t *testing.T) {
+	const (
+		frameLen   = 512
+		globalGain = 180
+	)
+	frameQuads := [][2][4]int16{
+		{{1, 0, 0, 0}, {0, -1, 0, 0}},
+		{{0, 0, 1, 0}, {1, 0, 0, 1}},
+	}
+
+	d := NewDecoder()
+	defer d.Close()
+	if _, err := d.Init2(ascLD); err != nil {
+		t.Fatalf("Init2: %v", err)
+	}
+	if got := d.FrameLength(); got != frameLen {
+		t.Fatalf("FrameLength() = %d, want %d", got, frameLen)
+	}
+
+	gain := math.Pow(2, float64(globalGain-100)/4)
+	spectra := make([][]float64, len(frameQuads))
+	for f, quads := range frameQuads {
+		spectra[f] = make([]float64, frameLen)
+		for i, q := range quads {
+			for j, v := range q {
+				spectra[f][4*i+j] = float64(v) * gain
+			}
+		}
+	}
+	want := ldReference(spectra, frameLen)
+
+	for f, quads := range frameQuads {
+		frame := ldFrame(t, globalGain, quads)
+		samples, info, err := d.Decode(frame)
+		if err != nil {
+			t.Fatalf("frame %d: Decode: %v", f, err)
+		}
+		if info.BytesConsumed != uint32(len(frame)) {
+			t.Errorf("frame %d: consumed %d bytes, want %d", f, info.BytesConsumed, len(frame))
+		}
+		// AAC-LD output is not muted on the first frame
+		pcm, _ := samples.([]int16)
+		if info.Samples != frameLen || len(pcm) != frameLen {
+			t.Fatalf("frame %d: %d samples (%d returned), want %d", f, info.Samples, len(pcm), frameLen)
+		}
+
+		var peak float64
+		for i, s := range pcm {
+			ref := want[f][i]
+			peak = math.Max(peak, math.Abs(ref))
+			if math.Abs(float64(s)-ref) > 1 {
+				t.Fatalf("frame %d: sample %d = %d, want %.2f", f, i, s, ref)
+			}
+		}
+		if peak < 100 {
+			t.Fatalf("frame %d: reference peak %.2f, want an audible signal", f, peak)
+		}
+	}
+}
+
+func TestDecode_LDRejectsShortWindows(t *testing.T) {
+	d := NewDecoder()
+	defer d.Close()
+	if _, err := d.Init2(ascLD); err != nil {
+		t.Fatalf("Init2: %v", err)
+	}
+
+	w := &adifBitWriter{}
+	w.writeBits(0, 4)   // element_instance_tag
+	w.writeBits(100, 8) // global_gain
+	w.writeBits(0, 1)   // ics_reserved_bit
+	w.writeBits(2, 2)   // window_sequence: EIGHT_SHORT_SEQUENCE
+	w.writeBits(0, 8)
+	w.byteAlign()
+	_, _, err := d.Decode(w.buf)
+	if err == nil || !strings.Contains(err.Error(), "only long windows") {
+		t.Errorf("Decode error = %v, want the AAC-LD window sequence error", err)
+	}
+}
@@ -0,0 +1,321 @@
+// decode_lfe_test.go
+package aac_test
+
+import (
+	"errors"
+	"math"
+	"os"
+	"testing"
+
+	"github.com/llehouerou/go-aac"
+	"github.com/llehouerou/go-aac/internal/output"
+)
+
+// writeSurround writes the elements of a 5.1 frame carrying the mono
+// channel stream in every channel: C (SCE), L/R and Ls/Rs (CPEs), LFE.
+func writeSurround(w *elementBitWriter, f *monoFrame) {
+	w.copyBits(f.payload, f.sceStart, f.icsEnd) // SCE
+	writeCPE(w, f, 0, cpeLayout{})
+	writeCPE(w, f, 1, cpeLayout{commonWindow: true})
+	w.writeBits(3, 3) // ID_LFE
+	w.writeBits(0, 4)
+	f.writeICS(w, false)
+}
+
+// TestDecode_SurroundReference decodes a 5.1 AAC-LC stream encoded by
+// FFmpeg, made by testdata/generate.go, and compares the output in WAV
+// channel order, FFmpeg's, with FFmpeg's decoding of it.
+func TestDecode_SurroundReference(t *testing.T) {
+	for _, name := range []string{"sine1k", "sweep", "speech_like"} {
+		t.Run(name, func(t *testing.T) {
+			data, ref := readReference(t, "aac_lc/48000_16_5.1_384k", name)
+			d := aac.NewDecoder()
+			cfg := d.Config()
+			cfg.ChannelOrder = aac.OrderWAV
+			d.SetConfiguration(cfg)
+			for i, info := range compareReference(t, d, data, ref, 6) {
+				if info.NumLFEChannels != 1 || info.ChannelPosition[3] != aac.ChannelLFE {
+					t.Fatalf("frame %d: %d LFE channels, channel 3 at %v; want the LFE",
+						i, info.NumLFEChannels, info.ChannelPosition[3])
+				}
+			}
+		})
+	}
+}
+
+// TestDecode_LFE decodes a 5.1 rewrite of sine1k.aac written by
+// writeSurround.
+func TestDecode_LFE(t *testing.T) {
+	data, err := os.ReadFile("testdata/sine1k.aac")
+	if err != nil {
+		t.Skipf("sine1k.aac not available: %v", err)
+	}
+	mono := decodeFrames(t, data, 1)
+
+	// Configuration 0 has no standard layout, the LFE is still placed last
+	for _, channelConfig := range []uint8{6, 0} {
+		surround := remuxMono(t, data, channelConfig, writeSurround)
+
+		d := aac.NewDecoder()
+		cfg := d.Config()
+		cfg.NoiseGenerator = silentNoise{}
+		d.SetConfiguration(cfg)
+		if _, err := d.Init(surround); err != nil {
+			t.Fatalf("config %d: Init: %v", channelConfig, err)
+		}
+		for f, offset := 0, 0; offset < len(surround); f++ {
+			samples, info, err := d.Decode(surround[offset:])
+			if err != nil {
+				t.Fatalf("config %d frame %d: %v", channelConfig, f, err)
+			}
+			offset += int(info.BytesConsumed)
+
+			if info.Channels != 6 {
+				t.Fatalf("config %d frame %d: channels = %d, want 6", channelConfig, f, info.Channels)
+			}
+			if info.NumLFEChannels != 1 || info.ChannelPosition[5] != aac.ChannelLFE {
+				t.Fatalf("config %d frame %d: LFE channels %d at position %v, want 1 at index 5",
+					channelConfig, f, info.NumLFEChannels, info.ChannelPosition[5])
+			}
+			pcm := samples.([]int16)
+			for i, m := range mono[f] {
+				if lfe := pcm[6*i+5]; lfe != m {
+					t.Fatalf("config %d frame %d sample %d: LFE %d, want %d", channelConfig, f, i, lfe, m)
+				}
+			}
+		}
+	}
+}
+
+// TestDecode_MaxChannels decodes the 5.1 stream under channel limits.
+func TestDecode_MaxChannels(t *testing.T) {
+	data, err := os.ReadFile("testdata/sine1k.aac")
+	if err != nil {
+		t.Skipf("sine1k.aac not available: %v", err)
+	}
+	surround := remuxMono(t, data, 6, writeSurround)
+
+	for _, tt := range []struct {
+		max  uint8
+		want error
+	}{
+		{2, aac.ErrChannelLimitExceeded},
+		{5, aac.ErrChannelLimitExceeded},
+		{6, nil},
+		{0, nil},
+	} {
+		d := aac.NewDecoder()
+		cfg := d.Config()
+		cfg.MaxChannels = tt.max
+		if err := d.SetConfig(cfg); err != nil {
+			t.Fatalf("MaxChannels %d: SetConfig: %v", tt.max, err)
+		}
+		if _, err := d.Init(surround); err != nil {
+			t.Fatalf("MaxChannels %d: Init: %v", tt.max, err)
+		}
+		_, info, err := d.Decode(surround)
+		if !errors.Is(err, tt.want) {
+			t.Errorf("MaxChannels %d: Decode error = %v, want %v", tt.max, err, tt.want)
+		}
+		if err == nil && info.Channels != 6 {
+			t.Errorf("MaxChannels %d: %d channels, want 6", tt.max, info.Channels)
+		}
+	}
+
+	d := aac.NewDecoder()
+	cfg := d.Config()
+	if cfg.MaxChannels != 64 {
+		t.Errorf("default MaxChannels = %d, want 64", cfg.MaxChannels)
+	}
+	cfg.MaxChannels = 65
+	if err := d.SetConfig(cfg); !errors.Is(err, aac.ErrInvalidNumChannels) {
+		t.Errorf("SetConfig(MaxChannels 65) = %v, want ErrInvalidNumChannels", err)
+	}
+}
+
+// decodeFloatFrames decodes data with cfg's output format set to float,
+// returning the interleaved samples of each frame.
+func decodeFloatFrames(t *testing.T, data []byte, cfg aac.Config) [][]float32 {
+	t.Helper()
+	d := aac.NewDecoder()
+	cfg.OutputFormat = aac.OutputFormatFloat
+	cfg.NoiseGenerator = silentNoise{}
+	d.SetConfiguration(cfg)
+	if _, err := d.Init(data); err != nil {
+		t.Fatalf("Init: %v", err)
+	}
+	var frames [][]float32
+	for offset := 0; offset < len(data); {
+		samples, info, err := d.Decode(data[offset:])
+		if err != nil {
+			t.Fatalf("frame %d: %v", len(frames), err)
+		}
+		offset += int(info.BytesConsumed)
+		frames = append(frames, samples.([]float32))
+	}
+	return frames
+}
+
+// TestDecode_DownmixLFE mixes the 5.1 rewrite of sine1k.aac down to
+// stereo with the LFE included, and compares the mix with the one of an
+// output.Downmixer fed the decoded 5.1 channels frame by frame, whose LFE
+// lowpass carries its state across frames like the decoder's.
+func TestDecode_DownmixLFE(t *testing.T) {
+	data, err := os.ReadFile("testdata/sine1k.aac")
+	if err != nil {
+		t.Skipf("sine1k.aac not available: %v", err)
+	}
+	surround := remuxMono(t, data, 6, writeSurround)
+
+	cfg := aac.NewDecoder().Config()
+	channels := decodeFloatFrames(t, surround, cfg)
+	cfg.DownMatrix = true
+	excluded := decodeFloatFrames(t, surround, cfg)
+	cfg.IncludeLFE = true
+
+	for _, tt := range []struct{ gain, cutoff float32 }{
+		{0, 0},    // output.DefaultLFEGain and DefaultLFECutoff
+		{0.5, 80}, // explicit gain and cutoff
+		{0.5, -1}, // unfiltered
+	} {
+		gain := tt.gain
+		cfg.LFEGain = tt.gain
+		cfg.LFECutoff = tt.cutoff
+		mixed := decodeFloatFrames(t, surround, cfg)
+		dm := output.NewDownmixer()
+		dm.IncludeLFE = true
+		dm.SampleRate = 44100
+		if tt.gain != 0 {
+			dm.LFEGain = tt.gain
+		}
+		switch {
+		case tt.cutoff > 0:
+			dm.LFECutoff = tt.cutoff
+		case tt.cutoff < 0:
+			dm.LFECutoff = 0
+		}
+
+		lfeHeard := false
+		for f, in := range channels {
+			if len(mixed[f]) != len(in)/3 {
+				t.Fatalf("gain %v frame %d: %d samples, want %d", gain, f, len(mixed[f]), len(in)/3)
+			}
+			frameLen := uint16(len(in) / 6)
+			wantL, wantR := dm.DownmixFrame(output.Deinterleave(in, 6), []uint8{0, 1, 2, 3, 4, 5}, frameLen)
+			for i := range int(frameLen) {
+				gotL, gotR := mixed[f][2*i], mixed[f][2*i+1]
+				if math.Abs(float64(gotL-wantL[i])) > 1e-6 || math.Abs(float64(gotR-wantR[i])) > 1e-6 {
+					t.Fatalf("gain %v frame %d sample %d: got (%v, %v), want (%v, %v)",
+						gain, f, i, gotL, gotR, wantL[i], wantR[i])
+				}
+				if gotL != excluded[f][2*i] {
+					lfeHeard = true
+				}
+			}
+		}
+		if !lfeHeard {
+			t.Errorf("gain %v: mix is the same as without the LFE", gain)
+		}
+	}
+}
+
+// TestDecode_Downmix71 mixes a 7.1 rewrite of sine1k.aac down to stereo
+// and compares the mix with the one of an output.Downmixer fed the
+// decoded 7.1 channels.
+func TestDecode_Downmix71(t *testing.T) {
+	data, err := os.ReadFile("testdata/sine1k.aac")
+	if err != nil {
+		t.Skipf("sine1k.aac not available: %v", err)
+	}
+	// C (SCE), L/R, Ls/Rs and Lrs/Rrs (CPEs), LFE
+	surround := remuxMono(t, data, 7, func(w *elementBitWriter, f *monoFrame) {
+		w.copyBits(f.payload, f.sceStart, f.icsEnd)
+		writeCPE(w, f, 0, cpeLayout{})
+		writeCPE(w, f, 1, cpeLayout{commonWindow: true})
+		writeCPE(w, f, 2, cpeLayout{})
+		w.writeBits(3, 3) // ID_LFE
+		w.writeBits(0, 4)
+		f.writeICS(w, false)
+	})
+
+	cfg := aac.NewDecoder().Config()
+	channels := decodeFloatFrames(t, surround, cfg)
+	cfg.DownMatrix = true
+	cfg.IncludeLFE = true
+	mixed := decodeFloatFrames(t, surround, cfg)
+
+	dm := output.NewDownmixer()
+	dm.IncludeLFE = true
+	dm.SampleRate = 44100
+	for f, in := range channels {
+		if len(mixed[f]) != len(in)/4 {
+			t.Fatalf("frame %d: %d samples, want %d", f, len(mixed[f]), len(in)/4)
+		}
+		frameLen := uint16(len(in) / 8)
+		wantL, wantR := dm.DownmixFrame(output.Deinterleave(in, 8), []uint8{0, 1, 2, 3, 4, 5, 6, 7}, frameLen)
+		for i := range int(frameLen) {
+			gotL, gotR := mixed[f][2*i], mixed[f][2*i+1]
+			if math.Abs(float64(gotL-wantL[i])) > 1e-6 || math.Abs(float64(gotR-wantR[i])) > 1e-6 {
+				t.Fatalf("frame %d sample %d: got (%v, %v), want (%v, %v)",
+					f, i, gotL, gotR, wantL[i], wantR[i])
+			}
+		}
+	}
+}
+
+// TestDecode_HighPrecisionDownmix mixes the 5.1 rewrite of sine1k.aac
+// down to double stereo, and checks that HighPrecisionDownmix computes
+// the matrix in float64 from the decoded channels.
+func TestDecode_HighPrecisionDownmix(t *testing.T) {
+	data, err := os.ReadFile("testdata/sine1k.aac")
+	if err != nil {
+		t.Skipf("sine1k.aac not available: %v", err)
+	}
+	surround := remuxMono(t, data, 6, writeSurround)
+
+	decode := func(cfg aac.Config) [][]float64 {
+		d := aac.NewDecoder()
+		cfg.OutputFormat = aac.OutputFormatDouble
+		cfg.NoiseGenerator = silentNoise{}
+		d.SetConfiguration(cfg)
+		if _, err := d.Init(surround); err != nil {
+			t.Fatalf("Init: %v", err)
+		}
+		var frames [][]float64
+		for offset := 0; offset < len(surround); {
+			samples, info, err := d.Decode(surround[offset:])
+			if err != nil {
+				t.Fatalf("frame %d: %v", len(frames), err)
+			}
+			offset += int(info.BytesConsumed)
+			frames = append(frames, samples.([]float64))
+		}
+		return frames
+	}
+	cfg := aac.NewDecoder().Config()
+	channels := decode(cfg)
+	cfg.DownMatrix = true
+	plain := decode(cfg)
+	cfg.HighPrecisionDownmix = true
+	precise := decode(cfg)
+
+	dmMul := 1 / (1 + math.Sqrt2 + 1/math.Sqrt2)
+	differs := false
+	for f, in := range channels {
+		for i := 0; i < len(in)/6; i++ {
+			c, l, r, ls, rs := in[6*i], in[6*i+1], in[6*i+2], in[6*i+3], in[6*i+4]
+			wantL := dmMul * (l + c/math.Sqrt2 + ls/math.Sqrt2)
+			wantR := dmMul * (r + c/math.Sqrt2 + rs/math.Sqrt2)
+			gotL, gotR := precise[f][2*i], precise[f][2*i+1]
+			if math.Abs(gotL-wantL) > 1e-12 || math.Abs(gotR-wantR) > 1e-12 {
+				t.Fatalf("frame %d sample %d: got (%v, %v), want (%v, %v)", f, i, gotL, gotR, wantL, wantR)
+			}
+			if gotL != plain[f][2*i] {
+				differs = true
+			}
+		}
+	}
+	if !differs {
+		t.Error("HighPrecisionDownmix output is the same as the float32 mix")
+	}
+}
@@ -0,0 +1,186 @@
+// decode_ltp_test.go
+package aac
+
+import (
+	"math"
+	"testing"
+)
+
+// ascLTP is an AudioSpecificConfig for stereo AAC LTP at 48 kHz.
+var ascLTP = []byte{0x21, 0x90}
+
+// ltpTestLag and ltpTestCoef are the LTP parameters of the second channel
+// in ltpPairFrame.
+const (
+	ltpTestLag  = 1000
+	ltpTestCoef = 3 // 0.911304 in the LTP codebook
+	ltpTestSFB  = 8 // bands of the predicted frame, bins 0-31 at 48 kHz
+)
+
+// ltpPairFrame returns a raw stereo AAC LTP frame with a common window.
+// With tone set, both channels carry a codebook 1 coefficient in bin 10;
+// otherwise their ltpTestSFB bands are zero, and with ltp set the second
+// channel predicts them all from its history through the second LTP data
+// of the shared ics_info, the first channel sending no LTP data.
+func ltpPairFrame(t *testing.T, tone, ltp bool) []byte {
+	t.Helper()
+	maxSFB := uint32(3)
+	if !tone {
+		maxSFB = ltpTestSFB
+	}
+
+	w := &adifBitWriter{}
+	w.writeBits(1, 3) // ID_CPE
+	w.writeBits(0, 4) // element_instance_tag
+	w.writeBits(1, 1) // common_window
+
+	// ics_info
+	w.writeBits(0, 1) // ics_reserved_bit
+	w.writeBits(0, 2) // window_sequence: ONLY_LONG_SEQUENCE
+	w.writeBits(0, 1) // window_shape: sine
+	w.writeBits(maxSFB, 6)
+	if ltp {
+		w.writeBits(1, 1) // predictor_data_present
+		w.writeBits(0, 1) // ltp_data_present (first channel)
+		w.writeBits(1, 1) // ltp_data_present (second channel)
+		w.writeBits(ltpTestLag, 11)
+		w.writeBits(ltpTestCoef, 3)
+		for range maxSFB {
+			w.writeBits(1, 1) // ltp_long_used
+		}
+	} else {
+		w.writeBits(0, 1) // predictor_data_present
+	}
+
+	w.writeBits(0, 2) // ms_mask_present
+
+	for range 2 {
+		w.writeBits(180, 8) // global_gain
+		if tone {
+			// section_data: codebook 1 over all bands, scale factors at
+			// global_gain
+			w.writeBits(1, 4)
+			w.writeBits(maxSFB, 5)
+			for range maxSFB {
+				w.writeBits(0, 1)
+			}
+		} else {
+			// section_data: ZERO_HCB over all bands, no scale factors
+			w.writeBits(0, 4)
+			w.writeBits(maxSFB, 5)
+		}
+		w.writeBits(0, 1) // pulse_data_present
+		w.writeBits(0, 1) // tns_data_present
+		w.writeBits(0, 1) // gain_control_data_present
+		if tone {
+			for _, q := range [][4]int16{{0, 0, 0, 0}, {0, 0, 0, 0}, {0, 0, 1, 0}} {
+				code, length := quadCodeword(t, q)
+				w.writeBits(code, length)
+			}
+		}
+	}
+
+	w.writeBits(7, 3) // ID_END
+	w.byteAlign()
+	return w.buf
+}
+
+// decodeLTPPair decodes the frames with a new LTP decoder and returns the
+// PCM of every frame, split into channels.
+func decodeLTPPair(t *testing.T, frames [][]byte) [][2][]float64 {
+	t.Helper()
+	d := NewDecoder()
+	defer d.Close()
+	if _, err := d.Init2(ascLTP); err != nil {
+		t.Fatalf("Init2: %v", err)
+	}
+
+	out := make([][2][]float64, len(frames))
+	for f, frame := range frames {
+		samples, info, err := d.Decode(frame)
+		if err != nil {
+			t.Fatalf("frame %d: Decode: %v", f, err)
+		}
+		if info.Samples == 0 {
+			continue
+		}
+		pcm, _ := samples.([]int16)
+		for i, s := range pcm {
+			out[f][i%2] = append(out[f][i%2], float64(s))
+		}
+	}
+	return out
+}
+
+// TestDecode_LTPCommonWindow decodes a tone, then a frame whose spectrum
+// is entirely predicted for the second channel, and checks that channel
+// against a prediction computed with the direct MDCT formulas from the
+// history visible in the output: the time samples of the two previous
+// frames and the overlap they left, which is the whole output of an
+// unpredicted silent frame.
+func TestDecode_LTPCommonWindow(t *testing.T) {
+	const n = 1024
+
+	tone := ltpPairFrame(t, true, false)
+	history := decodeLTPPair(t, [][]byte{tone, tone, tone, ltpPairFrame(t, false, false)})
+	got := decodeLTPPair(t, [][]byte{tone, tone, tone, ltpPairFrame(t, false, true)})
+
+	// The first channel has no LTP data
+	for i := range n {
+		if got[3][0][i] != history[3][0][i] {
+			t.Fatalf("first channel sample %d = %v, want %v without prediction", i, got[3][0][i], history[3][0][i])
+		}
+	}
+
+	// lt_pred_stat: two frames of time samples, then the overlap
+	state := make([]float64, 4*n)
+	copy(state, history[1][1])
+	copy(state[n:], history[2][1])
+	copy(state[2*n:], history[3][1])
+
+	window := make([]float64, n)
+	for i := range window {
+		window[i] = math.Sin(math.Pi / (2 * n) * (float64(i) + 0.5))
+	}
+	n0 := (float64(n) + 1) / 2
+	basis := func(i, k int) float64 {
+		return math.Cos(2 * math.Pi / (2 * n) * (float64(i) + n0) * (float64(k) + 0.5))
+	}
+
+	// Windowed estimate and its MDCT, kept in the predicted bands. The
+	// forward transform is scaled by 2 so that it inverts the decoder's
+	// IMDCT, as filter_bank_ltp() does ifilter_bank().
+	const coef = 0.911304
+	xEst := make([]float64, 2*n)
+	for i := range xEst {
+		w := window[i%n]
+		if i >= n {
+			w = window[2*n-1-i]
+		}
+		xEst[i] = state[2*n+i-ltpTestLag] * coef * w
+	}
+	const bins = 32
+	spec := make([]float64, bins)
+	for k := range spec {
+		for i, x := range xEst {
+			spec[k] += 2 * x * basis(i, k)
+		}
+	}
+
+	var peak float64
+	for i := range n {
+		var y float64
+		for k, x := range spec {
+			y += x * basis(i, k)
+		}
+		pred := 2.0 / (2 * n) * y * window[i]
+		peak = math.Max(peak, math.Abs(pred))
+		want := history[3][1][i] + pred
+		if math.Abs(got[3][1][i]-want) > 2 {
+			t.Fatalf("second channel sample %d = %v, want %.2f", i, got[3][1][i], want)
+		}
+	}
+	if peak < 100 {
+		t.Errorf("prediction peak %.2f, want an audible contribution", peak)
+	}
+}
@@ -0,0 +1,153 @@
+// decode_main_test.go
+package aac_test
+
+import (
+	"os"
+	"slices"
+	"testing"
+
+	"github.com/llehouerou/go-aac"
+	"github.com/llehouerou/go-aac/internal/tables"
+)
+
+// remuxMain rebuilds the mono AAC-LC ADTS stream data as AAC Main. With
+// predict set, every long window frame sends prediction data using all
+// the bands it may predict; otherwise predictor_data_present stays 0, as
+// in the LC stream.
+func remuxMain(t *testing.T, data []byte, predict bool) []byte {
+	t.Helper()
+	limit := tables.MaxPredSFB(4)
+	var out []byte
+	for _, f := range splitMonoFrames(t, data) {
+		w := &elementBitWriter{}
+		if f.ics.WindowSequence == 2 { // EIGHT_SHORT_SEQUENCE
+			w.copyBits(f.payload, 0, len(f.payload)*8)
+		} else {
+			// predictor_data_present is the last bit of the LC ics_info
+			w.copyBits(f.payload, 0, f.infoEnd-1)
+			if predict {
+				w.writeBits(1, 1) // predictor_data_present
+				w.writeBits(0, 1) // predictor_reset
+				for range min(f.ics.MaxSFB, limit) {
+					w.writeBits(1, 1) // prediction_used
+				}
+			} else {
+				w.writeBits(0, 1)
+			}
+			w.copyBits(f.payload, f.infoEnd, len(f.payload)*8)
+		}
+
+		header, err := aac.BuildADTSHeader(aac.ADTSConfig{
+			ObjectType:           aac.ObjectTypeMain,
+			SFIndex:              4,
+			ChannelConfiguration: 1,
+			BufferFullness:       0x7FF,
+		}, len(w.buf))
+		if err != nil {
+			t.Fatalf("BuildADTSHeader: %v", err)
+		}
+		out = append(out, header...)
+		out = append(out, w.buf...)
+	}
+	return out
+}
+
+// TestDecode_MainPrediction decodes sine1k.aac remuxed as AAC Main. The
+// predictors run on every long frame, but only change the output of the
+// bands that use them.
+func TestDecode_MainPrediction(t *testing.T) {
+	data, err := os.ReadFile("testdata/sine1k.aac")
+	if err != nil {
+		t.Skipf("sine1k.aac not available: %v", err)
+	}
+	lc := decodeFrames(t, data, 1)
+
+	// Without prediction data the predictors are updated but unused
+	plain := decodeFrames(t, remuxMain(t, data, false), 1)
+	if len(plain) != len(lc) {
+		t.Fatalf("Main decoded %d frames, LC %d", len(plain), len(lc))
+	}
+	for i := range lc {
+		if !slices.Equal(plain[i], lc[i]) {
+			t.Fatalf("frame %d: Main without prediction differs from LC", i)
+		}
+	}
+
+	// With every band predicted, the prediction adds to the spectra from
+	// the second long frame on
+	predicted := decodeFrames(t, remuxMain(t, data, true), 1)
+	if len(predicted) != len(lc) {
+		t.Fatalf("predicted Main decoded %d frames, LC %d", len(predicted), len(lc))
+	}
+	changed := 0
+	for i := range lc {
+		if !slices.Equal(predicted[i], lc[i]) {
+			changed++
+		}
+	}
+	if changed == 0 {
+		t.Error("prediction did not change the output")
+	}
+
+	// The predictor state carries across frames alike in every decoder
+	again := decodeFrames(t, remuxMain(t, data, true), 1)
+	for i := range predicted {
+		if !slices.Equal(again[i], predicted[i]) {
+			t.Fatalf("frame %d: a second decoder predicted differently", i)
+		}
+	}
+}
+
+// TestDecoder_Reset_MainPredictors checks that Reset restarts the MAIN
+// predictors: after a seek, a predicted stream decodes as it does from a
+// new decoder starting at the seek point.
+func TestDecoder_Reset_MainPredictors(t *testing.T) {
+	data, err := os.ReadFile("testdata/sine1k.aac")
+	if err != nil {
+		t.Skipf("sine1k.aac not available: %v", err)
+	}
+	main := remuxMain(t, data, true)
+	var frames [][]byte
+	for off := 0; off+7 <= len(main); {
+		n := int(main[off+3]&0x03)<<11 | int(main[off+4])<<3 | int(main[off+5]>>5)
+		frames = append(frames, main[off:off+n])
+		off += n
+	}
+	const seekTo = 5
+	if len(frames) < seekTo+3 {
+		t.Fatalf("only %d frames in test stream", len(frames))
+	}
+
+	newDecoder := func(first []byte) *aac.Decoder {
+		d := aac.NewDecoder()
+		cfg := d.Config()
+		cfg.NoiseGenerator = silentNoise{}
+		d.SetConfiguration(cfg)
+		if _, err := d.Init(first); err != nil {
+			t.Fatalf("Init: %v", err)
+		}
+		return d
+	}
+	d := newDecoder(main)
+	for i, frame := range frames[:seekTo] {
+		if _, _, err := d.Decode(frame); err != nil {
+			t.Fatalf("frame %d: %v", i, err)
+		}
+	}
+	d.Reset()
+
+	fresh := newDecoder(frames[seekTo])
+	for i, frame := range frames[seekTo:] {
+		got, _, err := d.Decode(frame)
+		if err != nil {
+			t.Fatalf("frame %d after Reset: %v", seekTo+i, err)
+		}
+		want, _, err := fresh.Decode(frame)
+		if err != nil {
+			t.Fatalf("frame %d, new decoder: %v", seekTo+i, err)
+		}
+		if !slices.Equal(got.([]int16), want.([]int16)) {
+			t.Fatalf("frame %d after Reset differs from a new decoder", seekTo+i)
+		}
+	}
+}
@@ -0,0 +1,90 @@
+// decode_mp4.go
+package aac
+
+import (
+	"errors"
+	"io"
+
+	"github.com/llehouerou/go-aac/internal/mp4"
+)
+
+// DecodeMP4 decodes the next sample of the AAC track of an MP4/M4A file
+// to 16-bit PCM.
+//
+// The first call after NewDecoder or Reset binds the decoder to r: it
+// reads the file's moov box, locates the first AAC sound track and
+// initializes the decoder from its AudioSpecificConfig. Later calls
+// decode the samples of that file in order whatever reader they are
+// passed; call Reset to start on another file. Only unfragmented files
+// are supported.
+//
+// As with Decode, the first sample has no output (Samples is 0 in the
+// FrameInfo). io.EOF is returned after the last sample. A sample that
+// fails to decode returns its error, and the next call moves on to the
+// following sample.
+func (d *Decoder) DecodeMP4(r io.ReadSeeker) ([]int16, *FrameInfo, error) {
+	if d == nil {
+		return nil, nil, ErrNilDecoder
+	}
+	if r == nil {
+		return nil, nil, ErrNilBuffer
+	}
+
+	if d.mp4Track == nil {
+		track, err := mp4.ReadAudioTrack(r)
+		if err != nil {
+			return nil, nil, mp4Error(err)
+		}
+		if _, err := d.Init2(track.ASC); err != nil {
+			return nil, nil, err
+		}
+		d.mp4Src, d.mp4Track, d.mp4Sample = r, track, 0
+	}
+
+	if d.mp4Sample >= len(d.mp4Track.Samples) {
+		return nil, nil, io.EOF
+	}
+	s := d.mp4Track.Samples[d.mp4Sample]
+	d.mp4Sample++
+
+	if cap(d.mp4Buf) < int(s.Size) {
+		d.mp4Buf = make([]byte, s.Size)
+	}
+	buf := d.mp4Buf[:s.Size]
+	if _, err := d.mp4Src.Seek(s.Offset, io.SeekStart); err != nil {
+		return nil, nil, err
+	}
+	if _, err := io.ReadFull(d.mp4Src, buf); err != nil {
+		if errors.Is(err, io.ErrUnexpectedEOF) || errors.Is(err, io.EOF) {
+			// The sample table points past the end of the file
+			return nil, nil, io.EOF
+		}
+		return nil, nil, err
+	}
+
+	samples, info, err := d.decodeInterleaved(buf, OutputFormat16Bit)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	pcm, _ := samples.([]int16)
+	if info.Samples == 0 {
+		pcm = nil
+	}
+	return pcm, info, nil
+}
+
+// mp4Error maps the container errors to decoder error codes, passing
+// I/O errors through.
+func mp4Error(err error) error {
+	switch {
+	case errors.Is(err, mp4.ErrNoAudioTrack):
+		return ErrMP4NoAudioTrack
+	case errors.Is(err, mp4.ErrFragmented):
+		return ErrMP4Fragmented
+	case errors.Is(err, mp4.ErrInvalid):
+		return ErrMP4Invalid
+	default:
+		return err
+	}
+}
@@ -0,0 +1,162 @@
+// decode_mp4_test.go
+package aac
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"io"
+	"os"
+	"slices"
+	"testing"
+)
+
+// mp4Box returns an MP4 box of type typ around the concatenated payloads.
+func mp4Box(typ string, payloads ...[]byte) []byte {
+	body := bytes.Join(payloads, nil)
+	b := binary.BigEndian.AppendUint32(nil, uint32(8+len(body)))
+	b = append(b, typ...)
+	return append(b, body...)
+}
+
+func mp4U32(vs ...uint32) []byte {
+	var b []byte
+	for _, v := range vs {
+		b = binary.BigEndian.AppendUint32(b, v)
+	}
+	return b
+}
+
+// buildM4A returns an M4A file holding samples, one per chunk, with the
+// moov box after the media data.
+func buildM4A(asc []byte, samples [][]byte) []byte {
+	ftyp := mp4Box("ftyp", []byte("M4A "), mp4U32(0))
+	offset := uint32(len(ftyp) + 8)
+	var mdat []byte
+	var offsets, sizes []uint32
+	for _, s := range samples {
+		offsets = append(offsets, offset+uint32(len(mdat)))
+		sizes = append(sizes, uint32(len(s)))
+		mdat = append(mdat, s...)
+	}
+
+	dsi := append([]byte{0x05, byte(len(asc))}, asc...)
+	dcd := append([]byte{0x04, byte(13 + len(dsi)), 0x40, 0x15}, make([]byte, 11)...)
+	es := append([]byte{0, 1, 0}, append(dcd, dsi...)...)
+	esds := mp4Box("esds", mp4U32(0), []byte{0x03, byte(len(es))}, es)
+	entry := make([]byte, 28)
+	entry[7] = 1
+	stbl := mp4Box("stbl",
+		mp4Box("stsd", mp4U32(0, 1), mp4Box("mp4a", entry, esds)),
+		mp4Box("stsc", mp4U32(0, 1, 1, 1, 1)),
+		mp4Box("stsz", mp4U32(0, 0, uint32(len(samples))), mp4U32(sizes...)),
+		mp4Box("stco", mp4U32(0, uint32(len(offsets))), mp4U32(offsets...)),
+	)
+	trak := mp4Box("trak", mp4Box("mdia",
+		mp4Box("hdlr", mp4U32(0, 0), []byte("soun"), make([]byte, 13)),
+		mp4Box("minf", stbl),
+	))
+	return bytes.Join([][]byte{ftyp, mp4Box("mdat", mdat), mp4Box("moov", trak)}, nil)
+}
+
+func TestDecodeMP4(t *testing.T) {
+	data, err := os.ReadFile("testdata/sine1k.aac")
+	if err != nil {
+		t.Skipf("sine1k.aac not available: %v", err)
+	}
+	ref := NewDecoder()
+	if _, err := ref.Init(data); err != nil {
+		t.Fatalf("Init ADTS: %v", err)
+	}
+	want, _ := decodeAll(t, ref, data)
+	file := buildM4A(sine1kASC, adtsPayloads(t, data))
+
+	d := NewDecoder()
+	d.config.OutputFormat = OutputFormatFloat // DecodeMP4 outputs 16-bit regardless
+	r := bytes.NewReader(file)
+	for i := range want {
+		got, info, err := d.DecodeMP4(r)
+		if err != nil {
+			t.Fatalf("sample %d: DecodeMP4: %v", i, err)
+		}
+		if i == 0 && (info.Samples != 0 || got != nil) {
+			t.Errorf("first sample: got %d samples, want none", info.Samples)
+		}
+		if i > 0 && !slices.Equal(got, want[i]) {
+			t.Fatalf("sample %d differs from the ADTS decode", i)
+		}
+	}
+	if _, _, err := d.DecodeMP4(r); !errors.Is(err, io.EOF) {
+		t.Errorf("after the last sample: got %v, want io.EOF", err)
+	}
+	if d.config.OutputFormat != OutputFormatFloat {
+		t.Error("DecodeMP4 changed the configured output format")
+	}
+	if d.SampleRate() != 44100 || d.Channels() != 1 {
+		t.Errorf("stream parameters %d Hz, %d channels", d.SampleRate(), d.Channels())
+	}
+}
+
+func TestDecodeMP4_Errors(t *testing.T) {
+	d := NewDecoder()
+	if _, _, err := d.DecodeMP4(nil); !errors.Is(err, ErrNilBuffer) {
+		t.Errorf("nil reader: got %v, want ErrNilBuffer", err)
+	}
+
+	video := mp4Box("moov", mp4Box("trak", mp4Box("mdia",
+		mp4Box("hdlr", mp4U32(0, 0), []byte("vide"), make([]byte, 13)))))
+	if _, _, err := d.DecodeMP4(bytes.NewReader(video)); !errors.Is(err, ErrMP4NoAudioTrack) {
+		t.Errorf("video only: got %v, want ErrMP4NoAudioTrack", err)
+	}
+
+	fragmented := append(buildM4A(sine1kASC, [][]byte{{0}}), mp4Box("moof")...)
+	if _, _, err := d.DecodeMP4(bytes.NewReader(fragmented)); !errors.Is(err, ErrMP4Fragmented) {
+		t.Errorf("fragmented: got %v, want ErrMP4Fragmented", err)
+	}
+}
+
+// valueReadSeeker is a file of a type that cannot be compared with ==.
+type valueReadSeeker struct {
+	r    *bytes.Reader
+	tags []string
+}
+
+func (v valueReadSeeker) Read(p []byte) (int, error) { return v.r.Read(p) }
+
+func (v valueReadSeeker) Seek(offset int64, whence int) (int64, error) {
+	return v.r.Seek(offset, whence)
+}
+
+func TestDecodeMP4_BoundReader(t *testing.T) {
+	data, err := os.ReadFile("testdata/sine1k.aac")
+	if err != nil {
+		t.Skipf("sine1k.aac not available: %v", err)
+	}
+	file := buildM4A(sine1kASC, adtsPayloads(t, data))
+
+	d := NewDecoder()
+	r := valueReadSeeker{r: bytes.NewReader(file)}
+	for i := range 3 {
+		if _, _, err := d.DecodeMP4(r); err != nil {
+			t.Fatalf("sample %d: %v", i, err)
+		}
+	}
+	if d.mp4Sample != 3 {
+		t.Errorf("next sample %d, want 3", d.mp4Sample)
+	}
+
+	// Another reader continues the bound file, Reset starts over
+	if _, _, err := d.DecodeMP4(bytes.NewReader(file)); err != nil {
+		t.Fatalf("DecodeMP4 with another reader: %v", err)
+	}
+	if d.mp4Sample != 4 {
+		t.Errorf("next sample %d after another reader, want 4", d.mp4Sample)
+	}
+	d.Reset()
+	if _, _, err := d.DecodeMP4(bytes.NewReader(file)); err != nil {
+		t.Fatalf("DecodeMP4 after Reset: %v", err)
+	}
+	if d.mp4Sample != 1 {
+		t.Errorf("next sample %d after Reset, want 1", d.mp4Sample)
+	}
+}
@@ -1,6 +1,9 @@
 package aac
 
-import "testing"
+import (
+	"os"
+	"testing"
+)
 
 // mockFilterBank is a minimal mock for testing filter bank initialization.
 type mockFilterBank struct {
@@ -113,6 +116,69 @@ func TestDecoder_Decode_ADTSHeaderParsed(t *testing.T) {
 	}
 }
 
+func TestDecoder_Decode_ADTSFrameLengthPadding(t *testing.T) {
+	// The raw_data_block (ID_END) ends after 1 byte, but frame_length also
+	// covers 4 bytes of padding. Decoding must advance past the padding so
+	// that the next frame starts on its syncword.
+	cfg := ADTSConfig{
+		ObjectType:           ObjectTypeLC,
+		SFIndex:              4,
+		ChannelConfiguration: 2,
+		BufferFullness:       0x7FF,
+	}
+	payload := []byte{0xE0, 0x00, 0x00, 0x00, 0x00}
+	header, err := BuildADTSHeader(cfg, len(payload))
+	if err != nil {
+		t.Fatalf("BuildADTSHeader failed: %v", err)
+	}
+	padded := append(header, payload...)
+
+	var stream []byte
+	stream = append(stream, padded...)
+	stream = append(stream, adtsEmptyFrame...)
+	stream = append(stream, padded...)
+
+	d := NewDecoder()
+	if _, err := d.Init(stream); err != nil {
+		t.Fatalf("Init failed: %v", err)
+	}
+
+	wantConsumed := []uint32{uint32(len(padded)), uint32(len(adtsEmptyFrame)), uint32(len(padded))}
+	pos := 0
+	for i, want := range wantConsumed {
+		_, info, err := d.Decode(stream[pos:])
+		if err != nil {
+			t.Fatalf("frame %d: Decode failed: %v", i, err)
+		}
+		if info.BytesConsumed != want {
+			t.Errorf("frame %d: BytesConsumed: got %d, want %d", i, info.BytesConsumed, want)
+		}
+		pos += int(info.BytesConsumed)
+	}
+	if pos != len(stream) {
+		t.Errorf("advanced %d bytes, want %d", pos, len(stream))
+	}
+}
+
+func TestDecoder_Decode_ADTSFrameLengthTruncated(t *testing.T) {
+	// frame_length declares 16 bytes but only 8 are available: the
+	// advance is clamped to the buffer.
+	frame := append([]byte(nil), adtsEmptyFrame...)
+	frame[4] = 0x02
+
+	d := NewDecoder()
+	if _, err := d.Init(frame); err != nil {
+		t.Fatalf("Init failed: %v", err)
+	}
+	_, info, err := d.Decode(frame)
+	if err != nil {
+		t.Fatalf("Decode failed: %v", err)
+	}
+	if info.BytesConsumed != uint32(len(frame)) {
+		t.Errorf("BytesConsumed: got %d, want %d", info.BytesConsumed, len(frame))
+	}
+}
+
 func TestDecoder_Decode_ADIFHeaderType(t *testing.T) {
 	d := NewDecoder()
 	// Manually set ADIF mode (since ADIF init is not fully implemented)
@@ -398,3 +464,307 @@ func TestDecoder_DecodeFloat32_ReturnsFloat32Slice(t *testing.T) {
 	_ = err
 	// Method exists with correct signature - that's what we're testing
 }
+
+// tappingFilterBank implements IFilterBank and SetMDCTTap, invoking the
+// installed tap with the first half of freqIn.
+type tappingFilterBank struct {
+	tap func(coeffs []float32)
+}
+
+func (fb *tappingFilterBank) SetMDCTTap(tap func(coeffs []float32)) {
+	fb.tap = tap
+}
+
+func (fb *tappingFilterBank) IFilterBank(_, _, _ uint8, freqIn, _, _ []float32) {
+	if fb.tap != nil {
+		fb.tap(freqIn[:len(freqIn)/2])
+	}
+}
+
+func TestDecoder_ApplyFilterBank_MDCTTap(t *testing.T) {
+	d := NewDecoder()
+	fb := &tappingFilterBank{}
+	d.fb = fb
+	if err := d.allocateChannelBuffers(2); err != nil {
+		t.Fatalf("allocateChannelBuffers failed: %v", err)
+	}
+
+	var gotChannel int
+	var gotLen int
+	d.config.MDCTTap = func(channel int, coeffs []float32) {
+		gotChannel = channel
+		gotLen = len(coeffs)
+	}
+
+	spec := make([]float32, d.frameLength)
+	if err := d.applyFilterBank(spec, 1, 0, 0); err != nil {
+		t.Fatalf("applyFilterBank failed: %v", err)
+	}
+	if gotChannel != 1 {
+		t.Errorf("tap channel: got %d, want 1", gotChannel)
+	}
+	if gotLen != int(d.frameLength)/2 {
+		t.Errorf("tap length: got %d, want %d", gotLen, d.frameLength/2)
+	}
+	if fb.tap != nil {
+		t.Error("tap should be removed after the filter bank call")
+	}
+}
+
+func TestDecoder_ApplyFilterBank_NoMDCTTap(t *testing.T) {
+	d := NewDecoder()
+	fb := &tappingFilterBank{}
+	d.fb = fb
+	if err := d.allocateChannelBuffers(1); err != nil {
+		t.Fatalf("allocateChannelBuffers failed: %v", err)
+	}
+
+	spec := make([]float32, d.frameLength)
+	if err := d.applyFilterBank(spec, 0, 0, 0); err != nil {
+		t.Fatalf("applyFilterBank failed: %v", err)
+	}
+	if fb.tap != nil {
+		t.Error("no tap should be installed when Config.MDCTTap is nil")
+	}
+}
+
+// ldFilterBank records which inverse filter bank path was taken.
+type ldFilterBank struct {
+	ldCalls, gaCalls int
+	freqLen          int
+}
+
+func (fb *ldFilterBank) IFilterBank(_, _, _ uint8, _, _, _ []float32) {
+	fb.gaCalls++
+}
+
+func (fb *ldFilterBank) IFilterBankLD(_, _ uint8, freqIn, _, _ []float32) {
+	fb.ldCalls++
+	fb.freqLen = len(freqIn)
+}
+
+func TestDecoder_Init2_LDFrameLength(t *testing.T) {
+	originalFactory := filterBankFactory
+	RegisterFilterBankFactory(testFilterBankFactory)
+	defer func() { filterBankFactory = originalFactory }()
+
+	d := NewDecoder()
+	// ER AAC LD (23), 48 kHz, mono
+	if _, err := d.Init2([]byte{0xB9, 0x88}); err != nil {
+		t.Fatalf("Init2 failed: %v", err)
+	}
+	if d.FrameLength() != 512 {
+		t.Errorf("FrameLength: got %d, want 512", d.FrameLength())
+	}
+	mock, ok := d.fb.(*mockFilterBank)
+	if !ok {
+		t.Fatalf("fb: got %T, want *mockFilterBank", d.fb)
+	}
+	if mock.frameLength != 1024 {
+		t.Errorf("filter bank frame length: got %d, want 1024", mock.frameLength)
+	}
+
+	// Re-initializing as LC restores the full frame length
+	if _, err := d.Init2([]byte{0x12, 0x10}); err != nil {
+		t.Fatalf("Init2 failed: %v", err)
+	}
+	if d.FrameLength() != 1024 {
+		t.Errorf("FrameLength after LC: got %d, want 1024", d.FrameLength())
+	}
+}
+
+func TestDecoder_ApplyFilterBank_LD(t *testing.T) {
+	d := NewDecoder()
+	d.objectType = uint8(ObjectTypeLD)
+	d.frameLength = 512
+	fb := &ldFilterBank{}
+	d.fb = fb
+	if err := d.allocateChannelBuffers(1); err != nil {
+		t.Fatalf("allocateChannelBuffers failed: %v", err)
+	}
+
+	spec := make([]float32, d.frameLength)
+	if err := d.applyFilterBank(spec, 0, 0, 1); err != nil {
+		t.Fatalf("applyFilterBank failed: %v", err)
+	}
+	if fb.ldCalls != 1 || fb.gaCalls != 0 {
+		t.Errorf("calls: LD=%d GA=%d, want LD=1 GA=0", fb.ldCalls, fb.gaCalls)
+	}
+	if fb.freqLen != 512 {
+		t.Errorf("LD input length: got %d, want 512", fb.freqLen)
+	}
+
+	// A filter bank without the LD path is rejected for LD streams
+	d.fb = &tappingFilterBank{}
+	if err := d.applyFilterBank(spec, 0, 0, 1); err != ErrNilDecoder {
+		t.Errorf("expected ErrNilDecoder, got %v", err)
+	}
+}
+
+func TestDecoder_ReportTonality(t *testing.T) {
+	d := NewDecoder()
+	d.setChannelTonality(0, 0.5)
+
+	info := &FrameInfo{}
+	d.reportTonality(info, 2)
+	if info.Tonality != nil {
+		t.Fatalf("Tonality should be nil without ComputeTonality, got %v", info.Tonality)
+	}
+
+	cfg := d.Config()
+	cfg.ComputeTonality = true
+	d.SetConfiguration(cfg)
+	d.setChannelTonality(0, 0.25)
+	d.setChannelTonality(1, 0.75)
+
+	d.reportTonality(info, 2)
+	if len(info.Tonality) != 2 || info.Tonality[0] != 0.25 || info.Tonality[1] != 0.75 {
+		t.Errorf("Tonality: got %v, want [0.25 0.75]", info.Tonality)
+	}
+
+	// The report must not alias the decoder's state
+	d.setChannelTonality(0, 1)
+	if info.Tonality[0] != 0.25 {
+		t.Errorf("Tonality aliased decoder state: got %v", info.Tonality[0])
+	}
+}
+
+// TestDecode_Tonality decodes sine1k.aac with ComputeTonality: the 1 kHz
+// tone keeps every frame's flatness near 0, and frames with a silent coded
+// band report exactly 0.
+func TestDecode_Tonality(t *testing.T) {
+	data, err := os.ReadFile("testdata/sine1k.aac")
+	if err != nil {
+		t.Skipf("sine1k.aac not available: %v", err)
+	}
+	d := NewDecoder()
+	cfg := d.Config()
+	cfg.ComputeTonality = true
+	d.SetConfiguration(cfg)
+	if _, err := d.Init(data); err != nil {
+		t.Fatalf("Init: %v", err)
+	}
+
+	measured := 0
+	for f, offset := 0, 0; offset < len(data); f++ {
+		_, info, err := d.Decode(data[offset:])
+		if err != nil {
+			t.Fatalf("frame %d: %v", f, err)
+		}
+		offset += int(info.BytesConsumed)
+
+		if len(info.Tonality) != 1 {
+			t.Fatalf("frame %d: Tonality %v, want one channel", f, info.Tonality)
+		}
+		flatness := info.Tonality[0]
+		if flatness < 0 || flatness > 0.01 {
+			t.Errorf("frame %d: flatness %v, want a tonal frame below 0.01", f, flatness)
+		}
+		if flatness > 0 {
+			measured++
+		}
+	}
+	if measured == 0 {
+		t.Error("no frame measured a non-zero flatness")
+	}
+}
+
+// adtsSBRFrame builds an ADTS frame whose raw_data_block holds a fill
+// element with an EXT_SBR_DATA payload followed by ID_END.
+func adtsSBRFrame(t *testing.T, sfIndex uint8) []byte {
+	t.Helper()
+	// ID_FIL, count=1, extension type 13 + 4 zero bits, ID_END
+	payload := []byte{0xC3, 0xA1, 0xC0}
+	header, err := BuildADTSHeader(ADTSConfig{
+		ObjectType:           ObjectTypeLC,
+		SFIndex:              sfIndex,
+		ChannelConfiguration: 2,
+		BufferFullness:       0x7FF,
+	}, len(payload))
+	if err != nil {
+		t.Fatalf("BuildADTSHeader failed: %v", err)
+	}
+	return append(header, payload...)
+}
+
+func TestDecoder_Decode_ADTSImplicitSBR(t *testing.T) {
+	tests := []struct {
+		name     string
+		sfIndex  uint8
+		dontUp   bool
+		wantRate uint32
+		wantDown bool
+	}{
+		{"22050 Hz core", 7, false, 22050, false},
+		{"24000 Hz core", 6, false, 24000, false},
+		{"44100 Hz core, downsampled SBR", 4, false, 44100, true},
+		{"22050 Hz core, DontUpSampleImplicitSBR", 7, true, 22050, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			plain, err := BuildADTSHeader(ADTSConfig{
+				ObjectType:           ObjectTypeLC,
+				SFIndex:              tt.sfIndex,
+				ChannelConfiguration: 2,
+				BufferFullness:       0x7FF,
+			}, 1)
+			if err != nil {
+				t.Fatalf("BuildADTSHeader failed: %v", err)
+			}
+			plain = append(plain, 0xE0)
+			sbr := adtsSBRFrame(t, tt.sfIndex)
+
+			d := NewDecoder()
+			cfg := d.Config()
+			cfg.DontUpSampleImplicitSBR = tt.dontUp
+			d.SetConfiguration(cfg)
+			if _, err := d.Init(plain); err != nil {
+				t.Fatalf("Init failed: %v", err)
+			}
+
+			// Before any SBR extension the core rate is reported
+			_, info, err := d.Decode(plain)
+			if err != nil {
+				t.Fatalf("Decode failed: %v", err)
+			}
+			if info.SBRPresence == SBRPresent || info.SampleRate != tt.wantRate {
+				t.Errorf("before SBR: got %d Hz, SBRPresence %d; want %d Hz, no SBR",
+					info.SampleRate, info.SBRPresence, tt.wantRate)
+			}
+
+			// The SBR frame and every frame after it report SBR, at the
+			// core rate while no SBR synthesis runs
+			for i, frame := range [][]byte{sbr, plain} {
+				_, info, err := d.Decode(frame)
+				if err != nil {
+					t.Fatalf("frame %d: Decode failed: %v", i, err)
+				}
+				if info.BytesConsumed != uint32(len(frame)) {
+					t.Errorf("frame %d: BytesConsumed: got %d, want %d", i, info.BytesConsumed, len(frame))
+				}
+				if info.SampleRate != tt.wantRate {
+					t.Errorf("frame %d: SampleRate: got %d, want %d", i, info.SampleRate, tt.wantRate)
+				}
+				if info.SBRPresence != SBRPresent || info.SBR != SBRNone {
+					t.Errorf("frame %d: SBRPresence %d, SBR %d; want %d, %d",
+						i, info.SBRPresence, info.SBR, SBRPresent, SBRNone)
+				}
+			}
+			if d.downSampledSBR != tt.wantDown {
+				t.Errorf("downsampled SBR: got %v, want %v", d.downSampledSBR, tt.wantDown)
+			}
+
+			// Re-initializing clears the latched signalling
+			if _, err := d.Init(plain); err != nil {
+				t.Fatalf("Init failed: %v", err)
+			}
+			_, info, err = d.Decode(plain)
+			if err != nil {
+				t.Fatalf("Decode failed: %v", err)
+			}
+			if info.SBRPresence == SBRPresent {
+				t.Errorf("after Init: SBRPresence: got %d, want no SBR", info.SBRPresence)
+			}
+		})
+	}
+}
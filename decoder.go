@@ -2,41 +2,87 @@
 package aac
 
 import (
+	"bufio"
+	"io"
+
 	"github.com/llehouerou/go-aac/internal/bits"
+	"github.com/llehouerou/go-aac/internal/filterbank"
+	"github.com/llehouerou/go-aac/internal/latm"
+	"github.com/llehouerou/go-aac/internal/mp4"
+	"github.com/llehouerou/go-aac/internal/output"
+	"github.com/llehouerou/go-aac/internal/sbr"
+	"github.com/llehouerou/go-aac/internal/spectrum"
+	"github.com/llehouerou/go-aac/internal/syntax"
 )
 
 // FilterBankFactory is a function that creates a filter bank for the given frame length.
-// This is used to break the import cycle between aac and filterbank packages.
-// The factory is registered by the filterbank package during import.
 type FilterBankFactory func(frameLength uint16) any
 
-// filterBankFactory is the registered factory function for creating filter banks.
-// It's set by RegisterFilterBankFactory, typically called from filterbank package init.
-var filterBankFactory FilterBankFactory
+// filterBankFactory creates the decoder's filter banks, those of the
+// filterbank package unless RegisterFilterBankFactory replaced it.
+var filterBankFactory FilterBankFactory = func(frameLength uint16) any {
+	return filterbank.NewFilterBank(frameLength)
+}
 
-// RegisterFilterBankFactory registers the factory function for creating filter banks.
-// This is called by the filterbank package during its initialization to break
-// the import cycle between aac and filterbank.
-//
-// Usage: The filterbank package should call this in its init() function:
-//
-//	func init() {
-//	    aac.RegisterFilterBankFactory(func(frameLength uint16) any {
-//	        return NewFilterBank(frameLength)
-//	    })
-//	}
+// RegisterFilterBankFactory replaces the factory function for creating
+// filter banks. Decoders created afterwards use the filter banks it
+// returns.
 func RegisterFilterBankFactory(factory FilterBankFactory) {
 	filterBankFactory = factory
 }
 
+// ElementDecoderFactory creates the element decoder that parses and
+// reconstructs the channel elements of a stream with the given parameters.
+// cfg is the decoder's configuration when the element decoder is created.
+type ElementDecoderFactory func(sfIndex uint8, frameLength uint16, objectType ObjectType, cfg *Config) any
+
+// elementDecoderFactory creates the decoder's element decoders, those of
+// the spectrum package unless RegisterElementDecoderFactory replaced it.
+var elementDecoderFactory ElementDecoderFactory = newElementDecoder
+
+// newElementDecoder creates a spectrum.ElementDecoder with the
+// reconstruction settings of cfg.
+func newElementDecoder(sfIndex uint8, frameLength uint16, objectType ObjectType, cfg *Config) any {
+	return spectrum.NewElementDecoder(sfIndex, frameLength, uint8(objectType), &spectrum.Options{
+		Float32Spectra: cfg.Float32Spectra,
+		NoiseGenerator: cfg.NoiseGenerator,
+	})
+}
+
+// RegisterElementDecoderFactory replaces the factory for element
+// decoders; with a nil factory, Decode cannot decode channel elements.
+func RegisterElementDecoderFactory(factory ElementDecoderFactory) {
+	elementDecoderFactory = factory
+}
+
+// SBRDecoderFactory creates the SBR decoder of a stream whose SBR output
+// runs at sampleRate Hz.
+type SBRDecoderFactory func(sampleRate uint32) any
+
+// sbrDecoderFactory creates the decoder's SBR decoders, those of the sbr
+// package unless RegisterSBRDecoderFactory replaced it.
+var sbrDecoderFactory SBRDecoderFactory = func(sampleRate uint32) any {
+	return sbr.NewDecoder(sampleRate)
+}
+
+// RegisterSBRDecoderFactory replaces the factory for SBR decoders; with a
+// nil factory, SBR payloads of fill elements are skipped.
+func RegisterSBRDecoderFactory(factory SBRDecoderFactory) {
+	sbrDecoderFactory = factory
+}
+
+// sbrExtensionDecoder is implemented by the SBR decoder of the sbr
+// package (see RegisterSBRDecoderFactory).
+type sbrExtensionDecoder interface {
+	// DecodeExtension parses an SBR fill element payload of count bytes
+	// from its extension type on, leaving r at the end of the payload.
+	DecodeExtension(r *bits.Reader, count uint32) error
+}
+
 // Maximum limits for decoder state arrays.
-// These match the constants in internal/syntax/limits.go but are duplicated
-// here to avoid import cycles (syntax imports aac for AudioSpecificConfig).
-//
-//nolint:unused // Used in Decoder struct field sizing
 const (
-	maxChannels       = 64 // Maximum number of channels
-	maxSyntaxElements = 48 // Maximum number of syntax elements
+	maxChannels       = syntax.MaxChannels       // Maximum number of channels
+	maxSyntaxElements = syntax.MaxSyntaxElements // Maximum number of syntax elements
 )
 
 // Decoder is the main AAC decoder.
@@ -61,15 +107,40 @@ type Decoder struct {
 	adifHeaderPresent bool
 	latmHeaderPresent bool
 
+	// LATM demultiplexing (*latm.Demuxer), whether the frames are LOAS
+	// with in-band config, and the AudioSpecificConfig in use
+	latm                 *latm.Demuxer
+	latmMuxConfigPresent bool
+	latmASC              []byte
+
 	// Stream parameters
 	sfIndex              uint8  // Sample frequency index
+	explicitRate         uint32 // Core sample rate of an ASC with sfIndex 15, 0 otherwise
 	objectType           uint8  // Audio object type
 	channelConfiguration uint8  // Channel configuration
 	frameLength          uint16 // Frame length (typically 1024)
 
+	// Error resilience tools of ER object types, from the GASpecificConfig
+	aacSectionDataResilienceFlag     bool
+	aacScalefactorDataResilienceFlag bool
+	aacSpectralDataResilienceFlag    bool
+
+	// Implicit SBR signalling
+	sbrPresentFlag bool // SBR extension seen in the stream
+	sbrSignalled   bool // AudioSpecificConfig signalled SBR presence explicitly
+	mpeg2          bool // ADTS id bit set: MPEG-2 AAC, which has no SBR
+	psPresent      bool // AudioSpecificConfig signalled PS
+	sbr            any  // SBR decoder (sbrExtensionDecoder), nil until SBR data is seen
+	downSampledSBR bool // SBR output kept at the core sample rate
+
 	// Frame state
-	frame             uint32 // Current frame number
-	postSeekResetFlag bool   // Reset state after seek
+	reader            bits.Reader   // Bitstream reader, reset for each frame
+	pcmDst            []int16       // Output buffer of DecodeInto
+	conceal           concealState  // Last good output, for Config.ConcealErrors
+	dither            ditherState   // Noise generator of Config.Dither
+	frame             uint32        // Current frame number
+	postSeekResetFlag bool          // Reset state after seek
+	features          streamFeature // Coding tools seen so far (see Capabilities)
 
 	// Output configuration
 	sampleBufferSize uint32 // Output buffer size
@@ -78,6 +149,11 @@ type Decoder struct {
 	firstSynEle      bool   // First syntax element of frame
 	hasLFE           bool   // Stream has LFE channel
 
+	// ADTS crc_check regions of the raw_data_block being parsed, noted by
+	// openCRCElement while collectCRC is set
+	collectCRC bool
+	crcRegions []crcRegion
+
 	// Per-frame element info
 	frChannels uint8 // Channels in current frame
 	frChEle    uint8 // Elements in current frame
@@ -87,16 +163,61 @@ type Decoder struct {
 	elementAlloced        [maxSyntaxElements]bool  // Element buffers allocated
 
 	// Processing components
-	// Note: FilterBank and DRC are typed as 'any' to avoid import cycles.
-	// The filterbank and output packages import syntax, which imports aac.
-	// These are initialized lazily during first decode.
-	fb  any // Filter bank for IMDCT (*filterbank.FilterBank)
-	drc any // Dynamic range control (*output.DRC)
+	// Note: FilterBank and the element decoder are typed as 'any', as
+	// made by filterBankFactory and elementDecoderFactory. These are
+	// initialized lazily during first decode.
+	fb       any      // Filter bank for IMDCT (*filterbank.FilterBank)
+	drc      *drcInfo // Dynamic range control data from fill elements
+	elements any      // Element parsing and reconstruction (*spectrum.ElementDecoder)
+
+	// Reconstructed spectra of the element being decoded, fed to the
+	// filter bank: one frame per channel of the element
+	specBuf []float32
+
+	// Elements of the current raw_data_block parsed with
+	// Config.ParallelChannels, awaiting reconstruction, and their spectra:
+	// one frame per channel
+	deferred     []deferredElement
+	parallelSpec [][]float32
+
+	// Spectrum handed to Config.SpectrumCallback, in float64
+	spectrumTap []float64
+
+	// Independently switched coupling channels, by element instance tag:
+	// filter bank output and overlap, and previous window shape
+	cceTimeOut         [16][]float32
+	cceOverlap         [16][]float32
+	cceWindowShapePrev [16]uint8
+
+	// Independently switched coupling channels of the current
+	// raw_data_block, added to the time output of the elements after them
+	independentCCEs []independentCCE
+
+	// Gapless trimming: samples per channel decoded so far, and output
+	// held back as possible padding for a layout of gaplessChannels
+	gaplessPosition uint64
+	gaplessHeld     any
+	gaplessChannels uint8
+
+	// Read-ahead buffer of the stream DecodeFrom is bound to, nil until
+	// its first call
+	srcBuf *bufio.Reader
+
+	// File DecodeMP4 is bound to, its AAC track (nil until the first
+	// call), the next sample to decode and the buffer it is read into
+	mp4Src    io.ReadSeeker
+	mp4Track  *mp4.Track
+	mp4Sample int
+	mp4Buf    []byte
 
 	// Per-channel state
 	windowShapePrev [maxChannels]uint8     // Previous window shape
 	ltpLag          [maxChannels]uint16    // LTP lag values
 	timeOut         [maxChannels][]float32 // Time-domain output buffers
+	forceMix        [2][]float32           // Mix buffers for ForceChannels and DownmixMono
+	downmixer       output.Downmixer       // Stereo mix of DownMatrix and ForceChannels
+	channelOrder    []uint8                // Source of each output channel for ChannelOrder, nil if unchanged
+	tonality        [maxChannels]float32   // Spectral flatness per channel
 	fbIntermed      [maxChannels][]float32 // Filter bank intermediate buffers
 
 	// LTP prediction state (for LTP profile)
@@ -107,10 +228,10 @@ type Decoder struct {
 	rngState2 uint32
 
 	// Program config
-	pceSet          bool               // PCE has been parsed
-	pce             any                // Program config element (*syntax.ProgramConfig)
-	elementID       [maxChannels]uint8 // Element ID per channel
-	internalChannel [maxChannels]uint8 // Internal channel mapping
+	pceSet          bool                  // PCE has been parsed
+	pce             *syntax.ProgramConfig // Program config element
+	elementID       [maxChannels]uint8    // Element ID per channel
+	internalChannel [maxChannels]uint8    // Internal channel mapping
 }
 
 // NewDecoder creates a new AAC decoder with default settings.
@@ -122,18 +243,16 @@ func NewDecoder() *Decoder {
 			DefObjectType: ObjectTypeMain, // FAAD2 default is MAIN (decoder.c:135)
 			DefSampleRate: 44100,
 			OutputFormat:  OutputFormat16Bit,
+			MaxChannels:   maxChannels,
 		},
 		frameLength: 1024,
+		drc:         newDRCInfo(),
 		// RNG seeds from FAAD2 decoder.c:151-153
 		// "Same as (1, 1) after 1024 iterations; otherwise first values does not look random at all"
 		rngState1: 0x2bb431ea,
 		rngState2: 0x206155b7,
 	}
 
-	// TODO(Step 7.2): Initialize FilterBank and DRC here once import cycle is resolved.
-	// Currently deferred to Init() method due to: aac -> filterbank -> syntax -> aac cycle.
-	// The syntax/asc.go file imports the root aac package for AudioSpecificConfig.
-
 	return d
 }
 
@@ -149,6 +268,83 @@ func (d *Decoder) Config() Config {
 func (d *Decoder) SetConfiguration(cfg Config) {
 	d.config = cfg
 	d.downMatrix = cfg.DownMatrix
+	if cfg.DitherSeed != d.dither.seed {
+		d.dither.reseed(cfg.DitherSeed)
+	}
+}
+
+// GetConfig returns the current decoder configuration, as Config does.
+func (d *Decoder) GetConfig() Config {
+	return d.config
+}
+
+// SetConfig validates cfg and makes it the decoder configuration. Before
+// Init it accepts any valid configuration, like SetConfiguration. Once
+// the decoder is initialized it applies, from the next frame on, changes
+// to the output: sample format, bit depth, byte order, planar output,
+// channel order, downmixing, forced channels, DRC, gapless trimming and
+// the per-frame checks and taps. Fields the decoder reads at Init
+// (DefObjectType, DefSampleRate, UseOldADTSFormat,
+// DontUpSampleImplicitSBR, Float32Spectra, PoolBuffers) cannot change
+// until the next Init: SetConfig then returns ErrConfigNeedsReinit and
+// keeps the current configuration. NoiseGenerator is taken when the
+// first frame is decoded and later changes are ignored.
+//
+// It returns ErrInvalidConfig for an unknown OutputFormat, ChannelOrder,
+// ByteOrder, ForceChannelsMode, ConcealErrors or Dither, or a SourceBitDepth
+// outside 8-32, and
+// ErrInvalidNumChannels for a ForceChannels or MaxChannels above the
+// supported channels.
+func (d *Decoder) SetConfig(cfg Config) error {
+	if d == nil {
+		return ErrNilDecoder
+	}
+	if err := validateConfig(&cfg); err != nil {
+		return err
+	}
+	if d.fb != nil && !sameInitConfig(&d.config, &cfg) {
+		return ErrConfigNeedsReinit
+	}
+	d.SetConfiguration(cfg)
+	return nil
+}
+
+// validateConfig checks the enumerated and bounded fields of cfg.
+func validateConfig(cfg *Config) error {
+	// The zero OutputFormat decodes as 16-bit
+	if cfg.OutputFormat > OutputFormatDouble ||
+		cfg.ChannelOrder > OrderWAV ||
+		cfg.ByteOrder > ByteOrderBigEndian ||
+		cfg.ForceChannelsMode > ForceChannelsPad ||
+		cfg.ConcealErrors > ConcealRepeat ||
+		cfg.Dither > DitherTriangular {
+		return ErrInvalidConfig
+	}
+	if cfg.SourceBitDepth != 0 && (cfg.SourceBitDepth < 8 || cfg.SourceBitDepth > 32) {
+		return ErrInvalidConfig
+	}
+	if cfg.ForceChannels > maxChannels || cfg.MaxChannels > maxChannels {
+		return ErrInvalidNumChannels
+	}
+	return nil
+}
+
+// channelLimit returns the most channels a frame may decode to.
+func (d *Decoder) channelLimit() uint8 {
+	if d.config.MaxChannels == 0 || d.config.MaxChannels > maxChannels {
+		return maxChannels
+	}
+	return d.config.MaxChannels
+}
+
+// sameInitConfig reports whether a and b agree on the fields read at Init.
+func sameInitConfig(a, b *Config) bool {
+	return a.DefObjectType == b.DefObjectType &&
+		a.DefSampleRate == b.DefSampleRate &&
+		a.UseOldADTSFormat == b.UseOldADTSFormat &&
+		a.DontUpSampleImplicitSBR == b.DontUpSampleImplicitSBR &&
+		a.Float32Spectra == b.Float32Spectra &&
+		a.PoolBuffers == b.PoolBuffers
 }
 
 // allocateChannelBuffers allocates per-channel buffers for the specified number of channels.
@@ -159,18 +355,21 @@ func (d *Decoder) allocateChannelBuffers(numChannels uint8) error {
 	if numChannels > maxChannels {
 		return ErrInvalidNumChannels
 	}
+	if numChannels > d.channelLimit() {
+		return ErrChannelLimitExceeded
+	}
 
 	frameLen := int(d.frameLength)
 
 	for ch := uint8(0); ch < numChannels; ch++ {
 		// Allocate timeOut buffer if not already allocated
 		if d.timeOut[ch] == nil {
-			d.timeOut[ch] = make([]float32, frameLen)
+			d.timeOut[ch] = d.getFloat32(frameLen)
 		}
 
 		// Allocate fbIntermed buffer if not already allocated
 		if d.fbIntermed[ch] == nil {
-			d.fbIntermed[ch] = make([]float32, frameLen)
+			d.fbIntermed[ch] = d.getFloat32(frameLen)
 		}
 	}
 
@@ -191,6 +390,10 @@ func (d *Decoder) allocateLTPBuffers(numChannels uint8) {
 	}
 }
 
+// sfIndexExplicit is the samplingFrequencyIndex escape of an
+// AudioSpecificConfig, followed by a 24-bit samplingFrequency.
+const sfIndexExplicit = 0x0F
+
 // Sample rate lookup table.
 // Indices 0-11 ported from: sample_rates[] in ~/dev/faad2/libfaad/common.c:61-65
 // Index 12 (7350 Hz) is defined in ISO/IEC 14496-3.
@@ -203,10 +406,17 @@ var sampleRates = [16]uint32{
 
 // SampleRate returns the current sample rate in Hz.
 func (d *Decoder) SampleRate() uint32 {
-	if d.sfIndex < 16 {
-		return sampleRates[d.sfIndex]
+	return d.coreSampleRate()
+}
+
+// coreSampleRate returns the sample rate of the AAC core: the explicit
+// rate of an AudioSpecificConfig with samplingFrequencyIndex 15, or the
+// rate of sfIndex otherwise.
+func (d *Decoder) coreSampleRate() uint32 {
+	if d.explicitRate != 0 {
+		return d.explicitRate
 	}
-	return 0
+	return getSampleRate(d.sfIndex)
 }
 
 // Channels returns the channel configuration value.
@@ -240,6 +450,53 @@ func (d *Decoder) PostSeekReset(frame int64) {
 	}
 }
 
+// Reset clears the state carried from one frame to the next, for use
+// after seeking: the overlap-add and output buffers, the LTP history and
+// lag, the MAIN predictor states, the LFE lowpass of the stereo mix, and
+// the PNS noise generator, which restarts from its initial state. The
+// stream parameters from Init and the configuration are kept.
+//
+// Reset also unbinds the readers of DecodeFrom and DecodeMP4: the next
+// DecodeFrom continues the stream from the current position of the
+// reader it is passed, discarding the bytes buffered from the previous
+// one, and the next DecodeMP4 starts over with the file it is passed.
+//
+// Without the overlap of the previous frame, the first frame decoded
+// after Reset is muted (Samples is 0) like the first frame of a stream.
+func (d *Decoder) Reset() {
+	for ch := 0; ch < maxChannels; ch++ {
+		clear(d.timeOut[ch])
+		clear(d.fbIntermed[ch])
+		clear(d.ltPredStat[ch])
+		d.ltpLag[ch] = 0
+		d.windowShapePrev[ch] = 0
+	}
+	clear(d.forceMix[0])
+	clear(d.forceMix[1])
+	d.downmixer.Reset()
+	for tag := range d.cceOverlap {
+		clear(d.cceOverlap[tag])
+		d.cceWindowShapePrev[tag] = 0
+	}
+
+	d.rngState1 = 0x2bb431ea
+	d.rngState2 = 0x206155b7
+	if r, ok := d.elements.(interface{ Reset() }); ok {
+		r.Reset()
+	}
+
+	// Output held back for gapless trimming precedes the seek point
+	d.gaplessHeld = nil
+	d.conceal = concealState{}
+	d.dither.reseed(d.config.DitherSeed)
+
+	d.srcBuf = nil
+	d.mp4Src, d.mp4Track, d.mp4Sample = nil, nil, 0
+
+	d.postSeekResetFlag = true
+	d.frame = 0
+}
+
 // InitResult contains the result of decoder initialization.
 // Returned by Init() and Init2() methods.
 //
@@ -255,25 +512,42 @@ type InitResult struct {
 //
 // Ported from: NeAACDecClose() in ~/dev/faad2/libfaad/decoder.c:532-582
 func (d *Decoder) Close() {
-	// Clear per-channel buffers to help GC
+	// Clear per-channel buffers to help GC, or return them to the pool
 	for ch := 0; ch < maxChannels; ch++ {
+		d.putFloat32(d.timeOut[ch])
+		d.putFloat32(d.fbIntermed[ch])
 		d.timeOut[ch] = nil
 		d.fbIntermed[ch] = nil
 		d.ltPredStat[ch] = nil
 	}
+	d.cceTimeOut = [16][]float32{}
+	d.cceOverlap = [16][]float32{}
+	d.independentCCEs = nil
 
 	// Clear component references
 	d.fb = nil
 	d.drc = nil
+	d.sbr = nil
+	d.elements = nil
+	d.srcBuf = nil
+	d.mp4Src = nil
+	d.mp4Track = nil
+	d.mp4Buf = nil
 	d.pce = nil
+	d.latm = nil
+	d.reader = bits.Reader{}
+	d.conceal = concealState{}
 }
 
 // Init initializes the decoder with the given AAC bitstream data.
-// It detects the stream format (ADTS, ADIF, or raw) and extracts stream parameters.
+// It detects the stream format (ADTS, ADIF, LOAS or raw) and extracts stream parameters.
 //
 // For ADTS streams, the header is detected but not consumed (BytesRead=0).
+// LOAS streams are configured from the StreamMuxConfig of the first
+// frame, which is not consumed either.
 // For ADIF streams, the header is consumed and BytesRead reflects bytes read.
-// For raw AAC, default parameters from Config are used.
+// Raw AAC carries no configuration; Init returns ErrNoHeaderDetected and
+// the stream must be configured with Init2 from its AudioSpecificConfig.
 //
 // Returns stream parameters in InitResult, or an error if initialization fails.
 //
@@ -289,8 +563,22 @@ func (d *Decoder) Init(data []byte) (InitResult, error) {
 		return InitResult{}, ErrBufferTooSmall
 	}
 
+	d.features = 0
+	d.sbrPresentFlag = false
+	d.sbrSignalled = false
+	d.mpeg2 = false
+	d.psPresent = false
+	d.conceal = concealState{}
+	d.dither.reseed(d.config.DitherSeed)
+	d.downSampledSBR = false
+	d.pceSet = false
+	d.pce = nil
+	d.latmHeaderPresent = false
+	d.frameLength = 1024
+
 	// Set defaults from config
 	d.sfIndex = getSRIndex(d.config.DefSampleRate)
+	d.explicitRate = 0
 	d.objectType = uint8(d.config.DefObjectType)
 
 	result := InitResult{
@@ -305,19 +593,25 @@ func (d *Decoder) Init(data []byte) (InitResult, error) {
 		return d.initFromADIF(data)
 	}
 
-	r := bits.NewReader(data)
+	// LOAS frames carry their AudioSpecificConfig in band
+	if latm.IsLOASSync(data) {
+		return d.initFromLOAS(data)
+	}
 
-	// Try ADTS parsing (most common format)
-	adts, err := parseADTSHeader(r, d.config.UseOldADTSFormat)
-	if err == nil {
-		return d.initFromADTS(adts, &result)
+	// ADTS must start on a syncword with layer 0, as in FAAD2. Raw
+	// access units carry no configuration and may contain 0xFFF by chance,
+	// so they are rejected with guidance towards Init2.
+	// Ported from: NeAACDecInit() ADTS check in ~/dev/faad2/libfaad/decoder.c:339-340
+	if data[0] != 0xFF || data[1]&0xF6 != 0xF0 {
+		return InitResult{}, ErrNoHeaderDetected
 	}
 
-	// Fallback to defaults (raw AAC or unrecognized format)
-	if err := d.initFilterBank(); err != nil {
+	r := bits.NewReader(data)
+	adts, err := parseADTSHeader(r, d.config.UseOldADTSFormat)
+	if err != nil {
 		return InitResult{}, err
 	}
-	return result, nil
+	return d.initFromADTS(adts, &result)
 }
 
 // adtsHeader contains the minimal ADTS header fields needed for Init().
@@ -328,6 +622,7 @@ type adtsHeader struct {
 	Profile              uint8 // 2 bits: object type - 1
 	SFIndex              uint8 // 4 bits: sample frequency index
 	ChannelConfiguration uint8 // 3 bits: channel config
+	ID                   uint8 // 1 bit: 0=MPEG-4, 1=MPEG-2
 }
 
 // parseADTSHeader parses an ADTS header from the bitstream.
@@ -361,6 +656,7 @@ func parseADTSHeader(r *bits.Reader, oldFormat bool) (*adtsHeader, error) {
 				Profile:              profile,
 				SFIndex:              sfIndex,
 				ChannelConfiguration: chanConfig,
+				ID:                   uint8(id),
 			}, nil
 		}
 		r.FlushBits(8)
@@ -370,18 +666,52 @@ func parseADTSHeader(r *bits.Reader, oldFormat bool) (*adtsHeader, error) {
 
 // initFromADIF initializes the decoder from an ADIF header.
 // ADIF (Audio Data Interchange Format) is a container format that stores
-// a single audio program with a header at the beginning of the file.
-// It's much rarer than ADTS but must be detected and handled.
-//
-// For now, we detect ADIF and return ErrADIFNotSupported.
-// Full ADIF support requires parsing the Program Config Element (PCE).
+// a single audio program with a header at the beginning of the file,
+// followed by byte-aligned raw_data_blocks without per-frame headers.
+// The decoder is configured from the first program config element and the
+// whole header is consumed, so BytesRead points at the first block.
 //
 // Ported from: NeAACDecInit() ADIF handling in ~/dev/faad2/libfaad/decoder.c:307-338
-func (d *Decoder) initFromADIF(_ []byte) (InitResult, error) {
+func (d *Decoder) initFromADIF(data []byte) (InitResult, error) {
 	d.adifHeaderPresent = true
-	// ADIF format is rare; return unsupported for now
-	// Full implementation would parse PCE from the ADIF header
-	return InitResult{}, ErrADIFNotSupported
+	d.adtsHeaderPresent = false
+
+	r := bits.NewReader(data)
+	adif, err := parseADIFHeader(r)
+	if err != nil {
+		return InitResult{}, err
+	}
+	r.ByteAlign()
+
+	bitsRead := r.GetProcessedBits()
+	if bitsRead > uint32(len(data))*8 {
+		return InitResult{}, ErrInputBufferTooSmall
+	}
+
+	// FAAD2 configures the decoder from the first PCE only
+	pce := &adif.PCE[0]
+	d.sfIndex = pce.SFIndex
+	d.objectType = pce.ObjectType + 1
+	d.channelConfiguration = 0 // Layout is defined by the PCE
+	d.pceSet = true
+	d.pce = pce
+
+	result := InitResult{
+		BytesRead:  (bitsRead + 7) / 8,
+		SampleRate: getSampleRate(d.sfIndex),
+		Channels:   pce.Channels,
+	}
+	if result.SampleRate == 0 {
+		return InitResult{}, ErrInvalidSampleRate
+	}
+	if !canDecodeOT(ObjectType(d.objectType)) {
+		return InitResult{}, &UnsupportedObjectTypeError{Type: d.objectType}
+	}
+
+	if err := d.initFilterBank(); err != nil {
+		return InitResult{}, err
+	}
+	return result, nil
 }
 
 // initFromADTS initializes the decoder from a parsed ADTS header.
@@ -393,6 +723,13 @@ func (d *Decoder) initFromADTS(adts *adtsHeader, result *InitResult) (InitResult
 	d.objectType = adts.Profile + 1 // ADTS profile is object_type - 1
 	d.channelConfiguration = adts.ChannelConfiguration
 
+	// MPEG-2 AAC profiles map onto the same object types, except that
+	// profile 3 is reserved rather than LTP, and MPEG-2 has no SBR
+	d.mpeg2 = adts.ID == 1
+	if d.mpeg2 && adts.Profile == 3 {
+		return InitResult{}, ErrBitstreamValueNotAllowed
+	}
+
 	result.SampleRate = getSampleRate(d.sfIndex)
 	if adts.ChannelConfiguration > 6 {
 		// Channel configs > 6 are complex; default to stereo
@@ -405,7 +742,7 @@ func (d *Decoder) initFromADTS(adts *adtsHeader, result *InitResult) (InitResult
 		return InitResult{}, ErrInvalidSampleRate
 	}
 	if !canDecodeOT(ObjectType(d.objectType)) {
-		return InitResult{}, ErrUnsupportedObjectType
+		return InitResult{}, &UnsupportedObjectTypeError{Type: d.objectType}
 	}
 
 	// Update channel configuration in decoder state
@@ -418,13 +755,23 @@ func (d *Decoder) initFromADTS(adts *adtsHeader, result *InitResult) (InitResult
 }
 
 // initFilterBank initializes the filter bank for the current frame length.
-// The filter bank is stored as 'any' to avoid import cycles.
-// If the factory is registered, it creates the filter bank immediately.
+// The filter bank is stored as 'any', as made by filterBankFactory.
+// If the factory is set, it creates the filter bank immediately.
 // Otherwise, it sets a marker for lazy initialization during decode.
 func (d *Decoder) initFilterBank() error {
-	// If factory is registered, use it to create the filter bank immediately
+	// The element decoder is recreated for the new stream parameters on
+	// the first decoded element
+	d.elements = nil
+	d.cceTimeOut = [16][]float32{}
+	d.cceOverlap = [16][]float32{}
+	d.cceWindowShapePrev = [16]uint8{}
+	d.drc = newDRCInfo()
+	d.sbr = nil
+	d.resetGapless()
+
+	// If a factory is set, use it to create the filter bank immediately
 	if filterBankFactory != nil {
-		d.fb = filterBankFactory(d.frameLength)
+		d.fb = filterBankFactory(d.filterBankLength())
 		return nil
 	}
 	// Otherwise, set a marker value to indicate initialization was requested.
@@ -433,8 +780,23 @@ func (d *Decoder) initFilterBank() error {
 	return nil
 }
 
+// filterBankLength returns the frame length the filter bank is created
+// with. FAAD2 initializes the filter bank before halving the frame length
+// for AAC-LD, so an LD filter bank is built for 1024 samples and holds the
+// 1024-point LD transform alongside the regular ones.
+//
+// Ported from: NeAACDecInit2() in ~/dev/faad2/libfaad/decoder.c
+func (d *Decoder) filterBankLength() uint16 {
+	if ObjectType(d.objectType) == ObjectTypeLD {
+		return 2 * d.frameLength
+	}
+	return d.frameLength
+}
+
 // getSampleRate returns the sample rate for a given index.
-// Returns 0 for invalid indices (>= 16).
+// Returns 0 for the reserved indices, for sfIndexExplicit, whose rate
+// follows the index in an AudioSpecificConfig, and for invalid indices
+// (>= 16).
 // Local version to avoid import cycle with tables package.
 //
 // Source: ~/dev/faad2/libfaad/common.c:59-71 (get_sample_rate function)
@@ -532,9 +894,20 @@ func (d *Decoder) Init2(asc []byte) (InitResult, error) {
 		return InitResult{}, ErrBufferTooSmall
 	}
 
-	// Clear header present flags (not ADTS or ADIF)
+	// Clear header present flags (not ADTS, ADIF or LATM)
 	d.adtsHeaderPresent = false
 	d.adifHeaderPresent = false
+	d.latmHeaderPresent = false
+	d.features = 0
+	d.sbrPresentFlag = false
+	d.sbrSignalled = false
+	d.mpeg2 = false
+	d.psPresent = false
+	d.conceal = concealState{}
+	d.dither.reseed(d.config.DitherSeed)
+	d.downSampledSBR = false
+	d.pceSet = false
+	d.pce = nil
 
 	// Parse the AudioSpecificConfig
 	r := bits.NewReader(asc)
@@ -546,7 +919,7 @@ func (d *Decoder) Init2(asc []byte) (InitResult, error) {
 
 	// Validate object type
 	if !canDecodeOT(ObjectType(mp4ASC.objectType)) {
-		return InitResult{}, ErrUnsupportedObjectType
+		return InitResult{}, &UnsupportedObjectTypeError{Type: mp4ASC.objectType}
 	}
 
 	// Validate sample rate
@@ -554,10 +927,35 @@ func (d *Decoder) Init2(asc []byte) (InitResult, error) {
 		return InitResult{}, ErrInvalidSampleRate
 	}
 
-	// Copy to decoder state
+	// Copy to decoder state. An explicit sample rate keeps the tables of
+	// the nearest standard rate.
 	d.sfIndex = mp4ASC.sfIndex
+	d.explicitRate = 0
+	if mp4ASC.sfIndex == sfIndexExplicit {
+		d.sfIndex = getSRIndex(mp4ASC.sampleRate)
+		d.explicitRate = mp4ASC.sampleRate
+	}
 	d.objectType = mp4ASC.objectType
 	d.channelConfiguration = mp4ASC.channelConfig
+	d.aacSectionDataResilienceFlag = mp4ASC.aacSectionDataResilienceFlag
+	d.aacScalefactorDataResilienceFlag = mp4ASC.aacScalefactorDataResilienceFlag
+	d.aacSpectralDataResilienceFlag = mp4ASC.aacSpectralDataResilienceFlag
+	d.sbrSignalled = mp4ASC.sbrPresentFlag != -1
+	d.sbrPresentFlag = mp4ASC.sbrPresentFlag == 1
+	d.downSampledSBR = mp4ASC.downSampledSBR
+	d.psPresent = mp4ASC.psPresentFlag
+
+	// frameLengthFlag selects 960-sample frames, and AAC-LD frames are
+	// half the GA frame length
+	// Ported from: NeAACDecInit2() ALLOW_SMALL_FRAMELENGTH and LD_DEC handling
+	// in ~/dev/faad2/libfaad/decoder.c
+	d.frameLength = 1024
+	if mp4ASC.frameLengthFlag {
+		d.frameLength = 960
+	}
+	if ObjectType(d.objectType) == ObjectTypeLD {
+		d.frameLength >>= 1
+	}
 
 	// Build result
 	result := InitResult{
@@ -600,6 +998,21 @@ func (d *Decoder) SimpleInit2(asc []byte) (sampleRate uint32, channels uint8, er
 	return result.SampleRate, result.Channels, nil
 }
 
+// InitFromASC initializes the decoder for raw frames described by an
+// AudioSpecificConfig, such as the DecoderSpecificInfo of an MP4 esds box
+// or the config= parameter of an RFC 3640 or RFC 6416 SDP description,
+// decoded from hex:
+//
+//	asc, err := hex.DecodeString("1210")
+//	sampleRate, channels, err := d.InitFromASC(asc)
+//
+// The frames passed to Decode afterwards are raw_data_blocks without
+// ADTS or LATM framing. It returns the output sample rate, which accounts
+// for SBR, and the number of output channels.
+func (d *Decoder) InitFromASC(asc []byte) (sampleRate uint32, channels uint8, err error) {
+	return d.SimpleInit2(asc)
+}
+
 // mp4AudioSpecificConfig holds parsed ASC data.
 // Local type to avoid import cycles.
 //
@@ -609,6 +1022,20 @@ type mp4AudioSpecificConfig struct {
 	sfIndex       uint8  // Sample frequency index
 	sampleRate    uint32 // Actual sample rate in Hz
 	channelConfig uint8  // Channel configuration
+	epConfig      uint8  // Error protection configuration (ER object types only)
+
+	frameLengthFlag bool // GASpecificConfig: 960-sample frames instead of 1024
+
+	// SBR and PS signalling: sbrPresentFlag is 1 or 0 when signalled
+	// explicitly, -1 otherwise
+	sbrPresentFlag int8
+	psPresentFlag  bool
+	downSampledSBR bool // SBR at the core sample rate
+
+	// GASpecificConfig error resilience flags (ER object types only)
+	aacSectionDataResilienceFlag     bool
+	aacScalefactorDataResilienceFlag bool
+	aacSpectralDataResilienceFlag    bool
 }
 
 // parseAudioSpecificConfig parses an MP4 AudioSpecificConfig.
@@ -625,7 +1052,7 @@ func parseAudioSpecificConfig(r *bits.Reader, _ uint32) (*mp4AudioSpecificConfig
 	asc.sfIndex = uint8(r.GetBits(4))
 
 	// If sfIndex == 0x0F, read 24-bit explicit sample rate
-	if asc.sfIndex == 0x0F {
+	if asc.sfIndex == sfIndexExplicit {
 		asc.sampleRate = r.GetBits(24)
 	} else {
 		asc.sampleRate = getSampleRate(asc.sfIndex)
@@ -634,9 +1061,111 @@ func parseAudioSpecificConfig(r *bits.Reader, _ uint32) (*mp4AudioSpecificConfig
 	// 4 bits: channelConfiguration
 	asc.channelConfig = uint8(r.GetBits(4))
 
-	// Note: We skip GASpecificConfig parsing for basic initialization.
-	// Full parsing would include frameLengthFlag, dependsOnCoreCoder, extensionFlag.
-	// For now, we use default frameLength (1024) set in NewDecoder().
+	// Explicit hierarchical signalling: SBR (and, for object type 29, PS)
+	// object types carry the SBR sample rate and the core object type
+	asc.sbrPresentFlag = -1
+	if asc.objectType == objectTypeSBR || asc.objectType == objectTypePS {
+		asc.sbrPresentFlag = 1
+		asc.psPresentFlag = asc.objectType == objectTypePS
+		readSBRSampleRate(r, asc)
+
+		// 5 bits: objectType of the core
+		asc.objectType = uint8(r.GetBits(5))
+	}
+
+	// The frame length is the first GASpecificConfig field, ahead of any
+	// PCE, so it is known even when the rest is skipped
+	asc.frameLengthFlag = r.ShowBits(1) == 1
+
+	// Note: We skip GASpecificConfig parsing for basic initialization. ER
+	// object types are followed by epConfig, and the others may be
+	// followed by the backward compatible SBR signalling. The PCE of a
+	// channelConfig 0 stream cannot be skipped here, so those streams are
+	// left for the full parser in internal/syntax to check.
+	if asc.channelConfig == 0 {
+		return asc, nil
+	}
+	skipGASpecificConfig(r, asc)
+	if asc.objectType >= erObjectStart {
+		// 2 bits: epConfig
+		asc.epConfig = uint8(r.GetBits(2))
+		if asc.epConfig != 0 {
+			return nil, ErrEPConfigNotSupported
+		}
+	}
+
+	// Explicit backward compatible signalling, after the core config
+	// Ported from: AudioSpecificConfigFromBitfile() in ~/dev/faad2/libfaad/mp4.c
+	if asc.sbrPresentFlag == -1 && r.RemainingBits() >= 16 && r.GetBits(11) == syncExtensionSBR {
+		// 5 bits: extensionAudioObjectType
+		if r.GetBits(5) != objectTypeSBR {
+			return asc, nil
+		}
+		// 1 bit: sbrPresentFlag
+		asc.sbrPresentFlag = int8(r.Get1Bit())
+		if asc.sbrPresentFlag == 0 {
+			return asc, nil
+		}
+		readSBRSampleRate(r, asc)
+
+		// 1 bit: psPresentFlag, after its own sync extension
+		if r.RemainingBits() >= 12 && r.GetBits(11) == syncExtensionPS {
+			asc.psPresentFlag = r.Get1Bit() == 1
+		}
+	}
 
 	return asc, nil
 }
+
+// erObjectStart is the first error resilient audio object type.
+const erObjectStart = 17
+
+// Object types and sync extension types signalling SBR and PS in an
+// AudioSpecificConfig. Local copies to avoid an import cycle.
+//
+// Ported from: ~/dev/faad2/libfaad/mp4.c
+const (
+	objectTypeSBR    = 5
+	objectTypePS     = 29
+	syncExtensionSBR = 0x2B7
+	syncExtensionPS  = 0x548
+)
+
+// readSBRSampleRate reads the extensionSamplingFrequencyIndex of the SBR
+// signalling, and its 24-bit explicit rate. SBR at the core rate runs
+// downsampled.
+func readSBRSampleRate(r *bits.Reader, asc *mp4AudioSpecificConfig) {
+	// 4 bits: extensionSamplingFrequencyIndex
+	extSFIndex := uint8(r.GetBits(4))
+	asc.downSampledSBR = extSFIndex == asc.sfIndex
+	if extSFIndex == sfIndexExplicit {
+		// 24 bits: extensionSamplingFrequency
+		asc.downSampledSBR = r.GetBits(24) == asc.sampleRate
+	}
+}
+
+// skipGASpecificConfig consumes a GASpecificConfig without a PCE,
+// keeping the error resilience flags in asc.
+//
+// Ported from: GASpecificConfig() in ~/dev/faad2/libfaad/mp4.c:145-200
+func skipGASpecificConfig(r *bits.Reader, asc *mp4AudioSpecificConfig) {
+	// 1 bit: frameLengthFlag
+	r.FlushBits(1)
+
+	// 1 bit: dependsOnCoreCoder, followed by a 14-bit coreCoderDelay
+	if r.Get1Bit() == 1 {
+		r.FlushBits(14)
+	}
+
+	// 1 bit: extensionFlag
+	if r.Get1Bit() == 1 {
+		if asc.objectType >= erObjectStart {
+			// 3 bits: section, scalefactor and spectral data resilience flags
+			asc.aacSectionDataResilienceFlag = r.Get1Bit() == 1
+			asc.aacScalefactorDataResilienceFlag = r.Get1Bit() == 1
+			asc.aacSpectralDataResilienceFlag = r.Get1Bit() == 1
+		}
+		// 1 bit: extensionFlag3
+		r.FlushBits(1)
+	}
+}
@@ -0,0 +1,54 @@
+// decoder_pool.go
+package aac
+
+// DecoderPool hands out decoders sharing one configuration, for servers
+// decoding many streams from many goroutines. A Decoder is not safe for
+// concurrent use; the pool lends each decoder to one goroutine at a time.
+//
+// Get blocks while all the pool's decoders are lent out. Put resets a
+// decoder and makes it available again. A decoder returned by Get keeps
+// the stream parameters of the last stream it decoded: call Init or Init2
+// for each new stream, unless it is known to share the previous stream's
+// AudioSpecificConfig.
+//
+// A DecoderPool is safe for concurrent use by any number of goroutines.
+type DecoderPool struct {
+	cfg  Config
+	idle chan *Decoder
+}
+
+// NewDecoderPool returns a pool of size decoders configured with cfg. A
+// size below 1 is raised to 1.
+func NewDecoderPool(cfg Config, size int) *DecoderPool {
+	size = max(size, 1)
+	p := &DecoderPool{cfg: cfg, idle: make(chan *Decoder, size)}
+	for range size {
+		d := NewDecoder()
+		d.SetConfiguration(cfg)
+		p.idle <- d
+	}
+	return p
+}
+
+// Get returns an idle decoder, waiting for one to be Put back if all are
+// in use.
+func (p *DecoderPool) Get() *Decoder {
+	return <-p.idle
+}
+
+// Put resets d, restores the pool's configuration and returns d to the
+// pool. d must not be used after Put. A nil d is ignored, and a decoder
+// that does not fit in the pool, such as one not obtained from Get, is
+// closed instead.
+func (p *DecoderPool) Put(d *Decoder) {
+	if d == nil {
+		return
+	}
+	d.Reset()
+	d.SetConfiguration(p.cfg)
+	select {
+	case p.idle <- d:
+	default:
+		d.Close()
+	}
+}
@@ -0,0 +1,82 @@
+// decoder_pool_test.go
+package aac
+
+import (
+	"slices"
+	"sync"
+	"testing"
+)
+
+func TestDecoderPool_Concurrent(t *testing.T) {
+	frames := poolFrames(t)
+	want := decodeCCEFrames(t, frames)
+
+	const (
+		workers = 8
+		streams = 4 // Streams decoded by each worker
+	)
+	pool := NewDecoderPool(NewDecoder().Config(), 3)
+
+	var wg sync.WaitGroup
+	errs := make(chan string, workers*streams)
+	for w := range workers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for s := range streams {
+				d := pool.Get()
+				if _, err := d.Init2(ascCCE); err != nil {
+					errs <- "Init2: " + err.Error()
+					pool.Put(d)
+					return
+				}
+				for f, frame := range frames {
+					samples, _, err := d.Decode(frame)
+					if err != nil {
+						errs <- "Decode: " + err.Error()
+						break
+					}
+					got, _ := samples.([]int16)
+					if !slices.Equal(got, want[f]) {
+						errs <- "output differs from the single-threaded decode"
+						t.Logf("worker %d stream %d frame %d differs", w, s, f)
+						break
+					}
+				}
+				pool.Put(d)
+			}
+		}()
+	}
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		t.Error(err)
+	}
+}
+
+func TestDecoderPool_Put(t *testing.T) {
+	cfg := NewDecoder().Config()
+	pool := NewDecoderPool(cfg, 1)
+
+	d := pool.Get()
+	changed := d.Config()
+	changed.OutputFormat = OutputFormatFloat
+	d.SetConfiguration(changed)
+	pool.Put(d)
+
+	if got := pool.Get(); got != d {
+		t.Fatal("Get did not return the decoder put back")
+	}
+	if d.Config().OutputFormat != cfg.OutputFormat {
+		t.Error("Put did not restore the pool configuration")
+	}
+
+	// A decoder beyond the pool's size is closed, not queued
+	pool.Put(d)
+	extra := NewDecoder()
+	pool.Put(extra)
+	if got := pool.Get(); got != d {
+		t.Error("the extra decoder displaced the pooled one")
+	}
+	pool.Put(nil)
+}
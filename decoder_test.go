@@ -1,7 +1,16 @@
 // decoder_test.go
 package aac
 
-import "testing"
+import (
+	"errors"
+	"math"
+	"os"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/llehouerou/go-aac/internal/syntax"
+)
 
 func TestDecoder_New(t *testing.T) {
 	dec := NewDecoder()
@@ -195,9 +204,9 @@ func TestDecoder_Close(t *testing.T) {
 	dec.allocateLTPBuffers(2)
 
 	// Simulate component references
-	dec.fb = struct{}{}  // Non-nil value
-	dec.drc = struct{}{} // Non-nil value
-	dec.pce = struct{}{} // Non-nil value
+	dec.fb = struct{}{} // Non-nil value
+	dec.drc = newDRCInfo()
+	dec.pce = &syntax.ProgramConfig{}
 
 	// Close should not panic
 	dec.Close()
@@ -281,6 +290,79 @@ func TestDecoder_PostSeekReset(t *testing.T) {
 	}
 }
 
+func TestDecoder_Reset(t *testing.T) {
+	data, err := os.ReadFile("testdata/sine1k.aac")
+	if err != nil {
+		t.Skipf("sine1k.aac not available: %v", err)
+	}
+
+	// ADTS frame boundaries
+	var frames [][]byte
+	for off := 0; off+7 <= len(data); {
+		n := int(data[off+3]&0x03)<<11 | int(data[off+4])<<3 | int(data[off+5]>>5)
+		frames = append(frames, data[off:off+n])
+		off += n
+	}
+	const seekTo = 5
+	if len(frames) < seekTo+3 {
+		t.Fatalf("only %d frames in test stream", len(frames))
+	}
+
+	decode := func(d *Decoder, frame []byte) ([]int16, *FrameInfo) {
+		t.Helper()
+		samples, info, err := d.Decode(frame)
+		if err != nil {
+			t.Fatalf("Decode: %v", err)
+		}
+		pcm, _ := samples.([]int16)
+		return pcm, info
+	}
+
+	d := NewDecoder()
+	if _, err := d.Init(data); err != nil {
+		t.Fatalf("Init: %v", err)
+	}
+	for _, frame := range frames[:seekTo] {
+		decode(d, frame)
+	}
+
+	d.Reset()
+
+	if !d.postSeekResetFlag {
+		t.Error("postSeekResetFlag not set after Reset")
+	}
+	if d.frame != 0 {
+		t.Errorf("frame = %d after Reset, want 0", d.frame)
+	}
+	if d.rngState1 != 0x2bb431ea || d.rngState2 != 0x206155b7 {
+		t.Errorf("RNG state = %#x, %#x after Reset", d.rngState1, d.rngState2)
+	}
+	for name, buf := range map[string][]float32{"timeOut": d.timeOut[0], "fbIntermed": d.fbIntermed[0]} {
+		for i, v := range buf {
+			if v != 0 {
+				t.Fatalf("%s[%d] = %v after Reset, want 0", name, i, v)
+			}
+		}
+	}
+
+	// Decoding on from the seek point matches a new decoder starting
+	// there, including the PNS noise
+	fresh := NewDecoder()
+	if _, err := fresh.Init(frames[seekTo]); err != nil {
+		t.Fatalf("Init: %v", err)
+	}
+	for i, frame := range frames[seekTo : seekTo+3] {
+		got, info := decode(d, frame)
+		want, _ := decode(fresh, frame)
+		if i == 0 && info.Samples != 0 {
+			t.Errorf("first frame after Reset has %d samples, want 0 (muted)", info.Samples)
+		}
+		if i > 0 && !slices.Equal(got, want) {
+			t.Errorf("frame %d after Reset differs from a new decoder", i)
+		}
+	}
+}
+
 func TestInitResult_Fields(t *testing.T) {
 	// Verify InitResult type exists with expected fields
 	result := InitResult{
@@ -372,7 +454,7 @@ func TestDecoder_Init_InvalidObjectType(t *testing.T) {
 
 	d := NewDecoder()
 	_, err := d.Init(adtsHeader)
-	if err != ErrUnsupportedObjectType {
+	if !errors.Is(err, ErrUnsupportedObjectType) {
 		t.Errorf("expected ErrUnsupportedObjectType, got %v", err)
 	}
 }
@@ -417,27 +499,24 @@ func TestDecoder_Init_ADIF_NotSet_For_ADTS(t *testing.T) {
 	}
 }
 
-func TestDecoder_Init_ADIF_Detected(t *testing.T) {
-	// Create minimal ADIF header with "ADIF" magic
-	// ADIF format is rare and complex; for now we just detect and return an error
-	adifData := []byte{
-		'A', 'D', 'I', 'F', // Magic signature
-		0x00, // copyright_id_present = 0
-		// Additional fields would follow but we only detect magic for now
-	}
+func TestDecoder_Init_RawStream(t *testing.T) {
+	// Raw data block: ID_SCE (000), element_instance_tag 0, then
+	// global_gain. No syncword, so there is nothing to configure from.
+	// A 0xFFF pattern later in the buffer must not be mistaken for ADTS.
+	raw := []byte{0x01, 0x40, 0x20, 0x07, 0x00, 0xFF, 0xF1, 0x50, 0x80, 0x00, 0x1F, 0xFC}
 
 	d := NewDecoder()
-	_, err := d.Init(adifData)
-
-	// ADIF should be detected (returns ErrADIFNotSupported for now)
-	if err != ErrADIFNotSupported {
-		t.Errorf("expected ErrADIFNotSupported for ADIF data, got %v", err)
+	_, err := d.Init(raw)
+	if err != ErrNoHeaderDetected {
+		t.Fatalf("expected ErrNoHeaderDetected, got %v", err)
 	}
-	if !d.adifHeaderPresent {
-		t.Error("adifHeaderPresent should be true for ADIF data")
+	if d.adtsHeaderPresent || d.adifHeaderPresent {
+		t.Error("no header type should be set for raw data")
 	}
-	if d.adtsHeaderPresent {
-		t.Error("adtsHeaderPresent should be false for ADIF data")
+
+	// The guidance points to the raw-config path
+	if !strings.Contains(err.Error(), "Init2") {
+		t.Errorf("error message should mention Init2, got %q", err.Error())
 	}
 }
 
@@ -511,6 +590,46 @@ func TestDecoder_Init2_BufferTooSmall(t *testing.T) {
 	}
 }
 
+func TestDecoder_Init2_EPConfig(t *testing.T) {
+	// ER AAC LC, 44100Hz, stereo, empty GASpecificConfig, then epConfig:
+	// 10001 0100 0010 000 xx
+	tests := []struct {
+		name    string
+		asc     []byte
+		wantErr error
+	}{
+		{"epConfig 0", []byte{0x8A, 0x10, 0x00}, nil},
+		{"epConfig 1", []byte{0x8A, 0x10, 0x40}, ErrEPConfigNotSupported},
+		{"epConfig 3", []byte{0x8A, 0x10, 0xC0}, ErrEPConfigNotSupported},
+		// extensionFlag=1 adds 3 resilience flags and extensionFlag3
+		// before epConfig: 10001 0100 0010 001 000 0 01
+		{"epConfig 1 after resilience flags", []byte{0x8A, 0x11, 0x04}, ErrEPConfigNotSupported},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := NewDecoder()
+			_, err := d.Init2(tt.asc)
+			if err != tt.wantErr {
+				t.Errorf("Init2: got %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestDecoder_Init2_ResilienceFlags(t *testing.T) {
+	// ER AAC LC, 44100Hz, stereo, extensionFlag=1 with section and
+	// spectral data resilience: 10001 0100 0010 001 101 0 00
+	d := NewDecoder()
+	if _, err := d.Init2([]byte{0x8A, 0x11, 0xA0}); err != nil {
+		t.Fatalf("Init2: %v", err)
+	}
+	if !d.aacSectionDataResilienceFlag || d.aacScalefactorDataResilienceFlag || !d.aacSpectralDataResilienceFlag {
+		t.Errorf("resilience flags %v %v %v, want true false true", d.aacSectionDataResilienceFlag,
+			d.aacScalefactorDataResilienceFlag, d.aacSpectralDataResilienceFlag)
+	}
+}
+
 func TestDecoder_Init2_InvalidObjectType(t *testing.T) {
 	// ASC with object type 0 (NULL, not supported)
 	// 5 bits: objectType = 0 (00000)
@@ -521,11 +640,35 @@ func TestDecoder_Init2_InvalidObjectType(t *testing.T) {
 
 	d := NewDecoder()
 	_, err := d.Init2(asc)
-	if err != ErrUnsupportedObjectType {
+	if !errors.Is(err, ErrUnsupportedObjectType) {
 		t.Errorf("expected ErrUnsupportedObjectType, got %v", err)
 	}
 }
 
+func TestDecoder_Init2_UnsupportedObjectTypeError(t *testing.T) {
+	// ASC with object type 7 (TwinVQ)
+	// 5 bits: objectType = 7 (00111)
+	// 4 bits: samplingFrequencyIndex = 4 (0100)
+	// 4 bits: channelConfiguration = 2 (0010)
+	asc := []byte{0x3A, 0x10}
+
+	d := NewDecoder()
+	_, err := d.Init2(asc)
+	var typeErr *UnsupportedObjectTypeError
+	if !errors.As(err, &typeErr) {
+		t.Fatalf("expected *UnsupportedObjectTypeError, got %T %v", err, err)
+	}
+	if typeErr.Type != 7 {
+		t.Errorf("Type = %d, want 7 (TwinVQ)", typeErr.Type)
+	}
+	if !errors.Is(err, ErrUnsupportedObjectType) {
+		t.Error("error does not wrap ErrUnsupportedObjectType")
+	}
+	if got, want := err.Error(), "unsupported audio object type 7"; got != want {
+		t.Errorf("Error() = %q, want %q", got, want)
+	}
+}
+
 func TestDecoder_Init2_SSRObjectType(t *testing.T) {
 	// ASC with object type 3 (SSR, not supported)
 	// 5 bits: objectType = 3 (00011)
@@ -536,7 +679,7 @@ func TestDecoder_Init2_SSRObjectType(t *testing.T) {
 
 	d := NewDecoder()
 	_, err := d.Init2(asc)
-	if err != ErrUnsupportedObjectType {
+	if !errors.Is(err, ErrUnsupportedObjectType) {
 		t.Errorf("expected ErrUnsupportedObjectType for SSR, got %v", err)
 	}
 }
@@ -632,6 +775,73 @@ func TestDecoder_Init2_ExplicitSampleRate(t *testing.T) {
 	}
 }
 
+// explicitRateASC returns an LC AudioSpecificConfig with the
+// samplingFrequencyIndex escape and the given 24-bit rate.
+func explicitRateASC(rate uint32, channels uint8) []byte {
+	w := &adifBitWriter{}
+	w.writeBits(2, 5)  // audioObjectType: LC
+	w.writeBits(15, 4) // samplingFrequencyIndex: escape
+	w.writeBits(rate, 24)
+	w.writeBits(uint32(channels), 4)
+	w.writeBits(0, 3) // GASpecificConfig
+	return w.buf
+}
+
+func TestDecoder_Init2_ExplicitSampleRateTables(t *testing.T) {
+	d := NewDecoder()
+	result, err := d.Init2(explicitRateASC(12345, 1))
+	if err != nil {
+		t.Fatalf("Init2: %v", err)
+	}
+	if result.SampleRate != 12345 || d.SampleRate() != 12345 {
+		t.Errorf("sample rate %d (SampleRate() %d), want 12345", result.SampleRate, d.SampleRate())
+	}
+	// The tables are those of the nearest standard rate, 12000 Hz
+	if d.sfIndex != 9 {
+		t.Errorf("sfIndex = %d, want 9", d.sfIndex)
+	}
+	var info FrameInfo
+	d.setSampleRateInfo(&info)
+	if info.SampleRate != 12345 {
+		t.Errorf("FrameInfo.SampleRate = %d, want 12345", info.SampleRate)
+	}
+
+	// A later ADTS stream drops the explicit rate
+	if _, err := d.Init([]byte{0xFF, 0xF1, 0x50, 0x80, 0x00, 0x1F, 0xFC}); err != nil {
+		t.Fatalf("Init: %v", err)
+	}
+	if d.SampleRate() != 44100 {
+		t.Errorf("after ADTS Init: SampleRate() = %d, want 44100", d.SampleRate())
+	}
+
+	// sine1k.aac decodes the same at 44000 Hz, which shares the 44100 Hz
+	// tables, and reports the explicit rate
+	data, err := os.ReadFile("testdata/sine1k.aac")
+	if err != nil {
+		t.Skipf("sine1k.aac not available: %v", err)
+	}
+	ref := NewDecoder()
+	if _, err := ref.Init(data); err != nil {
+		t.Fatalf("Init: %v", err)
+	}
+	if _, err := d.Init2(explicitRateASC(44000, 1)); err != nil {
+		t.Fatalf("Init2: %v", err)
+	}
+	want, _ := decodeAll(t, ref, data)
+	for i, payload := range adtsPayloads(t, data) {
+		samples, info, err := d.Decode(payload)
+		if err != nil {
+			t.Fatalf("frame %d: Decode: %v", i, err)
+		}
+		if info.SampleRate != 44000 {
+			t.Fatalf("frame %d: SampleRate = %d, want 44000", i, info.SampleRate)
+		}
+		if got, _ := samples.([]int16); !slices.Equal(got, want[i]) {
+			t.Fatalf("frame %d differs from the ADTS decode", i)
+		}
+	}
+}
+
 func TestDecoder_Init2_FilterBankInitialized(t *testing.T) {
 	asc := []byte{0x12, 0x10} // AAC-LC, 44100Hz, stereo
 
@@ -822,6 +1032,48 @@ func TestDecoder_SimpleInit2(t *testing.T) {
 	}
 }
 
+func TestDecoder_InitFromASC(t *testing.T) {
+	tests := []struct {
+		name     string
+		asc      []byte
+		rate     uint32
+		channels uint8
+		sbr      SBRPresence
+	}{
+		// AAC-LC, 44100 Hz, stereo
+		{"LC", []byte{0x12, 0x10}, 44100, 2, SBRNotPresent},
+		// AAC-LC at 24000 Hz, stereo, with backward compatible SBR
+		// signalling (syncExtensionType 0x2B7); the rate stays at the
+		// core rate while no SBR synthesis runs
+		{"HE-AAC", []byte{0x13, 0x10, 0x56, 0xE5, 0x98}, 24000, 2, SBRPresent},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := NewDecoder()
+			rate, channels, err := d.InitFromASC(tt.asc)
+			if err != nil {
+				t.Fatalf("InitFromASC: %v", err)
+			}
+			if rate != tt.rate || channels != tt.channels {
+				t.Errorf("got %d Hz, %d channels; want %d Hz, %d channels", rate, channels, tt.rate, tt.channels)
+			}
+			if d.ObjectType() != ObjectTypeLC || d.adtsHeaderPresent || d.latmHeaderPresent {
+				t.Errorf("object type %d, ADTS %v, LATM %v; want raw LC frames",
+					d.ObjectType(), d.adtsHeaderPresent, d.latmHeaderPresent)
+			}
+			var info FrameInfo
+			d.setSampleRateInfo(&info)
+			if info.SBRPresence != tt.sbr || info.SBR != SBRNone {
+				t.Errorf("SBRPresence %d, SBR %d; want %d, %d", info.SBRPresence, info.SBR, tt.sbr, SBRNone)
+			}
+		})
+	}
+
+	if _, _, err := NewDecoder().InitFromASC([]byte{0x12}); err != ErrBufferTooSmall {
+		t.Errorf("1-byte ASC: err = %v, want ErrBufferTooSmall", err)
+	}
+}
+
 func TestDecoder_ConcurrentDecode(t *testing.T) {
 	d := NewDecoder()
 
@@ -853,3 +1105,234 @@ func TestDecoder_ConcurrentDecode(t *testing.T) {
 		<-done
 	}
 }
+
+func TestDecoder_SetConfig_SwitchFormatBetweenFrames(t *testing.T) {
+	frames := poolFrames(t)
+	want := decodeCCEFrames(t, frames)
+
+	d := NewDecoder()
+	defer d.Close()
+	if _, err := d.Init2(ascCCE); err != nil {
+		t.Fatalf("Init2: %v", err)
+	}
+
+	for f := range 2 {
+		samples, _, err := d.Decode(frames[f])
+		if err != nil {
+			t.Fatalf("frame %d: Decode: %v", f, err)
+		}
+		if _, ok := samples.([]int16); samples != nil && !ok {
+			t.Fatalf("frame %d: got %T, want []int16", f, samples)
+		}
+	}
+
+	cfg := d.GetConfig()
+	cfg.OutputFormat = OutputFormatFloat
+	if err := d.SetConfig(cfg); err != nil {
+		t.Fatalf("SetConfig: %v", err)
+	}
+	if got := d.GetConfig().OutputFormat; got != OutputFormatFloat {
+		t.Fatalf("OutputFormat = %d, want %d", got, OutputFormatFloat)
+	}
+
+	samples, _, err := d.Decode(frames[2])
+	if err != nil {
+		t.Fatalf("frame 2: Decode: %v", err)
+	}
+	pcm, ok := samples.([]float32)
+	if !ok {
+		t.Fatalf("frame 2: got %T, want []float32", samples)
+	}
+	if len(pcm) != len(want[2]) {
+		t.Fatalf("frame 2: %d samples, want %d", len(pcm), len(want[2]))
+	}
+	nonZero := false
+	for i, v := range pcm {
+		// The 16-bit samples are the float samples rounded
+		if diff := math.Abs(float64(v)*32768 - float64(want[2][i])); diff > 1 {
+			t.Fatalf("sample %d = %v (%v as 16-bit), want %d", i, v, float64(v)*32768, want[2][i])
+		}
+		nonZero = nonZero || want[2][i] != 0
+	}
+	if !nonZero {
+		t.Fatal("frame 2 is silent")
+	}
+}
+
+func TestDecoder_SetConfig_Errors(t *testing.T) {
+	d := NewDecoder()
+	defer d.Close()
+
+	invalid := []struct {
+		name   string
+		modify func(*Config)
+		want   error
+	}{
+		{"output format", func(c *Config) { c.OutputFormat = OutputFormatDouble + 1 }, ErrInvalidConfig},
+		{"channel order", func(c *Config) { c.ChannelOrder = OrderWAV + 1 }, ErrInvalidConfig},
+		{"byte order", func(c *Config) { c.ByteOrder = ByteOrderBigEndian + 1 }, ErrInvalidConfig},
+		{"force channels mode", func(c *Config) { c.ForceChannelsMode = ForceChannelsPad + 1 }, ErrInvalidConfig},
+		{"source bit depth", func(c *Config) { c.SourceBitDepth = 4 }, ErrInvalidConfig},
+		{"force channels", func(c *Config) { c.ForceChannels = maxChannels + 1 }, ErrInvalidNumChannels},
+	}
+	for _, tt := range invalid {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := d.GetConfig()
+			tt.modify(&cfg)
+			if err := d.SetConfig(cfg); err != tt.want {
+				t.Errorf("SetConfig = %v, want %v", err, tt.want)
+			}
+		})
+	}
+
+	// Before Init, fields read at Init can change
+	cfg := d.GetConfig()
+	cfg.DefSampleRate = 48000
+	if err := d.SetConfig(cfg); err != nil {
+		t.Fatalf("SetConfig before Init: %v", err)
+	}
+	if _, err := d.Init2(ascCCE); err != nil {
+		t.Fatalf("Init2: %v", err)
+	}
+
+	reinit := []struct {
+		name   string
+		modify func(*Config)
+	}{
+		{"default sample rate", func(c *Config) { c.DefSampleRate = 44100 }},
+		{"default object type", func(c *Config) { c.DefObjectType = ObjectTypeLC }},
+		{"old ADTS format", func(c *Config) { c.UseOldADTSFormat = true }},
+		{"implicit SBR upsampling", func(c *Config) { c.DontUpSampleImplicitSBR = true }},
+		{"float32 spectra", func(c *Config) { c.Float32Spectra = true }},
+		{"pooled buffers", func(c *Config) { c.PoolBuffers = true }},
+	}
+	for _, tt := range reinit {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := d.GetConfig()
+			cfg.DownMatrix = true
+			tt.modify(&cfg)
+			if err := d.SetConfig(cfg); err != ErrConfigNeedsReinit {
+				t.Errorf("SetConfig = %v, want ErrConfigNeedsReinit", err)
+			}
+			if d.GetConfig().DownMatrix {
+				t.Error("rejected SetConfig changed the configuration")
+			}
+		})
+	}
+
+	var nilDecoder *Decoder
+	if err := nilDecoder.SetConfig(Config{}); err != ErrNilDecoder {
+		t.Errorf("nil decoder: %v, want ErrNilDecoder", err)
+	}
+}
+
+func TestDecoder_Init2_SBRSignalling(t *testing.T) {
+	// GASpecificConfig without PCE: frameLengthFlag, dependsOnCoreCoder
+	// and extensionFlag all 0
+	const gaConfig = 0
+	tests := []struct {
+		name     string
+		asc      func(w *adifBitWriter)
+		rate     uint32
+		presence SBRPresence
+		down     bool
+		ps       bool
+		// Presence once a frame carries SBR data
+		afterData SBRPresence
+	}{
+		{"hierarchical SBR", func(w *adifBitWriter) {
+			w.writeBits(5, 5) // audioObjectType: SBR
+			w.writeBits(6, 4) // 24000 Hz core
+			w.writeBits(2, 4)
+			w.writeBits(3, 4) // extensionSamplingFrequencyIndex: 48000 Hz
+			w.writeBits(2, 5) // core audioObjectType: LC
+			w.writeBits(gaConfig, 3)
+		}, 24000, SBRPresent, false, false, SBRPresent},
+		{"hierarchical PS", func(w *adifBitWriter) {
+			w.writeBits(29, 5) // audioObjectType: PS
+			w.writeBits(6, 4)
+			w.writeBits(1, 4)
+			w.writeBits(3, 4)
+			w.writeBits(2, 5)
+			w.writeBits(gaConfig, 3)
+		}, 24000, SBRPresent, false, true, SBRPresent},
+		{"hierarchical downsampled SBR", func(w *adifBitWriter) {
+			w.writeBits(5, 5)
+			w.writeBits(3, 4) // 48000 Hz core
+			w.writeBits(2, 4)
+			w.writeBits(3, 4) // SBR at the core rate
+			w.writeBits(2, 5)
+			w.writeBits(gaConfig, 3)
+		}, 48000, SBRPresent, true, false, SBRPresent},
+		{"backward compatible SBR and PS", func(w *adifBitWriter) {
+			w.writeBits(2, 5)
+			w.writeBits(6, 4)
+			w.writeBits(1, 4)
+			w.writeBits(gaConfig, 3)
+			w.writeBits(0x2B7, 11) // syncExtensionType
+			w.writeBits(5, 5)      // extensionAudioObjectType: SBR
+			w.writeBits(1, 1)      // sbrPresentFlag
+			w.writeBits(3, 4)
+			w.writeBits(0x548, 11) // syncExtensionType
+			w.writeBits(1, 1)      // psPresentFlag
+		}, 24000, SBRPresent, false, true, SBRPresent},
+		{"backward compatible without SBR", func(w *adifBitWriter) {
+			w.writeBits(2, 5)
+			w.writeBits(7, 4) // 22050 Hz
+			w.writeBits(2, 4)
+			w.writeBits(gaConfig, 3)
+			w.writeBits(0x2B7, 11)
+			w.writeBits(5, 5)
+			w.writeBits(0, 1) // sbrPresentFlag
+		}, 22050, SBRNotPresent, false, false, SBRNotPresent},
+		{"implicit at 22050 Hz", func(w *adifBitWriter) {
+			w.writeBits(2, 5)
+			w.writeBits(7, 4)
+			w.writeBits(2, 4)
+			w.writeBits(gaConfig, 3)
+		}, 22050, SBRUnknown, false, false, SBRPresent},
+		{"implicit at 44100 Hz", func(w *adifBitWriter) {
+			w.writeBits(2, 5)
+			w.writeBits(4, 4)
+			w.writeBits(2, 4)
+			w.writeBits(gaConfig, 3)
+		}, 44100, SBRNotPresent, false, false, SBRPresent},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := &adifBitWriter{}
+			tt.asc(w)
+
+			d := NewDecoder()
+			result, err := d.Init2(w.buf)
+			if err != nil {
+				t.Fatalf("Init2: %v", err)
+			}
+			if d.ObjectType() != ObjectTypeLC {
+				t.Errorf("ObjectType() = %d, want the LC core", d.ObjectType())
+			}
+			if result.SampleRate != tt.rate {
+				t.Errorf("SampleRate = %d, want %d", result.SampleRate, tt.rate)
+			}
+
+			var info FrameInfo
+			d.setSampleRateInfo(&info)
+			if info.SBRPresence != tt.presence || info.SBR != SBRNone || info.PSPresent != tt.ps {
+				t.Errorf("SBRPresence %d, SBR %d, PSPresent %v; want %d, %d, %v",
+					info.SBRPresence, info.SBR, info.PSPresent, tt.presence, SBRNone, tt.ps)
+			}
+			if d.downSampledSBR != tt.down {
+				t.Errorf("downsampled SBR: got %v, want %v", d.downSampledSBR, tt.down)
+			}
+			if info.SampleRate != tt.rate {
+				t.Errorf("FrameInfo.SampleRate = %d, want %d", info.SampleRate, tt.rate)
+			}
+
+			d.noteImplicitSBR(true)
+			d.setSampleRateInfo(&info)
+			if info.SBRPresence != tt.afterData {
+				t.Errorf("after SBR data: SBRPresence %d, want %d", info.SBRPresence, tt.afterData)
+			}
+		})
+	}
+}
@@ -0,0 +1,54 @@
+// dither.go
+package aac
+
+// DitherMode selects the dither added to 16-bit output before it is
+// rounded (Config.Dither).
+type DitherMode uint8
+
+const (
+	// DitherNone rounds samples as FAAD2 does.
+	DitherNone DitherMode = iota
+
+	// DitherRectangular adds noise uniform over ±0.5 LSB.
+	DitherRectangular
+
+	// DitherTriangular adds triangular (TPDF) noise over ±1 LSB, the sum
+	// of two rectangular values, which decorrelates the rounding error
+	// from the signal.
+	DitherTriangular
+)
+
+// ditherState is the dither noise generator of a decoder, a linear
+// congruential generator restarted from Config.DitherSeed so that dithered
+// output is reproducible.
+type ditherState struct {
+	seed  uint32
+	state uint32
+}
+
+// reseed restarts the generator from seed.
+func (s *ditherState) reseed(seed uint32) {
+	s.seed = seed
+	s.state = seed
+}
+
+// uniform returns the next value of the generator, uniform over
+// [-0.5, 0.5).
+func (s *ditherState) uniform() float32 {
+	// Numerical Recipes LCG; the top 24 bits fit a float32 exactly
+	s.state = s.state*1664525 + 1013904223
+	return float32(s.state>>8)/(1<<24) - 0.5
+}
+
+// source returns the function giving the dither noise of each sample, in
+// LSBs of 16-bit output, or nil when mode adds none.
+func (s *ditherState) source(mode DitherMode) func() float32 {
+	switch mode {
+	case DitherRectangular:
+		return s.uniform
+	case DitherTriangular:
+		return func() float32 { return s.uniform() + s.uniform() }
+	default:
+		return nil
+	}
+}
@@ -0,0 +1,94 @@
+// dither_test.go
+package aac
+
+import (
+	"os"
+	"slices"
+	"testing"
+)
+
+// decodeDithered decodes sine1k.aac with the given dither configuration.
+func decodeDithered(t *testing.T, data []byte, mode DitherMode, seed uint32) [][]int16 {
+	t.Helper()
+	d := NewDecoder()
+	cfg := d.Config()
+	cfg.Dither = mode
+	cfg.DitherSeed = seed
+	if err := d.SetConfig(cfg); err != nil {
+		t.Fatalf("SetConfig: %v", err)
+	}
+	if _, err := d.Init(data); err != nil {
+		t.Fatalf("Init: %v", err)
+	}
+	frames, _ := decodeAll(t, d, data)
+	return frames
+}
+
+func TestDecode_Dither(t *testing.T) {
+	data, err := os.ReadFile("testdata/sine1k.aac")
+	if err != nil {
+		t.Skipf("sine1k.aac not available: %v", err)
+	}
+	ref := NewDecoder()
+	if _, err := ref.Init(data); err != nil {
+		t.Fatalf("Init: %v", err)
+	}
+	want, _ := decodeAll(t, ref, data)
+
+	// With dithering off the seed changes nothing
+	if got := decodeDithered(t, data, DitherNone, 1234); !slices.EqualFunc(got, want, slices.Equal) {
+		t.Error("DitherNone output differs from the default output")
+	}
+
+	for _, mode := range []DitherMode{DitherRectangular, DitherTriangular} {
+		got := decodeDithered(t, data, mode, 1234)
+		changed := 0
+		for f := range want {
+			if len(got[f]) != len(want[f]) {
+				t.Fatalf("mode %d frame %d: %d samples, want %d", mode, f, len(got[f]), len(want[f]))
+			}
+			for i, v := range got[f] {
+				diff := int(v) - int(want[f][i])
+				if diff < -1 || diff > 1 {
+					t.Fatalf("mode %d frame %d sample %d: %d, undithered %d", mode, f, i, v, want[f][i])
+				}
+				if diff != 0 {
+					changed++
+				}
+			}
+		}
+		if changed == 0 {
+			t.Errorf("mode %d: dithering changed no sample", mode)
+		}
+
+		// The same seed gives the same output, another seed does not
+		if again := decodeDithered(t, data, mode, 1234); !slices.EqualFunc(again, got, slices.Equal) {
+			t.Errorf("mode %d: output not reproducible with the same seed", mode)
+		}
+		if other := decodeDithered(t, data, mode, 99); slices.EqualFunc(other, got, slices.Equal) {
+			t.Errorf("mode %d: seed has no effect", mode)
+		}
+	}
+}
+
+func TestDitherState_Reset(t *testing.T) {
+	d := NewDecoder()
+	cfg := d.Config()
+	cfg.DitherSeed = 7
+	d.SetConfiguration(cfg)
+
+	noise := d.dither.source(DitherTriangular)
+	first := []float32{noise(), noise(), noise()}
+	for _, v := range first {
+		if v < -1 || v >= 1 {
+			t.Errorf("triangular noise %v outside [-1, 1)", v)
+		}
+	}
+	d.Reset()
+	if got := []float32{noise(), noise(), noise()}; !slices.Equal(got, first) {
+		t.Errorf("after Reset noise = %v, want %v", got, first)
+	}
+	if d.dither.source(DitherNone) != nil {
+		t.Error("DitherNone has a noise source")
+	}
+}
@@ -37,19 +37,35 @@
 //   - Init, Init2: Return (InitResult, error) with BytesConsumed
 //   - Decode, DecodeFloat: Return (samples, *FrameInfo, error)
 //
+// File API:
+//   - DecodeFile: Streams an ADTS file to an io.Writer as raw PCM
+//   - DecodeMP4: Decodes the AAC track of an MP4/M4A file sample by sample
+//   - BuildADTSHeader: Builds ADTS headers for re-muxing raw frames
+//
 // # Supported Formats
 //
 // Object Types: AAC-LC, Main, LTP, LD, Error Resilient LC/LTP
-// Container Formats: ADTS, Raw AAC (via Init2/AudioSpecificConfig)
+// Container Formats: ADTS, ADIF, MP4/M4A (unfragmented), LOAS/LATM (via Init, or InitLATM for RTP
+// with out-of-band config), Raw AAC (via Init2/AudioSpecificConfig)
 // Output Formats: 16-bit, 24-bit, 32-bit integer; 32/64-bit float
+// Frame Lengths: 1024, or 960 when the AudioSpecificConfig sets frameLengthFlag
+// (512 and 480 for AAC-LD)
+//
+// For HE-AAC (SBR) streams, the SBR headers are parsed by internal/sbr and
+// FrameInfo.SBRPresence reports the signalling, but the high band is not
+// reconstructed: the output is the AAC core, and FrameInfo.SampleRate
+// and FrameInfo.SBR report the core rate and SBRNone accordingly.
+// HE-AACv2 (PS) streams decode likewise to their mono core.
 //
-// HE-AAC (SBR) and HE-AACv2 (PS) support is planned for future releases.
+// SSR streams are rejected with ErrUnsupportedObjectType: their
+// gain_control_data is parsed, but the band synthesis is not ported.
 //
 // # Thread Safety
 //
 // Decoder instances are NOT safe for concurrent use. Each goroutine should
 // have its own Decoder. Read-only accessors (SampleRate, Channels, etc.)
-// are safe to call concurrently after Init.
+// are safe to call concurrently after Init. A DecoderPool lends decoders
+// to goroutines one at a time; re-Init a pooled decoder for each stream.
 //
 // # Reference
 //
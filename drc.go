@@ -0,0 +1,285 @@
+// drc.go
+package aac
+
+import (
+	"math"
+
+	"github.com/llehouerou/go-aac/internal/bits"
+)
+
+// Extension payload types of a fill element, local copies of the syntax
+// package constants to avoid an import cycle.
+//
+// Ported from: ~/dev/faad2/libfaad/syntax.h:82-91
+const (
+	extFillData     = 1
+	extDataElement  = 2
+	extDynamicRange = 11
+	ancData         = 0 // data_element_version of ancillary data
+)
+
+// drcRefLevel is the reference level of dynamic range control, -20 dB in
+// quarter dB steps below full scale.
+//
+// Ported from: DRC_REF_LEVEL in ~/dev/faad2/libfaad/drc.h:38
+const drcRefLevel = 80
+
+// drcMaxBands bounds the number of DRC bands in a dynamic_range_info().
+const drcMaxBands = 17
+
+// DRCControl selects how dynamic range control data carried in the
+// stream is applied. The gains scale the spectrum ahead of the filter
+// bank, so they take effect before output samples are clipped.
+type DRCControl struct {
+	// Cut scales the compression the stream signals for loud passages,
+	// from 0 (none) to 1 (as signalled).
+	Cut float32
+
+	// Boost scales the gain the stream signals for quiet passages, from
+	// 0 (none) to 1 (as signalled).
+	Boost float32
+
+	// TargetLevel is the level, in quarter dB below full scale, that the
+	// program reference level of the stream is normalized to. 0 selects
+	// FAAD2's reference of 80 (-20 dB), which leaves streams without a
+	// prog_ref_level untouched.
+	TargetLevel uint8
+}
+
+// drcInfo holds the dynamic range control data parsed from fill
+// elements. It persists across frames until refreshed by the stream.
+// Local version of syntax.DRCInfo to avoid import cycles.
+//
+// Ported from: drc_info in ~/dev/faad2/libfaad/structs.h:85-101
+type drcInfo struct {
+	present             bool
+	numBands            uint8
+	pceInstanceTag      uint8
+	excludedChnsPresent bool
+	bandTop             [drcMaxBands]uint8
+	progRefLevel        uint8
+	dynRngSgn           [drcMaxBands]uint8
+	dynRngCtl           [drcMaxBands]uint8
+	excludeMask         [maxChannels]uint8
+}
+
+// newDRCInfo returns the DRC state of a new stream.
+//
+// Ported from: drc_init() in ~/dev/faad2/libfaad/drc.c:38-52
+func newDRCInfo() *drcInfo {
+	return &drcInfo{
+		numBands:     1,
+		bandTop:      [drcMaxBands]uint8{1024/4 - 1},
+		progRefLevel: drcRefLevel,
+	}
+}
+
+// parseFillElement consumes a fill element, parsing dynamic range info
+// into drc, and reports whether it carries SBR data. SBR payloads go to
+// the decoder returned by sbr, and are skipped if sbr is nil or returns
+// nil.
+// This is a local version to avoid import cycles with the syntax package.
+//
+// Ported from: fill_element() in ~/dev/faad2/libfaad/syntax.c:1110-1197
+func parseFillElement(r *bits.Reader, drc *drcInfo, sbr func() sbrExtensionDecoder) bool {
+	count := r.GetBits(4)
+	if count == 15 {
+		count += r.GetBits(8) - 1
+	}
+	if count == 0 {
+		return false
+	}
+
+	extensionType := r.ShowBits(4)
+	if extensionType == extSBRData || extensionType == extSBRDataCRC {
+		start := r.GetProcessedBits()
+		var dec sbrExtensionDecoder
+		if sbr != nil {
+			dec = sbr()
+		}
+		if dec != nil {
+			// An invalid SBR header leaves the core output unaffected,
+			// as in FAAD2, so the error is not reported
+			_ = dec.DecodeExtension(r, count)
+		}
+		for pos := r.GetProcessedBits(); pos < start+8*count; pos = r.GetProcessedBits() {
+			r.FlushBits(uint(min(start+8*count-pos, 8)))
+		}
+		return true
+	}
+
+	for count > 0 {
+		n := parseExtensionPayload(r, drc, count)
+		if n >= count {
+			break
+		}
+		count -= n
+	}
+	return false
+}
+
+// parseExtensionPayload parses an extension_payload() of count bytes and
+// returns the number of bytes it took.
+//
+// Ported from: extension_payload() in ~/dev/faad2/libfaad/syntax.c:2240-2299
+func parseExtensionPayload(r *bits.Reader, drc *drcInfo, count uint32) uint32 {
+	align := uint(4)
+
+	switch r.GetBits(4) {
+	case extDynamicRange:
+		drc.present = true
+		return parseDynamicRangeInfo(r, drc)
+
+	case extFillData:
+		r.FlushBits(4) // fill_nibble
+		for i := uint32(1); i < count; i++ {
+			r.FlushBits(8) // fill_byte
+		}
+		return count
+
+	case extDataElement:
+		if r.GetBits(4) == ancData {
+			loopCounter := uint32(0)
+			dataElementLength := uint32(0)
+			for {
+				part := r.GetBits(8)
+				dataElementLength += part
+				loopCounter++
+				if part != 255 {
+					break
+				}
+			}
+			// FAAD2 reads a single data_element_byte
+			if dataElementLength > 0 {
+				r.FlushBits(8)
+				return dataElementLength + loopCounter + 1
+			}
+			return loopCounter + 1
+		}
+		align = 0
+	}
+
+	// EXT_FIL and unknown types: fill_nibble, then other_bits
+	r.FlushBits(align)
+	for i := uint32(1); i < count; i++ {
+		r.FlushBits(8)
+	}
+	return count
+}
+
+// parseDynamicRangeInfo parses a dynamic_range_info() and returns the
+// number of bytes it took.
+//
+// Ported from: dynamic_range_info() in ~/dev/faad2/libfaad/syntax.c:2302-2364
+func parseDynamicRangeInfo(r *bits.Reader, drc *drcInfo) uint32 {
+	n := uint32(1)
+
+	drc.numBands = 1
+
+	if r.Get1Bit() == 1 { // pce_tag_present
+		drc.pceInstanceTag = uint8(r.GetBits(4))
+		r.FlushBits(4) // drc_tag_reserved_bits
+		n++
+	}
+
+	drc.excludedChnsPresent = r.Get1Bit() == 1
+	if drc.excludedChnsPresent {
+		n += parseExcludedChannels(r, drc)
+	}
+
+	if r.Get1Bit() == 1 { // drc_bands_present
+		bandIncr := uint8(r.GetBits(4))
+		r.FlushBits(4) // drc_bands_reserved_bits
+		n++
+		drc.numBands = min(drc.numBands+bandIncr, drcMaxBands)
+		for i := uint8(0); i < drc.numBands; i++ {
+			drc.bandTop[i] = uint8(r.GetBits(8))
+			n++
+		}
+	}
+
+	if r.Get1Bit() == 1 { // prog_ref_level_present
+		drc.progRefLevel = uint8(r.GetBits(7))
+		r.FlushBits(1) // prog_ref_level_reserved_bits
+		n++
+	}
+
+	for i := uint8(0); i < drc.numBands; i++ {
+		drc.dynRngSgn[i] = uint8(r.Get1Bit())
+		drc.dynRngCtl[i] = uint8(r.GetBits(7))
+		n++
+	}
+	return n
+}
+
+// parseExcludedChannels parses an excluded_channels() and returns the
+// number of bytes it took.
+//
+// Ported from: excluded_channels() in ~/dev/faad2/libfaad/syntax.c:2367-2394
+func parseExcludedChannels(r *bits.Reader, drc *drcInfo) uint32 {
+	n := uint32(0)
+	numExclChan := 7
+
+	for i := 0; i < 7; i++ {
+		drc.excludeMask[i] = uint8(r.Get1Bit())
+	}
+	n++
+
+	for r.Get1Bit() == 1 { // additional_excluded_chns
+		if numExclChan >= maxChannels-7 {
+			return n
+		}
+		for i := numExclChan; i < numExclChan+7; i++ {
+			drc.excludeMask[i] = uint8(r.Get1Bit())
+		}
+		n++
+		numExclChan += 7
+	}
+	return n
+}
+
+// applyDRC applies the stream's dynamic range control gains to the
+// reconstructed spectrum of a channel, when enabled by Config.DRC and not
+// excluded for the channel.
+//
+// Ported from: drc_decode() in ~/dev/faad2/libfaad/drc.c:112-172 and its
+// caller in ~/dev/faad2/libfaad/specrec.c:1022-1030
+func (d *Decoder) applyDRC(spec []float32, channel uint8) {
+	ctrl := d.config.DRC
+	drc := d.drc
+	if ctrl == nil || drc == nil || !drc.present {
+		return
+	}
+	if drc.excludedChnsPresent && drc.excludeMask[channel] == 1 {
+		return
+	}
+
+	refLevel := float64(drcRefLevel)
+	if ctrl.TargetLevel != 0 {
+		refLevel = float64(ctrl.TargetLevel)
+	}
+	if drc.numBands == 1 {
+		drc.bandTop[0] = 1024/4 - 1
+	}
+
+	bottom := 0
+	for bd := uint8(0); bd < drc.numBands; bd++ {
+		top := min(4*(int(drc.bandTop[bd])+1), len(spec))
+
+		ctl := float64(drc.dynRngCtl[bd])
+		var exp float64
+		if drc.dynRngSgn[bd] == 1 {
+			// Compress
+			exp = (-float64(ctrl.Cut)*ctl - (refLevel - float64(drc.progRefLevel))) / 24
+		} else {
+			// Boost
+			exp = (float64(ctrl.Boost)*ctl - (refLevel - float64(drc.progRefLevel))) / 24
+		}
+		factor := float32(math.Pow(2, exp))
+
+		for i := bottom; i < top; i++ {
+			spec[i] *= factor
+		}
+		bottom = max(bottom, top)
+	}
+}
@@ -0,0 +1,262 @@
+// drc_test.go
+package aac
+
+import (
+	"math"
+	"testing"
+
+	"github.com/llehouerou/go-aac/internal/bits"
+)
+
+// writeDRCFill writes the body of a fill element (after its element ID)
+// carrying a dynamic_range_info() with one band per dynRngCtl value and,
+// when excludeMask is non-zero, an excluded_channels() for channels 0-6.
+// A negative control value signals compression.
+func writeDRCFill(w *adifBitWriter, excludeMask uint8, bandTop []uint8, dynRngCtl []int) {
+	count := 1 + len(dynRngCtl) // extension type, flags and band controls
+	if excludeMask != 0 {
+		count++
+	}
+	if len(bandTop) > 0 {
+		count += 1 + len(bandTop)
+	}
+	w.writeBits(uint32(count), 4)
+	w.writeBits(extDynamicRange, 4)
+
+	w.writeBits(0, 1) // pce_tag_present
+	if excludeMask != 0 {
+		w.writeBits(1, 1)
+		w.writeBits(uint32(excludeMask), 7)
+		w.writeBits(0, 1) // additional_excluded_chns
+	} else {
+		w.writeBits(0, 1)
+	}
+	if len(bandTop) > 0 {
+		w.writeBits(1, 1)
+		w.writeBits(uint32(len(bandTop)-1), 4) // drc_band_incr
+		w.writeBits(0, 4)
+		for _, top := range bandTop {
+			w.writeBits(uint32(top), 8)
+		}
+	} else {
+		w.writeBits(0, 1)
+	}
+	w.writeBits(0, 1) // prog_ref_level_present
+
+	for _, ctl := range dynRngCtl {
+		if ctl < 0 {
+			w.writeBits(1, 1)
+			w.writeBits(uint32(-ctl), 7)
+		} else {
+			w.writeBits(0, 1)
+			w.writeBits(uint32(ctl), 7)
+		}
+	}
+}
+
+func TestParseFillElement_SingleBandDRC(t *testing.T) {
+	w := &adifBitWriter{}
+	writeDRCFill(w, 0, nil, []int{-24})
+	w.writeBits(7, 3) // ID_END
+
+	drc := newDRCInfo()
+	r := bits.NewReader(w.buf)
+	if parseFillElement(r, drc, nil) {
+		t.Fatal("DRC payload reported as SBR")
+	}
+	if got := r.GetBits(3); got != 7 {
+		t.Fatalf("next element = %d, want ID_END", got)
+	}
+	if !drc.present || drc.numBands != 1 || drc.excludedChnsPresent {
+		t.Fatalf("present=%v numBands=%d excluded=%v", drc.present, drc.numBands, drc.excludedChnsPresent)
+	}
+	if drc.dynRngSgn[0] != 1 || drc.dynRngCtl[0] != 24 {
+		t.Errorf("band 0: sgn=%d ctl=%d, want 1, 24", drc.dynRngSgn[0], drc.dynRngCtl[0])
+	}
+	if drc.progRefLevel != drcRefLevel {
+		t.Errorf("progRefLevel = %d, want %d", drc.progRefLevel, drcRefLevel)
+	}
+
+	// Compressing by 24 quarter dB steps at full cut halves the spectrum
+	d := NewDecoder()
+	d.drc = drc
+	d.config.DRC = &DRCControl{Cut: 1, Boost: 1}
+	spec := []float32{1, -2, 4, 8}
+	d.applyDRC(spec, 0)
+	for i, want := range []float32{0.5, -1, 2, 4} {
+		if spec[i] != want {
+			t.Errorf("spec[%d] = %v, want %v", i, spec[i], want)
+		}
+	}
+
+	// Without DRCControl the stream's DRC data is ignored
+	d.config.DRC = nil
+	spec = []float32{1}
+	d.applyDRC(spec, 0)
+	if spec[0] != 1 {
+		t.Errorf("spec[0] = %v without DRCControl, want 1", spec[0])
+	}
+}
+
+func TestParseFillElement_ExcludedChannelsDRC(t *testing.T) {
+	w := &adifBitWriter{}
+	writeDRCFill(w, 0x20, nil, []int{-24}) // mask bits are MSB first: channel 1
+	w.writeBits(7, 3)
+
+	drc := newDRCInfo()
+	r := bits.NewReader(w.buf)
+	parseFillElement(r, drc, nil)
+	if got := r.GetBits(3); got != 7 {
+		t.Fatalf("next element = %d, want ID_END", got)
+	}
+	if !drc.excludedChnsPresent {
+		t.Fatal("excludedChnsPresent = false")
+	}
+	for ch, want := range []uint8{0, 1, 0, 0, 0, 0, 0, 0} {
+		if drc.excludeMask[ch] != want {
+			t.Errorf("excludeMask[%d] = %d, want %d", ch, drc.excludeMask[ch], want)
+		}
+	}
+
+	d := NewDecoder()
+	d.drc = drc
+	d.config.DRC = &DRCControl{Cut: 1}
+	left, right := []float32{1}, []float32{1}
+	d.applyDRC(left, 0)
+	d.applyDRC(right, 1)
+	if left[0] != 0.5 || right[0] != 1 {
+		t.Errorf("left, right = %v, %v; want 0.5, 1", left[0], right[0])
+	}
+}
+
+func TestApplyDRC_Bands(t *testing.T) {
+	w := &adifBitWriter{}
+	// Bands of 8 and 24 lines: boost 24 steps, compress 12 steps
+	writeDRCFill(w, 0, []uint8{1, 7}, []int{24, -12})
+	drc := newDRCInfo()
+	parseFillElement(bits.NewReader(w.buf), drc, nil)
+	if drc.numBands != 2 {
+		t.Fatalf("numBands = %d, want 2", drc.numBands)
+	}
+
+	tests := []struct {
+		name  string
+		ctrl  DRCControl
+		gains [2]float64 // per band
+	}{
+		{"full", DRCControl{Cut: 1, Boost: 1}, [2]float64{2, math.Pow(2, -0.5)}},
+		{"half cut, no boost", DRCControl{Cut: 0.5}, [2]float64{1, math.Pow(2, -0.25)}},
+		// A target 6 dB below the reference halves every band
+		{"target level", DRCControl{TargetLevel: drcRefLevel + 24}, [2]float64{0.5, 0.5}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := NewDecoder()
+			d.drc = drc
+			d.config.DRC = &tt.ctrl
+			spec := make([]float32, 40)
+			for i := range spec {
+				spec[i] = 1
+			}
+			d.applyDRC(spec, 0)
+			for i, v := range spec {
+				want := 1.0
+				switch {
+				case i < 8:
+					want = tt.gains[0]
+				case i < 32:
+					want = tt.gains[1]
+				}
+				if math.Abs(float64(v)-want) > 1e-6 {
+					t.Fatalf("spec[%d] = %v, want %v", i, v, want)
+				}
+			}
+		})
+	}
+}
+
+// recordingSBRDecoder records the SBR payloads routed to it and reads
+// only the first byte of each.
+type recordingSBRDecoder struct {
+	sampleRate uint32
+	counts     []uint32
+	extTypes   []uint32
+}
+
+func (s *recordingSBRDecoder) DecodeExtension(r *bits.Reader, count uint32) error {
+	s.counts = append(s.counts, count)
+	s.extTypes = append(s.extTypes, r.GetBits(4))
+	r.FlushBits(4)
+	return ErrInvalidSBRParameter
+}
+
+func TestParseFillElement_RoutesSBR(t *testing.T) {
+	saved := sbrDecoderFactory
+	defer RegisterSBRDecoderFactory(saved)
+
+	var created *recordingSBRDecoder
+	RegisterSBRDecoderFactory(func(sampleRate uint32) any {
+		created = &recordingSBRDecoder{sampleRate: sampleRate}
+		return created
+	})
+
+	// Fill element with a 3-byte EXT_SBR_DATA_CRC payload, then ID_END
+	w := &adifBitWriter{}
+	w.writeBits(3, 4)
+	w.writeBits(extSBRDataCRC, 4)
+	w.writeBits(0xABCDE, 20)
+	w.writeBits(7, 3)
+
+	d := NewDecoder()
+	d.sfIndex = 7 // 22050 Hz
+	r := bits.NewReader(w.buf)
+	if !parseFillElement(r, newDRCInfo(), d.sbrDecoder) {
+		t.Fatal("SBR payload not reported")
+	}
+	if got := r.GetBits(3); got != 7 {
+		t.Fatalf("next element = %d, want ID_END", got)
+	}
+	if created == nil || created.sampleRate != 44100 {
+		t.Fatalf("SBR decoder not created at twice the core rate: %+v", created)
+	}
+	if len(created.counts) != 1 || created.counts[0] != 3 || created.extTypes[0] != extSBRDataCRC {
+		t.Errorf("routed payloads: counts %v, types %v; want [3], [%d]", created.counts, created.extTypes, extSBRDataCRC)
+	}
+
+	// The decoder is kept for the next payloads
+	parseFillElement(bits.NewReader(w.buf), newDRCInfo(), d.sbrDecoder)
+	if len(created.counts) != 2 {
+		t.Errorf("second payload went to a new decoder")
+	}
+}
+
+func TestParseFillElement_SkipsSBR(t *testing.T) {
+	// Fill element with an escaped count of 20 bytes of EXT_SBR_DATA,
+	// then ID_END, parsed with no SBR decoder
+	w := &adifBitWriter{}
+	w.writeBits(15, 4)
+	w.writeBits(6, 8) // count = 15 + 6 - 1
+	w.writeBits(extSBRData, 4)
+	for range 19 {
+		w.writeBits(0xA5, 8)
+	}
+	w.writeBits(0x5, 4)
+	w.writeBits(7, 3)
+
+	for _, sbr := range []func() sbrExtensionDecoder{nil, func() sbrExtensionDecoder { return nil }} {
+		r := bits.NewReader(w.buf)
+		drc := newDRCInfo()
+		if !parseFillElement(r, drc, sbr) {
+			t.Fatal("SBR payload not reported")
+		}
+		if got := r.GetProcessedBits(); got != 4+8+20*8 {
+			t.Errorf("consumed %d bits, want %d", got, 4+8+20*8)
+		}
+		if got := r.GetBits(3); got != 7 {
+			t.Errorf("next element = %d, want ID_END", got)
+		}
+		if drc.present {
+			t.Error("SBR payload parsed as DRC")
+		}
+	}
+}
@@ -1,5 +1,7 @@
 package aac
 
+import "strconv"
+
 // Error represents an AAC decoder error code.
 // Ported from: ~/dev/faad2/libfaad/error.c, error.h
 type Error int
@@ -49,7 +51,51 @@ const (
 	ErrBufferTooSmall        Error = 37 // buffer too small (< 2 bytes)
 	ErrUnsupportedObjectType Error = 38 // unsupported audio object type
 	ErrInvalidSampleRate     Error = 39 // invalid sample rate (0)
-	ErrADIFNotSupported      Error = 40 // ADIF format not yet supported
+
+	// ErrADIFNotSupported was returned by Init for ADIF streams.
+	//
+	// Deprecated: ADIF streams are decoded and this error is no longer
+	// returned. Its code stays reserved.
+	ErrADIFNotSupported Error = 40
+
+	ErrNoHeaderDetected Error = 41 // no ADTS/ADIF header, raw streams need Init2
+
+	// Encoding errors (go-aac specific).
+	ErrADTSFrameTooLong Error = 42 // frame does not fit the 13-bit aac_frame_length
+
+	// Configuration errors (go-aac specific).
+	ErrEPConfigNotSupported Error = 43 // ER AudioSpecificConfig with epConfig != 0
+	ErrLayoutChanged        Error = 44 // mid-stream PCE without Config.AllowLayoutChange
+
+	// Streaming errors (go-aac specific).
+	ErrSampleRingClosed Error = 45 // Write on a closed SampleRing
+
+	// Integrity errors (go-aac specific).
+	ErrADTSCRCMismatch Error = 46 // ADTS crc_check differs, with Config.VerifyCRC
+
+	// LATM errors (go-aac specific).
+	ErrLOASSyncwordNotFound Error = 47 // LOAS frame without the 0x2B7 syncword
+	ErrLATMConfigMissing    Error = 48 // AudioMuxElement before any StreamMuxConfig
+	ErrLATMNotSupported     Error = 49 // multiplex beyond one program, layer and access unit
+
+	// MP4 container errors (go-aac specific).
+	ErrMP4NoAudioTrack Error = 50 // no track with an AAC mp4a sample entry
+	ErrMP4Fragmented   Error = 51 // moof/mvex boxes, fragmented MP4
+	ErrMP4Invalid      Error = 52 // malformed box structure or sample tables
+
+	// SetConfig errors (go-aac specific).
+	ErrInvalidConfig     Error = 53 // Config field out of range
+	ErrConfigNeedsReinit Error = 54 // change to a Config field read at Init
+
+	// Config.MaxChannels error (go-aac specific).
+	ErrChannelLimitExceeded Error = 55 // frame decodes to more than Config.MaxChannels
+
+	// Config.Strict errors (go-aac specific).
+	ErrTNSOrderOutOfRange Error = 56 // TNS filter order above the maximum, clamped otherwise
+	ErrGlobalGainMismatch Error = 57 // global_gain differs from the first scalefactor
+
+	// ImportState error (go-aac specific).
+	ErrInvalidState Error = 58 // malformed state, or exported for another stream
 )
 
 // errMessages contains error messages matching FAAD2 exactly.
@@ -95,7 +141,25 @@ var errMessages = map[Error]string{
 	37: "buffer too small",
 	38: "unsupported audio object type",
 	39: "invalid sample rate",
-	40: "ADIF format not yet supported",
+	40: "ADIF format not supported",
+	41: "no ADTS or ADIF header detected; use Init2 with an AudioSpecificConfig for raw AAC",
+	42: "ADTS frame length exceeds 8191 bytes",
+	43: "error protection (epConfig != 0) not supported",
+	44: "program config element changes the channel layout",
+	45: "sample ring closed",
+	46: "ADTS CRC check failed",
+	47: "unable to find LOAS syncword",
+	48: "LATM frame without StreamMuxConfig",
+	49: "LATM multiplex configuration not supported",
+	50: "no AAC audio track in MP4 file",
+	51: "fragmented MP4 not supported",
+	52: "invalid MP4 box structure",
+	53: "invalid decoder configuration",
+	54: "configuration change requires re-initialization",
+	55: "channel limit exceeded",
+	56: "TNS filter order out of range",
+	57: "global_gain differs from the first scalefactor",
+	58: "invalid decoder state",
 }
 
 // Error implements the error interface.
@@ -115,3 +179,21 @@ func GetErrorMessage(errcode Error) string {
 	}
 	return "unknown error"
 }
+
+// UnsupportedObjectTypeError is returned by Init and Init2 for a stream
+// whose audio object type the decoder cannot decode, such as scalable,
+// TwinVQ or CELP. Type is the audio object type of the stream. It wraps
+// ErrUnsupportedObjectType, which errors.Is matches.
+type UnsupportedObjectTypeError struct {
+	Type uint8
+}
+
+// Error implements the error interface.
+func (e *UnsupportedObjectTypeError) Error() string {
+	return errMessages[ErrUnsupportedObjectType] + " " + strconv.Itoa(int(e.Type))
+}
+
+// Unwrap returns ErrUnsupportedObjectType.
+func (e *UnsupportedObjectTypeError) Unwrap() error {
+	return ErrUnsupportedObjectType
+}
@@ -0,0 +1,196 @@
+// force_channels.go
+package aac
+
+import (
+	"github.com/llehouerou/go-aac/internal/output"
+	"github.com/llehouerou/go-aac/internal/syntax"
+)
+
+// ForceChannelsMode selects how Config.ForceChannels adapts the decoded
+// channels to the forced count.
+type ForceChannelsMode uint8
+
+const (
+	// ForceChannelsMix duplicates a mono source into the first two output
+	// channels and mixes 5.0/5.1 and 7.1 down to stereo like DownMatrix,
+	// with the ITU-R BS.775-1 matrix or the matrix mixdown signalled by a
+	// PCE. Any other mismatch is padded with silence or truncated.
+	ForceChannelsMix ForceChannelsMode = iota
+
+	// ForceChannelsPad pads missing channels with silence and drops the
+	// channels beyond the forced count.
+	ForceChannelsPad
+)
+
+// forcedStereoMix reports whether ForceChannels turns the decoded channels
+// into a stereo mix rather than padding or truncating them.
+func (d *Decoder) forcedStereoMix(decoded uint8) bool {
+	if d.config.ForceChannels != 2 || d.config.ForceChannelsMode != ForceChannelsMix {
+		return false
+	}
+	return decoded == 1 || d.stereoMixable(decoded)
+}
+
+// stereoMixable reports whether decoded channels are in a layout the
+// Downmixer mixes to stereo: 5.0/5.1, or 7.1, eight channels ending with
+// an LFE as in channel configuration 7 and in the front, side, back, LFE
+// order of a PCE. The LFE is checked rather than the configuration, which
+// ADTS initialization reports as 2 for configuration 7 like FAAD2.
+func (d *Decoder) stereoMixable(decoded uint8) bool {
+	return decoded == 5 || decoded == 6 || (decoded == 8 && d.hasLFE)
+}
+
+// matrixMixdown reports whether the frame's 5.0/5.1 or 7.1 channels are
+// mixed down to stereo by DownMatrix.
+func (d *Decoder) matrixMixdown() bool {
+	return d.downMatrix && d.stereoMixable(d.frChannels)
+}
+
+// preciseDownmix reports whether the frame's DownMatrix mix is computed
+// in float64 for Config.HighPrecisionDownmix: a 5.0/5.1 mix to double
+// output with the ITU-R BS.775-1 matrix and without the LFE.
+func (d *Decoder) preciseDownmix() bool {
+	return d.config.HighPrecisionDownmix && d.config.OutputFormat == OutputFormatDouble &&
+		d.matrixMixdown() && d.frChannels <= 6 && d.mixdownPCE() == nil && !d.config.IncludeLFE
+}
+
+// mixdownPCE returns the current PCE if it signals a matrix mixdown,
+// which then replaces the ITU-R BS.775-1 coefficients of the 5.0/5.1 to
+// stereo mix.
+func (d *Decoder) mixdownPCE() *syntax.ProgramConfig {
+	if !d.pceSet || d.pce == nil || !d.pce.MatrixMixdownIdxPresent {
+		return nil
+	}
+	return d.pce
+}
+
+// configureDownmixer sets up d.downmixer for the frame's stereo mix from
+// the configuration and the matrix mixdown of the current PCE.
+func (d *Decoder) configureDownmixer() {
+	dm := &d.downmixer
+	dm.Enabled = true
+	dm.IncludeLFE = d.config.IncludeLFE
+	dm.LFEGain = d.config.LFEGain
+	if dm.LFEGain == 0 {
+		dm.LFEGain = output.DefaultLFEGain
+	}
+	switch {
+	case d.config.LFECutoff == 0:
+		dm.LFECutoff = output.DefaultLFECutoff
+	case d.config.LFECutoff < 0:
+		dm.LFECutoff = 0
+	default:
+		dm.LFECutoff = d.config.LFECutoff
+	}
+	dm.SampleRate = d.coreSampleRate()
+	dm.MatrixMixdown = false
+	if pce := d.mixdownPCE(); pce != nil {
+		dm.MatrixMixdown = true
+		dm.MatrixMixdownIdx = pce.MatrixMixdownIdx & 3
+		dm.PseudoSurround = pce.PseudoSurroundEnable
+	}
+}
+
+// outputSources returns the time-domain buffer feeding each of the
+// outputChannels output channels. A nil entry is output as silence.
+func (d *Decoder) outputSources(outputChannels uint8) [][]float32 {
+	decoded := d.frChannels
+	sources := make([][]float32, outputChannels)
+
+	if d.monoMixdown() {
+		sources[0] = d.monoMix()
+		return sources
+	}
+
+	upmix := d.upMatrix && decoded == 1 && outputChannels == 2
+	if !upmix && !d.matrixMixdown() {
+		if d.config.ForceChannels == 0 || decoded == 0 {
+			copy(sources, d.timeOut[:outputChannels])
+			return sources
+		}
+
+		if !d.forcedStereoMix(decoded) {
+			copy(sources, d.timeOut[:min(decoded, outputChannels)])
+			return sources
+		}
+	}
+
+	if decoded == 1 {
+		sources[0] = d.timeOut[0]
+		sources[1] = d.timeOut[0]
+		return sources
+	}
+
+	// 5.0/5.1 in C, L, R, Ls, Rs order, or 7.1 with Lrs and Rrs after
+	// them, then the LFE, which the Downmixer leaves out as in FAAD2 unless
+	// Config.IncludeLFE is set
+	// Ported from: get_sample() in ~/dev/faad2/libfaad/output.c:45-61
+	channelMap := []uint8{
+		output.ChannelCenter, output.ChannelFrontLeft, output.ChannelFrontRight,
+		output.ChannelRearLeft, output.ChannelRearRight, output.ChannelLFE,
+	}
+	if decoded == 8 {
+		channelMap = []uint8{
+			output.ChannelCenter, output.ChannelFrontLeft, output.ChannelFrontRight,
+			output.Channel71SideLeft, output.Channel71SideRight,
+			output.Channel71BackLeft, output.Channel71BackRight, output.Channel71LFE,
+		}
+	}
+	if last := len(channelMap) - 1; decoded == 5 || d.timeOut[channelMap[last]] == nil {
+		channelMap = channelMap[:last]
+	}
+	for _, ch := range channelMap {
+		if d.timeOut[ch] == nil {
+			return sources
+		}
+	}
+	frameLen := int(d.frameLength)
+	if len(d.forceMix[0]) != frameLen {
+		d.forceMix[0] = make([]float32, frameLen)
+		d.forceMix[1] = make([]float32, frameLen)
+	}
+	d.configureDownmixer()
+	d.downmixer.DownmixFrameInto(d.forceMix[0], d.forceMix[1], d.timeOut[:decoded], channelMap, d.frameLength)
+	sources[0] = d.forceMix[0]
+	sources[1] = d.forceMix[1]
+	return sources
+}
+
+// applyForcedLayout adjusts the channel positions of info to the forced
+// channel count: a stereo mix is reported as front left/right, truncated
+// channels are removed and padded channels stay ChannelUnknown.
+func (d *Decoder) applyForcedLayout(info *FrameInfo) {
+	forced := d.config.ForceChannels
+	if forced == 0 || forced == d.frChannels {
+		return
+	}
+
+	if d.forcedStereoMix(d.frChannels) {
+		for i := range info.ChannelPosition {
+			info.ChannelPosition[i] = ChannelUnknown
+		}
+		info.ChannelPosition[0] = ChannelFrontLeft
+		info.ChannelPosition[1] = ChannelFrontRight
+	} else {
+		for i := int(forced); i < len(info.ChannelPosition); i++ {
+			info.ChannelPosition[i] = ChannelUnknown
+		}
+	}
+
+	info.NumFrontChannels = 0
+	info.NumSideChannels = 0
+	info.NumBackChannels = 0
+	info.NumLFEChannels = 0
+	for _, pos := range info.ChannelPosition[:forced] {
+		switch pos {
+		case ChannelFrontCenter, ChannelFrontLeft, ChannelFrontRight:
+			info.NumFrontChannels++
+		case ChannelSideLeft, ChannelSideRight:
+			info.NumSideChannels++
+		case ChannelBackLeft, ChannelBackRight, ChannelBackCenter:
+			info.NumBackChannels++
+		case ChannelLFE:
+			info.NumLFEChannels++
+		}
+	}
+}
@@ -0,0 +1,183 @@
+// force_channels_test.go
+package aac
+
+import (
+	"math"
+	"testing"
+
+	"github.com/llehouerou/go-aac/internal/output"
+)
+
+// newForceChannelsDecoder returns a decoder in the post-reconstruction
+// state of a frame with the given standard channel configuration, where
+// channel ch holds the constant value 1000*(ch+1).
+func newForceChannelsDecoder(t *testing.T, channels uint8, forced uint8, mode ForceChannelsMode) *Decoder {
+	t.Helper()
+	d := NewDecoder()
+	cfg := d.Config()
+	cfg.ForceChannels = forced
+	cfg.ForceChannelsMode = mode
+	d.SetConfiguration(cfg)
+
+	d.frameLength = 1024
+	d.channelConfiguration = channels
+	d.frChannels = channels
+	if err := d.allocateChannelBuffers(channels); err != nil {
+		t.Fatalf("allocateChannelBuffers: %v", err)
+	}
+	for ch := uint8(0); ch < channels; ch++ {
+		for i := range d.timeOut[ch] {
+			d.timeOut[ch][i] = float32(1000 * (int(ch) + 1))
+		}
+	}
+	return d
+}
+
+// firstFrame returns the first interleaved sample frame of the output.
+func firstFrame(t *testing.T, d *Decoder, outputChannels uint8) []int16 {
+	t.Helper()
+	s16, ok := d.generatePCMOutput(outputChannels).([]int16)
+	if !ok {
+		t.Fatal("expected []int16 samples")
+	}
+	if len(s16) != int(d.frameLength)*int(outputChannels) {
+		t.Fatalf("samples length: got %d, want %d", len(s16), int(d.frameLength)*int(outputChannels))
+	}
+	return s16[:outputChannels]
+}
+
+func TestForceChannels_MonoToStereo(t *testing.T) {
+	tests := []struct {
+		name string
+		mode ForceChannelsMode
+		want []int16
+		pos  [2]ChannelPosition
+	}{
+		{"mix duplicates", ForceChannelsMix, []int16{1000, 1000}, [2]ChannelPosition{ChannelFrontLeft, ChannelFrontRight}},
+		{"pad with silence", ForceChannelsPad, []int16{1000, 0}, [2]ChannelPosition{ChannelFrontCenter, ChannelUnknown}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := newForceChannelsDecoder(t, 1, 2, tt.mode)
+
+			got := firstFrame(t, d, 2)
+			for ch := range tt.want {
+				if got[ch] != tt.want[ch] {
+					t.Errorf("channel %d: got %d, want %d", ch, got[ch], tt.want[ch])
+				}
+			}
+
+			info := &FrameInfo{}
+			d.createChannelConfig(info)
+			if info.ChannelPosition[0] != tt.pos[0] || info.ChannelPosition[1] != tt.pos[1] {
+				t.Errorf("positions: got %v, want %v", info.ChannelPosition[:2], tt.pos)
+			}
+		})
+	}
+}
+
+func TestForceChannels_5_1ToStereo(t *testing.T) {
+	// C=1000, L=2000, R=3000, Ls=4000, Rs=5000, LFE=6000
+	mixL := output.DownmixMul * (2000 + 1000*output.InvSqrt2 + 4000*output.InvSqrt2)
+	mixR := output.DownmixMul * (3000 + 1000*output.InvSqrt2 + 5000*output.InvSqrt2)
+
+	tests := []struct {
+		name      string
+		mode      ForceChannelsMode
+		want      []int16
+		wantFront uint8
+		wantBack  uint8
+	}{
+		{"mix downmixes", ForceChannelsMix, []int16{int16(mixL), int16(mixR)}, 2, 0},
+		{"pad truncates", ForceChannelsPad, []int16{1000, 2000}, 2, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := newForceChannelsDecoder(t, 6, 2, tt.mode)
+
+			got := firstFrame(t, d, 2)
+			for ch := range tt.want {
+				if got[ch] != tt.want[ch] {
+					t.Errorf("channel %d: got %d, want %d", ch, got[ch], tt.want[ch])
+				}
+			}
+
+			info := &FrameInfo{}
+			d.createChannelConfig(info)
+			if info.NumFrontChannels != tt.wantFront || info.NumBackChannels != tt.wantBack || info.NumLFEChannels != 0 {
+				t.Errorf("counts: got front=%d back=%d lfe=%d", info.NumFrontChannels, info.NumBackChannels, info.NumLFEChannels)
+			}
+			if info.ChannelPosition[2] != ChannelUnknown {
+				t.Errorf("ChannelPosition[2]: got %d, want ChannelUnknown", info.ChannelPosition[2])
+			}
+		})
+	}
+}
+
+func TestForceChannels_7_1ToStereo(t *testing.T) {
+	d := newForceChannelsDecoder(t, 8, 2, ForceChannelsMix)
+	d.channelConfiguration = 7
+	d.hasLFE = true
+
+	// C=1000, L=2000, R=3000, Ls=4000, Rs=5000, Lrs=6000, Rrs=7000
+	rearL := (4000 + 6000) * output.InvSqrt2
+	rearR := (5000 + 7000) * output.InvSqrt2
+	mixL := output.DownmixMul * (2000 + 1000*output.InvSqrt2 + rearL*output.InvSqrt2)
+	mixR := output.DownmixMul * (3000 + 1000*output.InvSqrt2 + rearR*output.InvSqrt2)
+	got := firstFrame(t, d, 2)
+	if want := []int16{int16(math.RoundToEven(float64(mixL))), int16(math.RoundToEven(float64(mixR)))}; got[0] != want[0] || got[1] != want[1] {
+		t.Errorf("got %v, want %v", got, want)
+	}
+}
+
+func TestForceChannels_StereoPaddedTo5_1(t *testing.T) {
+	d := newForceChannelsDecoder(t, 2, 6, ForceChannelsMix)
+
+	got := firstFrame(t, d, 6)
+	want := []int16{1000, 2000, 0, 0, 0, 0}
+	for ch := range want {
+		if got[ch] != want[ch] {
+			t.Errorf("channel %d: got %d, want %d", ch, got[ch], want[ch])
+		}
+	}
+}
+
+func TestDownmixMono(t *testing.T) {
+	// Channels hold 1000, 2000, ...: L, R in stereo, C, L, R, Ls, Rs, LFE
+	// in 3.0 and 5.1
+	tests := []struct {
+		channels uint8
+		want     float32
+	}{
+		{2, (1000 + 2000) * output.InvSqrt2},
+		{3, output.DownmixMul * (1000 + (2000+3000)*output.InvSqrt2)},
+		{6, output.DownmixMul * (1000 + (2000+3000)*output.InvSqrt2 + (4000+5000)*0.5)},
+	}
+
+	for _, tt := range tests {
+		d := newForceChannelsDecoder(t, tt.channels, 0, ForceChannelsMix)
+		d.config.DownmixMono = true
+
+		got := firstFrame(t, d, 1)
+		if want := int16(math.RoundToEven(float64(tt.want))); got[0] != want {
+			t.Errorf("%d channels: got %d, want %d", tt.channels, got[0], want)
+		}
+
+		var info FrameInfo
+		d.createChannelConfig(&info)
+		if info.NumFrontChannels != 1 || info.ChannelPosition[0] != ChannelFrontCenter {
+			t.Errorf("%d channels: layout %d front, position %d; want 1 front center",
+				tt.channels, info.NumFrontChannels, info.ChannelPosition[0])
+		}
+	}
+}
+
+func TestDownmixMono_ForceChannelsSupersedes(t *testing.T) {
+	d := newForceChannelsDecoder(t, 6, 2, ForceChannelsMix)
+	d.config.DownmixMono = true
+	if d.monoMixdown() {
+		t.Error("ForceChannels should supersede DownmixMono")
+	}
+}
@@ -0,0 +1,81 @@
+// frame_stats.go
+package aac
+
+import (
+	"github.com/llehouerou/go-aac/internal/bits"
+	"github.com/llehouerou/go-aac/internal/spectrum"
+)
+
+// ElementType is the type of a syntax element of a raw_data_block, with
+// the values of its id_syn_ele.
+type ElementType uint8
+
+// Syntax element types reported in FrameInfo.Elements.
+const (
+	ElementSCE ElementType = ElementType(idSCE) // Single Channel Element
+	ElementCPE ElementType = ElementType(idCPE) // Channel Pair Element
+	ElementCCE ElementType = ElementType(idCCE) // Coupling Channel Element
+	ElementLFE ElementType = ElementType(idLFE) // LFE Channel Element
+	ElementDSE ElementType = ElementType(idDSE) // Data Stream Element
+	ElementPCE ElementType = ElementType(idPCE) // Program Config Element
+	ElementFIL ElementType = ElementType(idFIL) // Fill Element
+)
+
+// ElementStats describes one syntax element of a decoded frame.
+type ElementStats struct {
+	Type ElementType
+
+	// Bits is the size of the element in the bitstream, including its
+	// 3-bit id_syn_ele. Error resilient streams code no element IDs.
+	Bits uint32
+}
+
+// ChannelTools reports the window sequence and the coding tools used by
+// one channel of a decoded frame.
+type ChannelTools struct {
+	// WindowSequence is 0 (ONLY_LONG), 1 (LONG_START), 2 (EIGHT_SHORT)
+	// or 3 (LONG_STOP)
+	WindowSequence uint8
+
+	TNS        bool // Temporal Noise Shaping
+	PNS        bool // Perceptual Noise Substitution in at least one band
+	Prediction bool // MAIN profile prediction
+	LTP        bool // Long Term Prediction
+
+	// MS and Intensity are tools of a channel pair and are reported on
+	// both of its channels
+	MS        bool // M/S stereo in at least one band
+	Intensity bool // Intensity stereo in at least one band
+}
+
+// channelToolSource is implemented by element decoders that report the
+// tools of the channels they parse.
+type channelToolSource interface {
+	ChannelTools(element any, index int) spectrum.ChannelTools
+}
+
+// noteChannelTools records the tools used by channel index of a parsed
+// element: in the stream's capabilities, and in result for
+// FrameInfo.ChannelTools when FrameStats is set.
+func (d *Decoder) noteChannelTools(result *rawDataBlockResult, element any, index int) {
+	s, ok := d.elements.(channelToolSource)
+	if !ok {
+		return
+	}
+	tools := ChannelTools(s.ChannelTools(element, index))
+	d.noteICSFeatures(tools.TNS, tools.LTP, tools.Prediction)
+	if d.config.FrameStats {
+		result.tools = append(result.tools, tools)
+	}
+}
+
+// noteElement records, when FrameStats is set, an element of the given
+// type that started at bit start of r.
+func (d *Decoder) noteElement(result *rawDataBlockResult, id elementID, start uint32, r *bits.Reader) {
+	if d.config.FrameStats {
+		result.elements = append(result.elements, ElementStats{
+			Type: ElementType(id),
+			Bits: r.GetProcessedBits() - start,
+		})
+	}
+}
@@ -0,0 +1,103 @@
+// frame_stats_test.go
+package aac_test
+
+import (
+	"os"
+	"testing"
+
+	"github.com/llehouerou/go-aac"
+	"github.com/llehouerou/go-aac/internal/syntax"
+)
+
+// TestDecode_FrameStats decodes a stereo rewrite of sine1k.aac with M/S
+// in every band and checks the reported element sizes and channel tools
+// against the rewritten channel pair elements.
+func TestDecode_FrameStats(t *testing.T) {
+	data, err := os.ReadFile("testdata/sine1k.aac")
+	if err != nil {
+		t.Skipf("sine1k.aac not available: %v", err)
+	}
+	type cpeFrame struct {
+		bits uint32
+		ics  *syntax.ICStream
+	}
+	var cpes []cpeFrame
+	stereo := remuxMono(t, data, 2, func(w *elementBitWriter, f *monoFrame) {
+		start := w.nbit
+		writeCPE(w, f, 0, cpeLayout{commonWindow: true, msMaskPresent: 1})
+		cpes = append(cpes, cpeFrame{uint32(w.nbit - start), f.ics})
+	})
+
+	d := aac.NewDecoder()
+	cfg := d.Config()
+	cfg.FrameStats = true
+	d.SetConfiguration(cfg)
+	if _, err := d.Init(stereo); err != nil {
+		t.Fatalf("Init: %v", err)
+	}
+
+	var pns, short, tns bool
+	for f, offset := 0, 0; offset < len(stereo); f++ {
+		_, info, err := d.Decode(stereo[offset:])
+		if err != nil {
+			t.Fatalf("frame %d: %v", f, err)
+		}
+		offset += int(info.BytesConsumed)
+
+		var total uint32
+		var cpeBits []uint32
+		for _, e := range info.Elements {
+			total += e.Bits
+			if e.Type == aac.ElementCPE {
+				cpeBits = append(cpeBits, e.Bits)
+			} else if e.Type != aac.ElementFIL {
+				t.Errorf("frame %d: unexpected element type %d", f, e.Type)
+			}
+		}
+		if len(cpeBits) != 1 || cpeBits[0] != cpes[f].bits {
+			t.Errorf("frame %d: CPE sizes %v, want [%d]", f, cpeBits, cpes[f].bits)
+		}
+		// The elements and ID_END fit in the payload
+		if payload := (info.BytesConsumed - 7) * 8; total+3 > payload {
+			t.Errorf("frame %d: elements take %d bits of a %d-bit payload", f, total, payload)
+		}
+
+		ics := cpes[f].ics
+		want := aac.ChannelTools{
+			WindowSequence: uint8(ics.WindowSequence),
+			TNS:            ics.TNSDataPresent,
+			PNS:            ics.NoiseUsed,
+			MS:             ics.MaxSFB > 0,
+		}
+		if len(info.ChannelTools) != 2 {
+			t.Fatalf("frame %d: %d channel tool reports, want 2", f, len(info.ChannelTools))
+		}
+		for ch, got := range info.ChannelTools {
+			if got != want {
+				t.Errorf("frame %d channel %d: tools %+v, want %+v", f, ch, got, want)
+			}
+		}
+		pns = pns || want.PNS
+		tns = tns || want.TNS
+		short = short || ics.WindowSequence == syntax.EightShortSequence
+	}
+	if !pns || !short {
+		t.Errorf("stream exercised PNS %v, eight short windows %v; want both", pns, short)
+	}
+	if c := d.Capabilities(); c.TNS != tns || c.Prediction || c.LTP {
+		t.Errorf("capabilities %+v, want TNS %v only", c, tns)
+	}
+
+	// Statistics are off by default
+	d = aac.NewDecoder()
+	if _, err := d.Init(stereo); err != nil {
+		t.Fatalf("Init: %v", err)
+	}
+	_, info, err := d.Decode(stereo)
+	if err != nil {
+		t.Fatalf("Decode: %v", err)
+	}
+	if info.Elements != nil || info.ChannelTools != nil {
+		t.Errorf("statistics reported without FrameStats: %+v, %+v", info.Elements, info.ChannelTools)
+	}
+}
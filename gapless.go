@@ -0,0 +1,128 @@
+// gapless.go
+package aac
+
+// GaplessInfo describes the priming and padding samples an encoder added
+// around the audio, as found in iTunSMPB tags or MP4 edit lists, for
+// Config.Gapless.
+type GaplessInfo struct {
+	// EncoderDelay is the number of samples per channel at the start of
+	// the decoded stream that precede the audio. It counts the first
+	// frame, which Decode mutes, so a typical 2112 sample delay leaves
+	// 1088 samples to trim from the next frame.
+	EncoderDelay uint32
+
+	// PaddingSamples is the number of samples per channel the encoder
+	// appended after the audio to fill the last frame.
+	PaddingSamples uint32
+}
+
+// trimGapless applies Config.Gapless to the PCM output of a frame and
+// sets info.Samples and info.TrimmedSamples accordingly.
+//
+// The stream length is not known in advance, so the padding is trimmed
+// by holding back the last PaddingSamples samples per channel of the
+// output: they are returned with the next frame, and those still held
+// when the stream ends are the padding. Output therefore lags the decode
+// by PaddingSamples.
+func (d *Decoder) trimGapless(samples any, info *FrameInfo) any {
+	g := d.config.Gapless
+	if g == nil || info.Channels == 0 {
+		return samples
+	}
+	channels := int(info.Channels)
+
+	if info.Samples == 0 {
+		// Muted frame: its samples count toward the delay
+		d.gaplessPosition += uint64(d.frameLength)
+		return samples
+	}
+	perChannel := int(info.Samples) / channels
+
+	skip := 0
+	if d.gaplessPosition < uint64(g.EncoderDelay) {
+		skip = int(min(uint64(g.EncoderDelay)-d.gaplessPosition, uint64(perChannel)))
+	}
+	d.gaplessPosition += uint64(perChannel)
+
+	if d.gaplessChannels != info.Channels {
+		// Held samples of another layout cannot be continued
+		d.gaplessHeld = nil
+		d.gaplessChannels = info.Channels
+	}
+	hold := int(g.PaddingSamples) * channels
+
+	var out any
+	var n int
+	switch s := samples.(type) {
+	case []int16:
+		out, n = holdBack(d, s[skip*channels:info.Samples], hold)
+	case []int32:
+		out, n = holdBack(d, s[skip*channels:info.Samples], hold)
+	case []float32:
+		out, n = holdBack(d, s[skip*channels:info.Samples], hold)
+	case []float64:
+		out, n = holdBack(d, s[skip*channels:info.Samples], hold)
+	case [][]int16:
+		out, n = holdBackPlanar(d, s, skip, perChannel, int(g.PaddingSamples))
+	case [][]int32:
+		out, n = holdBackPlanar(d, s, skip, perChannel, int(g.PaddingSamples))
+	case [][]float32:
+		out, n = holdBackPlanar(d, s, skip, perChannel, int(g.PaddingSamples))
+	case [][]float64:
+		out, n = holdBackPlanar(d, s, skip, perChannel, int(g.PaddingSamples))
+	default:
+		return samples
+	}
+
+	info.TrimmedSamples = info.Samples - uint32(n)
+	info.Samples = uint32(n)
+	return out
+}
+
+// holdBack appends samples to the held samples of earlier frames and
+// returns all but the last hold of them, keeping those for the next
+// frame, together with the number of samples returned.
+func holdBack[T int16 | int32 | float32 | float64](d *Decoder, samples []T, hold int) ([]T, int) {
+	held, _ := d.gaplessHeld.([]T)
+	all := append(held, samples...)
+	if len(all) <= hold {
+		d.gaplessHeld = all
+		return all[:0], 0
+	}
+	out := all[:len(all)-hold]
+	d.gaplessHeld = append([]T(nil), all[len(all)-hold:]...)
+	return out, len(out)
+}
+
+// holdBackPlanar is holdBack for planar output: samples [skip, end) of
+// each plane are appended to the held samples of that channel, and the
+// last hold of each channel are kept back.
+func holdBackPlanar[T int16 | int32 | float32 | float64](d *Decoder, planes [][]T, skip, end, hold int) ([][]T, int) {
+	held, _ := d.gaplessHeld.([][]T)
+	if len(held) != len(planes) {
+		held = make([][]T, len(planes))
+	}
+	out := make([][]T, len(planes))
+	kept := make([][]T, len(planes))
+	n := 0
+	for ch, plane := range planes {
+		all := append(held[ch], plane[skip:end]...)
+		if len(all) <= hold {
+			kept[ch] = all
+			out[ch] = all[:0]
+			continue
+		}
+		out[ch] = all[:len(all)-hold]
+		kept[ch] = append([]T(nil), all[len(all)-hold:]...)
+		n += len(out[ch])
+	}
+	d.gaplessHeld = kept
+	return out, n
+}
+
+// resetGapless restarts gapless trimming at the start of a stream.
+func (d *Decoder) resetGapless() {
+	d.gaplessPosition = 0
+	d.gaplessHeld = nil
+	d.gaplessChannels = 0
+}
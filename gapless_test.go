@@ -0,0 +1,89 @@
+// gapless_test.go
+package aac_test
+
+import (
+	"os"
+	"slices"
+	"testing"
+
+	"github.com/llehouerou/go-aac"
+)
+
+// decodeConcat decodes an ADTS stream and returns its concatenated
+// int16 output and the total of FrameInfo.TrimmedSamples.
+func decodeConcat(t *testing.T, data []byte, gapless *aac.GaplessInfo) ([]int16, int) {
+	t.Helper()
+	d := aac.NewDecoder()
+	cfg := d.Config()
+	cfg.NoiseGenerator = silentNoise{}
+	cfg.Gapless = gapless
+	d.SetConfiguration(cfg)
+	if _, err := d.Init(data); err != nil {
+		t.Fatalf("Init: %v", err)
+	}
+	var out []int16
+	trimmed := 0
+	for offset := 0; offset < len(data); {
+		samples, info, err := d.Decode(data[offset:])
+		if err != nil {
+			t.Fatalf("Decode: %v", err)
+		}
+		offset += int(info.BytesConsumed)
+		trimmed += int(info.TrimmedSamples)
+		pcm := samples.([]int16)
+		if len(pcm) < int(info.Samples) {
+			t.Fatalf("got %d samples, FrameInfo reports %d", len(pcm), info.Samples)
+		}
+		out = append(out, pcm[:info.Samples]...)
+	}
+	return out, trimmed
+}
+
+func TestDecode_Gapless(t *testing.T) {
+	data, err := os.ReadFile("testdata/sine1k.aac")
+	if err != nil {
+		t.Skipf("sine1k.aac not available: %v", err)
+	}
+	stereoData := remuxMono(t, data, 2, func(w *elementBitWriter, f *monoFrame) {
+		writeCPE(w, f, 0, cpeLayout{})
+	})
+
+	tests := []struct {
+		name     string
+		data     []byte
+		channels int
+		gapless  aac.GaplessInfo
+		// head and tail are the samples per channel trimmed from the
+		// output without Gapless
+		head, tail int
+	}{
+		// The muted first frame covers 1024 samples of the delay
+		{"delay only", data, 1, aac.GaplessInfo{EncoderDelay: 2112}, 1088, 0},
+		{"delay within the muted frame", data, 1, aac.GaplessInfo{EncoderDelay: 1000}, 0, 0},
+		{"padding only", data, 1, aac.GaplessInfo{PaddingSamples: 700}, 0, 700},
+		{"padding over a frame", data, 1, aac.GaplessInfo{PaddingSamples: 1500}, 0, 1500},
+		{"stereo delay and padding", stereoData, 2, aac.GaplessInfo{EncoderDelay: 2112, PaddingSamples: 321}, 1088, 321},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			plain, trimmed := decodeConcat(t, tt.data, nil)
+			if trimmed != 0 {
+				t.Fatalf("TrimmedSamples = %d without Gapless", trimmed)
+			}
+			gapless := tt.gapless
+			got, trimmed := decodeConcat(t, tt.data, &gapless)
+
+			want := plain[tt.head*tt.channels : len(plain)-tt.tail*tt.channels]
+			if len(got) != len(want) {
+				t.Fatalf("got %d samples, want %d", len(got), len(want))
+			}
+			if !slices.Equal(got, want) {
+				t.Fatal("trimmed output differs from the untrimmed output")
+			}
+			if wantTrimmed := (tt.head + tt.tail) * tt.channels; trimmed != wantTrimmed {
+				t.Errorf("TrimmedSamples total = %d, want %d", trimmed, wantTrimmed)
+			}
+		})
+	}
+}
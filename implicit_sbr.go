@@ -0,0 +1,77 @@
+// implicit_sbr.go
+package aac
+
+// Extension types signalling SBR data in a fill element.
+// Local copies of EXT_SBR_DATA and EXT_SBR_DATA_CRC to avoid an import
+// cycle with the syntax package.
+//
+// Ported from: ~/dev/faad2/libfaad/syntax.h:89-90
+const (
+	extSBRData    = 13
+	extSBRDataCRC = 14
+)
+
+// maxImplicitSBRCoreRate is the highest core sample rate that SBR doubles.
+// Above it the SBR tool runs in downsampled mode and the output keeps the
+// core rate.
+const maxImplicitSBRCoreRate = 24000
+
+// noteImplicitSBR latches implicit SBR signalling. ADTS and ADIF have no
+// SBR field, and an AudioSpecificConfig may leave it out, so HE-AAC is
+// then detected from the first frame carrying an SBR extension; from that
+// frame onward the stream is reported as SBR. Whether SBR runs upsampled
+// depends on the core rate and on DontUpSampleImplicitSBR. A
+// stream whose AudioSpecificConfig signals no SBR keeps that status, as
+// does MPEG-2 ADTS.
+//
+// Ported from: sbr_present_flag handling in ~/dev/faad2/libfaad/syntax.c:1140-1165
+func (d *Decoder) noteImplicitSBR(seen bool) {
+	if !seen || d.sbrSignalled || d.sbrPresentFlag || d.mpeg2 {
+		return
+	}
+	d.sbrPresentFlag = true
+	d.downSampledSBR = d.config.DontUpSampleImplicitSBR ||
+		d.coreSampleRate() > maxImplicitSBRCoreRate
+}
+
+// sbrDecoder returns the SBR decoder of the stream, creating it on the
+// first SBR payload. SBR data is associated with the first channel
+// element; payloads of the other elements of multichannel streams go to
+// the same decoder. It returns nil when RegisterSBRDecoderFactory removed
+// the SBR decoder factory.
+//
+// Ported from: sbrDecodeInit() call in ~/dev/faad2/libfaad/syntax.c:1140-1165
+func (d *Decoder) sbrDecoder() sbrExtensionDecoder {
+	if d.sbr == nil {
+		if sbrDecoderFactory == nil {
+			return nil
+		}
+		d.sbr = sbrDecoderFactory(2 * d.coreSampleRate())
+	}
+	dec, _ := d.sbr.(sbrExtensionDecoder)
+	return dec
+}
+
+// setSampleRateInfo reports the output sample rate and SBR status of the
+// current frame. No SBR synthesis runs yet, so the output keeps the core
+// rate and SBR is reported only through SBRPresence; FAAD2 doubles the
+// rate and sets the SBR mode once HF reconstruction upsamples.
+//
+// Ported from: aac_frame_decode() in ~/dev/faad2/libfaad/decoder.c:1148-1170
+func (d *Decoder) setSampleRateInfo(info *FrameInfo) {
+	info.SampleRate = d.coreSampleRate()
+	info.SBRPresence = d.sbrPresence()
+	info.PSPresent = d.psPresent
+	info.SBR = SBRNone
+}
+
+// sbrPresence returns the SBR presence of the stream for FrameInfo.
+func (d *Decoder) sbrPresence() SBRPresence {
+	switch {
+	case d.sbrPresentFlag:
+		return SBRPresent
+	case d.sbrSignalled || d.mpeg2 || d.coreSampleRate() > maxImplicitSBRCoreRate:
+		return SBRNotPresent
+	}
+	return SBRUnknown
+}
@@ -25,14 +25,23 @@ type Reader struct {
 //
 // Ported from: faad_initbits() in ~/dev/faad2/libfaad/bits.c:55-99
 func NewReader(data []byte) *Reader {
-	r := &Reader{
+	r := &Reader{}
+	r.Reset(data)
+	return r
+}
+
+// Reset reinitializes r in place to read from data, as NewReader does,
+// so that one Reader can be reused across frames without allocating.
+// It clears the error flag, unless data is empty.
+func (r *Reader) Reset(data []byte) {
+	*r = Reader{
 		buffer:     data,
 		bufferSize: len(data),
 	}
 
 	if len(data) == 0 {
 		r.err = true
-		return r
+		return
 	}
 
 	// Load first 32-bit word into bufa
@@ -42,8 +51,6 @@ func NewReader(data []byte) *Reader {
 	// Track position (next word to load would be at byte 8)
 	r.pos = 8
 	r.bitsLeft = 32
-
-	return r
 }
 
 // loadWord loads up to 4 bytes from buffer position as big-endian uint32.
@@ -159,6 +166,35 @@ func (r *Reader) GetBits(n uint) uint32 {
 	return ret
 }
 
+// ShowBits64 returns the next n bits without consuming them.
+// n must be 0-64. Reads of up to 32 bits use ShowBits; wider ones also
+// look at the word after bufb.
+func (r *Reader) ShowBits64(n uint) uint64 {
+	if n <= 32 {
+		return uint64(r.ShowBits(n))
+	}
+
+	// The unread bits of bufa, then bufb and the next word: at least 64
+	// bits, even with bufa exhausted
+	w := (uint64(r.bufa)<<32 | uint64(r.bufb)) << (32 - r.bitsLeft)
+	w |= uint64(r.loadWord(r.pos)) >> r.bitsLeft
+	return w >> (64 - n)
+}
+
+// GetBits64 reads and returns n bits from the stream.
+// n must be 0-64.
+func (r *Reader) GetBits64(n uint) uint64 {
+	if n <= 32 {
+		return uint64(r.GetBits(n))
+	}
+
+	ret := r.ShowBits64(n)
+	// FlushBits reloads at most one word at a time
+	r.FlushBits(32)
+	r.FlushBits(n - 32)
+	return ret
+}
+
 // Get1Bit reads and returns a single bit from the stream.
 // Optimized path for single-bit reads.
 //
@@ -204,6 +240,36 @@ func (r *Reader) GetProcessedBits() uint32 {
 	return uint32((r.pos-4)*8) - r.bitsLeft
 }
 
+// PeekBytes returns a copy of the next n bytes from the current
+// position without consuming them. Unless the position is byte-aligned,
+// each byte spans two bytes of the buffer. Near the end of the buffer it
+// returns only the whole bytes left, and nil when there are none.
+func (r *Reader) PeekBytes(n int) []byte {
+	if r.err || n <= 0 {
+		return nil
+	}
+
+	pos := r.GetProcessedBits()
+	n = min(n, int(r.RemainingBits()/8))
+	if n == 0 {
+		return nil
+	}
+
+	start := int(pos / 8)
+	shift := pos % 8
+	out := make([]byte, n)
+	if shift == 0 {
+		copy(out, r.buffer[start:])
+		return out
+	}
+	// A whole byte left past an unaligned position ends in the next
+	// byte of the buffer
+	for i := range out {
+		out[i] = r.buffer[start+i]<<shift | r.buffer[start+i+1]>>(8-shift)
+	}
+	return out
+}
+
 // GetBitBuffer reads 'bits' bits and returns them as a byte slice.
 // Partial final byte is left-aligned (MSB) with zero padding.
 //
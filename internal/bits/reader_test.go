@@ -211,6 +211,97 @@ func TestReader_ShowBits_EdgeCases(t *testing.T) {
 	}
 }
 
+func TestReader_ShowBits64(t *testing.T) {
+	data := []byte{0xFF, 0x0F, 0xAB, 0xCD, 0x12, 0x34, 0x56, 0x78, 0x9A, 0xBC, 0xDE, 0xF0}
+	r := NewReader(data)
+
+	tests := []struct {
+		name     string
+		n        uint
+		expected uint64
+	}{
+		{"peek 0 bits", 0, 0},
+		{"peek 32 bits", 32, 0xFF0FABCD},
+		{"peek 33 bits", 33, 0xFF0FABCD << 1},
+		{"peek 40 bits", 40, 0xFF0FABCD12},
+		{"peek 48 bits", 48, 0xFF0FABCD1234},
+		{"peek 64 bits", 64, 0xFF0FABCD12345678},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			got := r.ShowBits64(tc.n)
+			if got != tc.expected {
+				t.Errorf("ShowBits64(%d) = 0x%X, want 0x%X", tc.n, got, tc.expected)
+			}
+		})
+	}
+
+	// Verify ShowBits64 doesn't consume bits
+	if r.GetProcessedBits() != 0 {
+		t.Errorf("ShowBits64 consumed %d bits", r.GetProcessedBits())
+	}
+}
+
+func TestReader_ShowBits64_EdgeCases(t *testing.T) {
+	data := []byte{0x12, 0x34, 0x56, 0x78, 0xAB, 0xCD, 0xEF, 0x00, 0x11, 0x22, 0x33, 0x44}
+
+	tests := []struct {
+		name     string
+		bitsLeft uint32
+		n        uint
+		expected uint64
+	}{
+		// Low 16 bits of bufa, bufb and 16 bits of the next word
+		{"64 bits from three words", 16, 64, 0x5678ABCDEF001122},
+		// n == bitsLeft + 32: all of bufa and bufb
+		{"bufa and bufb exactly", 16, 48, 0x5678ABCDEF00},
+		// Just past bufb: one bit of the next word, 0x11 = 00010001
+		{"one bit past bufb", 16, 49, 0x5678ABCDEF00 << 1},
+		// One bit left in bufa, a 0: bufb, then 31 bits of the next word
+		{"one bit left", 1, 64, 0xABCDEF00<<31 | 0x11223344>>1},
+		// bufa exhausted: bufb and the next word
+		{"bufa exhausted", 0, 64, 0xABCDEF0011223344},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			r := NewReader(data)
+			r.bitsLeft = tc.bitsLeft
+			got := r.ShowBits64(tc.n)
+			if got != tc.expected {
+				t.Errorf("ShowBits64(%d) with %d bits left = 0x%X, want 0x%X", tc.n, tc.bitsLeft, got, tc.expected)
+			}
+		})
+	}
+}
+
+func TestReader_GetBits64(t *testing.T) {
+	data := []byte{
+		0xFF, 0x0F, 0xAB, 0xCD, 0x12, 0x34, 0x56, 0x78,
+		0x9A, 0xBC, 0xDE, 0xF0, 0x11, 0x22, 0x33, 0x44,
+	}
+	r := NewReader(data)
+
+	// 4 bits, then 36 bits spanning bufa and bufb
+	if got := r.GetBits64(4); got != 0xF {
+		t.Errorf("GetBits64(4) = 0x%X, want 0xF", got)
+	}
+	if got := r.GetBits64(36); got != 0xF0FABCD12 {
+		t.Errorf("GetBits64(36) = 0x%X, want 0xF0FABCD12", got)
+	}
+	// 64 bits spanning bufa, bufb and the next word
+	if got := r.GetBits64(64); got != 0x3456789ABCDEF011 {
+		t.Errorf("GetBits64(64) = 0x%X, want 0x3456789ABCDEF011", got)
+	}
+	if got := r.GetProcessedBits(); got != 104 {
+		t.Errorf("GetProcessedBits() = %d, want 104", got)
+	}
+	if got := r.GetBits(24); got != 0x223344 {
+		t.Errorf("GetBits(24) after 64-bit reads = 0x%X, want 0x223344", got)
+	}
+}
+
 func TestReader_FlushBits(t *testing.T) {
 	data := []byte{0xFF, 0x0F, 0xAB, 0xCD, 0x12, 0x34, 0x56, 0x78}
 	r := NewReader(data)
@@ -629,3 +720,130 @@ func TestReader_BitsAvailable(t *testing.T) {
 		t.Error("Should not have 17 bits available after reading 16")
 	}
 }
+
+func TestReader_Reset(t *testing.T) {
+	frames := [][]byte{
+		{0xFF, 0x0F, 0xAB, 0xCD, 0x12, 0x34, 0x56, 0x78, 0x9A, 0xBC, 0xDE},
+		{0x01, 0x23, 0x45},
+		{0x80, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x01},
+	}
+
+	// A reader left mid-word with its error flag set
+	r := NewReader(nil)
+	r.Reset([]byte{0xAA, 0xBB, 0xCC, 0xDD, 0xEE})
+	r.GetBits(13)
+	r.err = true
+
+	for i, data := range frames {
+		fresh := NewReader(data)
+		r.Reset(data)
+		if r.Error() {
+			t.Fatalf("frame %d: error flag not cleared", i)
+		}
+		if r.bufa != fresh.bufa || r.bufb != fresh.bufb || r.bitsLeft != fresh.bitsLeft ||
+			r.pos != fresh.pos || r.bufferSize != fresh.bufferSize {
+			t.Fatalf("frame %d: reset reader %+v, want %+v", i, *r, *fresh)
+		}
+		// Read the frame in uneven chunks, past its end
+		for _, n := range []uint{3, 17, 32, 1, 9, 30} {
+			got, want := r.GetBits(n), fresh.GetBits(n)
+			if got != want {
+				t.Fatalf("frame %d: GetBits(%d) = 0x%X, want 0x%X", i, n, got, want)
+			}
+		}
+		if r.GetProcessedBits() != fresh.GetProcessedBits() || r.RemainingBits() != fresh.RemainingBits() {
+			t.Errorf("frame %d: position %d/%d, want %d/%d", i,
+				r.GetProcessedBits(), r.RemainingBits(), fresh.GetProcessedBits(), fresh.RemainingBits())
+		}
+	}
+
+	// Resetting to no data sets the error flag, as NewReader does
+	r.Reset(nil)
+	if !r.Error() {
+		t.Error("Reset(nil): error flag not set")
+	}
+}
+
+// benchmarkFrames is a run of 768-byte frames to read a header from.
+var benchmarkFrames = func() [][]byte {
+	frames := make([][]byte, 64)
+	for i := range frames {
+		frames[i] = make([]byte, 768)
+		frames[i][0], frames[i][1] = 0xFF, byte(0xF0|i)
+	}
+	return frames
+}()
+
+// benchmarkReader keeps the readers of the benchmarks on the heap, as a
+// decoder holding them does.
+var benchmarkReader *Reader
+
+func BenchmarkNewReader(b *testing.B) {
+	b.ReportAllocs()
+	for i := range b.N {
+		benchmarkReader = NewReader(benchmarkFrames[i%len(benchmarkFrames)])
+		_ = benchmarkReader.GetBits(12)
+		_ = benchmarkReader.GetBits(4)
+	}
+}
+
+func BenchmarkReader_Reset(b *testing.B) {
+	b.ReportAllocs()
+	benchmarkReader = &Reader{}
+	for i := range b.N {
+		benchmarkReader.Reset(benchmarkFrames[i%len(benchmarkFrames)])
+		_ = benchmarkReader.GetBits(12)
+		_ = benchmarkReader.GetBits(4)
+	}
+}
+
+func TestReader_PeekBytes(t *testing.T) {
+	data := []byte{0xFF, 0x0F, 0xAB, 0xCD, 0x12, 0x34, 0x56, 0x78, 0x9A, 0xBC}
+
+	tests := []struct {
+		name string
+		skip uint
+		n    int
+		want []byte
+	}{
+		{"from the start", 0, 3, []byte{0xFF, 0x0F, 0xAB}},
+		{"across bufa and bufb", 24, 3, []byte{0xCD, 0x12, 0x34}},
+		{"past bufb", 16, 8, []byte{0xAB, 0xCD, 0x12, 0x34, 0x56, 0x78, 0x9A, 0xBC}},
+		// 0xCD12 = 1100110100010010, from bit 4: 11010001
+		{"unaligned across bufa and bufb", 28, 2, []byte{0xD1, 0x23}},
+		{"at the tail", 64, 4, []byte{0x9A, 0xBC}},
+		// 0xBC = 10111100: one whole byte left from bit 68, none from 73
+		{"unaligned at the tail", 68, 4, []byte{0xAB}},
+		{"less than a byte left", 73, 1, nil},
+		{"zero bytes", 8, 0, nil},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			r := NewReader(data)
+			r.FlushBits(tc.skip % 32)
+			for range tc.skip / 32 {
+				r.FlushBits(32)
+			}
+			before := r.GetProcessedBits()
+
+			got := r.PeekBytes(tc.n)
+			if string(got) != string(tc.want) || (got == nil) != (tc.want == nil) {
+				t.Errorf("PeekBytes(%d) after %d bits = %X, want %X", tc.n, tc.skip, got, tc.want)
+			}
+			if r.GetProcessedBits() != before {
+				t.Errorf("PeekBytes moved from bit %d to %d", before, r.GetProcessedBits())
+			}
+			// The next bits are still those peeked
+			if len(tc.want) > 0 {
+				if b := r.GetBits(8); b != uint32(tc.want[0]) {
+					t.Errorf("GetBits(8) after PeekBytes = 0x%X, want 0x%X", b, tc.want[0])
+				}
+			}
+		})
+	}
+
+	if got := NewReader(nil).PeekBytes(2); got != nil {
+		t.Errorf("PeekBytes on an empty reader = %X, want nil", got)
+	}
+}
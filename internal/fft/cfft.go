@@ -1,6 +1,10 @@
 package fft
 
-import "math"
+import (
+	"fmt"
+	"math"
+	"sync"
+)
 
 // CFFT holds state for a complex FFT of a fixed size.
 //
@@ -9,25 +13,42 @@ type CFFT struct {
 	N    uint16     // FFT size
 	IFac [15]uint16 // Factorization of N
 	Work []Complex  // Work buffer for intermediate results
-	Tab  []Complex  // Twiddle factor table
+	Tab  []Complex  // Twiddle factor table, shared by the CFFTs of size N: read-only
+}
+
+// cfftTables is the factorization and twiddle table of a size.
+type cfftTables struct {
+	ifac [15]uint16
+	tab  []Complex
 }
 
+// cfftCache holds the cfftTables of every size NewCFFT was called with.
+var cfftCache sync.Map // uint16 -> *cfftTables
+
 // NewCFFT creates and initializes a new CFFT for size n.
 // n must be divisible by 8 and only contain factors 2, 3, 4, 5.
+// The factorization and twiddle table are computed once per size and
+// shared; each CFFT has its own work buffer.
 //
 // Ported from: cffti() in ~/dev/faad2/libfaad/cfft.c:1005-1039
 func NewCFFT(n uint16) *CFFT {
-	cfft := &CFFT{
+	cached, ok := cfftCache.Load(n)
+	if !ok {
+		// Factorize n and compute twiddle factors
+		t := &cfftTables{tab: make([]Complex, n)}
+		factorize(n, t.ifac[:])
+		computeTwiddle(n, t.tab, t.ifac[:])
+		// A concurrent call may have stored the same tables first
+		cached, _ = cfftCache.LoadOrStore(n, t)
+	}
+	t := cached.(*cfftTables)
+
+	return &CFFT{
 		N:    n,
+		IFac: t.ifac,
 		Work: make([]Complex, n),
-		Tab:  make([]Complex, n),
+		Tab:  t.tab,
 	}
-
-	// Factorize n and compute twiddle factors
-	factorize(n, cfft.IFac[:])
-	computeTwiddle(n, cfft.Tab, cfft.IFac[:])
-
-	return cfft
 }
 
 // factorize computes the factorization of n into factors 2, 3, 4, 5.
@@ -442,8 +463,10 @@ func passf5(ido, l1 uint16, cc, ch []Complex, wa1, wa2, wa3, wa4 []Complex, isig
 				c3Re := cc[ac-1].Re + tr12*t2Re + tr11*t3Re
 				c3Im := cc[ac-1].Im + tr12*t2Im + tr11*t3Im
 
-				c4Re, c5Re := ComplexMult(ti12, ti11, t5Re, t4Re)
-				c4Im, c5Im := ComplexMult(ti12, ti11, t5Im, t4Im)
+				// Same rotation as the backward pass; the sign of the
+				// imaginary unit is applied in the output combination.
+				c5Re, c4Re := ComplexMult(ti11, ti12, t5Re, t4Re)
+				c5Im, c4Im := ComplexMult(ti11, ti12, t5Im, t4Im)
 
 				ch[ah+l1].Re = c2Re + c5Im
 				ch[ah+l1].Im = c2Im - c5Re
@@ -459,26 +482,71 @@ func passf5(ido, l1 uint16, cc, ch []Complex, wa1, wa2, wa3, wa4 []Complex, isig
 	// Note: ido > 1 case exists in FAAD2 but is marked as unreachable for AAC
 }
 
-// Forward performs the forward FFT (frequency analysis).
+// Size returns the number of complex values the CFFT transforms.
+func (cfft *CFFT) Size() uint16 {
+	return cfft.N
+}
+
+// checkLen panics unless c holds N values, so that a wrong buffer fails
+// with a clear message rather than an index out of range deep in a
+// butterfly or, for a longer one, a silently partial transform.
+func (cfft *CFFT) checkLen(c []Complex) {
+	if len(c) != int(cfft.N) {
+		panic(fmt.Sprintf("fft: input of %d values for a CFFT of size %d", len(c), cfft.N))
+	}
+}
+
+// checkWork panics unless work holds at least N values.
+func (cfft *CFFT) checkWork(work []Complex) {
+	if len(work) < int(cfft.N) {
+		panic(fmt.Sprintf("fft: work buffer of %d values for a CFFT of size %d", len(work), cfft.N))
+	}
+}
+
+// Forward performs the forward FFT (frequency analysis) of c in place.
+// It panics unless len(c) is N.
 //
 // Ported from: cfftf() in ~/dev/faad2/libfaad/cfft.c:896-899
 func (cfft *CFFT) Forward(c []Complex) {
-	cfft.cfftf1neg(c, -1)
+	cfft.checkLen(c)
+	cfft.cfftf1neg(c, cfft.Work, -1)
 }
 
-// Backward performs the backward FFT (synthesis).
+// Backward performs the backward FFT (synthesis) of c in place. It panics
+// unless len(c) is N.
 //
 // Ported from: cfftb() in ~/dev/faad2/libfaad/cfft.c:901-904
 func (cfft *CFFT) Backward(c []Complex) {
-	cfft.cfftf1pos(c, +1)
+	cfft.checkLen(c)
+	cfft.cfftf1pos(c, cfft.Work, +1)
+}
+
+// ForwardInto performs the forward FFT of c in place, as Forward does,
+// using work, of at least N values, instead of the CFFT's work buffer.
+// It does not modify the CFFT, so concurrent callers with their own work
+// buffers can share one.
+func (cfft *CFFT) ForwardInto(c, work []Complex) {
+	cfft.checkLen(c)
+	cfft.checkWork(work)
+	cfft.cfftf1neg(c, work[:cfft.N], -1)
+}
+
+// BackwardInto performs the backward FFT of c in place, as Backward
+// does, using work, of at least N values, instead of the CFFT's work
+// buffer. It does not modify the CFFT, so concurrent callers with their
+// own work buffers can share one.
+func (cfft *CFFT) BackwardInto(c, work []Complex) {
+	cfft.checkLen(c)
+	cfft.checkWork(work)
+	cfft.cfftf1pos(c, work[:cfft.N], +1)
 }
 
-// cfftf1pos is the main FFT computation for backward transform.
+// cfftf1pos is the main FFT computation for backward transform, with
+// ch as its work buffer.
 //
 // Ported from: cfftf1pos() in ~/dev/faad2/libfaad/cfft.c:740-816
-func (cfft *CFFT) cfftf1pos(c []Complex, isign int8) {
+func (cfft *CFFT) cfftf1pos(c, ch []Complex, isign int8) {
 	n := cfft.N
-	ch := cfft.Work
 	ifac := cfft.IFac[:]
 	wa := cfft.Tab
 
@@ -544,12 +612,12 @@ func (cfft *CFFT) cfftf1pos(c []Complex, isign int8) {
 	copy(c, ch[:n])
 }
 
-// cfftf1neg is the main FFT computation for forward transform.
+// cfftf1neg is the main FFT computation for forward transform, with
+// ch as its work buffer.
 //
 // Ported from: cfftf1neg() in ~/dev/faad2/libfaad/cfft.c:818-894
-func (cfft *CFFT) cfftf1neg(c []Complex, isign int8) {
+func (cfft *CFFT) cfftf1neg(c, ch []Complex, isign int8) {
 	n := cfft.N
-	ch := cfft.Work
 	ifac := cfft.IFac[:]
 	wa := cfft.Tab
 
@@ -3,6 +3,7 @@ package fft
 import (
 	"fmt"
 	"math"
+	"sync"
 	"testing"
 )
 
@@ -319,6 +320,95 @@ func TestPassf5_ForwardFFT_ido1(t *testing.T) {
 	}
 }
 
+func TestNewCFFT_SharedTables(t *testing.T) {
+	for _, n := range []uint16{64, 120, 512} {
+		t.Run(fmt.Sprintf("n=%d", n), func(t *testing.T) {
+			a, b := NewCFFT(n), NewCFFT(n)
+			if &a.Tab[0] != &b.Tab[0] {
+				t.Error("CFFTs of the same size do not share the twiddle table")
+			}
+			if a.IFac != b.IFac {
+				t.Errorf("IFac = %v and %v", a.IFac, b.IFac)
+			}
+			if &a.Work[0] == &b.Work[0] {
+				t.Error("CFFTs of the same size share the work buffer")
+			}
+
+			// Identical results to the tables computed afresh
+			var ifac [15]uint16
+			tab := make([]Complex, n)
+			factorize(n, ifac[:])
+			computeTwiddle(n, tab, ifac[:])
+			fresh := &CFFT{N: n, IFac: ifac, Work: make([]Complex, n), Tab: tab}
+
+			ca, cb, cf := make([]Complex, n), make([]Complex, n), make([]Complex, n)
+			for i := range ca {
+				ca[i] = Complex{Re: float32(i%13) - 6, Im: float32(i%5) - 2}
+			}
+			copy(cb, ca)
+			copy(cf, ca)
+			a.Forward(ca)
+			b.Forward(cb)
+			fresh.Forward(cf)
+			for i := range ca {
+				if ca[i] != cb[i] || ca[i] != cf[i] {
+					t.Fatalf("bin %d: %v, %v and %v", i, ca[i], cb[i], cf[i])
+				}
+			}
+		})
+	}
+}
+
+func TestCFFT_IntoConcurrent(t *testing.T) {
+	const n, workers = 512, 8
+	cfft := NewCFFT(n)
+
+	input := func(w int) []Complex {
+		c := make([]Complex, n)
+		for i := range c {
+			c[i] = Complex{Re: float32((i*(w+1))%17) - 8, Im: float32((i+w)%9) - 4}
+		}
+		return c
+	}
+
+	// Serial results through the CFFT's own work buffer
+	wantFwd := make([][]Complex, workers)
+	wantBwd := make([][]Complex, workers)
+	for w := range workers {
+		wantFwd[w] = input(w)
+		cfft.Forward(wantFwd[w])
+		wantBwd[w] = input(w)
+		cfft.Backward(wantBwd[w])
+	}
+
+	var wg sync.WaitGroup
+	gotFwd := make([][]Complex, workers)
+	gotBwd := make([][]Complex, workers)
+	for w := range workers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			work := make([]Complex, n)
+			for range 20 {
+				gotFwd[w] = input(w)
+				cfft.ForwardInto(gotFwd[w], work)
+				gotBwd[w] = input(w)
+				cfft.BackwardInto(gotBwd[w], work)
+			}
+		}()
+	}
+	wg.Wait()
+
+	for w := range workers {
+		for i := range n {
+			if gotFwd[w][i] != wantFwd[w][i] || gotBwd[w][i] != wantBwd[w][i] {
+				t.Fatalf("worker %d bin %d: forward %v, backward %v, want %v and %v",
+					w, i, gotFwd[w][i], gotBwd[w][i], wantFwd[w][i], wantBwd[w][i])
+			}
+		}
+	}
+}
+
 func TestCFFT_RoundTrip(t *testing.T) {
 	// Test that forward FFT followed by backward FFT recovers the original signal
 	// (with appropriate scaling).
@@ -383,3 +473,69 @@ func TestCFFT_KnownValues(t *testing.T) {
 		}
 	}
 }
+
+func BenchmarkNewCFFT(b *testing.B) {
+	for _, n := range []uint16{64, 512} {
+		b.Run(fmt.Sprintf("n=%d", n), func(b *testing.B) {
+			b.ReportAllocs()
+			for range b.N {
+				_ = NewCFFT(n)
+			}
+		})
+		// The work NewCFFT did for every CFFT before the tables were shared
+		b.Run(fmt.Sprintf("n=%d/tables", n), func(b *testing.B) {
+			b.ReportAllocs()
+			var ifac [15]uint16
+			tab := make([]Complex, n)
+			for range b.N {
+				factorize(n, ifac[:])
+				computeTwiddle(n, tab, ifac[:])
+			}
+		})
+	}
+}
+
+func TestCFFT_Size(t *testing.T) {
+	for _, n := range []uint16{64, 480, 512} {
+		if got := NewCFFT(n).Size(); got != n {
+			t.Errorf("NewCFFT(%d).Size() = %d", n, got)
+		}
+	}
+}
+
+func TestCFFT_LengthMismatch(t *testing.T) {
+	cfft := NewCFFT(64)
+	short := make([]Complex, 32)
+	work := make([]Complex, 64)
+
+	tests := []struct {
+		name string
+		call func()
+		want string
+	}{
+		{"Forward", func() { cfft.Forward(short) }, "fft: input of 32 values for a CFFT of size 64"},
+		{"Backward", func() { cfft.Backward(make([]Complex, 65)) }, "fft: input of 65 values for a CFFT of size 64"},
+		{"ForwardInto", func() { cfft.ForwardInto(short, work) }, "fft: input of 32 values for a CFFT of size 64"},
+		{"BackwardInto work", func() { cfft.BackwardInto(make([]Complex, 64), short) }, "fft: work buffer of 32 values for a CFFT of size 64"},
+	}
+	for _, tt := range tests {
+		func() {
+			defer func() {
+				if r := recover(); r != tt.want {
+					t.Errorf("%s: panic %v, want %q", tt.name, r, tt.want)
+				}
+			}()
+			tt.call()
+		}()
+	}
+
+	// The guards leave the CFFT usable
+	c := make([]Complex, 64)
+	c[0].Re = 1
+	cfft.Forward(c)
+	for k, v := range c {
+		if v.Re != 1 || v.Im != 0 {
+			t.Fatalf("FFT of an impulse: bin %d = %v, want 1", k, v)
+		}
+	}
+}
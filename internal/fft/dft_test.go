@@ -0,0 +1,98 @@
+package fft
+
+import (
+	"fmt"
+	"math"
+	"testing"
+)
+
+// referenceDFT computes the O(N²) discrete Fourier transform of x in
+// float64: X[k] = sum_n x[n] * exp(sign * 2πi * n * k / N).
+// Forward corresponds to sign = -1, Backward to sign = +1 (unnormalized).
+func referenceDFT(x []Complex, sign float64) []complex128 {
+	n := len(x)
+	out := make([]complex128, n)
+	for k := 0; k < n; k++ {
+		var sum complex128
+		for j := 0; j < n; j++ {
+			// Reduce the index product first to keep the angle small
+			arg := sign * 2 * math.Pi * float64((j*k)%n) / float64(n)
+			w := complex(math.Cos(arg), math.Sin(arg))
+			sum += complex(float64(x[j].Re), float64(x[j].Im)) * w
+		}
+		out[k] = sum
+	}
+	return out
+}
+
+// dftTestInput returns a deterministic, non-symmetric test signal.
+func dftTestInput(n int) []Complex {
+	x := make([]Complex, n)
+	for i := range x {
+		x[i] = Complex{
+			Re: float32(math.Sin(0.37*float64(i)) + 0.5*math.Cos(1.9*float64(i))),
+			Im: float32(math.Cos(0.11*float64(i*i%97)) - 0.25),
+		}
+	}
+	return x
+}
+
+// compareWithDFT checks got against want with a tolerance relative to the
+// largest reference magnitude.
+func compareWithDFT(t *testing.T, got []Complex, want []complex128) {
+	t.Helper()
+	maxAbs := 0.0
+	for _, v := range want {
+		maxAbs = math.Max(maxAbs, math.Hypot(real(v), imag(v)))
+	}
+	tol := 1e-5 * maxAbs
+
+	for k := range want {
+		dRe := float64(got[k].Re) - real(want[k])
+		dIm := float64(got[k].Im) - imag(want[k])
+		if math.Hypot(dRe, dIm) > tol {
+			t.Fatalf("bin %d: got (%g, %g), want (%g, %g), tolerance %g",
+				k, got[k].Re, got[k].Im, real(want[k]), imag(want[k]), tol)
+		}
+	}
+}
+
+func TestCFFT_MatchesReferenceDFT(t *testing.T) {
+	// 64/512 are the AAC short/long IMDCT sizes; 60/480 exercise the
+	// radix-3 and radix-5 passes used by 960-sample frames.
+	sizes := []uint16{60, 64, 128, 480, 512, 1024}
+
+	for _, n := range sizes {
+		t.Run(fmt.Sprintf("forward_n=%d", n), func(t *testing.T) {
+			x := dftTestInput(int(n))
+			want := referenceDFT(x, -1)
+
+			NewCFFT(n).Forward(x)
+			compareWithDFT(t, x, want)
+		})
+
+		t.Run(fmt.Sprintf("backward_n=%d", n), func(t *testing.T) {
+			x := dftTestInput(int(n))
+			want := referenceDFT(x, +1)
+
+			NewCFFT(n).Backward(x)
+			compareWithDFT(t, x, want)
+		})
+	}
+}
+
+func TestReferenceDFT_Impulse(t *testing.T) {
+	// Sanity check of the reference itself: a unit impulse at index 1
+	// transforms to exp(-2πik/N).
+	const n = 8
+	x := make([]Complex, n)
+	x[1] = Complex{Re: 1}
+
+	got := referenceDFT(x, -1)
+	for k := 0; k < n; k++ {
+		arg := -2 * math.Pi * float64(k) / n
+		if math.Abs(real(got[k])-math.Cos(arg)) > 1e-12 || math.Abs(imag(got[k])-math.Sin(arg)) > 1e-12 {
+			t.Errorf("bin %d: got %v, want (%g, %g)", k, got[k], math.Cos(arg), math.Sin(arg))
+		}
+	}
+}
@@ -1,4 +1,7 @@
 // Package fft implements the Fast Fourier Transform for MDCT.
 //
+// The MDCT and IMDCT built on it, with the pre- and post-twiddles of
+// FAAD2's mdct.c, are in package mdct, which imports this one.
+//
 // Ported from: ~/dev/faad2/libfaad/cfft.c, cfft.h, cfft_tab.h
 package fft
@@ -18,6 +18,7 @@
 package filterbank
 
 import (
+	"github.com/llehouerou/go-aac/internal/fft"
 	"github.com/llehouerou/go-aac/internal/mdct"
 )
 
@@ -35,16 +36,25 @@ const (
 //
 // Ported from: fb_info struct in ~/dev/faad2/libfaad/structs.h:67-83
 type FilterBank struct {
-	mdct256  *mdct.MDCT // For short blocks (256-sample IMDCT)
-	mdct2048 *mdct.MDCT // For long blocks (2048-sample IMDCT)
+	mdct256  *mdct.MDCT // For short blocks (256-sample IMDCT, 240 for 960-sample frames)
+	mdct2048 *mdct.MDCT // For long blocks (2048-sample IMDCT, 1920 for 960-sample frames)
+	mdctLD   *mdct.MDCT // For AAC-LD blocks (1024 or 960-sample IMDCT), nil if unsupported
+
+	// Long and short windows indexed by window shape, sized for the frame length
+	longWindow  [2][]float32
+	shortWindow [2][]float32
 
 	// Internal buffers (reused to avoid allocations)
 	transfBuf   []float32 // 2*frameLength for IMDCT output
 	windowedBuf []float32 // 2*frameLength for LTP windowed input
+
+	tapBuf []float32 // frameLength scratch for the MDCT tap
 }
 
 // NewFilterBank creates and initializes a FilterBank for the given frame length.
-// Standard AAC uses frameLength=1024.
+// Standard AAC uses frameLength=1024, or 960 when the AudioSpecificConfig
+// sets frameLengthFlag. As in FAAD2, an AAC-LD decoder passes the
+// un-halved frame length (1024 for 512-sample LD frames, 960 for 480).
 //
 // Ported from: filter_bank_init() in ~/dev/faad2/libfaad/filtbank.c:48-92
 func NewFilterBank(frameLen uint16) *FilterBank {
@@ -57,9 +67,49 @@ func NewFilterBank(frameLen uint16) *FilterBank {
 		windowedBuf: make([]float32, 2*frameLen),
 	}
 
+	fb.longWindow, fb.shortWindow = frameWindows(frameLen)
+
+	// LD transform of 2*(frameLen/2) samples, for 512 and 480-sample LD
+	// frames.
+	if frameLen == 1024 || frameLen == LongWindowSize960 {
+		fb.mdctLD = mdct.NewMDCT(frameLen)
+	}
+
 	return fb
 }
 
+// SetMDCTTap installs a callback receiving the input of every inverse FFT
+// run by IFilterBank: one call per long block, eight per short sequence.
+// The coefficients are the N/4 pre-twiddled complex values interleaved as
+// re, im (N/2 floats), reused between calls. Passing nil removes the tap.
+func (fb *FilterBank) SetMDCTTap(tap func(coeffs []float32)) {
+	if tap == nil {
+		fb.mdct256.Tap = nil
+		fb.mdct2048.Tap = nil
+		if fb.mdctLD != nil {
+			fb.mdctLD.Tap = nil
+		}
+		return
+	}
+
+	if fb.tapBuf == nil {
+		fb.tapBuf = make([]float32, len(fb.transfBuf)/2)
+	}
+	hook := func(z []fft.Complex) {
+		buf := fb.tapBuf[:2*len(z)]
+		for k, c := range z {
+			buf[2*k] = c.Re
+			buf[2*k+1] = c.Im
+		}
+		tap(buf)
+	}
+	fb.mdct256.Tap = hook
+	fb.mdct2048.Tap = hook
+	if fb.mdctLD != nil {
+		fb.mdctLD.Tap = hook
+	}
+}
+
 // IFilterBank performs the inverse filter bank operation.
 // This converts frequency-domain spectral data to time-domain samples.
 //
@@ -89,10 +139,10 @@ func (fb *FilterBank) IFilterBank(
 	nflat_ls := (nlong - nshort) / 2
 
 	// Get windows for current and previous frame
-	windowLong := GetLongWindow(int(windowShape))
-	windowLongPrev := GetLongWindow(int(windowShapePrev))
-	windowShort := GetShortWindow(int(windowShape))
-	windowShortPrev := GetShortWindow(int(windowShapePrev))
+	windowLong := fb.longWindow[windowShape]
+	windowLongPrev := fb.longWindow[windowShapePrev]
+	windowShort := fb.shortWindow[windowShape]
+	windowShortPrev := fb.shortWindow[windowShapePrev]
 
 	switch windowSequence {
 	case OnlyLongSequence:
@@ -215,6 +265,47 @@ func (fb *FilterBank) IFilterBank(
 	}
 }
 
+// IFilterBankLD performs the inverse filter bank for ER AAC LD (object
+// type 23). LD frames are always a single long block of len(freqIn)
+// samples (512 or 480), transformed with the 1024 or 960-point IMDCT and
+// windowed with the LD window selected by window shape: sine for
+// SineWindow, the low-overlap window for KBDWindow. The low-overlap window leaves the
+// last 3/8 of the overlap buffer zero, which is what gives LD its
+// reduced delay.
+//
+// Parameters match IFilterBank without the window sequence. The filter
+// bank must have been created with frameLen=1024 or 960.
+//
+// Ported from: ifilter_bank() LD_DEC path and imdct_long() in
+// ~/dev/faad2/libfaad/filtbank.c
+func (fb *FilterBank) IFilterBankLD(
+	windowShape uint8,
+	windowShapePrev uint8,
+	freqIn []float32,
+	timeOut []float32,
+	overlap []float32,
+) {
+	if fb.mdctLD == nil {
+		panic("filter bank was not created for AAC-LD")
+	}
+
+	nlong := len(freqIn)
+	transfBuf := fb.transfBuf
+
+	windowLong := GetLDWindow(int(windowShape), nlong)
+	windowLongPrev := GetLDWindow(int(windowShapePrev), nlong)
+
+	fb.mdctLD.IMDCT(freqIn, transfBuf)
+
+	// Same overlap-add as ONLY_LONG_SEQUENCE, with the LD windows
+	for i := 0; i < nlong; i++ {
+		timeOut[i] = overlap[i] + transfBuf[i]*windowLongPrev[i]
+	}
+	for i := 0; i < nlong; i++ {
+		overlap[i] = transfBuf[nlong+i] * windowLong[nlong-1-i]
+	}
+}
+
 // FilterBankLTP performs the forward filter bank operation for Long Term Prediction.
 // This converts time-domain samples to frequency-domain MDCT coefficients.
 //
@@ -246,8 +337,8 @@ func (fb *FilterBank) FilterBankLTP(
 	}
 
 	// Get windows for current and previous frame
-	windowLong := GetLongWindow(int(windowShape))
-	windowLongPrev := GetLongWindow(int(windowShapePrev))
+	windowLong := fb.longWindow[windowShape]
+	windowLongPrev := fb.longWindow[windowShapePrev]
 
 	// Use transfBuf as intermediate for forward MDCT output (needs 2*nlong)
 	// Only the first nlong coefficients are used in AAC
@@ -272,7 +363,7 @@ func (fb *FilterBank) FilterBankLTP(
 		// Second half: flat region + short window + zeros
 		// Ported from: filtbank.c:383-393
 		nflat_ls := (nlong - nshort) / 2
-		windowShort := GetShortWindow(int(windowShape))
+		windowShort := fb.shortWindow[windowShape]
 
 		for i := 0; i < nlong; i++ {
 			windowedBuf[i] = inData[i] * windowLongPrev[i]
@@ -295,7 +386,7 @@ func (fb *FilterBank) FilterBankLTP(
 		// Second half: window with long (descending)
 		// Ported from: filtbank.c:395-405
 		nflat_ls := (nlong - nshort) / 2
-		windowShortPrev := GetShortWindow(int(windowShapePrev))
+		windowShortPrev := fb.shortWindow[windowShapePrev]
 
 		for i := 0; i < nflat_ls; i++ {
 			windowedBuf[i] = 0
@@ -320,3 +411,35 @@ func (fb *FilterBank) FilterBankLTP(
 		panic("unknown window sequence in FilterBankLTP")
 	}
 }
+
+// FilterBankLTPLD performs the forward filter bank for Long Term
+// Prediction in ER AAC LD, the counterpart of IFilterBankLD: inData holds
+// 2*len(outMDCT) samples, windowed with the LD windows and transformed
+// with the 1024 or 960-point MDCT. The filter bank must have been created
+// with frameLen=1024 or 960.
+//
+// Ported from: filter_bank_ltp() LD_DEC path in ~/dev/faad2/libfaad/filtbank.c:337-408
+func (fb *FilterBank) FilterBankLTPLD(
+	windowShape uint8,
+	windowShapePrev uint8,
+	inData []float32,
+	outMDCT []float32,
+) {
+	if fb.mdctLD == nil {
+		panic("filter bank was not created for AAC-LD")
+	}
+
+	nlong := len(outMDCT)
+	windowedBuf := fb.windowedBuf
+	transfBuf := fb.transfBuf
+
+	windowLong := GetLDWindow(int(windowShape), nlong)
+	windowLongPrev := GetLDWindow(int(windowShapePrev), nlong)
+
+	for i := nlong - 1; i >= 0; i-- {
+		windowedBuf[i] = inData[i] * windowLongPrev[i]
+		windowedBuf[i+nlong] = inData[i+nlong] * windowLong[nlong-1-i]
+	}
+	fb.mdctLD.Forward(windowedBuf[:2*nlong], transfBuf[:2*nlong])
+	copy(outMDCT, transfBuf[:nlong])
+}
@@ -479,3 +479,147 @@ func TestFilterBank_RoundTrip_LTP(t *testing.T) {
 
 	t.Logf("Round-trip output energy: %v", energy)
 }
+
+func TestSetMDCTTap(t *testing.T) {
+	fb := NewFilterBank(1024)
+
+	freqIn := make([]float32, 1024)
+	for i := range freqIn {
+		freqIn[i] = float32(i%7) - 3
+	}
+	timeOut := make([]float32, 1024)
+	overlap := make([]float32, 1024)
+
+	var lengths []int
+	nonZero := false
+	fb.SetMDCTTap(func(coeffs []float32) {
+		lengths = append(lengths, len(coeffs))
+		for _, v := range coeffs {
+			if v != 0 {
+				nonZero = true
+			}
+		}
+	})
+
+	// Long block: one IMDCT of size 2048 -> 512 complex -> 1024 floats
+	fb.IFilterBank(OnlyLongSequence, SineWindow, SineWindow, freqIn, timeOut, overlap)
+	if len(lengths) != 1 || lengths[0] != 1024 {
+		t.Errorf("long block tap lengths: got %v, want [1024]", lengths)
+	}
+	if !nonZero {
+		t.Error("expected non-zero tapped coefficients")
+	}
+
+	// Short sequence: eight IMDCTs of size 256 -> 64 complex -> 128 floats
+	lengths = nil
+	fb.IFilterBank(EightShortSequence, SineWindow, SineWindow, freqIn, timeOut, overlap)
+	if len(lengths) != 8 {
+		t.Fatalf("short sequence tap calls: got %d, want 8", len(lengths))
+	}
+	for i, n := range lengths {
+		if n != 128 {
+			t.Errorf("short block %d tap length: got %d, want 128", i, n)
+		}
+	}
+
+	// Removing the tap stops the callbacks
+	fb.SetMDCTTap(nil)
+	lengths = nil
+	fb.IFilterBank(OnlyLongSequence, SineWindow, SineWindow, freqIn, timeOut, overlap)
+	if len(lengths) != 0 {
+		t.Errorf("expected no tap calls after removal, got %d", len(lengths))
+	}
+}
+
+func TestSetMDCTTap_DoesNotAlterOutput(t *testing.T) {
+	freqIn := make([]float32, 1024)
+	for i := range freqIn {
+		freqIn[i] = float32(math.Sin(float64(i) * 0.1))
+	}
+
+	run := func(tap bool) []float32 {
+		fb := NewFilterBank(1024)
+		if tap {
+			fb.SetMDCTTap(func([]float32) {})
+		}
+		timeOut := make([]float32, 1024)
+		overlap := make([]float32, 1024)
+		fb.IFilterBank(OnlyLongSequence, KBDWindow, SineWindow, freqIn, timeOut, overlap)
+		return timeOut
+	}
+
+	plain := run(false)
+	tapped := run(true)
+	for i := range plain {
+		if plain[i] != tapped[i] {
+			t.Fatalf("sample %d: got %v with tap, want %v", i, tapped[i], plain[i])
+		}
+	}
+}
+
+func TestIFilterBankLD_LowOverlapWindow(t *testing.T) {
+	const nlong = 512
+
+	run := func(shape uint8) (timeOut, overlap []float32) {
+		fb := NewFilterBank(2 * nlong)
+		freqIn := make([]float32, nlong)
+		for i := range freqIn {
+			freqIn[i] = float32(i%50) - 25
+		}
+		timeOut = make([]float32, nlong)
+		overlap = make([]float32, nlong)
+		fb.IFilterBankLD(shape, shape, freqIn, timeOut, overlap)
+		return timeOut, overlap
+	}
+
+	// Low-overlap window: the first 3/8 of the output has no contribution
+	// from the current frame, and the last 3/8 of the overlap is zero
+	timeOut, overlap := run(KBDWindow)
+	if len(timeOut) != nlong {
+		t.Fatalf("output length = %d, want %d", len(timeOut), nlong)
+	}
+	zeros := 3 * nlong / 8
+	for i := 0; i < zeros; i++ {
+		if timeOut[i] != 0 {
+			t.Fatalf("timeOut[%d] = %v, want 0", i, timeOut[i])
+		}
+	}
+	for i := nlong - zeros; i < nlong; i++ {
+		if overlap[i] != 0 {
+			t.Fatalf("overlap[%d] = %v, want 0", i, overlap[i])
+		}
+	}
+	nonZero := 0
+	for _, v := range overlap[:nlong-zeros] {
+		if v != 0 {
+			nonZero++
+		}
+	}
+	if nonZero == 0 {
+		t.Error("overlap region of the low-overlap window should be non-zero")
+	}
+
+	// The sine shape overlaps over the full frame, like LC
+	_, sineOverlap := run(SineWindow)
+	nonZero = 0
+	for _, v := range sineOverlap[nlong-zeros:] {
+		if v != 0 {
+			nonZero++
+		}
+	}
+	if nonZero == 0 {
+		t.Error("sine LD window should overlap the whole frame")
+	}
+}
+
+func TestIFilterBankLD_RequiresLDFrameLength(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Error("expected panic for a filter bank without the LD transform")
+		}
+	}()
+	fb := NewFilterBank(1024)
+	fb.mdctLD = nil // as for frame lengths without an LD transform
+	buf := make([]float32, 512)
+	fb.IFilterBankLD(SineWindow, SineWindow, buf, make([]float32, 512), make([]float32, 512))
+}
@@ -0,0 +1,62 @@
+// Package filterbank ltp.go provides the forward MDCT used by Long Term
+// Prediction.
+package filterbank
+
+import "github.com/llehouerou/go-aac/internal/tables"
+
+// ForwardMDCT runs the LTP forward filter bank on the float64 buffers of
+// the spectrum package, whose spectrum.ForwardMDCT interface it
+// implements. The underlying FilterBank is created on first use for the
+// requested frame length and reused while it stays the same.
+//
+// A ForwardMDCT reuses internal buffers and must not be shared between
+// goroutines.
+type ForwardMDCT struct {
+	fb       *FilterBank
+	frameLen uint16
+	ld       bool
+
+	in  []float32 // 2*frameLen time samples
+	out []float32 // frameLen MDCT coefficients
+}
+
+// NewForwardMDCT creates a forward MDCT for LTP.
+func NewForwardMDCT() *ForwardMDCT {
+	return &ForwardMDCT{}
+}
+
+// FilterBankLTP windows the 2*frameLen samples of inData for the window
+// sequence and shapes, and writes their frameLen MDCT coefficients to
+// outMDCT. AAC-LD (objectType 23) uses the LD windows and transform;
+// EIGHT_SHORT_SEQUENCE is not supported, as LTP skips short blocks.
+//
+// Ported from: filter_bank_ltp() in ~/dev/faad2/libfaad/filtbank.c:337-408
+func (f *ForwardMDCT) FilterBankLTP(windowSequence uint8, windowShape, windowShapePrev uint8,
+	inData []float64, outMDCT []float64, objectType uint8, frameLen uint16,
+) {
+	ld := objectType == tables.ObjectTypeLD
+	if f.fb == nil || f.frameLen != frameLen || f.ld != ld {
+		// An LD filter bank is built for the un-halved frame length
+		fbLen := frameLen
+		if ld {
+			fbLen = 2 * frameLen
+		}
+		f.fb = NewFilterBank(fbLen)
+		f.frameLen = frameLen
+		f.ld = ld
+		f.in = make([]float32, 2*frameLen)
+		f.out = make([]float32, frameLen)
+	}
+
+	for i := range f.in {
+		f.in[i] = float32(inData[i])
+	}
+	if ld {
+		f.fb.FilterBankLTPLD(windowShape, windowShapePrev, f.in, f.out)
+	} else {
+		f.fb.FilterBankLTP(windowSequence, windowShape, windowShapePrev, f.in, f.out)
+	}
+	for i, v := range f.out {
+		outMDCT[i] = float64(v)
+	}
+}
@@ -0,0 +1,59 @@
+package filterbank
+
+import (
+	"math"
+	"testing"
+
+	"github.com/llehouerou/go-aac/internal/tables"
+)
+
+func TestForwardMDCT_MatchesFilterBankLTP(t *testing.T) {
+	const n = 1024
+	in := make([]float64, 2*n)
+	in32 := make([]float32, 2*n)
+	for i := range in {
+		in[i] = 1000 * math.Sin(float64(i)*0.031)
+		in32[i] = float32(in[i])
+	}
+
+	want := make([]float32, n)
+	NewFilterBank(n).FilterBankLTP(LongStartSequence, 1, 0, in32, want)
+
+	got := make([]float64, 2*n)
+	NewForwardMDCT().FilterBankLTP(LongStartSequence, 1, 0, in, got, tables.ObjectTypeLTP, n)
+	for k := range want {
+		if got[k] != float64(want[k]) {
+			t.Fatalf("coefficient %d = %v, want %v", k, got[k], want[k])
+		}
+	}
+}
+
+// TestFilterBankLTPLD_PerfectReconstruction checks that the LD forward
+// filter bank inverts IFilterBankLD for both window shapes: the
+// low-overlap window reconstructs as the sine window does.
+func TestFilterBankLTPLD_PerfectReconstruction(t *testing.T) {
+	for _, n := range []int{LDWindowSize512, LDWindowSize480} {
+		fb := NewFilterBank(uint16(2 * n))
+		x := make([]float32, 6*n)
+		for i := range x {
+			x[i] = float32(math.Sin(float64(i)*0.07) + 0.5*math.Sin(float64(i)*0.43))
+		}
+
+		spec := make([]float32, n)
+		timeOut := make([]float32, n)
+		overlap := make([]float32, n)
+		for f := 0; f+2*n <= len(x); f += n {
+			shape := uint8(f / n % 2)
+			fb.FilterBankLTPLD(shape, 1-shape, x[f:f+2*n], spec)
+			fb.IFilterBankLD(shape, 1-shape, spec, timeOut, overlap)
+			if f == 0 {
+				continue
+			}
+			for i, v := range timeOut {
+				if math.Abs(float64(v-x[f+i])) > 1e-4 {
+					t.Fatalf("n=%d, frame at %d: out[%d] = %v, want %v", n, f, i, v, x[f+i])
+				}
+			}
+		}
+	}
+}
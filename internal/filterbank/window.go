@@ -51,3 +51,36 @@ func GetShortWindow(shape int) []float32 {
 		panic("invalid window shape")
 	}
 }
+
+// Window returns the rising half of the window of the given shape for
+// blocks of length samples: the long and short windows of 1024-sample
+// frames (1024, 128) and of 960-sample frames (960, 120). shape must be
+// SineWindow (0) or KBDWindow (1). The falling half is the same window
+// reversed.
+//
+// Ported from: sine_long_1024, kbd_long_1024 and the other window tables
+// selected in filter_bank_init() in ~/dev/faad2/libfaad/filtbank.c
+func Window(shape uint8, length int) []float32 {
+	var sine, kbd []float32
+	switch length {
+	case LongWindowSize:
+		sine, kbd = sineLong1024[:], kbdLong1024[:]
+	case ShortWindowSize:
+		sine, kbd = sineShort128[:], kbdShort128[:]
+	case LongWindowSize960:
+		sine, kbd = sineLong960[:], kbdLong960[:]
+	case ShortWindowSize960:
+		sine, kbd = sineShort120[:], kbdShort120[:]
+	default:
+		panic("invalid window length")
+	}
+
+	switch shape {
+	case SineWindow:
+		return sine
+	case KBDWindow:
+		return kbd
+	default:
+		panic("invalid window shape")
+	}
+}
@@ -0,0 +1,28 @@
+// Package filterbank window_960.go provides the windows of 960-sample frames.
+package filterbank
+
+// Window sizes for 960-sample frames (frameLengthFlag set).
+const (
+	// LongWindowSize960 is the size of long windows of 960-sample frames.
+	LongWindowSize960 = 960
+
+	// ShortWindowSize960 is the size of short windows of 960-sample frames.
+	ShortWindowSize960 = 120
+)
+
+// frameWindows returns the long and short windows, indexed by window
+// shape, of frames of frameLen samples (1024 or 960).
+//
+// Ported from: fb->long_window, fb->short_window set up in
+// filter_bank_init() in ~/dev/faad2/libfaad/filtbank.c
+func frameWindows(frameLen uint16) (long, short [2][]float32) {
+	nlong, nshort := LongWindowSize, ShortWindowSize
+	if frameLen == LongWindowSize960 {
+		nlong, nshort = LongWindowSize960, ShortWindowSize960
+	}
+	for shape := range long {
+		long[shape] = Window(uint8(shape), nlong)
+		short[shape] = Window(uint8(shape), nshort)
+	}
+	return long, short
+}
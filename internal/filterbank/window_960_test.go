@@ -0,0 +1,75 @@
+package filterbank
+
+import (
+	"math"
+	"testing"
+)
+
+// TestSineTables_960 checks the sine windows of 960-sample frames
+// against the formula, rounded to float32 as FAAD2's tables are.
+func TestSineTables_960(t *testing.T) {
+	for _, w := range [][]float32{sineLong960[:], sineShort120[:]} {
+		n := len(w)
+		for i, got := range w {
+			want := float32(math.Sin(math.Pi / float64(2*n) * (float64(i) + 0.5)))
+			if got != want {
+				t.Errorf("size %d: w[%d] = %v, want %v", n, i, got, want)
+				break
+			}
+		}
+	}
+}
+
+func TestFrameWindows_960(t *testing.T) {
+	long, short := frameWindows(LongWindowSize960)
+	for shape := range 2 {
+		if len(long[shape]) != LongWindowSize960 || len(short[shape]) != ShortWindowSize960 {
+			t.Fatalf("shape %d: window lengths %d/%d, want 960/120",
+				shape, len(long[shape]), len(short[shape]))
+		}
+		for _, w := range [][]float32{long[shape], short[shape]} {
+			n := len(w)
+			for i := 0; i < n/2; i++ {
+				sum := float64(w[i])*float64(w[i]) + float64(w[n-1-i])*float64(w[n-1-i])
+				if math.Abs(sum-1) > 1e-5 {
+					t.Errorf("shape %d, size %d: TDAC violation at %d: %v", shape, n, i, sum)
+					break
+				}
+			}
+		}
+	}
+}
+
+// TestFilterBank_960_PerfectReconstruction runs a signal through the
+// forward and inverse filter banks frame by frame, alternating window
+// shapes: after the first frame, the overlap-added output reproduces the
+// input.
+func TestFilterBank_960_PerfectReconstruction(t *testing.T) {
+	const n = LongWindowSize960
+	fb := NewFilterBank(n)
+	if fb.mdctLD == nil {
+		t.Error("expected the 480-sample LD transform to be initialized")
+	}
+
+	x := make([]float32, 6*n)
+	for i := range x {
+		x[i] = float32(math.Sin(float64(i)*0.05) + 0.3*math.Sin(float64(i)*0.71))
+	}
+
+	spec := make([]float32, n)
+	timeOut := make([]float32, n)
+	overlap := make([]float32, n)
+	for f := 0; f+2*n <= len(x); f += n {
+		shape := uint8(f / n % 2)
+		fb.FilterBankLTP(OnlyLongSequence, shape, 1-shape, x[f:f+2*n], spec)
+		fb.IFilterBank(OnlyLongSequence, shape, 1-shape, spec, timeOut, overlap)
+		if f == 0 {
+			continue
+		}
+		for i, v := range timeOut {
+			if math.Abs(float64(v-x[f+i])) > 1e-4 {
+				t.Fatalf("frame at %d: out[%d] = %v, want %v", f, i, v, x[f+i])
+			}
+		}
+	}
+}
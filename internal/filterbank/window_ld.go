@@ -0,0 +1,39 @@
+// Package filterbank window_ld.go provides the ER AAC LD windows.
+package filterbank
+
+// LD window sizes: half of the 1024/960-sample LD transform.
+const (
+	// LDWindowSize512 is the LD window size for 512-sample frames.
+	LDWindowSize512 = 512
+
+	// LDWindowSize480 is the LD window size for 480-sample frames.
+	LDWindowSize480 = 480
+)
+
+// GetLDWindow returns the LD window for the given shape and frame length.
+// shape must be SineWindow (0) or KBDWindow (1), the latter selecting the
+// low-overlap window (ISO/IEC 14496-3, 4.6.20.2), which is zero over the
+// first 3/8 of the half window, a sine slope over the next quarter and
+// one over the rest; frameLen must be 512 or 480.
+//
+// Ported from: fb->ld_window[window_shape] in ~/dev/faad2/libfaad/filtbank.c
+func GetLDWindow(shape int, frameLen int) []float32 {
+	var sine, lowOverlap []float32
+	switch frameLen {
+	case LDWindowSize512:
+		sine, lowOverlap = sineMid512[:], ldMid512[:]
+	case LDWindowSize480:
+		sine, lowOverlap = sineMid480[:], ldMid480[:]
+	default:
+		panic("invalid LD frame length")
+	}
+
+	switch shape {
+	case SineWindow:
+		return sine
+	case KBDWindow:
+		return lowOverlap
+	default:
+		panic("invalid window shape")
+	}
+}
@@ -0,0 +1,75 @@
+// Package filterbank window_ld_test.go tests the AAC-LD windows.
+package filterbank
+
+import (
+	"fmt"
+	"math"
+	"testing"
+)
+
+func TestGetLDWindow_LowOverlapShape(t *testing.T) {
+	tests := []struct {
+		frameLen int
+		zeros    int // 3N/16 with N = 2*frameLen
+		slope    int // N/8
+	}{
+		{512, 192, 128},
+		{480, 180, 120},
+	}
+
+	for _, tt := range tests {
+		t.Run(fmt.Sprintf("n=%d", tt.frameLen), func(t *testing.T) {
+			w := GetLDWindow(KBDWindow, tt.frameLen)
+			if len(w) != tt.frameLen {
+				t.Fatalf("len = %d, want %d", len(w), tt.frameLen)
+			}
+			for i := 0; i < tt.zeros; i++ {
+				if w[i] != 0 {
+					t.Fatalf("w[%d] = %v, want 0", i, w[i])
+				}
+			}
+			for i := tt.zeros; i < tt.zeros+tt.slope; i++ {
+				if w[i] <= 0 || w[i] >= 1 {
+					t.Fatalf("w[%d] = %v, want in (0, 1)", i, w[i])
+				}
+			}
+			for i := tt.zeros + tt.slope; i < tt.frameLen; i++ {
+				if w[i] != 1 {
+					t.Fatalf("w[%d] = %v, want 1", i, w[i])
+				}
+			}
+		})
+	}
+}
+
+func TestGetLDWindow_PrincenBradley(t *testing.T) {
+	// Perfect reconstruction requires w[i]^2 + w[n-1-i]^2 = 1
+	for _, n := range []int{512, 480} {
+		for _, shape := range []int{SineWindow, KBDWindow} {
+			w := GetLDWindow(shape, n)
+			for i := 0; i < n; i++ {
+				sum := float64(w[i])*float64(w[i]) + float64(w[n-1-i])*float64(w[n-1-i])
+				if math.Abs(sum-1) > 1e-6 {
+					t.Fatalf("n=%d shape=%d: w[%d]^2 + w[%d]^2 = %v, want 1", n, shape, i, n-1-i, sum)
+				}
+			}
+		}
+	}
+}
+
+func TestGetLDWindow_Sine(t *testing.T) {
+	w := GetLDWindow(SineWindow, 512)
+	want := math.Sin(math.Pi / 1024 * 0.5)
+	if math.Abs(float64(w[0])-want) > 1e-9 {
+		t.Errorf("w[0] = %v, want %v", w[0], want)
+	}
+}
+
+func TestGetLDWindow_InvalidFrameLength(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Error("expected panic for frame length 1024")
+		}
+	}()
+	GetLDWindow(SineWindow, 1024)
+}
@@ -0,0 +1,291 @@
+// Sine window tables of 960-sample frames, the sine_long_960 and
+// sine_short_120 tables of ~/dev/faad2/libfaad/sine_win.h.
+//
+// These values were evaluated in float64 from the formula below and
+// rounded to float32; they were not copied from sine_win.h. Evaluated
+// the same way, the formula reproduces the sine_long_1024 and
+// sine_short_128 tables of sine_win.h bit for bit. Running
+// scripts/generate_windows.go against a FAAD2 tree replaces this file
+// with the tables of sine_win.h.
+//
+// Formula: w[n] = sin((π/2N) * (n + 0.5)) for n = 0..N-1
+
+package filterbank
+
+// sineLong960 contains 960 sine window coefficients.
+var sineLong960 = [960]float32{
+	0.00081812299560725323, 0.0024543667964602917, 0.0040906040262347889, 0.0057268303042312665,
+	0.0073630412497795667, 0.0089992324822505774, 0.010635399621067973, 0.012271538285719925,
+	0.013907644095770843, 0.015543712670873098, 0.017179739630778748, 0.018815720595351273,
+	0.020451651184577289, 0.022087527018578291, 0.023723343717622355, 0.025359096902135895,
+	0.02699478219271537, 0.028630395210139003, 0.030265931575378515, 0.031901386909610863,
+	0.033536756834229922, 0.035172036970858266, 0.036807222941358832, 0.03844231036784667,
+	0.040077294872700696, 0.041712172078575326, 0.043346937608412281, 0.044981587085452274,
+	0.046616116133246711, 0.048250520375669424, 0.049884795436928406, 0.051518936941577477,
+	0.053152940514528055, 0.05478680178106083, 0.056420516366837495, 0.058054079897912433,
+	0.059687488000744485, 0.061320736302208578, 0.062953820429607482, 0.064586736010683543,
+	0.066219478673630344, 0.067852044047104376, 0.069484427760236847, 0.071116625442645326,
+	0.072748632724445358, 0.074380445236262346, 0.076012058609243122, 0.077643468475067617,
+	0.079274670465960706, 0.080905660214703745, 0.082536433354646319, 0.084166985519717977,
+	0.085797312344439894, 0.08742740946393647, 0.089057272513947183, 0.090686897130838162,
+	0.092316278951613845, 0.093945413613928774, 0.095574296756099186, 0.097202924017114667,
+	0.098831291036649949, 0.10045939345507648, 0.10208722691347409, 0.10371478705364275,
+	0.10534206951811415, 0.10696906995016339, 0.10859578399382071, 0.11022220729388306,
+	0.11184833549592578, 0.11347416424631433, 0.11509968919221586, 0.11672490598161088,
+	0.11834981026330495, 0.11997439768694032, 0.12159866390300751, 0.12322260456285709,
+	0.12484621531871118, 0.12646949182367517, 0.12809242973174936, 0.12971502469784049,
+	0.13133727237777362, 0.13295916842830346, 0.13458070850712617, 0.13620188827289098,
+	0.1378227033852118, 0.13944314950467873, 0.14106322229286994, 0.14268291741236294,
+	0.14430223052674651, 0.1459211573006321, 0.14753969339966552, 0.14915783449053857,
+	0.15077557624100058, 0.15239291431987001, 0.15400984439704607, 0.15562636214352044,
+	0.15724246323138855, 0.15885814333386142, 0.16047339812527725, 0.16208822328111283,
+	0.16370261447799522, 0.16531656739371339, 0.16693007770722967, 0.16854314109869134,
+	0.17015575324944229, 0.17176790984203447, 0.17337960656023951, 0.1749908390890603,
+	0.17660160311474246, 0.1782118943247859, 0.17982170840795647, 0.18143104105429744,
+	0.18303988795514095, 0.1846482448031197, 0.18625610729217837, 0.18786347111758517,
+	0.18947033197594348, 0.19107668556520319, 0.19268252758467228, 0.19428785373502844,
+	0.19589265971833042, 0.19749694123802966, 0.19910069399898173, 0.20070391370745785,
+	0.20230659607115639, 0.20390873679921434, 0.20551033160221882, 0.20711137619221853,
+	0.2087118662827353, 0.2103117975887755, 0.21191116582684155, 0.21350996671494332,
+	0.21510819597260969, 0.21670584932089998, 0.2183029224824154, 0.21989941118131037,
+	0.22149531114330429, 0.22309061809569264, 0.22468532776735858, 0.22627943588878449,
+	0.22787293819206314, 0.22946583041090926, 0.23105810828067111, 0.23264976753834157,
+	0.23424080392256982, 0.2358312131736727, 0.23742099103364595, 0.23901013324617582,
+	0.24059863555665043, 0.24218649371217096, 0.2437737034615633, 0.24536026055538934,
+	0.24694616074595821, 0.24853139978733782, 0.25011597343536629, 0.25169987744766298,
+	0.25328310758364025, 0.25486565960451457, 0.25644752927331782, 0.25802871235490898,
+	0.25960920461598508, 0.26118900182509258, 0.26276809975263904, 0.264346494170904,
+	0.26592418085405062, 0.26750115557813692, 0.2690774141211269, 0.27065295226290209,
+	0.2722277657852728, 0.27380185047198924, 0.27537520210875299, 0.27694781648322825,
+	0.27851968938505306, 0.28009081660585067, 0.28166119393924061, 0.28323081718085019,
+	0.28479968212832557, 0.28636778458134327, 0.28793512034162105, 0.2895016852129294,
+	0.29106747500110269, 0.29263248551405047, 0.29419671256176855, 0.29576015195635053,
+	0.29732279951199847, 0.29888465104503475, 0.30044570237391266, 0.30200594931922808,
+	0.30356538770373032, 0.30512401335233358, 0.30668182209212791, 0.3082388097523906,
+	0.30979497216459695, 0.31135030516243195, 0.31290480458180114, 0.31445846626084173,
+	0.31601128603993378, 0.31756325976171151, 0.31911438327107416, 0.32066465241519726,
+	0.32221406304354389, 0.3237626110078754, 0.32531029216226293, 0.32685710236309823,
+	0.32840303746910487, 0.32994809334134934, 0.33149226584325214, 0.33303555084059877,
+	0.33457794420155085, 0.33611944179665709, 0.33766003949886464, 0.33919973318352969,
+	0.34073851872842903, 0.34227639201377058, 0.34381334892220483, 0.34534938533883547,
+	0.34688449715123082, 0.34841868024943451, 0.34995193052597684, 0.35148424387588523,
+	0.3530156161966958, 0.35454604338846402, 0.35607552135377557, 0.35760404599775775,
+	0.35913161322809017, 0.36065821895501549, 0.36218385909135087, 0.36370852955249849,
+	0.36523222625645668, 0.36675494512383072, 0.36827668207784414, 0.36979743304434909,
+	0.37131719395183754, 0.37283596073145214, 0.37435372931699723, 0.37587049564494945,
+	0.37738625565446904, 0.37890100528741016, 0.38041474048833229, 0.38192745720451066,
+	0.38343915138594736, 0.38494981898538216, 0.38645945595830333, 0.38796805826295838,
+	0.38947562186036483, 0.39098214271432136, 0.39248761679141814, 0.39399204006104804,
+	0.39549540849541731, 0.39699771806955619, 0.39849896476132979, 0.39999914455144892,
+	0.40149825342348083, 0.4029962873638599, 0.40449324236189854, 0.40598911440979762,
+	0.40748389950265756, 0.40897759363848879, 0.41047019281822261, 0.41196169304572172,
+	0.41345209032779134, 0.41494138067418929, 0.41642956009763715, 0.41791662461383078,
+	0.41940257024145089, 0.42088739300217382, 0.42237108892068231, 0.42385365402467579,
+	0.42533508434488138, 0.42681537591506419, 0.42829452477203828, 0.42977252695567691,
+	0.43124937850892359, 0.4327250754778022, 0.43419961391142781, 0.43567298986201736,
+	0.43714519938489993, 0.4386162385385276, 0.44008610338448589, 0.4415547899875043,
+	0.44302229441546676, 0.4444886127394222, 0.44595374103359531, 0.44741767537539662,
+	0.44888041184543348, 0.45034194652752002, 0.45180227550868812, 0.45326139487919759,
+	0.45471930073254679, 0.45617598916548291, 0.45763145627801283, 0.45908569817341288,
+	0.46053871095824001, 0.46199049074234161, 0.46344103363886635, 0.4648903357642743,
+	0.46633839323834758, 0.46778520218420055, 0.46923075872829029, 0.47067505900042678,
+	0.47211809913378361, 0.473559875264908, 0.47500038353373147, 0.47643962008357982,
+	0.47787758106118372, 0.47931426261668875, 0.48074966090366611, 0.48218377207912272,
+	0.48361659230351117, 0.48504811774074069, 0.48647834455818684, 0.48790726892670194,
+	0.48933488702062544, 0.49076119501779414, 0.49218618909955225, 0.4936098654507618,
+	0.49503222025981264, 0.49645324971863303, 0.49787295002269943, 0.49929131737104687,
+	0.50070834796627905, 0.50212403801457872, 0.50353838372571758, 0.50495138131306638,
+	0.50636302699360547, 0.50777331698793449, 0.50918224752028274, 0.51058981481851895,
+	0.51199601511416226, 0.51340084464239111, 0.5148042996420541, 0.51620637635567967,
+	0.51760707102948678, 0.51900637991339404, 0.5204042992610306, 0.52180082532974559,
+	0.5231959543806185, 0.52458968267846895, 0.52598200649186677, 0.52737292209314224,
+	0.52876242575839572, 0.53015051376750766, 0.53153718240414882, 0.53292242795578981,
+	0.53430624671371152, 0.53568863497301467, 0.5370695890326298, 0.5384491051953274,
+	0.53982717976772743, 0.54120380906030963, 0.54257898938742311, 0.54395271706729609,
+	0.54532498842204635, 0.54669579977769045, 0.54806514746415391, 0.54943302781528081,
+	0.55079943716884383, 0.55216437186655387, 0.55352782825406999, 0.55488980268100907,
+	0.55625029150095584, 0.55760929107147217, 0.55896679775410718, 0.56032280791440703,
+	0.56167731792192455, 0.56303032415022869, 0.56438182297691453, 0.56573181078361312,
+	0.56708028395600085, 0.56842723888380908, 0.56977267196083414, 0.57111657958494688,
+	0.5724589581581021, 0.57379980408634845, 0.57513911377983773, 0.57647688365283478,
+	0.57781311012372727, 0.57914778961503466, 0.58048091855341843, 0.58181249336969099,
+	0.58314251049882604, 0.58447096637996743, 0.58579785745643886, 0.5871231801757536,
+	0.58844693098962408, 0.58976910635397084, 0.59108970272893246, 0.59240871657887506,
+	0.59372614437240179, 0.59504198258236185, 0.59635622768586039, 0.59766887616426767,
+	0.59897992450322879, 0.60028936919267273, 0.60159720672682204, 0.60290343360420195,
+	0.60420804632765002, 0.60551104140432555, 0.60681241534571839, 0.60811216466765883,
+	0.60941028589032697, 0.61070677553826158, 0.61200163014036979, 0.61329484622993591,
+	0.6145864203446314, 0.61587634902652377, 0.61716462882208556, 0.61845125628220421,
+	0.61973622796219074, 0.6210195404217892, 0.62230119022518604, 0.62358117394101886,
+	0.62485948814238634, 0.62613612940685637, 0.62741109431647635, 0.62868437945778133,
+	0.62995598142180376, 0.6312258968040827, 0.63249412220467238, 0.63376065422815175,
+	0.63502548948363347, 0.63628862458477287, 0.63755005614977711, 0.63880978080141437,
+	0.64006779516702261, 0.64132409587851869, 0.64257867957240766, 0.64383154288979139,
+	0.64508268247637779, 0.64633209498248945, 0.64757977706307335, 0.64882572537770888,
+	0.65006993659061751, 0.65131240737067142, 0.65255313439140239, 0.6537921143310107,
+	0.65502934387237444, 0.6562648197030575, 0.65749853851531948, 0.65873049700612374,
+	0.65996069187714679, 0.66118911983478657, 0.66241577759017178, 0.66364066185917048,
+	0.66486376936239888, 0.66608509682523009, 0.66730464097780284, 0.66852239855503059,
+	0.66973836629660977, 0.67095254094702883, 0.67216491925557675, 0.67337549797635199,
+	0.67458427386827102, 0.67579124369507693, 0.67699640422534835, 0.67819975223250784,
+	0.6794012844948305, 0.68060099779545302, 0.68179888892238183, 0.6829949546685018,
+	0.68418919183158522, 0.68538159721429948, 0.6865721676242168, 0.68776089987382172,
+	0.68894779078052026, 0.69013283716664842, 0.69131603585948032, 0.69249738369123692,
+	0.69367687749909468, 0.69485451412519361, 0.69603029041664599, 0.69720420322554499,
+	0.6983762494089728, 0.69954642582900894, 0.70071472935273893, 0.70188115685226271,
+	0.70304570520470289, 0.70420837129221303, 0.70536915200198613, 0.70652804422626281,
+	0.70768504486233985, 0.70884015081257845, 0.70999335898441218, 0.71114466629035589,
+	0.71229406964801356, 0.71344156598008612, 0.71458715221438085, 0.71573082528381859,
+	0.71687258212644223, 0.71801241968542517, 0.71915033490907943, 0.72028632475086318,
+	0.72142038616938986, 0.72255251612843596, 0.72368271159694841, 0.72481096954905433,
+	0.72593728696406734, 0.72706166082649704, 0.72818408812605584, 0.72930456585766834,
+	0.73042309102147829, 0.73153966062285736, 0.73265427167241282, 0.73376692118599507,
+	0.73487760618470666, 0.73598632369490991, 0.73709307074823383, 0.73819784438158398,
+	0.7393006416371487, 0.74040145956240777, 0.74150029521014038, 0.74259714563843293,
+	0.74369200791068657, 0.74478487909562552, 0.74587575626730473, 0.7469646365051178,
+	0.74805151689380467, 0.74913639452345926, 0.75021926648953774, 0.7513001298928661,
+	0.75237898183964769, 0.75345581944147122, 0.75453063981531798, 0.75560344008357094,
+	0.75667421737402052, 0.7577429688198738, 0.75880969155976152, 0.75987438273774599,
+	0.76093703950332836, 0.76199765901145666, 0.76305623842253323, 0.7641127749024228,
+	0.76516726562245885, 0.76621970775945247, 0.76727009849569949, 0.76831843501898767,
+	0.76936471452260446, 0.77040893420534517, 0.77145109127151923, 0.77249118293095853,
+	0.77352920639902467, 0.77456515889661659, 0.77559903765017735, 0.77663083989170278,
+	0.77766056285874774, 0.77868820379443371, 0.77971375994745673, 0.78073722857209438,
+	0.7817586069282132, 0.78277789228127592, 0.7837950819023487, 0.78481017306810918,
+	0.78582316306085265, 0.78683404916849986, 0.78784282868460465, 0.78884949890836076,
+	0.78985405714460888, 0.79085650070384439, 0.79185682690222425, 0.79285503306157401,
+	0.79385111650939555, 0.79484507457887377, 0.79583690460888346, 0.7968266039439974,
+	0.79781416993449272, 0.79879959993635774, 0.79978289131130009, 0.80076404142675273,
+	0.80174304765588145, 0.80271990737759213, 0.80369461797653707, 0.80466717684312294,
+	0.80563758137351671, 0.80660582896965372, 0.80757191703924336, 0.80853584299577741,
+	0.80949760425853601, 0.81045719825259466, 0.81141462240883167, 0.81236987416393414,
+	0.81332295096040597, 0.81427385024657362, 0.81522256947659355, 0.81616910611045879,
+	0.81711345761400589, 0.81805562145892174, 0.81899559512275033, 0.81993337608889916,
+	0.82086896184664637, 0.82180234989114709, 0.82273353772344116, 0.82366252285045805,
+	0.82458930278502518, 0.82551387504587381, 0.82643623715764569, 0.82735638665089983,
+	0.82827432106211907, 0.82919003793371693, 0.83010353481404364, 0.83101480925739313,
+	0.83192385882400965, 0.83283068108009373, 0.8337352735978093, 0.83463763395529,
+	0.83553775973664568, 0.83643564853196861, 0.8373312979373404, 0.83822470555483797,
+	0.8391158689925402, 0.84000478586453453, 0.84089145379092289, 0.84177587039782842,
+	0.84265803331740163, 0.84353794018782702, 0.844415588653329, 0.84529097636417849,
+	0.84616410097669936, 0.84703496015327406, 0.84790355156235042, 0.84876987287844796,
+	0.8496339217821639, 0.85049569596017915, 0.85135519310526508, 0.85221241091628885,
+	0.85306734709822074, 0.85391999936213903, 0.8547703654252371, 0.85561844301082923,
+	0.85646422984835635, 0.85730772367339259, 0.85814892222765105, 0.85898782325899026,
+	0.85982442452141949, 0.86065872377510555, 0.86149071878637817, 0.86232040732773629,
+	0.86314778717785412, 0.86397285612158659, 0.86479561194997623, 0.86561605246025763,
+	0.86643417545586487, 0.8672499787464365, 0.86806346014782143, 0.86887461748208539,
+	0.86968344857751578, 0.87048995126862871, 0.8712941233961734, 0.87209596280713941,
+	0.87289546735476109, 0.87369263489852411, 0.87448746330417138, 0.87527995044370765,
+	0.87607009419540649, 0.8768578924438154, 0.87764334307976144, 0.87842644400035663,
+	0.8792071931090043, 0.87998558831540419, 0.88076162753555787, 0.88153530869177488,
+	0.88230662971267804, 0.88307558853320878, 0.88384218309463292, 0.8846064113445461,
+	0.88536827123687933, 0.88612776073190436, 0.88688487779623937, 0.88763962040285382,
+	0.8883919865310751, 0.88914197416659224, 0.8898895813014629, 0.8906348059341177,
+	0.89137764606936598, 0.89211809971840128, 0.89285616489880615, 0.89359183963455824,
+	0.89432512195603453, 0.89505600990001788, 0.89578450150970124, 0.8965105948346932,
+	0.89723428793102356, 0.89795557886114807, 0.89867446569395382, 0.89939094650476437,
+	0.90010501937534515, 0.900816682393908, 0.90152593365511691, 0.90223277126009283,
+	0.90293719331641886, 0.90363919793814496, 0.90433878324579364, 0.90503594736636417,
+	0.90573068843333904, 0.90642300458668679, 0.90711289397286898, 0.90780035474484411,
+	0.90848538506207255, 0.90916798309052238, 0.90984814700267291, 0.9105258749775208,
+	0.91120116520058425, 0.91187401586390815, 0.91254442516606893, 0.9132123913121788,
+	0.91387791251389161, 0.91454098698940678, 0.91520161296347435, 0.91585978866739981,
+	0.91651551233904882, 0.91716878222285148, 0.91781959656980794, 0.91846795363749245,
+	0.91911385169005766, 0.9197572889982405, 0.92039826383936529, 0.92103677449734978,
+	0.92167281926270872, 0.92230639643255863, 0.92293750431062305, 0.92356614120723612,
+	0.92419230543934772, 0.92481599533052772, 0.92543720921097061, 0.92605594541749991,
+	0.92667220229357261, 0.92728597818928349, 0.9278972714613698, 0.92850608047321559,
+	0.92911240359485581, 0.92971623920298085, 0.93031758568094147, 0.93091644141875185,
+	0.93151280481309495, 0.93210667426732674, 0.93269804819147983, 0.93328692500226818,
+	0.93387330312309147, 0.93445718098403896, 0.93503855702189376, 0.9356174296801375,
+	0.9361937974089537, 0.93676765866523259, 0.93733901191257485, 0.93790785562129597,
+	0.93847418826842988, 0.93903800833773399, 0.93959931431969201, 0.94015810471151928,
+	0.94071437801716529, 0.94126813274731935, 0.94181936741941308, 0.94236808055762578,
+	0.94291427069288702, 0.94345793636288122, 0.94399907611205214, 0.9445376884916058,
+	0.94507377205951448, 0.94560732538052128, 0.94613834702614352, 0.94666683557467624,
+	0.94719278961119668, 0.94771620772756759, 0.94823708852244104, 0.94875543060126255,
+	0.94927123257627422, 0.94978449306651913, 0.95029521069784439, 0.9508033841029051,
+	0.95130901192116835, 0.95181209279891599, 0.95231262538924932, 0.95281060835209208,
+	0.95330604035419375, 0.95379892006913403, 0.95428924617732525, 0.95477701736601717,
+	0.95526223232929941, 0.95574488976810557, 0.95622498839021619, 0.95670252691026292,
+	0.95717750404973156, 0.95764991853696524, 0.95811976910716823, 0.95858705450240911,
+	0.9590517734716244, 0.95951392477062125, 0.95997350716208185, 0.96043051941556568,
+	0.96088496030751358, 0.96133682862125036, 0.96178612314698864, 0.96223284268183173,
+	0.96267698602977692, 0.96311855200171881, 0.96355753941545252, 0.96399394709567654,
+	0.96442777387399625, 0.96485901858892686, 0.96528768008589638, 0.96571375721724884,
+	0.96613724884224794, 0.96655815382707877, 0.96697647104485207, 0.96739219937560705,
+	0.9678053377063135, 0.96821588493087596, 0.9686238399501359, 0.9690292016718749,
+	0.96943196901081796, 0.96983214088863523, 0.9702297162339466, 0.97062469398232287,
+	0.97101707307629004, 0.97140685246533098, 0.97179403110588902, 0.97217860796137046,
+	0.97256058200214734, 0.97293995220556018, 0.97331671755592064, 0.97369087704451462,
+	0.97406242966960466, 0.97443137443643224, 0.97479771035722174, 0.97516143645118103,
+	0.97552255174450631, 0.97588105527038316, 0.97623694606898959, 0.97659022318749911,
+	0.9769408856800823, 0.9772889326079105, 0.97763436303915674, 0.97797717604900036,
+	0.97831737071962765, 0.97865494614023485, 0.97898990140703124, 0.9793222356232405,
+	0.97965194789910426, 0.97997903735188319, 0.98030350310586067, 0.98062534429234405,
+	0.98094456004966779, 0.98126114952319488, 0.98157511186532043, 0.98188644623547261,
+	0.98219515180011563, 0.98250122773275173, 0.98280467321392362, 0.9831054874312164,
+	0.98340366957925962, 0.98369921885973044, 0.98399213448135414, 0.98428241565990748,
+	0.98457006161822058, 0.98485507158617835, 0.98513744480072352, 0.98541718050585803,
+	0.98569427795264519, 0.98596873639921168, 0.98624055511074971, 0.98650973335951875,
+	0.98677627042484772, 0.98704016559313645, 0.98730141815785843, 0.98756002741956173,
+	0.9878159926858715, 0.98806931327149194, 0.98831998849820735, 0.98856801769488478,
+	0.98881340019747566, 0.98905613534901682, 0.98929622249963334, 0.98953366100653983,
+	0.9897684502340417, 0.99000058955353776, 0.99023007834352095, 0.99045691598958108,
+	0.99068110188440506, 0.99090263542778001, 0.99112151602659404, 0.99133774309483769,
+	0.99155131605360625, 0.99176223433110056, 0.99197049736262888, 0.99217610459060834,
+	0.99237905546456673, 0.99257934944114334, 0.99277698598409092, 0.99297196456427694,
+	0.9931642846596852, 0.99335394575541658, 0.9935409473436918, 0.99372528892385081,
+	0.99390697000235606, 0.99408599009279242, 0.99426234871586938, 0.99443604539942176,
+	0.99460707967841122, 0.99477545109492771, 0.99494115919818993, 0.99510420354454787,
+	0.99526458369748239, 0.99542229922760772, 0.99557734971267198, 0.9957297347375581,
+	0.99587945389428567, 0.99602650678201154, 0.99617089300703077, 0.996312612182778,
+	0.99645166392982831, 0.99658804787589839, 0.99672176365584741, 0.99685281091167777,
+	0.99698118929253687, 0.99710689845471678, 0.9972299380616565, 0.99735030778394196,
+	0.99746800729930707, 0.99758303629263478, 0.99769539445595812, 0.99780508148846014,
+	0.99791209709647588, 0.99801644099349218, 0.99811811290014918, 0.9982171125442405,
+	0.9983134396607144, 0.99840709399167404, 0.99849807528637868, 0.99858638330124405,
+	0.99867201779984294, 0.99875497855290596, 0.99883526533832245, 0.99891287794114036,
+	0.99898781615356746, 0.99906007977497158, 0.99912966861188113, 0.99919658247798593,
+	0.99926082119413751, 0.99932238458834943, 0.99938127249579811, 0.99943748475882266,
+	0.9994910212269259, 0.99954188175677483, 0.99959006621220037, 0.99963557446419837,
+	0.99967840639092931, 0.99971856187771946, 0.99975604081706027, 0.99979084310860955,
+	0.99982296865919107, 0.99985241738279484, 0.99987918920057806, 0.99990328404086437,
+	0.99992470183914461, 0.99994344253807677, 0.99995950608748674, 0.99997289244436727,
+	0.99998360157287902, 0.9999916334443506, 0.99999698803727832, 0.99999966533732598,
+}
+
+// sineShort120 contains 120 sine window coefficients.
+var sineShort120 = [120]float32{
+	0.0065449379673518581, 0.019633692460628301, 0.032719082821776137, 0.045798866936520764,
+	0.058870803651189033, 0.071932653156719387, 0.084982177372441653, 0.098017140329560604,
+	0.11103530855427768, 0.12403445145048532, 0.13701234168196802, 0.14996675555404498,
+	0.16289547339458871, 0.17579627993435451, 0.18866696468655522, 0.2015053223256171,
+	0.21430915306505074, 0.2270762630343732, 0.23980446465501651, 0.25249157701515795,
+	0.26513542624340797, 0.27773384588129219, 0.29028467725446233, 0.30278576984257455,
+	0.31523498164776964, 0.32763017956169349, 0.33996923973099419, 0.35225004792123349,
+	0.36447049987914965, 0.37662850169321072, 0.38872197015239557, 0.40074883310314097,
+	0.41270702980439467, 0.42459451128071307, 0.43640924067334208, 0.44814919358922251,
+	0.45981235844785984, 0.47139673682599764, 0.48290034380003727, 0.49432120828614451,
+	0.50565737337798455, 0.51690689668202749, 0.52806785065036788, 0.53913832291100017,
+	0.55011641659549326, 0.56100025066400971, 0.57178796022761225, 0.58247769686780215,
+	0.59306762895323706, 0.60355594195357143, 0.61394083875036642, 0.62422053994501769,
+	0.63439328416364549, 0.64445732835889735, 0.65441094810861034, 0.66425243791128175,
+	0.67398011147829784, 0.68359230202287125, 0.69308736254563585, 0.70246366611685174,
+	0.71171960615517127, 0.72085359670291882, 0.72986407269783549, 0.73874949024124614,
+	0.74750832686259672, 0.75613908178032274, 0.76464027615900032, 0.77301045336273688,
+	0.78124817920475842, 0.78935204219315003, 0.79732065377270711, 0.80515264856285818,
+	0.81284668459161513, 0.82040144352551359, 0.82781563089550192, 0.8350879763187431,
+	0.84221723371628654, 0.84920218152657889, 0.85604162291477137, 0.86273438597779162,
+	0.86927932394514362, 0.87567531537539978, 0.88192126434835494, 0.88801610065280734,
+	0.89395877996993212, 0.8997482840522214, 0.90538362089795521, 0.91086382492117579,
+	0.91618795711713585, 0.92135510522319242, 0.92636438387511799, 0.93121493475880357,
+	0.93590592675732565, 0.94043655609335486, 0.94480604646687794, 0.94901364918821385,
+	0.95305864330629697, 0.95694033573220882, 0.9606580613579353, 0.96421118317032928,
+	0.96759909236025976, 0.9708212084269281, 0.97387697927733363, 0.97676588132087239,
+	0.97948741955905139, 0.98204112767030383, 0.9844265680898916, 0.98664333208487909,
+	0.98869103982416728, 0.99056934044357725, 0.99227791210596694, 0.99381646205637797,
+	0.99518472667219682, 0.99638247150832537, 0.99740949133735191, 0.99826561018471582,
+	0.99895068135886012, 0.99946458747636568, 0.99980724048206482, 0.99997858166412923,
+}
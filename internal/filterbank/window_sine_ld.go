@@ -0,0 +1,529 @@
+// Sine and low-overlap window tables of the ER AAC LD filter bank, the
+// sine_mid_512, sine_mid_480, ld_mid_512 and ld_mid_480 tables of
+// ~/dev/faad2/libfaad/sine_win.h.
+//
+// These values were evaluated in float64 from the window formulas of
+// ISO/IEC 14496-3, 4.6.20.2, and rounded to float32; they were not
+// copied from sine_win.h. Evaluated the same way, the sine formula
+// reproduces the sine_long_1024 and sine_short_128 tables of sine_win.h
+// bit for bit. Running scripts/generate_windows.go against a FAAD2 tree
+// replaces this file with the tables of sine_win.h.
+//
+// Sine:        w[n] = sin((π/2N) * (n + 0.5)) for n = 0..N-1
+// Low-overlap: w[n] = 0 for n < 3N/8,
+//              sin((2π/N) * (n - 3N/8 + 0.5)) for 3N/8 <= n < 5N/8,
+//              1 for n >= 5N/8
+
+package filterbank
+
+// sineMid512 contains 512 sine window coefficients.
+var sineMid512 = [512]float32{
+	0.0015339801862847655, 0.0046019261204485705, 0.007669828739531097, 0.010737659167264491,
+	0.013805388528060391, 0.01687298794728171, 0.019940428551514441, 0.023007681468839369,
+	0.026074717829103901, 0.029141508764193722, 0.032208025408304586, 0.035274238898213947,
+	0.038340120373552694, 0.041405640977076739, 0.044470771854938668, 0.047535484156959303,
+	0.050599749036899282, 0.05366353765273052, 0.056726821166907748, 0.059789570746639868,
+	0.062851757564161406, 0.065913352797003805, 0.068974327628266746, 0.072034653246889332,
+	0.075094300847921305, 0.078153241632794232, 0.081211446809592441, 0.084268887593324071,
+	0.087325535206192059, 0.090381360877864983, 0.093436335845747787, 0.096490431355252593,
+	0.099543618660069319, 0.10259586902243628, 0.10564715371341062, 0.10869744401313872,
+	0.11174671121112659, 0.11479492660651008, 0.11784206150832498, 0.12088808723577708,
+	0.12393297511851216, 0.12697669649688587, 0.13001922272223335, 0.13306052515713906,
+	0.1361005751757062, 0.1391393441638262, 0.14217680351944803, 0.14521292465284746,
+	0.14824767898689603, 0.15128103795733022, 0.1543129730130201, 0.15734345561623825,
+	0.16037245724292828, 0.16339994938297323, 0.1664259035404641, 0.16945029123396796,
+	0.17247308399679595, 0.17549425337727143, 0.17851377093899751, 0.18153160826112497,
+	0.18454773693861962, 0.1875621285825296, 0.19057475482025274, 0.19358558729580361,
+	0.19659459767008022, 0.19960175762113097, 0.20260703884442113, 0.20561041305309924,
+	0.20861185197826349, 0.21161132736922755, 0.21460881099378676, 0.21760427463848364,
+	0.22059769010887351, 0.22358902922978999, 0.22657826384561, 0.22956536582051887,
+	0.23255030703877524, 0.23553305940497549, 0.23851359484431842, 0.24149188530286933,
+	0.24446790274782415, 0.24744161916777327, 0.25041300657296522, 0.25338203699557016,
+	0.25634868248994291, 0.25931291513288623, 0.26227470702391359, 0.26523403028551179,
+	0.26819085706340318, 0.27114515952680801, 0.27409690986870638, 0.2770460803060999,
+	0.27999264308027322, 0.28293657045705539, 0.28587783472708062, 0.28881640820604948,
+	0.29175226323498926, 0.29468537218051433, 0.2976157074350862, 0.30054324141727345,
+	0.30346794657201132, 0.30638979537086092, 0.30930876031226873, 0.31222481392182488,
+	0.31513792875252244, 0.31804807738501495, 0.32095523242787521, 0.32385936651785285,
+	0.32676045232013173, 0.32965846252858749, 0.33255336986604422, 0.3354451470845316,
+	0.33833376696554113, 0.34121920232028236, 0.34410142598993881, 0.34698041084592368,
+	0.34985612979013492, 0.35272855575521073, 0.35559766170478385, 0.35846342063373654,
+	0.36132580556845428, 0.36418478956707989, 0.36704034571976718, 0.3698924471489341,
+	0.37274106700951576, 0.37558617848921722, 0.37842775480876556, 0.38126576922216238,
+	0.38410019501693504, 0.38693100551438858, 0.38975817406985641, 0.39258167407295147,
+	0.39540147894781635, 0.39821756215337356, 0.40102989718357562, 0.40383845756765407,
+	0.40664321687036903, 0.40944414869225759, 0.41224122666988289, 0.41503442447608163,
+	0.41782371582021227, 0.42060907444840251, 0.42339047414379605, 0.42616788872679962,
+	0.42894129205532949, 0.43171065802505726, 0.43447596056965565, 0.43723717366104409,
+	0.43999427130963326, 0.44274722756457002, 0.44549601651398174, 0.44824061228521989,
+	0.45098098904510386, 0.45371712100016387, 0.45644898239688392, 0.45917654752194409,
+	0.46189979070246273, 0.46461868630623782, 0.46733320874198847, 0.47004333245959562,
+	0.47274903195034279, 0.47545028174715587, 0.47814705642484301, 0.48083933060033396,
+	0.48352707893291874, 0.48621027612448642, 0.48888889691976317, 0.4915629161065499,
+	0.49423230851595967, 0.49689704902265447, 0.49955711254508184, 0.50221247404571079,
+	0.50486310853126759, 0.50750899105297087, 0.51015009670676681, 0.51278640063356296,
+	0.51541787801946293, 0.51804450409599934, 0.52066625414036716, 0.52328310347565643,
+	0.52589502747108463, 0.52850200154222848, 0.531104001151255, 0.53370100180715296,
+	0.53629297906596318, 0.53887990853100842, 0.54146176585312344, 0.54403852673088382,
+	0.54661016691083486, 0.54917666218771966, 0.55173798840470734, 0.55429412145362,
+	0.5568450372751601, 0.55939071185913614, 0.56193112124468947, 0.5644662415205195,
+	0.56699604882510868, 0.56952051934694714, 0.57203962932475705, 0.57455335504771576,
+	0.57706167285567944, 0.57956455913940563, 0.58206199034077544, 0.58455394295301533,
+	0.58704039352091797, 0.58952131864106394, 0.59199669496204099, 0.59446649918466443,
+	0.5969307080621965, 0.59938929840056454, 0.60184224705858003, 0.60428953094815596,
+	0.60673112703452448, 0.60916701233645321, 0.61159716392646191, 0.61402155893103849,
+	0.61644017453085365, 0.61885298796097632, 0.62125997651108755, 0.62366111752569453,
+	0.62605638840434352, 0.62844576660183271, 0.63082922962842447, 0.63320675505005719,
+	0.63557832048855611, 0.63794390362184406, 0.64030348218415167, 0.64265703396622686,
+	0.64500453681554393, 0.64734596863651206, 0.64968130739068319, 0.6520105310969595,
+	0.65433361783180044, 0.65665054572942905, 0.65896129298203732, 0.66126583783999227,
+	0.66356415861203977, 0.66585623366550972, 0.66814204142651845, 0.67042156038017309,
+	0.67269476907077286, 0.67496164610201204, 0.67722217013718033, 0.67947631989936497,
+	0.68172407417164971, 0.68396541179731551, 0.68620031168003859, 0.68842875278409044,
+	0.6906507141345346, 0.69286617481742463, 0.69507511398000088, 0.69727751083088652,
+	0.69947334464028377, 0.70166259474016845, 0.70384524052448494, 0.70602126144933974,
+	0.70819063703319529, 0.71035334685706242, 0.71250937056469221, 0.71465868786276909,
+	0.71680127852109943, 0.71893712237280427, 0.72106619931450799, 0.72318848930652724,
+	0.72530397237306066, 0.72741262860237577, 0.72951443814699679, 0.73160938122389252,
+	0.73369743811466026, 0.73577858916571348, 0.73785281478846598, 0.73992009545951598,
+	0.74198041172083096, 0.74403374417992929, 0.74608007351006367, 0.74811938045040349,
+	0.75015164580621496, 0.7521768504490427, 0.75419497531688917, 0.75620600141439454,
+	0.75820990981301517, 0.76020668165120242, 0.7621962981345789, 0.76417874053611667,
+	0.76615399019631281, 0.76812202852336531, 0.7700828369933479, 0.77203639715038441,
+	0.77398269060682279, 0.77592169904340758, 0.77785340420945304, 0.77977778792301444,
+	0.78169483207105939, 0.7836045186096382, 0.78550682956405393, 0.78740174702903132,
+	0.78928925316888554, 0.79116933021769009, 0.79304196047944353, 0.79490712632823701,
+	0.79676481020841872, 0.79861499463476082, 0.80045766219262271, 0.80229279553811572,
+	0.8041203773982657, 0.80594039057117617, 0.80775281792619025, 0.80955764240405126,
+	0.81135484701706362, 0.81314441484925348, 0.81492632905652651, 0.81670057286682773,
+	0.81846712958029866, 0.82022598256943458, 0.82197711527924155, 0.82372051122739132,
+	0.82545615400437744, 0.82718402727366902, 0.82890411477186487, 0.8306164003088462,
+	0.83232086776792968, 0.83401750110601813, 0.8357062843537526, 0.83738720161566182,
+	0.83906023707031263, 0.84072537497045796, 0.84238259964318585, 0.8440318954900663,
+	0.84567324698729907, 0.8473066386858582, 0.84893205521163961, 0.85054948126560337,
+	0.85215890162391972, 0.8537603011381113, 0.85535366473519592, 0.85693897741782865,
+	0.85851622426444274, 0.86008539042939014, 0.8616464611430813, 0.86319942171212416,
+	0.86474425751946227, 0.86628095402451299, 0.86780949676330321, 0.86932987134860684,
+	0.87084206347007886, 0.87234605889439143, 0.87384184346536675, 0.87532940310411078,
+	0.87680872380914565, 0.87827979165654146, 0.87974259280004741, 0.88119711347122209,
+	0.88264333997956279, 0.88408125871263499, 0.88551085613619995, 0.88693211879434208,
+	0.88834503330959624, 0.88974958638307278, 0.89114576479458329, 0.89253355540276469,
+	0.89391294514520325, 0.89528392103855758, 0.89664647017868015, 0.89800057974073988,
+	0.89934623697934146, 0.90068342922864686, 0.90201214390249318, 0.90333236849451182,
+	0.90464409057824613, 0.90594729780726846, 0.90724197791529582, 0.90852811871630612,
+	0.90980570810465233, 0.91107473405517625, 0.91233518462332275, 0.91358704794525081,
+	0.9148303122379462, 0.91606496579933161, 0.91729099700837791, 0.91850839432521225,
+	0.91971714629122725, 0.92091724152918941, 0.92210866874334518, 0.92329141671952764,
+	0.9244654743252626, 0.92563083050987272, 0.92678747430458175, 0.92793539482261789,
+	0.92907458125931575, 0.93020502289221896, 0.93132670908118043, 0.93243962926846236,
+	0.93354377297883628, 0.93463912981968078, 0.93572568948108037, 0.93680344173592156,
+	0.93787237643998989, 0.93893248353206449, 0.93998375303401394, 0.94102617505088926,
+	0.94205973977101742, 0.94308443746609349, 0.94410025849127266, 0.94510719328526061,
+	0.94610523237040334, 0.94709436635277722, 0.94807458592227623, 0.94904588185270056,
+	0.950008245001843, 0.95096166631157508, 0.95190613680793223, 0.95284164760119872,
+	0.95376818988599033, 0.95468575494133834, 0.95559433413077111, 0.95649391890239499,
+	0.95738450078897597, 0.95826607140801756, 0.95913862246184189, 0.96000214573766585,
+	0.96085663310767966, 0.96170207652912254, 0.96253846804435916, 0.96336579978095416,
+	0.96418406395174572, 0.96499325285492032, 0.96579335887408357, 0.96658437447833312,
+	0.96736629222232839, 0.96813910474636233, 0.96890280477642887, 0.96965738512429245,
+	0.9704028386875555, 0.97113915844972509, 0.9718663374802794, 0.97258436893473221,
+	0.97329324605469814, 0.97399296216795594, 0.97468351068851067, 0.97536488511665698,
+	0.97603707903903913, 0.97670008612871184, 0.97735390014519996, 0.97799851493455714,
+	0.9786339244294231, 0.97926012264908213, 0.97987710369951764, 0.98048486177346938,
+	0.98108339115048671, 0.98167268619698311, 0.98225274136628937, 0.98282355119870535,
+	0.98338511032155118, 0.98393741344921892, 0.98448045538322093, 0.98501423101223984,
+	0.98553873531217606, 0.98605396334619544, 0.98655991026477552, 0.98705657130575097,
+	0.98754394179435923, 0.98802201714328353, 0.9884907928526967, 0.98895026451030299,
+	0.98940042779138027, 0.98984127845882053, 0.99027281236316911, 0.99069502544266463,
+	0.99110791372327678, 0.99151147331874401, 0.99190570043060933, 0.99229059134825726,
+	0.99266614244894802, 0.99303235019785141, 0.99338921114808065, 0.9937367219407246,
+	0.99407487930487937, 0.9944036800576791, 0.9947231211043257, 0.99503319943811863,
+	0.99533391214048228, 0.99562525638099431, 0.99590722941741161, 0.99617982859569687,
+	0.99644305135004263, 0.99669689520289595, 0.99694135776498205, 0.99717643673532608,
+	0.9974021299012753, 0.99761843513851955, 0.99782535041111164, 0.99802287377148624,
+	0.99821100336047808, 0.99838973740734027, 0.99855907422975931, 0.99871901223387294,
+	0.99886954991428356, 0.99901068585407338, 0.99914241872481691, 0.99926474728659442,
+	0.99937767038800285, 0.99948118696616695, 0.99957529604674922, 0.99965999674395922,
+	0.99973528826056179, 0.99980116988788414, 0.99985764100582386, 0.99990470108285279,
+	0.99994234967602391, 0.99997058643097403, 0.9999894110819284, 0.99999882345170188,
+}
+
+// sineMid480 contains 480 sine window coefficients.
+var sineMid480 = [480]float32{
+	0.0016362454436240478, 0.00490871880799799, 0.0081811396039371282, 0.011453472786443777,
+	0.014725683311458524, 0.017997736136235509, 0.021269596219717735, 0.024541228522912288,
+	0.027812598009265603, 0.03108366964503869, 0.034354408399682276, 0.037624779246211978,
+	0.040894747161583443, 0.044164277127067358, 0.047433334128624501, 0.050701883157280733,
+	0.053969889209501881, 0.057237317287568625, 0.060504132399951262, 0.063770299561684493,
+	0.06703578379474201, 0.070300550128411174, 0.073564563599667426, 0.076827789253548745,
+	0.080090192143530081, 0.083351737331897449, 0.086612389890122168, 0.089872114899234967,
+	0.093130877450199795, 0.096388642644287814, 0.09964537559345106, 0.1029010414206961,
+	0.10615560526045748, 0.10940903225897117, 0.11266128757464781, 0.11591233637844579,
+	0.11916214385424433, 0.1224106751992162, 0.12565789562420052, 0.12890377035407538,
+	0.13214826462813015, 0.13539134370043771, 0.13863297284022666, 0.14187311733225325,
+	0.14511174247717307, 0.14834881359191268, 0.15158429601004111, 0.15481815508214103,
+	0.1580503561761798, 0.16128086467788047, 0.16450964599109233, 0.16773666553816149,
+	0.17096188876030122, 0.17418528111796186, 0.17740680809120093, 0.18062643518005275,
+	0.18384412790489776, 0.18705985180683196, 0.19027357244803589, 0.19348525541214331,
+	0.19669486630460994, 0.19990237075308173, 0.20310773440776286, 0.2063109229417838,
+	0.20951190205156878, 0.21271063745720314, 0.21590709490280055, 0.2191012401568698,
+	0.2222930390126813, 0.22548245728863364, 0.22866946082861941, 0.23185401550239113,
+	0.23503608720592667, 0.23821564186179459, 0.24139264541951888, 0.24456706385594387,
+	0.2477388631755984, 0.25090800941106001, 0.25407446862331851, 0.25723820690213961,
+	0.26039919036642817, 0.26355738516459076, 0.26671275747489837, 0.26986527350584855,
+	0.27301489949652735, 0.27616160171697068, 0.27930534646852595, 0.2824461000842125,
+	0.2855838289290823, 0.28871849940058025, 0.29185007792890405, 0.29497853097736348,
+	0.2981038250427398, 0.30122592665564446, 0.3043448023808773, 0.30746041881778519,
+	0.31057274260061901, 0.31368174039889146, 0.31678737891773395, 0.31988962489825296,
+	0.32298844511788632, 0.32608380639075912, 0.32917567556803889, 0.33226401953829071,
+	0.33534880522783184, 0.33842999960108583, 0.34150756966093626, 0.34458148244908043,
+	0.34765170504638193, 0.35071820457322317, 0.35378094818985806, 0.35683990309676283,
+	0.35989503653498811, 0.36294631578650921, 0.36599370817457677, 0.36903718106406641,
+	0.37207670186182878, 0.37511223801703802, 0.37814375702154046, 0.38117122641020335,
+	0.38419461376126157, 0.38721388669666557, 0.39022901288242801, 0.39323996002896966,
+	0.39624669589146555, 0.39924918827019024, 0.40224740501086254, 0.40524131400498981,
+	0.40823088319021217, 0.41121608055064524, 0.41419687411722372, 0.41717323196804329,
+	0.42014512222870237, 0.42311251307264408, 0.42607537272149631, 0.4290336694454126,
+	0.43198737156341177, 0.43493644744371707, 0.43788086550409505, 0.44082059421219388,
+	0.44375560208588088, 0.44668585769357949, 0.44961132965460654, 0.45253198663950756,
+	0.45544779737039254, 0.4583587306212713, 0.46126475521838717, 0.4641658400405515,
+	0.46706195401947653, 0.46995306614010829, 0.47283914544095856, 0.47572016101443682,
+	0.47859608200718079, 0.48146687762038709, 0.48433251711014125, 0.4871929697877464,
+	0.49004820502005247, 0.49289819222978404, 0.49574290089586764, 0.49858230055375902,
+	0.50141636079576901, 0.50424505127138919, 0.50706834168761705, 0.50988620180928057,
+	0.51269860145936164, 0.51550551051931948, 0.51830689892941317, 0.5211027366890234,
+	0.52389299385697385, 0.52667764055185207, 0.52945664695232897, 0.53222998329747873,
+	0.53499761988709715, 0.53775952708201991, 0.54051567530443978, 0.54326603503822357,
+	0.54601057682922804, 0.54874927128561579, 0.55148208907816942, 0.55420900094060566,
+	0.5569299776698895, 0.559644990126546, 0.56235400923497303, 0.56505700598375241,
+	0.56775395142596052, 0.57044481667947822, 0.57312957292730071, 0.57580819141784534,
+	0.57848064346525996, 0.58114690044973039, 0.58380693381778626, 0.58646071508260733,
+	0.58910821582432815, 0.5917494076903429, 0.59438426239560849, 0.59701275172294799,
+	0.59963484752335228, 0.60225052171628191, 0.60485974628996786, 0.60746249330171087,
+	0.61005873487818185, 0.61264844321571899, 0.61523159058062682, 0.61780814930947214,
+	0.62037809180938108, 0.62294139055833386, 0.62549801810546068, 0.62804794707133416,
+	0.63059115014826372, 0.63312760010058777, 0.63565726976496484, 0.63818013205066515,
+	0.64069615993986073, 0.64320532648791406, 0.64570760482366729, 0.64820296814972966,
+	0.65069138974276486, 0.65317284295377664, 0.65564730120839498, 0.65811473800715958,
+	0.660575126925805, 0.66302844161554231, 0.6654746558033422, 0.66791374329221598,
+	0.67034567796149636, 0.67277043376711676, 0.67518798474189035, 0.67759830499578866,
+	0.68000136871621808, 0.68239715016829683, 0.6847856236951303, 0.68716676371808583,
+	0.68954054473706683, 0.69190694133078579, 0.69426592815703603, 0.69661747995296408,
+	0.69896157153533933, 0.70129817780082426, 0.7036272737262429, 0.70594883436884903,
+	0.70826283486659325, 0.71056925043838937, 0.71286805638437967, 0.71515922808619925,
+	0.71744274100723993, 0.71971857069291267, 0.7219866927709101, 0.72424708295146678,
+	0.72649971702762006, 0.72874457087546884, 0.73098162045443171, 0.73321084180750484,
+	0.73543221106151857, 0.73764570442739275, 0.73985129820039208, 0.74204896876037874,
+	0.74423869257206687, 0.7464204461852737, 0.7485942062351707, 0.75075994944253421,
+	0.75291765261399424, 0.75506729264228367, 0.75720884650648446, 0.75934229127227537,
+	0.76146760409217706, 0.7635847622057963, 0.76569374294007109, 0.76779452370951196,
+	0.7698870820164444, 0.77197139545125026, 0.7740474416926072, 0.77611519850772781,
+	0.77817464375259782, 0.78022575537221317, 0.78226851140081632, 0.78430288996213127,
+	0.78632886926959822, 0.78834642762660612, 0.7903555434267262, 0.79235619515394218,
+	0.79434836138288134, 0.79633202077904397, 0.79830715209903136, 0.80027373419077419,
+	0.80223174599375802, 0.80418116653924954, 0.80612197495052085, 0.80805415044307316,
+	0.80997767232485907, 0.81189251999650469, 0.81379867295152986, 0.81569611077656767,
+	0.8175848131515836, 0.81946475985009248, 0.82133593073937561, 0.82319830578069586,
+	0.82505186502951278, 0.82689658863569615, 0.82873245684373809, 0.83055944999296483,
+	0.83237754851774781, 0.83418673294771228, 0.83598698390794668, 0.83777828211920924,
+	0.83956060839813562, 0.84133394365744296, 0.84309826890613526, 0.84485356524970701,
+	0.84659981389034411, 0.84833699612712676, 0.85006509335622893, 0.8517840870711173,
+	0.85349395886275037, 0.85519469041977514, 0.85688626352872277, 0.85856866007420429,
+	0.86024186203910435, 0.86190585150477417, 0.86356061065122347, 0.86520612175731115,
+	0.8668423672009351, 0.86846932945922151, 0.87008699110871135, 0.87169533482554806,
+	0.87329434338566281, 0.87488399966495833, 0.87646428663949283, 0.87803518738566266,
+	0.8795966850803828, 0.88114876300126743, 0.88269140452680905, 0.8842245931365561,
+	0.88574831241129048, 0.88726254603320276, 0.88876727778606746, 0.89026249155541637,
+	0.89174817132871131, 0.89322430119551532, 0.89469086534766362, 0.89614784807943226,
+	0.89759523378770689, 0.89903300697214916, 0.90046115223536349, 0.9018796542830616,
+	0.90328849792422594, 0.90468766807127299, 0.90607714974021469, 0.90745692805081868,
+	0.90882698822676755, 0.91018731559581767, 0.9115378955899559, 0.91287871374555507,
+	0.91420975570353058, 0.9155310072094921, 0.91684245411389742, 0.9181440823722038,
+	0.91943587804501858, 0.92071782729824758, 0.92198991640324446, 0.92325213173695686,
+	0.92450445978207241, 0.92574688712716402, 0.92697940046683291, 0.92820198660185138,
+	0.92941463243930444, 0.93061732499272898, 0.93181005138225426, 0.93299279883473873,
+	0.93416555468390761, 0.93532830637048769, 0.93648104144234268, 0.93762374755460587,
+	0.93875641246981312, 0.93987902405803303, 0.94099157029699743, 0.94209403927222968,
+	0.94318641917717316, 0.9442686983133165, 0.94534086509031956, 0.94640290802613769,
+	0.94745481574714419, 0.94849657698825252, 0.94952818059303667, 0.95054961551385087,
+	0.95156087081194762, 0.95256193565759528, 0.95355279933019343, 0.9545334512183884,
+	0.95550388082018611, 0.9564640777430653, 0.95741403170408834, 0.95835373253001144,
+	0.95928317015739362, 0.96020233463270466, 0.96111121611243155, 0.96200980486318377,
+	0.96289809126179782, 0.96377606579543984, 0.96464371906170809, 0.96550104176873297,
+	0.96634802473527714, 0.96718465889083372, 0.96801093527572268, 0.96882684504118799,
+	0.96963237944949154, 0.97042752987400682, 0.97121228779931179, 0.9719866448212795,
+	0.97275059264716823, 0.97350412309571055, 0.97424722809720099, 0.97497989969358168,
+	0.97570213003852846, 0.97641391139753486, 0.977115236147994, 0.97780609677928154,
+	0.97848648589283505, 0.9791563962022336, 0.97981582053327632, 0.98046475182405801,
+	0.98110318312504596, 0.98173110759915416, 0.98234851852181571, 0.98295540928105551,
+	0.9835517733775615, 0.98413760442475307, 0.98471289614885038, 0.98527764238894122,
+	0.98583183709704714, 0.98637547433818806, 0.98690854829044583, 0.98743105324502667,
+	0.98794298360632238, 0.98844433389196995, 0.98893509873291074, 0.98941527287344755,
+	0.98988485117130098, 0.9903438285976649, 0.99079220023725956, 0.99122996128838525,
+	0.9916571070629725, 0.99207363298663342, 0.99247953459871008, 0.99287480755232194,
+	0.99325944761441354, 0.99363345066579889, 0.99399681270120543, 0.99434952982931801,
+	0.9946915982728195, 0.99502301436843166, 0.99534377456695422, 0.99565387543330319,
+	0.99595331364654771, 0.9962420859999449, 0.99652018940097464, 0.99678762087137318,
+	0.99704437754716435, 0.99729045667869021, 0.99752585563064111, 0.99775057188208349,
+	0.99796460302648671, 0.99816794677174903, 0.9983606009402225, 0.99854256346873571,
+	0.99871383240861611, 0.99887440592571108, 0.99902428230040718, 0.99916345992764877,
+	0.99929193731695531, 0.99940971309243731, 0.99951678599281069, 0.99961315487141067,
+	0.99969881869620414, 0.99977377654980037, 0.99983802762946083, 0.99989157124710815,
+	0.99993440682933299, 0.99996653391740109, 0.99998795216725689, 0.99999866134952808,
+}
+
+// ldMid512 contains 512 low-overlap window coefficients.
+var ldMid512 = [512]float32{
+	0, 0, 0, 0,
+	0, 0, 0, 0,
+	0, 0, 0, 0,
+	0, 0, 0, 0,
+	0, 0, 0, 0,
+	0, 0, 0, 0,
+	0, 0, 0, 0,
+	0, 0, 0, 0,
+	0, 0, 0, 0,
+	0, 0, 0, 0,
+	0, 0, 0, 0,
+	0, 0, 0, 0,
+	0, 0, 0, 0,
+	0, 0, 0, 0,
+	0, 0, 0, 0,
+	0, 0, 0, 0,
+	0, 0, 0, 0,
+	0, 0, 0, 0,
+	0, 0, 0, 0,
+	0, 0, 0, 0,
+	0, 0, 0, 0,
+	0, 0, 0, 0,
+	0, 0, 0, 0,
+	0, 0, 0, 0,
+	0, 0, 0, 0,
+	0, 0, 0, 0,
+	0, 0, 0, 0,
+	0, 0, 0, 0,
+	0, 0, 0, 0,
+	0, 0, 0, 0,
+	0, 0, 0, 0,
+	0, 0, 0, 0,
+	0, 0, 0, 0,
+	0, 0, 0, 0,
+	0, 0, 0, 0,
+	0, 0, 0, 0,
+	0, 0, 0, 0,
+	0, 0, 0, 0,
+	0, 0, 0, 0,
+	0, 0, 0, 0,
+	0, 0, 0, 0,
+	0, 0, 0, 0,
+	0, 0, 0, 0,
+	0, 0, 0, 0,
+	0, 0, 0, 0,
+	0, 0, 0, 0,
+	0, 0, 0, 0,
+	0, 0, 0, 0,
+	0.0061358846491544753, 0.01840672990580482, 0.030674803176636626, 0.04293825693494082,
+	0.055195244349689941, 0.067443919563664051, 0.079682437971430126, 0.091908956497132724,
+	0.10412163387205459, 0.11631863091190475, 0.12849811079379317, 0.14065823933284921,
+	0.15279718525844344, 0.16491312048996992, 0.17700422041214875, 0.18906866414980619,
+	0.2011046348420919, 0.21311031991609136, 0.22508391135979283, 0.2370236059943672,
+	0.24892760574572015, 0.26079411791527551, 0.27262135544994898, 0.28440753721127188,
+	0.29615088824362379, 0.30784964004153487, 0.31950203081601569, 0.33110630575987643,
+	0.34266071731199438, 0.35416352542049034, 0.36561299780477385, 0.37700741021641826,
+	0.38834504669882625, 0.39962419984564679, 0.41084317105790391, 0.42200027079979968,
+	0.43309381885315196, 0.4441221445704292, 0.45508358712634384, 0.46597649576796618,
+	0.47679923006332209, 0.487550160148436, 0.49822766697278187, 0.50883014254310699,
+	0.51935599016558964, 0.52980362468629461, 0.54017147272989285, 0.55045797293660481,
+	0.56066157619733603, 0.57078074588696726, 0.58081395809576453, 0.59075970185887416,
+	0.60061647938386897, 0.61038280627630948, 0.6200572117632891, 0.62963823891492698,
+	0.63912444486377573, 0.64851440102211244, 0.65780669329707864, 0.66699992230363747,
+	0.67609270357531592, 0.68508366777270036, 0.69397146088965389, 0.7027547444572253,
+	0.71143219574521643, 0.72000250796138165, 0.7284643904482252, 0.7368165688773699,
+	0.74505778544146595, 0.75318679904361252, 0.76120238548426167, 0.76910333764557959,
+	0.77688846567323244, 0.78455659715557513, 0.79210657730021228, 0.7995372691079049,
+	0.80684755354379922, 0.8140363297059483, 0.82110251499110465, 0.8280450452577558,
+	0.83486287498638001, 0.84155497743689833, 0.84812034480329712, 0.85455798836540053,
+	0.8608669386377672, 0.86704624551569265, 0.87309497841828998, 0.87901222642863341,
+	0.8847970984309379, 0.89044872324475788, 0.89596624975618522, 0.90134884704602203,
+	0.90659570451491533, 0.91170603200542999, 0.9166790599210427, 0.92151403934204201,
+	0.92621024213831127, 0.9307669610789836, 0.9351835099389475, 0.93945922360218992,
+	0.94359345816196039, 0.9475855910177412, 0.95143502096900834, 0.95514116830577067,
+	0.95870347489587149, 0.96212140426904158, 0.9653944416976894, 0.96852209427441727,
+	0.97150389098625178, 0.97433938278557586, 0.97702814265775428, 0.97956976568544063,
+	0.98196386910955524, 0.98421009238692903, 0.98630809724459867, 0.98825756773074946,
+	0.99005821026229712, 0.99170975366909953, 0.9932119492347945, 0.99456457073425542,
+	0.99576741446765982, 0.99682029929116578, 0.99772306664419164, 0.99847558057329477,
+	0.99907772775264536, 0.99952941750109314, 0.9998305817958234, 0.99998117528260111,
+	1, 1, 1, 1,
+	1, 1, 1, 1,
+	1, 1, 1, 1,
+	1, 1, 1, 1,
+	1, 1, 1, 1,
+	1, 1, 1, 1,
+	1, 1, 1, 1,
+	1, 1, 1, 1,
+	1, 1, 1, 1,
+	1, 1, 1, 1,
+	1, 1, 1, 1,
+	1, 1, 1, 1,
+	1, 1, 1, 1,
+	1, 1, 1, 1,
+	1, 1, 1, 1,
+	1, 1, 1, 1,
+	1, 1, 1, 1,
+	1, 1, 1, 1,
+	1, 1, 1, 1,
+	1, 1, 1, 1,
+	1, 1, 1, 1,
+	1, 1, 1, 1,
+	1, 1, 1, 1,
+	1, 1, 1, 1,
+	1, 1, 1, 1,
+	1, 1, 1, 1,
+	1, 1, 1, 1,
+	1, 1, 1, 1,
+	1, 1, 1, 1,
+	1, 1, 1, 1,
+	1, 1, 1, 1,
+	1, 1, 1, 1,
+	1, 1, 1, 1,
+	1, 1, 1, 1,
+	1, 1, 1, 1,
+	1, 1, 1, 1,
+	1, 1, 1, 1,
+	1, 1, 1, 1,
+	1, 1, 1, 1,
+	1, 1, 1, 1,
+	1, 1, 1, 1,
+	1, 1, 1, 1,
+	1, 1, 1, 1,
+	1, 1, 1, 1,
+	1, 1, 1, 1,
+	1, 1, 1, 1,
+	1, 1, 1, 1,
+	1, 1, 1, 1,
+}
+
+// ldMid480 contains 480 low-overlap window coefficients.
+var ldMid480 = [480]float32{
+	0, 0, 0, 0,
+	0, 0, 0, 0,
+	0, 0, 0, 0,
+	0, 0, 0, 0,
+	0, 0, 0, 0,
+	0, 0, 0, 0,
+	0, 0, 0, 0,
+	0, 0, 0, 0,
+	0, 0, 0, 0,
+	0, 0, 0, 0,
+	0, 0, 0, 0,
+	0, 0, 0, 0,
+	0, 0, 0, 0,
+	0, 0, 0, 0,
+	0, 0, 0, 0,
+	0, 0, 0, 0,
+	0, 0, 0, 0,
+	0, 0, 0, 0,
+	0, 0, 0, 0,
+	0, 0, 0, 0,
+	0, 0, 0, 0,
+	0, 0, 0, 0,
+	0, 0, 0, 0,
+	0, 0, 0, 0,
+	0, 0, 0, 0,
+	0, 0, 0, 0,
+	0, 0, 0, 0,
+	0, 0, 0, 0,
+	0, 0, 0, 0,
+	0, 0, 0, 0,
+	0, 0, 0, 0,
+	0, 0, 0, 0,
+	0, 0, 0, 0,
+	0, 0, 0, 0,
+	0, 0, 0, 0,
+	0, 0, 0, 0,
+	0, 0, 0, 0,
+	0, 0, 0, 0,
+	0, 0, 0, 0,
+	0, 0, 0, 0,
+	0, 0, 0, 0,
+	0, 0, 0, 0,
+	0, 0, 0, 0,
+	0, 0, 0, 0,
+	0, 0, 0, 0,
+	0.0065449379673518581, 0.019633692460628301, 0.032719082821776137, 0.045798866936520764,
+	0.058870803651189033, 0.071932653156719387, 0.084982177372441653, 0.098017140329560604,
+	0.11103530855427768, 0.12403445145048532, 0.13701234168196802, 0.14996675555404498,
+	0.16289547339458871, 0.17579627993435451, 0.18866696468655522, 0.2015053223256171,
+	0.21430915306505074, 0.2270762630343732, 0.23980446465501651, 0.25249157701515795,
+	0.26513542624340797, 0.27773384588129219, 0.29028467725446233, 0.30278576984257455,
+	0.31523498164776964, 0.32763017956169349, 0.33996923973099419, 0.35225004792123349,
+	0.36447049987914965, 0.37662850169321072, 0.38872197015239557, 0.40074883310314097,
+	0.41270702980439467, 0.42459451128071307, 0.43640924067334208, 0.44814919358922251,
+	0.45981235844785984, 0.47139673682599764, 0.48290034380003727, 0.49432120828614451,
+	0.50565737337798455, 0.51690689668202749, 0.52806785065036788, 0.53913832291100017,
+	0.55011641659549326, 0.56100025066400971, 0.57178796022761225, 0.58247769686780215,
+	0.59306762895323706, 0.60355594195357143, 0.61394083875036642, 0.62422053994501769,
+	0.63439328416364549, 0.64445732835889735, 0.65441094810861034, 0.66425243791128175,
+	0.67398011147829784, 0.68359230202287125, 0.69308736254563585, 0.70246366611685174,
+	0.71171960615517127, 0.72085359670291882, 0.72986407269783549, 0.73874949024124614,
+	0.74750832686259672, 0.75613908178032274, 0.76464027615900032, 0.77301045336273688,
+	0.78124817920475842, 0.78935204219315003, 0.79732065377270711, 0.80515264856285818,
+	0.81284668459161513, 0.82040144352551359, 0.82781563089550192, 0.8350879763187431,
+	0.84221723371628654, 0.84920218152657889, 0.85604162291477137, 0.86273438597779162,
+	0.86927932394514362, 0.87567531537539978, 0.88192126434835494, 0.88801610065280734,
+	0.89395877996993212, 0.8997482840522214, 0.90538362089795521, 0.91086382492117579,
+	0.91618795711713585, 0.92135510522319242, 0.92636438387511799, 0.93121493475880357,
+	0.93590592675732565, 0.94043655609335486, 0.94480604646687794, 0.94901364918821385,
+	0.95305864330629697, 0.95694033573220882, 0.9606580613579353, 0.96421118317032928,
+	0.96759909236025976, 0.9708212084269281, 0.97387697927733363, 0.97676588132087239,
+	0.97948741955905139, 0.98204112767030383, 0.9844265680898916, 0.98664333208487909,
+	0.98869103982416728, 0.99056934044357725, 0.99227791210596694, 0.99381646205637797,
+	0.99518472667219682, 0.99638247150832537, 0.99740949133735191, 0.99826561018471582,
+	0.99895068135886012, 0.99946458747636568, 0.99980724048206482, 0.99997858166412923,
+	1, 1, 1, 1,
+	1, 1, 1, 1,
+	1, 1, 1, 1,
+	1, 1, 1, 1,
+	1, 1, 1, 1,
+	1, 1, 1, 1,
+	1, 1, 1, 1,
+	1, 1, 1, 1,
+	1, 1, 1, 1,
+	1, 1, 1, 1,
+	1, 1, 1, 1,
+	1, 1, 1, 1,
+	1, 1, 1, 1,
+	1, 1, 1, 1,
+	1, 1, 1, 1,
+	1, 1, 1, 1,
+	1, 1, 1, 1,
+	1, 1, 1, 1,
+	1, 1, 1, 1,
+	1, 1, 1, 1,
+	1, 1, 1, 1,
+	1, 1, 1, 1,
+	1, 1, 1, 1,
+	1, 1, 1, 1,
+	1, 1, 1, 1,
+	1, 1, 1, 1,
+	1, 1, 1, 1,
+	1, 1, 1, 1,
+	1, 1, 1, 1,
+	1, 1, 1, 1,
+	1, 1, 1, 1,
+	1, 1, 1, 1,
+	1, 1, 1, 1,
+	1, 1, 1, 1,
+	1, 1, 1, 1,
+	1, 1, 1, 1,
+	1, 1, 1, 1,
+	1, 1, 1, 1,
+	1, 1, 1, 1,
+	1, 1, 1, 1,
+	1, 1, 1, 1,
+	1, 1, 1, 1,
+	1, 1, 1, 1,
+	1, 1, 1, 1,
+	1, 1, 1, 1,
+}
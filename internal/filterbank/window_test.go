@@ -3,6 +3,7 @@ package filterbank
 
 import (
 	"fmt"
+	"math"
 	"testing"
 )
 
@@ -86,3 +87,65 @@ func TestGetShortWindow_InvalidShape(t *testing.T) {
 	}()
 	GetShortWindow(-1) // Should panic
 }
+
+func TestWindow(t *testing.T) {
+	for _, n := range []int{LongWindowSize, ShortWindowSize, LongWindowSize960, ShortWindowSize960} {
+		for _, shape := range []uint8{SineWindow, KBDWindow} {
+			w := Window(shape, n)
+			if len(w) != n {
+				t.Fatalf("Window(%d, %d): %d samples", shape, n, len(w))
+			}
+
+			// The full window of 2n samples is w followed by w reversed,
+			// symmetric about its center
+			full := make([]float64, 2*n)
+			for i, v := range w {
+				full[i] = float64(v)
+				full[2*n-1-i] = float64(v)
+			}
+			// KBD reaches 1 in float32 before the center
+			for i := 1; i < n; i++ {
+				if full[i] < full[i-1] {
+					t.Errorf("Window(%d, %d): falling at %d", shape, n, i)
+					break
+				}
+			}
+			if full[n-1] != full[n] || full[n-1] < 0.99 || full[n-1] > 1 {
+				t.Errorf("Window(%d, %d): center %v, %v, want a peak near 1", shape, n, full[n-1], full[n])
+			}
+
+			// Princen-Bradley: w[i]^2 + w[i+n]^2 = 1
+			for i := range n {
+				if sum := full[i]*full[i] + full[i+n]*full[i+n]; math.Abs(sum-1) > 1e-5 {
+					t.Errorf("Window(%d, %d): w[%d]^2 + w[%d]^2 = %v", shape, n, i, i+n, sum)
+					break
+				}
+			}
+		}
+
+		// The shapes differ: the sine window has more leakage, starting higher
+		if sine, kbd := Window(SineWindow, n), Window(KBDWindow, n); sine[0] <= kbd[0] {
+			t.Errorf("length %d: sine window starts at %v, KBD at %v", n, sine[0], kbd[0])
+		}
+	}
+
+	// The long windows are those of the FAAD2 tables
+	if &Window(SineWindow, LongWindowSize)[0] != &GetLongWindow(SineWindow)[0] ||
+		&Window(KBDWindow, ShortWindowSize)[0] != &GetShortWindow(KBDWindow)[0] {
+		t.Error("Window does not return the FAAD2 tables")
+	}
+
+	for _, tt := range []struct {
+		shape  uint8
+		length int
+	}{{2, LongWindowSize}, {SineWindow, 512}} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("Window(%d, %d) did not panic", tt.shape, tt.length)
+				}
+			}()
+			Window(tt.shape, tt.length)
+		}()
+	}
+}
@@ -3,6 +3,7 @@ package huffman
 
 import (
 	"errors"
+	"math"
 
 	"github.com/llehouerou/go-aac/internal/bits"
 )
@@ -14,22 +15,27 @@ var ErrEscapeSequence = errors.New("huffman: invalid escape sequence")
 // Returns a value in the range [-60, 60] representing the delta
 // from the previous scale factor.
 //
-// The scale factor codebook uses binary search: start at offset 0,
-// read one bit at a time, and follow branches until reaching a leaf.
-//
 // Ported from: huffman_scale_factor() in ~/dev/faad2/libfaad/huffman.c:60-72
 func ScaleFactor(r *bits.Reader) int8 {
-	offset := uint16(0)
-	sf := *HCBSF
+	return int8(decodeScaleFactorCodeword(r))
+}
+
+// decodeScaleFactorCodeword walks the hcbSF binary tree one bit at a time.
+// Starting at index 0, each bit selects one of the two offsets of the
+// current node; a node whose second element is 0 is a leaf holding the
+// codeword index. The index is returned minus 60, the scale factor offset.
+//
+// Ported from: huffman_scale_factor() in ~/dev/faad2/libfaad/huffman.c:60-72
+func decodeScaleFactorCodeword(r *bits.Reader) int {
+	offset := 0
 
 	// Traverse binary tree until we hit a leaf (branch offset = 0)
-	for sf[offset][1] != 0 {
+	for hcbSF[offset][1] != 0 {
 		b := r.Get1Bit()
-		offset += uint16(sf[offset][b])
+		offset += int(hcbSF[offset][b])
 	}
 
-	// Return the value at the leaf node, adjusted to signed delta
-	return int8(sf[offset][0]) - 60
+	return int(hcbSF[offset][0]) - 60
 }
 
 // signBits reads sign bits for non-zero spectral coefficients.
@@ -50,7 +56,7 @@ func signBits(r *bits.Reader, sp []int16) {
 // For escape codebook (11), values of ±16 indicate more bits follow.
 // Returns error if escape sequence is malformed.
 //
-// Format: N ones followed by a zero (N >= 4), then N bits of magnitude.
+// Format: N-4 ones followed by a zero (N >= 4), then N bits of magnitude.
 // Final value = (1 << N) | magnitude_bits
 //
 // Ported from: huffman_getescape() in ~/dev/faad2/libfaad/huffman.c:110-148
@@ -82,15 +88,20 @@ func getEscape(r *bits.Reader, sp *int16) error {
 		return ErrEscapeSequence
 	}
 
-	// Read i bits for the offset
-	off := int16(r.GetBits(i))
-	j := off | (1 << i)
+	// Read i bits for the offset. The largest legal escape is N=12
+	// (magnitude 8191); N=15 would overflow int16, so larger magnitudes are
+	// saturated and left for inverse quantization to reject as out of
+	// range, as FAAD2 does with error 17.
+	j := int32(r.GetBits(i)) | 1<<i
+	if j > math.MaxInt16 {
+		j = math.MaxInt16
+	}
 
 	if neg {
 		j = -j
 	}
 
-	*sp = j
+	*sp = int16(j)
 	return nil
 }
 
@@ -327,3 +338,63 @@ func SpectralData(cb uint8, r *bits.Reader, sp []int16) error {
 		return ErrInvalidCodebook
 	}
 }
+
+// ErrSpectralLength indicates a number of spectral coefficients that is
+// not a whole number of codewords, or does not fit the output.
+var ErrSpectralLength = errors.New("huffman: invalid spectral data length")
+
+// ErrBitstreamOverrun indicates a codeword read past the end of the
+// bitstream.
+var ErrBitstreamOverrun = errors.New("huffman: read past end of bitstream")
+
+// DecodeSpectralData decodes n spectral coefficients of codebook cb into
+// out, one quad or pair codeword at a time. The zero, noise and intensity
+// codebooks carry no spectral data: their coefficients are zero.
+//
+// Ported from: the codeword loop of spectral_data() in ~/dev/faad2/libfaad/syntax.c
+func DecodeSpectralData(r *bits.Reader, cb int, out []int16, n int) error {
+	if cb < 0 || cb > 31 {
+		return ErrInvalidCodebook
+	}
+	if n < 0 || n > len(out) {
+		return ErrSpectralLength
+	}
+
+	switch Codebook(cb) {
+	case ZeroHCB, NoiseHCB, IntensityHCB, IntensityHCB2:
+		clear(out[:n])
+		return nil
+	}
+
+	inc := QuadLen
+	if Codebook(cb) >= FirstPairHCB {
+		inc = PairLen
+	}
+	if n%inc != 0 {
+		return ErrSpectralLength
+	}
+
+	start, avail := r.GetProcessedBits(), r.RemainingBits()
+	for k := 0; k < n; k += inc {
+		if err := SpectralData(uint8(cb), r, out[k:k+inc]); err != nil {
+			return err
+		}
+	}
+	if r.Error() || r.GetProcessedBits()-start > avail {
+		return ErrBitstreamOverrun
+	}
+	return nil
+}
+
+// DecodeScaleFactor decodes a scale factor delta, in the range [-60, 60],
+// from the hcbSF codebook.
+//
+// Ported from: huffman_scale_factor() in ~/dev/faad2/libfaad/huffman.c:60-72
+func DecodeScaleFactor(r *bits.Reader) (int, error) {
+	start, avail := r.GetProcessedBits(), r.RemainingBits()
+	delta := decodeScaleFactorCodeword(r)
+	if r.Error() || r.GetProcessedBits()-start > avail {
+		return 0, ErrBitstreamOverrun
+	}
+	return delta, nil
+}
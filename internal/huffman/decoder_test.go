@@ -3,6 +3,7 @@ package huffman
 
 import (
 	"fmt"
+	"strings"
 	"testing"
 
 	"github.com/llehouerou/go-aac/internal/bits"
@@ -116,6 +117,81 @@ func TestScaleFactor_ConsecutiveDecoding(t *testing.T) {
 	}
 }
 
+func TestDecodeScaleFactorCodeword(t *testing.T) {
+	tests := []struct {
+		name     string
+		data     []byte
+		expected int
+		bits     uint32
+	}{
+		// "0": 0 ->+1-> 1 (leaf 60)
+		{"delta_0", []byte{0x00, 0x00}, 0, 1},
+		// "100": 0 ->+2-> 2 ->+1-> 3 ->+2-> 5 (leaf 59)
+		{"delta_minus1", []byte{0x80, 0x00}, -1, 3},
+		// "1010": 0 ->+2-> 2 ->+1-> 3 ->+3-> 6 ->+3-> 9 (leaf 61)
+		{"delta_plus1", []byte{0xA0, 0x00}, 1, 4},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := bits.NewReader(tt.data)
+			got := decodeScaleFactorCodeword(r)
+			if got != tt.expected {
+				t.Errorf("decodeScaleFactorCodeword() = %d, want %d", got, tt.expected)
+			}
+			if consumed := r.GetProcessedBits(); consumed != tt.bits {
+				t.Errorf("consumed %d bits, want %d", consumed, tt.bits)
+			}
+		})
+	}
+}
+
+func TestDecodeScaleFactorCodeword_AllLeaves(t *testing.T) {
+	// Enumerate every root-to-leaf path of hcbSF, encode it as a bit
+	// string and check that decoding yields the leaf value minus 60.
+	type path struct {
+		node int
+		code []byte
+	}
+	stack := []path{{0, nil}}
+	leaves := 0
+
+	for len(stack) > 0 {
+		p := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+
+		if hcbSF[p.node][1] != 0 {
+			for b := 0; b < 2; b++ {
+				code := append(append([]byte(nil), p.code...), byte(b))
+				stack = append(stack, path{p.node + int(hcbSF[p.node][b]), code})
+			}
+			continue
+		}
+
+		data := make([]byte, 8)
+		for i, b := range p.code {
+			if b != 0 {
+				data[i/8] |= 0x80 >> (i % 8)
+			}
+		}
+		r := bits.NewReader(data)
+		got := decodeScaleFactorCodeword(r)
+		want := int(hcbSF[p.node][0]) - 60
+		if got != want {
+			t.Errorf("codeword %v: got %d, want %d", p.code, got, want)
+		}
+		if consumed := r.GetProcessedBits(); consumed != uint32(len(p.code)) {
+			t.Errorf("codeword %v: consumed %d bits, want %d", p.code, consumed, len(p.code))
+		}
+		leaves++
+	}
+
+	// 121 scale factor deltas in [-60, 60]
+	if leaves != 121 {
+		t.Errorf("found %d leaves, want 121", leaves)
+	}
+}
+
 func TestSignBits(t *testing.T) {
 	tests := []struct {
 		name     string
@@ -350,6 +426,21 @@ func TestGetEscape(t *testing.T) {
 			bits:     []uint8{1, 0, 0, 0, 0, 0, 1}, // 1 one, zero, then 5 bits = 00001 = 1
 			expected: 33,                           // (1 << 5) | 1 = 33
 		},
+		{
+			name:  "maximal legal escape: N=12",
+			input: -16,
+			// 8 ones raise N from 4 to 12, then 12 offset bits all set
+			bits:     []uint8{1, 1, 1, 1, 1, 1, 1, 1, 0, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1},
+			expected: -8191, // -((1 << 12) | 4095)
+		},
+		{
+			name:  "largest escape N=15 saturates instead of wrapping",
+			input: -16,
+			// 11 ones give N=15; (1 << 15) | off does not fit int16
+			bits: []uint8{1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 0,
+				1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1},
+			expected: -32767,
+		},
 		{
 			name:  "malformed escape: too many leading ones",
 			input: 16,
@@ -594,3 +685,154 @@ func TestSpectralData_VCB11(t *testing.T) {
 		})
 	}
 }
+
+func TestGetEscape_SpecFormula(t *testing.T) {
+	// escape_sequence: (N-4) ones, a zero, then an N-bit escape_word;
+	// magnitude = 2^N + escape_word
+	for n := uint(4); n <= 12; n++ {
+		for _, word := range []int32{0, 1, 1<<n - 1} {
+			var bitsIn []uint8
+			for k := uint(4); k < n; k++ {
+				bitsIn = append(bitsIn, 1)
+			}
+			bitsIn = append(bitsIn, 0)
+			for b := int(n) - 1; b >= 0; b-- {
+				bitsIn = append(bitsIn, uint8(word>>b)&1)
+			}
+
+			sp := int16(16)
+			if err := getEscape(bits.NewReader(buildSignBitstream(bitsIn)), &sp); err != nil {
+				t.Fatalf("N=%d word=%d: %v", n, word, err)
+			}
+			want := int16(1<<n + word)
+			if sp != want {
+				t.Errorf("N=%d word=%d: got %d, want %d", n, word, sp, want)
+			}
+		}
+	}
+}
+
+// bitString packs a string of '0' and '1' into bytes, MSB first, with
+// padding.
+func bitString(s string) []byte {
+	data := make([]uint8, len(s))
+	for i := range s {
+		data[i] = s[i] - '0'
+	}
+	return packBits(data)
+}
+
+func TestDecodeSpectralData(t *testing.T) {
+	tests := []struct {
+		cb   int
+		code string
+		want []int16
+	}{
+		{1, "1101010", []int16{1, 0, -1, 0}},
+		{2, "101111", []int16{0, 1, 1, 0}},
+		{3, "111111011100", []int16{2, 0, 0, 1}},
+		{4, "11100111010", []int16{1, -1, 0, 2}},
+		{5, "1111101010", []int16{-2, 3}},
+		{6, "111101101", []int16{4, -1}},
+		{7, "1111011010", []int16{5, 0}},
+		{8, "111001110", []int16{-6, 2}},
+		{9, "1111101011001", []int16{9, -3}},
+		{10, "1111110100110", []int16{-12, 7}},
+		{11, "01011001", []int16{3, -2}},
+		{11, "101101011000100", []int16{-20, 1}}, // escape: 16 + 4
+	}
+
+	for _, tc := range tests {
+		t.Run(fmt.Sprintf("codebook_%d_%v", tc.cb, tc.want), func(t *testing.T) {
+			// The same codeword twice
+			r := bits.NewReader(bitString(tc.code + tc.code))
+			n := 2 * len(tc.want)
+			out := make([]int16, n)
+			if err := DecodeSpectralData(r, tc.cb, out, n); err != nil {
+				t.Fatalf("DecodeSpectralData: %v", err)
+			}
+			if got := r.GetProcessedBits(); got != uint32(2*len(tc.code)) {
+				t.Errorf("read %d bits, want %d", got, 2*len(tc.code))
+			}
+			for i, v := range out {
+				if v != tc.want[i%len(tc.want)] {
+					t.Fatalf("out = %v, want %v twice", out, tc.want)
+				}
+			}
+		})
+	}
+}
+
+func TestDecodeSpectralData_NoSpectralData(t *testing.T) {
+	for _, cb := range []int{0, 13, 14, 15} {
+		r := bits.NewReader([]byte{0xFF})
+		out := []int16{1, 2, 3, 4, 5}
+		if err := DecodeSpectralData(r, cb, out, 4); err != nil {
+			t.Fatalf("codebook %d: %v", cb, err)
+		}
+		if out[0] != 0 || out[3] != 0 || out[4] != 5 {
+			t.Errorf("codebook %d: out = %v, want four zeros", cb, out)
+		}
+		if r.GetProcessedBits() != 0 {
+			t.Errorf("codebook %d: read %d bits", cb, r.GetProcessedBits())
+		}
+	}
+}
+
+func TestDecodeSpectralData_Errors(t *testing.T) {
+	out := make([]int16, 12)
+	tests := []struct {
+		name string
+		data []byte
+		cb   int
+		n    int
+		want error
+	}{
+		{"reserved codebook", make([]byte, 8), 12, 4, ErrInvalidCodebook},
+		{"codebook out of range", make([]byte, 8), 32, 4, ErrInvalidCodebook},
+		{"partial quad", make([]byte, 8), 1, 6, ErrSpectralLength},
+		{"partial pair", make([]byte, 8), 5, 3, ErrSpectralLength},
+		{"longer than out", make([]byte, 8), 5, 14, ErrSpectralLength},
+		// Two quads fill the byte; the third runs past it
+		{"past end", []byte{0xD4}, 1, 12, ErrBitstreamOverrun},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			if err := DecodeSpectralData(bits.NewReader(tc.data), tc.cb, out, tc.n); err != tc.want {
+				t.Errorf("got %v, want %v", err, tc.want)
+			}
+		})
+	}
+}
+
+func TestDecodeScaleFactor(t *testing.T) {
+	r := bits.NewReader(bitString("0" + "100" + "1010" + "1011" + "1100"))
+	for _, want := range []int{0, -1, 1, -2, 2} {
+		got, err := DecodeScaleFactor(r)
+		if err != nil || got != want {
+			t.Fatalf("DecodeScaleFactor = %d, %v, want %d", got, err, want)
+		}
+	}
+	if r.GetProcessedBits() != 16 {
+		t.Errorf("read %d bits, want 16", r.GetProcessedBits())
+	}
+
+	// A codeword of ones runs past a single byte
+	if _, err := DecodeScaleFactor(bits.NewReader([]byte{0xFF})); err != ErrBitstreamOverrun {
+		t.Errorf("past end: %v, want ErrBitstreamOverrun", err)
+	}
+}
+
+// BenchmarkDecodeSpectralData decodes a 1024-coefficient block of
+// codebook 11 pairs.
+func BenchmarkDecodeSpectralData(b *testing.B) {
+	data := bitString(strings.Repeat("01011001", 512))
+	out := make([]int16, 1024)
+	b.SetBytes(int64(len(data)))
+	b.ResetTimer()
+	for range b.N {
+		if err := DecodeSpectralData(bits.NewReader(data), 11, out, len(out)); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
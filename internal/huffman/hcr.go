@@ -0,0 +1,172 @@
+// internal/huffman/hcr.go
+package huffman
+
+import (
+	"errors"
+
+	"github.com/llehouerou/go-aac/internal/bits"
+)
+
+// ErrHCRLength indicates reordered spectral data with a longest codeword
+// length of zero, or shorter than its longest codeword.
+var ErrHCRLength = errors.New("huffman: invalid reordered spectral data length")
+
+// hcrMaxCwLen is the longest codeword of each codebook, escapes included,
+// which bounds the width of the segments of its priority codewords.
+//
+// Ported from: maxCwLen[] in ~/dev/faad2/libfaad/hcr.c
+var hcrMaxCwLen = [32]uint8{
+	0, 11, 9, 20, 16, 13, 11, 14, 12, 17, 14, 49,
+	0, 0, 0, 0, 14, 17, 21, 21, 25, 25, 29, 29, 29, 29, 33, 33, 33, 37, 37, 41,
+}
+
+// HCRCodeword is a codeword of Huffman codeword reordered spectral data:
+// the codebook of its section and the offset of its coefficients in the
+// spectrum.
+type HCRCodeword struct {
+	CB     uint8
+	Offset uint16
+}
+
+// hcrSegment is the part of the reordered spectral data that a segment
+// still holds: bits [lo, hi), read from lo or, with reverse, from hi.
+type hcrSegment struct {
+	lo, hi  int
+	reverse bool
+}
+
+// hcrDecoder holds the reordered spectral data of a channel as one bit
+// per byte, and a scratch buffer to decode codewords from.
+type hcrDecoder struct {
+	data []uint8
+	buf  []byte
+}
+
+// decode decodes a codeword of codebook cb from the bits carried over
+// from other segments followed by the bits of seg, into sp. On success it
+// consumes the codeword's bits from seg; otherwise it returns false and
+// the bits seg held, appended to carried, for the codeword to continue in
+// another segment, and empties seg.
+func (h *hcrDecoder) decode(cb uint8, carried []uint8, seg *hcrSegment, sp []int16) (ok bool, rest []uint8) {
+	avail := len(carried) + seg.hi - seg.lo
+	h.buf = h.buf[:0]
+	for i := range avail/8 + 8 {
+		var b byte
+		for j := range 8 {
+			if n := i*8 + j; n < avail && h.bit(carried, seg, n) != 0 {
+				b |= 0x80 >> j
+			}
+		}
+		h.buf = append(h.buf, b)
+	}
+
+	var tmp [QuadLen]int16
+	r := bits.NewReader(h.buf)
+	if SpectralData(cb, r, tmp[:]) == nil && int(r.GetProcessedBits()) <= avail {
+		n := int(r.GetProcessedBits()) - len(carried)
+		if seg.reverse {
+			seg.hi -= max(n, 0)
+		} else {
+			seg.lo += max(n, 0)
+		}
+		width := QuadLen
+		if cb >= uint8(FirstPairHCB) {
+			width = PairLen
+		}
+		copy(sp[:width], tmp[:width])
+		return true, nil
+	}
+
+	for n := range seg.hi - seg.lo {
+		carried = append(carried, h.bit(nil, seg, n))
+	}
+	seg.lo = seg.hi
+	return false, carried
+}
+
+// bit returns bit n of the carried bits followed by those of seg in its
+// reading direction.
+func (h *hcrDecoder) bit(carried []uint8, seg *hcrSegment, n int) uint8 {
+	if n < len(carried) {
+		return carried[n]
+	}
+	n -= len(carried)
+	if seg.reverse {
+		return h.data[seg.hi-1-n]
+	}
+	return h.data[seg.lo+n]
+}
+
+// ReorderedSpectralData decodes length bits of Huffman codeword
+// reordered spectral data into spec. codewords lists the codewords in
+// priority order, as they are sorted from the section data.
+//
+// The data is split into segments, each starting with one priority
+// codeword (PCW) and as wide as the longest codeword of its codebook, at
+// most longestCodeword bits; bits left after the last full segment extend
+// it. The other codewords follow in sets of one per segment. Within set s,
+// codeword k is first tried in segment k, then in the next segments in
+// turn, continuing with a segment's remaining bits where it did not fit
+// the previous one. Segments are read from their end for odd sets and
+// from their start for even ones.
+//
+// Ported from: reordered_spectral_data() in ~/dev/faad2/libfaad/hcr.c
+func ReorderedSpectralData(r *bits.Reader, length uint16, longestCodeword uint8,
+	codewords []HCRCodeword, spec []int16,
+) error {
+	// No data, e.g. silence
+	if length == 0 {
+		return nil
+	}
+	// With spectral data, at least one codeword has a nonzero length
+	if longestCodeword == 0 || length < uint16(longestCodeword) {
+		return ErrHCRLength
+	}
+
+	h := &hcrDecoder{data: make([]uint8, length)}
+	for i := range h.data {
+		h.data[i] = r.Get1Bit()
+	}
+
+	// Step 1: decode the PCWs, one at the start of each segment, as long
+	// as a full segment fits
+	var segments []hcrSegment
+	pos := 0
+	for _, cw := range codewords {
+		width := int(min(hcrMaxCwLen[cw.CB&31], longestCodeword))
+		if pos+width > int(length) {
+			break
+		}
+		seg := hcrSegment{lo: pos, hi: pos + width}
+		h.decode(cw.CB, nil, &seg, spec[cw.Offset:])
+		seg.reverse = true
+		segments = append(segments, seg)
+		pos += width
+	}
+	if len(segments) == 0 {
+		return ErrHCRLength
+	}
+	segments[len(segments)-1].hi = int(length)
+
+	// Step 2: decode the non-PCWs, set by set
+	rest := codewords[len(segments):]
+	carried := make([][]uint8, len(rest))
+	decoded := make([]bool, len(rest))
+	n := len(segments)
+	for set := 0; set*n < len(rest); set++ {
+		for trial := range n {
+			for k := 0; k < n && set*n+k < len(rest); k++ {
+				i := set*n + k
+				seg := &segments[(trial+k)%n]
+				if decoded[i] || seg.lo == seg.hi {
+					continue
+				}
+				decoded[i], carried[i] = h.decode(rest[i].CB, carried[i], seg, spec[rest[i].Offset:])
+			}
+		}
+		for s := range segments {
+			segments[s].reverse = !segments[s].reverse
+		}
+	}
+	return nil
+}
@@ -0,0 +1,187 @@
+// internal/huffman/hcr_test.go
+package huffman
+
+import (
+	"testing"
+
+	"github.com/llehouerou/go-aac/internal/bits"
+)
+
+// spectralCodeword returns the bits of the codeword of codebook cb
+// decoding to values, sign bits included, found by trying every bit
+// string up to 16 bits.
+func spectralCodeword(t *testing.T, cb uint8, values []int16) []uint8 {
+	t.Helper()
+	for length := 1; length <= 16; length++ {
+		for code := uint32(0); code < 1<<length; code++ {
+			v := code << (32 - length)
+			r := bits.NewReader([]byte{byte(v >> 24), byte(v >> 16), byte(v >> 8), byte(v), 0, 0, 0, 0})
+			var sp [QuadLen]int16
+			if SpectralData(cb, r, sp[:]) != nil || int(r.GetProcessedBits()) != length {
+				continue
+			}
+			match := true
+			for i, want := range values {
+				match = match && sp[i] == want
+			}
+			if !match {
+				continue
+			}
+			out := make([]uint8, length)
+			for i := range out {
+				out[i] = uint8(code>>(length-1-i)) & 1
+			}
+			return out
+		}
+	}
+	t.Fatalf("no codebook %d codeword for %v", cb, values)
+	return nil
+}
+
+// hcrEncode lays out codewords, given in priority order with their bits,
+// as Huffman codeword reordered spectral data: the inverse of
+// ReorderedSpectralData, placing each codeword where the decoder looks
+// for it. It fails the test if a codeword finds no room.
+func hcrEncode(t *testing.T, codewords []HCRCodeword, cwBits [][]uint8, longest uint8) []uint8 {
+	t.Helper()
+	length := 0
+	for _, b := range cwBits {
+		length += len(b)
+	}
+	data := make([]uint8, length)
+
+	// Priority codewords at the start of their segments
+	var segments []hcrSegment
+	pos := 0
+	for i, cw := range codewords {
+		width := int(min(hcrMaxCwLen[cw.CB], longest))
+		if pos+width > length {
+			break
+		}
+		copy(data[pos:], cwBits[i])
+		segments = append(segments, hcrSegment{lo: pos + len(cwBits[i]), hi: pos + width, reverse: true})
+		pos += width
+	}
+	segments[len(segments)-1].hi = length
+
+	rest := cwBits[len(segments):]
+	written := make([]int, len(rest))
+	n := len(segments)
+	for set := 0; set*n < len(rest); set++ {
+		for trial := range n {
+			for k := 0; k < n && set*n+k < len(rest); k++ {
+				i := set*n + k
+				seg := &segments[(trial+k)%n]
+				for written[i] < len(rest[i]) && seg.lo < seg.hi {
+					if seg.reverse {
+						seg.hi--
+						data[seg.hi] = rest[i][written[i]]
+					} else {
+						data[seg.lo] = rest[i][written[i]]
+						seg.lo++
+					}
+					written[i]++
+				}
+			}
+		}
+		for s := range segments {
+			segments[s].reverse = !segments[s].reverse
+		}
+	}
+	for i, w := range written {
+		if w != len(rest[i]) {
+			t.Fatalf("non-priority codeword %d: %d of %d bits placed", i, w, len(rest[i]))
+		}
+	}
+	return data
+}
+
+// packBits packs one bit per byte into bytes, MSB first, with padding.
+func packBits(data []uint8) []byte {
+	buf := make([]byte, len(data)/8+8)
+	for i, b := range data {
+		if b != 0 {
+			buf[i/8] |= 0x80 >> (i % 8)
+		}
+	}
+	return buf
+}
+
+func TestReorderedSpectralData(t *testing.T) {
+	// Pairs of codebooks 11 and 5, then quads of codebooks 1 and 2, in
+	// priority order. Segments as wide as the longest codeword leave
+	// little room after their priority codewords, so that the other
+	// codewords span several segments; wider segments leave fewer
+	// codewords outside them.
+	type entry struct {
+		cb     uint8
+		values []int16
+	}
+	entries := []entry{
+		{11, []int16{3, -2}},
+		{11, []int16{0, 7}},
+		{5, []int16{1, -1}},
+		{5, []int16{0, 0}},
+		{5, []int16{-3, 2}},
+		{1, []int16{1, 0, -1, 0}},
+		{2, []int16{0, 0, 0, 0}},
+		{1, []int16{-1, 1, 1, -1}},
+		{1, []int16{0, 0, 0, 0}},
+		{2, []int16{1, 0, 0, -1}},
+	}
+
+	var codewords []HCRCodeword
+	var cwBits [][]uint8
+	want := make([]int16, 64)
+	longest := 0
+	offset := uint16(0)
+	for _, e := range entries {
+		codewords = append(codewords, HCRCodeword{CB: e.cb, Offset: offset})
+		b := spectralCodeword(t, e.cb, e.values)
+		cwBits = append(cwBits, b)
+		longest = max(longest, len(b))
+		copy(want[offset:], e.values)
+		offset += uint16(len(e.values))
+	}
+
+	for _, tc := range []struct {
+		name    string
+		longest uint8
+	}{
+		{"longest codeword", uint8(longest)},
+		{"wider segments", 20},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			data := hcrEncode(t, codewords, cwBits, tc.longest)
+			spec := make([]int16, len(want))
+			r := bits.NewReader(packBits(data))
+			if err := ReorderedSpectralData(r, uint16(len(data)), tc.longest, codewords, spec); err != nil {
+				t.Fatalf("ReorderedSpectralData: %v", err)
+			}
+			if got := r.GetProcessedBits(); got != uint32(len(data)) {
+				t.Errorf("read %d bits, want %d", got, len(data))
+			}
+			for i := range want {
+				if spec[i] != want[i] {
+					t.Fatalf("spec = %v, want %v", spec, want)
+				}
+			}
+		})
+	}
+}
+
+func TestReorderedSpectralData_Lengths(t *testing.T) {
+	cw := []HCRCodeword{{CB: 1}}
+	spec := make([]int16, 4)
+	r := bits.NewReader(make([]byte, 8))
+
+	if err := ReorderedSpectralData(r, 0, 0, cw, spec); err != nil {
+		t.Errorf("empty data: %v, want nil", err)
+	}
+	if err := ReorderedSpectralData(r, 8, 0, cw, spec); err != ErrHCRLength {
+		t.Errorf("longest codeword 0: %v, want ErrHCRLength", err)
+	}
+	if err := ReorderedSpectralData(r, 4, 8, cw, spec); err != ErrHCRLength {
+		t.Errorf("data shorter than the longest codeword: %v, want ErrHCRLength", err)
+	}
+}
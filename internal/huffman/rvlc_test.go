@@ -0,0 +1,68 @@
+// internal/huffman/rvlc_test.go
+package huffman
+
+import "testing"
+
+// codewordBits returns a reader of the bits of cw, MSB first, reversed
+// with reverse.
+func codewordBits(h rvlcCodeword, reverse bool) func() (uint8, bool) {
+	n := uint8(0)
+	return func() (uint8, bool) {
+		if n >= h.len {
+			return 0, false
+		}
+		shift := h.len - 1 - n
+		if reverse {
+			shift = n
+		}
+		n++
+		return uint8(h.cw>>shift) & 1, true
+	}
+}
+
+func TestRVLCScaleFactor(t *testing.T) {
+	seen := make(map[int8]bool)
+	for _, h := range rvlcBook {
+		for _, reverse := range []bool{false, true} {
+			got, ok := RVLCScaleFactor(codewordBits(h, reverse))
+			if h.index == rvlcInvalid {
+				// Invalid codewords are not palindromes
+				if !reverse && ok {
+					t.Errorf("invalid codeword %0*b decoded to %d", h.len, h.cw, got)
+				}
+				continue
+			}
+			// Valid codewords read the same backward
+			if !ok || got != h.index {
+				t.Errorf("codeword %0*b (reverse %v) = %d, %v, want %d", h.len, h.cw, reverse, got, ok, h.index)
+			}
+			seen[got] = true
+		}
+	}
+	for v := int8(-RVLCEscapeValue); v <= RVLCEscapeValue; v++ {
+		if !seen[v] {
+			t.Errorf("no codeword for %d", v)
+		}
+	}
+
+	// Running out of bits
+	if _, ok := RVLCScaleFactor(codewordBits(rvlcCodeword{len: 2, cw: 3}, false)); ok {
+		t.Error("truncated codeword decoded")
+	}
+}
+
+func TestRVLCEscape(t *testing.T) {
+	seen := make(map[int8]bool)
+	for _, h := range rvlcEscapeBook {
+		got, ok := RVLCEscape(codewordBits(h, false))
+		if !ok || got != h.index {
+			t.Errorf("escape %0*b = %d, %v, want %d", h.len, h.cw, got, ok, h.index)
+		}
+		seen[got] = true
+	}
+	for v := int8(0); v <= 53; v++ {
+		if !seen[v] {
+			t.Errorf("no escape codeword for %d", v)
+		}
+	}
+}
@@ -0,0 +1,246 @@
+// internal/latm/config.go
+package latm
+
+import (
+	"errors"
+
+	"github.com/llehouerou/go-aac/internal/bits"
+)
+
+// Errors returned by the demuxer.
+var (
+	// ErrSyncNotFound is returned when a LOAS frame does not start with
+	// the AudioSyncStream syncword.
+	ErrSyncNotFound = errors.New("latm: LOAS syncword not found")
+
+	// ErrNoConfig is returned for an AudioMuxElement that relies on a
+	// StreamMuxConfig that was never received.
+	ErrNoConfig = errors.New("latm: no StreamMuxConfig received")
+
+	// ErrUnsupported is returned for multiplexes outside the supported
+	// subset.
+	ErrUnsupported = errors.New("latm: unsupported multiplex configuration")
+
+	// ErrTruncated is returned when a frame ends before its payload.
+	ErrTruncated = errors.New("latm: truncated frame")
+)
+
+// frameLengthType values of a layer.
+const frameLengthVariable = 0 // payload length signalled per frame
+
+// StreamMuxConfig holds the fields of a StreamMuxConfig() for a single
+// program and layer.
+//
+// Ported from: latm_header in ~/dev/faad2/libfaad/structs.h
+type StreamMuxConfig struct {
+	AudioMuxVersion           uint8
+	AllStreamsSameTimeFraming bool
+	NumSubFrames              uint8 // access units per AudioMuxElement, minus one
+
+	// ASC holds the AudioSpecificConfig, starting on a byte boundary.
+	// With audioMuxVersion 1 it includes the fill bits that follow it.
+	ASC []byte
+
+	FrameLengthType    uint8
+	LatmBufferFullness uint8
+	OtherDataPresent   bool
+	OtherDataLenBits   uint32
+	CRCCheckPresent    bool
+	CRCCheckSum        uint8
+}
+
+// latmGetValue reads a LatmGetValue() field.
+//
+// Ported from: latm_get_value() in ~/dev/faad2/libfaad/syntax.c
+func latmGetValue(r *bits.Reader) uint32 {
+	n := r.GetBits(2) // bytesForValue
+	var v uint32
+	for range n + 1 {
+		v = v<<8 | r.GetBits(8)
+	}
+	return v
+}
+
+// ParseStreamMuxConfig parses a StreamMuxConfig(), such as the one an
+// RTP session signals out of band in the SDP config parameter.
+func ParseStreamMuxConfig(data []byte) (*StreamMuxConfig, error) {
+	r := bits.NewReader(data)
+	c, err := parseStreamMuxConfig(r)
+	if err != nil {
+		return nil, err
+	}
+	if r.GetProcessedBits() > uint32(len(data))*8 {
+		return nil, ErrTruncated
+	}
+	return c, nil
+}
+
+// parseStreamMuxConfig parses a StreamMuxConfig().
+//
+// Ported from: latmParseConfig() in ~/dev/faad2/libfaad/syntax.c
+func parseStreamMuxConfig(r *bits.Reader) (*StreamMuxConfig, error) {
+	c := &StreamMuxConfig{AudioMuxVersion: r.Get1Bit()}
+	if c.AudioMuxVersion == 1 && r.Get1Bit() == 1 {
+		// audioMuxVersionA
+		return nil, ErrUnsupported
+	}
+	if c.AudioMuxVersion == 1 {
+		latmGetValue(r) // taraBufferFullness
+	}
+
+	c.AllStreamsSameTimeFraming = r.Get1Bit() == 1
+	c.NumSubFrames = uint8(r.GetBits(6))
+	numProgram := r.GetBits(4)
+	numLayer := r.GetBits(3)
+	if numProgram != 0 || numLayer != 0 || !c.AllStreamsSameTimeFraming {
+		return nil, ErrUnsupported
+	}
+
+	// The first layer of the first program always carries its config
+	if c.AudioMuxVersion == 0 {
+		start := r.GetProcessedBits()
+		if err := skipAudioSpecificConfig(r); err != nil {
+			return nil, err
+		}
+		length := r.GetProcessedBits() - start
+		r.ResetBits(start)
+		c.ASC = r.GetBitBuffer(uint(length))
+	} else {
+		length := latmGetValue(r) // ascLen, in bits
+		c.ASC = r.GetBitBuffer(uint(length))
+	}
+
+	c.FrameLengthType = uint8(r.GetBits(3))
+	if c.FrameLengthType != frameLengthVariable {
+		return nil, ErrUnsupported
+	}
+	c.LatmBufferFullness = uint8(r.GetBits(8))
+
+	c.OtherDataPresent = r.Get1Bit() == 1
+	if c.OtherDataPresent {
+		if c.AudioMuxVersion == 1 {
+			c.OtherDataLenBits = latmGetValue(r)
+		} else {
+			for {
+				esc := r.Get1Bit()
+				c.OtherDataLenBits = c.OtherDataLenBits<<8 | r.GetBits(8)
+				if esc == 0 {
+					break
+				}
+			}
+		}
+	}
+
+	c.CRCCheckPresent = r.Get1Bit() == 1
+	if c.CRCCheckPresent {
+		c.CRCCheckSum = uint8(r.GetBits(8))
+	}
+	if r.Error() {
+		return nil, ErrTruncated
+	}
+	return c, nil
+}
+
+// getObjectType reads an audioObjectType with its escape.
+//
+// Ported from: AudioSpecificConfigFromBitfile() in ~/dev/faad2/libfaad/mp4.c
+func getObjectType(r *bits.Reader) uint8 {
+	ot := uint8(r.GetBits(5))
+	if ot == 31 {
+		ot = 32 + uint8(r.GetBits(6))
+	}
+	return ot
+}
+
+// skipAudioSpecificConfig reads past an AudioSpecificConfig(), whose
+// length the StreamMuxConfig of audioMuxVersion 0 does not give.
+//
+// Ported from: AudioSpecificConfigFromBitfile() in ~/dev/faad2/libfaad/mp4.c
+func skipAudioSpecificConfig(r *bits.Reader) error {
+	start := r.GetProcessedBits()
+	ot := getObjectType(r)
+	if r.GetBits(4) == 15 {
+		r.FlushBits(24) // samplingFrequency
+	}
+	channelConfig := r.GetBits(4)
+
+	if ot == 5 || ot == 29 {
+		// Explicit SBR/PS signalling: extension rate and core object type
+		if r.GetBits(4) == 15 {
+			r.FlushBits(24)
+		}
+		ot = getObjectType(r)
+	}
+
+	switch ot {
+	case 1, 2, 3, 4, 6, 7, 17, 19, 20, 21, 22, 23:
+		skipGASpecificConfig(r, ot, channelConfig, start)
+	default:
+		return ErrUnsupported
+	}
+
+	if ot >= 17 {
+		if epConfig := r.GetBits(2); epConfig >= 2 {
+			return ErrUnsupported
+		}
+	}
+	return nil
+}
+
+// skipGASpecificConfig reads past a GASpecificConfig(). ascStart is the
+// position of the AudioSpecificConfig, the reference of the byte
+// alignment inside a program_config_element().
+//
+// Ported from: GASpecificConfig() in ~/dev/faad2/libfaad/mp4.c
+func skipGASpecificConfig(r *bits.Reader, ot uint8, channelConfig, ascStart uint32) {
+	r.FlushBits(1) // frameLengthFlag
+	if r.Get1Bit() == 1 {
+		r.FlushBits(14) // coreCoderDelay
+	}
+	extensionFlag := r.Get1Bit()
+	if channelConfig == 0 {
+		skipProgramConfig(r, ascStart)
+	}
+	if ot == 6 || ot == 20 {
+		r.FlushBits(3) // layerNr
+	}
+	if extensionFlag == 1 {
+		switch ot {
+		case 22:
+			r.FlushBits(5 + 11) // numOfSubFrame, layer_length
+		case 17, 19, 20, 23:
+			r.FlushBits(3) // resilience flags
+		}
+		r.FlushBits(1) // extensionFlag3
+	}
+}
+
+// skipProgramConfig reads past a program_config_element().
+//
+// Ported from: program_config_element() in ~/dev/faad2/libfaad/syntax.c
+func skipProgramConfig(r *bits.Reader, ascStart uint32) {
+	r.FlushBits(4 + 2 + 4) // element_instance_tag, object_type, sf_index
+	front := r.GetBits(4)
+	side := r.GetBits(4)
+	back := r.GetBits(4)
+	lfe := r.GetBits(2)
+	assoc := r.GetBits(3)
+	cc := r.GetBits(4)
+	if r.Get1Bit() == 1 {
+		r.FlushBits(4) // mono_mixdown_element_number
+	}
+	if r.Get1Bit() == 1 {
+		r.FlushBits(4) // stereo_mixdown_element_number
+	}
+	if r.Get1Bit() == 1 {
+		r.FlushBits(3) // matrix_mixdown_idx, pseudo_surround_enable
+	}
+	r.FlushBits(uint(5*(front+side+back) + 4*(lfe+assoc) + 5*cc))
+	if pad := (r.GetProcessedBits() - ascStart) % 8; pad != 0 {
+		r.FlushBits(uint(8 - pad))
+	}
+	comment := r.GetBits(8)
+	for range comment {
+		r.FlushBits(8)
+	}
+}
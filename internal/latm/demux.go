@@ -0,0 +1,116 @@
+// internal/latm/demux.go
+package latm
+
+import "github.com/llehouerou/go-aac/internal/bits"
+
+// LOAS AudioSyncStream framing.
+const (
+	syncWord       = 0x2B7 // 11-bit AudioSyncStream syncword
+	loasHeaderSize = 3     // syncword and 13-bit audioMuxLengthBytes
+)
+
+// IsLOASSync reports whether data starts with the LOAS syncword.
+func IsLOASSync(data []byte) bool {
+	return len(data) >= 2 && data[0] == 0x56 && data[1]&0xE0 == 0xE0
+}
+
+// Demuxer extracts access units from LATM. It keeps the last
+// StreamMuxConfig for the AudioMuxElements that reuse it.
+type Demuxer struct {
+	config *StreamMuxConfig
+}
+
+// NewDemuxer returns a demuxer that has not received a StreamMuxConfig.
+func NewDemuxer() *Demuxer {
+	return &Demuxer{}
+}
+
+// SetConfig sets the StreamMuxConfig of a stream that does not carry it
+// in band (muxConfigPresent 0).
+func (d *Demuxer) SetConfig(c *StreamMuxConfig) {
+	d.config = c
+}
+
+// Config returns the current StreamMuxConfig, or nil before one was
+// received.
+func (d *Demuxer) Config() *StreamMuxConfig {
+	return d.config
+}
+
+// ParseLOAS parses the AudioSyncStream frame at the start of data, whose
+// AudioMuxElement carries its config in band. It returns the access units
+// of the frame and the size of the frame in bytes.
+//
+// Ported from: latmCheck() in ~/dev/faad2/libfaad/syntax.c
+func (d *Demuxer) ParseLOAS(data []byte) ([][]byte, int, error) {
+	if len(data) < loasHeaderSize {
+		return nil, 0, ErrTruncated
+	}
+	r := bits.NewReader(data)
+	if r.GetBits(11) != syncWord {
+		return nil, 0, ErrSyncNotFound
+	}
+	size := loasHeaderSize + int(r.GetBits(13)) // audioMuxLengthBytes
+	if size > len(data) {
+		return nil, 0, ErrTruncated
+	}
+
+	payloads, _, err := d.ParseAudioMuxElement(data[loasHeaderSize:size], true)
+	if err != nil {
+		return nil, 0, err
+	}
+	return payloads, size, nil
+}
+
+// ParseAudioMuxElement parses an AudioMuxElement(muxConfigPresent) and
+// returns its access units and its size in bytes. Without
+// muxConfigPresent, the config must have been set with SetConfig.
+//
+// Ported from: latmAudioMuxElement() in ~/dev/faad2/libfaad/syntax.c
+func (d *Demuxer) ParseAudioMuxElement(data []byte, muxConfigPresent bool) ([][]byte, int, error) {
+	r := bits.NewReader(data)
+	if muxConfigPresent && r.Get1Bit() == 0 {
+		// useSameStreamMux is 0: a new StreamMuxConfig follows
+		c, err := parseStreamMuxConfig(r)
+		if err != nil {
+			return nil, 0, err
+		}
+		d.config = c
+	}
+	c := d.config
+	if c == nil {
+		return nil, 0, ErrNoConfig
+	}
+
+	payloads := make([][]byte, 0, int(c.NumSubFrames)+1)
+	for range int(c.NumSubFrames) + 1 {
+		// PayloadLengthInfo(): MuxSlotLengthBytes
+		var length uint32
+		for {
+			tmp := r.GetBits(8)
+			length += tmp
+			if tmp != 255 {
+				break
+			}
+		}
+		if r.GetProcessedBits()+8*length > uint32(len(data))*8 {
+			return nil, 0, ErrTruncated
+		}
+		// PayloadMux()
+		payloads = append(payloads, r.GetBitBuffer(uint(8*length)))
+	}
+	if c.OtherDataPresent {
+		for left := c.OtherDataLenBits; left > 0; {
+			n := min(left, 32)
+			r.FlushBits(uint(n))
+			left -= n
+		}
+	}
+	r.ByteAlign()
+
+	consumed := r.GetProcessedBits() / 8
+	if consumed > uint32(len(data)) {
+		return nil, 0, ErrTruncated
+	}
+	return payloads, int(consumed), nil
+}
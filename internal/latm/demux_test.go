@@ -0,0 +1,239 @@
+package latm
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+
+	"github.com/llehouerou/go-aac/internal/bits"
+)
+
+// bitWriter packs values MSB first for building test bitstreams.
+type bitWriter struct {
+	buf  []byte
+	nbit int
+}
+
+func (w *bitWriter) writeBits(val uint32, n int) {
+	for i := n - 1; i >= 0; i-- {
+		if w.nbit%8 == 0 {
+			w.buf = append(w.buf, 0)
+		}
+		if val&(1<<uint(i)) != 0 {
+			w.buf[len(w.buf)-1] |= 0x80 >> uint(w.nbit%8)
+		}
+		w.nbit++
+	}
+}
+
+func (w *bitWriter) writeBytes(b []byte) {
+	for _, v := range b {
+		w.writeBits(uint32(v), 8)
+	}
+}
+
+func (w *bitWriter) byteAlign() {
+	for w.nbit%8 != 0 {
+		w.writeBits(0, 1)
+	}
+}
+
+// lcMonoASC is the AudioSpecificConfig of mono AAC LC at 44100 Hz.
+var lcMonoASC = []byte{0x12, 0x08}
+
+// writeStreamMuxConfig writes an audioMuxVersion 0 StreamMuxConfig for
+// asc, with otherDataLenBits of other data if non-zero.
+func writeStreamMuxConfig(w *bitWriter, asc []byte, ascBits int, otherDataLenBits uint32) {
+	w.writeBits(0, 1) // audioMuxVersion
+	w.writeBits(1, 1) // allStreamsSameTimeFraming
+	w.writeBits(0, 6) // numSubFrames
+	w.writeBits(0, 4) // numProgram
+	w.writeBits(0, 3) // numLayer
+	r := bits.NewReader(append(asc, 0, 0, 0, 0))
+	for n := ascBits; n > 0; n -= min(n, 8) {
+		w.writeBits(r.GetBits(uint(min(n, 8))), min(n, 8))
+	}
+	w.writeBits(0, 3)    // frameLengthType
+	w.writeBits(0xFF, 8) // latmBufferFullness
+	if otherDataLenBits != 0 {
+		w.writeBits(1, 1)
+		w.writeBits(0, 1) // otherDataLenEsc
+		w.writeBits(otherDataLenBits, 8)
+	} else {
+		w.writeBits(0, 1)
+	}
+	w.writeBits(0, 1) // crcCheckPresent
+}
+
+// writePayload writes PayloadLengthInfo() and PayloadMux().
+func writePayload(w *bitWriter, payload []byte) {
+	n := len(payload)
+	for ; n >= 255; n -= 255 {
+		w.writeBits(255, 8)
+	}
+	w.writeBits(uint32(n), 8)
+	w.writeBytes(payload)
+}
+
+// loasFrame wraps an AudioMuxElement(1) in LOAS framing. A nil asc
+// reuses the previous StreamMuxConfig.
+func loasFrame(asc []byte, payload []byte) []byte {
+	w := &bitWriter{}
+	if asc != nil {
+		w.writeBits(0, 1) // useSameStreamMux
+		writeStreamMuxConfig(w, asc, 16, 0)
+	} else {
+		w.writeBits(1, 1)
+	}
+	writePayload(w, payload)
+	w.byteAlign()
+
+	f := &bitWriter{}
+	f.writeBits(syncWord, 11)
+	f.writeBits(uint32(len(w.buf)), 13)
+	f.writeBytes(w.buf)
+	return f.buf
+}
+
+func TestParseLOAS(t *testing.T) {
+	first := bytes.Repeat([]byte{0xA5}, 300) // length escape
+	second := []byte{1, 2, 3}
+	stream := append(loasFrame(lcMonoASC, first), loasFrame(nil, second)...)
+
+	d := NewDemuxer()
+	payloads, size, err := d.ParseLOAS(stream)
+	if err != nil {
+		t.Fatalf("first frame: %v", err)
+	}
+	if len(payloads) != 1 || !bytes.Equal(payloads[0], first) {
+		t.Fatalf("first frame payload mismatch: %d payloads", len(payloads))
+	}
+	if c := d.Config(); c == nil || !bytes.Equal(c.ASC, lcMonoASC) {
+		t.Fatalf("config = %+v, want ASC % x", c, lcMonoASC)
+	}
+
+	payloads, size2, err := d.ParseLOAS(stream[size:])
+	if err != nil {
+		t.Fatalf("second frame: %v", err)
+	}
+	if !bytes.Equal(payloads[0], second) {
+		t.Errorf("second payload = % x, want % x", payloads[0], second)
+	}
+	if size+size2 != len(stream) {
+		t.Errorf("frame sizes %d + %d, stream %d bytes", size, size2, len(stream))
+	}
+}
+
+func TestParseLOAS_Errors(t *testing.T) {
+	d := NewDemuxer()
+	if _, _, err := d.ParseLOAS([]byte{0xFF, 0xF1, 0x50, 0x80}); !errors.Is(err, ErrSyncNotFound) {
+		t.Errorf("ADTS data: got %v, want ErrSyncNotFound", err)
+	}
+	if _, _, err := d.ParseLOAS(loasFrame(nil, []byte{1})); !errors.Is(err, ErrNoConfig) {
+		t.Errorf("useSameStreamMux first: got %v, want ErrNoConfig", err)
+	}
+	frame := loasFrame(lcMonoASC, []byte{1, 2, 3})
+	if _, _, err := d.ParseLOAS(frame[:len(frame)-1]); !errors.Is(err, ErrTruncated) {
+		t.Errorf("truncated: got %v, want ErrTruncated", err)
+	}
+
+	w := &bitWriter{}
+	w.writeBits(0, 1) // useSameStreamMux
+	w.writeBits(0, 1) // audioMuxVersion
+	w.writeBits(1, 1)
+	w.writeBits(0, 6)
+	w.writeBits(1, 4) // numProgram: two programs
+	w.writeBits(0, 3)
+	w.writeBits(0, 32)
+	if _, _, err := d.ParseAudioMuxElement(w.buf, true); !errors.Is(err, ErrUnsupported) {
+		t.Errorf("two programs: got %v, want ErrUnsupported", err)
+	}
+}
+
+func TestParseAudioMuxElement_OutOfBandConfig(t *testing.T) {
+	w := &bitWriter{}
+	writeStreamMuxConfig(w, lcMonoASC, 16, 12)
+	c, err := ParseStreamMuxConfig(w.buf)
+	if err != nil {
+		t.Fatalf("ParseStreamMuxConfig: %v", err)
+	}
+	if !c.OtherDataPresent || c.OtherDataLenBits != 12 || !bytes.Equal(c.ASC, lcMonoASC) {
+		t.Fatalf("config = %+v", c)
+	}
+
+	d := NewDemuxer()
+	d.SetConfig(c)
+	e := &bitWriter{}
+	writePayload(e, []byte{9, 8, 7})
+	e.writeBits(0xABC, 12) // otherData
+	e.byteAlign()
+	e.writeBits(0xEE, 8) // the next element
+
+	payloads, size, err := d.ParseAudioMuxElement(e.buf, false)
+	if err != nil {
+		t.Fatalf("ParseAudioMuxElement: %v", err)
+	}
+	if !bytes.Equal(payloads[0], []byte{9, 8, 7}) || size != len(e.buf)-1 {
+		t.Errorf("got payload % x, size %d; want 09 08 07, %d", payloads[0], size, len(e.buf)-1)
+	}
+}
+
+func TestParseStreamMuxConfig_Version1(t *testing.T) {
+	w := &bitWriter{}
+	w.writeBits(1, 1) // audioMuxVersion
+	w.writeBits(0, 1) // audioMuxVersionA
+	w.writeBits(0, 2) // taraBufferFullness: 1 byte
+	w.writeBits(0xFF, 8)
+	w.writeBits(1, 1)
+	w.writeBits(0, 6)
+	w.writeBits(0, 4)
+	w.writeBits(0, 3)
+	w.writeBits(0, 2) // ascLen: 1 byte
+	w.writeBits(24, 8)
+	w.writeBytes([]byte{0x12, 0x08, 0x56}) // ASC and fill bits
+	w.writeBits(0, 3)
+	w.writeBits(0xFF, 8)
+	w.writeBits(0, 1)
+	w.writeBits(1, 1) // crcCheckPresent
+	w.writeBits(0x5A, 8)
+
+	c, err := ParseStreamMuxConfig(w.buf)
+	if err != nil {
+		t.Fatalf("ParseStreamMuxConfig: %v", err)
+	}
+	if !bytes.Equal(c.ASC, []byte{0x12, 0x08, 0x56}) || !c.CRCCheckPresent || c.CRCCheckSum != 0x5A {
+		t.Errorf("config = %+v", c)
+	}
+}
+
+func TestSkipAudioSpecificConfig(t *testing.T) {
+	// LC 48000 Hz with a PCE: one SCE, one CPE, no comment
+	w := &bitWriter{}
+	w.writeBits(1, 3) // 3 bits of lead-in, so the PCE alignment is relative
+	start := w.nbit
+	w.writeBits(2, 5)
+	w.writeBits(3, 4)
+	w.writeBits(0, 4) // channelConfiguration 0
+	w.writeBits(0, 3) // frameLengthFlag, dependsOnCoreCoder, extensionFlag
+	w.writeBits(0, 4+2+4)
+	w.writeBits(2, 4) // num_front_channel_elements
+	w.writeBits(0, 4+4+2+3+4)
+	w.writeBits(0, 3) // no mixdowns
+	w.writeBits(0x01, 5)
+	w.writeBits(0x10, 5)
+	for (w.nbit-start)%8 != 0 {
+		w.writeBits(0, 1)
+	}
+	w.writeBits(0, 8) // comment_field_bytes
+	want := w.nbit - start
+	w.writeBits(0xFFFF, 16)
+
+	r := bits.NewReader(w.buf)
+	r.FlushBits(3)
+	if err := skipAudioSpecificConfig(r); err != nil {
+		t.Fatalf("skipAudioSpecificConfig: %v", err)
+	}
+	if got := int(r.GetProcessedBits()) - start; got != want {
+		t.Errorf("read %d bits, want %d", got, want)
+	}
+}
@@ -0,0 +1,14 @@
+// Package latm demultiplexes AAC carried in LATM (Low-overhead MPEG-4
+// Audio Transport Multiplex), as used by DVB broadcasts in LOAS framing
+// and by the RTP MP4A-LATM payload format.
+//
+// It parses the AudioSyncStream (LOAS) framing, the AudioMuxElement and
+// its StreamMuxConfig, and returns the raw access units together with the
+// AudioSpecificConfig carried in band. Only the single program, single
+// layer multiplexes of AAC streams are supported: audioMuxVersionA 1,
+// several programs or layers, and the CELP, HVXC and fixed frame length
+// types are rejected with ErrUnsupported.
+//
+// Ported from: latmAudioMuxElement(), latmParseConfig() in
+// ~/dev/faad2/libfaad/syntax.c
+package latm
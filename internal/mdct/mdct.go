@@ -8,13 +8,18 @@ import "github.com/llehouerou/go-aac/internal/fft"
 //
 // Ported from: mdct_info struct in ~/dev/faad2/libfaad/structs.h:57-65
 type MDCT struct {
-	N      uint16        // Transform size (256 or 2048 for AAC)
+	N      uint16        // Transform size (256 or 2048 for AAC, 240 or 1920 for 960-sample frames)
 	N2     uint16        // N/2
 	N4     uint16        // N/4
 	N8     uint16        // N/8
 	cfft   *fft.CFFT     // Complex FFT of size N/4
 	sincos []fft.Complex // Pre/post twiddle factors (N/4 entries)
 	work   []fft.Complex // Reusable work buffer for transforms
+
+	// Tap, when non-nil, receives the pre-twiddled N/4 complex values
+	// handed to the inverse FFT in IMDCT. The slice is only valid for
+	// the duration of the call.
+	Tap func(z []fft.Complex)
 }
 
 // NewMDCT creates and initializes an MDCT for the given transform size.
@@ -54,8 +59,16 @@ func getSinCosTable(n uint16) []fft.Complex {
 	switch n {
 	case 2048:
 		return mdctTab2048[:]
+	case 1024:
+		return mdctTab1024[:]
 	case 256:
 		return mdctTab256[:]
+	case 1920:
+		return mdctTab1920[:]
+	case 960:
+		return mdctTab960[:]
+	case 240:
+		return mdctTab240[:]
 	default:
 		return nil
 	}
@@ -95,6 +108,10 @@ func (m *MDCT) IMDCT(xIn []float32, xOut []float32) {
 		z1[k].Im, z1[k].Re = fft.ComplexMult(x1, x2, c1, c2)
 	}
 
+	if m.Tap != nil {
+		m.Tap(z1)
+	}
+
 	// Complex IFFT
 	m.cfft.Backward(z1)
 
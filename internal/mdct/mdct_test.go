@@ -12,6 +12,10 @@ func TestNewMDCT_CreatesValidInstance(t *testing.T) {
 	}{
 		{256, 64},   // short blocks
 		{2048, 512}, // long blocks
+		{1024, 256}, // AAC-LD long blocks
+		{240, 60},   // short blocks of 960-sample frames
+		{1920, 480}, // long blocks of 960-sample frames
+		{960, 240},  // AAC-LD long blocks of 480-sample frames
 	}
 
 	for _, tt := range tests {
@@ -52,6 +56,9 @@ func TestMDCTTables_MatchFAAD2(t *testing.T) {
 		if len(mdctTab256) != 64 {
 			t.Errorf("mdctTab256 length = %d, want 64", len(mdctTab256))
 		}
+		if len(mdctTab1024) != 256 {
+			t.Errorf("mdctTab1024 length = %d, want 256", len(mdctTab1024))
+		}
 	})
 
 	// Validate all entries match formula: sqrt(2/N) * exp(j * 2*PI * (k + 1/8) / N)
@@ -91,6 +98,23 @@ func TestMDCTTables_MatchFAAD2(t *testing.T) {
 			}
 		}
 	})
+
+	t.Run("AllEntries_N1024", func(t *testing.T) {
+		n := 1024.0
+		scale := math.Sqrt(2.0 / n)
+		for k := 0; k < len(mdctTab1024); k++ {
+			angle := 2.0 * math.Pi * (float64(k) + 0.125) / n
+			expectedRe := float32(scale * math.Cos(angle))
+			expectedIm := float32(scale * math.Sin(angle))
+
+			if math.Abs(float64(mdctTab1024[k].Re-expectedRe)) > tolerance {
+				t.Errorf("mdctTab1024[%d].Re = %v, want %v", k, mdctTab1024[k].Re, expectedRe)
+			}
+			if math.Abs(float64(mdctTab1024[k].Im-expectedIm)) > tolerance {
+				t.Errorf("mdctTab1024[%d].Im = %v, want %v", k, mdctTab1024[k].Im, expectedIm)
+			}
+		}
+	})
 }
 
 func TestIMDCT_Linearity(t *testing.T) {
@@ -252,3 +276,108 @@ func TestMDCT_MultipleCallsNoAllocation(t *testing.T) {
 		m.Forward(forwardInput, forwardOutput)
 	}
 }
+
+// TestIMDCT_DirectFormula compares IMDCT with the direct evaluation
+// x[n] = 2/N * sum X[k] cos(2*pi/N * (n + n0) * (k + 1/2)), n0 = (N/2 + 1)/2,
+// for the power-of-two sizes and the mixed-radix 960-frame sizes.
+func TestIMDCT_DirectFormula(t *testing.T) {
+	for _, n := range []uint16{256, 2048, 240, 1920, 960} {
+		m := NewMDCT(n)
+		input := make([]float32, n/2)
+		for i := range input {
+			input[i] = float32(math.Sin(float64(i)*0.37) + 0.2*float64(i%7))
+		}
+		output := make([]float32, n)
+		m.IMDCT(input, output)
+
+		size := float64(n)
+		n0 := (size/2 + 1) / 2
+		for i := range output {
+			var want float64
+			for k, x := range input {
+				want += float64(x) * math.Cos(2*math.Pi/size*(float64(i)+n0)*(float64(k)+0.5))
+			}
+			want *= 2 / size
+			if math.Abs(float64(output[i])-want) > 1e-4 {
+				t.Errorf("N=%d: output[%d] = %v, want %v", n, i, output[i], want)
+				break
+			}
+		}
+	}
+}
+
+// TestMDCT_ForwardDirectFormula compares the first N/2 outputs of Forward,
+// the MDCT coefficients, with the direct evaluation
+// X[k] = 2 * sum x[n] cos(2*pi/N * (n + n0) * (k + 1/2)), n0 = (N/2 + 1)/2,
+// for the long and short windows of 1024- and 960-sample frames.
+func TestMDCT_ForwardDirectFormula(t *testing.T) {
+	for _, n := range []uint16{2048, 256, 1920, 240} {
+		m := NewMDCT(n)
+		input := make([]float32, n)
+		for i := range input {
+			input[i] = float32(math.Sin(float64(i)*0.37) + 0.2*float64(i%7))
+		}
+		output := make([]float32, n)
+		m.Forward(input, output)
+
+		size := float64(n)
+		n0 := (size/2 + 1) / 2
+		for k := range int(n / 2) {
+			var want float64
+			for i, x := range input {
+				want += float64(x) * math.Cos(2*math.Pi/size*(float64(i)+n0)*(float64(k)+0.5))
+			}
+			want *= 2
+			if math.Abs(float64(output[k])-want) > 1e-4*math.Max(math.Abs(want), size) {
+				t.Errorf("N=%d: X[%d] = %v, want %v", n, k, output[k], want)
+				break
+			}
+		}
+	}
+}
+
+// TestMDCT_TDACReconstruction transforms a signal in half-overlapping
+// blocks under a sine window, which meets the Princen-Bradley condition
+// w[n]^2 + w[n+N/2]^2 = 1, and checks that windowing the IMDCT of each
+// block and overlap-adding cancels the time-domain aliasing, giving back
+// the signal wherever two blocks overlap.
+func TestMDCT_TDACReconstruction(t *testing.T) {
+	for _, n := range []int{2048, 256, 1920, 240} {
+		m := NewMDCT(uint16(n))
+		hop := n / 2
+		window := make([]float32, n)
+		for i := range window {
+			window[i] = float32(math.Sin(math.Pi / float64(n) * (float64(i) + 0.5)))
+		}
+
+		const blocks = 4
+		signal := make([]float32, (blocks+1)*hop)
+		for i := range signal {
+			signal[i] = float32(1000*math.Sin(float64(i)*0.05) + 300*math.Cos(float64(i)*0.71))
+		}
+
+		out := make([]float32, len(signal))
+		block := make([]float32, n)
+		coefs := make([]float32, n)
+		synth := make([]float32, n)
+		for b := range blocks {
+			start := b * hop
+			for i := range block {
+				block[i] = signal[start+i] * window[i]
+			}
+			m.Forward(block, coefs)
+			m.IMDCT(coefs[:hop], synth)
+			for i := range synth {
+				out[start+i] += synth[i] * window[i]
+			}
+		}
+
+		// The first and last half blocks have no overlapping partner
+		for i := hop; i < blocks*hop; i++ {
+			if math.Abs(float64(out[i]-signal[i])) > 0.05 {
+				t.Errorf("N=%d: sample %d = %v, want %v", n, i, out[i], signal[i])
+				break
+			}
+		}
+	}
+}
@@ -592,3 +592,1055 @@ var mdctTab256 = [64]fft.Complex{
 	{Re: 4.066145255116195e-03, Im: 8.829477030246070e-02},
 	{Re: 1.898058472816106e-03, Im: 8.836796576833582e-02},
 }
+
+// mdctTab1024 contains 256 complex twiddle factors for N=1024 MDCT.
+var mdctTab1024 = [256]fft.Complex{
+	{Re: 4.419416082501231e-02, Im: 3.389650346796237e-05},
+	{Re: 4.419312089738881e-02, Im: 3.050661383643209e-04},
+	{Re: 4.419041712374158e-02, Im: 5.762242876929942e-04},
+	{Re: 4.418604960586616e-02, Im: 8.473607425029190e-04},
+	{Re: 4.418001850819714e-02, Im: 1.118465294659819e-03},
+	{Re: 4.417232405780194e-02, Im: 1.389527737230534e-03},
+	{Re: 4.416296654437225e-02, Im: 1.660537864867306e-03},
+	{Re: 4.415194632021317e-02, Im: 1.931485474192006e-03},
+	{Re: 4.413926380022993e-02, Im: 2.202360364180283e-03},
+	{Re: 4.412491946191222e-02, Im: 2.473152336545627e-03},
+	{Re: 4.410891384531627e-02, Im: 2.743851196123332e-03},
+	{Re: 4.409124755304450e-02, Im: 3.014446751254335e-03},
+	{Re: 4.407192125022284e-02, Im: 3.284928814168924e-03},
+	{Re: 4.405093566447565e-02, Im: 3.555287201370309e-03},
+	{Re: 4.402829158589839e-02, Im: 3.825511734018019e-03},
+	{Re: 4.400398986702780e-02, Im: 4.095592238311131e-03},
+	{Re: 4.397803142280989e-02, Im: 4.365518545871309e-03},
+	{Re: 4.395041723056538e-02, Im: 4.635280494125636e-03},
+	{Re: 4.392114832995302e-02, Im: 4.904867926689230e-03},
+	{Re: 4.389022582293038e-02, Im: 5.174270693747627e-03},
+	{Re: 4.385765087371236e-02, Im: 5.443478652438914e-03},
+	{Re: 4.382342470872738e-02, Im: 5.712481667235603e-03},
+	{Re: 4.378754861657122e-02, Im: 5.981269610326228e-03},
+	{Re: 4.375002394795847e-02, Im: 6.249832361996652e-03},
+	{Re: 4.371085211567169e-02, Im: 6.518159811011066e-03},
+	{Re: 4.367003459450823e-02, Im: 6.786241854992675e-03},
+	{Re: 4.362757292122470e-02, Im: 7.054068400804043e-03},
+	{Re: 4.358346869447907e-02, Im: 7.321629364927094e-03},
+	{Re: 4.353772357477058e-02, Im: 7.588914673842760e-03},
+	{Re: 4.349033928437712e-02, Im: 7.855914264410227e-03},
+	{Re: 4.344131760729045e-02, Im: 8.122618084245819e-03},
+	{Re: 4.339066038914899e-02, Im: 8.389016092101461e-03},
+	{Re: 4.333836953716837e-02, Im: 8.655098258242726e-03},
+	{Re: 4.328444702006964e-02, Im: 8.920854564826452e-03},
+	{Re: 4.322889486800505e-02, Im: 9.186275006277899e-03},
+	{Re: 4.317171517248178e-02, Im: 9.451349589667460e-03},
+	{Re: 4.311291008628299e-02, Im: 9.716068335086899e-03},
+	{Re: 4.305248182338699e-02, Im: 9.980421276025064e-03},
+	{Re: 4.299043265888368e-02, Im: 1.024439845974314e-02},
+	{Re: 4.292676492888907e-02, Im: 1.050798994764937e-02},
+	{Re: 4.286148103045718e-02, Im: 1.077118581567321e-02},
+	{Re: 4.279458342148991e-02, Im: 1.103397615463899e-02},
+	{Re: 4.272607462064441e-02, Im: 1.129635107063897e-02},
+	{Re: 4.265595720723832e-02, Im: 1.155830068540587e-02},
+	{Re: 4.258423382115262e-02, Im: 1.181981513668474e-02},
+	{Re: 4.251090716273227e-02, Im: 1.208088457860430e-02},
+	{Re: 4.243597999268449e-02, Im: 1.234149918204762e-02},
+	{Re: 4.235945513197491e-02, Im: 1.260164913502218e-02},
+	{Re: 4.228133546172126e-02, Im: 1.286132464302928e-02},
+	{Re: 4.220162392308500e-02, Im: 1.312051592943283e-02},
+	{Re: 4.212032351716048e-02, Im: 1.337921323582738e-02},
+	{Re: 4.203743730486204e-02, Im: 1.363740682240556e-02},
+	{Re: 4.195296840680873e-02, Im: 1.389508696832477e-02},
+	{Re: 4.186692000320682e-02, Im: 1.415224397207316e-02},
+	{Re: 4.177929533373007e-02, Im: 1.440886815183488e-02},
+	{Re: 4.169009769739775e-02, Im: 1.466494984585461e-02},
+	{Re: 4.159933045245047e-02, Im: 1.492047941280131e-02},
+	{Re: 4.150699701622369e-02, Im: 1.517544723213120e-02},
+	{Re: 4.141310086501909e-02, Im: 1.542984370445000e-02},
+	{Re: 4.131764553397371e-02, Im: 1.568365925187432e-02},
+	{Re: 4.122063461692680e-02, Im: 1.593688431839223e-02},
+	{Re: 4.112207176628457e-02, Im: 1.618950937022313e-02},
+	{Re: 4.102196069288261e-02, Im: 1.644152489617658e-02},
+	{Re: 4.092030516584628e-02, Im: 1.669292140801048e-02},
+	{Re: 4.081710901244871e-02, Im: 1.694368944078825e-02},
+	{Re: 4.071237611796673e-02, Im: 1.719381955323518e-02},
+	{Re: 4.060611042553465e-02, Im: 1.744330232809392e-02},
+	{Re: 4.049831593599570e-02, Im: 1.769212837247900e-02},
+	{Re: 4.038899670775152e-02, Im: 1.794028831823049e-02},
+	{Re: 4.027815685660924e-02, Im: 1.818777282226668e-02},
+	{Re: 4.016580055562661e-02, Im: 1.843457256693589e-02},
+	{Re: 4.005193203495486e-02, Im: 1.868067826036722e-02},
+	{Re: 3.993655558167945e-02, Im: 1.892608063682041e-02},
+	{Re: 3.981967553965861e-02, Im: 1.917077045703469e-02},
+	{Re: 3.970129630935988e-02, Im: 1.941473850857662e-02},
+	{Re: 3.958142234769436e-02, Im: 1.965797560618696e-02},
+	{Re: 3.946005816784897e-02, Im: 1.990047259212645e-02},
+	{Re: 3.933720833911647e-02, Im: 2.014222033652065e-02},
+	{Re: 3.921287748672348e-02, Im: 2.038320973770361e-02},
+	{Re: 3.908707029165633e-02, Im: 2.062343172256057e-02},
+	{Re: 3.895979149048479e-02, Im: 2.086287724686960e-02},
+	{Re: 3.883104587518381e-02, Im: 2.110153729564202e-02},
+	{Re: 3.870083829295300e-02, Im: 2.133940288346190e-02},
+	{Re: 3.856917364603429e-02, Im: 2.157646505482430e-02},
+	{Re: 3.843605689152718e-02, Im: 2.181271488447245e-02},
+	{Re: 3.830149304120226e-02, Im: 2.204814347773380e-02},
+	{Re: 3.816548716131242e-02, Im: 2.228274197085487e-02},
+	{Re: 3.802804437240218e-02, Im: 2.251650153133501e-02},
+	{Re: 3.788916984911485e-02, Im: 2.274941335825885e-02},
+	{Re: 3.774886881999776e-02, Im: 2.298146868262778e-02},
+	{Re: 3.760714656730533e-02, Im: 2.321265876768999e-02},
+	{Re: 3.746400842680030e-02, Im: 2.344297490926944e-02},
+	{Re: 3.731945978755273e-02, Im: 2.367240843609359e-02},
+	{Re: 3.717350609173721e-02, Im: 2.390095071011981e-02},
+	{Re: 3.702615283442789e-02, Im: 2.412859312686067e-02},
+	{Re: 3.687740556339162e-02, Im: 2.435532711570784e-02},
+	{Re: 3.672726987887907e-02, Im: 2.458114414025478e-02},
+	{Re: 3.657575143341393e-02, Im: 2.480603569861817e-02},
+	{Re: 3.642285593158002e-02, Im: 2.502999332375793e-02},
+	{Re: 3.626858912980656e-02, Im: 2.525300858379606e-02},
+	{Re: 3.611295683615146e-02, Im: 2.547507308233408e-02},
+	{Re: 3.595596491008259e-02, Im: 2.569617845876910e-02},
+	{Re: 3.579761926225727e-02, Im: 2.591631638860870e-02},
+	{Re: 3.563792585429960e-02, Im: 2.613547858378423e-02},
+	{Re: 3.547689069857617e-02, Im: 2.635365679296290e-02},
+	{Re: 3.531451985796956e-02, Im: 2.657084280185848e-02},
+	{Re: 3.515081944565013e-02, Im: 2.678702843354045e-02},
+	{Re: 3.498579562484590e-02, Im: 2.700220554874200e-02},
+	{Re: 3.481945460861045e-02, Im: 2.721636604616636e-02},
+	{Re: 3.465180265958902e-02, Im: 2.742950186279182e-02},
+	{Re: 3.448284608978273e-02, Im: 2.764160497417536e-02},
+	{Re: 3.431259126031095e-02, Im: 2.785266739475472e-02},
+	{Re: 3.414104458117177e-02, Im: 2.806268117814906e-02},
+	{Re: 3.396821251100071e-02, Im: 2.827163841745814e-02},
+	{Re: 3.379410155682751e-02, Im: 2.847953124556000e-02},
+	{Re: 3.361871827383121e-02, Im: 2.868635183540716e-02},
+	{Re: 3.344206926509329e-02, Im: 2.889209240032129e-02},
+	{Re: 3.326416118134905e-02, Im: 2.909674519428644e-02},
+	{Re: 3.308500072073731e-02, Im: 2.930030251224058e-02},
+	{Re: 3.290459462854817e-02, Im: 2.950275669036572e-02},
+	{Re: 3.272294969696901e-02, Im: 2.970410010637650e-02},
+	{Re: 3.254007276482884e-02, Im: 2.990432517980710e-02},
+	{Re: 3.235597071734081e-02, Im: 3.010342437229666e-02},
+	{Re: 3.217065048584292e-02, Im: 3.030139018787314e-02},
+	{Re: 3.198411904753718e-02, Im: 3.049821517323546e-02},
+	{Re: 3.179638342522679e-02, Im: 3.069389191803417e-02},
+	{Re: 3.160745068705184e-02, Im: 3.088841305515042e-02},
+	{Re: 3.141732794622312e-02, Im: 3.108177126097334e-02},
+	{Re: 3.122602236075437e-02, Im: 3.127395925567578e-02},
+	{Re: 3.103354113319275e-02, Im: 3.146496980348835e-02},
+	{Re: 3.083989151034770e-02, Im: 3.165479571297190e-02},
+	{Re: 3.064508078301806e-02, Im: 3.184342983728822e-02},
+	{Re: 3.044911628571761e-02, Im: 3.203086507446914e-02},
+	{Re: 3.025200539639892e-02, Im: 3.221709436768393e-02},
+	{Re: 3.005375553617554e-02, Im: 3.240211070550494e-02},
+	{Re: 2.985437416904269e-02, Im: 3.258590712217165e-02},
+	{Re: 2.965386880159613e-02, Im: 3.276847669785284e-02},
+	{Re: 2.945224698274963e-02, Im: 3.294981255890716e-02},
+	{Re: 2.924951630345070e-02, Im: 3.312990787814195e-02},
+	{Re: 2.904568439639484e-02, Im: 3.330875587507023e-02},
+	{Re: 2.884075893573814e-02, Im: 3.348634981616600e-02},
+	{Re: 2.863474763680838e-02, Im: 3.366268301511775e-02},
+	{Re: 2.842765825581451e-02, Im: 3.383774883308021e-02},
+	{Re: 2.821949858955470e-02, Im: 3.401154067892428e-02},
+	{Re: 2.801027647512271e-02, Im: 3.418405200948518e-02},
+	{Re: 2.779999978961291e-02, Im: 3.435527632980883e-02},
+	{Re: 2.758867644982364e-02, Im: 3.452520719339634e-02},
+	{Re: 2.737631441195922e-02, Im: 3.469383820244675e-02},
+	{Re: 2.716292167133035e-02, Im: 3.486116300809788e-02},
+	{Re: 2.694850626205310e-02, Im: 3.502717531066536e-02},
+	{Re: 2.673307625674645e-02, Im: 3.519186885987983e-02},
+	{Re: 2.651663976622832e-02, Im: 3.535523745512225e-02},
+	{Re: 2.629920493921025e-02, Im: 3.551727494565735e-02},
+	{Re: 2.608077996199060e-02, Im: 3.567797523086519e-02},
+	{Re: 2.586137305814630e-02, Im: 3.583733226047085e-02},
+	{Re: 2.564099248822326e-02, Im: 3.599534003477226e-02},
+	{Re: 2.541964654942538e-02, Im: 3.615199260486601e-02},
+	{Re: 2.519734357530217e-02, Im: 3.630728407287136e-02},
+	{Re: 2.497409193543494e-02, Im: 3.646120859215234e-02},
+	{Re: 2.474990003512176e-02, Im: 3.661376036753778e-02},
+	{Re: 2.452477631506095e-02, Im: 3.676493365553957e-02},
+	{Re: 2.429872925103334e-02, Im: 3.691472276456884e-02},
+	{Re: 2.407176735358313e-02, Im: 3.706312205515032e-02},
+	{Re: 2.384389916769747e-02, Im: 3.721012594013458e-02},
+	{Re: 2.361513327248478e-02, Im: 3.735572888490844e-02},
+	{Re: 2.338547828085170e-02, Im: 3.749992540760333e-02},
+	{Re: 2.315494283917888e-02, Im: 3.764271007930167e-02},
+	{Re: 2.292353562699538e-02, Im: 3.778407752424126e-02},
+	{Re: 2.269126535665197e-02, Im: 3.792402242001772e-02},
+	{Re: 2.245814077299303e-02, Im: 3.806253949778481e-02},
+	{Re: 2.222417065302738e-02, Im: 3.819962354245284e-02},
+	{Re: 2.198936380559779e-02, Im: 3.833526939288501e-02},
+	{Re: 2.175372907104936e-02, Im: 3.846947194209172e-02},
+	{Re: 2.151727532089667e-02, Im: 3.860222613742284e-02},
+	{Re: 2.128001145748976e-02, Im: 3.873352698075795e-02},
+	{Re: 2.104194641367899e-02, Im: 3.886336952869453e-02},
+	{Re: 2.080308915247869e-02, Im: 3.899174889273400e-02},
+	{Re: 2.056344866672976e-02, Im: 3.911866023946590e-02},
+	{Re: 2.032303397876104e-02, Im: 3.924409879074973e-02},
+	{Re: 2.008185414004964e-02, Im: 3.936805982389495e-02},
+	{Re: 1.983991823088016e-02, Im: 3.949053867183872e-02},
+	{Re: 1.959723536000288e-02, Im: 3.961153072332162e-02},
+	{Re: 1.935381466429069e-02, Im: 3.973103142306133e-02},
+	{Re: 1.910966530839521e-02, Im: 3.984903627192403e-02},
+	{Re: 1.886479648440169e-02, Im: 3.996554082709386e-02},
+	{Re: 1.861921741148297e-02, Im: 4.008054070224015e-02},
+	{Re: 1.837293733555232e-02, Im: 4.019403156768264e-02},
+	{Re: 1.812596552891542e-02, Im: 4.030600915055434e-02},
+	{Re: 1.787831128992119e-02, Im: 4.041646923496258e-02},
+	{Re: 1.762998394261176e-02, Im: 4.052540766214761e-02},
+	{Re: 1.738099283637140e-02, Im: 4.063282033063920e-02},
+	{Re: 1.713134734557451e-02, Im: 4.073870319641112e-02},
+	{Re: 1.688105686923275e-02, Im: 4.084305227303329e-02},
+	{Re: 1.663013083064107e-02, Im: 4.094586363182198e-02},
+	{Re: 1.637857867702302e-02, Im: 4.104713340198761e-02},
+	{Re: 1.612640987917497e-02, Im: 4.114685777078060e-02},
+	{Re: 1.587363393110967e-02, Im: 4.124503298363482e-02},
+	{Re: 1.562026034969866e-02, Im: 4.134165534430899e-02},
+	{Re: 1.536629867431410e-02, Im: 4.143672121502585e-02},
+	{Re: 1.511175846646952e-02, Im: 4.153022701660909e-02},
+	{Re: 1.485664930945988e-02, Im: 4.162216922861812e-02},
+	{Re: 1.460098080800075e-02, Im: 4.171254438948065e-02},
+	{Re: 1.434476258786670e-02, Im: 4.180134909662295e-02},
+	{Re: 1.408800429552891e-02, Im: 4.188858000659797e-02},
+	{Re: 1.383071559779197e-02, Im: 4.197423383521126e-02},
+	{Re: 1.357290618142993e-02, Im: 4.205830735764459e-02},
+	{Re: 1.331458575282159e-02, Im: 4.214079740857736e-02},
+	{Re: 1.305576403758509e-02, Im: 4.222170088230577e-02},
+	{Re: 1.279645078021173e-02, Im: 4.230101473285976e-02},
+	{Re: 1.253665574369907e-02, Im: 4.237873597411770e-02},
+	{Re: 1.227638870918341e-02, Im: 4.245486167991876e-02},
+	{Re: 1.201565947557149e-02, Im: 4.252938898417316e-02},
+	{Re: 1.175447785917159e-02, Im: 4.260231508097002e-02},
+	{Re: 1.149285369332395e-02, Im: 4.267363722468299e-02},
+	{Re: 1.123079682803055e-02, Im: 4.274335273007371e-02},
+	{Re: 1.096831712958424e-02, Im: 4.281145897239277e-02},
+	{Re: 1.070542448019733e-02, Im: 4.287795338747865e-02},
+	{Re: 1.044212877762946e-02, Im: 4.294283347185422e-02},
+	{Re: 1.017843993481504e-02, Im: 4.300609678282095e-02},
+	{Re: 9.914367879489940e-03, Im: 4.306774093855097e-02},
+	{Re: 9.649922553817804e-03, Im: 4.312776361817662e-02},
+	{Re: 9.385113914015676e-03, Im: 4.318616256187796e-02},
+	{Re: 9.119951929979173e-03, Im: 4.324293557096776e-02},
+	{Re: 8.854446584907114e-03, Im: 4.329808050797433e-02},
+	{Re: 8.588607874925698e-03, Im: 4.335159529672195e-02},
+	{Re: 8.322445808712090e-03, Im: 4.340347792240906e-02},
+	{Re: 8.055970407117649e-03, Im: 4.345372643168414e-02},
+	{Re: 7.789191702790634e-03, Im: 4.350233893271921e-02},
+	{Re: 7.522119739798478e-03, Im: 4.354931359528109e-02},
+	{Re: 7.254764573249636e-03, Im: 4.359464865080028e-02},
+	{Re: 6.987136268915022e-03, Im: 4.363834239243755e-02},
+	{Re: 6.719244902849028e-03, Im: 4.368039317514825e-02},
+	{Re: 6.451100561010175e-03, Im: 4.372079941574416e-02},
+	{Re: 6.182713338881377e-03, Im: 4.375955959295316e-02},
+	{Re: 5.914093341089844e-03, Im: 4.379667224747649e-02},
+	{Re: 5.645250681026688e-03, Im: 4.383213598204368e-02},
+	{Re: 5.376195480466099e-03, Im: 4.386594946146517e-02},
+	{Re: 5.106937869184310e-03, Im: 4.389811141268257e-02},
+	{Re: 4.837487984578198e-03, Im: 4.392862062481660e-02},
+	{Re: 4.567855971283626e-03, Im: 4.395747594921266e-02},
+	{Re: 4.298051980793497e-03, Im: 4.398467629948408e-02},
+	{Re: 4.028086171075562e-03, Im: 4.401022065155308e-02},
+	{Re: 3.757968706189968e-03, Im: 4.403410804368924e-02},
+	{Re: 3.487709755906599e-03, Im: 4.405633757654571e-02},
+	{Re: 3.217319495322171e-03, Im: 4.407690841319318e-02},
+	{Re: 2.946808104477191e-03, Im: 4.409581977915127e-02},
+	{Re: 2.676185767972620e-03, Im: 4.411307096241772e-02},
+	{Re: 2.405462674586471e-03, Im: 4.412866131349524e-02},
+	{Re: 2.134649016890197e-03, Im: 4.414259024541593e-02},
+	{Re: 1.863754990864946e-03, Im: 4.415485723376338e-02},
+	{Re: 1.592790795517687e-03, Im: 4.416546181669240e-02},
+	{Re: 1.321766632497226e-03, Im: 4.417440359494644e-02},
+	{Re: 1.050692705710116e-03, Im: 4.418168223187261e-02},
+	{Re: 7.795792209364912e-04, Im: 4.418729745343434e-02},
+	{Re: 5.084363854458175e-04, Im: 4.419124904822170e-02},
+	{Re: 2.372744076125916e-04, Im: 4.419353686745939e-02},
+}
+
+// mdctTab1920 contains 480 complex twiddle factors for N=1920 MDCT.
+var mdctTab1920 = [480]fft.Complex{
+	{Re: 3.227485851809719e-02, Im: 1.320240417598891e-05},
+	{Re: 3.227464249450500e-02, Im: 1.188213724829469e-04},
+	{Re: 3.227408083542091e-02, Im: 2.244390683084658e-04},
+	{Re: 3.227317354685984e-02, Im: 3.300543605718758e-04},
+	{Re: 3.227192063853813e-02, Im: 4.356661182182475e-04},
+	{Re: 3.227032212387340e-02, Im: 5.412732102305040e-04},
+	{Re: 3.226837801998448e-02, Im: 6.468745056415342e-04},
+	{Re: 3.226608834769115e-02, Im: 7.524688735463032e-04},
+	{Re: 3.226345313151396e-02, Im: 8.580551831139646e-04},
+	{Re: 3.226047239967396e-02, Im: 9.636323035999703e-04},
+	{Re: 3.225714618409239e-02, Im: 1.069199104358180e-03},
+	{Re: 3.225347452039035e-02, Im: 1.174754454852969e-03},
+	{Re: 3.224945744788841e-02, Im: 1.280297224671335e-03},
+	{Re: 3.224509500960619e-02, Im: 1.385826283535007e-03},
+	{Re: 3.224038725226189e-02, Im: 1.491340501312546e-03},
+	{Re: 3.223533422627183e-02, Im: 1.596838748031448e-03},
+	{Re: 3.222993598574983e-02, Im: 1.702319893890247e-03},
+	{Re: 3.222419258850673e-02, Im: 1.807782809270614e-03},
+	{Re: 3.221810409604969e-02, Im: 1.913226364749454e-03},
+	{Re: 3.221167057358158e-02, Im: 2.018649431111000e-03},
+	{Re: 3.220489209000026e-02, Im: 2.124050879358907e-03},
+	{Re: 3.219776871789783e-02, Im: 2.229429580728344e-03},
+	{Re: 3.219030053355988e-02, Im: 2.334784406698078e-03},
+	{Re: 3.218248761696469e-02, Im: 2.440114229002567e-03},
+	{Re: 3.217433005178230e-02, Im: 2.545417919644033e-03},
+	{Re: 3.216582792537368e-02, Im: 2.650694350904551e-03},
+	{Re: 3.215698132878977e-02, Im: 2.755942395358119e-03},
+	{Re: 3.214779035677053e-02, Im: 2.861160925882736e-03},
+	{Re: 3.213825510774387e-02, Im: 2.966348815672475e-03},
+	{Re: 3.212837568382465e-02, Im: 3.071504938249541e-03},
+	{Re: 3.211815219081356e-02, Im: 3.176628167476343e-03},
+	{Re: 3.210758473819601e-02, Im: 3.281717377567552e-03},
+	{Re: 3.209667343914092e-02, Im: 3.386771443102157e-03},
+	{Re: 3.208541841049953e-02, Im: 3.491789239035513e-03},
+	{Re: 3.207381977280416e-02, Im: 3.596769640711398e-03},
+	{Re: 3.206187765026691e-02, Im: 3.701711523874050e-03},
+	{Re: 3.204959217077830e-02, Im: 3.806613764680210e-03},
+	{Re: 3.203696346590595e-02, Im: 3.911475239711157e-03},
+	{Re: 3.202399167089316e-02, Im: 4.016294825984738e-03},
+	{Re: 3.201067692465741e-02, Im: 4.121071400967394e-03},
+	{Re: 3.199701936978896e-02, Im: 4.225803842586187e-03},
+	{Re: 3.198301915254924e-02, Im: 4.330491029240806e-03},
+	{Re: 3.196867642286932e-02, Im: 4.435131839815587e-03},
+	{Re: 3.195399133434831e-02, Im: 4.539725153691518e-03},
+	{Re: 3.193896404425173e-02, Im: 4.644269850758234e-03},
+	{Re: 3.192359471350976e-02, Im: 4.748764811426022e-03},
+	{Re: 3.190788350671560e-02, Im: 4.853208916637804e-03},
+	{Re: 3.189183059212366e-02, Im: 4.957601047881123e-03},
+	{Re: 3.187543614164774e-02, Im: 5.061940087200120e-03},
+	{Re: 3.185870033085924e-02, Im: 5.166224917207510e-03},
+	{Re: 3.184162333898525e-02, Im: 5.270454421096544e-03},
+	{Re: 3.182420534890663e-02, Im: 5.374627482652975e-03},
+	{Re: 3.180644654715607e-02, Im: 5.478742986267004e-03},
+	{Re: 3.178834712391607e-02, Im: 5.582799816945231e-03},
+	{Re: 3.176990727301689e-02, Im: 5.686796860322603e-03},
+	{Re: 3.175112719193453e-02, Im: 5.790733002674331e-03},
+	{Re: 3.173200708178859e-02, Im: 5.894607130927836e-03},
+	{Re: 3.171254714734006e-02, Im: 5.998418132674653e-03},
+	{Re: 3.169274759698922e-02, Im: 6.102164896182355e-03},
+	{Re: 3.167260864277335e-02, Im: 6.205846310406456e-03},
+	{Re: 3.165213050036445e-02, Im: 6.309461265002305e-03},
+	{Re: 3.163131338906700e-02, Im: 6.413008650336980e-03},
+	{Re: 3.161015753181553e-02, Im: 6.516487357501178e-03},
+	{Re: 3.158866315517225e-02, Im: 6.619896278321075e-03},
+	{Re: 3.156683048932470e-02, Im: 6.723234305370210e-03},
+	{Re: 3.154465976808318e-02, Im: 6.826500331981332e-03},
+	{Re: 3.152215122887829e-02, Im: 6.929693252258260e-03},
+	{Re: 3.149930511275841e-02, Im: 7.032811961087725e-03},
+	{Re: 3.147612166438708e-02, Im: 7.135855354151194e-03},
+	{Re: 3.145260113204044e-02, Im: 7.238822327936717e-03},
+	{Re: 3.142874376760445e-02, Im: 7.341711779750727e-03},
+	{Re: 3.140454982657236e-02, Im: 7.444522607729855e-03},
+	{Re: 3.138001956804180e-02, Im: 7.547253710852729e-03},
+	{Re: 3.135515325471216e-02, Im: 7.649903988951771e-03},
+	{Re: 3.132995115288166e-02, Im: 7.752472342724969e-03},
+	{Re: 3.130441353244458e-02, Im: 7.854957673747658e-03},
+	{Re: 3.127854066688830e-02, Im: 7.957358884484279e-03},
+	{Re: 3.125233283329045e-02, Im: 8.059674878300130e-03},
+	{Re: 3.122579031231585e-02, Im: 8.161904559473123e-03},
+	{Re: 3.119891338821358e-02, Im: 8.264046833205498e-03},
+	{Re: 3.117170234881391e-02, Im: 8.366100605635567e-03},
+	{Re: 3.114415748552521e-02, Im: 8.468064783849413e-03},
+	{Re: 3.111627909333083e-02, Im: 8.569938275892610e-03},
+	{Re: 3.108806747078596e-02, Im: 8.671719990781895e-03},
+	{Re: 3.105952292001441e-02, Im: 8.773408838516883e-03},
+	{Re: 3.103064574670538e-02, Im: 8.875003730091698e-03},
+	{Re: 3.100143626011021e-02, Im: 8.976503577506683e-03},
+	{Re: 3.097189477303902e-02, Im: 9.077907293780012e-03},
+	{Re: 3.094202160185742e-02, Im: 9.179213792959351e-03},
+	{Re: 3.091181706648306e-02, Im: 9.280421990133479e-03},
+	{Re: 3.088128149038225e-02, Im: 9.381530801443919e-03},
+	{Re: 3.085041520056648e-02, Im: 9.482539144096531e-03},
+	{Re: 3.081921852758892e-02, Im: 9.583445936373116e-03},
+	{Re: 3.078769180554087e-02, Im: 9.684250097642993e-03},
+	{Re: 3.075583537204819e-02, Im: 9.784950548374581e-03},
+	{Re: 3.072364956826770e-02, Im: 9.885546210146954e-03},
+	{Re: 3.069113473888348e-02, Im: 9.986036005661397e-03},
+	{Re: 3.065829123210326e-02, Im: 1.008641885875293e-02},
+	{Re: 3.062511939965459e-02, Im: 1.018669369440184e-02},
+	{Re: 3.059161959678116e-02, Im: 1.028685943874520e-02},
+	{Re: 3.055779218223894e-02, Im: 1.038691501908836e-02},
+	{Re: 3.052363751829235e-02, Im: 1.048685936391645e-02},
+	{Re: 3.048915597071042e-02, Im: 1.058669140290581e-02},
+	{Re: 3.045434790876281e-02, Im: 1.068641006693552e-02},
+	{Re: 3.041921370521590e-02, Im: 1.078601428809879e-02},
+	{Re: 3.038375373632880e-02, Im: 1.088550299971446e-02},
+	{Re: 3.034796838184926e-02, Im: 1.098487513633832e-02},
+	{Re: 3.031185802500970e-02, Im: 1.108412963377465e-02},
+	{Re: 3.027542305252302e-02, Im: 1.118326542908751e-02},
+	{Re: 3.023866385457851e-02, Im: 1.128228146061219e-02},
+	{Re: 3.020158082483764e-02, Im: 1.138117666796652e-02},
+	{Re: 3.016417436042988e-02, Im: 1.147994999206229e-02},
+	{Re: 3.012644486194840e-02, Im: 1.157860037511656e-02},
+	{Re: 3.008839273344581e-02, Im: 1.167712676066298e-02},
+	{Re: 3.005001838242986e-02, Im: 1.177552809356311e-02},
+	{Re: 3.001132221985899e-02, Im: 1.187380332001774e-02},
+	{Re: 2.997230466013804e-02, Im: 1.197195138757814e-02},
+	{Re: 2.993296612111371e-02, Im: 1.206997124515736e-02},
+	{Re: 2.989330702407016e-02, Im: 1.216786184304146e-02},
+	{Re: 2.985332779372444e-02, Im: 1.226562213290081e-02},
+	{Re: 2.981302885822201e-02, Im: 1.236325106780122e-02},
+	{Re: 2.977241064913208e-02, Im: 1.246074760221525e-02},
+	{Re: 2.973147360144304e-02, Im: 1.255811069203333e-02},
+	{Re: 2.969021815355779e-02, Im: 1.265533929457500e-02},
+	{Re: 2.964864474728902e-02, Im: 1.275243236860003e-02},
+	{Re: 2.960675382785452e-02, Im: 1.284938887431961e-02},
+	{Re: 2.956454584387237e-02, Im: 1.294620777340745e-02},
+	{Re: 2.952202124735618e-02, Im: 1.304288802901093e-02},
+	{Re: 2.947918049371022e-02, Im: 1.313942860576218e-02},
+	{Re: 2.943602404172453e-02, Im: 1.323582846978919e-02},
+	{Re: 2.939255235357005e-02, Im: 1.333208658872686e-02},
+	{Re: 2.934876589479362e-02, Im: 1.342820193172806e-02},
+	{Re: 2.930466513431305e-02, Im: 1.352417346947470e-02},
+	{Re: 2.926025054441206e-02, Im: 1.362000017418870e-02},
+	{Re: 2.921552260073521e-02, Im: 1.371568101964305e-02},
+	{Re: 2.917048178228285e-02, Im: 1.381121498117276e-02},
+	{Re: 2.912512857140596e-02, Im: 1.390660103568585e-02},
+	{Re: 2.907946345380099e-02, Im: 1.400183816167432e-02},
+	{Re: 2.903348691850466e-02, Im: 1.409692533922505e-02},
+	{Re: 2.898719945788873e-02, Im: 1.419186155003076e-02},
+	{Re: 2.894060156765472e-02, Im: 1.428664577740092e-02},
+	{Re: 2.889369374682859e-02, Im: 1.438127700627259e-02},
+	{Re: 2.884647649775542e-02, Im: 1.447575422322134e-02},
+	{Re: 2.879895032609402e-02, Im: 1.457007641647208e-02},
+	{Re: 2.875111574081149e-02, Im: 1.466424257590989e-02},
+	{Re: 2.870297325417782e-02, Im: 1.475825169309086e-02},
+	{Re: 2.865452338176036e-02, Im: 1.485210276125287e-02},
+	{Re: 2.860576664241833e-02, Im: 1.494579477532638e-02},
+	{Re: 2.855670355829722e-02, Im: 1.503932673194520e-02},
+	{Re: 2.850733465482325e-02, Im: 1.513269762945720e-02},
+	{Re: 2.845766046069773e-02, Im: 1.522590646793509e-02},
+	{Re: 2.840768150789134e-02, Im: 1.531895224918710e-02},
+	{Re: 2.835739833163855e-02, Im: 1.541183397676765e-02},
+	{Re: 2.830681147043174e-02, Im: 1.550455065598807e-02},
+	{Re: 2.825592146601559e-02, Im: 1.559710129392722e-02},
+	{Re: 2.820472886338114e-02, Im: 1.568948489944210e-02},
+	{Re: 2.815323421076003e-02, Im: 1.578170048317853e-02},
+	{Re: 2.810143805961864e-02, Im: 1.587374705758169e-02},
+	{Re: 2.804934096465212e-02, Im: 1.596562363690672e-02},
+	{Re: 2.799694348377852e-02, Im: 1.605732923722927e-02},
+	{Re: 2.794424617813275e-02, Im: 1.614886287645603e-02},
+	{Re: 2.789124961206062e-02, Im: 1.624022357433526e-02},
+	{Re: 2.783795435311277e-02, Im: 1.633141035246730e-02},
+	{Re: 2.778436097203862e-02, Im: 1.642242223431499e-02},
+	{Re: 2.773047004278021e-02, Im: 1.651325824521422e-02},
+	{Re: 2.767628214246612e-02, Im: 1.660391741238427e-02},
+	{Re: 2.762179785140520e-02, Im: 1.669439876493831e-02},
+	{Re: 2.756701775308045e-02, Im: 1.678470133389373e-02},
+	{Re: 2.751194243414270e-02, Im: 1.687482415218258e-02},
+	{Re: 2.745657248440439e-02, Im: 1.696476625466187e-02},
+	{Re: 2.740090849683318e-02, Im: 1.705452667812395e-02},
+	{Re: 2.734495106754567e-02, Im: 1.714410446130678e-02},
+	{Re: 2.728870079580096e-02, Im: 1.723349864490430e-02},
+	{Re: 2.723215828399429e-02, Im: 1.732270827157659e-02},
+	{Re: 2.717532413765050e-02, Im: 1.741173238596023e-02},
+	{Re: 2.711819896541767e-02, Im: 1.750057003467848e-02},
+	{Re: 2.706078337906049e-02, Im: 1.758922026635150e-02},
+	{Re: 2.700307799345375e-02, Im: 1.767768213160651e-02},
+	{Re: 2.694508342657579e-02, Im: 1.776595468308802e-02},
+	{Re: 2.688680029950181e-02, Im: 1.785403697546792e-02},
+	{Re: 2.682822923639729e-02, Im: 1.794192806545562e-02},
+	{Re: 2.676937086451126e-02, Im: 1.802962701180815e-02},
+	{Re: 2.671022581416959e-02, Im: 1.811713287534026e-02},
+	{Re: 2.665079471876826e-02, Im: 1.820444471893445e-02},
+	{Re: 2.659107821476655e-02, Im: 1.829156160755102e-02},
+	{Re: 2.653107694168023e-02, Im: 1.837848260823808e-02},
+	{Re: 2.647079154207476e-02, Im: 1.846520679014155e-02},
+	{Re: 2.641022266155831e-02, Im: 1.855173322451512e-02},
+	{Re: 2.634937094877495e-02, Im: 1.863806098473018e-02},
+	{Re: 2.628823705539761e-02, Im: 1.872418914628578e-02},
+	{Re: 2.622682163612120e-02, Im: 1.881011678681850e-02},
+	{Re: 2.616512534865550e-02, Im: 1.889584298611236e-02},
+	{Re: 2.610314885371820e-02, Im: 1.898136682610861e-02},
+	{Re: 2.604089281502776e-02, Im: 1.906668739091566e-02},
+	{Re: 2.597835789929634e-02, Im: 1.915180376681878e-02},
+	{Re: 2.591554477622264e-02, Im: 1.923671504228999e-02},
+	{Re: 2.585245411848475e-02, Im: 1.932142030799773e-02},
+	{Re: 2.578908660173293e-02, Im: 1.940591865681666e-02},
+	{Re: 2.572544290458239e-02, Im: 1.949020918383736e-02},
+	{Re: 2.566152370860596e-02, Im: 1.957429098637600e-02},
+	{Re: 2.559732969832690e-02, Im: 1.965816316398402e-02},
+	{Re: 2.553286156121149e-02, Im: 1.974182481845777e-02},
+	{Re: 2.546811998766167e-02, Im: 1.982527505384817e-02},
+	{Re: 2.540310567100769e-02, Im: 1.990851297647023e-02},
+	{Re: 2.533781930750065e-02, Im: 1.999153769491266e-02},
+	{Re: 2.527226159630505e-02, Im: 2.007434832004743e-02},
+	{Re: 2.520643323949131e-02, Im: 2.015694396503930e-02},
+	{Re: 2.514033494202824e-02, Im: 2.023932374535523e-02},
+	{Re: 2.507396741177551e-02, Im: 2.032148677877399e-02},
+	{Re: 2.500733135947605e-02, Im: 2.040343218539548e-02},
+	{Re: 2.494042749874844e-02, Im: 2.048515908765024e-02},
+	{Re: 2.487325654607928e-02, Im: 2.056666661030880e-02},
+	{Re: 2.480581922081550e-02, Im: 2.064795388049108e-02},
+	{Re: 2.473811624515669e-02, Im: 2.072902002767572e-02},
+	{Re: 2.467014834414732e-02, Im: 2.080986418370941e-02},
+	{Re: 2.460191624566901e-02, Im: 2.089048548281618e-02},
+	{Re: 2.453342068043271e-02, Im: 2.097088306160671e-02},
+	{Re: 2.446466238197090e-02, Im: 2.105105605908749e-02},
+	{Re: 2.439564208662971e-02, Im: 2.113100361667016e-02},
+	{Re: 2.432636053356106e-02, Im: 2.121072487818059e-02},
+	{Re: 2.425681846471472e-02, Im: 2.129021898986812e-02},
+	{Re: 2.418701662483038e-02, Im: 2.136948510041468e-02},
+	{Re: 2.411695576142967e-02, Im: 2.144852236094391e-02},
+	{Re: 2.404663662480814e-02, Im: 2.152732992503024e-02},
+	{Re: 2.397605996802725e-02, Im: 2.160590694870798e-02},
+	{Re: 2.390522654690630e-02, Im: 2.168425259048032e-02},
+	{Re: 2.383413712001432e-02, Im: 2.176236601132840e-02},
+	{Re: 2.376279244866197e-02, Im: 2.184024637472023e-02},
+	{Re: 2.369119329689335e-02, Im: 2.191789284661968e-02},
+	{Re: 2.361934043147785e-02, Im: 2.199530459549542e-02},
+	{Re: 2.354723462190195e-02, Im: 2.207248079232982e-02},
+	{Re: 2.347487664036092e-02, Im: 2.214942061062780e-02},
+	{Re: 2.340226726175062e-02, Im: 2.222612322642573e-02},
+	{Re: 2.332940726365917e-02, Im: 2.230258781830022e-02},
+	{Re: 2.325629742635859e-02, Im: 2.237881356737692e-02},
+	{Re: 2.318293853279653e-02, Im: 2.245479965733928e-02},
+	{Re: 2.310933136858779e-02, Im: 2.253054527443734e-02},
+	{Re: 2.303547672200597e-02, Im: 2.260604960749639e-02},
+	{Re: 2.296137538397498e-02, Im: 2.268131184792569e-02},
+	{Re: 2.288702814806065e-02, Im: 2.275633118972709e-02},
+	{Re: 2.281243581046212e-02, Im: 2.283110682950373e-02},
+	{Re: 2.273759917000341e-02, Im: 2.290563796646857e-02},
+	{Re: 2.266251902812481e-02, Im: 2.297992380245302e-02},
+	{Re: 2.258719618887434e-02, Im: 2.305396354191546e-02},
+	{Re: 2.251163145889907e-02, Im: 2.312775639194975e-02},
+	{Re: 2.243582564743656e-02, Im: 2.320130156229375e-02},
+	{Re: 2.235977956630614e-02, Im: 2.327459826533779e-02},
+	{Re: 2.228349402990026e-02, Im: 2.334764571613305e-02},
+	{Re: 2.220696985517572e-02, Im: 2.342044313240002e-02},
+	{Re: 2.213020786164496e-02, Im: 2.349298973453686e-02},
+	{Re: 2.205320887136724e-02, Im: 2.356528474562775e-02},
+	{Re: 2.197597370893991e-02, Im: 2.363732739145118e-02},
+	{Re: 2.189850320148949e-02, Im: 2.370911690048832e-02},
+	{Re: 2.182079817866289e-02, Im: 2.378065250393119e-02},
+	{Re: 2.174285947261846e-02, Im: 2.385193343569095e-02},
+	{Re: 2.166468791801715e-02, Im: 2.392295893240609e-02},
+	{Re: 2.158628435201348e-02, Im: 2.399372823345060e-02},
+	{Re: 2.150764961424667e-02, Im: 2.406424058094211e-02},
+	{Re: 2.142878454683160e-02, Im: 2.413449521975004e-02},
+	{Re: 2.134968999434975e-02, Im: 2.420449139750366e-02},
+	{Re: 2.127036680384026e-02, Im: 2.427422836460012e-02},
+	{Re: 2.119081582479075e-02, Im: 2.434370537421254e-02},
+	{Re: 2.111103790912832e-02, Im: 2.441292168229795e-02},
+	{Re: 2.103103391121033e-02, Im: 2.448187654760532e-02},
+	{Re: 2.095080468781534e-02, Im: 2.455056923168343e-02},
+	{Re: 2.087035109813387e-02, Im: 2.461899899888883e-02},
+	{Re: 2.078967400375922e-02, Im: 2.468716511639367e-02},
+	{Re: 2.070877426867828e-02, Im: 2.475506685419361e-02},
+	{Re: 2.062765275926220e-02, Im: 2.482270348511558e-02},
+	{Re: 2.054631034425717e-02, Im: 2.489007428482561e-02},
+	{Re: 2.046474789477512e-02, Im: 2.495717853183656e-02},
+	{Re: 2.038296628428436e-02, Im: 2.502401550751585e-02},
+	{Re: 2.030096638860021e-02, Im: 2.509058449609317e-02},
+	{Re: 2.021874908587569e-02, Im: 2.515688478466814e-02},
+	{Re: 2.013631525659207e-02, Im: 2.522291566321792e-02},
+	{Re: 2.005366578354941e-02, Im: 2.528867642460486e-02},
+	{Re: 1.997080155185719e-02, Im: 2.535416636458405e-02},
+	{Re: 1.988772344892474e-02, Im: 2.541938478181082e-02},
+	{Re: 1.980443236445182e-02, Im: 2.548433097784832e-02},
+	{Re: 1.972092919041902e-02, Im: 2.554900425717499e-02},
+	{Re: 1.963721482107823e-02, Im: 2.561340392719195e-02},
+	{Re: 1.955329015294311e-02, Im: 2.567752929823048e-02},
+	{Re: 1.946915608477943e-02, Im: 2.574137968355936e-02},
+	{Re: 1.938481351759546e-02, Im: 2.580495439939228e-02},
+	{Re: 1.930026335463233e-02, Im: 2.586825276489509e-02},
+	{Re: 1.921550650135438e-02, Im: 2.593127410219317e-02},
+	{Re: 1.913054386543939e-02, Im: 2.599401773637861e-02},
+	{Re: 1.904537635676895e-02, Im: 2.605648299551750e-02},
+	{Re: 1.896000488741865e-02, Im: 2.611866921065710e-02},
+	{Re: 1.887443037164833e-02, Im: 2.618057571583302e-02},
+	{Re: 1.878865372589233e-02, Im: 2.624220184807629e-02},
+	{Re: 1.870267586874958e-02, Im: 2.630354694742058e-02},
+	{Re: 1.861649772097388e-02, Im: 2.636461035690914e-02},
+	{Re: 1.853012020546395e-02, Im: 2.642539142260192e-02},
+	{Re: 1.844354424725358e-02, Im: 2.648588949358253e-02},
+	{Re: 1.835677077350174e-02, Im: 2.654610392196525e-02},
+	{Re: 1.826980071348261e-02, Im: 2.660603406290191e-02},
+	{Re: 1.818263499857567e-02, Im: 2.666567927458885e-02},
+	{Re: 1.809527456225569e-02, Im: 2.672503891827379e-02},
+	{Re: 1.800772034008277e-02, Im: 2.678411235826261e-02},
+	{Re: 1.791997326969228e-02, Im: 2.684289896192624e-02},
+	{Re: 1.783203429078485e-02, Im: 2.690139809970738e-02},
+	{Re: 1.774390434511629e-02, Im: 2.695960914512727e-02},
+	{Re: 1.765558437648753e-02, Im: 2.701753147479236e-02},
+	{Re: 1.756707533073446e-02, Im: 2.707516446840106e-02},
+	{Re: 1.747837815571786e-02, Im: 2.713250750875030e-02},
+	{Re: 1.738949380131323e-02, Im: 2.718955998174217e-02},
+	{Re: 1.730042321940057e-02, Im: 2.724632127639054e-02},
+	{Re: 1.721116736385427e-02, Im: 2.730279078482755e-02},
+	{Re: 1.712172719053283e-02, Im: 2.735896790231012e-02},
+	{Re: 1.703210365726866e-02, Im: 2.741485202722645e-02},
+	{Re: 1.694229772385778e-02, Im: 2.747044256110246e-02},
+	{Re: 1.685231035204961e-02, Im: 2.752573890860821e-02},
+	{Re: 1.676214250553660e-02, Im: 2.758074047756423e-02},
+	{Re: 1.667179514994393e-02, Im: 2.763544667894790e-02},
+	{Re: 1.658126925281921e-02, Im: 2.768985692689976e-02},
+	{Re: 1.649056578362207e-02, Im: 2.774397063872977e-02},
+	{Re: 1.639968571371378e-02, Im: 2.779778723492354e-02},
+	{Re: 1.630863001634690e-02, Im: 2.785130613914858e-02},
+	{Re: 1.621739966665477e-02, Im: 2.790452677826041e-02},
+	{Re: 1.612599564164116e-02, Im: 2.795744858230874e-02},
+	{Re: 1.603441892016974e-02, Im: 2.801007098454356e-02},
+	{Re: 1.594267048295361e-02, Im: 2.806239342142124e-02},
+	{Re: 1.585075131254485e-02, Im: 2.811441533261050e-02},
+	{Re: 1.575866239332391e-02, Im: 2.816613616099846e-02},
+	{Re: 1.566640471148913e-02, Im: 2.821755535269662e-02},
+	{Re: 1.557397925504618e-02, Im: 2.826867235704673e-02},
+	{Re: 1.548138701379741e-02, Im: 2.831948662662675e-02},
+	{Re: 1.538862897933138e-02, Im: 2.836999761725667e-02},
+	{Re: 1.529570614501208e-02, Im: 2.842020478800436e-02},
+	{Re: 1.520261950596844e-02, Im: 2.847010760119136e-02},
+	{Re: 1.510937005908357e-02, Im: 2.851970552239864e-02},
+	{Re: 1.501595880298412e-02, Im: 2.856899802047230e-02},
+	{Re: 1.492238673802960e-02, Im: 2.861798456752929e-02},
+	{Re: 1.482865486630167e-02, Im: 2.866666463896304e-02},
+	{Re: 1.473476419159334e-02, Im: 2.871503771344912e-02},
+	{Re: 1.464071571939833e-02, Im: 2.876310327295073e-02},
+	{Re: 1.454651045690021e-02, Im: 2.881086080272436e-02},
+	{Re: 1.445214941296167e-02, Im: 2.885830979132524e-02},
+	{Re: 1.435763359811366e-02, Im: 2.890544973061281e-02},
+	{Re: 1.426296402454464e-02, Im: 2.895228011575620e-02},
+	{Re: 1.416814170608968e-02, Im: 2.899880044523960e-02},
+	{Re: 1.407316765821963e-02, Im: 2.904501022086767e-02},
+	{Re: 1.397804289803025e-02, Im: 2.909090894777083e-02},
+	{Re: 1.388276844423128e-02, Im: 2.913649613441058e-02},
+	{Re: 1.378734531713560e-02, Im: 2.918177129258478e-02},
+	{Re: 1.369177453864822e-02, Im: 2.922673393743288e-02},
+	{Re: 1.359605713225541e-02, Im: 2.927138358744105e-02},
+	{Re: 1.350019412301369e-02, Im: 2.931571976444742e-02},
+	{Re: 1.340418653753887e-02, Im: 2.935974199364715e-02},
+	{Re: 1.330803540399507e-02, Im: 2.940344980359754e-02},
+	{Re: 1.321174175208366e-02, Im: 2.944684272622306e-02},
+	{Re: 1.311530661303231e-02, Im: 2.948992029682036e-02},
+	{Re: 1.301873101958388e-02, Im: 2.953268205406327e-02},
+	{Re: 1.292201600598538e-02, Im: 2.957512754000774e-02},
+	{Re: 1.282516260797692e-02, Im: 2.961725630009669e-02},
+	{Re: 1.272817186278056e-02, Im: 2.965906788316497e-02},
+	{Re: 1.263104480908929e-02, Im: 2.970056184144409e-02},
+	{Re: 1.253378248705580e-02, Im: 2.974173773056712e-02},
+	{Re: 1.243638593828142e-02, Im: 2.978259510957335e-02},
+	{Re: 1.233885620580495e-02, Im: 2.982313354091309e-02},
+	{Re: 1.224119433409147e-02, Im: 2.986335259045229e-02},
+	{Re: 1.214340136902114e-02, Im: 2.990325182747726e-02},
+	{Re: 1.204547835787809e-02, Im: 2.994283082469920e-02},
+	{Re: 1.194742634933907e-02, Im: 2.998208915825889e-02},
+	{Re: 1.184924639346233e-02, Im: 3.002102640773107e-02},
+	{Re: 1.175093954167631e-02, Im: 3.005964215612911e-02},
+	{Re: 1.165250684676842e-02, Im: 3.009793598990937e-02},
+	{Re: 1.155394936287375e-02, Im: 3.013590749897563e-02},
+	{Re: 1.145526814546376e-02, Im: 3.017355627668356e-02},
+	{Re: 1.135646425133501e-02, Im: 3.021088191984498e-02},
+	{Re: 1.125753873859783e-02, Im: 3.024788402873225e-02},
+	{Re: 1.115849266666500e-02, Im: 3.028456220708250e-02},
+	{Re: 1.105932709624035e-02, Im: 3.032091606210192e-02},
+	{Re: 1.096004308930749e-02, Im: 3.035694520446993e-02},
+	{Re: 1.086064170911835e-02, Im: 3.039264924834335e-02},
+	{Re: 1.076112402018189e-02, Im: 3.042802781136054e-02},
+	{Re: 1.066149108825257e-02, Im: 3.046308051464555e-02},
+	{Re: 1.056174398031906e-02, Im: 3.049780698281207e-02},
+	{Re: 1.046188376459276e-02, Im: 3.053220684396752e-02},
+	{Re: 1.036191151049633e-02, Im: 3.056627972971703e-02},
+	{Re: 1.026182828865232e-02, Im: 3.060002527516736e-02},
+	{Re: 1.016163517087162e-02, Im: 3.063344311893084e-02},
+	{Re: 1.006133323014202e-02, Im: 3.066653290312921e-02},
+	{Re: 9.960923540616725e-03, Im: 3.069929427339746e-02},
+	{Re: 9.860407177602856e-03, Im: 3.073172687888763e-02},
+	{Re: 9.759785217549881e-03, Im: 3.076383037227258e-02},
+	{Re: 9.659058738038183e-03, Im: 3.079560440974969e-02},
+	{Re: 9.558228817767411e-03, Im: 3.082704865104454e-02},
+	{Re: 9.457296536545009e-03, Im: 3.085816275941457e-02},
+	{Re: 9.356262975274638e-03, Im: 3.088894640165267e-02},
+	{Re: 9.255129215944541e-03, Im: 3.091939924809077e-02},
+	{Re: 9.153896341616062e-03, Im: 3.094952097260335e-02},
+	{Re: 9.052565436411933e-03, Im: 3.097931125261093e-02},
+	{Re: 8.951137585504747e-03, Im: 3.100876976908356e-02},
+	{Re: 8.849613875105298e-03, Im: 3.103789620654419e-02},
+	{Re: 8.747995392450972e-03, Im: 3.106669025307206e-02},
+	{Re: 8.646283225794056e-03, Im: 3.109515160030610e-02},
+	{Re: 8.544478464390168e-03, Im: 3.112327994344810e-02},
+	{Re: 8.442582198486492e-03, Im: 3.115107498126614e-02},
+	{Re: 8.340595519310174e-03, Im: 3.117853641609769e-02},
+	{Re: 8.238519519056634e-03, Im: 3.120566395385282e-02},
+	{Re: 8.136355290877799e-03, Im: 3.123245730401742e-02},
+	{Re: 8.034103928870502e-03, Im: 3.125891617965623e-02},
+	{Re: 7.931766528064660e-03, Im: 3.128504029741595e-02},
+	{Re: 7.829344184411629e-03, Im: 3.131082937752825e-02},
+	{Re: 7.726837994772422e-03, Im: 3.133628314381282e-02},
+	{Re: 7.624249056905984e-03, Im: 3.136140132368027e-02},
+	{Re: 7.521578469457400e-03, Im: 3.138618364813507e-02},
+	{Re: 7.418827331946206e-03, Im: 3.141062985177843e-02},
+	{Re: 7.315996744754505e-03, Im: 3.143473967281116e-02},
+	{Re: 7.213087809115295e-03, Im: 3.145851285303643e-02},
+	{Re: 7.110101627100599e-03, Im: 3.148194913786261e-02},
+	{Re: 7.007039301609667e-03, Im: 3.150504827630588e-02},
+	{Re: 6.903901936357235e-03, Im: 3.152781002099304e-02},
+	{Re: 6.800690635861605e-03, Im: 3.155023412816410e-02},
+	{Re: 6.697406505432889e-03, Im: 3.157232035767488e-02},
+	{Re: 6.594050651161149e-03, Im: 3.159406847299960e-02},
+	{Re: 6.490624179904555e-03, Im: 3.161547824123344e-02},
+	{Re: 6.387128199277502e-03, Im: 3.163654943309496e-02},
+	{Re: 6.283563817638816e-03, Im: 3.165728182292862e-02},
+	{Re: 6.179932144079812e-03, Im: 3.167767518870720e-02},
+	{Re: 6.076234288412460e-03, Im: 3.169772931203412e-02},
+	{Re: 5.972471361157488e-03, Im: 3.171744397814584e-02},
+	{Re: 5.868644473532466e-03, Im: 3.173681897591413e-02},
+	{Re: 5.764754737439972e-03, Im: 3.175585409784832e-02},
+	{Re: 5.660803265455597e-03, Im: 3.177454914009754e-02},
+	{Re: 5.556791170816098e-03, Im: 3.179290390245293e-02},
+	{Re: 5.452719567407447e-03, Im: 3.181091818834971e-02},
+	{Re: 5.348589569752904e-03, Im: 3.182859180486936e-02},
+	{Re: 5.244402293001087e-03, Im: 3.184592456274164e-02},
+	{Re: 5.140158852914029e-03, Im: 3.186291627634665e-02},
+	{Re: 5.035860365855222e-03, Im: 3.187956676371677e-02},
+	{Re: 4.931507948777673e-03, Im: 3.189587584653867e-02},
+	{Re: 4.827102719211936e-03, Im: 3.191184335015518e-02},
+	{Re: 4.722645795254123e-03, Im: 3.192746910356715e-02},
+	{Re: 4.618138295553996e-03, Im: 3.194275293943533e-02},
+	{Re: 4.513581339302890e-03, Im: 3.195769469408210e-02},
+	{Re: 4.408976046221832e-03, Im: 3.197229420749328e-02},
+	{Re: 4.304323536549454e-03, Im: 3.198655132331982e-02},
+	{Re: 4.199624931030060e-03, Im: 3.200046588887945e-02},
+	{Re: 4.094881350901605e-03, Im: 3.201403775515835e-02},
+	{Re: 3.990093917883680e-03, Im: 3.202726677681276e-02},
+	{Re: 3.885263754165508e-03, Im: 3.204015281217045e-02},
+	{Re: 3.780391982393921e-03, Im: 3.205269572323236e-02},
+	{Re: 3.675479725661350e-03, Im: 3.206489537567398e-02},
+	{Re: 3.570528107493757e-03, Im: 3.207675163884684e-02},
+	{Re: 3.465538251838685e-03, Im: 3.208826438577988e-02},
+	{Re: 3.360511283053109e-03, Im: 3.209943349318083e-02},
+	{Re: 3.255448325891518e-03, Im: 3.211025884143752e-02},
+	{Re: 3.150350505493752e-03, Im: 3.212074031461917e-02},
+	{Re: 3.045218947373042e-03, Im: 3.213087780047764e-02},
+	{Re: 2.940054777403913e-03, Im: 3.214067119044858e-02},
+	{Re: 2.834859121810137e-03, Im: 3.215012037965264e-02},
+	{Re: 2.729633107152672e-03, Im: 3.215922526689663e-02},
+	{Re: 2.624377860317597e-03, Im: 3.216798575467450e-02},
+	{Re: 2.519094508504045e-03, Im: 3.217640174916846e-02},
+	{Re: 2.413784179212129e-03, Im: 3.218447316024999e-02},
+	{Re: 2.308448000230878e-03, Im: 3.219219990148074e-02},
+	{Re: 2.203087099626121e-03, Im: 3.219958189011354e-02},
+	{Re: 2.097702605728485e-03, Im: 3.220661904709320e-02},
+	{Re: 1.992295647121215e-03, Im: 3.221331129705744e-02},
+	{Re: 1.886867352628160e-03, Im: 3.221965856833763e-02},
+	{Re: 1.781418851301654e-03, Im: 3.222566079295960e-02},
+	{Re: 1.675951272410423e-03, Im: 3.223131790664435e-02},
+	{Re: 1.570465745427526e-03, Im: 3.223662984880872e-02},
+	{Re: 1.464963400018189e-03, Im: 3.224159656256609e-02},
+	{Re: 1.359445366027779e-03, Im: 3.224621799472695e-02},
+	{Re: 1.253912773469668e-03, Im: 3.225049409579946e-02},
+	{Re: 1.148366752513145e-03, Im: 3.225442481999003e-02},
+	{Re: 1.042808433471277e-03, Im: 3.225801012520375e-02},
+	{Re: 9.372389467888862e-04, Im: 3.226124997304489e-02},
+	{Re: 8.316594230303392e-04, Im: 3.226414432881727e-02},
+	{Re: 7.260709928675208e-04, Im: 3.226669316152467e-02},
+	{Re: 6.204747870676891e-04, Im: 3.226889644387115e-02},
+	{Re: 5.148719364813658e-04, Im: 3.227075415226131e-02},
+	{Re: 4.092635720302615e-04, Im: 3.227226626680059e-02},
+	{Re: 3.036508246950931e-04, Im: 3.227343277129547e-02},
+	{Re: 1.980348255035431e-04, Im: 3.227425365325361e-02},
+	{Re: 9.241670551811174e-05, Im: 3.227472890388403e-02},
+}
+
+// mdctTab240 contains 60 complex twiddle factors for N=240 MDCT.
+var mdctTab240 = [60]fft.Complex{
+	{Re: 9.128660411181481e-02, Im: 2.987357797926516e-04},
+	{Re: 9.124750248145415e-02, Im: 2.688238127538266e-03},
+	{Re: 9.114586437080717e-02, Im: 5.075898091151757e-03},
+	{Re: 9.098175943755793e-02, Im: 7.460079287760339e-03},
+	{Re: 9.075530015103049e-02, Im: 9.839147718664365e-03},
+	{Re: 9.046664171510792e-02, Im: 1.221147288919841e-02},
+	{Re: 9.011598196186341e-02, Im: 1.457542892619089e-02},
+	{Re: 8.970356121597596e-02, Im: 1.692939569225627e-02},
+	{Re: 8.922966213002395e-02, Im: 1.927175989615636e-02},
+	{Re: 8.869460949076910e-02, Im: 2.160091619846952e-02},
+	{Re: 8.809876999656388e-02, Im: 2.391526831181008e-02},
+	{Re: 8.744255200603476e-02, Im: 2.621323009484404e-02},
+	{Re: 8.672640525821365e-02, Im: 2.849322663935104e-02},
+	{Re: 8.595082056430915e-02, Im: 3.075369534958787e-02},
+	{Re: 8.511632947132917e-02, Im: 3.299308701321344e-02},
+	{Re: 8.422350389778506e-02, Im: 3.520986686304164e-02},
+	{Re: 8.327295574172730e-02, Im: 3.740251562889399e-02},
+	{Re: 8.226533646138109e-02, Im: 3.956953057883165e-02},
+	{Re: 8.120133662866953e-02, Im: 4.170942654905272e-02},
+	{Re: 8.008168545592993e-02, Im: 4.382073696174943e-02},
+	{Re: 7.890715029614830e-02, Im: 4.590201483022720e-02},
+	{Re: 7.767853611705376e-02, Im: 4.795183375059713e-02},
+	{Re: 7.639668494943408e-02, Im: 4.996878887936194e-02},
+	{Re: 7.506247531004960e-02, Im: 5.195149789622554e-02},
+	{Re: 7.367682159954192e-02, Im: 5.389860195146629e-02},
+	{Re: 7.224067347574926e-02, Im: 5.580876659722474e-02},
+	{Re: 7.075501520285850e-02, Im: 5.768068270206755e-02},
+	{Re: 6.922086497683957e-02, Im: 5.951306734820074e-02},
+	{Re: 6.763927422762485e-02, Im: 6.130466471071754e-02},
+	{Re: 6.601132689851166e-02, Im: 6.305424691827793e-02},
+	{Re: 6.433813870328159e-02, Im: 6.476061489463045e-02},
+	{Re: 6.262085636154602e-02, Im: 6.642259918039907e-02},
+	{Re: 6.086065681284182e-02, Im: 6.803906073457233e-02},
+	{Re: 5.905874641001574e-02, Im: 6.960889171514502e-02},
+	{Re: 5.721636009245044e-02, Im: 7.113101623837788e-02},
+	{Re: 5.533476053969873e-02, Im: 7.260439111615448e-02},
+	{Re: 5.341523730610608e-02, Im: 7.402800657093034e-02},
+	{Re: 5.145910593701446e-02, Im: 7.540088692778391e-02},
+	{Re: 4.946770706715330e-02, Im: 7.672209128309554e-02},
+	{Re: 4.744240550183542e-02, Im: 7.799071414939568e-02},
+	{Re: 4.538458928158762e-02, Im: 7.920588607594094e-02},
+	{Re: 4.329566873085702e-02, Im: 8.036677424459204e-02},
+	{Re: 4.117707549144522e-02, Im: 8.147258304058583e-02},
+	{Re: 3.903026154133238e-02, Im: 8.252255459780995e-02},
+	{Re: 3.685669819956419e-02, Im: 8.351596931820629e-02},
+	{Re: 3.465787511788319e-02, Im: 8.445214636494768e-02},
+	{Re: 3.243529925979586e-02, Im: 8.533044412904939e-02},
+	{Re: 3.019049386777523e-02, Im: 8.615026066909583e-02},
+	{Re: 2.792499741930642e-02, Im: 8.691103412378122e-02},
+	{Re: 2.564036257249118e-02, Im: 8.761224309698118e-02},
+	{Re: 2.333815510193344e-02, Im: 8.825340701509166e-02},
+	{Re: 2.101995282563568e-02, Im: 8.883408645639004e-02},
+	{Re: 1.868734452364132e-02, Im: 8.935388345219286e-02},
+	{Re: 1.634192884916415e-02, Im: 8.981244175960360e-02},
+	{Re: 1.398531323295129e-02, Im: 9.020944710566388e-02},
+	{Re: 1.161911278163058e-02, Im: 9.054462740274036e-02},
+	{Re: 9.244949170796947e-03, Im: 9.081775293500007e-02},
+	{Re: 6.864449533597188e-03, Im: 9.102863651584610e-02},
+	{Re: 4.479245345574007e-03, Im: 9.117713361620602e-02},
+	{Re: 2.090971306534245e-03, Im: 9.126314246358484e-02},
+}
+
+// mdctTab960 contains 240 complex twiddle factors for N=960 MDCT.
+var mdctTab960 = [240]fft.Complex{
+	{Re: 4.564353118357253e-02, Im: 3.734203495898271e-05},
+	{Re: 4.564230917378893e-02, Im: 3.360753153621082e-04},
+	{Re: 4.563913199938973e-02, Im: 6.347941994172692e-04},
+	{Re: 4.563399979647449e-02, Im: 9.334858910016861e-04},
+	{Re: 4.562691278488972e-02, Im: 1.232137595157415e-03},
+	{Re: 4.561787126821940e-02, Im: 1.530736518639439e-03},
+	{Re: 4.560687563377201e-02, Im: 1.829269870463692e-03},
+	{Re: 4.559392635256393e-02, Im: 2.127724862454977e-03},
+	{Re: 4.557902397929929e-02, Im: 2.426088709794772e-03},
+	{Re: 4.556216915234614e-02, Im: 2.724348631568890e-03},
+	{Re: 4.554336259370917e-02, Im: 3.022491851314968e-03},
+	{Re: 4.552260510899877e-02, Im: 3.320505597569770e-03},
+	{Re: 4.549989758739649e-02, Im: 3.618377104416271e-03},
+	{Re: 4.547524100161699e-02, Im: 3.916093612030515e-03},
+	{Re: 4.544863640786632e-02, Im: 4.213642367228189e-03},
+	{Re: 4.542008494579676e-02, Im: 4.511010624010943e-03},
+	{Re: 4.538958783845790e-02, Im: 4.808185644112373e-03},
+	{Re: 4.535714639224431e-02, Im: 5.105154697543694e-03},
+	{Re: 4.532276199683957e-02, Im: 5.401905063139045e-03},
+	{Re: 4.528643612515677e-02, Im: 5.698424029100428e-03},
+	{Re: 4.524817033327531e-02, Im: 5.994698893542231e-03},
+	{Re: 4.520796626037440e-02, Im: 6.290716965035343e-03},
+	{Re: 4.516582562866267e-02, Im: 6.586465563150805e-03},
+	{Re: 4.512175024330455e-02, Im: 6.881932019002999e-03},
+	{Re: 4.507574199234286e-02, Im: 7.177103675792348e-03},
+	{Re: 4.502780284661792e-02, Im: 7.471967889347482e-03},
+	{Re: 4.497793485968320e-02, Im: 7.766512028666877e-03},
+	{Re: 4.492614016771729e-02, Im: 8.060723476459919e-03},
+	{Re: 4.487242098943239e-02, Im: 8.354589629687404e-03},
+	{Re: 4.481677962597932e-02, Im: 8.648097900101384e-03},
+	{Re: 4.475921846084890e-02, Im: 8.941235714784430e-03},
+	{Re: 4.469973995976984e-02, Im: 9.233990516688197e-03},
+	{Re: 4.463834667060321e-02, Im: 9.526349765171334e-03},
+	{Re: 4.457504122323316e-02, Im: 9.818300936536689e-03},
+	{Re: 4.450982632945436e-02, Im: 1.010983152456776e-02},
+	{Re: 4.444270478285581e-02, Im: 1.040092904106446e-02},
+	{Re: 4.437367945870117e-02, Im: 1.069158101637801e-02},
+	{Re: 4.430275331380559e-02, Im: 1.098177499994516e-02},
+	{Re: 4.422992938640906e-02, Im: 1.127149856082151e-02},
+	{Re: 4.415521079604625e-02, Im: 1.156073928821396e-02},
+	{Re: 4.407860074341288e-02, Im: 1.184948479201244e-02},
+	{Re: 4.400010251022864e-02, Im: 1.213772270332058e-02},
+	{Re: 4.391971945909658e-02, Im: 1.242544067498558e-02},
+	{Re: 4.383745503335906e-02, Im: 1.271262638212715e-02},
+	{Re: 4.375331275695030e-02, Im: 1.299926752266540e-02},
+	{Re: 4.366729623424535e-02, Im: 1.328535181784791e-02},
+	{Re: 4.357940914990578e-02, Im: 1.357086701277563e-02},
+	{Re: 4.348965526872176e-02, Im: 1.385580087692787e-02},
+	{Re: 4.339803843545084e-02, Im: 1.414014120468623e-02},
+	{Re: 4.330456257465322e-02, Im: 1.442387581585743e-02},
+	{Re: 4.320923169052368e-02, Im: 1.470699255619506e-02},
+	{Re: 4.311204986672001e-02, Im: 1.498947929792025e-02},
+	{Re: 4.301302126618810e-02, Im: 1.527132394024118e-02},
+	{Re: 4.291215013098359e-02, Im: 1.555251440987142e-02},
+	{Re: 4.280944078209019e-02, Im: 1.583303866154714e-02},
+	{Re: 4.270489761923457e-02, Im: 1.611288467854303e-02},
+	{Re: 4.259852512069787e-02, Im: 1.639204047318714e-02},
+	{Re: 4.249032784312391e-02, Im: 1.667049408737433e-02},
+	{Re: 4.238031042132393e-02, Im: 1.694823359307852e-02},
+	{Re: 4.226847756807813e-02, Im: 1.722524709286369e-02},
+	{Re: 4.215483407393373e-02, Im: 1.750152272039347e-02},
+	{Re: 4.203938480699978e-02, Im: 1.777704864093950e-02},
+	{Re: 4.192213471273864e-02, Im: 1.805181305188837e-02},
+	{Re: 4.180308881375410e-02, Im: 1.832580418324719e-02},
+	{Re: 4.168225220957626e-02, Im: 1.859901029814781e-02},
+	{Re: 4.155963007644307e-02, Im: 1.887141969334957e-02},
+	{Re: 4.143522766707859e-02, Im: 1.914302069974063e-02},
+	{Re: 4.130905031046799e-02, Im: 1.941380168283783e-02},
+	{Re: 4.118110341162930e-02, Im: 1.968375104328509e-02},
+	{Re: 4.105139245138180e-02, Im: 1.995285721735026e-02},
+	{Re: 4.091992298611133e-02, Im: 2.022110867742051e-02},
+	{Re: 4.078670064753220e-02, Im: 2.048849393249609e-02},
+	{Re: 4.065173114244602e-02, Im: 2.075500152868261e-02},
+	{Re: 4.051502025249716e-02, Im: 2.102062004968164e-02},
+	{Re: 4.037657383392514e-02, Im: 2.128533811727980e-02},
+	{Re: 4.023639781731375e-02, Im: 2.154914439183613e-02},
+	{Re: 4.009449820733699e-02, Im: 2.181202757276782e-02},
+	{Re: 3.995088108250189e-02, Im: 2.207397639903436e-02},
+	{Re: 3.980555259488806e-02, Im: 2.233497964961987e-02},
+	{Re: 3.965851896988423e-02, Im: 2.259502614401377e-02},
+	{Re: 3.950978650592153e-02, Im: 2.285410474268975e-02},
+	{Re: 3.935936157420369e-02, Im: 2.311220434758292e-02},
+	{Re: 3.920725061843415e-02, Im: 2.336931390256522e-02},
+	{Re: 3.905346015453997e-02, Im: 2.362542239391905e-02},
+	{Re: 3.889799677039277e-02, Im: 2.388051885080905e-02},
+	{Re: 3.874086712552652e-02, Im: 2.413459234575200e-02},
+	{Re: 3.858207795085223e-02, Im: 2.438763199508502e-02},
+	{Re: 3.842163604836965e-02, Im: 2.463962695943173e-02},
+	{Re: 3.825954829087589e-02, Im: 2.489056644416653e-02},
+	{Re: 3.809582162167102e-02, Im: 2.514043969987710e-02},
+	{Re: 3.793046305426060e-02, Im: 2.538923602282481e-02},
+	{Re: 3.776347967205533e-02, Im: 2.563694475540323e-02},
+	{Re: 3.759487862806751e-02, Im: 2.588355528659472e-02},
+	{Re: 3.742466714460476e-02, Im: 2.612905705242488e-02},
+	{Re: 3.725285251296048e-02, Im: 2.637343953641518e-02},
+	{Re: 3.707944209310167e-02, Im: 2.661669227003335e-02},
+	{Re: 3.690444331335355e-02, Im: 2.685880483314191e-02},
+	{Re: 3.672786367008143e-02, Im: 2.709976685444445e-02},
+	{Re: 3.654971072736948e-02, Im: 2.733956801192999e-02},
+	{Re: 3.636999211669686e-02, Im: 2.757819803331503e-02},
+	{Re: 3.618871553661070e-02, Im: 2.781564669648370e-02},
+	{Re: 3.600588875239635e-02, Im: 2.805190382992554e-02},
+	{Re: 3.582151959574473e-02, Im: 2.828695931317131e-02},
+	{Re: 3.563561596441692e-02, Im: 2.852080307722640e-02},
+	{Re: 3.544818582190572e-02, Im: 2.875342510500227e-02},
+	{Re: 3.525923719709464e-02, Im: 2.898481543174547e-02},
+	{Re: 3.506877818391389e-02, Im: 2.921496414546453e-02},
+	{Re: 3.487681694099370e-02, Im: 2.944386138735455e-02},
+	{Re: 3.468336169131481e-02, Im: 2.967149735221951e-02},
+	{Re: 3.448842072185625e-02, Im: 2.989786228889232e-02},
+	{Re: 3.429200238324035e-02, Im: 3.012294650065248e-02},
+	{Re: 3.409411508937499e-02, Im: 3.034674034564150e-02},
+	{Re: 3.389476731709324e-02, Im: 3.056923423727590e-02},
+	{Re: 3.369396760579015e-02, Im: 3.079041864465790e-02},
+	{Re: 3.349172455705707e-02, Im: 3.101028409298362e-02},
+	{Re: 3.328804683431309e-02, Im: 3.122882116394905e-02},
+	{Re: 3.308294316243393e-02, Im: 3.144602049615339e-02},
+	{Re: 3.287642232737828e-02, Im: 3.166187278550016e-02},
+	{Re: 3.266849317581137e-02, Im: 3.187636878559569e-02},
+	{Re: 3.245916461472598e-02, Im: 3.208949930814525e-02},
+	{Re: 3.224844561106099e-02, Im: 3.230125522334658e-02},
+	{Re: 3.203634519131716e-02, Im: 3.251162746028108e-02},
+	{Re: 3.182287244117060e-02, Im: 3.272060700730226e-02},
+	{Re: 3.160803650508338e-02, Im: 3.292818491242189e-02},
+	{Re: 3.139184658591201e-02, Im: 3.313435228369339e-02},
+	{Re: 3.117431194451307e-02, Im: 3.333910028959274e-02},
+	{Re: 3.095544189934660e-02, Im: 3.354242015939681e-02},
+	{Re: 3.073524582607686e-02, Im: 3.374430318355912e-02},
+	{Re: 3.051373315717078e-02, Im: 3.394474071408279e-02},
+	{Re: 3.029091338149384e-02, Im: 3.414372416489113e-02},
+	{Re: 3.006679604390361e-02, Im: 3.434124501219540e-02},
+	{Re: 2.984139074484093e-02, Im: 3.453729479485988e-02},
+	{Re: 2.961470713991861e-02, Im: 3.473186511476439e-02},
+	{Re: 2.938675493950781e-02, Im: 3.492494763716399e-02},
+	{Re: 2.915754390832211e-02, Im: 3.511653409104608e-02},
+	{Re: 2.892708386499921e-02, Im: 3.530661626948461e-02},
+	{Re: 2.869538468168036e-02, Im: 3.549518602999169e-02},
+	{Re: 2.846245628358740e-02, Im: 3.568223529486639e-02},
+	{Re: 2.822830864859766e-02, Im: 3.586775605154078e-02},
+	{Re: 2.799295180681651e-02, Im: 3.605174035292306e-02},
+	{Re: 2.775639584014774e-02, Im: 3.623418031773815e-02},
+	{Re: 2.751865088186163e-02, Im: 3.641506813086513e-02},
+	{Re: 2.727972711616093e-02, Im: 3.659439604367214e-02},
+	{Re: 2.703963477774455e-02, Im: 3.677215637434824e-02},
+	{Re: 2.679838415136919e-02, Im: 3.694834150823251e-02},
+	{Re: 2.655598557140873e-02, Im: 3.712294389814019e-02},
+	{Re: 2.631244942141157e-02, Im: 3.729595606468603e-02},
+	{Re: 2.606778613365583e-02, Im: 3.746737059660465e-02},
+	{Re: 2.582200618870244e-02, Im: 3.763718015106798e-02},
+	{Re: 2.557512011494625e-02, Im: 3.780537745399991e-02},
+	{Re: 2.532713848816494e-02, Im: 3.797195530038777e-02},
+	{Re: 2.507807193106606e-02, Im: 3.813690655459105e-02},
+	{Re: 2.482793111283195e-02, Im: 3.830022415064700e-02},
+	{Re: 2.457672674866275e-02, Im: 3.846190109257339e-02},
+	{Re: 2.432446959931732e-02, Im: 3.862193045466811e-02},
+	{Re: 2.407117047065239e-02, Im: 3.878030538180592e-02},
+	{Re: 2.381684021315956e-02, Im: 3.893701908973206e-02},
+	{Re: 2.356148972150061e-02, Im: 3.909206486535285e-02},
+	{Re: 2.330512993404071e-02, Im: 3.924543606702329e-02},
+	{Re: 2.304777183237993e-02, Im: 3.939712612483159e-02},
+	{Re: 2.278942644088278e-02, Im: 3.954712854088051e-02},
+	{Re: 2.253010482620602e-02, Im: 3.969543688956580e-02},
+	{Re: 2.226981809682450e-02, Im: 3.984204481785142e-02},
+	{Re: 2.200857740255544e-02, Im: 3.998694604554165e-02},
+	{Re: 2.174639393408067e-02, Im: 4.013013436555019e-02},
+	{Re: 2.148327892246739e-02, Im: 4.027160364416597e-02},
+	{Re: 2.121924363868698e-02, Im: 4.041134782131593e-02},
+	{Re: 2.095429939313221e-02, Im: 4.054936091082462e-02},
+	{Re: 2.068845753513276e-02, Im: 4.068563700067065e-02},
+	{Re: 2.042172945246904e-02, Im: 4.082017025323990e-02},
+	{Re: 2.015412657088436e-02, Im: 4.095295490557557e-02},
+	{Re: 1.988566035359554e-02, Im: 4.108398526962509e-02},
+	{Re: 1.961634230080178e-02, Im: 4.121325573248379e-02},
+	{Re: 1.934618394919210e-02, Im: 4.134076075663527e-02},
+	{Re: 1.907519687145115e-02, Im: 4.146649488018867e-02},
+	{Re: 1.880339267576342e-02, Im: 4.159045271711261e-02},
+	{Re: 1.853078300531599e-02, Im: 4.171262895746593e-02},
+	{Re: 1.825737953779985e-02, Im: 4.183301836762512e-02},
+	{Re: 1.798319398490960e-02, Im: 4.195161579050852e-02},
+	{Re: 1.770823809184174e-02, Im: 4.206841614579730e-02},
+	{Re: 1.743252363679162e-02, Im: 4.218341443015294e-02},
+	{Re: 1.715606243044884e-02, Im: 4.229660571743169e-02},
+	{Re: 1.687886631549136e-02, Im: 4.240798515889554e-02},
+	{Re: 1.660094716607815e-02, Im: 4.251754798341991e-02},
+	{Re: 1.632231688734058e-02, Im: 4.262528949769807e-02},
+	{Re: 1.604298741487245e-02, Im: 4.273120508644212e-02},
+	{Re: 1.576297071421868e-02, Im: 4.283529021258076e-02},
+	{Re: 1.548227878036274e-02, Im: 4.293754041745361e-02},
+	{Re: 1.520092363721288e-02, Im: 4.303795132100221e-02},
+	{Re: 1.491891733708700e-02, Im: 4.313651862195764e-02},
+	{Re: 1.463627196019643e-02, Im: 4.323323809802478e-02},
+	{Re: 1.435299961412835e-02, Im: 4.332810560606319e-02},
+	{Re: 1.406911243332731e-02, Im: 4.342111708226457e-02},
+	{Re: 1.378462257857526e-02, Im: 4.351226854232684e-02},
+	{Re: 1.349954223647074e-02, Im: 4.360155608162483e-02},
+	{Re: 1.321388361890677e-02, Im: 4.368897587537754e-02},
+	{Re: 1.292765896254778e-02, Im: 4.377452417881194e-02},
+	{Re: 1.264088052830543e-02, Im: 4.385819732732345e-02},
+	{Re: 1.235356060081337e-02, Im: 4.393999173663287e-02},
+	{Re: 1.206571148790101e-02, Im: 4.401990390293994e-02},
+	{Re: 1.177734552006630e-02, Im: 4.409793040307342e-02},
+	{Re: 1.148847504994758e-02, Im: 4.417406789463774e-02},
+	{Re: 1.119911245179432e-02, Im: 4.424831311615618e-02},
+	{Re: 1.090927012093716e-02, Im: 4.432066288721052e-02},
+	{Re: 1.061896047325690e-02, Im: 4.439111410857741e-02},
+	{Re: 1.032819594465261e-02, Im: 4.445966376236098e-02},
+	{Re: 1.003698899050896e-02, Im: 4.452630891212223e-02},
+	{Re: 9.745352085162640e-03, Im: 4.459104670300473e-02},
+	{Re: 9.453297721368043e-03, Im: 4.465387436185701e-02},
+	{Re: 9.160838409762090e-03, Im: 4.471478919735126e-02},
+	{Re: 8.867986678328292e-03, Im: 4.477378860009867e-02},
+	{Re: 8.574755071860144e-03, Im: 4.483087004276118e-02},
+	{Re: 8.281156151423708e-03, Im: 4.488603108015979e-02},
+	{Re: 7.987202493819593e-03, Im: 4.493926934937922e-02},
+	{Re: 7.692906691044108e-03, Im: 4.499058256986924e-02},
+	{Re: 7.398281349749959e-03, Im: 4.503996854354225e-02},
+	{Re: 7.103339090706179e-03, Im: 4.508742515486749e-02},
+	{Re: 6.808092548257511e-03, Im: 4.513295037096172e-02},
+	{Re: 6.512554369783156e-03, Im: 4.517654224167617e-02},
+	{Re: 6.216737215155064e-03, Im: 4.521819889968019e-02},
+	{Re: 5.920653756195582e-03, Im: 4.525791856054120e-02},
+	{Re: 5.624316676134662e-03, Im: 4.529569952280113e-02},
+	{Re: 5.327738669066505e-03, Im: 4.533154016804929e-02},
+	{Re: 5.030932439405845e-03, Im: 4.536543896099174e-02},
+	{Re: 4.733910701343736e-03, Im: 4.539739444951699e-02},
+	{Re: 4.436686178302867e-03, Im: 4.542740526475829e-02},
+	{Re: 4.139271602392550e-03, Im: 4.545547012115219e-02},
+	{Re: 3.841679713863350e-03, Im: 4.548158781649365e-02},
+	{Re: 3.543923260561322e-03, Im: 4.550575723198753e-02},
+	{Re: 3.246014997381893e-03, Im: 4.552797733229651e-02},
+	{Re: 2.947967685723554e-03, Im: 4.554824716558545e-02},
+	{Re: 2.649794092941157e-03, Im: 4.556656586356215e-02},
+	{Re: 2.351506991799027e-03, Im: 4.558293264151456e-02},
+	{Re: 2.053119159923782e-03, Im: 4.559734679834439e-02},
+	{Re: 1.754643379257026e-03, Im: 4.560980771659712e-02},
+	{Re: 1.456092435507820e-03, Im: 4.562031486248851e-02},
+	{Re: 1.157479117604938e-03, Im: 4.562886778592738e-02},
+	{Re: 8.588162171490381e-04, Im: 4.563546612053497e-02},
+	{Re: 5.601165278647575e-04, Im: 4.564010958366058e-02},
+	{Re: 2.613928450526357e-04, Im: 4.564279797639369e-02},
+}
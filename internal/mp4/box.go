@@ -0,0 +1,97 @@
+// internal/mp4/box.go
+package mp4
+
+import (
+	"encoding/binary"
+	"errors"
+	"io"
+)
+
+// Errors returned by ReadAudioTrack.
+var (
+	// ErrInvalid is returned for a malformed box structure.
+	ErrInvalid = errors.New("mp4: invalid box structure")
+
+	// ErrNoAudioTrack is returned when the file has no AAC sound track.
+	ErrNoAudioTrack = errors.New("mp4: no AAC audio track")
+
+	// ErrFragmented is returned for fragmented files, whose samples are
+	// described by moof boxes rather than by the moov sample tables.
+	ErrFragmented = errors.New("mp4: fragmented files are not supported")
+)
+
+// boxHeader is the header of a box: its type and the position and size
+// of its payload in the file.
+type boxHeader struct {
+	typ    string
+	offset int64 // first payload byte
+	size   int64 // payload size
+}
+
+// readBoxHeader reads the header of the box at offset, which must end no
+// later than end. A size of 0 extends the box to end.
+func readBoxHeader(r io.ReadSeeker, offset, end int64) (boxHeader, error) {
+	var b [16]byte
+	if end-offset < 8 {
+		return boxHeader{}, ErrInvalid
+	}
+	if _, err := r.Seek(offset, io.SeekStart); err != nil {
+		return boxHeader{}, err
+	}
+	if _, err := io.ReadFull(r, b[:8]); err != nil {
+		return boxHeader{}, ErrInvalid
+	}
+	size := int64(binary.BigEndian.Uint32(b[:4]))
+	h := boxHeader{typ: string(b[4:8]), offset: offset + 8}
+
+	switch size {
+	case 0:
+		size = end - offset
+	case 1:
+		// 64-bit largesize
+		if _, err := io.ReadFull(r, b[8:16]); err != nil {
+			return boxHeader{}, ErrInvalid
+		}
+		size = int64(binary.BigEndian.Uint64(b[8:16]))
+		h.offset += 8
+	}
+	h.size = size - (h.offset - offset)
+	if h.size < 0 || h.offset+h.size > end {
+		return boxHeader{}, ErrInvalid
+	}
+	return h, nil
+}
+
+// children calls fn for each box in the payload [offset, end), stopping
+// early when fn returns an error.
+func children(r io.ReadSeeker, offset, end int64, fn func(h boxHeader) error) error {
+	for offset < end {
+		h, err := readBoxHeader(r, offset, end)
+		if err != nil {
+			return err
+		}
+		if err := fn(h); err != nil {
+			return err
+		}
+		offset = h.offset + h.size
+	}
+	return nil
+}
+
+// readPayload returns the payload of h.
+func readPayload(r io.ReadSeeker, h boxHeader) ([]byte, error) {
+	if _, err := r.Seek(h.offset, io.SeekStart); err != nil {
+		return nil, err
+	}
+	// Sample tables are the largest boxes read whole; refuse absurd sizes
+	// before allocating
+	const maxPayload = 1 << 28
+	if h.size > maxPayload {
+		return nil, ErrInvalid
+	}
+	buf := make([]byte, h.size)
+	if _, err := io.ReadFull(r, buf); err != nil {
+		return nil, ErrInvalid
+	}
+	return buf, nil
+}
@@ -0,0 +1,8 @@
+// Package mp4 locates the AAC access units of an MP4/M4A file.
+//
+// It reads the moov box of an unfragmented ISO base media file: the
+// first sound track with an mp4a sample entry gives the
+// AudioSpecificConfig (from its esds box) and, through its stsz, stco or
+// co64 and stsc tables, the offset and size of every sample. Fragmented
+// files (moof boxes) are rejected with ErrFragmented.
+package mp4
@@ -0,0 +1,168 @@
+// internal/mp4/esds.go
+package mp4
+
+import (
+	"encoding/binary"
+	"io"
+)
+
+// MPEG-4 descriptor tags of an esds box.
+const (
+	tagESDescriptor            = 0x03
+	tagDecoderConfigDescriptor = 0x04
+	tagDecoderSpecificInfo     = 0x05
+)
+
+// objectTypeIndication values of AAC streams: MPEG-4 Audio, and the
+// MPEG-2 AAC Main, LC and SSR profiles.
+var aacObjectTypes = map[byte]bool{0x40: true, 0x66: true, 0x67: true, 0x68: true}
+
+// readSampleDescription reads an stsd box and returns the
+// AudioSpecificConfig of its first mp4a entry. It returns errSkipTrack if
+// the entry is not AAC.
+func readSampleDescription(r io.ReadSeeker, stsd boxHeader) ([]byte, error) {
+	// version/flags and entry_count precede the sample entries
+	start, end := stsd.offset+8, stsd.offset+stsd.size
+	if start > end {
+		return nil, ErrInvalid
+	}
+	entry, err := readBoxHeader(r, start, end)
+	if err != nil {
+		return nil, err
+	}
+	if entry.typ != "mp4a" {
+		return nil, errSkipTrack
+	}
+
+	// AudioSampleEntry: 6 reserved bytes and data_reference_index, then
+	// the sound description, whose version sets its size
+	b, err := readPayload(r, entry)
+	if err != nil {
+		return nil, err
+	}
+	if len(b) < 28 {
+		return nil, ErrInvalid
+	}
+	fields := int64(28)
+	switch binary.BigEndian.Uint16(b[8:]) {
+	case 1:
+		fields += 16
+	case 2:
+		fields += 36
+	}
+
+	var asc []byte
+	var walk func(h boxHeader) error
+	walk = func(h boxHeader) error {
+		switch h.typ {
+		case "wave":
+			// QuickTime wraps the esds box in a wave box
+			return children(r, h.offset, h.offset+h.size, walk)
+		case "esds":
+			p, err := readPayload(r, h)
+			if err != nil {
+				return err
+			}
+			asc, err = parseESDS(p)
+			return err
+		}
+		return nil
+	}
+	if err := children(r, entry.offset+fields, entry.offset+entry.size, walk); err != nil {
+		return nil, err
+	}
+	if asc == nil {
+		return nil, errSkipTrack
+	}
+	return asc, nil
+}
+
+// parseESDS returns the DecoderSpecificInfo of an esds payload, the
+// AudioSpecificConfig.
+//
+// Ported from: ES_Descriptor syntax of ISO/IEC 14496-1
+func parseESDS(b []byte) ([]byte, error) {
+	if len(b) < 4 {
+		return nil, ErrInvalid
+	}
+	b = b[4:] // version/flags
+
+	tag, body, _, err := descriptor(b)
+	if err != nil || tag != tagESDescriptor {
+		return nil, ErrInvalid
+	}
+	if len(body) < 3 {
+		return nil, ErrInvalid
+	}
+	flags := body[2]
+	body = body[3:] // ES_ID, flags
+	if flags&0x80 != 0 {
+		body = skip(body, 2) // dependsOn_ES_ID
+	}
+	if flags&0x40 != 0 && len(body) > 0 {
+		body = skip(body, 1+int(body[0])) // URL
+	}
+	if flags&0x20 != 0 {
+		body = skip(body, 2) // OCR_ES_Id
+	}
+
+	for len(body) > 0 {
+		tag, dcd, rest, err := descriptor(body)
+		if err != nil {
+			return nil, err
+		}
+		body = rest
+		if tag != tagDecoderConfigDescriptor {
+			continue
+		}
+		if len(dcd) < 13 {
+			return nil, ErrInvalid
+		}
+		if !aacObjectTypes[dcd[0]] {
+			return nil, errSkipTrack
+		}
+		for dcd = dcd[13:]; len(dcd) > 0; {
+			tag, dsi, rest, err := descriptor(dcd)
+			if err != nil {
+				return nil, err
+			}
+			if tag == tagDecoderSpecificInfo {
+				return dsi, nil
+			}
+			dcd = rest
+		}
+	}
+	return nil, errSkipTrack
+}
+
+// descriptor splits the descriptor at the start of b into its tag, its
+// body and the bytes that follow it. The size uses up to four bytes of 7
+// bits, the top bit flagging continuation.
+func descriptor(b []byte) (tag byte, body, rest []byte, err error) {
+	if len(b) < 2 {
+		return 0, nil, nil, ErrInvalid
+	}
+	tag = b[0]
+	size, i := 0, 1
+	for {
+		if i >= len(b) || i > 4 {
+			return 0, nil, nil, ErrInvalid
+		}
+		c := b[i]
+		i++
+		size = size<<7 | int(c&0x7F)
+		if c&0x80 == 0 {
+			break
+		}
+	}
+	if size > len(b)-i {
+		return 0, nil, nil, ErrInvalid
+	}
+	return tag, b[i : i+size], b[i+size:], nil
+}
+
+// skip drops n bytes from the front of b, or all of them if it is
+// shorter.
+func skip(b []byte, n int) []byte {
+	return b[min(n, len(b)):]
+}
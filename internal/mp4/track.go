@@ -0,0 +1,258 @@
+// internal/mp4/track.go
+package mp4
+
+import (
+	"encoding/binary"
+	"errors"
+	"io"
+)
+
+// Sample is the location of one access unit in the file.
+type Sample struct {
+	Offset int64
+	Size   uint32
+}
+
+// Track describes the AAC sound track of a file.
+type Track struct {
+	ID        uint32
+	Timescale uint32 // media time units per second, from mdhd
+	ASC       []byte // AudioSpecificConfig from the esds box
+	Samples   []Sample
+}
+
+// errSkipTrack marks a trak that is not an AAC sound track.
+var errSkipTrack = errors.New("mp4: not an AAC sound track")
+
+// ReadAudioTrack reads the moov box of r and returns its first AAC sound
+// track.
+func ReadAudioTrack(r io.ReadSeeker) (*Track, error) {
+	end, err := r.Seek(0, io.SeekEnd)
+	if err != nil {
+		return nil, err
+	}
+
+	var moov *boxHeader
+	err = children(r, 0, end, func(h boxHeader) error {
+		switch h.typ {
+		case "moov":
+			moov = &h
+		case "moof":
+			return ErrFragmented
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	if moov == nil {
+		return nil, ErrInvalid
+	}
+
+	var track *Track
+	err = children(r, moov.offset, moov.offset+moov.size, func(h boxHeader) error {
+		switch h.typ {
+		case "mvex":
+			return ErrFragmented
+		case "trak":
+			if track != nil {
+				return nil
+			}
+			t, err := readTrak(r, h)
+			if errors.Is(err, errSkipTrack) {
+				return nil
+			}
+			track = t
+			return err
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	if track == nil {
+		return nil, ErrNoAudioTrack
+	}
+	return track, nil
+}
+
+// sampleTables holds the raw stbl tables of a track.
+type sampleTables struct {
+	stsz, stco, co64, stsc []byte
+}
+
+// readTrak reads a trak box, returning errSkipTrack unless it is an AAC
+// sound track.
+func readTrak(r io.ReadSeeker, trak boxHeader) (*Track, error) {
+	t := &Track{}
+	var tables sampleTables
+	var sound bool
+
+	var walk func(h boxHeader) error
+	walk = func(h boxHeader) error {
+		switch h.typ {
+		case "mdia", "minf", "stbl":
+			return children(r, h.offset, h.offset+h.size, walk)
+		case "tkhd":
+			b, err := readPayload(r, h)
+			if err != nil {
+				return err
+			}
+			// track_ID follows the times, 32 or 64 bits each
+			idOffset := 12
+			if len(b) > 0 && b[0] == 1 {
+				idOffset = 20
+			}
+			if len(b) < idOffset+4 {
+				return ErrInvalid
+			}
+			t.ID = binary.BigEndian.Uint32(b[idOffset:])
+		case "mdhd":
+			b, err := readPayload(r, h)
+			if err != nil {
+				return err
+			}
+			scaleOffset := 12
+			if len(b) > 0 && b[0] == 1 {
+				scaleOffset = 20
+			}
+			if len(b) < scaleOffset+4 {
+				return ErrInvalid
+			}
+			t.Timescale = binary.BigEndian.Uint32(b[scaleOffset:])
+		case "hdlr":
+			b, err := readPayload(r, h)
+			if err != nil {
+				return err
+			}
+			sound = len(b) >= 12 && string(b[8:12]) == "soun"
+		case "stsd":
+			asc, err := readSampleDescription(r, h)
+			if err != nil {
+				return err
+			}
+			t.ASC = asc
+		case "stsz", "stco", "co64", "stsc":
+			b, err := readPayload(r, h)
+			if err != nil {
+				return err
+			}
+			switch h.typ {
+			case "stsz":
+				tables.stsz = b
+			case "stco":
+				tables.stco = b
+			case "co64":
+				tables.co64 = b
+			case "stsc":
+				tables.stsc = b
+			}
+		}
+		return nil
+	}
+	if err := children(r, trak.offset, trak.offset+trak.size, walk); err != nil {
+		return nil, err
+	}
+	if !sound || t.ASC == nil {
+		return nil, errSkipTrack
+	}
+
+	samples, err := tables.samples()
+	if err != nil {
+		return nil, err
+	}
+	t.Samples = samples
+	return t, nil
+}
+
+// samples resolves the sample tables into sample locations: stsc groups
+// the samples into chunks, whose offsets come from stco or co64, and
+// stsz gives the size of each sample.
+func (st *sampleTables) samples() ([]Sample, error) {
+	if st.stsz == nil || st.stsc == nil || (st.stco == nil && st.co64 == nil) {
+		return nil, ErrInvalid
+	}
+
+	// stsz: version/flags, sample_size, sample_count, entry_size[]
+	if len(st.stsz) < 12 {
+		return nil, ErrInvalid
+	}
+	constSize := binary.BigEndian.Uint32(st.stsz[4:])
+	count := int(binary.BigEndian.Uint32(st.stsz[8:]))
+	if constSize == 0 && len(st.stsz) < 12+4*count {
+		return nil, ErrInvalid
+	}
+	size := func(i int) uint32 {
+		if constSize != 0 {
+			return constSize
+		}
+		return binary.BigEndian.Uint32(st.stsz[12+4*i:])
+	}
+
+	// stco/co64: version/flags, entry_count, chunk_offset[]
+	var chunks []int64
+	if st.co64 != nil {
+		if len(st.co64) < 8 {
+			return nil, ErrInvalid
+		}
+		n := int(binary.BigEndian.Uint32(st.co64[4:]))
+		if len(st.co64) < 8+8*n {
+			return nil, ErrInvalid
+		}
+		chunks = make([]int64, n)
+		for i := range chunks {
+			chunks[i] = int64(binary.BigEndian.Uint64(st.co64[8+8*i:]))
+		}
+	} else {
+		if len(st.stco) < 8 {
+			return nil, ErrInvalid
+		}
+		n := int(binary.BigEndian.Uint32(st.stco[4:]))
+		if len(st.stco) < 8+4*n {
+			return nil, ErrInvalid
+		}
+		chunks = make([]int64, n)
+		for i := range chunks {
+			chunks[i] = int64(binary.BigEndian.Uint32(st.stco[8+4*i:]))
+		}
+	}
+
+	// stsc: version/flags, entry_count, then first_chunk (1-based),
+	// samples_per_chunk and sample_description_index per entry, each
+	// entry running up to the next one's first chunk
+	if len(st.stsc) < 8 {
+		return nil, ErrInvalid
+	}
+	entries := int(binary.BigEndian.Uint32(st.stsc[4:]))
+	if len(st.stsc) < 8+12*entries {
+		return nil, ErrInvalid
+	}
+
+	samples := make([]Sample, 0, count)
+	for e := range entries {
+		first := int(binary.BigEndian.Uint32(st.stsc[8+12*e:]))
+		perChunk := int(binary.BigEndian.Uint32(st.stsc[12+12*e:]))
+		last := len(chunks)
+		if e+1 < entries {
+			last = int(binary.BigEndian.Uint32(st.stsc[8+12*(e+1):])) - 1
+		}
+		if first < 1 || last > len(chunks) {
+			return nil, ErrInvalid
+		}
+		for c := first - 1; c < last; c++ {
+			offset := chunks[c]
+			for range perChunk {
+				if len(samples) == count {
+					return samples, nil
+				}
+				s := Sample{Offset: offset, Size: size(len(samples))}
+				samples = append(samples, s)
+				offset += int64(s.Size)
+			}
+		}
+	}
+	if len(samples) != count {
+		return nil, ErrInvalid
+	}
+	return samples, nil
+}
@@ -0,0 +1,160 @@
+package mp4
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"testing"
+)
+
+// box returns a box of type typ around the concatenated payloads.
+func box(typ string, payloads ...[]byte) []byte {
+	body := bytes.Join(payloads, nil)
+	b := binary.BigEndian.AppendUint32(nil, uint32(8+len(body)))
+	b = append(b, typ...)
+	return append(b, body...)
+}
+
+func u32(vs ...uint32) []byte {
+	var b []byte
+	for _, v := range vs {
+		b = binary.BigEndian.AppendUint32(b, v)
+	}
+	return b
+}
+
+// esds returns an esds box for asc, with a descriptor size in the
+// four-byte form some muxers write.
+func esds(asc []byte) []byte {
+	size := func(n int) []byte { return []byte{0x80, 0x80, 0x80, byte(n)} }
+	dsi := append(append([]byte{tagDecoderSpecificInfo}, size(len(asc))...), asc...)
+	dcd := append([]byte{0x40, 0x15, 0, 0, 0}, u32(128000, 128000)...)
+	dcd = append(append([]byte{tagDecoderConfigDescriptor}, size(len(dcd)+len(dsi))...), append(dcd, dsi...)...)
+	sl := []byte{0x06, 0x01, 0x02}
+	es := append([]byte{0, 1, 0}, append(dcd, sl...)...)
+	es = append(append([]byte{tagESDescriptor}, size(len(es))...), es...)
+	return box("esds", u32(0), es)
+}
+
+// mp4a returns an mp4a sample entry around esds.
+func mp4a(esds []byte) []byte {
+	entry := make([]byte, 28)
+	entry[7] = 1                               // data_reference_index
+	binary.BigEndian.PutUint16(entry[16:], 2)  // channelcount
+	binary.BigEndian.PutUint16(entry[18:], 16) // samplesize
+	binary.BigEndian.PutUint32(entry[24:], 44100<<16)
+	return box("mp4a", entry, esds)
+}
+
+// buildFile returns an M4A file with samples in chunks of chunkSize
+// samples, the mdat placed before the moov. An extra video track comes
+// first.
+func buildFile(asc []byte, samples [][]byte, chunkSize int) []byte {
+	ftyp := box("ftyp", []byte("M4A "), u32(0), []byte("isomM4A "))
+	mdatStart := len(ftyp) + 8
+	var mdat []byte
+	var offsets, sizes []uint32
+	for i, s := range samples {
+		if i%chunkSize == 0 {
+			offsets = append(offsets, uint32(mdatStart+len(mdat)))
+		}
+		sizes = append(sizes, uint32(len(s)))
+		mdat = append(mdat, s...)
+	}
+
+	// A full first entry, then a last chunk with the remainder
+	stsc := u32(0, 1, 1, uint32(chunkSize), 1)
+	if rem := len(samples) % chunkSize; rem != 0 && len(offsets) > 1 {
+		stsc = u32(0, 2, 1, uint32(chunkSize), 1, uint32(len(offsets)), uint32(rem), 1)
+	}
+	stbl := box("stbl",
+		box("stsd", u32(0, 1), mp4a(esds(asc))),
+		box("stts", u32(0, 1, uint32(len(samples)), 1024)),
+		box("stsc", stsc),
+		box("stsz", u32(0, 0, uint32(len(samples))), u32(sizes...)),
+		box("stco", u32(0, uint32(len(offsets))), u32(offsets...)),
+	)
+	audio := box("trak",
+		box("tkhd", u32(0, 0, 0, 2, 0)),
+		box("mdia",
+			box("mdhd", u32(0, 0, 0, 44100, 0, 0)),
+			box("hdlr", u32(0, 0), []byte("soun"), make([]byte, 13)),
+			box("minf", stbl),
+		),
+	)
+	video := box("trak",
+		box("tkhd", u32(0, 0, 0, 1, 0)),
+		box("mdia", box("hdlr", u32(0, 0), []byte("vide"), make([]byte, 13))),
+	)
+	moov := box("moov", box("mvhd", make([]byte, 100)), video, audio)
+	return bytes.Join([][]byte{ftyp, box("mdat", mdat), moov}, nil)
+}
+
+func TestReadAudioTrack(t *testing.T) {
+	asc := []byte{0x12, 0x10}
+	var samples [][]byte
+	for i := range 11 {
+		samples = append(samples, bytes.Repeat([]byte{byte(i)}, 10+i))
+	}
+	file := buildFile(asc, samples, 4)
+
+	track, err := ReadAudioTrack(bytes.NewReader(file))
+	if err != nil {
+		t.Fatalf("ReadAudioTrack: %v", err)
+	}
+	if track.ID != 2 || track.Timescale != 44100 || !bytes.Equal(track.ASC, asc) {
+		t.Errorf("track ID %d, timescale %d, ASC % x", track.ID, track.Timescale, track.ASC)
+	}
+	if len(track.Samples) != len(samples) {
+		t.Fatalf("got %d samples, want %d", len(track.Samples), len(samples))
+	}
+	for i, s := range track.Samples {
+		got := file[s.Offset : s.Offset+int64(s.Size)]
+		if !bytes.Equal(got, samples[i]) {
+			t.Errorf("sample %d at %d: % x, want % x", i, s.Offset, got, samples[i])
+		}
+	}
+}
+
+func TestReadAudioTrack_Errors(t *testing.T) {
+	asc := []byte{0x12, 0x10}
+	file := buildFile(asc, [][]byte{{1}, {2}}, 1)
+
+	fragmented := append(bytes.Clone(file), box("moof", box("mfhd", u32(0, 1)))...)
+	if _, err := ReadAudioTrack(bytes.NewReader(fragmented)); !errors.Is(err, ErrFragmented) {
+		t.Errorf("moof: got %v, want ErrFragmented", err)
+	}
+
+	noAudio := box("moov", box("trak", box("mdia", box("hdlr", u32(0, 0), []byte("vide"), make([]byte, 13)))))
+	if _, err := ReadAudioTrack(bytes.NewReader(noAudio)); !errors.Is(err, ErrNoAudioTrack) {
+		t.Errorf("video only: got %v, want ErrNoAudioTrack", err)
+	}
+
+	truncated := file[:len(file)-10]
+	if _, err := ReadAudioTrack(bytes.NewReader(truncated)); !errors.Is(err, ErrInvalid) {
+		t.Errorf("truncated: got %v, want ErrInvalid", err)
+	}
+}
+
+func TestParseESDS_Flags(t *testing.T) {
+	// streamDependenceFlag, URL_Flag and OCRstreamFlag fields precede the
+	// DecoderConfigDescriptor
+	asc := []byte{0x11, 0x90}
+	dsi := append([]byte{tagDecoderSpecificInfo, byte(len(asc))}, asc...)
+	dcd := append([]byte{tagDecoderConfigDescriptor, byte(13 + len(dsi)), 0x40, 0x15}, make([]byte, 11)...)
+	dcd = append(dcd, dsi...)
+	es := []byte{0, 1, 0xE0, 0, 7, 3, 'a', 'b', 'c', 0, 9}
+	es = append(es, dcd...)
+	payload := append(u32(0), append([]byte{tagESDescriptor, byte(len(es))}, es...)...)
+
+	got, err := parseESDS(payload)
+	if err != nil || !bytes.Equal(got, asc) {
+		t.Errorf("got % x, %v; want % x", got, err, asc)
+	}
+
+	// An MPEG-1 audio stream is not AAC
+	payload[4+2+len(es)-len(dcd)+2] = 0x6B
+	if _, err := parseESDS(payload); !errors.Is(err, errSkipTrack) {
+		t.Errorf("MP3 objectTypeIndication: got %v, want errSkipTrack", err)
+	}
+}
@@ -0,0 +1,81 @@
+// internal/output/bytes.go
+package output
+
+import (
+	"encoding/binary"
+	"math"
+)
+
+// Byte orders for ToPCMBytes.
+const (
+	ByteOrderLittleEndian uint8 = 0 // WAV, most sound APIs
+	ByteOrderBigEndian    uint8 = 1 // AIFF, network order
+)
+
+// ToPCMBytes converts float32 samples to the requested PCM format and
+// packs the interleaved samples into bytes in the given byte order.
+//
+// Sample sizes are 2 bytes for 16-bit, 3 bytes for 24-bit (the packed
+// layout of WAV and AIFF, not padded to 4), 4 bytes for 32-bit and float
+// and 8 bytes for double. The other parameters are those of OutputToPCM.
+func ToPCMBytes(input [][]float32, channelMap []uint8, channels uint8,
+	frameLen uint16, format uint8, downMatrix, upMatrix bool, byteOrder uint8) []byte {
+
+	samples := OutputToPCM(input, channelMap, channels, frameLen, format, downMatrix, upMatrix)
+	return PackPCM(samples, format == FormatInt24, byteOrder)
+}
+
+// PackPCM packs samples returned by OutputToPCM into bytes. packed24
+// stores []int32 samples in 3 bytes, as for format 2 (24-bit); otherwise
+// they take 4 bytes.
+func PackPCM(samples interface{}, packed24 bool, byteOrder uint8) []byte {
+	var order binary.AppendByteOrder = binary.LittleEndian
+	if byteOrder == ByteOrderBigEndian {
+		order = binary.BigEndian
+	}
+
+	switch s := samples.(type) {
+	case []int16:
+		out := make([]byte, 0, len(s)*2)
+		for _, v := range s {
+			out = order.AppendUint16(out, uint16(v))
+		}
+		return out
+
+	case []int32:
+		if !packed24 {
+			out := make([]byte, 0, len(s)*4)
+			for _, v := range s {
+				out = order.AppendUint32(out, uint32(v))
+			}
+			return out
+		}
+		out := make([]byte, 0, len(s)*3)
+		for _, v := range s {
+			u := uint32(v)
+			if byteOrder == ByteOrderBigEndian {
+				out = append(out, byte(u>>16), byte(u>>8), byte(u))
+			} else {
+				out = append(out, byte(u), byte(u>>8), byte(u>>16))
+			}
+		}
+		return out
+
+	case []float32:
+		out := make([]byte, 0, len(s)*4)
+		for _, v := range s {
+			out = order.AppendUint32(out, math.Float32bits(v))
+		}
+		return out
+
+	case []float64:
+		out := make([]byte, 0, len(s)*8)
+		for _, v := range s {
+			out = order.AppendUint64(out, math.Float64bits(v))
+		}
+		return out
+
+	default:
+		return nil
+	}
+}
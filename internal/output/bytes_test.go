@@ -0,0 +1,65 @@
+package output
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestPackPCM_NegativeSamples(t *testing.T) {
+	tests := []struct {
+		name      string
+		samples   interface{}
+		packed24  bool
+		byteOrder uint8
+		want      []byte
+	}{
+		{"int16 LE", []int16{-2, 1}, false, ByteOrderLittleEndian, []byte{0xFE, 0xFF, 0x01, 0x00}},
+		{"int16 BE", []int16{-2, 1}, false, ByteOrderBigEndian, []byte{0xFF, 0xFE, 0x00, 0x01}},
+		{"int24 LE", []int32{-8388608, -2}, true, ByteOrderLittleEndian, []byte{0x00, 0x00, 0x80, 0xFE, 0xFF, 0xFF}},
+		{"int24 BE", []int32{-8388608, -2}, true, ByteOrderBigEndian, []byte{0x80, 0x00, 0x00, 0xFF, 0xFF, 0xFE}},
+		{"int32 LE", []int32{-2}, false, ByteOrderLittleEndian, []byte{0xFE, 0xFF, 0xFF, 0xFF}},
+		{"int32 BE", []int32{-2}, false, ByteOrderBigEndian, []byte{0xFF, 0xFF, 0xFF, 0xFE}},
+		{"float32 LE", []float32{-1}, false, ByteOrderLittleEndian, []byte{0x00, 0x00, 0x80, 0xBF}},
+		{"float64 BE", []float64{-1}, false, ByteOrderBigEndian, []byte{0xBF, 0xF0, 0, 0, 0, 0, 0, 0}},
+	}
+
+	for _, tt := range tests {
+		if got := PackPCM(tt.samples, tt.packed24, tt.byteOrder); !bytes.Equal(got, tt.want) {
+			t.Errorf("%s: got % x, want % x", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestToPCMBytes(t *testing.T) {
+	input := [][]float32{{-256, 40000}, {1, -40000}}
+	channelMap := []uint8{0, 1}
+
+	tests := []struct {
+		name      string
+		format    uint8
+		byteOrder uint8
+		want      []byte
+	}{
+		// -256, 1, 32767 (clipped), -32768 (clipped)
+		{"16-bit LE", FormatInt16, ByteOrderLittleEndian,
+			[]byte{0x00, 0xFF, 0x01, 0x00, 0xFF, 0x7F, 0x00, 0x80}},
+		{"16-bit BE", FormatInt16, ByteOrderBigEndian,
+			[]byte{0xFF, 0x00, 0x00, 0x01, 0x7F, 0xFF, 0x80, 0x00}},
+		// Scaled by 256: -65536, 256, 8388607, -8388608
+		{"24-bit LE", FormatInt24, ByteOrderLittleEndian,
+			[]byte{0x00, 0x00, 0xFF, 0x00, 0x01, 0x00, 0xFF, 0xFF, 0x7F, 0x00, 0x00, 0x80}},
+		{"24-bit BE", FormatInt24, ByteOrderBigEndian,
+			[]byte{0xFF, 0x00, 0x00, 0x00, 0x01, 0x00, 0x7F, 0xFF, 0xFF, 0x80, 0x00, 0x00}},
+	}
+
+	for _, tt := range tests {
+		got := ToPCMBytes(input, channelMap, 2, 2, tt.format, false, false, tt.byteOrder)
+		if !bytes.Equal(got, tt.want) {
+			t.Errorf("%s: got % x, want % x", tt.name, got, tt.want)
+		}
+	}
+
+	if got := ToPCMBytes(input, channelMap, 2, 2, FormatInt32, false, false, ByteOrderLittleEndian); len(got) != 16 {
+		t.Errorf("32-bit: got %d bytes, want 16", len(got))
+	}
+}
@@ -4,6 +4,12 @@
 // Ported from: ~/dev/faad2/libfaad/output.c (get_sample function)
 package output
 
+import (
+	"math"
+
+	"github.com/llehouerou/go-aac/internal/syntax"
+)
+
 // Channel position constants for AAC 5.1 layout.
 // These match FAAD2's internal_channel ordering for downmix.
 //
@@ -17,6 +23,20 @@ const (
 	ChannelLFE        uint8 = 5 // Low Frequency Effects (subwoofer)
 )
 
+// Channel positions of the 7.1 layout (channel configuration 7) beyond
+// the front channels, which are at ChannelCenter, ChannelFrontLeft and
+// ChannelFrontRight as in 5.1.
+//
+// Source: channel configuration 7 of create_channel_config() in
+// ~/dev/faad2/libfaad/decoder.c
+const (
+	Channel71SideLeft  uint8 = 3 // Side left (Ls)
+	Channel71SideRight uint8 = 4 // Side right (Rs)
+	Channel71BackLeft  uint8 = 5 // Back left (Lrs)
+	Channel71BackRight uint8 = 6 // Back right (Rrs)
+	Channel71LFE       uint8 = 7 // Low Frequency Effects (subwoofer)
+)
+
 // Downmix matrix coefficients for 5.1 to stereo conversion.
 // Based on ITU-R BS.775-1 recommendation.
 //
@@ -31,6 +51,29 @@ const (
 	InvSqrt2 = float32(0.7071067811865475244)
 )
 
+// MatrixMixdownCoefs holds the surround coefficient A selected by a PCE's
+// matrix_mixdown_idx: 1/sqrt(2), 1/2, 1/(2*sqrt(2)) and 0.
+//
+// Source: ISO/IEC 13818-7 matrix-mixdown process (also ISO/IEC 14496-3)
+var MatrixMixdownCoefs = [4]float32{
+	0.7071067811865475244,
+	0.5,
+	0.3535533905932737622,
+	0,
+}
+
+// LFE downmix defaults set by NewDownmixer.
+//
+// The LFE channel is reproduced 10 dB above the main channels in playback,
+// so folding it into L/R at unity gain overemphasizes it; -10 dB restores
+// its intended level. Its content is limited to 120 Hz (ITU-R BS.775), and
+// anything above that in the decoded channel is coding noise that the
+// lowpass keeps out of the stereo mix.
+const (
+	DefaultLFEGain   = float32(0.31622776601683794) // -10 dB
+	DefaultLFECutoff = float32(120)                 // Hz
+)
+
 // Downmixer handles multichannel to stereo downmixing.
 //
 // By default, it performs 5.1 to stereo downmix using ITU-R BS.775-1 coefficients.
@@ -48,21 +91,64 @@ type Downmixer struct {
 	IncludeLFE bool
 
 	// LFEGain is the mixing level for LFE when IncludeLFE is true.
-	// Typical values: 0.5 to 0.7 (LFE is usually attenuated in downmix).
-	// Only used when IncludeLFE is true.
+	// NewDownmixer sets DefaultLFEGain (-10 dB); 0.5 to 0.7 are also
+	// common. Only used when IncludeLFE is true.
 	LFEGain float32
+
+	// LFECutoff is the cutoff in Hz of a second-order Butterworth lowpass
+	// that DownmixFrame applies to the LFE before mixing it. Zero disables
+	// the filter. The filter keeps state across frames, so it only runs in
+	// DownmixFrame; the per-sample methods mix the LFE unfiltered.
+	LFECutoff float32
+
+	// SampleRate is the stream sample rate in Hz, needed by the LFE
+	// lowpass. The filter is bypassed while it is zero or when LFECutoff
+	// is not below the Nyquist frequency.
+	SampleRate uint32
+
+	lfeFilter lfeLowpass
+
+	// MatrixMixdown replaces the ITU-R BS.775-1 coefficients with the
+	// standard matrix-mixdown coefficients a PCE signals through
+	// matrix_mixdown_idx. See ConfigureFromPCE.
+	MatrixMixdown bool
+
+	// MatrixMixdownIdx selects the surround coefficient from
+	// MatrixMixdownCoefs. Only used when MatrixMixdown is true.
+	MatrixMixdownIdx uint8
+
+	// PseudoSurround mixes both surround channels into each output with
+	// opposite signs, for decoding by a matrix surround decoder. Only
+	// used when MatrixMixdown is true.
+	PseudoSurround bool
+}
+
+// ConfigureFromPCE enables the matrix mixdown signalled by pce, or
+// reverts to the ITU-R BS.775-1 coefficients if the PCE signals none.
+func (d *Downmixer) ConfigureFromPCE(pce *syntax.ProgramConfig) {
+	d.MatrixMixdown = pce.MatrixMixdownIdxPresent
+	d.MatrixMixdownIdx = pce.MatrixMixdownIdx & 3
+	d.PseudoSurround = pce.PseudoSurroundEnable
 }
 
 // NewDownmixer creates a new Downmixer with default settings.
-// Downmixing is enabled, LFE is excluded (matching FAAD2 defaults).
+// Downmixing is enabled, LFE is excluded (matching FAAD2 defaults). Setting
+// IncludeLFE mixes the LFE at DefaultLFEGain, lowpassed at DefaultLFECutoff
+// once SampleRate is set.
 func NewDownmixer() *Downmixer {
 	return &Downmixer{
 		Enabled:    true,
 		IncludeLFE: false,
-		LFEGain:    0.0,
+		LFEGain:    DefaultLFEGain,
+		LFECutoff:  DefaultLFECutoff,
 	}
 }
 
+// Reset clears the LFE lowpass history, e.g. after a seek.
+func (d *Downmixer) Reset() {
+	d.lfeFilter.reset()
+}
+
 // Downmix5_1ToStereo converts a 5.1 channel sample to stereo.
 //
 // The channel map specifies which input channels correspond to which positions:
@@ -75,6 +161,78 @@ func NewDownmixer() *Downmixer {
 //
 // Ported from: get_sample in ~/dev/faad2/libfaad/output.c:45-61
 func (d *Downmixer) Downmix5_1ToStereo(input [][]float32, channelMap []uint8, sampleIdx uint16) (left, right float32) {
+	var lfe float32
+	if d.IncludeLFE && len(channelMap) > int(ChannelLFE) {
+		lfe = input[channelMap[ChannelLFE]][sampleIdx]
+	}
+	return d.downmixSample(input, channelMap, sampleIdx, lfe)
+}
+
+// Downmix7_1ToStereo converts a 7.1 channel sample to stereo.
+//
+// The channel map follows the 7.1 layout: channelMap[0]=Center,
+// [1]=FrontLeft, [2]=FrontRight, [3]=SideLeft, [4]=SideRight,
+// [5]=BackLeft, [6]=BackRight, [7]=LFE. The side and back surrounds of
+// each side are first folded into one surround as Downmix7_1To5_1 does,
+// which is then mixed like the surround of 5.1:
+//
+//	L = DM_MUL * (L + C*InvSqrt2 + (Ls + Lrs)/2)
+//	R = DM_MUL * (R + C*InvSqrt2 + (Rs + Rrs)/2)
+//
+// MatrixMixdown, IncludeLFE and Enabled apply as for 5.1.
+func (d *Downmixer) Downmix7_1ToStereo(input [][]float32, channelMap []uint8, sampleIdx uint16) (left, right float32) {
+	var lfe float32
+	if d.IncludeLFE && len(channelMap) > int(Channel71LFE) {
+		lfe = input[channelMap[Channel71LFE]][sampleIdx]
+	}
+	return d.downmix71Sample(input, channelMap, sampleIdx, lfe)
+}
+
+// downmix71Sample mixes one 7.1 sample to stereo, taking the LFE sample
+// separately like downmixSample.
+func (d *Downmixer) downmix71Sample(input [][]float32, channelMap []uint8, sampleIdx uint16, lfe float32) (left, right float32) {
+	frontL := input[channelMap[ChannelFrontLeft]][sampleIdx]
+	frontR := input[channelMap[ChannelFrontRight]][sampleIdx]
+	if !d.Enabled {
+		return frontL, frontR
+	}
+
+	rearL, rearR := foldSurrounds(input, channelMap, sampleIdx)
+	return d.mixStereo(input[channelMap[ChannelCenter]][sampleIdx], frontL, frontR, rearL, rearR, lfe)
+}
+
+// Downmix7_1To5_1 converts a 7.1 channel sample, with the channel map of
+// Downmix7_1ToStereo, to 5.1 in the order of the 5.1 channel constants.
+// The front channels and the LFE are kept, and the side and back
+// surrounds of each side are mixed at -3 dB into the 5.1 surround, which
+// keeps the power of uncorrelated surrounds:
+//
+//	Ls' = (Ls + Lrs) * InvSqrt2
+//	Rs' = (Rs + Rrs) * InvSqrt2
+func (d *Downmixer) Downmix7_1To5_1(input [][]float32, channelMap []uint8, sampleIdx uint16) (out [6]float32) {
+	out[ChannelCenter] = input[channelMap[ChannelCenter]][sampleIdx]
+	out[ChannelFrontLeft] = input[channelMap[ChannelFrontLeft]][sampleIdx]
+	out[ChannelFrontRight] = input[channelMap[ChannelFrontRight]][sampleIdx]
+	out[ChannelRearLeft], out[ChannelRearRight] = foldSurrounds(input, channelMap, sampleIdx)
+	if len(channelMap) > int(Channel71LFE) {
+		out[ChannelLFE] = input[channelMap[Channel71LFE]][sampleIdx]
+	}
+	return out
+}
+
+// foldSurrounds mixes the side and back surrounds of a 7.1 sample into
+// the left and right surrounds of 5.1.
+func foldSurrounds(input [][]float32, channelMap []uint8, sampleIdx uint16) (rearL, rearR float32) {
+	rearL = (input[channelMap[Channel71SideLeft]][sampleIdx] +
+		input[channelMap[Channel71BackLeft]][sampleIdx]) * InvSqrt2
+	rearR = (input[channelMap[Channel71SideRight]][sampleIdx] +
+		input[channelMap[Channel71BackRight]][sampleIdx]) * InvSqrt2
+	return rearL, rearR
+}
+
+// downmixSample mixes one 5.1 sample to stereo, taking the LFE sample
+// separately so DownmixFrame can pass it through the lowpass first.
+func (d *Downmixer) downmixSample(input [][]float32, channelMap []uint8, sampleIdx uint16, lfe float32) (left, right float32) {
 	if !d.Enabled {
 		// Pass through front L/R when disabled
 		return input[channelMap[ChannelFrontLeft]][sampleIdx],
@@ -82,20 +240,45 @@ func (d *Downmixer) Downmix5_1ToStereo(input [][]float32, channelMap []uint8, sa
 	}
 
 	// Get channel samples using the channel map
-	center := input[channelMap[ChannelCenter]][sampleIdx]
-	frontL := input[channelMap[ChannelFrontLeft]][sampleIdx]
-	frontR := input[channelMap[ChannelFrontRight]][sampleIdx]
-	rearL := input[channelMap[ChannelRearLeft]][sampleIdx]
-	rearR := input[channelMap[ChannelRearRight]][sampleIdx]
+	return d.mixStereo(
+		input[channelMap[ChannelCenter]][sampleIdx],
+		input[channelMap[ChannelFrontLeft]][sampleIdx],
+		input[channelMap[ChannelFrontRight]][sampleIdx],
+		input[channelMap[ChannelRearLeft]][sampleIdx],
+		input[channelMap[ChannelRearRight]][sampleIdx],
+		lfe)
+}
 
-	// Apply ITU-R BS.775-1 downmix matrix
-	left = DownmixMul * (frontL + center*InvSqrt2 + rearL*InvSqrt2)
-	right = DownmixMul * (frontR + center*InvSqrt2 + rearR*InvSqrt2)
+// mixStereo applies the stereo downmix matrix to the samples of the 5.1
+// positions.
+func (d *Downmixer) mixStereo(center, frontL, frontR, rearL, rearR, lfe float32) (left, right float32) {
+	mul := DownmixMul
+	if d.MatrixMixdown {
+		// Matrix mixdown with surround coefficient A:
+		//   L = (L + C/sqrt(2) + A*Ls) / (1 + 1/sqrt(2) + A)
+		// or, with pseudo surround,
+		//   L = (L + C/sqrt(2) - A*(Ls+Rs)) / (1 + 1/sqrt(2) + 2A)
+		//   R = (R + C/sqrt(2) + A*(Ls+Rs)) / (1 + 1/sqrt(2) + 2A)
+		a := MatrixMixdownCoefs[d.MatrixMixdownIdx&3]
+		if d.PseudoSurround {
+			mul = 1 / (1 + InvSqrt2 + 2*a)
+			surround := a * (rearL + rearR)
+			left = mul * (frontL + center*InvSqrt2 - surround)
+			right = mul * (frontR + center*InvSqrt2 + surround)
+		} else {
+			mul = 1 / (1 + InvSqrt2 + a)
+			left = mul * (frontL + center*InvSqrt2 + a*rearL)
+			right = mul * (frontR + center*InvSqrt2 + a*rearR)
+		}
+	} else {
+		// Apply ITU-R BS.775-1 downmix matrix
+		left = DownmixMul * (frontL + center*InvSqrt2 + rearL*InvSqrt2)
+		right = DownmixMul * (frontR + center*InvSqrt2 + rearR*InvSqrt2)
+	}
 
 	// Optionally mix in LFE
-	if d.IncludeLFE && len(channelMap) > int(ChannelLFE) {
-		lfe := input[channelMap[ChannelLFE]][sampleIdx]
-		lfeContrib := lfe * d.LFEGain * DownmixMul
+	if d.IncludeLFE {
+		lfeContrib := lfe * d.LFEGain * mul
 		left += lfeContrib
 		right += lfeContrib
 	}
@@ -103,22 +286,99 @@ func (d *Downmixer) Downmix5_1ToStereo(input [][]float32, channelMap []uint8, sa
 	return left, right
 }
 
-// DownmixFrame converts a full frame of 5.1 audio to stereo.
+// DownmixFrame converts a full frame of 5.1 audio to stereo, or of 7.1
+// audio like Downmix7_1ToStereo when channelMap has the 7 or 8 entries of
+// the 7.1 layout.
 //
 // Returns two slices: left and right channel output samples.
 // The output length matches frameLen.
 //
 // This is more efficient than calling Downmix5_1ToStereo for each sample
-// when processing complete frames.
+// when processing complete frames. Unlike the per-sample methods, it
+// applies the LFE lowpass configured by LFECutoff.
 func (d *Downmixer) DownmixFrame(input [][]float32, channelMap []uint8, frameLen uint16) (left, right []float32) {
 	left = make([]float32, frameLen)
 	right = make([]float32, frameLen)
+	d.DownmixFrameInto(left, right, input, channelMap, frameLen)
+	return left, right
+}
+
+// DownmixFrameInto is DownmixFrame writing the stereo mix into left and
+// right, which must hold frameLen samples, so a decoder can reuse its
+// buffers from frame to frame.
+func (d *Downmixer) DownmixFrameInto(left, right []float32, input [][]float32, channelMap []uint8, frameLen uint16) {
+	layout71 := len(channelMap) > int(Channel71BackRight)
+	lfeCh := ChannelLFE
+	if layout71 {
+		lfeCh = Channel71LFE
+	}
+	var lfeIn []float32
+	if d.Enabled && d.IncludeLFE && len(channelMap) > int(lfeCh) {
+		lfeIn = input[channelMap[lfeCh]]
+	}
+	filter := lfeIn != nil && d.lfeFilter.configure(d.LFECutoff, d.SampleRate)
 
 	for i := uint16(0); i < frameLen; i++ {
-		left[i], right[i] = d.Downmix5_1ToStereo(input, channelMap, i)
+		var lfe float32
+		if lfeIn != nil {
+			lfe = lfeIn[i]
+			if filter {
+				lfe = d.lfeFilter.process(lfe)
+			}
+		}
+		if layout71 {
+			left[i], right[i] = d.downmix71Sample(input, channelMap, i, lfe)
+		} else {
+			left[i], right[i] = d.downmixSample(input, channelMap, i, lfe)
+		}
 	}
+}
 
-	return left, right
+// lfeLowpass is a second-order Butterworth lowpass (RBJ biquad, direct
+// form I) for the LFE channel.
+type lfeLowpass struct {
+	cutoff     float32
+	sampleRate uint32
+
+	b0, b1, b2, a1, a2 float32
+	x1, x2, y1, y2     float32
+}
+
+// configure updates the coefficients for cutoff and sampleRate, clearing
+// the history when they change. It reports whether filtering is possible.
+func (f *lfeLowpass) configure(cutoff float32, sampleRate uint32) bool {
+	if cutoff <= 0 || sampleRate == 0 || float64(cutoff) >= float64(sampleRate)/2 {
+		return false
+	}
+	if cutoff == f.cutoff && sampleRate == f.sampleRate {
+		return true
+	}
+
+	w0 := 2 * math.Pi * float64(cutoff) / float64(sampleRate)
+	cosW0 := math.Cos(w0)
+	alpha := math.Sin(w0) / math.Sqrt2 // Q = 1/sqrt(2)
+	a0 := 1 + alpha
+
+	f.b1 = float32((1 - cosW0) / a0)
+	f.b0 = f.b1 / 2
+	f.b2 = f.b0
+	f.a1 = float32(-2 * cosW0 / a0)
+	f.a2 = float32((1 - alpha) / a0)
+	f.cutoff = cutoff
+	f.sampleRate = sampleRate
+	f.reset()
+	return true
+}
+
+func (f *lfeLowpass) reset() {
+	f.x1, f.x2, f.y1, f.y2 = 0, 0, 0, 0
+}
+
+func (f *lfeLowpass) process(x float32) float32 {
+	y := f.b0*x + f.b1*f.x1 + f.b2*f.x2 - f.a1*f.y1 - f.a2*f.y2
+	f.x2, f.x1 = f.x1, x
+	f.y2, f.y1 = f.y1, y
+	return y
 }
 
 // GetDownmixedSample returns a sample for the specified output channel,
@@ -4,6 +4,8 @@ package output
 import (
 	"math"
 	"testing"
+
+	"github.com/llehouerou/go-aac/internal/syntax"
 )
 
 func TestChannelConstants(t *testing.T) {
@@ -116,6 +118,56 @@ func TestDownmix5_1ToStereo(t *testing.T) {
 	}
 }
 
+func TestDownmix5_1ToStereo_MatrixMixdown(t *testing.T) {
+	input := [][]float32{
+		{1000.0}, // Center
+		{500.0},  // Front Left
+		{600.0},  // Front Right
+		{200.0},  // Rear Left
+		{300.0},  // Rear Right
+	}
+	channelMap := []uint8{0, 1, 2, 3, 4}
+	c, l, r, ls, rs := 1000.0, 500.0, 600.0, 200.0, 300.0
+
+	for idx, a := range []float64{1 / math.Sqrt2, 0.5, 1 / (2 * math.Sqrt2), 0} {
+		pce := &syntax.ProgramConfig{
+			MatrixMixdownIdxPresent: true,
+			MatrixMixdownIdx:        uint8(idx),
+		}
+
+		dm := NewDownmixer()
+		dm.ConfigureFromPCE(pce)
+		left, right := dm.Downmix5_1ToStereo(input, channelMap, 0)
+
+		norm := 1 / (1 + 1/math.Sqrt2 + a)
+		wantL := norm * (l + c/math.Sqrt2 + a*ls)
+		wantR := norm * (r + c/math.Sqrt2 + a*rs)
+		if math.Abs(float64(left)-wantL) > 0.01 || math.Abs(float64(right)-wantR) > 0.01 {
+			t.Errorf("idx %d: got (%v, %v), want (%v, %v)", idx, left, right, wantL, wantR)
+		}
+
+		pce.PseudoSurroundEnable = true
+		dm.ConfigureFromPCE(pce)
+		left, right = dm.Downmix5_1ToStereo(input, channelMap, 0)
+
+		norm = 1 / (1 + 1/math.Sqrt2 + 2*a)
+		wantL = norm * (l + c/math.Sqrt2 - a*(ls+rs))
+		wantR = norm * (r + c/math.Sqrt2 + a*(ls+rs))
+		if math.Abs(float64(left)-wantL) > 0.01 || math.Abs(float64(right)-wantR) > 0.01 {
+			t.Errorf("idx %d pseudo surround: got (%v, %v), want (%v, %v)", idx, left, right, wantL, wantR)
+		}
+	}
+
+	// A PCE without matrix mixdown restores the ITU coefficients
+	dm := NewDownmixer()
+	dm.ConfigureFromPCE(&syntax.ProgramConfig{MatrixMixdownIdxPresent: true, MatrixMixdownIdx: 3})
+	dm.ConfigureFromPCE(&syntax.ProgramConfig{})
+	left, _ := dm.Downmix5_1ToStereo(input, channelMap, 0)
+	if want := DownmixMul * (500 + 1000*InvSqrt2 + 200*InvSqrt2); left != want {
+		t.Errorf("ITU left: got %v, want %v", left, want)
+	}
+}
+
 func TestDownmix5_1ToStereo_WithLFE(t *testing.T) {
 	input := [][]float32{
 		{1000.0}, // Center
@@ -151,6 +203,76 @@ func TestDownmix5_1ToStereo_WithLFE(t *testing.T) {
 	}
 }
 
+func TestDownmix5_1ToStereo_DefaultLFEGain(t *testing.T) {
+	// LFE only: the contribution is the LFE attenuated by 10 dB, then
+	// normalized like the other channels
+	input := [][]float32{{0}, {0}, {0}, {0}, {0}, {1000}}
+	channelMap := []uint8{0, 1, 2, 3, 4, 5}
+
+	dm := NewDownmixer()
+	dm.IncludeLFE = true
+	left, right := dm.Downmix5_1ToStereo(input, channelMap, 0)
+
+	want := float32(1000) * DefaultLFEGain * DownmixMul
+	if math.Abs(float64(left-want)) > 0.01 || math.Abs(float64(right-want)) > 0.01 {
+		t.Errorf("LFE contribution: got (%v, %v), want %v", left, right, want)
+	}
+	if gainDB := 20 * math.Log10(float64(DefaultLFEGain)); math.Abs(gainDB+10) > 0.01 {
+		t.Errorf("DefaultLFEGain: got %.2f dB, want -10 dB", gainDB)
+	}
+}
+
+func TestDownmixFrame_LFELowpass(t *testing.T) {
+	const (
+		sampleRate = 48000
+		frameLen   = 4800
+	)
+	// rms of the mixed left channel over the second half of the frame,
+	// once the filter has settled, for an LFE sine at freq
+	mixedRMS := func(freq float64, cutoff float32) float64 {
+		lfe := make([]float32, frameLen)
+		for i := range lfe {
+			lfe[i] = float32(1000 * math.Sin(2*math.Pi*freq*float64(i)/sampleRate))
+		}
+		silent := make([]float32, frameLen)
+		input := [][]float32{silent, silent, silent, silent, silent, lfe}
+
+		dm := NewDownmixer()
+		dm.IncludeLFE = true
+		dm.LFECutoff = cutoff
+		dm.SampleRate = sampleRate
+		left, right := dm.DownmixFrame(input, []uint8{0, 1, 2, 3, 4, 5}, frameLen)
+
+		var sum float64
+		for i := frameLen / 2; i < frameLen; i++ {
+			if left[i] != right[i] {
+				t.Fatalf("sample %d: LFE must feed L and R equally, got %v and %v", i, left[i], right[i])
+			}
+			sum += float64(left[i]) * float64(left[i])
+		}
+		return math.Sqrt(sum / (frameLen / 2))
+	}
+
+	// 2 kHz content is well above the 120 Hz cutoff: at least 40 dB down
+	hf := mixedRMS(2000, DefaultLFECutoff)
+	hfUnfiltered := mixedRMS(2000, 0)
+	if hf > hfUnfiltered/100 {
+		t.Errorf("2 kHz LFE: filtered rms %v, unfiltered %v; want at least 40 dB attenuation", hf, hfUnfiltered)
+	}
+
+	// A cutoff above Nyquist bypasses the filter
+	if got := mixedRMS(2000, 30000); got != hfUnfiltered {
+		t.Errorf("cutoff above Nyquist: rms %v, want unfiltered %v", got, hfUnfiltered)
+	}
+
+	// 40 Hz content passes nearly unchanged
+	lf := mixedRMS(40, DefaultLFECutoff)
+	lfUnfiltered := mixedRMS(40, 0)
+	if ratio := lf / lfUnfiltered; ratio < 0.9 || ratio > 1.1 {
+		t.Errorf("40 Hz LFE: filtered/unfiltered rms ratio %v, want about 1", ratio)
+	}
+}
+
 func TestDownmix5_1ToStereo_Disabled(t *testing.T) {
 	input := [][]float32{
 		{1000.0}, // Center
@@ -267,6 +389,27 @@ func TestDownmixFrame(t *testing.T) {
 	}
 }
 
+// TestDownmixFrame_71 checks that a 7.1 channel map selects the mix of
+// Downmix7_1ToStereo.
+func TestDownmixFrame_71(t *testing.T) {
+	input := make([][]float32, 8)
+	for ch := range input {
+		input[ch] = []float32{float32(100 * (ch + 1)), float32(-50 * (ch + 1))}
+	}
+	channelMap := []uint8{0, 1, 2, 3, 4, 5, 6, 7}
+
+	dm := NewDownmixer()
+	dm.IncludeLFE = true
+	dm.LFECutoff = 0
+	left, right := dm.DownmixFrame(input, channelMap, 2)
+	for i := range uint16(2) {
+		wantL, wantR := dm.Downmix7_1ToStereo(input, channelMap, i)
+		if left[i] != wantL || right[i] != wantR {
+			t.Errorf("sample %d: got (%v, %v), want (%v, %v)", i, left[i], right[i], wantL, wantR)
+		}
+	}
+}
+
 func TestDownmixFrame_Disabled(t *testing.T) {
 	input := [][]float32{
 		{1000.0, 2000.0}, // Center
@@ -424,3 +567,81 @@ func TestDownmixer_EdgeCases(t *testing.T) {
 		})
 	}
 }
+
+// input7_1 returns one 7.1 sample with a distinct value in each channel.
+func input7_1() ([][]float32, []uint8) {
+	input := [][]float32{
+		{1000}, // Center
+		{500},  // Front Left
+		{600},  // Front Right
+		{200},  // Side Left
+		{300},  // Side Right
+		{120},  // Back Left
+		{80},   // Back Right
+		{4000}, // LFE
+	}
+	return input, []uint8{0, 1, 2, 3, 4, 5, 6, 7}
+}
+
+func TestDownmix7_1ToStereo(t *testing.T) {
+	input, channelMap := input7_1()
+	dm := NewDownmixer()
+
+	left, right := dm.Downmix7_1ToStereo(input, channelMap, 0)
+	wantL := DownmixMul * (500 + 1000*InvSqrt2 + (200+120)*0.5)
+	wantR := DownmixMul * (600 + 1000*InvSqrt2 + (300+80)*0.5)
+	if math.Abs(float64(left-wantL)) > 1e-3 || math.Abs(float64(right-wantR)) > 1e-3 {
+		t.Errorf("got (%v, %v), want (%v, %v)", left, right, wantL, wantR)
+	}
+
+	// Each surround reaches its own side of the mix only
+	for _, tc := range []struct {
+		ch          uint8
+		left, right bool
+	}{
+		{Channel71SideLeft, true, false},
+		{Channel71SideRight, false, true},
+		{Channel71BackLeft, true, false},
+		{Channel71BackRight, false, true},
+	} {
+		in, _ := input7_1()
+		in[tc.ch][0] += 100
+		l, r := dm.Downmix7_1ToStereo(in, channelMap, 0)
+		if (l != left) != tc.left || (r != right) != tc.right {
+			t.Errorf("channel %d: mix changed to (%v, %v) from (%v, %v)", tc.ch, l, r, left, right)
+		}
+	}
+
+	// The folded surrounds mix like the 5.1 surrounds
+	fold := dm.Downmix7_1To5_1(input, channelMap, 0)
+	in5 := make([][]float32, 6)
+	for ch := range in5 {
+		in5[ch] = []float32{fold[ch]}
+	}
+	l5, r5 := dm.Downmix5_1ToStereo(in5, []uint8{0, 1, 2, 3, 4, 5}, 0)
+	if l5 != left || r5 != right {
+		t.Errorf("7.1 mix (%v, %v) differs from the 5.1 mix of the fold (%v, %v)", left, right, l5, r5)
+	}
+
+	dm.IncludeLFE = true
+	l, _ := dm.Downmix7_1ToStereo(input, channelMap, 0)
+	if want := left + 4000*dm.LFEGain*DownmixMul; math.Abs(float64(l-want)) > 1e-3 {
+		t.Errorf("with LFE: left = %v, want %v", l, want)
+	}
+
+	dm.Enabled = false
+	if l, r := dm.Downmix7_1ToStereo(input, channelMap, 0); l != 500 || r != 600 {
+		t.Errorf("disabled: got (%v, %v), want (500, 600)", l, r)
+	}
+}
+
+func TestDownmix7_1To5_1(t *testing.T) {
+	input, channelMap := input7_1()
+	dm := NewDownmixer()
+
+	got := dm.Downmix7_1To5_1(input, channelMap, 0)
+	want := [6]float32{1000, 500, 600, (200 + 120) * InvSqrt2, (300 + 80) * InvSqrt2, 4000}
+	if got != want {
+		t.Errorf("got %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,53 @@
+// internal/output/mono.go
+package output
+
+// DownmixToMono mixes the first numChannels input channels, in AAC
+// channel configuration order, down to a single channel.
+//
+// The mix folds the stereo pair into one channel at -3 dB, so that
+// uncorrelated left and right content keeps its level:
+//
+//	1.0 (C):                M = C
+//	2.0 (L, R):             M = (L + R) * InvSqrt2
+//	3.0 (C, L, R):          M = DownmixMul * (C + (L + R)*InvSqrt2)
+//	5.0/5.1 (C, L, R, Ls, Rs[, LFE]):
+//	                        M = DownmixMul * (C + (L + R)*InvSqrt2 + (Ls + Rs)/2)
+//
+// The 3.0 and 5.x rows are (L' + R') * InvSqrt2 of the ITU-R BS.775-1
+// stereo downmix L', R' that DownMatrix uses, with absent surrounds as
+// silence. As in FAAD2's downmix the LFE is left out. Other layouts are
+// mixed as the largest of these they start with (4.0 as 3.0, 7.1 as
+// 5.0).
+//
+// The result is not clipped: correlated stereo content can exceed full
+// scale, and is clipped when converted to integer PCM.
+func DownmixToMono(input [][]float32, channelMap []uint8, numChannels uint8, frameLen uint16) []float32 {
+	output := make([]float32, frameLen)
+	ch := func(i uint8) []float32 {
+		return input[channelMap[i]]
+	}
+
+	switch {
+	case numChannels == 0:
+	case numChannels == 1:
+		copy(output, ch(0))
+	case numChannels == 2:
+		l, r := ch(0), ch(1)
+		for i := range output {
+			output[i] = (l[i] + r[i]) * InvSqrt2
+		}
+	case numChannels < 5:
+		c, l, r := ch(ChannelCenter), ch(ChannelFrontLeft), ch(ChannelFrontRight)
+		for i := range output {
+			output[i] = DownmixMul * (c[i] + (l[i]+r[i])*InvSqrt2)
+		}
+	default:
+		c, l, r := ch(ChannelCenter), ch(ChannelFrontLeft), ch(ChannelFrontRight)
+		ls, rs := ch(ChannelRearLeft), ch(ChannelRearRight)
+		for i := range output {
+			output[i] = DownmixMul * (c[i] + (l[i]+r[i])*InvSqrt2 + (ls[i]+rs[i])*0.5)
+		}
+	}
+
+	return output
+}
@@ -0,0 +1,79 @@
+package output
+
+import (
+	"math"
+	"testing"
+)
+
+func TestDownmixToMono_Matrix(t *testing.T) {
+	// C=1000, L=2000, R=3000, Ls=4000, Rs=5000, LFE=6000
+	input := [][]float32{{1000}, {2000}, {3000}, {4000}, {5000}, {6000}}
+	channelMap := []uint8{0, 1, 2, 3, 4, 5}
+
+	tests := []struct {
+		name     string
+		input    [][]float32
+		channels uint8
+		want     float32
+	}{
+		{"mono", input[:1], 1, 1000},
+		{"stereo", [][]float32{{2000}, {3000}}, 2, 5000 * InvSqrt2},
+		{"3.0", input[:3], 3, DownmixMul * (1000 + 5000*InvSqrt2)},
+		{"5.0", input[:5], 5, DownmixMul * (1000 + 5000*InvSqrt2 + 4500)},
+		{"5.1 without LFE", input, 6, DownmixMul * (1000 + 5000*InvSqrt2 + 4500)},
+	}
+
+	for _, tt := range tests {
+		got := DownmixToMono(tt.input, channelMap, tt.channels, 1)
+		if math.Abs(float64(got[0]-tt.want)) > 1e-3 {
+			t.Errorf("%s: got %v, want %v", tt.name, got[0], tt.want)
+		}
+	}
+
+	// The 5.1 mix is the -3 dB fold of the DownMatrix stereo mix
+	left, right := NewDownmixer().Downmix5_1ToStereo(input, channelMap, 0)
+	if got := DownmixToMono(input, channelMap, 6, 1)[0]; math.Abs(float64(got-(left+right)*InvSqrt2)) > 1e-3 {
+		t.Errorf("5.1 mono = %v, want (L'+R')/sqrt(2) = %v", got, (left+right)*InvSqrt2)
+	}
+}
+
+func TestDownmixToMono_EnergyPreserving(t *testing.T) {
+	// Uncorrelated (orthogonal) left and right at the same level fold to a
+	// mono channel at that level
+	const frameLen = 1024
+	l := make([]float32, frameLen)
+	r := make([]float32, frameLen)
+	for i := range l {
+		phase := 2 * math.Pi * 8 * float64(i) / frameLen
+		l[i] = float32(10000 * math.Sin(phase))
+		r[i] = float32(10000 * math.Cos(phase))
+	}
+
+	power := func(s []float32) float64 {
+		var sum float64
+		for _, v := range s {
+			sum += float64(v) * float64(v)
+		}
+		return sum / float64(len(s))
+	}
+
+	mono := DownmixToMono([][]float32{l, r}, []uint8{0, 1}, 2, frameLen)
+	want := (power(l) + power(r)) / 2
+	if got := power(mono); math.Abs(got-want)/want > 1e-4 {
+		t.Errorf("mono power %v, want %v", got, want)
+	}
+}
+
+func TestDownmixToMono_Clipping(t *testing.T) {
+	// Correlated full-scale stereo sums 3 dB over full scale
+	input := [][]float32{{30000, -30000, 100}, {30000, -30000, 100}}
+	mono := DownmixToMono(input, []uint8{0, 1}, 2, 3)
+
+	got := OutputToPCM16([][]float32{mono}, []uint8{0}, 1, 3, false, false)
+	want := []int16{32767, -32768, 141}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("sample %d: got %d, want %d", i, got[i], want[i])
+		}
+	}
+}
@@ -18,6 +18,12 @@ const DMMul = float32(0.3203772410170407)
 // RSQRT2 is 1/sqrt(2), used for downmix calculations.
 const RSQRT2 = float32(0.7071067811865475244)
 
+// Full-precision DMMul and RSQRT2 for the float64 downmix.
+const (
+	dmMulDouble  = 0.3203772410170407
+	rsqrt2Double = 0.7071067811865475244
+)
+
 // clip16 clips and rounds a float32 to int16 range.
 // Matches FAAD2's CLIP macro + lrintf behavior.
 //
@@ -92,6 +98,25 @@ func getSample(input [][]float32, channel uint8, sample uint16,
 		input[channelMap[4]][sample]*RSQRT2)
 }
 
+// getSampleDouble is getSample computing the downmix in float64, so the
+// sums of a high dynamic range mix are not rounded to float32 before
+// reaching double output.
+func getSampleDouble(input [][]float32, channel uint8, sample uint16,
+	downMatrix bool, channelMap []uint8) float64 {
+
+	if !downMatrix {
+		return float64(input[channelMap[channel]][sample])
+	}
+
+	center := float64(input[channelMap[0]][sample]) * rsqrt2Double
+	if channel == 0 {
+		return dmMulDouble * (float64(input[channelMap[1]][sample]) + center +
+			float64(input[channelMap[3]][sample])*rsqrt2Double)
+	}
+	return dmMulDouble * (float64(input[channelMap[2]][sample]) + center +
+		float64(input[channelMap[4]][sample])*rsqrt2Double)
+}
+
 // ToPCM16Bit converts float32 samples to 16-bit PCM.
 //
 // Parameters:
@@ -102,17 +127,25 @@ func getSample(input [][]float32, channel uint8, sample uint16,
 //   - downMatrix: Enable 5.1 to stereo downmixing
 //   - upMatrix: Enable mono to stereo upmixing
 //   - output: Destination slice for interleaved int16 samples
+//   - dither: Noise added to each sample before clipping, nil for none
+//
+// With a nil dither the output is that of FAAD2.
 //
 // Ported from: to_PCM_16bit in ~/dev/faad2/libfaad/output.c:89-152
 func ToPCM16Bit(input [][]float32, channelMap []uint8, channels uint8,
-	frameLen uint16, downMatrix, upMatrix bool, output []int16) {
+	frameLen uint16, downMatrix, upMatrix bool, output []int16, dither func() float32) {
+
+	clip := clip16
+	if dither != nil {
+		clip = func(sample float32) int16 { return clip16(sample + dither()) }
+	}
 
 	switch {
 	case channels == 1 && !downMatrix:
 		// Mono: direct copy with clipping
 		ch := channelMap[0]
 		for i := uint16(0); i < frameLen; i++ {
-			output[i] = clip16(input[ch][i])
+			output[i] = clip(input[ch][i])
 		}
 
 	case channels == 2 && !downMatrix:
@@ -120,7 +153,7 @@ func ToPCM16Bit(input [][]float32, channelMap []uint8, channels uint8,
 			// Mono to stereo upmix: duplicate to both channels
 			ch := channelMap[0]
 			for i := uint16(0); i < frameLen; i++ {
-				sample := clip16(input[ch][i])
+				sample := clip(input[ch][i])
 				output[i*2+0] = sample
 				output[i*2+1] = sample
 			}
@@ -129,8 +162,8 @@ func ToPCM16Bit(input [][]float32, channelMap []uint8, channels uint8,
 			chL := channelMap[0]
 			chR := channelMap[1]
 			for i := uint16(0); i < frameLen; i++ {
-				output[i*2+0] = clip16(input[chL][i])
-				output[i*2+1] = clip16(input[chR][i])
+				output[i*2+0] = clip(input[chL][i])
+				output[i*2+1] = clip(input[chR][i])
 			}
 		}
 
@@ -139,7 +172,7 @@ func ToPCM16Bit(input [][]float32, channelMap []uint8, channels uint8,
 		for ch := uint8(0); ch < channels; ch++ {
 			for i := uint16(0); i < frameLen; i++ {
 				inp := getSample(input, ch, i, downMatrix, channelMap)
-				output[int(i)*int(channels)+int(ch)] = clip16(inp)
+				output[int(i)*int(channels)+int(ch)] = clip(inp)
 			}
 		}
 	}
@@ -257,6 +290,69 @@ func ToPCM32Bit(input [][]float32, channelMap []uint8, channels uint8,
 	}
 }
 
+// clipLeftJustified rounds a 16-bit-referenced sample to bitDepth bits and
+// left-justifies it in an int32, leaving the low 32-bitDepth bits zero.
+// bitDepth is clamped to [8, 32].
+func clipLeftJustified(sample float32, bitDepth uint8) int32 {
+	bitDepth = min(max(bitDepth, 8), 32)
+
+	scaled := math.RoundToEven(math.Ldexp(float64(sample), int(bitDepth)-16))
+	maxVal := math.Ldexp(1, int(bitDepth)-1)
+	if scaled >= maxVal-1 {
+		scaled = maxVal - 1
+	} else if scaled <= -maxVal {
+		scaled = -maxVal
+	}
+	return int32(int64(scaled) << (32 - bitDepth))
+}
+
+// ToPCM32BitLeftJustified converts float32 samples to 32-bit PCM that
+// carries exactly sourceBitDepth significant bits.
+//
+// ToPCM32Bit scales by 65536 as if the full 32 bits were significant, which
+// fills the low bits of content from a 24-bit source with rounding noise.
+// Here each sample is instead quantized to sourceBitDepth bits and placed
+// in the upper bits of the int32, so 24-bit content maps to the same
+// values as ToPCM24Bit shifted left by 8.
+//
+// Parameters are those of ToPCM32Bit, plus sourceBitDepth in [8, 32].
+func ToPCM32BitLeftJustified(input [][]float32, channelMap []uint8, channels uint8,
+	frameLen uint16, downMatrix, upMatrix bool, sourceBitDepth uint8, output []int32) {
+
+	switch {
+	case channels == 1 && !downMatrix:
+		ch := channelMap[0]
+		for i := uint16(0); i < frameLen; i++ {
+			output[i] = clipLeftJustified(input[ch][i], sourceBitDepth)
+		}
+
+	case channels == 2 && !downMatrix:
+		if upMatrix {
+			ch := channelMap[0]
+			for i := uint16(0); i < frameLen; i++ {
+				sample := clipLeftJustified(input[ch][i], sourceBitDepth)
+				output[i*2+0] = sample
+				output[i*2+1] = sample
+			}
+		} else {
+			chL := channelMap[0]
+			chR := channelMap[1]
+			for i := uint16(0); i < frameLen; i++ {
+				output[i*2+0] = clipLeftJustified(input[chL][i], sourceBitDepth)
+				output[i*2+1] = clipLeftJustified(input[chR][i], sourceBitDepth)
+			}
+		}
+
+	default:
+		for ch := uint8(0); ch < channels; ch++ {
+			for i := uint16(0); i < frameLen; i++ {
+				inp := getSample(input, ch, i, downMatrix, channelMap)
+				output[int(i)*int(channels)+int(ch)] = clipLeftJustified(inp, sourceBitDepth)
+			}
+		}
+	}
+}
+
 // ToPCMFloat converts float32 samples to normalized float32 PCM.
 //
 // Input values are scaled by FloatScale (1/32768) to normalize to [-1.0, 1.0].
@@ -330,6 +426,22 @@ func ToPCMFloat(input [][]float32, channelMap []uint8, channels uint8,
 // Ported from: to_PCM_double in ~/dev/faad2/libfaad/output.c:346-396
 func ToPCMDouble(input [][]float32, channelMap []uint8, channels uint8,
 	frameLen uint16, downMatrix, upMatrix bool, output []float64) {
+	toPCMDouble(input, channelMap, channels, frameLen, downMatrix, upMatrix, false, output)
+}
+
+// ToPCMDoubleHighPrecision is ToPCMDouble with the 5.1 to stereo downmix
+// computed in float64 rather than float32. FAAD2 downmixes in float32
+// whatever the output format, which limits double output to float32
+// precision; this variant keeps the full precision of the matrix sums at
+// the cost of no longer being bit-identical to FAAD2. Without downMatrix
+// the output is the same as ToPCMDouble's.
+func ToPCMDoubleHighPrecision(input [][]float32, channelMap []uint8, channels uint8,
+	frameLen uint16, downMatrix, upMatrix bool, output []float64) {
+	toPCMDouble(input, channelMap, channels, frameLen, downMatrix, upMatrix, true, output)
+}
+
+func toPCMDouble(input [][]float32, channelMap []uint8, channels uint8,
+	frameLen uint16, downMatrix, upMatrix, precise bool, output []float64) {
 
 	switch {
 	case channels == 1 && !downMatrix:
@@ -362,8 +474,13 @@ func ToPCMDouble(input [][]float32, channelMap []uint8, channels uint8,
 		// Generic multichannel with optional downmix
 		for ch := uint8(0); ch < channels; ch++ {
 			for i := uint16(0); i < frameLen; i++ {
-				inp := getSample(input, ch, i, downMatrix, channelMap)
-				output[int(i)*int(channels)+int(ch)] = float64(inp) * float64(FloatScale)
+				var inp float64
+				if precise {
+					inp = getSampleDouble(input, ch, i, downMatrix, channelMap)
+				} else {
+					inp = float64(getSample(input, ch, i, downMatrix, channelMap))
+				}
+				output[int(i)*int(channels)+int(ch)] = inp * float64(FloatScale)
 			}
 		}
 	}
@@ -406,7 +523,7 @@ func OutputToPCM(input [][]float32, channelMap []uint8, channels uint8,
 	switch format {
 	case FormatInt16: // FAAD_FMT_16BIT
 		output := make([]int16, totalSamples)
-		ToPCM16Bit(input, channelMap, channels, frameLen, downMatrix, upMatrix, output)
+		ToPCM16Bit(input, channelMap, channels, frameLen, downMatrix, upMatrix, output, nil)
 		return output
 
 	case FormatInt24: // FAAD_FMT_24BIT
@@ -432,7 +549,7 @@ func OutputToPCM(input [][]float32, channelMap []uint8, channels uint8,
 	default:
 		// Default to 16-bit
 		output := make([]int16, totalSamples)
-		ToPCM16Bit(input, channelMap, channels, frameLen, downMatrix, upMatrix, output)
+		ToPCM16Bit(input, channelMap, channels, frameLen, downMatrix, upMatrix, output, nil)
 		return output
 	}
 }
@@ -443,7 +560,7 @@ func OutputToPCM16(input [][]float32, channelMap []uint8, channels uint8,
 	frameLen uint16, downMatrix, upMatrix bool) []int16 {
 
 	output := make([]int16, int(frameLen)*int(channels))
-	ToPCM16Bit(input, channelMap, channels, frameLen, downMatrix, upMatrix, output)
+	ToPCM16Bit(input, channelMap, channels, frameLen, downMatrix, upMatrix, output, nil)
 	return output
 }
 
@@ -467,6 +584,17 @@ func OutputToPCM32(input [][]float32, channelMap []uint8, channels uint8,
 	return output
 }
 
+// OutputToPCM32LeftJustified converts float32 samples to 32-bit PCM with
+// sourceBitDepth significant bits.
+// This is a type-safe wrapper around ToPCM32BitLeftJustified.
+func OutputToPCM32LeftJustified(input [][]float32, channelMap []uint8, channels uint8,
+	frameLen uint16, downMatrix, upMatrix bool, sourceBitDepth uint8) []int32 {
+
+	output := make([]int32, int(frameLen)*int(channels))
+	ToPCM32BitLeftJustified(input, channelMap, channels, frameLen, downMatrix, upMatrix, sourceBitDepth, output)
+	return output
+}
+
 // OutputToPCMFloat32 converts float32 samples to normalized float32 PCM.
 // This is a type-safe wrapper around ToPCMFloat.
 func OutputToPCMFloat32(input [][]float32, channelMap []uint8, channels uint8,
@@ -486,3 +614,14 @@ func OutputToPCMFloat64(input [][]float32, channelMap []uint8, channels uint8,
 	ToPCMDouble(input, channelMap, channels, frameLen, downMatrix, upMatrix, output)
 	return output
 }
+
+// OutputToPCMFloat64HighPrecision converts float32 samples to normalized
+// float64 PCM, downmixing in float64.
+// This is a type-safe wrapper around ToPCMDoubleHighPrecision.
+func OutputToPCMFloat64HighPrecision(input [][]float32, channelMap []uint8, channels uint8,
+	frameLen uint16, downMatrix, upMatrix bool) []float64 {
+
+	output := make([]float64, int(frameLen)*int(channels))
+	ToPCMDoubleHighPrecision(input, channelMap, channels, frameLen, downMatrix, upMatrix, output)
+	return output
+}
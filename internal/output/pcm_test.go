@@ -158,7 +158,7 @@ func TestToPCM16Bit_Mono(t *testing.T) {
 	channelMap := []uint8{0}
 
 	output := make([]int16, 7)
-	ToPCM16Bit(input, channelMap, 1, 7, false, false, output)
+	ToPCM16Bit(input, channelMap, 1, 7, false, false, output, nil)
 
 	expected := []int16{0, 100, -100, 32767, -32768, 32767, -32768}
 	for i, want := range expected {
@@ -177,7 +177,7 @@ func TestToPCM16Bit_Stereo(t *testing.T) {
 	channelMap := []uint8{0, 1}
 
 	output := make([]int16, 6) // 3 samples * 2 channels
-	ToPCM16Bit(input, channelMap, 2, 3, false, false, output)
+	ToPCM16Bit(input, channelMap, 2, 3, false, false, output, nil)
 
 	// Expected: L0, R0, L1, R1, L2, R2
 	expected := []int16{100, -100, 200, -200, 300, -300}
@@ -196,7 +196,7 @@ func TestToPCM16Bit_StereoUpMatrix(t *testing.T) {
 	channelMap := []uint8{0}
 
 	output := make([]int16, 6) // 3 samples * 2 channels
-	ToPCM16Bit(input, channelMap, 2, 3, false, true, output)
+	ToPCM16Bit(input, channelMap, 2, 3, false, true, output, nil)
 
 	// Expected: L0=R0, L1=R1, L2=R2 (mono duplicated to both channels)
 	expected := []int16{100, 100, 200, 200, 300, 300}
@@ -266,7 +266,7 @@ func TestToPCM16Bit_Downmix(t *testing.T) {
 	channelMap := []uint8{0, 1, 2, 3, 4}
 
 	output := make([]int16, 4) // 2 samples * 2 channels
-	ToPCM16Bit(input, channelMap, 2, 2, true, false, output)
+	ToPCM16Bit(input, channelMap, 2, 2, true, false, output, nil)
 
 	// Calculate expected left output for sample 0
 	expectedL0 := DMMul * (input[1][0] + input[0][0]*RSQRT2 + input[3][0]*RSQRT2)
@@ -489,6 +489,74 @@ func TestToPCM32Bit_Downmix(t *testing.T) {
 	}
 }
 
+func TestToPCM32BitLeftJustified_24BitSource(t *testing.T) {
+	// Fractional 16-bit-referenced values carry 8 extra bits of precision
+	input := [][]float32{
+		{0.0, 100.3, -100.3, 0.00390625, 32767.5, -40000.0},
+	}
+	channelMap := []uint8{0}
+
+	output := make([]int32, 6)
+	ToPCM32BitLeftJustified(input, channelMap, 1, 6, false, false, 24, output)
+
+	full := make([]int32, 6)
+	ToPCM32Bit(input, channelMap, 1, 6, false, false, full)
+
+	for i, x := range input[0] {
+		want := clip24(x*256.0) << 8
+		if output[i] != want {
+			t.Errorf("output[%d] = %d, want %d", i, output[i], want)
+		}
+		if output[i]&0xFF != 0 {
+			t.Errorf("output[%d] = %#x has non-zero low byte", i, output[i])
+		}
+	}
+
+	// The 16-bit-referenced path fills the low byte for 100.3
+	if full[1] == output[1] {
+		t.Errorf("expected ToPCM32Bit to differ from the 24-bit path, both %d", full[1])
+	}
+}
+
+func TestToPCM32BitLeftJustified_BitDepths(t *testing.T) {
+	input := [][]float32{{100.3, -32768.0, 40000.0}}
+	channelMap := []uint8{0}
+
+	tests := []struct {
+		bitDepth uint8
+		want     []int32
+	}{
+		{16, []int32{100 << 16, -32768 << 16, 32767 << 16}},
+		{24, []int32{25677 << 8, -8388608 << 8, 8388607 << 8}},
+		{32, []int32{6573261, -2147483648, 2147483647}},
+		{40, []int32{6573261, -2147483648, 2147483647}}, // clamped to 32
+	}
+
+	for _, tt := range tests {
+		output := OutputToPCM32LeftJustified(input, channelMap, 1, 3, false, false, tt.bitDepth)
+		for i, want := range tt.want {
+			if output[i] != want {
+				t.Errorf("bitDepth %d: output[%d] = %d, want %d", tt.bitDepth, i, output[i], want)
+			}
+		}
+	}
+}
+
+func TestToPCM32BitLeftJustified_StereoUpMatrix(t *testing.T) {
+	input := [][]float32{{1.5, -2.25}}
+	channelMap := []uint8{0}
+
+	output := make([]int32, 4)
+	ToPCM32BitLeftJustified(input, channelMap, 2, 2, false, true, 24, output)
+
+	expected := []int32{384 << 8, 384 << 8, -576 << 8, -576 << 8}
+	for i, want := range expected {
+		if output[i] != want {
+			t.Errorf("output[%d] = %d, want %d", i, output[i], want)
+		}
+	}
+}
+
 func TestToPCMFloat_Mono(t *testing.T) {
 	// Input in 16-bit range, will be normalized to [-1.0, 1.0]
 	input := [][]float32{
@@ -672,6 +740,49 @@ func TestToPCMDouble_Downmix(t *testing.T) {
 	}
 }
 
+func TestToPCMDoubleHighPrecision_Downmix(t *testing.T) {
+	// High dynamic range: a loud center nearly cancelled by the left
+	// surround, leaving a quiet left signal. In float32 the center and
+	// surround terms (~8.7e6) round to whole units before they cancel.
+	input := [][]float32{
+		{12345678},  // Center
+		{0.3},       // Left
+		{0.7},       // Right
+		{-12345677}, // Left Surround
+		{-12345679}, // Right Surround
+	}
+	channelMap := []uint8{0, 1, 2, 3, 4}
+
+	// C + Ls = 1 and C + Rs = -1 exactly, so the reference mix has no
+	// cancellation error
+	wantL := dmMulDouble * (float64(input[1][0]) + 1*rsqrt2Double)
+	wantR := dmMulDouble * (float64(input[2][0]) - 1*rsqrt2Double)
+
+	precise := OutputToPCMFloat64HighPrecision(input, channelMap, 2, 1, true, false)
+	single := OutputToPCMFloat64(input, channelMap, 2, 1, true, false)
+
+	for i, want := range []float64{wantL, wantR} {
+		preciseErr := math.Abs(precise[i]/float64(FloatScale) - want)
+		singleErr := math.Abs(single[i]/float64(FloatScale) - want)
+		if preciseErr > 1e-6 {
+			t.Errorf("channel %d: float64 downmix error %g, want < 1e-6", i, preciseErr)
+		}
+		if preciseErr >= singleErr {
+			t.Errorf("channel %d: float64 downmix error %g not below float32 error %g", i, preciseErr, singleErr)
+		}
+	}
+
+	// Without downmix both paths are identical
+	stereo := [][]float32{{0.1, -0.2}, {0.3, -0.4}}
+	a := OutputToPCMFloat64HighPrecision(stereo, []uint8{0, 1}, 2, 2, false, false)
+	b := OutputToPCMFloat64(stereo, []uint8{0, 1}, 2, 2, false, false)
+	for i := range a {
+		if a[i] != b[i] {
+			t.Errorf("stereo output[%d]: got %v, want %v", i, a[i], b[i])
+		}
+	}
+}
+
 func TestToPCMDouble_NoClipping(t *testing.T) {
 	// Double output doesn't clip - values can exceed [-1.0, 1.0]
 	input := [][]float32{
@@ -1014,3 +1125,31 @@ func TestOutputToPCM16_WithUpmix(t *testing.T) {
 		}
 	}
 }
+
+func TestToPCM16Bit_Dither(t *testing.T) {
+	input := [][]float32{make([]float32, 256), make([]float32, 256)}
+	for i := range input[0] {
+		input[0][i] = float32(i)*37.25 - 4000
+		input[1][i] = float32(i%7) - 3.5
+	}
+	channelMap := []uint8{0, 1}
+	want := make([]int16, 512)
+	ToPCM16Bit(input, channelMap, 2, 256, false, false, want, nil)
+
+	// A TPDF source at the extremes of its range
+	var n int
+	tpdf := func() float32 {
+		n++
+		return []float32{-0.999, 0.999, 0.25, -0.5}[n%4]
+	}
+	got := make([]int16, 512)
+	ToPCM16Bit(input, channelMap, 2, 256, false, false, got, tpdf)
+	if n != 512 {
+		t.Errorf("dither called %d times, want 512", n)
+	}
+	for i := range got {
+		if diff := int(got[i]) - int(want[i]); diff < -1 || diff > 1 {
+			t.Errorf("output[%d] = %d, undithered %d", i, got[i], want[i])
+		}
+	}
+}
@@ -0,0 +1,117 @@
+// internal/output/planar.go
+package output
+
+// Planar output
+//
+// The planar variants return one slice per output channel instead of
+// interleaving the channels. They convert through the interleaved
+// functions, so clipping, downmix and upmix are exactly those of the
+// interleaved output; the planes share one backing array.
+
+// Deinterleave splits interleaved samples of the given number of
+// channels into one slice per channel.
+func Deinterleave[T int16 | int32 | float32 | float64](interleaved []T, channels int) [][]T {
+	if channels <= 0 {
+		return nil
+	}
+	frameLen := len(interleaved) / channels
+	backing := make([]T, frameLen*channels)
+	planes := make([][]T, channels)
+	for ch := range planes {
+		plane := backing[ch*frameLen : (ch+1)*frameLen : (ch+1)*frameLen]
+		for i := range plane {
+			plane[i] = interleaved[i*channels+ch]
+		}
+		planes[ch] = plane
+	}
+	return planes
+}
+
+// OutputToPCMPlanar converts float32 samples to the requested PCM format,
+// one slice per output channel.
+//
+// Returns a value of the appropriate type:
+//   - format 1 (16-bit): [][]int16
+//   - format 2 (24-bit): [][]int32
+//   - format 3 (32-bit): [][]int32
+//   - format 4 (float):  [][]float32
+//   - format 5 (double): [][]float64
+//
+// Parameters are those of OutputToPCM.
+func OutputToPCMPlanar(input [][]float32, channelMap []uint8, channels uint8,
+	frameLen uint16, format uint8, downMatrix, upMatrix bool) interface{} {
+
+	return deinterleaveAny(OutputToPCM(input, channelMap, channels, frameLen, format, downMatrix, upMatrix), int(channels))
+}
+
+// deinterleaveAny deinterleaves a slice returned by OutputToPCM.
+func deinterleaveAny(out interface{}, channels int) interface{} {
+	switch out := out.(type) {
+	case []int16:
+		return Deinterleave(out, channels)
+	case []int32:
+		return Deinterleave(out, channels)
+	case []float32:
+		return Deinterleave(out, channels)
+	case []float64:
+		return Deinterleave(out, channels)
+	default:
+		return out
+	}
+}
+
+// OutputToPCM16Planar converts float32 samples to planar 16-bit PCM.
+func OutputToPCM16Planar(input [][]float32, channelMap []uint8, channels uint8,
+	frameLen uint16, downMatrix, upMatrix bool) [][]int16 {
+
+	return Deinterleave(OutputToPCM16(input, channelMap, channels, frameLen, downMatrix, upMatrix), int(channels))
+}
+
+// OutputToPCM24Planar converts float32 samples to planar 24-bit PCM
+// (stored in int32).
+func OutputToPCM24Planar(input [][]float32, channelMap []uint8, channels uint8,
+	frameLen uint16, downMatrix, upMatrix bool) [][]int32 {
+
+	return Deinterleave(OutputToPCM24(input, channelMap, channels, frameLen, downMatrix, upMatrix), int(channels))
+}
+
+// OutputToPCM32Planar converts float32 samples to planar 32-bit PCM.
+func OutputToPCM32Planar(input [][]float32, channelMap []uint8, channels uint8,
+	frameLen uint16, downMatrix, upMatrix bool) [][]int32 {
+
+	return Deinterleave(OutputToPCM32(input, channelMap, channels, frameLen, downMatrix, upMatrix), int(channels))
+}
+
+// OutputToPCM32LeftJustifiedPlanar converts float32 samples to planar
+// 32-bit PCM with sourceBitDepth significant bits.
+func OutputToPCM32LeftJustifiedPlanar(input [][]float32, channelMap []uint8, channels uint8,
+	frameLen uint16, downMatrix, upMatrix bool, sourceBitDepth uint8) [][]int32 {
+
+	return Deinterleave(OutputToPCM32LeftJustified(input, channelMap, channels, frameLen,
+		downMatrix, upMatrix, sourceBitDepth), int(channels))
+}
+
+// OutputToPCMFloat32Planar converts float32 samples to planar normalized
+// float32 PCM.
+func OutputToPCMFloat32Planar(input [][]float32, channelMap []uint8, channels uint8,
+	frameLen uint16, downMatrix, upMatrix bool) [][]float32 {
+
+	return Deinterleave(OutputToPCMFloat32(input, channelMap, channels, frameLen, downMatrix, upMatrix), int(channels))
+}
+
+// OutputToPCMFloat64Planar converts float32 samples to planar normalized
+// float64 PCM.
+func OutputToPCMFloat64Planar(input [][]float32, channelMap []uint8, channels uint8,
+	frameLen uint16, downMatrix, upMatrix bool) [][]float64 {
+
+	return Deinterleave(OutputToPCMFloat64(input, channelMap, channels, frameLen, downMatrix, upMatrix), int(channels))
+}
+
+// OutputToPCMFloat64HighPrecisionPlanar converts float32 samples to planar
+// normalized float64 PCM, downmixing in float64.
+func OutputToPCMFloat64HighPrecisionPlanar(input [][]float32, channelMap []uint8, channels uint8,
+	frameLen uint16, downMatrix, upMatrix bool) [][]float64 {
+
+	return Deinterleave(OutputToPCMFloat64HighPrecision(input, channelMap, channels, frameLen,
+		downMatrix, upMatrix), int(channels))
+}
@@ -0,0 +1,109 @@
+// internal/output/planar_test.go
+package output
+
+import (
+	"reflect"
+	"testing"
+)
+
+// planarTestInput returns five channels of frameLen samples with distinct
+// values, some beyond the 16-bit range to exercise clipping.
+func planarTestInput(frameLen int) [][]float32 {
+	input := make([][]float32, 5)
+	for ch := range input {
+		input[ch] = make([]float32, frameLen)
+		for i := range input[ch] {
+			input[ch][i] = float32((ch+1)*1000+i*37) * float32(1-2*(i%2))
+		}
+	}
+	input[1][3] = 40000
+	input[2][4] = -40000
+	return input
+}
+
+func TestOutputToPCMPlanar_MatchesInterleaved(t *testing.T) {
+	const frameLen = 16
+	input := planarTestInput(frameLen)
+
+	tests := []struct {
+		name       string
+		channelMap []uint8
+		channels   uint8
+		downMatrix bool
+		upMatrix   bool
+	}{
+		{"stereo", []uint8{1, 2}, 2, false, false},
+		{"5.1 downmix", []uint8{0, 1, 2, 3, 4}, 2, true, false},
+		{"mono upmix", []uint8{0}, 2, false, true},
+		{"5 channels", []uint8{0, 1, 2, 3, 4}, 5, false, false},
+	}
+	formats := []uint8{FormatInt16, FormatInt24, FormatInt32, FormatFloat32, FormatFloat64}
+
+	for _, tt := range tests {
+		for _, format := range formats {
+			interleaved := OutputToPCM(input, tt.channelMap, tt.channels, frameLen, format, tt.downMatrix, tt.upMatrix)
+			planar := OutputToPCMPlanar(input, tt.channelMap, tt.channels, frameLen, format, tt.downMatrix, tt.upMatrix)
+
+			got := reflect.ValueOf(planar)
+			want := reflect.ValueOf(interleaved)
+			if got.Kind() != reflect.Slice || got.Len() != int(tt.channels) {
+				t.Fatalf("%s format %d: got %T with %d planes", tt.name, format, planar, got.Len())
+			}
+			for ch := 0; ch < int(tt.channels); ch++ {
+				plane := got.Index(ch)
+				if plane.Len() != frameLen {
+					t.Fatalf("%s format %d: plane %d has %d samples", tt.name, format, ch, plane.Len())
+				}
+				for i := 0; i < frameLen; i++ {
+					g := plane.Index(i).Interface()
+					w := want.Index(i*int(tt.channels) + ch).Interface()
+					if g != w {
+						t.Fatalf("%s format %d: plane %d sample %d = %v, want %v", tt.name, format, ch, i, g, w)
+					}
+				}
+			}
+		}
+	}
+}
+
+func TestOutputToPCMPlanar_TypedVariants(t *testing.T) {
+	const frameLen = 8
+	input := planarTestInput(frameLen)
+	channelMap := []uint8{0, 1, 2, 3, 4}
+
+	// 5.1 downmix to stereo through each typed variant
+	if got, want := OutputToPCM16Planar(input, channelMap, 2, frameLen, true, false),
+		Deinterleave(OutputToPCM16(input, channelMap, 2, frameLen, true, false), 2); !reflect.DeepEqual(got, want) {
+		t.Errorf("OutputToPCM16Planar = %v, want %v", got, want)
+	}
+	if got, want := OutputToPCM24Planar(input, channelMap, 2, frameLen, true, false),
+		Deinterleave(OutputToPCM24(input, channelMap, 2, frameLen, true, false), 2); !reflect.DeepEqual(got, want) {
+		t.Errorf("OutputToPCM24Planar = %v, want %v", got, want)
+	}
+	if got, want := OutputToPCM32Planar(input, channelMap, 2, frameLen, true, false),
+		Deinterleave(OutputToPCM32(input, channelMap, 2, frameLen, true, false), 2); !reflect.DeepEqual(got, want) {
+		t.Errorf("OutputToPCM32Planar = %v, want %v", got, want)
+	}
+	if got, want := OutputToPCMFloat32Planar(input, channelMap, 2, frameLen, true, false),
+		Deinterleave(OutputToPCMFloat32(input, channelMap, 2, frameLen, true, false), 2); !reflect.DeepEqual(got, want) {
+		t.Errorf("OutputToPCMFloat32Planar = %v, want %v", got, want)
+	}
+	if got, want := OutputToPCMFloat64Planar(input, channelMap, 2, frameLen, true, false),
+		Deinterleave(OutputToPCMFloat64(input, channelMap, 2, frameLen, true, false), 2); !reflect.DeepEqual(got, want) {
+		t.Errorf("OutputToPCMFloat64Planar = %v, want %v", got, want)
+	}
+}
+
+func TestDeinterleave(t *testing.T) {
+	got := Deinterleave([]int16{1, 2, 3, 4, 5, 6}, 3)
+	want := [][]int16{{1, 4}, {2, 5}, {3, 6}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Deinterleave = %v, want %v", got, want)
+	}
+
+	// Appending to a plane must not overwrite the next one
+	got[0] = append(got[0], 99)
+	if got[1][0] != 2 {
+		t.Errorf("append to plane 0 changed plane 1: %v", got[1])
+	}
+}
@@ -0,0 +1,89 @@
+// internal/sbr/decoder.go
+package sbr
+
+import "github.com/llehouerou/go-aac/internal/bits"
+
+// Extension types of SBR fill element payloads.
+//
+// Ported from: ~/dev/faad2/libfaad/syntax.h
+const (
+	ExtSBRData    = 13 // EXT_SBR_DATA
+	ExtSBRDataCRC = 14 // EXT_SBR_DATA_CRC
+)
+
+// Decoder holds the SBR state of one channel element.
+//
+// Ported from: sbr_info in ~/dev/faad2/libfaad/sbr_dec.h
+type Decoder struct {
+	sampleRate  uint32 // SBR output sample rate
+	header      Header
+	headerCount uint32
+	tables      *FrequencyTables
+}
+
+// NewDecoder returns the SBR decoder of a channel element whose SBR
+// output runs at sampleRate Hz, twice the core sample rate.
+//
+// Ported from: sbrDecodeInit() in ~/dev/faad2/libfaad/sbr_dec.c
+func NewDecoder(sampleRate uint32) *Decoder {
+	return &Decoder{sampleRate: sampleRate}
+}
+
+// DecodeExtension parses the sbr_extension_data() of a fill element
+// payload of count bytes, r being positioned at its 4-bit extension type.
+// It always leaves r at the end of the payload.
+//
+// A header describing invalid frequency tables is returned as
+// ErrInvalidParameter and the previous header is kept, so decoding can
+// continue with it. The sbr_data() that follows the header is skipped.
+//
+// Ported from: sbr_extension_data() in ~/dev/faad2/libfaad/sbr_syntax.c
+func (d *Decoder) DecodeExtension(r *bits.Reader, count uint32) error {
+	start := r.GetProcessedBits()
+	defer func() {
+		end := start + 8*count
+		for pos := r.GetProcessedBits(); pos < end; pos = r.GetProcessedBits() {
+			r.FlushBits(uint(min(end-pos, 32)))
+		}
+	}()
+
+	if r.GetBits(4) == ExtSBRDataCRC {
+		r.FlushBits(10) // bs_sbr_crc_bits
+	}
+
+	if r.Get1Bit() == 0 {
+		// bs_header_flag: no new header in this frame
+		return nil
+	}
+
+	header := ParseHeader(r)
+	d.headerCount++
+	if d.tables != nil && header.sameTables(&d.header) {
+		d.header = header
+		return nil
+	}
+
+	t, err := NewFrequencyTables(&header, d.sampleRate)
+	if err != nil {
+		return err
+	}
+	d.header = header
+	d.tables = t
+	return nil
+}
+
+// Header returns the last valid SBR header and whether one was received.
+func (d *Decoder) Header() (Header, bool) {
+	return d.header, d.tables != nil
+}
+
+// Tables returns the frequency band tables of the last valid header, or
+// nil before one was received.
+func (d *Decoder) Tables() *FrequencyTables {
+	return d.tables
+}
+
+// SampleRate returns the SBR output sample rate.
+func (d *Decoder) SampleRate() uint32 {
+	return d.sampleRate
+}
@@ -0,0 +1,98 @@
+package sbr
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/llehouerou/go-aac/internal/bits"
+)
+
+// sbrPayload builds an SBR fill element payload of count bytes: the
+// extension type, the CRC bits for ExtSBRDataCRC, then the header flag
+// and header if h is non-nil. The rest stands in for sbr_data().
+func sbrPayload(extType uint32, h *Header, count int) []byte {
+	w := &bitWriter{}
+	w.writeBits(extType, 4)
+	if extType == ExtSBRDataCRC {
+		w.writeBits(0x3FF, 10)
+	}
+	if h != nil {
+		w.writeBits(1, 1)
+		writeHeader(w, *h, true, false)
+	} else {
+		w.writeBits(0, 1)
+	}
+	for len(w.buf) < count {
+		w.writeBits(0, 8)
+	}
+	// A marker byte after the payload
+	w.buf = append(w.buf[:count], 0xA5)
+	return w.buf
+}
+
+func TestDecoder_DecodeExtension(t *testing.T) {
+	h := Header{StartFreq: 5, StopFreq: 9, FreqScale: 2, AlterScale: 1, NoiseBands: 2}
+
+	for _, extType := range []uint32{ExtSBRData, ExtSBRDataCRC} {
+		d := NewDecoder(44100)
+		if _, ok := d.Header(); ok {
+			t.Fatal("header reported before any was received")
+		}
+
+		r := bits.NewReader(sbrPayload(extType, &h, 12))
+		if err := d.DecodeExtension(r, 12); err != nil {
+			t.Fatalf("ext %d: %v", extType, err)
+		}
+		if r.GetBits(8) != 0xA5 {
+			t.Errorf("ext %d: payload not consumed exactly", extType)
+		}
+		got, ok := d.Header()
+		if !ok || got.StartFreq != 5 || got.StopFreq != 9 {
+			t.Errorf("ext %d: header %+v, %v", extType, got, ok)
+		}
+		if ft := d.Tables(); ft == nil || ft.K0 != 14 || ft.K2 != 47 {
+			t.Errorf("ext %d: tables %+v, want k0 14, k2 47", extType, ft)
+		}
+	}
+}
+
+func TestDecoder_DecodeExtension_KeepsTables(t *testing.T) {
+	h := Header{StartFreq: 5, StopFreq: 9, FreqScale: 2, AlterScale: 1, NoiseBands: 2}
+	d := NewDecoder(44100)
+	if err := d.DecodeExtension(bits.NewReader(sbrPayload(ExtSBRData, &h, 4)), 4); err != nil {
+		t.Fatal(err)
+	}
+	tables := d.Tables()
+
+	// No header, then the same header with another amp_res
+	if err := d.DecodeExtension(bits.NewReader(sbrPayload(ExtSBRData, nil, 4)), 4); err != nil {
+		t.Fatal(err)
+	}
+	h.AmpRes = 1
+	if err := d.DecodeExtension(bits.NewReader(sbrPayload(ExtSBRData, &h, 4)), 4); err != nil {
+		t.Fatal(err)
+	}
+	if d.Tables() != tables {
+		t.Error("tables recomputed for an unchanged layout")
+	}
+	if got, _ := d.Header(); got.AmpRes != 1 {
+		t.Error("header not updated")
+	}
+
+	// An invalid header is reported and the previous one kept
+	want, _ := d.Header()
+	bad := h
+	bad.XOverBand = 7
+	bad.StopFreq = 0
+	bad.StartFreq = 15
+	r := bits.NewReader(sbrPayload(ExtSBRData, &bad, 4))
+	if err := d.DecodeExtension(r, 4); !errors.Is(err, ErrInvalidParameter) {
+		t.Errorf("invalid header: got %v, want ErrInvalidParameter", err)
+	}
+	if r.GetBits(8) != 0xA5 {
+		t.Error("invalid header payload not consumed exactly")
+	}
+	if got, _ := d.Header(); got != want || d.Tables() != tables {
+		t.Errorf("invalid header replaced the previous one: %+v", got)
+	}
+}
@@ -0,0 +1,15 @@
+// Package sbr parses the Spectral Band Replication payloads of HE-AAC
+// streams, so that the aac decoder can detect SBR and validate its
+// headers.
+//
+// Its scope is the SBR bitstream header and the frequency band tables
+// derived from it (master, high/low resolution and noise floor tables),
+// parsed from every SBR fill element the aac decoder meets. The
+// reconstruction of the high band is out of scope: sbr_data() (grids,
+// delta coding and the Huffman tables of sbr_huff.c) is skipped, and
+// there is no HF generation (sbr_hfgen.c), envelope adjustment
+// (sbr_hfadj.c) or QMF bank (sbr_qmf.c). HE-AAC streams therefore decode
+// to their AAC core, at the core sample rate.
+//
+// Ported from: ~/dev/faad2/libfaad/sbr_syntax.c, sbr_fbt.c
+package sbr
@@ -0,0 +1,331 @@
+// internal/sbr/fbt.go
+package sbr
+
+import (
+	"errors"
+	"math"
+	"slices"
+
+	"github.com/llehouerou/go-aac/internal/tables"
+)
+
+// ErrInvalidParameter is returned when an SBR header describes frequency
+// band tables outside the limits of the standard.
+var ErrInvalidParameter = errors.New("sbr: invalid SBR parameter")
+
+// Table size limits.
+//
+// Ported from: ~/dev/faad2/libfaad/sbr_dec.h
+const (
+	maxMasterBands = 64 // f_master has at most 64 bands
+	maxNoiseBands  = 5  // N_Q
+	maxKx          = 32 // highest start of the SBR range
+	qmfChannels    = 64 // synthesis QMF bands
+)
+
+// startMinTable is the lowest QMF start channel per sample rate index,
+// NINT(startMin * 128 / fs) with startMin 3, 4 or 5 kHz.
+//
+// Ported from: qmf_start_channel() in ~/dev/faad2/libfaad/sbr_fbt.c
+var startMinTable = [12]uint8{7, 7, 10, 11, 12, 16, 16, 17, 24, 32, 35, 48}
+
+// offsetIndexTable selects the startOffsets row per sample rate index.
+var offsetIndexTable = [12]uint8{5, 5, 4, 4, 4, 3, 2, 1, 0, 6, 6, 6}
+
+// startOffsets maps bs_start_freq to an offset from startMin. Row 6 is
+// also used when bs_samplerate_mode is 0.
+var startOffsets = [7][16]int8{
+	{-8, -7, -6, -5, -4, -3, -2, -1, 0, 1, 2, 3, 4, 5, 6, 7}, // 16000
+	{-5, -4, -3, -2, -1, 0, 1, 2, 3, 4, 5, 6, 7, 9, 11, 13},  // 22050
+	{-5, -3, -2, -1, 0, 1, 2, 3, 4, 5, 6, 7, 9, 11, 13, 16},  // 24000
+	{-6, -4, -2, -1, 0, 1, 2, 3, 4, 5, 6, 7, 9, 11, 13, 16},  // 32000
+	{-4, -2, -1, 0, 1, 2, 3, 4, 5, 6, 7, 9, 11, 13, 16, 20},  // 44100-64000
+	{-2, -1, 0, 1, 2, 3, 4, 5, 6, 7, 9, 11, 13, 16, 20, 24},  // >64000
+	{0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15},   // <16000
+}
+
+// FrequencyTables holds the frequency band tables of an SBR header, in
+// QMF channels.
+//
+// Ported from: sbr_info tables in ~/dev/faad2/libfaad/sbr_dec.h
+type FrequencyTables struct {
+	K0 uint8 // First QMF channel of the master table
+	K2 uint8 // Last QMF channel of the master table
+	Kx uint8 // First QMF channel of the SBR range
+	M  uint8 // Number of QMF channels in the SBR range
+
+	Master  []uint8 // f_master, NMaster+1 borders
+	High    []uint8 // f_table_res[HI], NHigh+1 borders
+	Low     []uint8 // f_table_res[LO], NLow+1 borders
+	Noise   []uint8 // f_table_noise, NQ+1 borders
+	NMaster uint8
+	NHigh   uint8
+	NLow    uint8
+	NQ      uint8
+}
+
+// NewFrequencyTables derives the frequency band tables of h for an SBR
+// output sample rate of sampleRate Hz (twice the core rate).
+//
+// Ported from: calc_sbr_tables() in ~/dev/faad2/libfaad/sbr_syntax.c
+func NewFrequencyTables(h *Header, sampleRate uint32) (*FrequencyTables, error) {
+	k0 := qmfStartChannel(h.StartFreq, true, sampleRate)
+	k2 := qmfStopChannel(h.StopFreq, sampleRate, k0)
+	if k2 <= k0 {
+		return nil, ErrInvalidParameter
+	}
+
+	// Maximum width of the master range per sample rate
+	switch {
+	case sampleRate >= 48000:
+		if k2-k0 > 32 {
+			return nil, ErrInvalidParameter
+		}
+	case sampleRate <= 32000:
+		if k2-k0 > 48 {
+			return nil, ErrInvalidParameter
+		}
+	default: // 44100
+		if k2-k0 > 45 {
+			return nil, ErrInvalidParameter
+		}
+	}
+
+	var master []uint8
+	var err error
+	if h.FreqScale == 0 {
+		master, err = masterFrequencyTableFs0(k0, k2, h.AlterScale)
+	} else {
+		master, err = masterFrequencyTable(k0, k2, h.FreqScale, h.AlterScale)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	t := &FrequencyTables{K0: k0, K2: k2, Master: master, NMaster: uint8(len(master) - 1)}
+	if err := t.derive(h.XOverBand, h.NoiseBands); err != nil {
+		return nil, err
+	}
+	return t, nil
+}
+
+// qmfStartChannel returns k0, the first QMF channel of the master table.
+//
+// Ported from: qmf_start_channel() in ~/dev/faad2/libfaad/sbr_fbt.c
+func qmfStartChannel(bsStartFreq uint8, samplerateMode bool, sampleRate uint32) uint8 {
+	srIndex := tables.GetSRIndex(sampleRate)
+	startMin := int(startMinTable[srIndex])
+	row := 6
+	if samplerateMode {
+		row = int(offsetIndexTable[srIndex])
+	}
+	return uint8(startMin + int(startOffsets[row][bsStartFreq&15]))
+}
+
+// qmfStopChannel returns k2, the QMF channel ending the master table:
+// 2*k0 or 3*k0 for bs_stop_freq 14 and 15, and otherwise stopMin plus
+// the sum of the first bs_stop_freq steps of an exponential scale from
+// stopMin to channel 64.
+//
+// Ported from: qmf_stop_channel() in ~/dev/faad2/libfaad/sbr_fbt.c
+func qmfStopChannel(bsStopFreq uint8, sampleRate uint32, k0 uint8) uint8 {
+	switch bsStopFreq {
+	case 15:
+		return uint8(min(64, 3*int(k0)))
+	case 14:
+		return uint8(min(64, 2*int(k0)))
+	}
+
+	// stopMin is 6, 8 or 10 kHz in QMF channels
+	var stopMinHz float64
+	switch {
+	case sampleRate < 32000:
+		stopMinHz = 6000
+	case sampleRate < 64000:
+		stopMinHz = 8000
+	default:
+		stopMinHz = 10000
+	}
+	stopMin := nint(stopMinHz * 2 * qmfChannels / float64(sampleRate))
+
+	// stopDk holds the steps of stopVec[k] = NINT(stopMin * (64/stopMin)^(k/13)),
+	// sorted ascending
+	stopDk := exponentialSteps(stopMin, qmfChannels, 13)
+	slices.Sort(stopDk)
+	k2 := stopMin
+	for _, dk := range stopDk[:bsStopFreq] {
+		k2 += dk
+	}
+	return uint8(min(64, k2))
+}
+
+// masterFrequencyTableFs0 builds the linear master table of bs_freq_scale
+// 0: bands of 1 (or 2 with bs_alter_scale) QMF channels, the difference
+// to k2 being spread over the bands at the end.
+//
+// Ported from: master_frequency_table_fs0() in ~/dev/faad2/libfaad/sbr_fbt.c
+func masterFrequencyTableFs0(k0, k2, alterScale uint8) ([]uint8, error) {
+	dk := 1
+	if alterScale != 0 {
+		dk = 2
+	}
+	numBands := 2 * ((int(k2) - int(k0)) / (2 * dk))
+	numBands = min(numBands, maxMasterBands-1)
+	if numBands <= 0 {
+		return nil, ErrInvalidParameter
+	}
+
+	vDk := make([]int, numBands)
+	for k := range vDk {
+		vDk[k] = dk
+	}
+
+	k2Diff := int(k2) - (int(k0) + numBands*dk)
+	if max(k2Diff, -k2Diff) > numBands {
+		// More channels left over than bands to spread them on
+		return nil, ErrInvalidParameter
+	}
+	if k2Diff != 0 {
+		incr, k := 1, 0
+		if k2Diff > 0 {
+			incr, k = -1, numBands-1
+		}
+		for k2Diff != 0 {
+			vDk[k] -= incr
+			k += incr
+			k2Diff += incr
+		}
+	}
+
+	master := make([]uint8, numBands+1)
+	master[0] = k0
+	for k := 1; k <= numBands; k++ {
+		master[k] = master[k-1] + uint8(vDk[k-1])
+	}
+	return master, nil
+}
+
+// masterFrequencyTable builds the master table of bs_freq_scale 1-3: 12,
+// 10 or 8 bands per octave, in two regions when k2/k0 exceeds 2.2449, the
+// second warped by 1.3 with bs_alter_scale.
+//
+// Ported from: master_frequency_table() in ~/dev/faad2/libfaad/sbr_fbt.c
+func masterFrequencyTable(k0, k2, freqScale, alterScale uint8) ([]uint8, error) {
+	bands := [3]float64{12, 10, 8}[(freqScale-1)%3]
+	warp := 1.0
+	if alterScale != 0 {
+		warp = 1.3
+	}
+
+	twoRegions := float64(k2)/float64(k0) > 2.2449
+	k1 := k2
+	if twoRegions {
+		k1 = 2 * k0
+	}
+
+	numBands0 := 2 * nint(bands*math.Log(float64(k1)/float64(k0))/(2*math.Ln2))
+	numBands0 = min(numBands0, maxMasterBands-1)
+	if numBands0 <= 0 {
+		return nil, ErrInvalidParameter
+	}
+	vDk0 := exponentialSteps(k0, k1, numBands0)
+	slices.Sort(vDk0)
+
+	master := make([]uint8, 0, maxMasterBands+1)
+	master = append(master, k0)
+	for _, dk := range vDk0 {
+		if dk <= 0 {
+			return nil, ErrInvalidParameter
+		}
+		master = append(master, master[len(master)-1]+uint8(dk))
+	}
+	if !twoRegions {
+		return master, nil
+	}
+
+	numBands1 := 2 * nint(bands*math.Log(float64(k2)/float64(k1))/(2*math.Ln2*warp))
+	numBands1 = min(numBands1, maxMasterBands-1-numBands0)
+	if numBands1 <= 0 {
+		return master, nil
+	}
+	vDk1 := exponentialSteps(k1, k2, numBands1)
+
+	// The second region may not have bands narrower than the first
+	if minDk1, maxDk0 := slices.Min(vDk1), slices.Max(vDk0); minDk1 < maxDk0 {
+		slices.Sort(vDk1)
+		change := maxDk0 - vDk1[0]
+		vDk1[0] += change
+		vDk1[numBands1-1] -= change
+	}
+	slices.Sort(vDk1)
+
+	for _, dk := range vDk1 {
+		if dk <= 0 {
+			return nil, ErrInvalidParameter
+		}
+		master = append(master, master[len(master)-1]+uint8(dk))
+	}
+	return master, nil
+}
+
+// exponentialSteps returns the numBands band widths of an exponential
+// scale from a to b: NINT(a*(b/a)^((k+1)/n)) - NINT(a*(b/a)^(k/n)).
+func exponentialSteps[T uint8 | int](a, b T, numBands int) []int {
+	ratio := float64(b) / float64(a)
+	border := func(k int) int {
+		return nint(float64(a) * math.Pow(ratio, float64(k)/float64(numBands)))
+	}
+	steps := make([]int, numBands)
+	for k := range steps {
+		steps[k] = border(k+1) - border(k)
+	}
+	return steps
+}
+
+// derive computes the high and low resolution and noise floor tables
+// from the master table.
+//
+// Ported from: derived_frequency_table() in ~/dev/faad2/libfaad/sbr_fbt.c
+func (t *FrequencyTables) derive(xoverBand, noiseBands uint8) error {
+	if xoverBand >= t.NMaster {
+		return ErrInvalidParameter
+	}
+
+	t.NHigh = t.NMaster - xoverBand
+	t.High = t.Master[xoverBand:]
+	t.Kx = t.High[0]
+	t.M = t.High[t.NHigh] - t.Kx
+	if t.Kx > maxKx || int(t.Kx)+int(t.M) > qmfChannels {
+		return ErrInvalidParameter
+	}
+
+	// Every other border of the high table, keeping the first band
+	// single when NHigh is odd
+	minus := t.NHigh & 1
+	t.NLow = t.NHigh/2 + t.NHigh&1
+	t.Low = make([]uint8, t.NLow+1)
+	t.Low[0] = t.High[0]
+	for k := uint8(1); k <= t.NLow; k++ {
+		t.Low[k] = t.High[2*k-minus]
+	}
+
+	t.NQ = 1
+	if noiseBands != 0 {
+		nq := nint(float64(noiseBands) * math.Log(float64(t.K2)/float64(t.Kx)) / math.Ln2)
+		t.NQ = uint8(min(maxNoiseBands, max(1, nq)))
+	}
+	t.Noise = make([]uint8, t.NQ+1)
+	i := uint8(0)
+	for k := uint8(0); k <= t.NQ; k++ {
+		if k > 0 {
+			i += (t.NLow - i) / (t.NQ + 1 - k)
+		}
+		t.Noise[k] = t.Low[i]
+	}
+	return nil
+}
+
+// nint rounds a non-negative value to the nearest integer, halves up.
+func nint(x float64) int {
+	return int(x + 0.5)
+}
@@ -0,0 +1,196 @@
+package sbr
+
+import (
+	"errors"
+	"slices"
+	"testing"
+)
+
+func TestQMFStartChannel(t *testing.T) {
+	tests := []struct {
+		startFreq  uint8
+		sampleRate uint32
+		want       uint8
+	}{
+		{0, 44100, 8},  // startMin 12, offset -4
+		{5, 44100, 14}, // offset 2
+		{15, 44100, 32},
+		{0, 32000, 10},
+		{15, 24000, 32},
+		{8, 16000, 24},
+		{0, 96000, 5},
+	}
+	for _, tt := range tests {
+		if got := qmfStartChannel(tt.startFreq, true, tt.sampleRate); got != tt.want {
+			t.Errorf("start %d at %d Hz: got %d, want %d", tt.startFreq, tt.sampleRate, got, tt.want)
+		}
+	}
+
+	// bs_samplerate_mode 0 uses the identity offsets
+	if got := qmfStartChannel(3, false, 44100); got != 15 {
+		t.Errorf("samplerate mode 0: got %d, want 15", got)
+	}
+}
+
+func TestQMFStopChannel(t *testing.T) {
+	// stopMin per sample rate index, as tabulated in FAAD2
+	stopMin := []uint8{13, 15, 20, 21, 23, 32, 32, 35, 48, 64, 70, 96}
+	rates := []uint32{96000, 88200, 64000, 48000, 44100, 32000, 24000, 22050, 16000, 12000, 11025, 8000}
+	for i, rate := range rates {
+		if got, want := qmfStopChannel(0, rate, 0), min(stopMin[i], 64); got != want {
+			t.Errorf("stopMin at %d Hz: got %d, want %d", rate, got, want)
+		}
+	}
+
+	// At 44100 Hz stopMin 23 steps up to 64 in 13 steps
+	tests := []struct {
+		stopFreq uint8
+		k0       uint8
+		want     uint8
+	}{
+		{9, 0, 47},
+		{13, 0, 64},
+		{14, 20, 40},
+		{15, 20, 60},
+		{15, 30, 64},
+	}
+	for _, tt := range tests {
+		if got := qmfStopChannel(tt.stopFreq, 44100, tt.k0); got != tt.want {
+			t.Errorf("stop %d, k0 %d: got %d, want %d", tt.stopFreq, tt.k0, got, tt.want)
+		}
+	}
+}
+
+func TestMasterFrequencyTableFs0(t *testing.T) {
+	got, err := masterFrequencyTableFs0(10, 20, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := []uint8{10, 11, 12, 13, 14, 15, 16, 17, 18, 19, 20}; !slices.Equal(got, want) {
+		t.Errorf("alter 0: got %v, want %v", got, want)
+	}
+
+	// Bands of 2, the 2 channels left widening the last bands
+	got, err = masterFrequencyTableFs0(10, 20, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := []uint8{10, 12, 14, 17, 20}; !slices.Equal(got, want) {
+		t.Errorf("alter 1: got %v, want %v", got, want)
+	}
+
+	if _, err := masterFrequencyTableFs0(10, 11, 1); !errors.Is(err, ErrInvalidParameter) {
+		t.Errorf("no band: got %v, want ErrInvalidParameter", err)
+	}
+}
+
+func TestMasterFrequencyTable_TwoRegions(t *testing.T) {
+	// 10 bands per octave from 12 to 24, then 8 channels warped by 1.3
+	got, err := masterFrequencyTable(12, 32, 2, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []uint8{12, 13, 14, 15, 16, 17, 18, 19, 20, 22, 24, 26, 28, 30, 32}
+	if !slices.Equal(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+}
+
+func TestDerivedFrequencyTables(t *testing.T) {
+	master := []uint8{12, 13, 14, 15, 16, 17, 18, 19, 20, 22, 24, 26, 28, 30, 32}
+	ft := &FrequencyTables{K0: 12, K2: 32, Master: master, NMaster: 14}
+	if err := ft.derive(0, 2); err != nil {
+		t.Fatal(err)
+	}
+
+	if ft.Kx != 12 || ft.M != 20 || ft.NHigh != 14 {
+		t.Errorf("Kx %d, M %d, NHigh %d; want 12, 20, 14", ft.Kx, ft.M, ft.NHigh)
+	}
+	if want := []uint8{12, 14, 16, 18, 20, 24, 28, 32}; !slices.Equal(ft.Low, want) {
+		t.Errorf("Low = %v, want %v", ft.Low, want)
+	}
+	// NQ = NINT(2 * log2(32/12)) = 3
+	if want := []uint8{12, 16, 20, 32}; !slices.Equal(ft.Noise, want) {
+		t.Errorf("Noise = %v, want %v", ft.Noise, want)
+	}
+
+	// An odd NHigh keeps the first low band single
+	if err := ft.derive(1, 0); err != nil {
+		t.Fatal(err)
+	}
+	if want := []uint8{13, 14, 16, 18, 20, 24, 28, 32}; !slices.Equal(ft.Low, want) || ft.NQ != 1 {
+		t.Errorf("Low = %v, NQ %d; want %v, 1", ft.Low, ft.NQ, want)
+	}
+
+	if err := ft.derive(14, 2); !errors.Is(err, ErrInvalidParameter) {
+		t.Errorf("xover at the last band: got %v, want ErrInvalidParameter", err)
+	}
+}
+
+// TestNewFrequencyTables_Invariants checks the structure of the tables of
+// every valid header at the usual SBR output rates.
+func TestNewFrequencyTables_Invariants(t *testing.T) {
+	valid := 0
+	for _, rate := range []uint32{32000, 44100, 48000} {
+		for start := uint8(0); start < 16; start++ {
+			for stop := uint8(0); stop < 16; stop++ {
+				for scale := uint8(0); scale < 4; scale++ {
+					for alter := uint8(0); alter < 2; alter++ {
+						for xover := uint8(0); xover < 8; xover++ {
+							h := Header{StartFreq: start, StopFreq: stop, XOverBand: xover,
+								FreqScale: scale, AlterScale: alter, NoiseBands: 2}
+							ft, err := NewFrequencyTables(&h, rate)
+							if err != nil {
+								if !errors.Is(err, ErrInvalidParameter) {
+									t.Fatalf("%+v at %d Hz: unexpected error %v", h, rate, err)
+								}
+								continue
+							}
+							valid++
+							checkTables(t, ft, &h, rate)
+						}
+					}
+				}
+			}
+		}
+	}
+	if valid == 0 {
+		t.Fatal("no valid header")
+	}
+}
+
+func checkTables(t *testing.T, ft *FrequencyTables, h *Header, rate uint32) {
+	t.Helper()
+	fail := func(format string, args ...any) {
+		t.Helper()
+		t.Fatalf("%+v at %d Hz: "+format, append([]any{*h, rate}, args...)...)
+	}
+
+	if len(ft.Master) != int(ft.NMaster)+1 || ft.Master[0] != ft.K0 || ft.Master[ft.NMaster] != ft.K2 {
+		fail("master %v does not span %d-%d", ft.Master, ft.K0, ft.K2)
+	}
+	for k := 1; k < len(ft.Master); k++ {
+		if ft.Master[k] <= ft.Master[k-1] {
+			fail("master %v is not increasing", ft.Master)
+		}
+	}
+	if ft.Kx > maxKx || int(ft.Kx)+int(ft.M) > qmfChannels || ft.High[ft.NHigh] != ft.Kx+ft.M {
+		fail("Kx %d, M %d out of range", ft.Kx, ft.M)
+	}
+	if ft.Low[0] != ft.Kx || ft.Low[ft.NLow] != ft.K2 || ft.Noise[0] != ft.Kx || ft.Noise[ft.NQ] != ft.K2 {
+		fail("low %v or noise %v do not span %d-%d", ft.Low, ft.Noise, ft.Kx, ft.K2)
+	}
+	if ft.NQ < 1 || ft.NQ > maxNoiseBands {
+		fail("NQ %d out of range", ft.NQ)
+	}
+	for _, b := range ft.Low {
+		if !slices.Contains(ft.High, b) {
+			fail("low border %d not in high table %v", b, ft.High)
+		}
+	}
+	for _, b := range ft.Noise {
+		if !slices.Contains(ft.Low, b) {
+			fail("noise border %d not in low table %v", b, ft.Low)
+		}
+	}
+}
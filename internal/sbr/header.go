@@ -0,0 +1,87 @@
+// internal/sbr/header.go
+package sbr
+
+import "github.com/llehouerou/go-aac/internal/bits"
+
+// Header holds the fields of an sbr_header().
+//
+// Ported from: sbr_info header fields in ~/dev/faad2/libfaad/sbr_dec.h
+type Header struct {
+	AmpRes     uint8 // bs_amp_res: 0 = 1.5 dB, 1 = 3.0 dB envelope steps
+	StartFreq  uint8 // bs_start_freq
+	StopFreq   uint8 // bs_stop_freq
+	XOverBand  uint8 // bs_xover_band
+	FreqScale  uint8 // bs_freq_scale
+	AlterScale uint8 // bs_alter_scale
+	NoiseBands uint8 // bs_noise_bands
+
+	LimiterBands  uint8 // bs_limiter_bands
+	LimiterGains  uint8 // bs_limiter_gains
+	InterpolFreq  uint8 // bs_interpol_freq
+	SmoothingMode uint8 // bs_smoothing_mode
+}
+
+// Header defaults for fields whose header_extra flag is not set.
+//
+// Ported from: sbr_header() in ~/dev/faad2/libfaad/sbr_syntax.c
+const (
+	defaultFreqScale     = 2
+	defaultAlterScale    = 1
+	defaultNoiseBands    = 2
+	defaultLimiterBands  = 2
+	defaultLimiterGains  = 2
+	defaultInterpolFreq  = 1
+	defaultSmoothingMode = 1
+)
+
+// ParseHeader parses an sbr_header().
+//
+// Ported from: sbr_header() in ~/dev/faad2/libfaad/sbr_syntax.c
+func ParseHeader(r *bits.Reader) Header {
+	var h Header
+
+	h.AmpRes = r.Get1Bit()
+	h.StartFreq = uint8(r.GetBits(4))
+	h.StopFreq = uint8(r.GetBits(4))
+	h.XOverBand = uint8(r.GetBits(3))
+	r.FlushBits(2) // bs_reserved
+	headerExtra1 := r.Get1Bit() == 1
+	headerExtra2 := r.Get1Bit() == 1
+
+	if headerExtra1 {
+		h.FreqScale = uint8(r.GetBits(2))
+		h.AlterScale = r.Get1Bit()
+		h.NoiseBands = uint8(r.GetBits(2))
+	} else {
+		h.FreqScale = defaultFreqScale
+		h.AlterScale = defaultAlterScale
+		h.NoiseBands = defaultNoiseBands
+	}
+
+	if headerExtra2 {
+		h.LimiterBands = uint8(r.GetBits(2))
+		h.LimiterGains = uint8(r.GetBits(2))
+		h.InterpolFreq = r.Get1Bit()
+		h.SmoothingMode = r.Get1Bit()
+	} else {
+		h.LimiterBands = defaultLimiterBands
+		h.LimiterGains = defaultLimiterGains
+		h.InterpolFreq = defaultInterpolFreq
+		h.SmoothingMode = defaultSmoothingMode
+	}
+
+	return h
+}
+
+// sameTables reports whether h and o give the same frequency band
+// tables, so that a new header does not reset the SBR state.
+//
+// Ported from: sbr_reset() in ~/dev/faad2/libfaad/sbr_syntax.c
+func (h *Header) sameTables(o *Header) bool {
+	return h.StartFreq == o.StartFreq &&
+		h.StopFreq == o.StopFreq &&
+		h.FreqScale == o.FreqScale &&
+		h.AlterScale == o.AlterScale &&
+		h.XOverBand == o.XOverBand &&
+		h.NoiseBands == o.NoiseBands
+}
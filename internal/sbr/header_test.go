@@ -0,0 +1,84 @@
+package sbr
+
+import (
+	"testing"
+
+	"github.com/llehouerou/go-aac/internal/bits"
+)
+
+// bitWriter packs values MSB first for building test bitstreams.
+type bitWriter struct {
+	buf  []byte
+	nbit int
+}
+
+func (w *bitWriter) writeBits(val uint32, n int) {
+	for i := n - 1; i >= 0; i-- {
+		if w.nbit%8 == 0 {
+			w.buf = append(w.buf, 0)
+		}
+		if val&(1<<uint(i)) != 0 {
+			w.buf[len(w.buf)-1] |= 0x80 >> uint(w.nbit%8)
+		}
+		w.nbit++
+	}
+}
+
+// writeHeader writes an sbr_header() with h's fields, including the
+// header_extra fields when extra1 or extra2 is set.
+func writeHeader(w *bitWriter, h Header, extra1, extra2 bool) {
+	w.writeBits(uint32(h.AmpRes), 1)
+	w.writeBits(uint32(h.StartFreq), 4)
+	w.writeBits(uint32(h.StopFreq), 4)
+	w.writeBits(uint32(h.XOverBand), 3)
+	w.writeBits(0, 2) // bs_reserved
+	w.writeBits(boolBit(extra1), 1)
+	w.writeBits(boolBit(extra2), 1)
+	if extra1 {
+		w.writeBits(uint32(h.FreqScale), 2)
+		w.writeBits(uint32(h.AlterScale), 1)
+		w.writeBits(uint32(h.NoiseBands), 2)
+	}
+	if extra2 {
+		w.writeBits(uint32(h.LimiterBands), 2)
+		w.writeBits(uint32(h.LimiterGains), 2)
+		w.writeBits(uint32(h.InterpolFreq), 1)
+		w.writeBits(uint32(h.SmoothingMode), 1)
+	}
+}
+
+func boolBit(b bool) uint32 {
+	if b {
+		return 1
+	}
+	return 0
+}
+
+func TestParseHeader(t *testing.T) {
+	full := Header{
+		AmpRes: 1, StartFreq: 5, StopFreq: 9, XOverBand: 3,
+		FreqScale: 1, AlterScale: 0, NoiseBands: 3,
+		LimiterBands: 1, LimiterGains: 3, InterpolFreq: 0, SmoothingMode: 0,
+	}
+
+	w := &bitWriter{}
+	writeHeader(w, full, true, true)
+	if got := ParseHeader(bits.NewReader(w.buf)); got != full {
+		t.Errorf("with extras: got %+v, want %+v", got, full)
+	}
+
+	w = &bitWriter{}
+	writeHeader(w, full, false, false)
+	want := Header{
+		AmpRes: 1, StartFreq: 5, StopFreq: 9, XOverBand: 3,
+		FreqScale: 2, AlterScale: 1, NoiseBands: 2,
+		LimiterBands: 2, LimiterGains: 2, InterpolFreq: 1, SmoothingMode: 1,
+	}
+	r := bits.NewReader(w.buf)
+	if got := ParseHeader(r); got != want {
+		t.Errorf("defaults: got %+v, want %+v", got, want)
+	}
+	if r.GetProcessedBits() != 16 {
+		t.Errorf("header without extras took %d bits, want 16", r.GetProcessedBits())
+	}
+}
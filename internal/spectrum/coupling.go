@@ -0,0 +1,120 @@
+// internal/spectrum/coupling.go
+package spectrum
+
+import (
+	"math"
+
+	"github.com/llehouerou/go-aac/internal/huffman"
+	"github.com/llehouerou/go-aac/internal/syntax"
+)
+
+// DependentCoupling is the contribution of a dependently switched coupling
+// channel to one target channel: the coupling channel's spectrum, added
+// to the target's band by band with the gains of the target's gain
+// element list.
+type DependentCoupling struct {
+	// AfterTNS adds the coupling channel after the target's TNS rather
+	// than before it
+	AfterTNS bool
+
+	// ICS is the coupling channel's stream, which gives the bands, window
+	// grouping and zero bands of Spec
+	ICS *syntax.ICStream
+
+	// Spec is the coupling channel's reconstructed spectrum
+	Spec []float64
+
+	// Gains holds the gain of each window group and band
+	Gains *[syntax.MaxWindowGroups][syntax.MaxSFB]float64
+}
+
+// cceScale maps gain_element_scale to the factor of one gain step:
+// 2^(1/8), 2^(1/4), 2^(1/2) and 2.
+var cceScale = [4]float64{
+	math.Exp2(1.0 / 8), math.Exp2(1.0 / 4), math.Exp2(1.0 / 2), 2,
+}
+
+// CouplingGains returns the gains of gain element list index of cce for
+// each window group and band. The first list is unity; a common gain
+// element applies to every band. With gain_element_sign set, the lowest
+// bit of a per-band gain element is its sign.
+//
+// Ported from: the gain computation of the coupling channel decoding
+// process, ISO/IEC 14496-3 4.6.8.3 (FAAD2 parses but does not apply
+// coupling channels)
+func CouplingGains(cce *syntax.CCEResult, index int) *[syntax.MaxWindowGroups][syntax.MaxSFB]float64 {
+	var gains [syntax.MaxWindowGroups][syntax.MaxSFB]float64
+	scale := cceScale[cce.GainElementScale&3]
+	list := &cce.GainLists[index]
+	ics := &cce.Element.ICS1
+
+	common := 1.0
+	if index > 0 && list.Common {
+		common = math.Pow(scale, -float64(list.CommonGain))
+	}
+	for g := uint8(0); g < ics.NumWindowGroups; g++ {
+		for sfb := uint8(0); sfb < ics.MaxSFB; sfb++ {
+			if index == 0 || list.Common {
+				gains[g][sfb] = common
+				continue
+			}
+			t, sign := int(list.Gain[g][sfb]), 1.0
+			if cce.GainElementSign {
+				if t&1 != 0 {
+					sign = -1
+				}
+				t >>= 1
+			}
+			gains[g][sfb] = sign * math.Pow(scale, -float64(t))
+		}
+	}
+	return &gains
+}
+
+// IndependentCouplingGain returns the gain with which an independently
+// switched coupling channel is added to the time signal of the target
+// channels of gain element list index: the common gain of the list.
+func IndependentCouplingGain(cce *syntax.CCEResult, index int) float64 {
+	if index == 0 {
+		return 1
+	}
+	return math.Pow(cceScale[cce.GainElementScale&3], -float64(cce.GainLists[index].CommonGain))
+}
+
+// applyDependentCoupling adds the coupling channel c to spec, a target
+// spectrum in the per-window layout of frameLen samples.
+func applyDependentCoupling[T Float](spec []T, c *DependentCoupling, frameLen uint16) {
+	ics := c.ICS
+	winLen := int(frameLen)
+	if ics.WindowSequence == syntax.EightShortSequence {
+		winLen /= 8
+	}
+
+	base := 0
+	for g := uint8(0); g < ics.NumWindowGroups; g++ {
+		for sfb := uint8(0); sfb < ics.MaxSFB; sfb++ {
+			if ics.SFBCB[g][sfb] == uint8(huffman.ZeroHCB) {
+				continue
+			}
+			gain := c.Gains[g][sfb]
+			lo, hi := int(ics.SWBOffset[sfb]), min(int(ics.SWBOffset[sfb+1]), winLen)
+			for w := range int(ics.WindowGroupLength[g]) {
+				off := base + w*winLen
+				for k := lo; k < hi; k++ {
+					spec[off+k] += T(gain * c.Spec[off+k])
+				}
+			}
+		}
+		base += int(ics.WindowGroupLength[g]) * winLen
+	}
+}
+
+// applyCouplings adds the couplings added after TNS, or before it, to
+// spec.
+func applyCouplings[T Float](spec []T, couplings []DependentCoupling, afterTNS bool, frameLen uint16) {
+	for i := range couplings {
+		if couplings[i].AfterTNS == afterTNS {
+			applyDependentCoupling(spec, &couplings[i], frameLen)
+		}
+	}
+}
@@ -0,0 +1,96 @@
+// internal/spectrum/coupling_test.go
+package spectrum
+
+import (
+	"math"
+	"testing"
+
+	"github.com/llehouerou/go-aac/internal/huffman"
+	"github.com/llehouerou/go-aac/internal/syntax"
+)
+
+func TestCouplingGains(t *testing.T) {
+	cce := &syntax.CCEResult{GainElementScale: 1, GainElementSign: true}
+	ics := &cce.Element.ICS1
+	ics.NumWindowGroups = 1
+	ics.MaxSFB = 2
+	cce.GainLists[1] = syntax.CCEGainList{Common: true, CommonGain: 4}
+	cce.GainLists[2].Gain[0][0] = 4 // +2 steps
+	cce.GainLists[2].Gain[0][1] = 3 // -1 step, negative
+
+	if g := CouplingGains(cce, 0); g[0][0] != 1 || g[0][1] != 1 {
+		t.Errorf("list 0 gains %v, want unity", g[0][:2])
+	}
+	// Steps of 2^(1/4): a common gain of 4 steps halves
+	if g := CouplingGains(cce, 1); math.Abs(g[0][0]-0.5) > 1e-12 || g[0][1] != g[0][0] {
+		t.Errorf("list 1 gains %v, want 0.5", g[0][:2])
+	}
+	g := CouplingGains(cce, 2)
+	if want := math.Pow(2, -0.5); math.Abs(g[0][0]-want) > 1e-12 {
+		t.Errorf("list 2 band 0 gain %v, want %v", g[0][0], want)
+	}
+	if want := -math.Pow(2, -0.25); math.Abs(g[0][1]-want) > 1e-12 {
+		t.Errorf("list 2 band 1 gain %v, want %v", g[0][1], want)
+	}
+	if got := IndependentCouplingGain(cce, 1); math.Abs(got-0.5) > 1e-12 {
+		t.Errorf("IndependentCouplingGain = %v, want 0.5", got)
+	}
+}
+
+func TestApplyCouplings(t *testing.T) {
+	ics := &syntax.ICStream{
+		WindowSequence:  syntax.EightShortSequence,
+		NumWindowGroups: 2,
+		MaxSFB:          2,
+	}
+	ics.WindowGroupLength[0] = 1
+	ics.WindowGroupLength[1] = 7
+	ics.SWBOffset[1] = 4
+	ics.SWBOffset[2] = 8
+	ics.SFBCB[0][0] = 1
+	ics.SFBCB[0][1] = uint8(huffman.ZeroHCB)
+	ics.SFBCB[1][0] = 1
+	ics.SFBCB[1][1] = 1
+
+	const frameLen = 1024
+	src := make([]float64, frameLen)
+	for i := range src {
+		src[i] = 1
+	}
+	var gains [syntax.MaxWindowGroups][syntax.MaxSFB]float64
+	gains[0][0], gains[0][1] = 2, 2
+	gains[1][0], gains[1][1] = 3, 4
+	couplings := []DependentCoupling{
+		{AfterTNS: true, ICS: ics, Spec: src, Gains: &gains},
+	}
+
+	spec := make([]float32, frameLen)
+	applyCouplings(spec, couplings, false, frameLen)
+	for i, v := range spec {
+		if v != 0 {
+			t.Fatalf("spec[%d] = %v before TNS, want the after-TNS coupling skipped", i, v)
+		}
+	}
+
+	applyCouplings(spec, couplings, true, frameLen)
+	winLen := frameLen / 8
+	for w := range 8 {
+		for k := range winLen {
+			var want float32
+			switch {
+			case k >= 8:
+			case w == 0 && k < 4:
+				want = 2
+			case w == 0:
+				// Zero band of the coupling channel
+			case k < 4:
+				want = 3
+			default:
+				want = 4
+			}
+			if got := spec[w*winLen+k]; got != want {
+				t.Fatalf("window %d bin %d = %v, want %v", w, k, got, want)
+			}
+		}
+	}
+}
@@ -0,0 +1,53 @@
+// internal/spectrum/deinterleave.go
+package spectrum
+
+import "github.com/llehouerou/go-aac/internal/syntax"
+
+// DeinterleaveShortWindows maps short-block spectral data from its
+// bitstream order to the per-window layout the rest of the pipeline uses.
+//
+// In the bitstream, the coefficients of a window group are stored band by
+// band, each band holding its coefficients for every window of the group
+// in turn: group, then scale factor band, then window, then bin. Scale
+// factors, PNS, intensity stereo, TNS and the filter bank all expect each
+// of the eight windows as a contiguous block of frameLength/8 bins
+// instead. Long blocks are stored contiguously already and are copied
+// unchanged.
+//
+// grouped and out must not overlap and must hold at least the frame
+// length (the short window length times eight).
+//
+// Ported from: quant_to_spec() indexing in ~/dev/faad2/libfaad/specrec.c:549-693
+func DeinterleaveShortWindows[T Float](grouped []T, ics *syntax.ICStream, out []T) {
+	if ics.WindowSequence != syntax.EightShortSequence {
+		copy(out, grouped)
+		return
+	}
+
+	winInc := ics.SWBOffset[ics.NumSWB]
+	k := uint16(0)
+	gindex := uint16(0)
+	for g := uint8(0); g < ics.NumWindowGroups; g++ {
+		for sfb := uint8(0); sfb < ics.NumSWB; sfb++ {
+			low := ics.SWBOffset[sfb]
+			width := ics.SWBOffset[sfb+1] - low
+			for win := uint16(0); win < uint16(ics.WindowGroupLength[g]); win++ {
+				wa := gindex + win*winInc + low
+				copy(out[wa:wa+width], grouped[k:k+width])
+				k += width
+			}
+		}
+		gindex += uint16(ics.WindowGroupLength[g]) * winInc
+	}
+}
+
+// deinterleaveSpectrum reorders specData in place when it holds a short
+// block grouped over more than one window.
+func deinterleaveSpectrum[T Float](specData []T, ics *syntax.ICStream) {
+	if ics.WindowSequence != syntax.EightShortSequence || ics.NumWindowGroups == ics.NumWindows {
+		// One window per group: bitstream order is already per window
+		return
+	}
+	grouped := append([]T(nil), specData...)
+	DeinterleaveShortWindows(grouped, ics, specData)
+}
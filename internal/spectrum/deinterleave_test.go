@@ -0,0 +1,75 @@
+// internal/spectrum/deinterleave_test.go
+package spectrum
+
+import (
+	"testing"
+
+	"github.com/llehouerou/go-aac/internal/syntax"
+)
+
+func TestDeinterleaveShortWindows(t *testing.T) {
+	// Two 4-bin bands per window (8 bins), grouped as windows 0-2 and 3-7
+	ics := &syntax.ICStream{
+		WindowSequence:  syntax.EightShortSequence,
+		NumWindows:      8,
+		NumWindowGroups: 2,
+		NumSWB:          2,
+		MaxSFB:          2,
+	}
+	ics.WindowGroupLength[0] = 3
+	ics.WindowGroupLength[1] = 5
+	ics.SWBOffset[1] = 4
+	ics.SWBOffset[2] = 8
+
+	grouped := make([]float64, 64)
+	for i := range grouped {
+		grouped[i] = float64(i)
+	}
+	out := make([]float64, 64)
+	DeinterleaveShortWindows(grouped, ics, out)
+
+	tests := []struct {
+		name     string
+		out, src int
+	}{
+		{"group 0 window 0 band 0", 0, 0},
+		{"group 0 window 1 band 0", 8, 4},
+		{"group 0 window 2 band 0", 16, 8},
+		{"group 0 window 0 band 1", 4, 12},
+		{"group 0 window 2 band 1 last bin", 23, 23},
+		{"group 1 window 3 band 0", 24, 24},
+		{"group 1 window 4 band 0", 32, 28},
+		{"group 1 window 3 band 1", 28, 44},
+		{"group 1 window 7 band 1 last bin", 63, 63},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if out[tt.out] != grouped[tt.src] {
+				t.Errorf("out[%d] = %v, want grouped[%d] = %v", tt.out, out[tt.out], tt.src, grouped[tt.src])
+			}
+		})
+	}
+
+	// Every coefficient lands exactly once
+	seen := make([]bool, len(grouped))
+	for _, v := range out {
+		seen[int(v)] = true
+	}
+	for i, ok := range seen {
+		if !ok {
+			t.Errorf("grouped[%d] missing from output", i)
+		}
+	}
+}
+
+func TestDeinterleaveShortWindows_LongBlockCopied(t *testing.T) {
+	ics := &syntax.ICStream{WindowSequence: syntax.OnlyLongSequence}
+	grouped := []float32{1, 2, 3, 4}
+	out := make([]float32, 4)
+	DeinterleaveShortWindows(grouped, ics, out)
+	for i := range grouped {
+		if out[i] != grouped[i] {
+			t.Errorf("out[%d] = %v, want %v", i, out[i], grouped[i])
+		}
+	}
+}
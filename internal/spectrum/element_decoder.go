@@ -0,0 +1,756 @@
+// internal/spectrum/element_decoder.go
+package spectrum
+
+import (
+	"encoding/binary"
+	"errors"
+
+	"github.com/llehouerou/go-aac/internal/bits"
+	"github.com/llehouerou/go-aac/internal/filterbank"
+	"github.com/llehouerou/go-aac/internal/syntax"
+	"github.com/llehouerou/go-aac/internal/tables"
+)
+
+var (
+	// ErrForeignElement indicates an element not returned by the
+	// decoder's Parse methods was passed for reconstruction.
+	ErrForeignElement = errors.New("spectrum: element was not parsed by this decoder")
+
+	// ErrInvalidState indicates a malformed state passed to LoadState.
+	ErrInvalidState = errors.New("spectrum: invalid element decoder state")
+)
+
+// Options holds the settings of the aac decoder's configuration that
+// change how elements are reconstructed.
+type Options struct {
+	// Float32Spectra reconstructs spectra in float32 instead of float64
+	Float32Spectra bool
+
+	// NoiseGenerator, when non-nil, replaces the PNS random number
+	// generator
+	NoiseGenerator NoiseGenerator
+}
+
+// ChannelTools reports the window sequence and the coding tools used by
+// one channel of a parsed element, as aac.ChannelTools.
+type ChannelTools struct {
+	WindowSequence uint8
+	TNS            bool
+	PNS            bool
+	Prediction     bool
+	LTP            bool
+	MS             bool
+	Intensity      bool
+}
+
+// ElementDecoder parses channel elements with the syntax package and
+// reconstructs their spectra, holding the state that persists across
+// frames of one stream. It is what the aac decoder parses and
+// reconstructs channel elements with.
+//
+// For LTP object types it keeps each channel's prediction history, which
+// the aac decoder feeds back through UpdateLTPState after the inverse
+// filter bank. For MAIN it keeps each channel's backward-adaptive
+// predictors, one per spectral coefficient.
+type ElementDecoder struct {
+	sfIndex     uint8
+	frameLength uint16
+	objectType  uint8
+	float32Spec bool
+	resilience  syntax.ResilienceFlags
+
+	pns *PNSState
+
+	// LTP history (4*frameLength samples) and, for AAC-LD, last
+	// transmitted lag of each channel, with the forward MDCT predicting
+	// from them
+	ltpState [][]int16
+	ltpLag   []uint16
+	ltpMDCT  ForwardMDCT
+
+	// MAIN profile predictor states of each channel, frameLength per
+	// channel
+	predState [][]PredState
+
+	// Spectrum buffers of the element's channels, in the precision
+	// selected by float32Spec
+	spec64 [2][]float64
+	spec32 [2][]float32
+
+	// Spectrum buffers of every channel ([][]float64 or [][]float32) and
+	// the forward MDCTs of LTP jobs, for ReconstructElements
+	parallelSpec any
+	parallelLTP  []ForwardMDCT
+
+	// Dependently switched coupling channels of the current
+	// raw_data_block, added to the target elements reconstructed after
+	// them
+	couplings []coupledChannel
+}
+
+// coupledChannel is a reconstructed dependently switched coupling
+// channel element.
+type coupledChannel struct {
+	cce  *syntax.CCEResult
+	spec []float64
+}
+
+// NewElementDecoder creates an element decoder for a stream with the
+// given parameters, with the settings of opts when it is non-nil.
+func NewElementDecoder(sfIndex uint8, frameLength uint16, objectType uint8, opts *Options) *ElementDecoder {
+	e := &ElementDecoder{
+		sfIndex:     sfIndex,
+		frameLength: frameLength,
+		objectType:  objectType,
+		pns:         NewPNSState(),
+	}
+	if opts != nil {
+		e.float32Spec = opts.Float32Spectra
+		e.pns.Generator = opts.NoiseGenerator
+	}
+	return e
+}
+
+// SetResilience enables the error resilience tools signalled by the
+// GASpecificConfig of an ER object type for the elements parsed next.
+func (e *ElementDecoder) SetResilience(sectionData, scalefactorData, spectralData bool) {
+	e.resilience = syntax.ResilienceFlags{
+		SectionData:     sectionData,
+		ScalefactorData: scalefactorData,
+		SpectralData:    spectralData,
+	}
+}
+
+// ParseSCE parses a single_lfe_channel_element() for the given output
+// channel, storing its quantized coefficients in quant. It returns the
+// parsed element for ReconstructSCE and its instance tag.
+//
+// Ported from: single_lfe_channel_element() in ~/dev/faad2/libfaad/syntax.c:652-696
+func (e *ElementDecoder) ParseSCE(r *bits.Reader, channel uint8, quant []int16) (element any, tag uint8, err error) {
+	res, err := syntax.ParseSingleChannelElement(r, channel, &syntax.SCEConfig{
+		SFIndex:     e.sfIndex,
+		FrameLength: e.frameLength,
+		ObjectType:  uint8(e.objectType),
+		Resilience:  e.resilience,
+	})
+	if err != nil {
+		return nil, 0, err
+	}
+	copy(quant, res.SpecData)
+	return &res.Element, res.Tag, nil
+}
+
+// ParseLFE parses an LFE element, which shares the syntax of an SCE. The
+// LFE channel takes no part in prediction, so prediction and LTP data
+// signalled for it are ignored.
+//
+// Ported from: single_lfe_channel_element() in ~/dev/faad2/libfaad/syntax.c:652-696
+func (e *ElementDecoder) ParseLFE(r *bits.Reader, channel uint8, quant []int16) (element any, tag uint8, err error) {
+	res, err := syntax.ParseLFEElement(r, channel, &syntax.SCEConfig{
+		SFIndex:     e.sfIndex,
+		FrameLength: e.frameLength,
+		ObjectType:  uint8(e.objectType),
+		Resilience:  e.resilience,
+	})
+	if err != nil {
+		return nil, 0, err
+	}
+	ics := &res.Element.ICS1
+	ics.PredictorDataPresent = false
+	ics.Pred = syntax.PredInfo{}
+	ics.LTP = syntax.LTPInfo{}
+	copy(quant, res.SpecData)
+	return &res.Element, res.Tag, nil
+}
+
+// ParseCPE parses a channel_pair_element() whose first channel is
+// channel, storing the quantized coefficients of its two channels in
+// quant1 and quant2. It returns the parsed element for ReconstructCPE and
+// its instance tag.
+//
+// Ported from: channel_pair_element() in ~/dev/faad2/libfaad/syntax.c:698-826
+func (e *ElementDecoder) ParseCPE(r *bits.Reader, channel uint8, quant1, quant2 []int16) (element any, tag uint8, err error) {
+	res, err := syntax.ParseChannelPairElement(r, channel, &syntax.CPEConfig{
+		SFIndex:     e.sfIndex,
+		FrameLength: e.frameLength,
+		ObjectType:  uint8(e.objectType),
+		Resilience:  e.resilience,
+	})
+	if err != nil {
+		return nil, 0, err
+	}
+	copy(quant1, res.SpecData1)
+	copy(quant2, res.SpecData2)
+	return &res.Element, res.Tag, nil
+}
+
+// ParseCCE parses a coupling_channel_element(), storing the quantized
+// coefficients of its channel in quant. It returns the parsed element for
+// ReconstructCCE and its instance tag.
+//
+// Ported from: coupling_channel_element() in ~/dev/faad2/libfaad/syntax.c:987-1076
+func (e *ElementDecoder) ParseCCE(r *bits.Reader, quant []int16) (element any, tag uint8, err error) {
+	res, err := syntax.ParseCouplingChannelElement(r, &syntax.CCEConfig{
+		SFIndex:     e.sfIndex,
+		FrameLength: e.frameLength,
+		ObjectType:  uint8(e.objectType),
+		Resilience:  e.resilience,
+	})
+	if err != nil {
+		return nil, 0, err
+	}
+	copy(quant, res.SpecData)
+	return res, res.Tag, nil
+}
+
+// ReconstructCCE reconstructs the spectrum of an element returned by
+// ParseCCE into spec. A dependently switched coupling channel skips TNS
+// and is kept to be added to its targets by the ReconstructSCE and
+// ReconstructCPE calls that follow, until ClearCouplings; an
+// independently switched one goes through TNS and the filter bank like a
+// regular channel, and is added to the time signal of its targets with
+// IndependentCouplingGain.
+func (e *ElementDecoder) ReconstructCCE(element any, quant []int16, spec []float32, windowShapePrev uint8) error {
+	cce, ok := element.(*syntax.CCEResult)
+	if !ok {
+		return ErrForeignElement
+	}
+	if len(spec) < len(quant) {
+		return ErrLengthMismatch
+	}
+	ics := &cce.Element.ICS1
+	dependent := cce.CouplingPoint() != syntax.CouplingAfterIMDCT
+	cfg := &ReconstructSingleChannelConfig{
+		ICS:             ics,
+		Element:         &cce.Element,
+		FrameLength:     e.frameLength,
+		ObjectType:      e.objectType,
+		SRIndex:         e.sfIndex,
+		WindowShape:     ics.WindowShape,
+		WindowShapePrev: windowShapePrev,
+		PNSState:        e.pns,
+		SkipTNS:         dependent,
+	}
+
+	spec1 := make([]float64, len(quant))
+	if err := ReconstructSingleChannel(quant, spec1, cfg); err != nil {
+		return err
+	}
+	narrow(spec, spec1)
+	if dependent {
+		e.couplings = append(e.couplings, coupledChannel{cce: cce, spec: spec1})
+	}
+	return nil
+}
+
+// ClearCouplings drops the coupling channels of the current
+// raw_data_block, before the next one.
+func (e *ElementDecoder) ClearCouplings() {
+	e.couplings = e.couplings[:0]
+}
+
+// IndependentCoupling reports whether an element returned by ParseCCE is
+// an independently switched coupling channel, which goes through the
+// filter bank before being added to its targets.
+func (e *ElementDecoder) IndependentCoupling(element any) bool {
+	cce, ok := element.(*syntax.CCEResult)
+	return ok && cce.CouplingPoint() == syntax.CouplingAfterIMDCT
+}
+
+// IndependentCouplingGain returns the gain with which an independently
+// switched coupling channel element returned by ParseCCE is added to the
+// time signal of channel 0 or 1 of the element with instance tag tag, a
+// channel pair element if targetIsCPE is set. It returns 0 when the
+// coupling channel does not target that channel.
+func (e *ElementDecoder) IndependentCouplingGain(element any, targetIsCPE bool, tag uint8, channel int) float32 {
+	cce, ok := element.(*syntax.CCEResult)
+	if !ok || cce.CouplingPoint() != syntax.CouplingAfterIMDCT {
+		return 0
+	}
+	var gain float64
+	for _, list := range cce.TargetGainLists(targetIsCPE, tag, channel) {
+		gain += IndependentCouplingGain(cce, list)
+	}
+	return float32(gain)
+}
+
+// dependentCouplings returns the dependently switched coupling channels of
+// the current raw_data_block that target channel 0 or 1 of ele.
+func (e *ElementDecoder) dependentCouplings(ele *syntax.Element, isCPE bool, channel int) []DependentCoupling {
+	if ele.LFE {
+		return nil
+	}
+	var out []DependentCoupling
+	for _, c := range e.couplings {
+		for _, list := range c.cce.TargetGainLists(isCPE, ele.ElementInstanceTag, channel) {
+			out = append(out, DependentCoupling{
+				AfterTNS: c.cce.CouplingPoint() == syntax.CouplingAfterTNS,
+				ICS:      &c.cce.Element.ICS1,
+				Spec:     c.spec,
+				Gains:    CouplingGains(c.cce, list),
+			})
+		}
+	}
+	return out
+}
+
+// Window returns the window sequence and shape of the first (index 0) or
+// second (index 1) channel stream of a parsed element, or of the channel
+// of a parsed coupling channel element.
+func (e *ElementDecoder) Window(element any, index int) (windowSequence, windowShape uint8) {
+	if cce, ok := element.(*syntax.CCEResult); ok {
+		return uint8(cce.Element.ICS1.WindowSequence), cce.Element.ICS1.WindowShape
+	}
+	ele, ok := element.(*syntax.Element)
+	if !ok {
+		return 0, 0
+	}
+	ics := &ele.ICS1
+	if index == 1 {
+		ics = &ele.ICS2
+	}
+	return uint8(ics.WindowSequence), ics.WindowShape
+}
+
+// Stereo returns the common_window flag and ms_mask_present of a parsed
+// channel pair element.
+func (e *ElementDecoder) Stereo(element any) (commonWindow bool, msMaskPresent uint8) {
+	ele, ok := element.(*syntax.Element)
+	if !ok {
+		return false, 0
+	}
+	return ele.CommonWindow, ele.ICS1.MSMaskPresent
+}
+
+// SecondChannelStart returns the reader position at which the second
+// individual_channel_stream of a parsed channel pair element started.
+func (e *ElementDecoder) SecondChannelStart(element any) uint32 {
+	ele, ok := element.(*syntax.Element)
+	if !ok {
+		return 0
+	}
+	return ele.ICS2Start
+}
+
+// ChannelTools returns the window sequence and coding tools of channel
+// index of a parsed channel element. The stereo tools are read from the
+// second channel, which carries the intensity codebooks, and reported on
+// both.
+func (e *ElementDecoder) ChannelTools(element any, index int) ChannelTools {
+	ele, ok := element.(*syntax.Element)
+	if !ok {
+		return ChannelTools{}
+	}
+	ics := &ele.ICS1
+	ltp := &ele.ICS1.LTP
+	if index == 1 {
+		ics = &ele.ICS2
+		ltp = &ele.ICS2.LTP
+		if ele.CommonWindow {
+			ltp = &ele.ICS2.LTP2
+		}
+	}
+	tools := ChannelTools{
+		WindowSequence: uint8(ics.WindowSequence),
+		TNS:            ics.TNSDataPresent,
+		PNS:            ics.NoiseUsed,
+		Prediction:     e.objectType == tables.ObjectTypeMain && ics.PredictorDataPresent,
+		LTP:            IsLTPObjectType(e.objectType) && ltp.DataPresent,
+	}
+	if ele.PairedChannel >= 0 {
+		tools.MS = ele.ICS1.MSMaskPresent == 2 || msUsed(&ele.ICS1)
+		tools.Intensity = ele.ICS2.IsUsed
+	}
+	return tools
+}
+
+// SpectralFlatness returns the spectral flatness of channel index of a
+// parsed element, reconstructed into spec, as measured by the package's
+// SpectralFlatness. It returns 0 for a foreign element.
+func (e *ElementDecoder) SpectralFlatness(element any, index int, spec []float32) float32 {
+	ele, ok := element.(*syntax.Element)
+	if !ok {
+		return 0
+	}
+	ics := &ele.ICS1
+	if index == 1 {
+		ics = &ele.ICS2
+	}
+	return SpectralFlatness(spec, ics, e.frameLength)
+}
+
+// msUsed reports whether ms_used is set for any band of ics.
+func msUsed(ics *syntax.ICStream) bool {
+	if ics.MSMaskPresent != 1 {
+		return false
+	}
+	for g := range ics.NumWindowGroups {
+		for sfb := range ics.MaxSFB {
+			if ics.MSUsed[g][sfb] != 0 {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// ReconstructSCE reconstructs the spectrum of an element returned by
+// ParseSCE from its quantized coefficients, writing it to spec for the
+// filter bank. windowShapePrev is the channel's window shape in the
+// previous frame.
+//
+// Ported from: reconstruct_single_channel() in ~/dev/faad2/libfaad/specrec.c:905-1129
+func (e *ElementDecoder) ReconstructSCE(element any, quant []int16, spec []float32, windowShapePrev uint8) error {
+	ele, ok := element.(*syntax.Element)
+	if !ok {
+		return ErrForeignElement
+	}
+	if len(spec) < len(quant) {
+		return ErrLengthMismatch
+	}
+	cfg := e.sceConfig(ele, windowShapePrev)
+
+	if e.float32Spec {
+		spec1, _ := e.buffers32(len(quant))
+		if err := ReconstructSingleChannel(quant, spec1, cfg); err != nil {
+			return err
+		}
+		copy(spec, spec1)
+		return nil
+	}
+
+	spec1, _ := e.buffers64(len(quant))
+	if err := ReconstructSingleChannel(quant, spec1, cfg); err != nil {
+		return err
+	}
+	narrow(spec, spec1)
+	return nil
+}
+
+// ReconstructCPE reconstructs the spectra of an element returned by
+// ParseCPE from the quantized coefficients of its channels, writing them
+// to spec1 and spec2 for the filter bank. windowShapePrev1 and
+// windowShapePrev2 are the channels' window shapes in the previous frame.
+//
+// Ported from: reconstruct_channel_pair() in ~/dev/faad2/libfaad/specrec.c:1131-1365
+func (e *ElementDecoder) ReconstructCPE(element any, quant1, quant2 []int16, spec1, spec2 []float32, windowShapePrev1, windowShapePrev2 uint8) error {
+	ele, ok := element.(*syntax.Element)
+	if !ok {
+		return ErrForeignElement
+	}
+	if len(quant1) != len(quant2) || len(spec1) < len(quant1) || len(spec2) < len(quant2) {
+		return ErrLengthMismatch
+	}
+	cfg := e.cpeConfig(ele, windowShapePrev1, windowShapePrev2)
+
+	if e.float32Spec {
+		s1, s2 := e.buffers32(len(quant1))
+		if err := ReconstructChannelPair(quant1, quant2, s1, s2, cfg); err != nil {
+			return err
+		}
+		copy(spec1, s1)
+		copy(spec2, s2)
+		return nil
+	}
+
+	s1, s2 := e.buffers64(len(quant1))
+	if err := ReconstructChannelPair(quant1, quant2, s1, s2, cfg); err != nil {
+		return err
+	}
+	narrow(spec1, s1)
+	narrow(spec2, s2)
+	return nil
+}
+
+// sceConfig returns the reconstruction configuration of an SCE or LFE,
+// readying its channel's LTP history or MAIN predictors.
+func (e *ElementDecoder) sceConfig(ele *syntax.Element, windowShapePrev uint8) *ReconstructSingleChannelConfig {
+	cfg := &ReconstructSingleChannelConfig{
+		ICS:             &ele.ICS1,
+		Element:         ele,
+		FrameLength:     e.frameLength,
+		ObjectType:      e.objectType,
+		SRIndex:         e.sfIndex,
+		WindowShape:     ele.ICS1.WindowShape,
+		WindowShapePrev: windowShapePrev,
+		PNSState:        e.pns,
+		Couplings:       e.dependentCouplings(ele, false, 0),
+	}
+	if IsLTPObjectType(e.objectType) {
+		e.prepareLTP(ele.Channel, &ele.ICS1.LTP)
+		cfg.LTPState = e.ltpHistory(ele.Channel)
+		cfg.LTPFilterBank = e.ltpMDCT
+	}
+	if e.objectType == tables.ObjectTypeMain {
+		cfg.PredState = e.predictors(ele.Channel)
+	}
+	return cfg
+}
+
+// cpeConfig returns the reconstruction configuration of a CPE, readying
+// its channels' LTP histories or MAIN predictors.
+func (e *ElementDecoder) cpeConfig(ele *syntax.Element, windowShapePrev1, windowShapePrev2 uint8) *ReconstructChannelPairConfig {
+	cfg := &ReconstructChannelPairConfig{
+		ICS1:             &ele.ICS1,
+		ICS2:             &ele.ICS2,
+		Element:          ele,
+		FrameLength:      e.frameLength,
+		ObjectType:       e.objectType,
+		SRIndex:          e.sfIndex,
+		WindowShape1:     ele.ICS1.WindowShape,
+		WindowShapePrev1: windowShapePrev1,
+		WindowShape2:     ele.ICS2.WindowShape,
+		WindowShapePrev2: windowShapePrev2,
+		PNSState:         e.pns,
+		Couplings1:       e.dependentCouplings(ele, true, 0),
+		Couplings2:       e.dependentCouplings(ele, true, 1),
+	}
+	if IsLTPObjectType(e.objectType) {
+		// With a common window, the second channel predicts from the
+		// second LTP data of the shared ics_info
+		ch2 := uint8(ele.PairedChannel)
+		ltp2 := &ele.ICS2.LTP
+		if ele.CommonWindow {
+			ltp2 = &ele.ICS2.LTP2
+		}
+		e.prepareLTP(ele.Channel, &ele.ICS1.LTP)
+		e.prepareLTP(ch2, ltp2)
+		cfg.LTPState1 = e.ltpHistory(ele.Channel)
+		cfg.LTPState2 = e.ltpHistory(ch2)
+		cfg.LTPFilterBank = e.ltpMDCT
+	}
+	if e.objectType == tables.ObjectTypeMain {
+		cfg.PredState1 = e.predictors(ele.Channel)
+		cfg.PredState2 = e.predictors(uint8(ele.PairedChannel))
+	}
+	return cfg
+}
+
+// UpdateLTPState appends a channel's filter bank output, its time samples
+// and the overlap kept for the next frame, to the channel's LTP history.
+// It does nothing for object types without LTP.
+//
+// Ported from: lt_update_state() calls in ~/dev/faad2/libfaad/specrec.c
+func (e *ElementDecoder) UpdateLTPState(channel uint8, timeOut, overlap []float32) {
+	if !IsLTPObjectType(e.objectType) {
+		return
+	}
+	LTPUpdateState(e.ltpHistory(channel), timeOut, overlap, e.frameLength, e.objectType)
+}
+
+// Reset restarts the PNS noise generator from its initial state, as
+// aac.Decoder.Reset does after a seek, and clears the LTP history, the
+// MAIN predictors and the coupling channels, which no longer precede the
+// next frame. A custom noise generator is kept.
+func (e *ElementDecoder) Reset() {
+	generator := e.pns.Generator
+	e.pns = NewPNSState()
+	e.pns.Generator = generator
+	e.ltpState = nil
+	e.ltpLag = nil
+	e.predState = nil
+	e.couplings = nil
+}
+
+// AppendState appends the state carried across frames, for
+// aac.Decoder.ExportState: the PNS generator registers and, per channel,
+// the LTP lag and history and the MAIN predictor states.
+func (e *ElementDecoder) AppendState(b []byte) []byte {
+	b = binary.LittleEndian.AppendUint32(b, e.pns.R1)
+	b = binary.LittleEndian.AppendUint32(b, e.pns.R2)
+	channels := max(len(e.ltpState), len(e.ltpLag))
+	b = append(b, uint8(channels))
+	for ch := range channels {
+		var lag uint16
+		if ch < len(e.ltpLag) {
+			lag = e.ltpLag[ch]
+		}
+		b = binary.LittleEndian.AppendUint16(b, lag)
+		var history []int16
+		if ch < len(e.ltpState) {
+			history = e.ltpState[ch]
+		}
+		if history == nil {
+			b = append(b, 0)
+			continue
+		}
+		b = append(b, 1)
+		for _, v := range history {
+			b = binary.LittleEndian.AppendUint16(b, uint16(v))
+		}
+	}
+
+	b = append(b, uint8(len(e.predState)))
+	for _, states := range e.predState {
+		if states == nil {
+			b = append(b, 0)
+			continue
+		}
+		b = append(b, 1)
+		for i := range states {
+			for _, v := range predStateValues(&states[i]) {
+				b = binary.LittleEndian.AppendUint16(b, uint16(*v))
+			}
+		}
+	}
+	return b
+}
+
+// predStateBytes is the serialized size of a predictor state.
+const predStateBytes = 12
+
+// predStateValues returns the fields of a predictor state in their
+// serialized order: cor, var and r.
+func predStateValues(s *PredState) [6]*int16 {
+	return [6]*int16{&s.COR[0], &s.COR[1], &s.VAR[0], &s.VAR[1], &s.R[0], &s.R[1]}
+}
+
+// LoadState restores a state appended by AppendState, for
+// aac.Decoder.ImportState. It returns ErrInvalidState, leaving the
+// decoder unchanged, when b is malformed or holds LTP histories or
+// predictor states of another frame length.
+func (e *ElementDecoder) LoadState(b []byte) error {
+	if len(b) < 9 {
+		return ErrInvalidState
+	}
+	r1 := binary.LittleEndian.Uint32(b)
+	r2 := binary.LittleEndian.Uint32(b[4:])
+	channels := int(b[8])
+	b = b[9:]
+
+	historyLen := 4 * int(e.frameLength)
+	lags := make([]uint16, channels)
+	histories := make([][]int16, channels)
+	for ch := range channels {
+		if len(b) < 3 {
+			return ErrInvalidState
+		}
+		lags[ch] = binary.LittleEndian.Uint16(b)
+		present := b[2]
+		b = b[3:]
+		if present == 0 {
+			continue
+		}
+		if len(b) < 2*historyLen {
+			return ErrInvalidState
+		}
+		histories[ch] = make([]int16, historyLen)
+		for i := range histories[ch] {
+			histories[ch][i] = int16(binary.LittleEndian.Uint16(b[2*i:]))
+		}
+		b = b[2*historyLen:]
+	}
+
+	if len(b) < 1 {
+		return ErrInvalidState
+	}
+	predChannels := int(b[0])
+	b = b[1:]
+	predLen := predStateBytes * int(e.frameLength)
+	predictors := make([][]PredState, predChannels)
+	for ch := range predChannels {
+		if len(b) < 1 {
+			return ErrInvalidState
+		}
+		present := b[0]
+		b = b[1:]
+		if present == 0 {
+			continue
+		}
+		if len(b) < predLen {
+			return ErrInvalidState
+		}
+		predictors[ch] = make([]PredState, e.frameLength)
+		for i := range predictors[ch] {
+			for _, v := range predStateValues(&predictors[ch][i]) {
+				*v = int16(binary.LittleEndian.Uint16(b))
+				b = b[2:]
+			}
+		}
+	}
+	if len(b) != 0 {
+		return ErrInvalidState
+	}
+
+	e.pns.R1, e.pns.R2 = r1, r2
+	e.ltpLag = lags
+	e.ltpState = histories
+	e.predState = predictors
+	e.couplings = nil
+	return nil
+}
+
+// ltpHistory returns the LTP history of a channel, allocating it silent
+// on first use.
+func (e *ElementDecoder) ltpHistory(channel uint8) []int16 {
+	if int(channel) >= len(e.ltpState) {
+		e.ltpState = append(e.ltpState, make([][]int16, int(channel)+1-len(e.ltpState))...)
+	}
+	if e.ltpState[channel] == nil {
+		e.ltpState[channel] = make([]int16, 4*int(e.frameLength))
+	}
+	return e.ltpState[channel]
+}
+
+// predictors returns the MAIN predictor states of a channel, allocating
+// them reset on first use.
+//
+// Ported from: pred_stat allocation in reconstruct_single_channel() and
+// reconstruct_channel_pair() in ~/dev/faad2/libfaad/specrec.c
+func (e *ElementDecoder) predictors(channel uint8) []PredState {
+	if int(channel) >= len(e.predState) {
+		e.predState = append(e.predState, make([][]PredState, int(channel)+1-len(e.predState))...)
+	}
+	if e.predState[channel] == nil {
+		e.predState[channel] = make([]PredState, e.frameLength)
+		ResetAllPredictors(e.predState[channel], e.frameLength)
+	}
+	return e.predState[channel]
+}
+
+// prepareLTP readies LTP for a channel: it creates the forward MDCT on
+// first use and, for AAC-LD, where the lag is only sent when it changes,
+// records a transmitted lag or restores the channel's last one.
+//
+// Ported from: reconstruct_single_channel() LD_DEC lag handling in
+// ~/dev/faad2/libfaad/specrec.c
+func (e *ElementDecoder) prepareLTP(channel uint8, ltp *syntax.LTPInfo) {
+	if e.ltpMDCT == nil {
+		e.ltpMDCT = filterbank.NewForwardMDCT()
+	}
+	if e.objectType != tables.ObjectTypeLD {
+		return
+	}
+	if int(channel) >= len(e.ltpLag) {
+		e.ltpLag = append(e.ltpLag, make([]uint16, int(channel)+1-len(e.ltpLag))...)
+	}
+	if ltp.DataPresent && ltp.LagUpdate {
+		e.ltpLag[channel] = ltp.Lag
+	}
+	ltp.Lag = e.ltpLag[channel]
+}
+
+// buffers64 returns the two float64 spectrum buffers, sized to n.
+func (e *ElementDecoder) buffers64(n int) ([]float64, []float64) {
+	if len(e.spec64[0]) != n {
+		e.spec64[0] = make([]float64, n)
+		e.spec64[1] = make([]float64, n)
+	}
+	return e.spec64[0], e.spec64[1]
+}
+
+// buffers32 returns the two float32 spectrum buffers, sized to n.
+func (e *ElementDecoder) buffers32(n int) ([]float32, []float32) {
+	if len(e.spec32[0]) != n {
+		e.spec32[0] = make([]float32, n)
+		e.spec32[1] = make([]float32, n)
+	}
+	return e.spec32[0], e.spec32[1]
+}
+
+// narrow converts a float64 spectrum to the filter bank's float32.
+func narrow(dst []float32, src []float64) {
+	for i, v := range src {
+		dst[i] = float32(v)
+	}
+}
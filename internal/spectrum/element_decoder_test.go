@@ -0,0 +1,155 @@
+// internal/spectrum/element_decoder_test.go
+package spectrum
+
+import (
+	"testing"
+
+	"github.com/llehouerou/go-aac/internal/bits"
+	"github.com/llehouerou/go-aac/internal/syntax"
+	"github.com/llehouerou/go-aac/internal/tables"
+)
+
+func TestElementDecoder_ReconstructSCE_ForeignElement(t *testing.T) {
+	e := NewElementDecoder(4, 1024, tables.ObjectTypeLC, nil)
+	quant := make([]int16, 1024)
+	spec := make([]float32, 1024)
+
+	if err := e.ReconstructSCE(&syntax.ICStream{}, quant, spec, 0); err != ErrForeignElement {
+		t.Errorf("foreign element: got %v, want ErrForeignElement", err)
+	}
+	if err := e.ReconstructSCE(&syntax.Element{}, quant, spec[:10], 0); err != ErrLengthMismatch {
+		t.Errorf("short spectrum: got %v, want ErrLengthMismatch", err)
+	}
+}
+
+func TestElementDecoder_SilentSCE(t *testing.T) {
+	// Tag 0, global_gain 100, long window with max_sfb 0: no sections,
+	// so every coefficient is zero
+	//   tag(4)=0000 gain(8)=01100100 reserved(1)=0 seq(2)=00 shape(1)=0
+	//   max_sfb(6)=000000 predictor(1)=0 pulse(1)=0 tns(1)=0 gain_ctrl(1)=0
+	data := []byte{0x06, 0x40, 0x00, 0x00, 0x00}
+
+	for _, float32Spectra := range []bool{false, true} {
+		e := NewElementDecoder(4, 1024, tables.ObjectTypeLC, &Options{Float32Spectra: float32Spectra})
+		quant := make([]int16, 1024)
+		element, tag, err := e.ParseSCE(bits.NewReader(data), 0, quant)
+		if err != nil {
+			t.Fatalf("ParseSCE: %v", err)
+		}
+		seq, shape := e.Window(element, 0)
+		if tag != 0 || seq != uint8(syntax.OnlyLongSequence) || shape != 0 {
+			t.Errorf("got tag=%d seq=%d shape=%d, want 0, 0, 0", tag, seq, shape)
+		}
+
+		spec := make([]float32, 1024)
+		spec[0] = 1
+		if err := e.ReconstructSCE(element, quant, spec, 0); err != nil {
+			t.Fatalf("ReconstructSCE: %v", err)
+		}
+		for i, v := range spec {
+			if v != 0 {
+				t.Fatalf("float32Spectra=%v: spec[%d] = %v, want 0", float32Spectra, i, v)
+			}
+		}
+	}
+}
+
+func TestElementDecoder_ParseLFE(t *testing.T) {
+	// MAIN profile LFE with predictor_data_present set but no band using
+	// prediction: tag 0, global_gain 100, long window, max_sfb 0,
+	// predictor_data_present 1, predictor_reset 0, then no pulse, tns or
+	// gain control
+	data := []byte{0x06, 0x40, 0x02, 0x00, 0x00}
+
+	e := NewElementDecoder(4, 1024, tables.ObjectTypeMain, nil)
+	sce, _, err := e.ParseSCE(bits.NewReader(data), 0, make([]int16, 1024))
+	if err != nil || !sce.(*syntax.Element).ICS1.PredictorDataPresent {
+		t.Fatalf("ParseSCE: predictor data not parsed (err %v)", err)
+	}
+
+	element, _, err := e.ParseLFE(bits.NewReader(data), 5, make([]int16, 1024))
+	if err != nil {
+		t.Fatalf("ParseLFE: %v", err)
+	}
+	ics := &element.(*syntax.Element).ICS1
+	if ics.PredictorDataPresent {
+		t.Error("LFE kept predictor_data_present")
+	}
+	if element.(*syntax.Element).Channel != 5 {
+		t.Errorf("channel: got %d, want 5", element.(*syntax.Element).Channel)
+	}
+}
+
+func TestElementDecoder_LTPState(t *testing.T) {
+	e := NewElementDecoder(3, 512, tables.ObjectTypeLD, nil)
+
+	// An LD lag is kept for the channel until the next lag update
+	ltp := &syntax.LTPInfo{DataPresent: true, LagUpdate: true, Lag: 700}
+	e.prepareLTP(1, ltp)
+	ltp = &syntax.LTPInfo{DataPresent: true}
+	e.prepareLTP(1, ltp)
+	if ltp.Lag != 700 {
+		t.Errorf("lag without update: got %d, want 700", ltp.Lag)
+	}
+	other := &syntax.LTPInfo{DataPresent: true}
+	e.prepareLTP(0, other)
+	if other.Lag != 0 {
+		t.Errorf("lag of another channel: got %d, want 0", other.Lag)
+	}
+
+	timeOut := make([]float32, 512)
+	overlap := make([]float32, 512)
+	for i := range timeOut {
+		timeOut[i] = 100.4
+		overlap[i] = -3.6
+	}
+	e.UpdateLTPState(1, timeOut, overlap)
+	state := e.ltpHistory(1)
+	if len(state) != 4*512 || state[2*512] != 100 || state[3*512] != -4 {
+		t.Errorf("history after update: len %d, time %d, overlap %d", len(state), state[2*512], state[3*512])
+	}
+
+	e.Reset()
+	if e.ltpHistory(1)[2*512] != 0 {
+		t.Error("Reset kept the LTP history")
+	}
+	e.prepareLTP(1, ltp)
+	if ltp.Lag != 0 {
+		t.Errorf("lag after Reset: got %d, want 0", ltp.Lag)
+	}
+}
+
+func TestElementDecoder_MainPredictors(t *testing.T) {
+	e := NewElementDecoder(4, 1024, tables.ObjectTypeMain, nil)
+
+	// A CPE predicts each of its channels with their own states
+	pair := e.cpeConfig(&syntax.Element{Channel: 0, PairedChannel: 1}, 0, 0)
+	if len(pair.PredState1) != 1024 || len(pair.PredState2) != 1024 {
+		t.Fatalf("CPE predictors: got %d and %d, want 1024", len(pair.PredState1), len(pair.PredState2))
+	}
+	if &pair.PredState1[0] == &pair.PredState2[0] {
+		t.Error("the channels of a CPE share their predictors")
+	}
+	if pair.PredState2[1023] != *NewPredState() {
+		t.Errorf("new predictor: got %+v, want reset", pair.PredState2[1023])
+	}
+
+	// The states persist across frames
+	pair.PredState1[5].R[0] = 42
+	single := e.sceConfig(&syntax.Element{Channel: 0}, 0)
+	if single.PredState[5].R[0] != 42 {
+		t.Error("channel 0 predictors were not kept")
+	}
+
+	// Reset restarts every channel's predictors
+	e.Reset()
+	pair = e.cpeConfig(&syntax.Element{Channel: 0, PairedChannel: 1}, 0, 0)
+	if pair.PredState1[5] != *NewPredState() {
+		t.Errorf("predictor after Reset: got %+v, want reset", pair.PredState1[5])
+	}
+
+	lc := NewElementDecoder(4, 1024, tables.ObjectTypeLC, nil)
+	if cfg := lc.sceConfig(&syntax.Element{}, 0); cfg.PredState != nil {
+		t.Error("LC channel got MAIN predictors")
+	}
+}
@@ -0,0 +1,106 @@
+// internal/spectrum/element_parallel.go
+package spectrum
+
+import (
+	"github.com/llehouerou/go-aac/internal/filterbank"
+	"github.com/llehouerou/go-aac/internal/syntax"
+)
+
+// ReconstructElements reconstructs the spectra of several elements
+// returned by ParseSCE, ParseLFE and ParseCPE together, on up to workers
+// goroutines, for aac.Config.ParallelChannels. quant, spec and
+// windowShapePrev hold one entry per channel, in element order: one for
+// an SCE or LFE, two for a CPE.
+//
+// The elements run through the package's ReconstructElements, so with
+// more than one worker their PNS noise comes from per-element generators.
+// Dependently switched coupling channels kept by ReconstructCCE apply as
+// they do in ReconstructSCE and ReconstructCPE.
+func (e *ElementDecoder) ReconstructElements(elements []any, quant [][]int16, spec [][]float32, windowShapePrev []uint8, workers int) error {
+	if e.float32Spec {
+		return reconstructElements[float32](e, elements, quant, spec, windowShapePrev, workers)
+	}
+	return reconstructElements[float64](e, elements, quant, spec, windowShapePrev, workers)
+}
+
+// reconstructElements builds the jobs of ReconstructElements in precision
+// T and narrows their spectra to spec.
+func reconstructElements[T Float](e *ElementDecoder, elements []any, quant [][]int16, spec [][]float32, windowShapePrev []uint8, workers int) error {
+	if len(quant) != len(spec) || len(quant) != len(windowShapePrev) {
+		return ErrLengthMismatch
+	}
+	bufs := parallelBuffers[T](e, len(quant), int(e.frameLength))
+	jobs := make([]ElementJob[T], len(elements))
+	ch := 0
+	for i, element := range elements {
+		ele, ok := element.(*syntax.Element)
+		if !ok {
+			return ErrForeignElement
+		}
+		job := &jobs[i]
+		pair := ele.PairedChannel >= 0
+		channels := 1
+		if pair {
+			channels = 2
+		}
+		if ch+channels > len(quant) {
+			return ErrLengthMismatch
+		}
+		for c := ch; c < ch+channels; c++ {
+			if len(quant[c]) > len(bufs[c]) || len(spec[c]) < len(quant[c]) {
+				return ErrLengthMismatch
+			}
+		}
+
+		job.QuantData1, job.SpecData1 = quant[ch], bufs[ch][:len(quant[ch])]
+		if pair {
+			job.Pair = e.cpeConfig(ele, windowShapePrev[ch], windowShapePrev[ch+1])
+			job.QuantData2, job.SpecData2 = quant[ch+1], bufs[ch+1][:len(quant[ch+1])]
+		} else {
+			job.Single = e.sceConfig(ele, windowShapePrev[ch])
+		}
+		if IsLTPObjectType(e.objectType) && workers > 1 {
+			// The forward MDCT reuses internal buffers
+			mdct := e.parallelMDCT(i)
+			if pair {
+				job.Pair.LTPFilterBank = mdct
+			} else {
+				job.Single.LTPFilterBank = mdct
+			}
+		}
+		ch += channels
+	}
+
+	if err := ReconstructElements(jobs, e.pns, workers); err != nil {
+		return err
+	}
+	for c := range ch {
+		for i, v := range bufs[c][:len(quant[c])] {
+			spec[c][i] = float32(v)
+		}
+	}
+	return nil
+}
+
+// parallelBuffers returns n spectrum buffers of frameLength samples in
+// precision T, kept across frames.
+func parallelBuffers[T Float](e *ElementDecoder, n, frameLength int) [][]T {
+	bufs, _ := e.parallelSpec.([][]T)
+	if len(bufs) < n || (len(bufs) > 0 && len(bufs[0]) != frameLength) {
+		bufs = make([][]T, n)
+		for i := range bufs {
+			bufs[i] = make([]T, frameLength)
+		}
+		e.parallelSpec = bufs
+	}
+	return bufs
+}
+
+// parallelMDCT returns the forward MDCT of LTP job i, creating it on first
+// use.
+func (e *ElementDecoder) parallelMDCT(i int) ForwardMDCT {
+	for len(e.parallelLTP) <= i {
+		e.parallelLTP = append(e.parallelLTP, filterbank.NewForwardMDCT())
+	}
+	return e.parallelLTP[i]
+}
@@ -0,0 +1,57 @@
+// internal/spectrum/flatness.go
+package spectrum
+
+import (
+	"math"
+
+	"github.com/llehouerou/go-aac/internal/syntax"
+)
+
+// SpectralFlatness returns the spectral flatness of a reconstructed
+// channel: the geometric mean of its band energies divided by their
+// arithmetic mean. Values near 1 indicate a noise-like frame, values
+// near 0 a tonal one.
+//
+// Band energies are the mean power of the bins in each coded scale factor
+// band (below MaxSFB), so wide high-frequency bands do not outweigh narrow
+// low ones. Short blocks contribute the bands of all eight windows. A
+// frame without coded bands, or with a silent coded band, reports 0.
+func SpectralFlatness[T Float](spec []T, ics *syntax.ICStream, frameLength uint16) float32 {
+	windowLen := int(frameLength)
+	if ics.WindowSequence == syntax.EightShortSequence {
+		windowLen /= 8
+	}
+
+	var logSum, sum float64
+	n := 0
+	for w := 0; w < int(ics.NumWindows); w++ {
+		base := w * windowLen
+		for sfb := uint8(0); sfb < ics.MaxSFB; sfb++ {
+			low := base + int(ics.SWBOffset[sfb])
+			high := base + int(min(ics.SWBOffset[sfb+1], ics.SWBOffsetMax))
+			if high > len(spec) || high <= low {
+				continue
+			}
+
+			var energy float64
+			for _, x := range spec[low:high] {
+				energy += float64(x) * float64(x)
+			}
+			if energy == 0 {
+				return 0
+			}
+			energy /= float64(high - low)
+
+			logSum += math.Log(energy)
+			sum += energy
+			n++
+		}
+	}
+	if n == 0 {
+		return 0
+	}
+
+	geometric := math.Exp(logSum / float64(n))
+	arithmetic := sum / float64(n)
+	return float32(geometric / arithmetic)
+}
@@ -0,0 +1,99 @@
+// internal/spectrum/flatness_test.go
+package spectrum
+
+import (
+	"math"
+	"testing"
+
+	"github.com/llehouerou/go-aac/internal/syntax"
+)
+
+// newFlatnessICS returns an ICS for 44100 Hz with maxSFB coded bands.
+func newFlatnessICS(t *testing.T, seq syntax.WindowSequence, maxSFB uint8) *syntax.ICStream {
+	t.Helper()
+	ics := &syntax.ICStream{WindowSequence: seq, MaxSFB: maxSFB}
+	if err := syntax.WindowGroupingInfo(ics, 4, 1024); err != nil {
+		t.Fatalf("WindowGroupingInfo failed: %v", err)
+	}
+	return ics
+}
+
+func TestSpectralFlatness_WhiteSpectrum(t *testing.T) {
+	ics := newFlatnessICS(t, syntax.OnlyLongSequence, 49)
+	spec := make([]float64, 1024)
+	for i := range spec {
+		// Equal power in every bin, alternating sign
+		spec[i] = 1 - 2*float64(i%2)
+	}
+
+	got := SpectralFlatness(spec, ics, 1024)
+	if math.Abs(float64(got)-1) > 1e-6 {
+		t.Errorf("flatness: got %v, want 1", got)
+	}
+}
+
+func TestSpectralFlatness_Tonal(t *testing.T) {
+	ics := newFlatnessICS(t, syntax.OnlyLongSequence, 40)
+	spec := make([]float64, 1024)
+	for i := range spec {
+		spec[i] = 1e-3
+	}
+	// One strong partial in band 10
+	spec[ics.SWBOffset[10]] = 1e4
+
+	got := SpectralFlatness(spec, ics, 1024)
+	if got <= 0 || got > 0.1 {
+		t.Errorf("flatness: got %v, want close to 0", got)
+	}
+
+	// Bins above MaxSFB are not coded and must not count
+	spec[1000] = 1e6
+	if again := SpectralFlatness(spec, ics, 1024); again != got {
+		t.Errorf("flatness changed by uncoded bin: got %v, want %v", again, got)
+	}
+}
+
+func TestSpectralFlatness_SilentBandOrNoBands(t *testing.T) {
+	ics := newFlatnessICS(t, syntax.OnlyLongSequence, 10)
+	spec := make([]float64, 1024)
+	for i := range spec {
+		spec[i] = 1
+	}
+	for bin := ics.SWBOffset[3]; bin < ics.SWBOffset[4]; bin++ {
+		spec[bin] = 0
+	}
+	if got := SpectralFlatness(spec, ics, 1024); got != 0 {
+		t.Errorf("silent band: got %v, want 0", got)
+	}
+
+	ics.MaxSFB = 0
+	if got := SpectralFlatness(spec, ics, 1024); got != 0 {
+		t.Errorf("no coded bands: got %v, want 0", got)
+	}
+}
+
+func TestSpectralFlatness_ShortWindows(t *testing.T) {
+	ics := newFlatnessICS(t, syntax.EightShortSequence, 14)
+	spec := make([]float32, 1024)
+	for w := 0; w < 8; w++ {
+		// Each window is white but louder than the previous one, so the
+		// band energies differ across windows only
+		for i := 0; i < 128; i++ {
+			spec[w*128+i] = float32(w + 1)
+		}
+	}
+
+	// Band energies are (w+1)^2, each repeated over 14 bands
+	var logSum, sum float64
+	for w := 1; w <= 8; w++ {
+		e := float64(w * w)
+		logSum += math.Log(e)
+		sum += e
+	}
+	want := math.Exp(logSum/8) / (sum / 8)
+
+	got := SpectralFlatness(spec, ics, 1024)
+	if math.Abs(float64(got)-want) > 1e-5 {
+		t.Errorf("flatness: got %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,80 @@
+// internal/spectrum/float_test.go
+package spectrum
+
+import (
+	"math"
+	"testing"
+
+	"github.com/llehouerou/go-aac/internal/syntax"
+	"github.com/llehouerou/go-aac/internal/tables"
+)
+
+func TestReconstructSingleChannel_Float32MatchesFloat64(t *testing.T) {
+	for _, ot := range []uint8{tables.ObjectTypeLC, tables.ObjectTypeMain} {
+		quant := make([]int16, 1024)
+		for i := range quant {
+			quant[i] = int16((i*7)%17 - 8)
+		}
+
+		newCfg := func() *ReconstructSingleChannelConfig {
+			ics := newParallelTestICS(t, true)
+			ics.TNSDataPresent = true
+			ics.TNS.NFilt[0] = 1
+			ics.TNS.Length[0][0] = 20
+			ics.TNS.Order[0][0] = 4
+			ics.TNS.CoefRes[0] = 1
+			for k := range 4 {
+				ics.TNS.Coef[0][0][k] = uint8(k + 2)
+			}
+			cfg := &ReconstructSingleChannelConfig{
+				ICS:         ics,
+				Element:     &syntax.Element{},
+				FrameLength: 1024,
+				ObjectType:  ot,
+				SRIndex:     4,
+				PNSState:    NewPNSState(),
+			}
+			if ot == tables.ObjectTypeMain {
+				cfg.PredState = make([]PredState, 1024)
+				ResetAllPredictors(cfg.PredState, 1024)
+			}
+			return cfg
+		}
+
+		spec64 := make([]float64, 1024)
+		if err := ReconstructSingleChannel(append([]int16(nil), quant...), spec64, newCfg()); err != nil {
+			t.Fatalf("%v float64: %v", ot, err)
+		}
+		spec32 := make([]float32, 1024)
+		if err := ReconstructSingleChannel(append([]int16(nil), quant...), spec32, newCfg()); err != nil {
+			t.Fatalf("%v float32: %v", ot, err)
+		}
+
+		peak := 0.0
+		for _, v := range spec64 {
+			peak = math.Max(peak, math.Abs(v))
+		}
+		for i := range spec64 {
+			if diff := math.Abs(float64(spec32[i]) - spec64[i]); diff > 1e-5*peak {
+				t.Fatalf("%v bin %d: float32 %v, float64 %v", ot, i, spec32[i], spec64[i])
+			}
+		}
+	}
+}
+
+func TestApplyICPrediction_Float32InPlace(t *testing.T) {
+	ics := &syntax.ICStream{WindowSequence: syntax.EightShortSequence}
+	states := make([]PredState, 16)
+	states[3].R[0] = 7
+
+	spec := make([]float32, 16)
+	spec[2] = 1.5
+	applyICPrediction(ics, spec, states, 16, 4)
+
+	if states[3].R[0] != 0 {
+		t.Error("short frame should reset predictors in float32 mode too")
+	}
+	if spec[2] != 1.5 {
+		t.Errorf("spectrum altered: got %v, want 1.5", spec[2])
+	}
+}
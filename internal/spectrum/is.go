@@ -25,7 +25,7 @@ type ISDecodeConfig struct {
 // The left channel is NOT modified; only the right channel is written.
 //
 // Ported from: is_decode() in ~/dev/faad2/libfaad/is.c:46-106
-func ISDecode(lSpec, rSpec []float64, cfg *ISDecodeConfig) {
+func ISDecode[T Float](lSpec, rSpec []T, cfg *ISDecodeConfig) {
 	icsL := cfg.ICSL
 	icsR := cfg.ICSR
 
@@ -46,9 +46,11 @@ func ISDecode(lSpec, rSpec []float64, cfg *ISDecodeConfig) {
 					}
 
 					// Calculate scale: 0.5^(scaleFactor/4)
-					scale := math.Pow(0.5, 0.25*float64(scaleFactor))
+					scale := T(math.Pow(0.5, 0.25*float64(scaleFactor)))
 
-					// Determine sign inversion
+					// Out of phase when the codebook direction (+1 for
+					// INTENSITY_HCB, -1 for INTENSITY_HCB2) differs from
+					// the ms_used sign, i.e. one of them inverts but not both
 					invertSign := isDir != InvertIntensity(icsL, g, sfb)
 
 					// Calculate SFB bounds, clamped to swb_offset_max
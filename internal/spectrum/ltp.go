@@ -4,8 +4,8 @@ package spectrum
 import (
 	"math"
 
-	"github.com/llehouerou/go-aac"
 	"github.com/llehouerou/go-aac/internal/syntax"
+	"github.com/llehouerou/go-aac/internal/tables"
 )
 
 // ltpCodebook contains the 8 LTP coefficient values.
@@ -26,9 +26,9 @@ var ltpCodebook = [8]float64{
 // IsLTPObjectType returns true if the given object type supports LTP.
 //
 // Ported from: is_ltp_ot() in ~/dev/faad2/libfaad/lt_predict.c:49-66
-func IsLTPObjectType(objectType aac.ObjectType) bool {
+func IsLTPObjectType(objectType uint8) bool {
 	switch objectType {
-	case aac.ObjectTypeLTP, aac.ObjectTypeERLTP, aac.ObjectTypeLD:
+	case tables.ObjectTypeLTP, tables.ObjectTypeERLTP, tables.ObjectTypeLD:
 		return true
 	default:
 		return false
@@ -67,37 +67,37 @@ func realToInt16(sigIn float64) int16 {
 //   - ltPredStat: LTP state buffer (4*frameLen samples for LTP, or 4*512 for LD)
 //   - time: decoded time-domain samples for current frame
 //   - overlap: overlap samples from filter bank
-//   - frameLen: frame length (1024 or 960)
+//   - frameLen: frame length (1024 or 960, 512 or 480 for LD)
 //   - objectType: AAC object type
 //
 // Ported from: lt_update_state() in ~/dev/faad2/libfaad/lt_predict.c:173-213
-func LTPUpdateState(ltPredStat []int16, time, overlap []float64, frameLen uint16, objectType aac.ObjectType) {
-	if objectType == aac.ObjectTypeLD {
+func LTPUpdateState[T Float](ltPredStat []int16, time, overlap []T, frameLen uint16, objectType uint8) {
+	if objectType == tables.ObjectTypeLD {
 		// LD mode: extra 512 samples lookback
 		for i := uint16(0); i < frameLen; i++ {
-			ltPredStat[i] = ltPredStat[i+frameLen]             // Shift down
-			ltPredStat[frameLen+i] = ltPredStat[i+2*frameLen]  // Shift down
-			ltPredStat[2*frameLen+i] = realToInt16(time[i])    // New time samples
-			ltPredStat[3*frameLen+i] = realToInt16(overlap[i]) // New overlap samples
+			ltPredStat[i] = ltPredStat[i+frameLen]                      // Shift down
+			ltPredStat[frameLen+i] = ltPredStat[i+2*frameLen]           // Shift down
+			ltPredStat[2*frameLen+i] = realToInt16(float64(time[i]))    // New time samples
+			ltPredStat[3*frameLen+i] = realToInt16(float64(overlap[i])) // New overlap samples
 		}
 	} else {
 		// Non-LD mode (LTP, etc.)
 		for i := uint16(0); i < frameLen; i++ {
-			ltPredStat[i] = ltPredStat[i+frameLen]             // Shift down
-			ltPredStat[frameLen+i] = realToInt16(time[i])      // New time samples
-			ltPredStat[2*frameLen+i] = realToInt16(overlap[i]) // New overlap samples
+			ltPredStat[i] = ltPredStat[i+frameLen]                      // Shift down
+			ltPredStat[frameLen+i] = realToInt16(float64(time[i]))      // New time samples
+			ltPredStat[2*frameLen+i] = realToInt16(float64(overlap[i])) // New overlap samples
 			// ltPredStat[3*frameLen+i] stays zero (initialized once)
 		}
 	}
 }
 
 // ForwardMDCT is the interface for forward MDCT transformation.
-// This is provided by the filterbank package (Phase 5).
+// filterbank.ForwardMDCT implements it.
 type ForwardMDCT interface {
 	// FilterBankLTP applies forward MDCT for LTP.
 	// Transforms time-domain samples to frequency-domain coefficients.
 	FilterBankLTP(windowSequence uint8, windowShape, windowShapePrev uint8,
-		inData []float64, outMDCT []float64, objectType aac.ObjectType, frameLen uint16)
+		inData []float64, outMDCT []float64, objectType uint8, frameLen uint16)
 }
 
 // LTPConfig holds configuration for LTP prediction.
@@ -112,7 +112,7 @@ type LTPConfig struct {
 	SRIndex uint8
 
 	// ObjectType is the AAC object type
-	ObjectType aac.ObjectType
+	ObjectType uint8
 
 	// FrameLength is the frame length (1024 or 960)
 	FrameLength uint16
@@ -132,7 +132,7 @@ type LTPConfig struct {
 // 2. Multiplies by the LTP coefficient
 // 3. Applies forward MDCT to get frequency-domain prediction
 // 4. Applies TNS encoding to match original processing
-// 5. Adds prediction to spectrum for bands where LTP is active
+// 5. Adds prediction to spectrum only for LongUsed bands below LastBand
 //
 // Parameters:
 //   - spec: spectral coefficients to modify (input/output)
@@ -142,13 +142,13 @@ type LTPConfig struct {
 // Note: LTP is only applied to long windows, not short blocks.
 //
 // Ported from: lt_prediction() in ~/dev/faad2/libfaad/lt_predict.c:80-133
-func LTPPrediction(spec []float64, ltPredStat []int16, cfg *LTPConfig) {
+func LTPPrediction[T Float](spec []T, ltPredStat []int16, cfg *LTPConfig) {
 	LTPPredictionWithMDCT(spec, ltPredStat, nil, cfg)
 }
 
 // LTPPredictionWithMDCT applies Long Term Prediction with an explicit forward MDCT.
 // Use this when the filterbank is available.
-func LTPPredictionWithMDCT(spec []float64, ltPredStat []int16, fb ForwardMDCT, cfg *LTPConfig) {
+func LTPPredictionWithMDCT[T Float](spec []T, ltPredStat []int16, fb ForwardMDCT, cfg *LTPConfig) {
 	ics := cfg.ICS
 	ltp := cfg.LTP
 
@@ -164,8 +164,6 @@ func LTPPredictionWithMDCT(spec []float64, ltPredStat []int16, fb ForwardMDCT, c
 
 	// Forward MDCT is required for actual prediction
 	if fb == nil {
-		// TODO: Remove this check once filterbank is implemented
-		// For now, return early if no filterbank is provided
 		return
 	}
 
@@ -205,7 +203,7 @@ func LTPPredictionWithMDCT(spec []float64, ltPredStat []int16, fb ForwardMDCT, c
 			}
 
 			for bin := low; bin < high; bin++ {
-				spec[bin] += XEst[bin]
+				spec[bin] += T(XEst[bin])
 			}
 		}
 	}
@@ -5,23 +5,23 @@ import (
 	"math"
 	"testing"
 
-	"github.com/llehouerou/go-aac"
 	"github.com/llehouerou/go-aac/internal/syntax"
+	"github.com/llehouerou/go-aac/internal/tables"
 )
 
 func TestIsLTPObjectType(t *testing.T) {
 	tests := []struct {
 		name       string
-		objectType aac.ObjectType
+		objectType uint8
 		want       bool
 	}{
-		{"LC is not LTP", aac.ObjectTypeLC, false},
-		{"Main is not LTP", aac.ObjectTypeMain, false},
-		{"LTP is LTP", aac.ObjectTypeLTP, true},
-		{"ER_LTP is LTP", aac.ObjectTypeERLTP, true},
-		{"LD is LTP", aac.ObjectTypeLD, true},
-		{"SSR is not LTP", aac.ObjectTypeSSR, false},
-		{"HE_AAC is not LTP", aac.ObjectTypeHEAAC, false},
+		{"LC is not LTP", tables.ObjectTypeLC, false},
+		{"Main is not LTP", tables.ObjectTypeMain, false},
+		{"LTP is LTP", tables.ObjectTypeLTP, true},
+		{"ER_LTP is LTP", tables.ObjectTypeERLTP, true},
+		{"LD is LTP", tables.ObjectTypeLD, true},
+		{"SSR is not LTP", tables.ObjectTypeSSR, false},
+		{"HE_AAC is not LTP", tables.ObjectTypeHEAAC, false},
 	}
 
 	for _, tt := range tests {
@@ -112,7 +112,7 @@ func TestLTPUpdateState_NonLD(t *testing.T) {
 		overlap[i] = float64(i * 20)
 	}
 
-	LTPUpdateState(state, time, overlap, frameLen, aac.ObjectTypeLTP)
+	LTPUpdateState(state, time, overlap, frameLen, tables.ObjectTypeLTP)
 
 	// Expected layout after update:
 	// [0..7] = old state[8..15]
@@ -169,7 +169,7 @@ func TestLTPUpdateState_LD(t *testing.T) {
 		overlap[i] = float64(i * 20)
 	}
 
-	LTPUpdateState(state, time, overlap, frameLen, aac.ObjectTypeLD)
+	LTPUpdateState(state, time, overlap, frameLen, tables.ObjectTypeLD)
 
 	// Expected layout after update (LD mode):
 	// [0..7] = old state[8..15]
@@ -231,7 +231,7 @@ func TestLTPPrediction_NoDataPresent(t *testing.T) {
 		ICS:         ics,
 		LTP:         ltp,
 		SRIndex:     4,
-		ObjectType:  aac.ObjectTypeLTP,
+		ObjectType:  tables.ObjectTypeLTP,
 		FrameLength: frameLen,
 		// FilterBank is nil - won't be called since DataPresent is false
 	}
@@ -269,7 +269,7 @@ func TestLTPPrediction_ShortBlocks(t *testing.T) {
 		ICS:         ics,
 		LTP:         ltp,
 		SRIndex:     4,
-		ObjectType:  aac.ObjectTypeLTP,
+		ObjectType:  tables.ObjectTypeLTP,
 		FrameLength: frameLen,
 	}
 
@@ -282,3 +282,64 @@ func TestLTPPrediction_ShortBlocks(t *testing.T) {
 		}
 	}
 }
+
+// constantMDCT is a ForwardMDCT returning 1.0 in every bin, so the
+// prediction added by LTP is visible wherever it is applied.
+type constantMDCT struct{}
+
+func (constantMDCT) FilterBankLTP(_, _, _ uint8, _ []float64, outMDCT []float64, _ uint8, _ uint16) {
+	for i := range outMDCT {
+		outMDCT[i] = 1.0
+	}
+}
+
+func TestLTPPrediction_BandLimits(t *testing.T) {
+	frameLen := uint16(1024)
+	ics := &syntax.ICStream{
+		WindowSequence: syntax.OnlyLongSequence,
+		MaxSFB:         49,
+		NumSWB:         49,
+		SWBOffsetMax:   1024,
+	}
+	offsets, err := tables.GetSWBOffset(4, frameLen, false)
+	if err != nil {
+		t.Fatalf("GetSWBOffset failed: %v", err)
+	}
+	copy(ics.SWBOffset[:], offsets)
+
+	ltp := &syntax.LTPInfo{
+		DataPresent: true,
+		Lag:         100,
+		Coef:        3,
+		LastBand:    6,
+	}
+	ltp.LongUsed[0] = true
+	ltp.LongUsed[2] = true
+	ltp.LongUsed[5] = true
+	ltp.LongUsed[7] = true // Beyond LastBand, must be ignored
+
+	cfg := &LTPConfig{
+		ICS:         ics,
+		LTP:         ltp,
+		SRIndex:     4,
+		ObjectType:  tables.ObjectTypeLTP,
+		FrameLength: frameLen,
+	}
+
+	spec := make([]float64, frameLen)
+	state := make([]int16, 4*frameLen)
+	LTPPredictionWithMDCT(spec, state, constantMDCT{}, cfg)
+
+	predicted := map[int]bool{0: true, 2: true, 5: true}
+	for sfb := 0; sfb < int(ics.NumSWB); sfb++ {
+		want := 0.0
+		if predicted[sfb] {
+			want = 1.0
+		}
+		for bin := ics.SWBOffset[sfb]; bin < ics.SWBOffset[sfb+1]; bin++ {
+			if spec[bin] != want {
+				t.Fatalf("sfb %d bin %d: got %v, want %v", sfb, bin, spec[bin], want)
+			}
+		}
+	}
+}
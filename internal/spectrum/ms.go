@@ -23,7 +23,7 @@ type MSDecodeConfig struct {
 // - Noise bands (handled by pns_decode)
 //
 // Ported from: ms_decode() in ~/dev/faad2/libfaad/ms.c:39-77
-func MSDecode(lSpec, rSpec []float64, cfg *MSDecodeConfig) {
+func MSDecode[T Float](lSpec, rSpec []T, cfg *MSDecodeConfig) {
 	icsL := cfg.ICSL
 	icsR := cfg.ICSR
 
@@ -0,0 +1,112 @@
+// internal/spectrum/parallel.go
+package spectrum
+
+import (
+	"sync"
+	"sync/atomic"
+)
+
+// ElementJob describes the spectral reconstruction of one syntax element.
+// Exactly one of Single (SCE/LFE) or Pair (CPE) must be set. A CPE is a
+// single job because M/S, intensity stereo and correlated PNS couple its
+// two channels.
+type ElementJob[T Float] struct {
+	Single *ReconstructSingleChannelConfig
+	Pair   *ReconstructChannelPairConfig
+
+	// QuantData1/SpecData1 hold the (first) channel's buffers,
+	// QuantData2/SpecData2 the second channel of a CPE.
+	QuantData1, QuantData2 []int16
+	SpecData1, SpecData2   []T
+}
+
+// run reconstructs the element, overriding its PNS state.
+func (j *ElementJob[T]) run(pns *PNSState) error {
+	if j.Pair != nil {
+		j.Pair.PNSState = pns
+		return ReconstructChannelPair(j.QuantData1, j.QuantData2, j.SpecData1, j.SpecData2, j.Pair)
+	}
+	j.Single.PNSState = pns
+	return ReconstructSingleChannel(j.QuantData1, j.SpecData1, j.Single)
+}
+
+// ReconstructElements reconstructs every element of a frame.
+//
+// With workers <= 1 the elements run in bitstream order sharing pns, which
+// reproduces FAAD2's noise sequence exactly. With more workers the elements
+// run concurrently on a pool of that size. Since the order in which
+// elements would draw from a shared generator is then undefined, each
+// element gets its own generator derived from pns and its position in the
+// frame, and pns is stepped once afterwards. Parallel output is therefore
+// deterministic but its PNS noise differs from the sequential path.
+//
+// Jobs must not share mutable state other than pns: in parallel mode each
+// LTP job needs its own LTPFilterBank, as the filter bank reuses internal
+// buffers. A nil pns disables PNS for all elements. The first error in
+// bitstream order is returned.
+func ReconstructElements[T Float](jobs []ElementJob[T], pns *PNSState, workers int) error {
+	if workers <= 1 || len(jobs) <= 1 {
+		for i := range jobs {
+			if err := jobs[i].run(pns); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	states := make([]*PNSState, len(jobs))
+	if pns != nil {
+		for i := range states {
+			states[i] = splitPNSState(pns, i)
+		}
+		RNG(&pns.R1, &pns.R2)
+	}
+
+	if workers > len(jobs) {
+		workers = len(jobs)
+	}
+
+	// The calling goroutine is one of the workers
+	errs := make([]error, len(jobs))
+	var next atomic.Int32
+	work := func() {
+		for i := int(next.Add(1)) - 1; i < len(jobs); i = int(next.Add(1)) - 1 {
+			errs[i] = jobs[i].run(states[i])
+		}
+	}
+	var wg sync.WaitGroup
+	for w := 1; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			work()
+		}()
+	}
+	work()
+	wg.Wait()
+
+	for _, err := range errs {
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// splitPNSState derives an independent generator for the element at index
+// from the frame's base state. Neither register may be zero, as a zero
+// polycounter never leaves that state.
+func splitPNSState(base *PNSState, index int) *PNSState {
+	s := &PNSState{
+		R1:        base.R1 ^ (0x9E3779B9 * uint32(index+1)),
+		R2:        base.R2 + 0x7F4A7C15*uint32(index+1),
+		Generator: base.Generator,
+	}
+	if s.R1 == 0 {
+		s.R1 = 0x2bb431ea
+	}
+	if s.R2 == 0 {
+		s.R2 = 0x206155b7
+	}
+	return s
+}
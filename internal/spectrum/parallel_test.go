@@ -0,0 +1,239 @@
+// internal/spectrum/parallel_test.go
+package spectrum
+
+import (
+	"testing"
+
+	"github.com/llehouerou/go-aac/internal/huffman"
+	"github.com/llehouerou/go-aac/internal/syntax"
+	"github.com/llehouerou/go-aac/internal/tables"
+)
+
+// newParallelTestICS builds a long-block ICS using the 44100 Hz band layout,
+// with band 40 coded as noise when withNoise is set.
+func newParallelTestICS(t testing.TB, withNoise bool) *syntax.ICStream {
+	t.Helper()
+	offsets, err := tables.GetSWBOffset(4, 1024, false)
+	if err != nil {
+		t.Fatalf("GetSWBOffset failed: %v", err)
+	}
+	numSWB, _ := tables.GetNumSWB(4, 1024, false)
+
+	ics := &syntax.ICStream{
+		NumWindowGroups: 1,
+		NumWindows:      1,
+		MaxSFB:          numSWB,
+		NumSWB:          numSWB,
+		WindowSequence:  syntax.OnlyLongSequence,
+		SWBOffsetMax:    1024,
+	}
+	ics.WindowGroupLength[0] = 1
+	copy(ics.SWBOffset[:], offsets)
+	for sfb := uint8(0); sfb < numSWB; sfb++ {
+		ics.SFBCB[0][sfb] = 1
+		ics.ScaleFactors[0][sfb] = 100
+	}
+	if withNoise {
+		ics.SFBCB[0][40] = uint8(huffman.NoiseHCB)
+	}
+	return ics
+}
+
+// newSixChannelJobs builds a 5.1 frame: SCE (C), CPE (L/R), CPE (Ls/Rs), LFE.
+func newSixChannelJobs(t testing.TB, withNoise bool) []ElementJob[float64] {
+	t.Helper()
+	quant := func(seed int) []int16 {
+		q := make([]int16, 1024)
+		for i := range q {
+			q[i] = int16((i*7+seed*13)%17 - 8)
+		}
+		return q
+	}
+	single := func(seed int) ElementJob[float64] {
+		return ElementJob[float64]{
+			Single: &ReconstructSingleChannelConfig{
+				ICS:         newParallelTestICS(t, withNoise),
+				Element:     &syntax.Element{},
+				FrameLength: 1024,
+				ObjectType:  tables.ObjectTypeLC,
+				SRIndex:     4,
+			},
+			QuantData1: quant(seed),
+			SpecData1:  make([]float64, 1024),
+		}
+	}
+	pair := func(seed int) ElementJob[float64] {
+		ics1 := newParallelTestICS(t, withNoise)
+		ics1.MSMaskPresent = 2
+		return ElementJob[float64]{
+			Pair: &ReconstructChannelPairConfig{
+				ICS1:        ics1,
+				ICS2:        newParallelTestICS(t, withNoise),
+				Element:     &syntax.Element{CommonWindow: true},
+				FrameLength: 1024,
+				ObjectType:  tables.ObjectTypeLC,
+				SRIndex:     4,
+			},
+			QuantData1: quant(seed),
+			QuantData2: quant(seed + 1),
+			SpecData1:  make([]float64, 1024),
+			SpecData2:  make([]float64, 1024),
+		}
+	}
+	return []ElementJob[float64]{single(0), pair(1), pair(3), single(5)}
+}
+
+func collectSpectra(jobs []ElementJob[float64]) [][]float64 {
+	var out [][]float64
+	for _, j := range jobs {
+		out = append(out, j.SpecData1)
+		if j.Pair != nil {
+			out = append(out, j.SpecData2)
+		}
+	}
+	return out
+}
+
+func TestReconstructElements_ParallelMatchesSequential(t *testing.T) {
+	seq := newSixChannelJobs(t, false)
+	par := newSixChannelJobs(t, false)
+
+	if err := ReconstructElements(seq, nil, 1); err != nil {
+		t.Fatalf("sequential: %v", err)
+	}
+	if err := ReconstructElements(par, nil, 4); err != nil {
+		t.Fatalf("parallel: %v", err)
+	}
+
+	want := collectSpectra(seq)
+	got := collectSpectra(par)
+	if len(got) != 6 {
+		t.Fatalf("channels: got %d, want 6", len(got))
+	}
+	for ch := range want {
+		for i := range want[ch] {
+			if got[ch][i] != want[ch][i] {
+				t.Fatalf("channel %d bin %d: got %v, want %v", ch, i, got[ch][i], want[ch][i])
+			}
+		}
+	}
+}
+
+func TestReconstructElements_SequentialSharesPNSState(t *testing.T) {
+	// Sequential mode must draw from the shared generator in bitstream
+	// order, exactly like calling the reconstruct functions one by one.
+	jobs := newSixChannelJobs(t, true)
+	ref := newSixChannelJobs(t, true)
+
+	pns := NewPNSState()
+	if err := ReconstructElements(jobs, pns, 1); err != nil {
+		t.Fatalf("ReconstructElements: %v", err)
+	}
+
+	refPNS := NewPNSState()
+	for i := range ref {
+		if err := ref[i].run(refPNS); err != nil {
+			t.Fatalf("run: %v", err)
+		}
+	}
+
+	if *pns != *refPNS {
+		t.Errorf("PNS state: got %+v, want %+v", *pns, *refPNS)
+	}
+	want := collectSpectra(ref)
+	got := collectSpectra(jobs)
+	for ch := range want {
+		for i := range want[ch] {
+			if got[ch][i] != want[ch][i] {
+				t.Fatalf("channel %d bin %d: got %v, want %v", ch, i, got[ch][i], want[ch][i])
+			}
+		}
+	}
+}
+
+func TestReconstructElements_ParallelPNSDeterministic(t *testing.T) {
+	run := func() [][]float64 {
+		jobs := newSixChannelJobs(t, true)
+		if err := ReconstructElements(jobs, NewPNSState(), 3); err != nil {
+			t.Fatalf("ReconstructElements: %v", err)
+		}
+		return collectSpectra(jobs)
+	}
+
+	first := run()
+	for n := 0; n < 5; n++ {
+		again := run()
+		for ch := range first {
+			for i := range first[ch] {
+				if again[ch][i] != first[ch][i] {
+					t.Fatalf("run %d channel %d bin %d: got %v, want %v", n, ch, i, again[ch][i], first[ch][i])
+				}
+			}
+		}
+	}
+
+	// Noise bands of independent elements must not be identical
+	offsets, _ := tables.GetSWBOffset(4, 1024, false)
+	k := offsets[40]
+	if first[0][k] == 0 || first[0][k] == first[5][k] {
+		t.Errorf("expected distinct non-zero noise, got %v and %v", first[0][k], first[5][k])
+	}
+}
+
+func TestReconstructElements_FirstErrorInOrder(t *testing.T) {
+	jobs := newSixChannelJobs(t, false)
+	// Mismatched buffer lengths fail inverse quantization
+	jobs[1].SpecData1 = make([]float64, 10)
+	jobs[3].Single.ICS.PulseDataPresent = true
+	jobs[3].Single.ICS.WindowSequence = syntax.EightShortSequence
+
+	for _, workers := range []int{1, 4} {
+		err := ReconstructElements(append([]ElementJob[float64](nil), jobs...), nil, workers)
+		if err != ErrLengthMismatch {
+			t.Errorf("workers=%d: expected ErrLengthMismatch, got %v", workers, err)
+		}
+	}
+}
+
+func TestSplitPNSState_KeepsGenerator(t *testing.T) {
+	base := &PNSState{R1: 1, R2: 2, Generator: &patternGenerator{}}
+	if s := splitPNSState(base, 3); s.Generator != base.Generator {
+		t.Error("split PNS state lost the Generator")
+	}
+}
+
+func benchmarkReconstructElements(b *testing.B, workers int) {
+	jobs := newSixChannelJobs(b, true)
+	quant := make([][2][]int16, len(jobs))
+	for i, j := range jobs {
+		quant[i][0] = append([]int16(nil), j.QuantData1...)
+		if j.QuantData2 != nil {
+			quant[i][1] = append([]int16(nil), j.QuantData2...)
+		}
+	}
+	pns := NewPNSState()
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for n := 0; n < b.N; n++ {
+		b.StopTimer()
+		for i := range jobs {
+			jobs[i].QuantData1 = append(jobs[i].QuantData1[:0], quant[i][0]...)
+			if quant[i][1] != nil {
+				jobs[i].QuantData2 = append(jobs[i].QuantData2[:0], quant[i][1]...)
+			}
+		}
+		b.StartTimer()
+		if err := ReconstructElements(jobs, pns, workers); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkReconstructElements_6ch_Sequential(b *testing.B) {
+	benchmarkReconstructElements(b, 1)
+}
+
+func BenchmarkReconstructElements_6ch_Parallel(b *testing.B) {
+	benchmarkReconstructElements(b, 4)
+}
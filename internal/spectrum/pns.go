@@ -11,6 +11,13 @@ import (
 // Ported from: NOISE_OFFSET in ~/dev/faad2/libfaad/pns.h:40
 const NoiseOffset = 90
 
+// NoiseGenerator is a noise source replacing the R1/R2 generator, as
+// aac.Config.NoiseGenerator. Fill overwrites spec with the noise of one
+// band whose energy is set by scaleFactor.
+type NoiseGenerator interface {
+	Fill(spec []float64, scaleFactor int)
+}
+
 // PNSState holds the random number generator state for PNS decoding.
 // The state must be preserved across frames for proper decoder behavior.
 //
@@ -18,6 +25,10 @@ const NoiseOffset = 90
 type PNSState struct {
 	R1 uint32
 	R2 uint32
+
+	// Generator, when non-nil, fills noise bands instead of the R1/R2
+	// generator, which is then left untouched.
+	Generator NoiseGenerator
 }
 
 // NewPNSState creates a new PNS state with default initial values.
@@ -53,7 +64,7 @@ type PNSDecodeConfig struct {
 // and the random values are normalized to unit energy.
 //
 // Ported from: gen_rand_vector() in ~/dev/faad2/libfaad/pns.c:80-107 (floating-point path)
-func genRandVector(spec []float64, scaleFactor int16, r1, r2 *uint32) {
+func genRandVector[T Float](spec []T, scaleFactor int16, r1, r2 *uint32) {
 	size := len(spec)
 	if size == 0 {
 		return
@@ -72,7 +83,7 @@ func genRandVector(spec []float64, scaleFactor int16, r1, r2 *uint32) {
 	for i := 0; i < size; i++ {
 		// Convert RNG output to signed float
 		tmp := float64(int32(RNG(r1, r2)))
-		spec[i] = tmp
+		spec[i] = T(tmp)
 		energy += tmp * tmp
 	}
 
@@ -84,7 +95,37 @@ func genRandVector(spec []float64, scaleFactor int16, r1, r2 *uint32) {
 		scale *= math.Pow(2.0, 0.25*float64(sf))
 
 		for i := 0; i < size; i++ {
-			spec[i] *= scale
+			spec[i] *= T(scale)
+		}
+	}
+}
+
+// fillNoise fills one noise band from the state's Generator if set, or
+// from the r1/r2 generator otherwise.
+func fillNoise[T Float](spec []T, scaleFactor int16, gen NoiseGenerator, r1, r2 *uint32) {
+	if gen == nil {
+		genRandVector(spec, scaleFactor, r1, r2)
+		return
+	}
+	if s, ok := any(spec).([]float64); ok {
+		gen.Fill(s, int(scaleFactor))
+		return
+	}
+	tmp := make([]float64, len(spec))
+	gen.Fill(tmp, int(scaleFactor))
+	for i, v := range tmp {
+		spec[i] = T(v)
+	}
+}
+
+// copyCorrelatedNoise sets dst to the left channel noise src rescaled from
+// the left to the right scale factor. This reproduces correlated noise
+// for a Generator, which cannot be replayed like the r1/r2 generator.
+func copyCorrelatedNoise[T Float](dst, src []T, sfL, sfR int16) {
+	scale := T(math.Pow(2.0, 0.25*float64(sfR-sfL)))
+	for i := range dst {
+		if i < len(src) {
+			dst[i] = src[i] * scale
 		}
 	}
 }
@@ -98,8 +139,12 @@ func genRandVector(spec []float64, scaleFactor int16, r1, r2 *uint32) {
 //     the same noise is used for both channels (correlated).
 //   - Otherwise, independent noise is generated for each channel.
 //
+// When state.Generator is set it supplies the noise, and correlated right
+// channel bands copy the left band's noise rescaled to their own scale
+// factor.
+//
 // Ported from: pns_decode() in ~/dev/faad2/libfaad/pns.c:150-270
-func PNSDecode(specL, specR []float64, state *PNSState, cfg *PNSDecodeConfig) {
+func PNSDecode[T Float](specL, specR []T, state *PNSState, cfg *PNSDecodeConfig) {
 	icsL := cfg.ICSL
 	icsR := cfg.ICSR
 
@@ -114,6 +159,7 @@ func PNSDecode(specL, specR []float64, state *PNSState, cfg *PNSDecodeConfig) {
 				// RNG state for potential right channel correlation
 				// Captured inside left channel block, per FAAD2 pns.c:209-210
 				var r1Dep, r2Dep uint32
+				var noiseL []T
 
 				// Process left channel PNS
 				if IsNoiseICS(icsL, g, sfb) {
@@ -133,7 +179,8 @@ func PNSDecode(specL, specR []float64, state *PNSState, cfg *PNSDecodeConfig) {
 						// This must happen inside the left noise block, per FAAD2 pns.c:209-210
 						r1Dep = state.R1
 						r2Dep = state.R2
-						genRandVector(specL[beginIdx:endIdx], icsL.ScaleFactors[g][sfb], &state.R1, &state.R2)
+						noiseL = specL[beginIdx:endIdx]
+						fillNoise(noiseL, icsL.ScaleFactors[g][sfb], state.Generator, &state.R1, &state.R2)
 					}
 				}
 
@@ -158,12 +205,16 @@ func PNSDecode(specL, specR []float64, state *PNSState, cfg *PNSDecodeConfig) {
 							icsL.MSMaskPresent == 2)
 
 					if beginIdx < endIdx && int(endIdx) <= len(specR) {
-						if useCorrelated {
+						switch {
+						case useCorrelated && state.Generator != nil:
+							copyCorrelatedNoise(specR[beginIdx:endIdx], noiseL,
+								icsL.ScaleFactors[g][sfb], icsR.ScaleFactors[g][sfb])
+						case useCorrelated:
 							// Use the same RNG state as left channel (dependent)
 							genRandVector(specR[beginIdx:endIdx], icsR.ScaleFactors[g][sfb], &r1Dep, &r2Dep)
-						} else {
+						default:
 							// Use independent RNG state
-							genRandVector(specR[beginIdx:endIdx], icsR.ScaleFactors[g][sfb], &state.R1, &state.R2)
+							fillNoise(specR[beginIdx:endIdx], icsR.ScaleFactors[g][sfb], state.Generator, &state.R1, &state.R2)
 						}
 					}
 				}
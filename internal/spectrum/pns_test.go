@@ -772,3 +772,98 @@ func TestPNSDecode_StatePreservedAcrossCalls(t *testing.T) {
 		t.Error("consecutive calls should produce different noise")
 	}
 }
+
+// patternGenerator fills each band with its scale factor followed by a
+// running call counter, and records the scale factors it was asked for.
+type patternGenerator struct {
+	calls        int
+	scaleFactors []int
+}
+
+func (p *patternGenerator) Fill(spec []float64, scaleFactor int) {
+	p.calls++
+	p.scaleFactors = append(p.scaleFactors, scaleFactor)
+	for i := range spec {
+		spec[i] = float64(scaleFactor) + float64(p.calls)/10
+	}
+}
+
+// newGeneratorTestPair builds a stereo pair with a noise band in each
+// channel (scale factors 4 and 8) over bins 0-7.
+func newGeneratorTestPair(msUsed uint8) (*syntax.ICStream, *syntax.ICStream) {
+	newICS := func(sf int16) *syntax.ICStream {
+		ics := &syntax.ICStream{
+			NumWindowGroups: 1,
+			MaxSFB:          1,
+			WindowSequence:  syntax.OnlyLongSequence,
+			SWBOffsetMax:    1024,
+		}
+		ics.WindowGroupLength[0] = 1
+		ics.SWBOffset[1] = 8
+		ics.SFBCB[0][0] = uint8(huffman.NoiseHCB)
+		ics.ScaleFactors[0][0] = sf
+		return ics
+	}
+	icsL := newICS(4)
+	icsL.MSMaskPresent = 1
+	icsL.MSUsed[0][0] = msUsed
+	return icsL, newICS(8)
+}
+
+func TestPNSDecode_Generator(t *testing.T) {
+	icsL, icsR := newGeneratorTestPair(0)
+	gen := &patternGenerator{}
+	state := NewPNSState()
+	state.Generator = gen
+	before := *state
+
+	specL := make([]float64, 16)
+	specR := make([]float64, 16)
+	PNSDecode(specL, specR, state, &PNSDecodeConfig{
+		ICSL:        icsL,
+		ICSR:        icsR,
+		FrameLength: 1024,
+		ChannelPair: true,
+	})
+
+	if gen.calls != 2 || gen.scaleFactors[0] != 4 || gen.scaleFactors[1] != 8 {
+		t.Fatalf("generator calls: got %d with scale factors %v, want 2 with [4 8]", gen.calls, gen.scaleFactors)
+	}
+	for i := 0; i < 8; i++ {
+		if specL[i] != 4.1 || specR[i] != 8.2 {
+			t.Fatalf("bin %d: got L=%v R=%v, want 4.1 and 8.2", i, specL[i], specR[i])
+		}
+	}
+	if specL[8] != 0 || specR[8] != 0 {
+		t.Errorf("bins outside the noise band were written")
+	}
+	if state.R1 != before.R1 || state.R2 != before.R2 {
+		t.Errorf("built-in generator state advanced with a custom Generator")
+	}
+}
+
+func TestPNSDecode_GeneratorCorrelated(t *testing.T) {
+	// Correlated noise: the right band reuses the left band's noise,
+	// rescaled from scale factor 4 to 8 (a factor of 2^(4/4) = 2)
+	icsL, icsR := newGeneratorTestPair(1)
+	gen := &patternGenerator{}
+	state := &PNSState{Generator: gen}
+
+	specL := make([]float32, 8)
+	specR := make([]float32, 8)
+	PNSDecode(specL, specR, state, &PNSDecodeConfig{
+		ICSL:        icsL,
+		ICSR:        icsR,
+		FrameLength: 1024,
+		ChannelPair: true,
+	})
+
+	if gen.calls != 1 {
+		t.Fatalf("generator calls: got %d, want 1", gen.calls)
+	}
+	for i := range specL {
+		if specL[i] != 4.1 || specR[i] != 2*specL[i] {
+			t.Fatalf("bin %d: got L=%v R=%v, want 4.1 and %v", i, specL[i], specR[i], 2*specL[i])
+		}
+	}
+}
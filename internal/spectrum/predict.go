@@ -204,6 +204,12 @@ func PNSResetPredState(ics *syntax.ICStream, states []PredState) {
 // For short sequences, all predictors are reset.
 // For long sequences, prediction is applied per SFB based on prediction_used flags.
 //
+// Resetting on every EIGHT_SHORT_SEQUENCE frame is what handles window
+// sequence changes: state never survives a switch into short blocks, and
+// the first long frame after them starts from reset predictors. No reset
+// happens on LONG_START or LONG_STOP frames, which FAAD2 predicts like
+// any long frame.
+//
 // Ported from: ic_prediction() in ~/dev/faad2/libfaad/ic_predict.c:245-279
 func ICPrediction(ics *syntax.ICStream, spec []float32, states []PredState, frameLen uint16, sfIndex uint8) {
 	if ics.WindowSequence == syntax.EightShortSequence {
@@ -376,3 +376,72 @@ func TestPNSResetPredState_ShortSequence(t *testing.T) {
 		t.Errorf("states[0].R[0] = %d, want 100 (short sequence, no reset)", states[0].R[0])
 	}
 }
+
+func TestICPrediction_WindowSequenceTransitions(t *testing.T) {
+	// long -> short -> long: the short frame must reset every predictor so
+	// the second long frame behaves exactly like the first one.
+	frameLen := uint16(1024)
+	states := make([]PredState, frameLen)
+	ResetAllPredictors(states, frameLen)
+
+	longICS := &syntax.ICStream{
+		WindowSequence:       syntax.OnlyLongSequence,
+		MaxSFB:               10,
+		PredictorDataPresent: true,
+		SWBOffsetMax:         100,
+	}
+	for i := uint8(0); i < 10; i++ {
+		longICS.Pred.PredictionUsed[i] = true
+	}
+	for i := 0; i <= 10; i++ {
+		longICS.SWBOffset[i] = uint16(i * 10)
+	}
+	shortICS := &syntax.ICStream{WindowSequence: syntax.EightShortSequence}
+
+	input := func() []float32 {
+		spec := make([]float32, frameLen)
+		for i := range spec {
+			spec[i] = float32(i%7) + 0.5
+		}
+		return spec
+	}
+
+	// Two long frames build up predictor state
+	ICPrediction(longICS, input(), states, frameLen, 3)
+	firstLong := make([]PredState, frameLen)
+	copy(firstLong, states)
+	secondOut := input()
+	ICPrediction(longICS, secondOut, states, frameLen, 3)
+
+	fresh := *NewPredState()
+	if states[5] == fresh {
+		t.Fatal("long frames should have updated predictor state")
+	}
+
+	// Short frame: nothing is carried through
+	ICPrediction(shortICS, input(), states, frameLen, 3)
+	for i := range states {
+		if states[i] != fresh {
+			t.Fatalf("states[%d] = %+v after short frame, want reset %+v", i, states[i], fresh)
+		}
+	}
+
+	// Long again: identical to the very first long frame
+	ICPrediction(longICS, input(), states, frameLen, 3)
+	for i := range states {
+		if states[i] != firstLong[i] {
+			t.Fatalf("states[%d] = %+v after short->long, want %+v", i, states[i], firstLong[i])
+		}
+	}
+
+	// A transition frame keeps predicting from the accumulated state
+	startICS := *longICS
+	startICS.WindowSequence = syntax.LongStartSequence
+	startOut := input()
+	ICPrediction(&startICS, startOut, states, frameLen, 3)
+	for i := 0; i < 100; i++ {
+		if startOut[i] != secondOut[i] {
+			t.Fatalf("LONG_START bin %d = %v, want %v (predicted like a long frame)", i, startOut[i], secondOut[i])
+		}
+	}
+}
@@ -8,11 +8,24 @@ import "github.com/llehouerou/go-aac/internal/syntax"
 //
 // The function modifies specData in place. It should only be called
 // for long blocks (pulse coding is not allowed in short blocks).
+// The start band must be one of the ics's num_swb bands, and every pulse
+// position is checked against both frameLen and the length of specData,
+// so malformed pulse data yields ErrPulseOutOfRange instead of an
+// out-of-range write.
 //
 // Ported from: pulse_decode() in ~/dev/faad2/libfaad/pulse.c:36-58
 func PulseDecode(ics *syntax.ICStream, specData []int16, frameLen uint16) error {
 	pul := &ics.Pul
 
+	limit := frameLen
+	if len(specData) < int(limit) {
+		limit = uint16(len(specData))
+	}
+
+	if pul.PulseStartSFB >= ics.NumSWB {
+		return syntax.ErrPulseOutOfRange
+	}
+
 	// Start position is clamped to swb_offset_max
 	k := ics.SWBOffset[pul.PulseStartSFB]
 	if k > ics.SWBOffsetMax {
@@ -24,8 +37,8 @@ func PulseDecode(ics *syntax.ICStream, specData []int16, frameLen uint16) error
 	for i := uint8(0); i < numPulses; i++ {
 		k += uint16(pul.PulseOffset[i])
 
-		if k >= frameLen {
-			return syntax.ErrPulsePosition
+		if k >= limit {
+			return syntax.ErrPulseOutOfRange
 		}
 
 		if specData[k] > 0 {
@@ -147,8 +147,8 @@ func TestPulseDecode_PositionExceedsFrame(t *testing.T) {
 	specData := make([]int16, 1024)
 
 	err := PulseDecode(ics, specData, 1024)
-	if err != syntax.ErrPulsePosition {
-		t.Errorf("expected ErrPulsePosition, got %v", err)
+	if err != syntax.ErrPulseOutOfRange {
+		t.Errorf("expected ErrPulseOutOfRange, got %v", err)
 	}
 }
 
@@ -271,3 +271,77 @@ func TestPulseDecode_LargeAmplitude(t *testing.T) {
 		t.Errorf("specData[0]: got %d, want 115", specData[0])
 	}
 }
+
+func TestPulseDecode_OffsetOutOfRange(t *testing.T) {
+	// Malformed stream: cumulative offsets (4 x 31) walk past the frame
+	ics := &syntax.ICStream{
+		NumSWB:       49,
+		SWBOffsetMax: 1024,
+	}
+	ics.SWBOffset[48] = 960
+
+	ics.Pul = syntax.PulseInfo{
+		NumberPulse:   3,
+		PulseStartSFB: 48,
+		PulseOffset:   [4]uint8{31, 31, 31, 31},
+		PulseAmp:      [4]uint8{1, 1, 1, 1},
+	}
+
+	specData := make([]int16, 1024)
+
+	err := PulseDecode(ics, specData, 1024)
+	if err != syntax.ErrPulseOutOfRange {
+		t.Errorf("expected ErrPulseOutOfRange, got %v", err)
+	}
+}
+
+func TestPulseDecode_ShortBuffer(t *testing.T) {
+	// A buffer shorter than frameLen must not be written past its end
+	ics := &syntax.ICStream{
+		NumSWB:       10,
+		SWBOffsetMax: 1024,
+	}
+	ics.SWBOffset[0] = 0
+
+	ics.Pul = syntax.PulseInfo{
+		NumberPulse:   1,
+		PulseStartSFB: 0,
+		PulseOffset:   [4]uint8{20, 20, 0, 0}, // Positions: 20, 40
+		PulseAmp:      [4]uint8{1, 1, 0, 0},
+	}
+
+	specData := make([]int16, 32)
+
+	err := PulseDecode(ics, specData, 1024)
+	if err != syntax.ErrPulseOutOfRange {
+		t.Errorf("expected ErrPulseOutOfRange, got %v", err)
+	}
+}
+
+func TestPulseDecode_StartBandOutOfRange(t *testing.T) {
+	// pulse_start_sfb == num_swb passes the syntax check but names no band
+	ics := &syntax.ICStream{
+		NumSWB:       10,
+		SWBOffsetMax: 1024,
+	}
+	for i := 0; i <= 10; i++ {
+		ics.SWBOffset[i] = uint16(i * 4)
+	}
+
+	ics.Pul = syntax.PulseInfo{
+		NumberPulse:   0,
+		PulseStartSFB: 10,
+		PulseOffset:   [4]uint8{1, 0, 0, 0},
+		PulseAmp:      [4]uint8{1, 0, 0, 0},
+	}
+
+	specData := make([]int16, 1024)
+
+	err := PulseDecode(ics, specData, 1024)
+	if err != syntax.ErrPulseOutOfRange {
+		t.Errorf("expected ErrPulseOutOfRange, got %v", err)
+	}
+	if specData[41] != 0 {
+		t.Errorf("specData[41] = %d, want untouched", specData[41])
+	}
+}
@@ -2,8 +2,8 @@
 package spectrum
 
 import (
-	"github.com/llehouerou/go-aac"
 	"github.com/llehouerou/go-aac/internal/syntax"
+	"github.com/llehouerou/go-aac/internal/tables"
 )
 
 // ReconstructChannelPairConfig holds configuration for channel pair reconstruction.
@@ -23,7 +23,7 @@ type ReconstructChannelPairConfig struct {
 	FrameLength uint16
 
 	// ObjectType is the AAC object type
-	ObjectType aac.ObjectType
+	ObjectType uint8
 
 	// SRIndex is the sample rate index (0-15)
 	SRIndex uint8
@@ -57,6 +57,11 @@ type ReconstructChannelPairConfig struct {
 
 	// PNSState is the PNS random number generator state
 	PNSState *PNSState
+
+	// Couplings1 and Couplings2 are the dependently switched coupling
+	// channels added to each channel (nil without coupling)
+	Couplings1 []DependentCoupling
+	Couplings2 []DependentCoupling
 }
 
 // ReconstructChannelPair performs spectral reconstruction for a channel pair (stereo).
@@ -65,17 +70,18 @@ type ReconstructChannelPairConfig struct {
 // the filter bank.
 //
 // Processing order:
-// 1. Inverse quantization + scale factors for both channels
+// 1. Inverse quantization, short window deinterleaving and scale factors for both channels
 // 2. PNS decode (with correlation based on ms_mask_present)
 // 3. M/S stereo decode
 // 4. Intensity stereo decode
 // 5. IC Prediction (MAIN profile, both channels)
 // 6. PNS reset pred state (MAIN profile, both channels)
 // 7. LTP prediction (LTP profile, both channels, using ltp2 for channel 2 when common_window)
-// 8. TNS decode for both channels
+// 8. TNS decode for both channels, with dependently switched coupling
+// channels added before or after it
 //
 // Ported from: reconstruct_channel_pair() in ~/dev/faad2/libfaad/specrec.c:1131-1365
-func ReconstructChannelPair(quantData1, quantData2 []int16, specData1, specData2 []float64, cfg *ReconstructChannelPairConfig) error {
+func ReconstructChannelPair[T Float](quantData1, quantData2 []int16, specData1, specData2 []T, cfg *ReconstructChannelPairConfig) error {
 	ics1 := cfg.ICS1
 	ics2 := cfg.ICS2
 	ele := cfg.Element
@@ -108,6 +114,8 @@ func ReconstructChannelPair(quantData1, quantData2 []int16, specData1, specData2
 	if err := InverseQuantize(quantData2, specData2); err != nil {
 		return err
 	}
+	deinterleaveSpectrum(specData1, ics1)
+	deinterleaveSpectrum(specData2, ics2)
 
 	// 1d. Apply scale factors: spec[i] *= 2^((sf-100)/4)
 	ApplyScaleFactors(specData1, &ApplyScaleFactorsConfig{
@@ -150,29 +158,13 @@ func ReconstructChannelPair(quantData1, quantData2 []int16, specData1, specData2
 
 	// 5 & 6. IC Prediction (MAIN profile only)
 	// FAAD2: specrec.c:1219-1233
-	if cfg.ObjectType == aac.ObjectTypeMain {
+	if cfg.ObjectType == tables.ObjectTypeMain {
 		if cfg.PredState1 != nil {
-			// Convert float64 to float32 for IC prediction (channel 1)
-			specData32_1 := make([]float32, len(specData1))
-			for i, v := range specData1 {
-				specData32_1[i] = float32(v)
-			}
-			ICPrediction(ics1, specData32_1, cfg.PredState1, frameLen, cfg.SRIndex)
-			for i, v := range specData32_1 {
-				specData1[i] = float64(v)
-			}
+			applyICPrediction(ics1, specData1, cfg.PredState1, frameLen, cfg.SRIndex)
 			PNSResetPredState(ics1, cfg.PredState1)
 		}
 		if cfg.PredState2 != nil {
-			// Convert float64 to float32 for IC prediction (channel 2)
-			specData32_2 := make([]float32, len(specData2))
-			for i, v := range specData2 {
-				specData32_2[i] = float32(v)
-			}
-			ICPrediction(ics2, specData32_2, cfg.PredState2, frameLen, cfg.SRIndex)
-			for i, v := range specData32_2 {
-				specData2[i] = float64(v)
-			}
+			applyICPrediction(ics2, specData2, cfg.PredState2, frameLen, cfg.SRIndex)
 			PNSResetPredState(ics2, cfg.PredState2)
 		}
 	}
@@ -212,6 +204,9 @@ func ReconstructChannelPair(quantData1, quantData2 []int16, specData1, specData2
 		}
 	}
 
+	applyCouplings(specData1, cfg.Couplings1, false, frameLen)
+	applyCouplings(specData2, cfg.Couplings2, false, frameLen)
+
 	// 8. TNS decode (temporal noise shaping)
 	// FAAD2: tns_decode_frame() in specrec.c:1270-1273
 	if ics1.TNSDataPresent {
@@ -231,6 +226,9 @@ func ReconstructChannelPair(quantData1, quantData2 []int16, specData1, specData2
 		})
 	}
 
+	applyCouplings(specData1, cfg.Couplings1, true, frameLen)
+	applyCouplings(specData2, cfg.Couplings2, true, frameLen)
+
 	return nil
 }
 
@@ -248,7 +246,7 @@ type ReconstructSingleChannelConfig struct {
 	FrameLength uint16
 
 	// ObjectType is the AAC object type
-	ObjectType aac.ObjectType
+	ObjectType uint8
 
 	// SRIndex is the sample rate index (0-15)
 	SRIndex uint8
@@ -270,6 +268,14 @@ type ReconstructSingleChannelConfig struct {
 
 	// PNSState is the PNS random number generator state
 	PNSState *PNSState
+
+	// Couplings are the dependently switched coupling channels added to
+	// the channel (nil without coupling)
+	Couplings []DependentCoupling
+
+	// SkipTNS leaves TNS out. A dependently switched coupling channel is
+	// added to its targets' spectra without its own TNS.
+	SkipTNS bool
 }
 
 // ReconstructSingleChannel performs spectral reconstruction for a single channel.
@@ -285,10 +291,11 @@ type ReconstructSingleChannelConfig struct {
 // 5. IC Prediction (MAIN profile only)
 // 6. PNS reset pred state (MAIN profile only)
 // 7. LTP prediction (LTP profile only)
-// 8. TNS decode (temporal noise shaping)
+// 8. TNS decode (temporal noise shaping), with dependently switched
+// coupling channels added before or after it
 //
 // Ported from: reconstruct_single_channel() in ~/dev/faad2/libfaad/specrec.c:905-1129
-func ReconstructSingleChannel(quantData []int16, specData []float64, cfg *ReconstructSingleChannelConfig) error {
+func ReconstructSingleChannel[T Float](quantData []int16, specData []T, cfg *ReconstructSingleChannelConfig) error {
 	ics := cfg.ICS
 	frameLen := cfg.FrameLength
 
@@ -306,6 +313,7 @@ func ReconstructSingleChannel(quantData []int16, specData []float64, cfg *Recons
 	if err := InverseQuantize(quantData, specData); err != nil {
 		return err
 	}
+	deinterleaveSpectrum(specData, ics)
 
 	// 3. Apply scale factors: spec[i] *= 2^((sf-100)/4)
 	ApplyScaleFactors(specData, &ApplyScaleFactorsConfig{
@@ -325,19 +333,8 @@ func ReconstructSingleChannel(quantData []int16, specData []float64, cfg *Recons
 	}
 
 	// 5 & 6. IC Prediction (MAIN profile only)
-	if cfg.ObjectType == aac.ObjectTypeMain && cfg.PredState != nil {
-		// Convert float64 to float32 for IC prediction
-		specData32 := make([]float32, len(specData))
-		for i, v := range specData {
-			specData32[i] = float32(v)
-		}
-
-		ICPrediction(ics, specData32, cfg.PredState, frameLen, cfg.SRIndex)
-
-		// Convert back to float64
-		for i, v := range specData32 {
-			specData[i] = float64(v)
-		}
+	if cfg.ObjectType == tables.ObjectTypeMain && cfg.PredState != nil {
+		applyICPrediction(ics, specData, cfg.PredState, frameLen, cfg.SRIndex)
 
 		// Reset predictors for PNS bands
 		PNSResetPredState(ics, cfg.PredState)
@@ -356,8 +353,10 @@ func ReconstructSingleChannel(quantData []int16, specData []float64, cfg *Recons
 		})
 	}
 
+	applyCouplings(specData, cfg.Couplings, false, frameLen)
+
 	// 8. TNS decode (temporal noise shaping)
-	if ics.TNSDataPresent {
+	if ics.TNSDataPresent && !cfg.SkipTNS {
 		TNSDecodeFrame(specData, &TNSDecodeConfig{
 			ICS:         ics,
 			SRIndex:     cfg.SRIndex,
@@ -366,5 +365,25 @@ func ReconstructSingleChannel(quantData []int16, specData []float64, cfg *Recons
 		})
 	}
 
+	applyCouplings(specData, cfg.Couplings, true, frameLen)
+
 	return nil
 }
+
+// applyICPrediction runs ICPrediction, which works on float32, on spec.
+// float32 spectra are predicted in place, float64 ones through a copy.
+func applyICPrediction[T Float](ics *syntax.ICStream, spec []T, states []PredState, frameLen uint16, sfIndex uint8) {
+	if spec32, ok := any(spec).([]float32); ok {
+		ICPrediction(ics, spec32, states, frameLen, sfIndex)
+		return
+	}
+
+	spec32 := make([]float32, len(spec))
+	for i, v := range spec {
+		spec32[i] = float32(v)
+	}
+	ICPrediction(ics, spec32, states, frameLen, sfIndex)
+	for i, v := range spec32 {
+		spec[i] = T(v)
+	}
+}
@@ -2,11 +2,12 @@
 package spectrum
 
 import (
+	"math"
 	"testing"
 
-	"github.com/llehouerou/go-aac"
 	"github.com/llehouerou/go-aac/internal/huffman"
 	"github.com/llehouerou/go-aac/internal/syntax"
+	"github.com/llehouerou/go-aac/internal/tables"
 )
 
 func TestReconstructSingleChannelConfig_Defaults(t *testing.T) {
@@ -17,15 +18,15 @@ func TestReconstructSingleChannelConfig_Defaults(t *testing.T) {
 		ICS:         ics,
 		Element:     ele,
 		FrameLength: 1024,
-		ObjectType:  aac.ObjectTypeLC,
+		ObjectType:  tables.ObjectTypeLC,
 		SRIndex:     4, // 44100 Hz
 	}
 
 	if cfg.FrameLength != 1024 {
 		t.Errorf("FrameLength: got %d, want 1024", cfg.FrameLength)
 	}
-	if cfg.ObjectType != aac.ObjectTypeLC {
-		t.Errorf("ObjectType: got %d, want %d", cfg.ObjectType, aac.ObjectTypeLC)
+	if cfg.ObjectType != tables.ObjectTypeLC {
+		t.Errorf("ObjectType: got %d, want %d", cfg.ObjectType, tables.ObjectTypeLC)
 	}
 }
 
@@ -67,7 +68,7 @@ func TestReconstructSingleChannel_BasicLC(t *testing.T) {
 		ICS:         ics,
 		Element:     ele,
 		FrameLength: 1024,
-		ObjectType:  aac.ObjectTypeLC,
+		ObjectType:  tables.ObjectTypeLC,
 		SRIndex:     4,
 		PNSState:    NewPNSState(),
 	}
@@ -136,7 +137,7 @@ func TestReconstructSingleChannel_WithPulse(t *testing.T) {
 		ICS:         ics,
 		Element:     ele,
 		FrameLength: 1024,
-		ObjectType:  aac.ObjectTypeLC,
+		ObjectType:  tables.ObjectTypeLC,
 		SRIndex:     4,
 		PNSState:    NewPNSState(),
 	}
@@ -171,7 +172,7 @@ func TestReconstructSingleChannel_PulseInShortBlock_Error(t *testing.T) {
 		ICS:         ics,
 		Element:     &syntax.Element{},
 		FrameLength: 1024,
-		ObjectType:  aac.ObjectTypeLC,
+		ObjectType:  tables.ObjectTypeLC,
 	}
 
 	quantData := make([]int16, 1024)
@@ -211,7 +212,7 @@ func TestReconstructSingleChannel_WithNoiseBand(t *testing.T) {
 		ICS:         ics,
 		Element:     &syntax.Element{},
 		FrameLength: 1024,
-		ObjectType:  aac.ObjectTypeLC,
+		ObjectType:  tables.ObjectTypeLC,
 		SRIndex:     4,
 		PNSState:    NewPNSState(),
 	}
@@ -275,7 +276,7 @@ func TestReconstructSingleChannel_WithTNS(t *testing.T) {
 		ICS:         ics,
 		Element:     &syntax.Element{},
 		FrameLength: 1024,
-		ObjectType:  aac.ObjectTypeLC,
+		ObjectType:  tables.ObjectTypeLC,
 		SRIndex:     4,
 		PNSState:    NewPNSState(),
 	}
@@ -344,7 +345,7 @@ func TestReconstructSingleChannel_MainProfile_ICPrediction(t *testing.T) {
 		ICS:         ics,
 		Element:     &syntax.Element{},
 		FrameLength: 1024,
-		ObjectType:  aac.ObjectTypeMain, // MAIN profile
+		ObjectType:  tables.ObjectTypeMain, // MAIN profile
 		SRIndex:     4,
 		PNSState:    NewPNSState(),
 		PredState:   predState,
@@ -409,7 +410,7 @@ func TestReconstructSingleChannel_LTPProfile(t *testing.T) {
 		ICS:         ics,
 		Element:     &syntax.Element{},
 		FrameLength: 1024,
-		ObjectType:  aac.ObjectTypeLTP, // LTP profile
+		ObjectType:  tables.ObjectTypeLTP, // LTP profile
 		SRIndex:     4,
 		PNSState:    NewPNSState(),
 		LTPState:    ltpState,
@@ -454,7 +455,7 @@ func TestReconstructSingleChannel_ShortBlocks(t *testing.T) {
 		ICS:         ics,
 		Element:     &syntax.Element{},
 		FrameLength: 1024,
-		ObjectType:  aac.ObjectTypeLC,
+		ObjectType:  tables.ObjectTypeLC,
 		SRIndex:     4,
 		PNSState:    NewPNSState(),
 	}
@@ -540,7 +541,7 @@ func TestReconstructChannelPair_BasicStereo(t *testing.T) {
 		ICS2:        ics2,
 		Element:     ele,
 		FrameLength: 1024,
-		ObjectType:  aac.ObjectTypeLC,
+		ObjectType:  tables.ObjectTypeLC,
 		SRIndex:     4,
 		PNSState:    NewPNSState(),
 	}
@@ -611,7 +612,7 @@ func TestReconstructChannelPair_WithMSStereo(t *testing.T) {
 		ICS2:        ics2,
 		Element:     ele,
 		FrameLength: 1024,
-		ObjectType:  aac.ObjectTypeLC,
+		ObjectType:  tables.ObjectTypeLC,
 		SRIndex:     4,
 		PNSState:    NewPNSState(),
 	}
@@ -644,6 +645,108 @@ func TestReconstructChannelPair_WithMSStereo(t *testing.T) {
 	}
 }
 
+// newMSTestPair builds a common-window long-block pair with two 4-bin
+// bands. Band 0 has unit gain and band 1 a gain of 2 (scale factor 104).
+func newMSTestPair(msMaskPresent uint8) *ReconstructChannelPairConfig {
+	newICS := func() *syntax.ICStream {
+		ics := &syntax.ICStream{
+			NumWindowGroups: 1,
+			NumWindows:      1,
+			MaxSFB:          2,
+			NumSWB:          2,
+			WindowSequence:  syntax.OnlyLongSequence,
+			GlobalGain:      100,
+			SWBOffsetMax:    1024,
+		}
+		ics.WindowGroupLength[0] = 1
+		ics.SWBOffset[1] = 4
+		ics.SWBOffset[2] = 8
+		ics.SFBCB[0][0] = 1
+		ics.SFBCB[0][1] = 1
+		ics.ScaleFactors[0][0] = 100
+		ics.ScaleFactors[0][1] = 104
+		return ics
+	}
+	ics1 := newICS()
+	ics1.MSMaskPresent = msMaskPresent
+	return &ReconstructChannelPairConfig{
+		ICS1:        ics1,
+		ICS2:        newICS(),
+		Element:     &syntax.Element{CommonWindow: true},
+		FrameLength: 1024,
+		ObjectType:  tables.ObjectTypeLC,
+		SRIndex:     4,
+	}
+}
+
+func TestReconstructChannelPair_MSMatrixValues(t *testing.T) {
+	// Dequantized values: |q|^(4/3) * 2^((sf-100)/4)
+	dequant := func(q int16, gain float64) float64 {
+		v := math.Pow(math.Abs(float64(q)), 4.0/3.0) * gain
+		if q < 0 {
+			return -v
+		}
+		return v
+	}
+
+	tests := []struct {
+		name   string
+		msMask uint8
+		msUsed [2]uint8
+		wantMS [2]bool // M/S applied per band
+		quantM int16
+		quantS int16
+		gains  [2]float64
+	}{
+		{"all bands", 2, [2]uint8{0, 0}, [2]bool{true, true}, 10, 2, [2]float64{1, 2}},
+		{"all bands negative side", 2, [2]uint8{0, 0}, [2]bool{true, true}, 10, -3, [2]float64{1, 2}},
+		{"band 1 only", 1, [2]uint8{0, 1}, [2]bool{false, true}, 10, 2, [2]float64{1, 2}},
+		{"band 0 only", 1, [2]uint8{1, 0}, [2]bool{true, false}, 7, 5, [2]float64{1, 2}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := newMSTestPair(tt.msMask)
+			cfg.ICS1.MSUsed[0][0] = tt.msUsed[0]
+			cfg.ICS1.MSUsed[0][1] = tt.msUsed[1]
+
+			quantData1 := make([]int16, 1024)
+			quantData2 := make([]int16, 1024)
+			for i := 0; i < 8; i++ {
+				quantData1[i] = tt.quantM
+				quantData2[i] = tt.quantS
+			}
+			specData1 := make([]float64, 1024)
+			specData2 := make([]float64, 1024)
+
+			if err := ReconstructChannelPair(quantData1, quantData2, specData1, specData2, cfg); err != nil {
+				t.Fatalf("ReconstructChannelPair failed: %v", err)
+			}
+
+			const tolerance = 1e-9
+			for i := 0; i < 8; i++ {
+				band := i / 4
+				m := dequant(tt.quantM, tt.gains[band])
+				s := dequant(tt.quantS, tt.gains[band])
+				wantL, wantR := m, s
+				if tt.wantMS[band] {
+					wantL, wantR = m+s, m-s
+				}
+				if math.Abs(specData1[i]-wantL) > tolerance {
+					t.Errorf("bin %d (band %d): L = %v, want %v", i, band, specData1[i], wantL)
+				}
+				if math.Abs(specData2[i]-wantR) > tolerance {
+					t.Errorf("bin %d (band %d): R = %v, want %v", i, band, specData2[i], wantR)
+				}
+			}
+			for i := 8; i < 1024; i++ {
+				if specData1[i] != 0 || specData2[i] != 0 {
+					t.Fatalf("bin %d beyond coded bands: got L=%v R=%v, want 0", i, specData1[i], specData2[i])
+				}
+			}
+		})
+	}
+}
+
 func TestReconstructChannelPair_WithIntensityStereo(t *testing.T) {
 	ics1 := &syntax.ICStream{
 		NumWindowGroups: 1,
@@ -690,7 +793,7 @@ func TestReconstructChannelPair_WithIntensityStereo(t *testing.T) {
 		ICS2:        ics2,
 		Element:     ele,
 		FrameLength: 1024,
-		ObjectType:  aac.ObjectTypeLC,
+		ObjectType:  tables.ObjectTypeLC,
 		SRIndex:     4,
 		PNSState:    NewPNSState(),
 	}
@@ -716,6 +819,56 @@ func TestReconstructChannelPair_WithIntensityStereo(t *testing.T) {
 	}
 }
 
+func TestReconstructChannelPair_IntensityStereoOutOfPhase(t *testing.T) {
+	// Band 1 of the right channel is an intensity band with an IS position
+	// of 6. Its sign comes from the codebook XOR ms_used, and the M/S
+	// matrix must leave it alone.
+	tests := []struct {
+		name     string
+		codebook huffman.Codebook
+		msUsed   uint8
+		sign     float64
+	}{
+		{"HCB, ms_used=0", huffman.IntensityHCB, 0, 1},
+		{"HCB, ms_used=1", huffman.IntensityHCB, 1, -1},
+		{"HCB2, ms_used=0", huffman.IntensityHCB2, 0, -1},
+		{"HCB2, ms_used=1", huffman.IntensityHCB2, 1, 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := newMSTestPair(1)
+			cfg.ICS1.MSUsed[0][1] = tt.msUsed
+			cfg.ICS2.MSUsed[0][1] = tt.msUsed
+			cfg.ICS2.SFBCB[0][1] = uint8(tt.codebook)
+			cfg.ICS2.ScaleFactors[0][1] = 6
+
+			quantData1 := make([]int16, 1024)
+			quantData2 := make([]int16, 1024)
+			for i := 4; i < 8; i++ {
+				quantData1[i] = int16(i - 6)
+			}
+			specData1 := make([]float64, 1024)
+			specData2 := make([]float64, 1024)
+
+			if err := ReconstructChannelPair(quantData1, quantData2, specData1, specData2, cfg); err != nil {
+				t.Fatalf("ReconstructChannelPair failed: %v", err)
+			}
+
+			scale := tt.sign * math.Pow(0.5, 6.0/4.0)
+			const tolerance = 1e-9
+			for i := 4; i < 8; i++ {
+				want := specData1[i] * scale
+				if math.Abs(specData2[i]-want) > tolerance {
+					t.Errorf("bin %d: R = %v, want %v (L = %v)", i, specData2[i], want, specData1[i])
+				}
+			}
+			if specData1[4] == 0 {
+				t.Fatal("left channel of the intensity band is silent")
+			}
+		})
+	}
+}
+
 func TestReconstructChannelPair_ShortBlocks(t *testing.T) {
 	ics1 := &syntax.ICStream{
 		NumWindowGroups: 2,
@@ -770,7 +923,7 @@ func TestReconstructChannelPair_ShortBlocks(t *testing.T) {
 		ICS2:        ics2,
 		Element:     ele,
 		FrameLength: 1024,
-		ObjectType:  aac.ObjectTypeLC,
+		ObjectType:  tables.ObjectTypeLC,
 		SRIndex:     4,
 		PNSState:    NewPNSState(),
 	}
@@ -864,7 +1017,7 @@ func TestReconstructChannelPair_WithTNS(t *testing.T) {
 		ICS2:        ics2,
 		Element:     ele,
 		FrameLength: 1024,
-		ObjectType:  aac.ObjectTypeLC,
+		ObjectType:  tables.ObjectTypeLC,
 		SRIndex:     4,
 		PNSState:    NewPNSState(),
 	}
@@ -955,7 +1108,7 @@ func TestReconstructChannelPair_MainProfile_ICPrediction(t *testing.T) {
 		ICS2:        ics2,
 		Element:     ele,
 		FrameLength: 1024,
-		ObjectType:  aac.ObjectTypeMain,
+		ObjectType:  tables.ObjectTypeMain,
 		SRIndex:     4,
 		PNSState:    NewPNSState(),
 		PredState1:  predState1,
@@ -1040,7 +1193,7 @@ func TestReconstructChannelPair_CorrelatedPNS(t *testing.T) {
 		ICS2:        ics2,
 		Element:     ele,
 		FrameLength: 1024,
-		ObjectType:  aac.ObjectTypeLC,
+		ObjectType:  tables.ObjectTypeLC,
 		SRIndex:     4,
 		PNSState:    NewPNSState(),
 	}
@@ -1134,7 +1287,7 @@ func TestReconstructChannelPair_LTPProfile_CommonWindow(t *testing.T) {
 		ICS2:        ics2,
 		Element:     ele,
 		FrameLength: 1024,
-		ObjectType:  aac.ObjectTypeLTP,
+		ObjectType:  tables.ObjectTypeLTP,
 		SRIndex:     4,
 		PNSState:    NewPNSState(),
 		LTPState1:   ltpState1,
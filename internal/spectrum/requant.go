@@ -2,6 +2,7 @@ package spectrum
 
 import (
 	"errors"
+	"math"
 
 	"github.com/llehouerou/go-aac/internal/tables"
 )
@@ -16,7 +17,7 @@ var ErrLengthMismatch = errors.New("spectrum: input and output length mismatch")
 // Uses the precomputed IQTable for efficiency.
 //
 // Ported from: iquant() usage in ~/dev/faad2/libfaad/specrec.c:636-639
-func InverseQuantize(quantData []int16, specData []float64) error {
+func InverseQuantize[T Float](quantData []int16, specData []T) error {
 	if len(quantData) != len(specData) {
 		return ErrLengthMismatch
 	}
@@ -26,8 +27,23 @@ func InverseQuantize(quantData []int16, specData []float64) error {
 		if err != nil {
 			return err
 		}
-		specData[i] = val
+		specData[i] = T(val)
 	}
 
 	return nil
 }
+
+// InverseQuantizeValue returns sign(x) * |x|^(4/3) for one quantized
+// value, for analysis tools. Values within the IQTable range give the
+// table entry, exactly as InverseQuantize computes them. Larger
+// magnitudes, which no escape codeword can produce and for which
+// InverseQuantize fails, are computed directly instead.
+//
+// Ported from: iquant() in ~/dev/faad2/libfaad/specrec.c:430-497
+func InverseQuantizeValue(x int16) float64 {
+	if val, err := tables.IQuant(x); err == nil {
+		return val
+	}
+	mag := math.Abs(float64(x))
+	return math.Copysign(mag*math.Cbrt(mag), float64(x))
+}
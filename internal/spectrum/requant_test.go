@@ -128,6 +128,26 @@ func TestInverseQuantize_Overflow(t *testing.T) {
 	}
 }
 
+func TestInverseQuantize_SaturatedEscape(t *testing.T) {
+	// Escapes beyond N=12 saturate to ±32767; the most negative int16 must
+	// also be rejected rather than indexing the table with a negative value.
+	specData := make([]float64, 1)
+	for _, q := range []int16{32767, -32767, -32768, 16383} {
+		if err := InverseQuantize([]int16{q}, specData); err != tables.ErrIQTableOverflow {
+			t.Errorf("q=%d: expected ErrIQTableOverflow, got %v", q, err)
+		}
+	}
+
+	// The maximal legal escape magnitude follows |q|^(4/3)
+	if err := InverseQuantize([]int16{-8191}, specData); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := -math.Pow(8191, 4.0/3.0)
+	if math.Abs(specData[0]-want) > 1e-9*math.Abs(want) {
+		t.Errorf("got %v, want %v", specData[0], want)
+	}
+}
+
 func TestInverseQuantize_Formula(t *testing.T) {
 	// Verify the formula: spec = sign(q) * |q|^(4/3)
 	testCases := []int16{1, 2, 8, 27, 64, 125}
@@ -170,3 +190,159 @@ func TestInverseQuantize_LengthMismatch(t *testing.T) {
 		t.Error("expected error for length mismatch, got nil")
 	}
 }
+
+// Inverse quantization variants compared by the benchmarks below. The
+// table lookup is what InverseQuantize uses; the hybrid keeps only the
+// values Huffman codebooks code without an escape (|q| < 16) in a table.
+const iqHybridTableSize = 16
+
+var iqHybridTable = func() (t [iqHybridTableSize]float64) {
+	for i := range t {
+		t[i] = math.Pow(float64(i), 4.0/3.0)
+	}
+	return t
+}()
+
+func inverseQuantizePow(quantData []int16, specData []float64) {
+	for i, q := range quantData {
+		v := math.Pow(math.Abs(float64(q)), 4.0/3.0)
+		if q < 0 {
+			v = -v
+		}
+		specData[i] = v
+	}
+}
+
+func inverseQuantizeHybrid(quantData []int16, specData []float64) {
+	for i, q := range quantData {
+		a := int32(q)
+		if a < 0 {
+			a = -a
+		}
+		var v float64
+		if a < iqHybridTableSize {
+			v = iqHybridTable[a]
+		} else {
+			v = math.Pow(float64(a), 4.0/3.0)
+		}
+		if q < 0 {
+			v = -v
+		}
+		specData[i] = v
+	}
+}
+
+// iqBenchmarkFrame returns a frame of quantized coefficients. With
+// large=false magnitudes follow a roughly Laplacian distribution as in
+// real streams (mostly zeros and small values, rare escapes); with
+// large=true every coefficient is an escape value up to 8191, touching
+// the whole table.
+func iqBenchmarkFrame(large bool) []int16 {
+	q := make([]int16, 1024)
+	seed := uint32(12345)
+	for i := range q {
+		seed = seed*1664525 + 1013904223
+		u := (float64(seed>>8) + 0.5) / (1 << 24)
+		var mag int
+		if large {
+			mag = 16 + int(u*(tables.IQTableSize-16))
+		} else {
+			mag = int(-math.Log(u) * 1.5)
+			if mag >= tables.IQTableSize {
+				mag = tables.IQTableSize - 1
+			}
+		}
+		if seed&1 == 1 {
+			mag = -mag
+		}
+		q[i] = int16(mag)
+	}
+	return q
+}
+
+func TestInverseQuantizeVariants_MatchTable(t *testing.T) {
+	for _, large := range []bool{false, true} {
+		quant := iqBenchmarkFrame(large)
+		want := make([]float64, len(quant))
+		if err := InverseQuantize(quant, want); err != nil {
+			t.Fatalf("InverseQuantize: %v", err)
+		}
+		pow := make([]float64, len(quant))
+		hybrid := make([]float64, len(quant))
+		inverseQuantizePow(quant, pow)
+		inverseQuantizeHybrid(quant, hybrid)
+		for i := range want {
+			tol := 1e-12 * math.Max(1, math.Abs(want[i]))
+			if math.Abs(pow[i]-want[i]) > tol || math.Abs(hybrid[i]-want[i]) > tol {
+				t.Fatalf("large=%v q=%d: table %v, pow %v, hybrid %v", large, quant[i], want[i], pow[i], hybrid[i])
+			}
+		}
+	}
+}
+
+func benchmarkInverseQuantize(b *testing.B, large bool, iq func(q []int16, spec []float64)) {
+	quant := iqBenchmarkFrame(large)
+	spec := make([]float64, len(quant))
+	b.SetBytes(int64(len(quant)) * 2)
+	b.ResetTimer()
+	for n := 0; n < b.N; n++ {
+		iq(quant, spec)
+	}
+}
+
+func inverseQuantizeTable(quantData []int16, specData []float64) {
+	_ = InverseQuantize(quantData, specData)
+}
+
+func BenchmarkInverseQuantize_Table_Typical(b *testing.B) {
+	benchmarkInverseQuantize(b, false, inverseQuantizeTable)
+}
+
+func BenchmarkInverseQuantize_Pow_Typical(b *testing.B) {
+	benchmarkInverseQuantize(b, false, inverseQuantizePow)
+}
+
+func BenchmarkInverseQuantize_Hybrid_Typical(b *testing.B) {
+	benchmarkInverseQuantize(b, false, inverseQuantizeHybrid)
+}
+
+func BenchmarkInverseQuantize_Table_Large(b *testing.B) {
+	benchmarkInverseQuantize(b, true, inverseQuantizeTable)
+}
+
+func BenchmarkInverseQuantize_Pow_Large(b *testing.B) {
+	benchmarkInverseQuantize(b, true, inverseQuantizePow)
+}
+
+func BenchmarkInverseQuantize_Hybrid_Large(b *testing.B) {
+	benchmarkInverseQuantize(b, true, inverseQuantizeHybrid)
+}
+
+func TestInverseQuantizeValue(t *testing.T) {
+	check := func(x int16) {
+		t.Helper()
+		mag := math.Pow(math.Abs(float64(x)), 4.0/3.0)
+		want := math.Copysign(mag, float64(x))
+		got := InverseQuantizeValue(x)
+		if math.Abs(got-want) > 1e-12*math.Max(mag, 1) {
+			t.Fatalf("InverseQuantizeValue(%d) = %v, want %v", x, got, want)
+		}
+	}
+
+	// The table range, matching InverseQuantize
+	spec := make([]float64, 1)
+	for x := -tables.IQTableSize + 1; x < tables.IQTableSize; x++ {
+		check(int16(x))
+		if err := InverseQuantize([]int16{int16(x)}, spec); err != nil || spec[0] != InverseQuantizeValue(int16(x)) {
+			t.Fatalf("InverseQuantize(%d) = %v, %v, differs from InverseQuantizeValue", x, spec[0], err)
+		}
+	}
+
+	// Beyond the table
+	for _, x := range []int16{8192, -8192, 8193, 12345, -20000, math.MaxInt16, math.MinInt16} {
+		check(x)
+	}
+	if InverseQuantizeValue(0) != 0 {
+		t.Error("InverseQuantizeValue(0) != 0")
+	}
+}
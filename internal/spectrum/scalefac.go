@@ -22,7 +22,7 @@ type ApplyScaleFactorsConfig struct {
 // by dedicated tools (is_decode, pns_decode) later in the pipeline.
 //
 // Ported from: quant_to_spec() scale factor part in ~/dev/faad2/libfaad/specrec.c:549-693
-func ApplyScaleFactors(specData []float64, cfg *ApplyScaleFactorsConfig) {
+func ApplyScaleFactors[T Float](specData []T, cfg *ApplyScaleFactorsConfig) {
 	ics := cfg.ICS
 
 	// Process each window group
@@ -64,7 +64,7 @@ func ApplyScaleFactors(specData []float64, cfg *ApplyScaleFactorsConfig) {
 					expIdx = len(tables.Pow2SFTable) - 1
 				}
 
-				scf := tables.Pow2SFTable[expIdx] * tables.Pow2FracTable[frac]
+				scf := T(tables.Pow2SFTable[expIdx] * tables.Pow2FracTable[frac])
 
 				// Apply to all windows in this group
 				for win := uint8(0); win < ics.WindowGroupLength[g]; win++ {
@@ -0,0 +1,80 @@
+// internal/spectrum/strict.go
+package spectrum
+
+import (
+	"errors"
+
+	"github.com/llehouerou/go-aac/internal/huffman"
+	"github.com/llehouerou/go-aac/internal/syntax"
+)
+
+// Errors of CheckStrict, which the aac decoder reports as its error codes
+// of the same names.
+var (
+	ErrTNSOrderOutOfRange    = errors.New("spectrum: TNS filter order above the maximum")
+	ErrScalefactorOutOfRange = errors.New("spectrum: scalefactor beyond the decoder's clamp")
+	ErrGlobalGainMismatch    = errors.New("spectrum: global_gain differs from the first scalefactor")
+)
+
+// strictScaleFactorLimit bounds PNS energies and intensity positions,
+// which genRandVector and the intensity stereo decoder clamp to it.
+const strictScaleFactorLimit = 120
+
+// CheckStrict returns the error that aac.Config.Strict raises for a
+// parsed element, or nil if the element holds no value that decoding
+// would clamp or tolerate.
+func (e *ElementDecoder) CheckStrict(element any) error {
+	var ele *syntax.Element
+	switch v := element.(type) {
+	case *syntax.Element:
+		ele = v
+	case *syntax.CCEResult:
+		ele = &v.Element
+	default:
+		return ErrForeignElement
+	}
+	if err := checkStrictICS(&ele.ICS1); err != nil {
+		return err
+	}
+	if ele.PairedChannel >= 0 {
+		return checkStrictICS(&ele.ICS2)
+	}
+	return nil
+}
+
+// checkStrictICS checks the TNS filter orders and scalefactors of one
+// individual channel stream.
+func checkStrictICS(ics *syntax.ICStream) error {
+	if ics.TNSDataPresent {
+		for w := range ics.NumWindows {
+			for f := range ics.TNS.NFilt[w] {
+				if ics.TNS.Order[w][f] > TNSMaxOrder {
+					return ErrTNSOrderOutOfRange
+				}
+			}
+		}
+	}
+
+	// global_gain is the value of the first spectral scalefactor, which
+	// the others are coded relative to
+	first := true
+	for g := range ics.NumWindowGroups {
+		for sfb := range ics.MaxSFB {
+			sf := ics.ScaleFactors[g][sfb]
+			cb := huffman.Codebook(ics.SFBCB[g][sfb])
+			switch {
+			case cb == huffman.ZeroHCB:
+			case IsNoise(cb) || IsIntensity(cb) != 0:
+				if sf < -strictScaleFactorLimit || sf > strictScaleFactorLimit {
+					return ErrScalefactorOutOfRange
+				}
+			case first:
+				if sf != int16(ics.GlobalGain) {
+					return ErrGlobalGainMismatch
+				}
+				first = false
+			}
+		}
+	}
+	return nil
+}
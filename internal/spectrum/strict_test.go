@@ -0,0 +1,45 @@
+// internal/spectrum/strict_test.go
+package spectrum
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/llehouerou/go-aac/internal/huffman"
+	"github.com/llehouerou/go-aac/internal/syntax"
+)
+
+func TestCheckStrict_ScaleFactors(t *testing.T) {
+	tests := []struct {
+		name string
+		cb   [2]huffman.Codebook
+		sf   [2]int16
+		want error
+	}{
+		{"first scalefactor is global_gain", [2]huffman.Codebook{1, 1}, [2]int16{100, 90}, nil},
+		{"zero band before the first", [2]huffman.Codebook{huffman.ZeroHCB, 1}, [2]int16{0, 100}, nil},
+		{"global_gain mismatch", [2]huffman.Codebook{1, 1}, [2]int16{101, 100}, ErrGlobalGainMismatch},
+		{"noise energy in range", [2]huffman.Codebook{huffman.NoiseHCB, 1}, [2]int16{-120, 100}, nil},
+		{"noise energy beyond clamp", [2]huffman.Codebook{huffman.NoiseHCB, 1}, [2]int16{121, 100}, ErrScalefactorOutOfRange},
+		{"intensity position beyond clamp", [2]huffman.Codebook{1, huffman.IntensityHCB}, [2]int16{100, -121}, ErrScalefactorOutOfRange},
+	}
+	e := &ElementDecoder{}
+	for _, tt := range tests {
+		ele := &syntax.Element{PairedChannel: -1}
+		ics := &ele.ICS1
+		ics.GlobalGain = 100
+		ics.NumWindowGroups = 1
+		ics.MaxSFB = 2
+		for sfb := range 2 {
+			ics.SFBCB[0][sfb] = uint8(tt.cb[sfb])
+			ics.ScaleFactors[0][sfb] = tt.sf[sfb]
+		}
+		if err := e.CheckStrict(ele); !errors.Is(err, tt.want) {
+			t.Errorf("%s: got %v, want %v", tt.name, err, tt.want)
+		}
+	}
+
+	if err := e.CheckStrict(struct{}{}); !errors.Is(err, ErrForeignElement) {
+		t.Errorf("foreign element: got %v", err)
+	}
+}
@@ -2,7 +2,6 @@
 package spectrum
 
 import (
-	"github.com/llehouerou/go-aac"
 	"github.com/llehouerou/go-aac/internal/syntax"
 	"github.com/llehouerou/go-aac/internal/tables"
 )
@@ -27,7 +26,7 @@ import (
 // Uses a double ringbuffer for efficient state management.
 //
 // Ported from: tns_ar_filter() in ~/dev/faad2/libfaad/tns.c:244-293
-func tnsARFilter(spectrum []float64, size int16, inc int8, lpc []float64, order uint8) {
+func tnsARFilter[T Float](spectrum []T, size int16, inc int8, lpc []float64, order uint8) {
 	tnsARFilterWithOffset(spectrum, 0, size, inc, lpc, order)
 }
 
@@ -46,22 +45,22 @@ func tnsARFilter(spectrum []float64, size int16, inc int8, lpc []float64, order
 //   - order: filter order
 //
 // Ported from: tns_ar_filter() in ~/dev/faad2/libfaad/tns.c:244-293
-func tnsARFilterWithOffset(spectrum []float64, startOffset int, size int16, inc int8, lpc []float64, order uint8) {
+func tnsARFilterWithOffset[T Float](spectrum []T, startOffset int, size int16, inc int8, lpc []float64, order uint8) {
 	if size <= 0 || order == 0 {
 		return
 	}
 
 	// State is stored as a double ringbuffer for efficient wraparound
-	state := make([]float64, 2*TNSMaxOrder)
+	state := make([]T, 2*TNSMaxOrder)
 	stateIndex := int8(0)
 
 	// Process each sample
 	idx := startOffset
 	for i := int16(0); i < size; i++ {
 		// Compute filter output: y = x - sum(lpc[j+1] * state[j])
-		y := 0.0
+		var y T
 		for j := uint8(0); j < order; j++ {
-			y += state[int(stateIndex)+int(j)] * lpc[j+1]
+			y += state[int(stateIndex)+int(j)] * T(lpc[j+1])
 		}
 		y = spectrum[idx] - y
 
@@ -97,21 +96,21 @@ func tnsARFilterWithOffset(spectrum []float64, startOffset int, size int16, inc
 //   - order: filter order
 //
 // Ported from: tns_ma_filter() in ~/dev/faad2/libfaad/tns.c:295-339
-func tnsMAFilter(spectrum []float64, size int16, inc int8, lpc []float64, order uint8) {
+func tnsMAFilter[T Float](spectrum []T, size int16, inc int8, lpc []float64, order uint8) {
 	tnsMAFilterWithOffset(spectrum, 0, size, inc, lpc, order)
 }
 
 // tnsMAFilterWithOffset applies an all-zero (MA) FIR filter starting at a specific offset.
 //
 // Ported from: tns_ma_filter() in ~/dev/faad2/libfaad/tns.c:295-339
-func tnsMAFilterWithOffset(spectrum []float64, startOffset int, size int16, inc int8, lpc []float64, order uint8) {
+func tnsMAFilterWithOffset[T Float](spectrum []T, startOffset int, size int16, inc int8, lpc []float64, order uint8) {
 	if size <= 0 || order == 0 {
 		return
 	}
 
 	// State is stored as a double ringbuffer for efficient wraparound
 	// State stores INPUT values (x), not output values
-	state := make([]float64, 2*TNSMaxOrder)
+	state := make([]T, 2*TNSMaxOrder)
 	stateIndex := int8(0)
 
 	// Process each sample
@@ -121,9 +120,9 @@ func tnsMAFilterWithOffset(spectrum []float64, startOffset int, size int16, inc
 		x := spectrum[idx]
 
 		// Compute filter output: y = x + sum(lpc[j+1] * state[j])
-		y := 0.0
+		var y T
 		for j := uint8(0); j < order; j++ {
-			y += state[int(stateIndex)+int(j)] * lpc[j+1]
+			y += state[int(stateIndex)+int(j)] * T(lpc[j+1])
 		}
 		y = x + y
 
@@ -190,7 +189,7 @@ type TNSDecodeConfig struct {
 	SRIndex uint8
 
 	// ObjectType is the AAC object type
-	ObjectType aac.ObjectType
+	ObjectType uint8
 
 	// FrameLength is the frame length (typically 1024 or 960)
 	FrameLength uint16
@@ -201,7 +200,7 @@ type TNSDecodeConfig struct {
 // inverse operation of TNS decoding. Used by LTP to match TNS processing.
 //
 // Ported from: tns_encode_frame() in ~/dev/faad2/libfaad/tns.c:139-191
-func TNSEncodeFrame(spec []float64, cfg *TNSDecodeConfig) {
+func TNSEncodeFrame[T Float](spec []T, cfg *TNSDecodeConfig) {
 	ics := cfg.ICS
 
 	if !ics.TNSDataPresent {
@@ -239,7 +238,7 @@ func TNSEncodeFrame(spec []float64, cfg *TNSDecodeConfig) {
 			tnsDecodeCoef(tnsOrder, tns.CoefRes[w], tns.CoefCompress[w][f], tns.Coef[w][f][:], lpc)
 
 			// Calculate filter region bounds
-			maxTNS := tables.MaxTNSSFB(cfg.SRIndex, cfg.ObjectType, isShort)
+			maxTNS := tables.MaxTNSSFB(cfg.SRIndex, uint8(cfg.ObjectType), isShort)
 
 			// Start position
 			start := bottom
@@ -297,7 +296,7 @@ func TNSEncodeFrame(spec []float64, cfg *TNSDecodeConfig) {
 // the temporal envelope of quantization noise.
 //
 // Ported from: tns_decode_frame() in ~/dev/faad2/libfaad/tns.c:84-136
-func TNSDecodeFrame(spec []float64, cfg *TNSDecodeConfig) {
+func TNSDecodeFrame[T Float](spec []T, cfg *TNSDecodeConfig) {
 	ics := cfg.ICS
 
 	if !ics.TNSDataPresent {
@@ -335,7 +334,7 @@ func TNSDecodeFrame(spec []float64, cfg *TNSDecodeConfig) {
 			tnsDecodeCoef(tnsOrder, tns.CoefRes[w], tns.CoefCompress[w][f], tns.Coef[w][f][:], lpc)
 
 			// Calculate filter region bounds
-			maxTNS := tables.MaxTNSSFB(cfg.SRIndex, cfg.ObjectType, isShort)
+			maxTNS := tables.MaxTNSSFB(cfg.SRIndex, uint8(cfg.ObjectType), isShort)
 
 			// Start position
 			start := bottom
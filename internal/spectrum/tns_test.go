@@ -3,10 +3,12 @@ package spectrum
 
 import (
 	"math"
+	"math/rand"
+	"slices"
 	"testing"
 
-	"github.com/llehouerou/go-aac"
 	"github.com/llehouerou/go-aac/internal/syntax"
+	"github.com/llehouerou/go-aac/internal/tables"
 )
 
 func TestTNSDecodeCoef_Order1(t *testing.T) {
@@ -399,7 +401,7 @@ func TestTNSDecodeFrame_NoTNSData(t *testing.T) {
 	cfg := &TNSDecodeConfig{
 		ICS:         ics,
 		SRIndex:     4, // 44100 Hz
-		ObjectType:  aac.ObjectTypeLC,
+		ObjectType:  tables.ObjectTypeLC,
 		FrameLength: 1024,
 	}
 
@@ -450,7 +452,7 @@ func TestTNSDecodeFrame_SingleFilter(t *testing.T) {
 	cfg := &TNSDecodeConfig{
 		ICS:         ics,
 		SRIndex:     4, // 44100 Hz
-		ObjectType:  aac.ObjectTypeLC,
+		ObjectType:  tables.ObjectTypeLC,
 		FrameLength: 1024,
 	}
 
@@ -503,7 +505,7 @@ func TestTNSDecodeFrame_ShortBlock(t *testing.T) {
 	cfg := &TNSDecodeConfig{
 		ICS:         ics,
 		SRIndex:     4,
-		ObjectType:  aac.ObjectTypeLC,
+		ObjectType:  tables.ObjectTypeLC,
 		FrameLength: 1024,
 	}
 
@@ -564,7 +566,7 @@ func TestTNSDecodeFrame_MultipleFilters(t *testing.T) {
 	cfg := &TNSDecodeConfig{
 		ICS:         ics,
 		SRIndex:     4,
-		ObjectType:  aac.ObjectTypeLC,
+		ObjectType:  tables.ObjectTypeLC,
 		FrameLength: 1024,
 	}
 
@@ -618,7 +620,7 @@ func TestTNSDecodeFrame_MaxOrder(t *testing.T) {
 	cfg := &TNSDecodeConfig{
 		ICS:         ics,
 		SRIndex:     4,
-		ObjectType:  aac.ObjectTypeLC,
+		ObjectType:  tables.ObjectTypeLC,
 		FrameLength: 1024,
 	}
 
@@ -667,7 +669,7 @@ func TestTNSDecodeFrame_OrderExceedsMax(t *testing.T) {
 	cfg := &TNSDecodeConfig{
 		ICS:         ics,
 		SRIndex:     4,
-		ObjectType:  aac.ObjectTypeLC,
+		ObjectType:  tables.ObjectTypeLC,
 		FrameLength: 1024,
 	}
 
@@ -716,7 +718,7 @@ func TestTNSDecodeFrame_ZeroRegion(t *testing.T) {
 	cfg := &TNSDecodeConfig{
 		ICS:         ics,
 		SRIndex:     4,
-		ObjectType:  aac.ObjectTypeLC,
+		ObjectType:  tables.ObjectTypeLC,
 		FrameLength: 1024,
 	}
 
@@ -770,7 +772,7 @@ func TestTNSDecodeFrame_BackwardDirection(t *testing.T) {
 	cfg := &TNSDecodeConfig{
 		ICS:         ics,
 		SRIndex:     4,
-		ObjectType:  aac.ObjectTypeLC,
+		ObjectType:  tables.ObjectTypeLC,
 		FrameLength: 1024,
 	}
 
@@ -835,7 +837,7 @@ func TestTNSDecodeFrame_NonZeroCoefficients(t *testing.T) {
 	cfg := &TNSDecodeConfig{
 		ICS:         ics,
 		SRIndex:     4,
-		ObjectType:  aac.ObjectTypeLC,
+		ObjectType:  tables.ObjectTypeLC,
 		FrameLength: 1024,
 	}
 
@@ -954,7 +956,7 @@ func TestTNSEncodeFrame_NoTNSData(t *testing.T) {
 	cfg := &TNSDecodeConfig{
 		ICS:         ics,
 		SRIndex:     4, // 44100 Hz
-		ObjectType:  aac.ObjectTypeLC,
+		ObjectType:  tables.ObjectTypeLC,
 		FrameLength: 1024,
 	}
 
@@ -967,3 +969,89 @@ func TestTNSEncodeFrame_NoTNSData(t *testing.T) {
 		}
 	}
 }
+
+// tnsRoundTrip encodes then decodes a random spectrum with random TNS
+// filters and returns the largest reconstruction error relative to the
+// largest coefficient of the filtered spectrum, and whether encoding
+// changed the spectrum.
+func tnsRoundTrip[T Float](rng *rand.Rand, short bool) (relErr float64, filtered bool) {
+	seq, numWindows, maxOrder := syntax.OnlyLongSequence, uint8(1), uint8(12)
+	if short {
+		seq, numWindows, maxOrder = syntax.EightShortSequence, 8, 7
+	}
+	offsets := tables.SWBOffsets(1024, 4, uint8(seq))
+	numSWB := uint8(len(offsets) - 1)
+	ics := &syntax.ICStream{
+		TNSDataPresent: true,
+		NumWindows:     numWindows,
+		WindowSequence: seq,
+		NumSWB:         numSWB,
+		MaxSFB:         numSWB,
+		SWBOffsetMax:   offsets[numSWB],
+	}
+	copy(ics.SWBOffset[:], offsets)
+
+	tns := &ics.TNS
+	for w := range numWindows {
+		tns.NFilt[w] = 1
+		if !short {
+			tns.NFilt[w] = uint8(1 + rng.Intn(3))
+		}
+		tns.CoefRes[w] = uint8(rng.Intn(2))
+		// The filters tile the bands from the top down, the last one
+		// reaching band 0 below the TNS limit
+		remaining := int(numSWB)
+		for f := range tns.NFilt[w] {
+			tns.Length[w][f] = uint8(remaining)
+			if f < tns.NFilt[w]-1 {
+				tns.Length[w][f] = uint8(1 + rng.Intn(remaining/int(tns.NFilt[w])))
+			}
+			remaining -= int(tns.Length[w][f])
+			tns.Order[w][f] = uint8(1 + rng.Intn(int(maxOrder)))
+			tns.Direction[w][f] = uint8(rng.Intn(2))
+			tns.CoefCompress[w][f] = uint8(rng.Intn(2))
+			coefBits := 3 + tns.CoefRes[w] - tns.CoefCompress[w][f]
+			for i := range tns.Order[w][f] {
+				tns.Coef[w][f][i] = uint8(rng.Intn(1 << coefBits))
+			}
+			// Index 0 is a zero coefficient
+			tns.Coef[w][f][0] = uint8(1 + rng.Intn(1<<coefBits-1))
+		}
+	}
+
+	cfg := &TNSDecodeConfig{ICS: ics, SRIndex: 4, ObjectType: tables.ObjectTypeLC, FrameLength: 1024}
+	spec := make([]T, 1024)
+	for i := range spec {
+		spec[i] = T(rng.NormFloat64() * 1000)
+	}
+	original := slices.Clone(spec)
+
+	TNSEncodeFrame(spec, cfg)
+	filtered = !slices.Equal(spec, original)
+	var peak float64
+	for _, v := range spec {
+		peak = max(peak, math.Abs(float64(v)))
+	}
+	TNSDecodeFrame(spec, cfg)
+
+	var maxErr float64
+	for i := range spec {
+		maxErr = max(maxErr, math.Abs(float64(spec[i]-original[i])))
+	}
+	return maxErr / peak, filtered
+}
+
+func TestTNSEncodeFrame_RoundTrip(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	for _, short := range []bool{false, true} {
+		for trial := range 50 {
+			if relErr, filtered := tnsRoundTrip[float64](rng, short); !filtered || relErr > 1e-9 {
+				t.Errorf("short %v trial %d float64: filtered %v, relative error %g", short, trial, filtered, relErr)
+			}
+			// High-order AR filters amplify the float32 rounding
+			if relErr, filtered := tnsRoundTrip[float32](rng, short); !filtered || relErr > 1e-3 {
+				t.Errorf("short %v trial %d float32: filtered %v, relative error %g", short, trial, filtered, relErr)
+			}
+		}
+	}
+}
@@ -4,11 +4,35 @@ package syntax
 import (
 	"errors"
 
-	"github.com/llehouerou/go-aac"
 	"github.com/llehouerou/go-aac/internal/bits"
 	"github.com/llehouerou/go-aac/internal/tables"
 )
 
+// AudioSpecificConfig contains the MP4 AudioSpecificConfig data.
+// Source: ~/dev/faad2/include/neaacdec.h:140-161
+type AudioSpecificConfig struct {
+	// Audio Specific Info
+	ObjectTypeIndex        uint8
+	SamplingFrequencyIndex uint8
+	SamplingFrequency      uint32
+	ChannelsConfiguration  uint8
+
+	// GA Specific Info
+	FrameLengthFlag                  bool
+	DependsOnCoreCoder               bool
+	CoreCoderDelay                   uint16
+	ExtensionFlag                    bool
+	AACSectionDataResilienceFlag     bool
+	AACScalefactorDataResilienceFlag bool
+	AACSpectralDataResilienceFlag    bool
+	EPConfig                         uint8
+
+	// SBR extension
+	SBRPresentFlag  int8
+	ForceUpSampling bool
+	DownSampledSBR  bool
+}
+
 // ASC parsing errors.
 var (
 	// ErrASCNil is returned when nil config is passed.
@@ -34,7 +58,7 @@ var (
 )
 
 // SRIndexExplicit indicates an explicit 24-bit sample rate follows.
-const SRIndexExplicit = 0x0f
+const SRIndexExplicit = tables.ExplicitSRIndex
 
 // objectTypesTable defines which audio object types can be decoded.
 // Ported from: ~/dev/faad2/libfaad/mp4.c:40-117 (ObjectTypesTable)
@@ -94,7 +118,7 @@ func isObjectTypeSupported(objType uint8) bool {
 // Returns the parsed PCE if channelsConfiguration is 0, otherwise nil.
 //
 // Ported from: ~/dev/faad2/libfaad/syntax.c:109-165
-func parseGASpecificConfig(r *bits.Reader, asc *aac.AudioSpecificConfig) (*ProgramConfig, error) {
+func parseGASpecificConfig(r *bits.Reader, asc *AudioSpecificConfig) (*ProgramConfig, error) {
 	// 1 bit: frameLengthFlag (0 = 1024, 1 = 960)
 	// Note: FAAD2 conditionally rejects frameLengthFlag=1 unless ALLOW_SMALL_FRAMELENGTH
 	// is defined. This implementation allows both frame lengths for flexibility.
@@ -139,7 +163,7 @@ func parseGASpecificConfig(r *bits.Reader, asc *aac.AudioSpecificConfig) (*Progr
 // Returns the parsed config, optional PCE, and any error.
 //
 // Ported from: ~/dev/faad2/libfaad/mp4.c:299-313 (AudioSpecificConfig2)
-func ParseASC(data []byte) (*aac.AudioSpecificConfig, *ProgramConfig, error) {
+func ParseASC(data []byte) (*AudioSpecificConfig, *ProgramConfig, error) {
 	r := bits.NewReader(data)
 	if r.Error() {
 		return nil, nil, ErrASCBitstreamError
@@ -151,7 +175,7 @@ func ParseASC(data []byte) (*aac.AudioSpecificConfig, *ProgramConfig, error) {
 // Use this when you know there's no SBR extension data in the config.
 //
 // Ported from: ~/dev/faad2/libfaad/mp4.c short_form parameter
-func ParseASCShortForm(data []byte) (*aac.AudioSpecificConfig, *ProgramConfig, error) {
+func ParseASCShortForm(data []byte) (*AudioSpecificConfig, *ProgramConfig, error) {
 	r := bits.NewReader(data)
 	if r.Error() {
 		return nil, nil, ErrASCBitstreamError
@@ -164,8 +188,8 @@ func ParseASCShortForm(data []byte) (*aac.AudioSpecificConfig, *ProgramConfig, e
 // shortForm disables SBR extension parsing when true.
 //
 // Ported from: ~/dev/faad2/libfaad/mp4.c:127-297 (AudioSpecificConfigFromBitfile)
-func ParseASCFromBitstream(r *bits.Reader, bufferSize uint32, shortForm bool) (*aac.AudioSpecificConfig, *ProgramConfig, error) {
-	asc := &aac.AudioSpecificConfig{}
+func ParseASCFromBitstream(r *bits.Reader, bufferSize uint32, shortForm bool) (*AudioSpecificConfig, *ProgramConfig, error) {
+	asc := &AudioSpecificConfig{}
 	startPos := r.GetProcessedBits()
 
 	// 5 bits: objectTypeIndex
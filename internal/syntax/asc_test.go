@@ -5,7 +5,6 @@ import (
 	"fmt"
 	"testing"
 
-	"github.com/llehouerou/go-aac"
 	"github.com/llehouerou/go-aac/internal/bits"
 )
 
@@ -181,7 +180,7 @@ func TestParseGASpecificConfig(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			r := bits.NewReader(tt.data)
-			asc := &aac.AudioSpecificConfig{
+			asc := &AudioSpecificConfig{
 				ChannelsConfiguration: tt.channelConfig,
 				ObjectTypeIndex:       tt.objectType,
 			}
@@ -265,7 +264,7 @@ func TestParseGASpecificConfigPCE(t *testing.T) {
 
 	data := []byte{0x0A, 0xA0, 0x80, 0x00, 0x04, 0x00, 0x00}
 	r := bits.NewReader(data)
-	asc := &aac.AudioSpecificConfig{
+	asc := &AudioSpecificConfig{
 		ChannelsConfiguration: 0, // Triggers PCE parsing
 		ObjectTypeIndex:       2, // LC
 	}
@@ -339,7 +338,7 @@ func TestParseGASpecificConfigERResilienceFlags(t *testing.T) {
 
 	data := []byte{0x34}
 	r := bits.NewReader(data)
-	asc := &aac.AudioSpecificConfig{
+	asc := &AudioSpecificConfig{
 		ChannelsConfiguration: 2,  // Non-zero, no PCE
 		ObjectTypeIndex:       17, // ER AAC LC
 	}
@@ -412,6 +411,14 @@ func TestParseASC(t *testing.T) {
 			wantObjType: 3,
 			wantErr:     ErrASCUnsupportedObjectType,
 		},
+		{
+			name: "unsupported object type (AAC Scalable)",
+			// objType=6 (Scalable), srIndex=4, channels=2
+			// 00110 0100 0010 = 0x32 0x10
+			data:        []byte{0x32, 0x10},
+			wantObjType: 6,
+			wantErr:     ErrASCUnsupportedObjectType,
+		},
 		{
 			name: "AAC-LC mono (upmatrix to stereo)",
 			// objType=2, srIndex=4, channels=1
@@ -436,6 +443,20 @@ func TestParseASC(t *testing.T) {
 			wantChannels:   2,
 			wantErr:        nil,
 		},
+		{
+			name: "ER AAC LC with error protection",
+			// Same as above with epConfig=1 (2 bits: 01)
+			// 10001 0100 0010 000 01 = 0x8A 0x10 0x40
+			data:    []byte{0x8A, 0x10, 0x40},
+			wantErr: ErrASCEPConfigNotSupported,
+		},
+		{
+			name: "ER AAC LC with resilience flags and error protection",
+			// extensionFlag=1, resilience flags=000, extensionFlag3=0, epConfig=3
+			// 10001 0100 0010 001 000 0 11 = 0x8A 0x11 0x0C
+			data:    []byte{0x8A, 0x11, 0x0C},
+			wantErr: ErrASCEPConfigNotSupported,
+		},
 		{
 			name: "invalid channel config",
 			// objType=2, srIndex=4, channels=8 (invalid, max is 7)
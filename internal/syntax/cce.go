@@ -9,9 +9,10 @@ import (
 // CCEConfig holds configuration for Coupling Channel Element parsing.
 // Ported from: coupling_channel_element() parameters in ~/dev/faad2/libfaad/syntax.c:987
 type CCEConfig struct {
-	SFIndex     uint8  // Sample rate index (0-11)
-	FrameLength uint16 // Frame length (960 or 1024)
-	ObjectType  uint8  // Audio object type
+	SFIndex     uint8           // Sample rate index (0-11)
+	FrameLength uint16          // Frame length (960 or 1024)
+	ObjectType  uint8           // Audio object type
+	Resilience  ResilienceFlags // Error resilience tools (ER object types)
 }
 
 // CCECoupledElement holds information about a coupled element target.
@@ -23,8 +24,34 @@ type CCECoupledElement struct {
 	CCR         bool  // Apply coupling to right channel (only if TargetIsCPE)
 }
 
+// CCEGainList holds a gain element list of a Coupling Channel Element,
+// giving the gains of the coupling channel for one target channel. The
+// values are those of the bitstream: scale factor Huffman deltas, summed
+// over the bands for per-band lists, before the gain_element_sign and
+// gain_element_scale interpretation.
+// Ported from: coupling_channel_element() in ~/dev/faad2/libfaad/syntax.c:1046-1073
+type CCEGainList struct {
+	Common     bool                           // common_gain_element_present (or implied)
+	CommonGain int16                          // common_gain_element
+	Gain       [MaxWindowGroups][MaxSFB]int16 // Accumulated dpcm_gain_element per group and band
+}
+
+// MaxCCEGainLists is the maximum number of gain element lists of a CCE:
+// two for each of its up to eight coupled channel pairs.
+const MaxCCEGainLists = 16
+
+// CouplingPoint is where in the decoding of its targets a coupling
+// channel is added.
+type CouplingPoint uint8
+
+// Coupling points, selected by ind_sw_cce_flag and cc_domain.
+const (
+	CouplingBeforeTNS  CouplingPoint = iota // Dependently switched, to the spectrum before TNS
+	CouplingAfterTNS                        // Dependently switched, to the spectrum after TNS
+	CouplingAfterIMDCT                      // Independently switched, to the time signal
+)
+
 // CCEResult holds the result of parsing a Coupling Channel Element.
-// Note: CCE data is parsed but not used for decoding (rarely used in practice).
 // Ported from: coupling_channel_element() in ~/dev/faad2/libfaad/syntax.c:987-1076
 type CCEResult struct {
 	Tag                 uint8                // Element instance tag (0-15)
@@ -36,7 +63,60 @@ type CCEResult struct {
 	GainElementSign     bool                 // Sign of gain elements
 	GainElementScale    uint8                // Scale of gain elements (0-3)
 	Element             Element              // Parsed ICS element
-	SpecData            []int16              // Spectral data (parsed but not used)
+	SpecData            []int16              // Spectral data
+
+	// Gain element lists; the first is implicitly unity and not coded
+	GainLists [MaxCCEGainLists]CCEGainList
+}
+
+// CouplingPoint returns where the coupling channel is added to its
+// targets.
+func (c *CCEResult) CouplingPoint() CouplingPoint {
+	switch {
+	case c.IndSwCCEFlag:
+		return CouplingAfterIMDCT
+	case c.CCDomain:
+		return CouplingAfterTNS
+	default:
+		return CouplingBeforeTNS
+	}
+}
+
+// TargetGainLists returns the indices of the gain element lists that
+// apply to a target channel: channel 0 or 1 of the channel pair element,
+// or channel 0 of the single channel element, with instance tag tag. Gain
+// lists follow the coupled elements in order, one per coupled channel; a
+// channel pair with neither cc_l nor cc_r set shares one list between its
+// channels.
+func (c *CCEResult) TargetGainLists(targetIsCPE bool, tag uint8, channel int) []int {
+	var lists []int
+	index := 0
+	for i := uint8(0); i <= c.NumCoupledElements; i++ {
+		e := &c.CoupledElements[i]
+		left, right := !e.TargetIsCPE || e.CCL || !e.CCR, e.TargetIsCPE && (e.CCR || !e.CCL)
+		if e.TargetIsCPE != targetIsCPE || e.TargetTag != tag {
+			index++
+			if e.CCL && e.CCR {
+				index++
+			}
+			continue
+		}
+		if left {
+			if channel == 0 {
+				lists = append(lists, index)
+			}
+			if !e.TargetIsCPE || e.CCL {
+				index++
+			}
+		}
+		if right {
+			if channel == 1 {
+				lists = append(lists, index)
+			}
+			index++
+		}
+	}
+	return lists
 }
 
 // parseCCEHeader parses the CCE header fields.
@@ -103,7 +183,6 @@ func parseCCEHeader(r *bits.Reader, result *CCEResult) error {
 // 3. Validates that intensity stereo is not used (illegal in CCE)
 // 4. Parses gain element lists
 //
-// Note: CCE data is parsed but discarded (rarely used in practice).
 // Ported from: coupling_channel_element() in ~/dev/faad2/libfaad/syntax.c:987-1076
 func ParseCouplingChannelElement(r *bits.Reader, cfg *CCEConfig) (*CCEResult, error) {
 	result := &CCEResult{
@@ -131,6 +210,7 @@ func ParseCouplingChannelElement(r *bits.Reader, cfg *CCEConfig) (*CCEResult, er
 		ObjectType:   cfg.ObjectType,
 		CommonWindow: false,
 		ScalFlag:     false,
+		Resilience:   cfg.Resilience,
 	}
 
 	if err := ParseIndividualChannelStream(r, &result.Element, &result.Element.ICS1, result.SpecData, icsCfg); err != nil {
@@ -172,17 +252,22 @@ func parseCCEGainElements(r *bits.Reader, result *CCEResult) error {
 			cge = r.Get1Bit() != 0
 		}
 
+		list := &result.GainLists[c]
+		list.Common = cge
 		if cge {
 			// Common gain element: decode single huffman scale factor
 			// Ported from: syntax.c:1058-1060
-			_ = huffman.ScaleFactor(r)
+			list.CommonGain = int16(huffman.ScaleFactor(r))
 		} else {
-			// Per-SFB gain elements: decode scale factor for each non-zero SFB
+			// Per-SFB gain elements: decode scale factor for each non-zero SFB,
+			// each a delta from the previous one
 			// Ported from: syntax.c:1062-1071
+			var gain int16
 			for g := uint8(0); g < ics.NumWindowGroups; g++ {
 				for sfb := uint8(0); sfb < ics.MaxSFB; sfb++ {
 					if ics.SFBCB[g][sfb] != uint8(huffman.ZeroHCB) {
-						_ = huffman.ScaleFactor(r)
+						gain += int16(huffman.ScaleFactor(r))
+						list.Gain[g][sfb] = gain
 					}
 				}
 			}
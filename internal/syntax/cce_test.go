@@ -296,3 +296,121 @@ func TestParseCouplingChannelElement_IntensityStereoError(t *testing.T) {
 	// Full integration testing would require a complete CCE bitstream
 	t.Log("Integration test: CCE with intensity stereo should return ErrIntensityStereoInCCE")
 }
+
+func TestParseCouplingChannelElement_GainLists(t *testing.T) {
+	w := &bitWriter{}
+	w.writeBits(1, 4) // element_instance_tag
+	w.writeBits(0, 1) // ind_sw_cce_flag
+	w.writeBits(1, 3) // num_coupled_elements: two targets
+	w.writeBits(0, 1) // cc_target_is_cpe: SCE
+	w.writeBits(3, 4) // cc_target_tag_select
+	w.writeBits(1, 1) // cc_target_is_cpe: CPE
+	w.writeBits(2, 4) // cc_target_tag_select
+	w.writeBits(1, 1) // cc_l
+	w.writeBits(1, 1) // cc_r
+	w.writeBits(1, 1) // cc_domain: after TNS
+	w.writeBits(1, 1) // gain_element_sign
+	w.writeBits(3, 2) // gain_element_scale
+
+	// individual_channel_stream: bands 0 and 2 with codebook 1, band 1
+	// zero, all coefficients zero
+	w.writeBits(100, 8) // global_gain
+	w.writeBits(0, 1)   // ics_reserved_bit
+	w.writeBits(0, 2)   // window_sequence: ONLY_LONG_SEQUENCE
+	w.writeBits(0, 1)   // window_shape
+	w.writeBits(3, 6)   // max_sfb
+	w.writeBits(0, 1)   // predictor_data_present
+	for _, cb := range []uint32{1, 0, 1} {
+		w.writeBits(cb, 4)
+		w.writeBits(1, 5)
+	}
+	w.writeBits(0, 1) // scale factor delta 0, band 0
+	w.writeBits(0, 1) // scale factor delta 0, band 2
+	w.writeBits(0, 1) // pulse_data_present
+	w.writeBits(0, 1) // tns_data_present
+	w.writeBits(0, 1) // gain_control_data_present
+	w.writeBits(0, 1) // codebook 1 quad of zeros, band 0
+	w.writeBits(0, 1) // codebook 1 quad of zeros, band 2
+
+	// Gain element list 1: a common gain of +2
+	w.writeBits(1, 1)
+	w.writeBits(0xC, 4)
+	// Gain element list 2: per-band deltas +1, then -2
+	w.writeBits(0, 1)
+	w.writeBits(0xA, 4)
+	w.writeBits(0xB, 4)
+
+	res, err := ParseCouplingChannelElement(bits.NewReader(append(w.buf, 0, 0)), &CCEConfig{
+		SFIndex:     4,
+		FrameLength: 1024,
+		ObjectType:  2,
+	})
+	if err != nil {
+		t.Fatalf("ParseCouplingChannelElement: %v", err)
+	}
+	if res.NumGainElementLists != 3 {
+		t.Fatalf("NumGainElementLists = %d, want 3", res.NumGainElementLists)
+	}
+	if got := res.CouplingPoint(); got != CouplingAfterTNS {
+		t.Errorf("CouplingPoint() = %d, want CouplingAfterTNS", got)
+	}
+
+	if l := res.GainLists[1]; !l.Common || l.CommonGain != 2 {
+		t.Errorf("list 1: Common %v CommonGain %d, want true 2", l.Common, l.CommonGain)
+	}
+	l := res.GainLists[2]
+	if l.Common || l.Gain[0][0] != 1 || l.Gain[0][1] != 0 || l.Gain[0][2] != -1 {
+		t.Errorf("list 2: Common %v gains %v, want false [1 0 -1]", l.Common, l.Gain[0][:3])
+	}
+
+	for _, tc := range []struct {
+		isCPE   bool
+		tag     uint8
+		channel int
+		want    []int
+	}{
+		{false, 3, 0, []int{0}},
+		{true, 2, 0, []int{1}},
+		{true, 2, 1, []int{2}},
+		{false, 2, 0, nil},
+		{true, 3, 0, nil},
+	} {
+		got := res.TargetGainLists(tc.isCPE, tc.tag, tc.channel)
+		if len(got) != len(tc.want) || (len(got) > 0 && got[0] != tc.want[0]) {
+			t.Errorf("TargetGainLists(%v, %d, %d) = %v, want %v", tc.isCPE, tc.tag, tc.channel, got, tc.want)
+		}
+	}
+}
+
+func TestCCEResult_TargetGainLists(t *testing.T) {
+	// A CPE with neither cc_l nor cc_r shares one list between its
+	// channels; one with only cc_r couples its right channel
+	res := &CCEResult{NumCoupledElements: 2}
+	res.CoupledElements[0] = CCECoupledElement{TargetIsCPE: true, TargetTag: 0}
+	res.CoupledElements[1] = CCECoupledElement{TargetIsCPE: true, TargetTag: 1, CCR: true}
+	res.CoupledElements[2] = CCECoupledElement{TargetTag: 0}
+
+	for _, tc := range []struct {
+		isCPE   bool
+		tag     uint8
+		channel int
+		want    int
+	}{
+		{true, 0, 0, 0},
+		{true, 0, 1, 0},
+		{true, 1, 0, -1},
+		{true, 1, 1, 1},
+		{false, 0, 0, 2},
+	} {
+		got := res.TargetGainLists(tc.isCPE, tc.tag, tc.channel)
+		if tc.want < 0 {
+			if len(got) != 0 {
+				t.Errorf("TargetGainLists(%v, %d, %d) = %v, want none", tc.isCPE, tc.tag, tc.channel, got)
+			}
+			continue
+		}
+		if len(got) != 1 || got[0] != tc.want {
+			t.Errorf("TargetGainLists(%v, %d, %d) = %v, want [%d]", tc.isCPE, tc.tag, tc.channel, got, tc.want)
+		}
+	}
+}
@@ -6,9 +6,10 @@ import "github.com/llehouerou/go-aac/internal/bits"
 // CPEConfig holds configuration for Channel Pair Element parsing.
 // Ported from: channel_pair_element() parameters in ~/dev/faad2/libfaad/syntax.c:698
 type CPEConfig struct {
-	SFIndex     uint8  // Sample rate index (0-11)
-	FrameLength uint16 // Frame length (960 or 1024)
-	ObjectType  uint8  // Audio object type
+	SFIndex     uint8           // Sample rate index (0-11)
+	FrameLength uint16          // Frame length (960 or 1024)
+	ObjectType  uint8           // Audio object type
+	Resilience  ResilienceFlags // Error resilience tools (ER object types)
 }
 
 // CPEResult holds the result of parsing a Channel Pair Element.
@@ -73,6 +74,12 @@ func ParseChannelPairElement(r *bits.Reader, channels uint8, cfg *CPEConfig) (*C
 			return nil, err
 		}
 
+		// ER objects send the first channel's LTP data after the M/S mask
+		// Ported from: syntax.c ERROR_RESIL section after the ms_mask
+		if err := parseERPairLTP(r, &result.Element.ICS1, &result.Element.ICS1.LTP, cfg); err != nil {
+			return nil, err
+		}
+
 		// Copy ICS1 to ICS2 (they share window configuration)
 		// Ported from: syntax.c:764
 		result.Element.ICS2 = result.Element.ICS1
@@ -90,11 +97,23 @@ func ParseChannelPairElement(r *bits.Reader, channels uint8, cfg *CPEConfig) (*C
 		ObjectType:   cfg.ObjectType,
 		CommonWindow: result.Element.CommonWindow,
 		ScalFlag:     false,
+		Resilience:   cfg.Resilience,
 	}
 	if err := ParseIndividualChannelStream(r, &result.Element, &result.Element.ICS1, result.SpecData1, ics1Cfg); err != nil {
 		return nil, err
 	}
 
+	// ER objects send the second channel's LTP data between the two
+	// channel streams. FAAD2 stores it in ics1->ltp2, which reconstruction
+	// never reads; it goes to ICS2.LTP2, where the common window case
+	// looks for the second channel's LTP.
+	// Ported from: syntax.c ERROR_RESIL section after the first channel
+	if result.Element.CommonWindow {
+		if err := parseERPairLTP(r, &result.Element.ICS1, &result.Element.ICS2.LTP2, cfg); err != nil {
+			return nil, err
+		}
+	}
+
 	// Parse individual channel stream for channel 2
 	// Ported from: syntax.c:797-801
 	ics2Cfg := &ICSConfig{
@@ -103,7 +122,9 @@ func ParseChannelPairElement(r *bits.Reader, channels uint8, cfg *CPEConfig) (*C
 		ObjectType:   cfg.ObjectType,
 		CommonWindow: result.Element.CommonWindow,
 		ScalFlag:     false,
+		Resilience:   cfg.Resilience,
 	}
+	result.Element.ICS2Start = r.GetProcessedBits()
 	if err := ParseIndividualChannelStream(r, &result.Element, &result.Element.ICS2, result.SpecData2, ics2Cfg); err != nil {
 		return nil, err
 	}
@@ -114,6 +135,20 @@ func ParseChannelPairElement(r *bits.Reader, channels uint8, cfg *CPEConfig) (*C
 	return result, nil
 }
 
+// parseERPairLTP parses an ltp_data_present flag and LTP data into ltp
+// for an ER object type with a common window whose ics_info signalled
+// predictor data. It reads nothing for other object types.
+func parseERPairLTP(r *bits.Reader, ics *ICStream, ltp *LTPInfo, cfg *CPEConfig) error {
+	if cfg.ObjectType < ERObjectStart || !ics.PredictorDataPresent {
+		return nil
+	}
+	ltp.DataPresent = r.Get1Bit() != 0
+	if !ltp.DataPresent {
+		return nil
+	}
+	return ParseLTPData(r, ics, ltp, cfg.FrameLength, cfg.ObjectType)
+}
+
 // parseMSMask parses the M/S stereo mask from the bitstream.
 // Ported from: channel_pair_element() ms_mask section in syntax.c:723-741
 func parseMSMask(r *bits.Reader, ics *ICStream) error {
@@ -5,13 +5,14 @@ import (
 	"testing"
 
 	"github.com/llehouerou/go-aac/internal/bits"
+	"github.com/llehouerou/go-aac/internal/tables"
 )
 
 func TestCPEConfig_Fields(t *testing.T) {
 	cfg := &CPEConfig{
 		SFIndex:     4,
 		FrameLength: 1024,
-		ObjectType:  ObjectTypeLC,
+		ObjectType:  tables.ObjectTypeLC,
 	}
 
 	if cfg.SFIndex != 4 {
@@ -20,8 +21,8 @@ func TestCPEConfig_Fields(t *testing.T) {
 	if cfg.FrameLength != 1024 {
 		t.Errorf("FrameLength = %d, want 1024", cfg.FrameLength)
 	}
-	if cfg.ObjectType != ObjectTypeLC {
-		t.Errorf("ObjectType = %d, want %d", cfg.ObjectType, ObjectTypeLC)
+	if cfg.ObjectType != tables.ObjectTypeLC {
+		t.Errorf("ObjectType = %d, want %d", cfg.ObjectType, tables.ObjectTypeLC)
 	}
 }
 
@@ -108,6 +109,23 @@ func TestParseMSMask_Reserved(t *testing.T) {
 	}
 }
 
+func TestParseChannelPairElement_ReservedMSMask(t *testing.T) {
+	// Common-window CPE whose ms_mask_present is the reserved value 3:
+	// element_instance_tag=0, common_window=1, ics_info (long window,
+	// max_sfb=0, no prediction), ms_mask_present=11
+	data := []byte{0x08, 0x00, 0xC0}
+	r := bits.NewReader(data)
+
+	_, err := ParseChannelPairElement(r, 0, &CPEConfig{
+		SFIndex:     4,
+		FrameLength: 1024,
+		ObjectType:  2,
+	})
+	if err != ErrMSMaskReserved {
+		t.Errorf("Expected ErrMSMaskReserved, got %v", err)
+	}
+}
+
 func TestParseMSMask_NoMS(t *testing.T) {
 	// Test ms_mask_present == 0 (no M/S stereo)
 	data := []byte{0x00} // 00 + padding
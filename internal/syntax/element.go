@@ -12,7 +12,10 @@ type Element struct {
 	PairedChannel      int16 // Paired channel for CPE (-1 if none)
 	ElementInstanceTag uint8 // Element instance tag (0-15)
 	CommonWindow       bool  // True if CPE shares window info
+	LFE                bool  // Parsed from an LFE element, which no CCE targets
 
 	ICS1 ICStream // First (or only) channel stream
 	ICS2 ICStream // Second channel stream (CPE only)
+
+	ICS2Start uint32 // Reader position where ICS2 starts (CPE only)
 }
@@ -20,6 +20,10 @@ var (
 var (
 	// ErrICSReservedBit indicates ics_reserved_bit is not 0.
 	ErrICSReservedBit = errors.New("syntax: ics_reserved_bit must be 0")
+
+	// ErrLDWindowSequence indicates a window sequence other than
+	// ONLY_LONG_SEQUENCE in an AAC-LD stream, which has no block switching.
+	ErrLDWindowSequence = errors.New("syntax: AAC-LD allows only long windows")
 )
 
 // LTP errors.
@@ -50,6 +54,10 @@ var (
 var (
 	// ErrScaleFactorRange indicates a scale factor is out of the valid range [0, 255].
 	ErrScaleFactorRange = errors.New("syntax: scale factor out of range [0, 255]")
+
+	// ErrRVLCLength indicates an RVLC scale factor length shorter than the
+	// PCM noise energy it counts.
+	ErrRVLCLength = errors.New("syntax: RVLC scale factor length too short")
 )
 
 // Pulse data errors.
@@ -60,15 +68,17 @@ var (
 	// ErrPulseInShortBlock indicates pulse coding is not allowed in short blocks.
 	ErrPulseInShortBlock = errors.New("syntax: pulse coding not allowed in short blocks")
 
-	// ErrPulsePosition indicates pulse position exceeds frame length.
+	// ErrPulseOutOfRange indicates a cumulative pulse offset falls outside
+	// the spectral coefficient buffer (frame length).
 	// FAAD2 error code: 15
-	ErrPulsePosition = errors.New("syntax: pulse position exceeds frame length")
+	ErrPulseOutOfRange = errors.New("syntax: pulse position out of range")
 )
 
 // Gain control errors.
 var (
-	// ErrGainControlNotSupported indicates gain control (SSR profile) is not supported.
-	ErrGainControlNotSupported = errors.New("syntax: gain control (SSR) not supported")
+	// ErrGainControlNotSupported indicates gain control data in a stream
+	// whose object type is not SSR, the only one defining it.
+	ErrGainControlNotSupported = errors.New("syntax: gain control data outside the SSR object type")
 )
 
 // SCE/LFE errors.
@@ -0,0 +1,80 @@
+// internal/syntax/gain_control.go
+package syntax
+
+import "github.com/llehouerou/go-aac/internal/bits"
+
+// SSRBands is the number of PQF bands of the SSR object type.
+const SSRBands = 4
+
+// SSRInfo contains the gain control data of the SSR object type. For each
+// PQF band above the first, up to MaxBand, and each gain control window
+// of the window sequence, AdjustNum gain changes are given by a level code
+// (AlevCode) and a location code (AlocCode).
+//
+// Ported from: ssr_info in ~/dev/faad2/libfaad/structs.h
+type SSRInfo struct {
+	MaxBand   uint8                 // Highest band with gain control data (0-3)
+	AdjustNum [SSRBands][8]uint8    // Gain changes per band and window (0-7)
+	AlevCode  [SSRBands][8][8]uint8 // Gain level codes (4 bits)
+	AlocCode  [SSRBands][8][8]uint8 // Gain location codes (2-5 bits)
+}
+
+// GainControlWindows returns the number of gain control windows of a
+// window sequence: one for ONLY_LONG_SEQUENCE, eight for
+// EIGHT_SHORT_SEQUENCE and two for the transition sequences.
+func GainControlWindows(windowSequence WindowSequence) uint8 {
+	switch windowSequence {
+	case OnlyLongSequence:
+		return 1
+	case EightShortSequence:
+		return 8
+	default:
+		return 2
+	}
+}
+
+// GainControlLocationBits returns the size of aloccode in window wd of a
+// window sequence.
+//
+// Ported from: gain_control_data() in ~/dev/faad2/libfaad/syntax.c
+func GainControlLocationBits(windowSequence WindowSequence, wd uint8) uint {
+	switch windowSequence {
+	case OnlyLongSequence:
+		return 5
+	case LongStartSequence:
+		if wd == 0 {
+			return 4
+		}
+		return 2
+	case EightShortSequence:
+		return 2
+	default: // LongStopSequence
+		if wd == 0 {
+			return 4
+		}
+		return 5
+	}
+}
+
+// ParseGainControlData parses gain_control_data() into ssr, for the
+// window sequence of ics.
+//
+// Ported from: gain_control_data() in ~/dev/faad2/libfaad/syntax.c
+func ParseGainControlData(r *bits.Reader, ics *ICStream, ssr *SSRInfo) {
+	// max_band (2 bits)
+	ssr.MaxBand = uint8(r.GetBits(2))
+
+	windows := GainControlWindows(ics.WindowSequence)
+	for bd := uint8(1); bd <= ssr.MaxBand; bd++ {
+		for wd := uint8(0); wd < windows; wd++ {
+			// adjust_num (3 bits)
+			ssr.AdjustNum[bd][wd] = uint8(r.GetBits(3))
+			locBits := GainControlLocationBits(ics.WindowSequence, wd)
+			for ad := uint8(0); ad < ssr.AdjustNum[bd][wd]; ad++ {
+				// alevcode (4 bits), aloccode (2, 4 or 5 bits)
+				ssr.AlevCode[bd][wd][ad] = uint8(r.GetBits(4))
+				ssr.AlocCode[bd][wd][ad] = uint8(r.GetBits(locBits))
+			}
+		}
+	}
+}
@@ -0,0 +1,112 @@
+// internal/syntax/gain_control_test.go
+package syntax
+
+import (
+	"testing"
+
+	"github.com/llehouerou/go-aac/internal/bits"
+)
+
+// bitWriter packs values MSB first for building test bitstreams.
+type bitWriter struct {
+	buf  []byte
+	nbit int
+}
+
+func (w *bitWriter) writeBits(val uint32, n int) {
+	for i := n - 1; i >= 0; i-- {
+		if w.nbit%8 == 0 {
+			w.buf = append(w.buf, 0)
+		}
+		if val&(1<<uint(i)) != 0 {
+			w.buf[len(w.buf)-1] |= 0x80 >> uint(w.nbit%8)
+		}
+		w.nbit++
+	}
+}
+
+func TestParseGainControlData(t *testing.T) {
+	tests := []struct {
+		name    string
+		seq     WindowSequence
+		windows uint8
+		locBits []uint // aloccode size per window
+	}{
+		{"only long", OnlyLongSequence, 1, []uint{5}},
+		{"long start", LongStartSequence, 2, []uint{4, 2}},
+		{"eight short", EightShortSequence, 8, []uint{2, 2, 2, 2, 2, 2, 2, 2}},
+		{"long stop", LongStopSequence, 2, []uint{4, 5}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			const maxBand = 3
+
+			// Band bd, window wd carries (bd+wd)%3 adjustments with level
+			// code bd+wd+ad and the largest location code of the window.
+			w := &bitWriter{}
+			w.writeBits(maxBand, 2)
+			wantBits := 2
+			for bd := 1; bd <= maxBand; bd++ {
+				for wd := range int(tt.windows) {
+					adjust := (bd + wd) % 3
+					w.writeBits(uint32(adjust), 3)
+					wantBits += 3
+					for ad := range adjust {
+						w.writeBits(uint32(bd+wd+ad), 4)
+						w.writeBits(1<<tt.locBits[wd]-1, int(tt.locBits[wd]))
+						wantBits += 4 + int(tt.locBits[wd])
+					}
+				}
+			}
+			w.writeBits(0, 8) // trailing data
+
+			if got := GainControlWindows(tt.seq); got != tt.windows {
+				t.Fatalf("GainControlWindows = %d, want %d", got, tt.windows)
+			}
+
+			r := bits.NewReader(w.buf)
+			ics := &ICStream{WindowSequence: tt.seq}
+			var ssr SSRInfo
+			ParseGainControlData(r, ics, &ssr)
+
+			if got := int(r.GetProcessedBits()); got != wantBits {
+				t.Errorf("consumed %d bits, want %d", got, wantBits)
+			}
+			if ssr.MaxBand != maxBand {
+				t.Errorf("MaxBand = %d, want %d", ssr.MaxBand, maxBand)
+			}
+			for bd := 1; bd <= maxBand; bd++ {
+				for wd := range int(tt.windows) {
+					adjust := (bd + wd) % 3
+					if int(ssr.AdjustNum[bd][wd]) != adjust {
+						t.Fatalf("AdjustNum[%d][%d] = %d, want %d", bd, wd, ssr.AdjustNum[bd][wd], adjust)
+					}
+					for ad := range adjust {
+						if int(ssr.AlevCode[bd][wd][ad]) != bd+wd+ad {
+							t.Errorf("AlevCode[%d][%d][%d] = %d, want %d", bd, wd, ad, ssr.AlevCode[bd][wd][ad], bd+wd+ad)
+						}
+						if want := uint8(1<<tt.locBits[wd] - 1); ssr.AlocCode[bd][wd][ad] != want {
+							t.Errorf("AlocCode[%d][%d][%d] = %d, want %d", bd, wd, ad, ssr.AlocCode[bd][wd][ad], want)
+						}
+					}
+				}
+			}
+		})
+	}
+}
+
+func TestParseGainControlData_NoBands(t *testing.T) {
+	// max_band 0 carries no band data
+	r := bits.NewReader([]byte{0x3F, 0xFF})
+	ics := &ICStream{WindowSequence: EightShortSequence}
+	var ssr SSRInfo
+	ParseGainControlData(r, ics, &ssr)
+
+	if ssr.MaxBand != 0 {
+		t.Errorf("MaxBand = %d, want 0", ssr.MaxBand)
+	}
+	if got := r.GetProcessedBits(); got != 2 {
+		t.Errorf("consumed %d bits, want 2", got)
+	}
+}
@@ -0,0 +1,112 @@
+// internal/syntax/hcr.go
+package syntax
+
+import (
+	"github.com/llehouerou/go-aac/internal/bits"
+	"github.com/llehouerou/go-aac/internal/huffman"
+)
+
+// ResilienceFlags are the error resilience tools of an ER object type,
+// from the aac*DataResilienceFlag bits of its GASpecificConfig.
+type ResilienceFlags struct {
+	SectionData     bool // aacSectionDataResilienceFlag: virtual codebooks 16-31
+	ScalefactorData bool // aacScalefactorDataResilienceFlag: RVLC scale factors
+	SpectralData    bool // aacSpectralDataResilienceFlag: Huffman codeword reordering
+}
+
+// maxReorderedSpectralDataLength is the maximum
+// length_of_reordered_spectral_data.
+// Ported from: side_info() in ~/dev/faad2/libfaad/syntax.c
+const maxReorderedSpectralDataLength = 12288
+
+// maxLongestCodewordLength is the maximum length_of_longest_codeword:
+// the longest escape codeword.
+const maxLongestCodewordLength = 49
+
+// hcrPriority lists the codebooks in the order their codewords are
+// sorted, without and with virtual codebooks. Each pair of codebooks
+// sharing a dimension and largest value is sorted together.
+//
+// Ported from: PreSortCB_STD[] and PreSortCB_ER[] in ~/dev/faad2/libfaad/hcr.c
+var (
+	hcrPriority   = []uint8{11, 9, 7, 5, 3, 1}
+	hcrPriorityER = []uint8{11, 31, 30, 29, 28, 27, 26, 25, 24, 23, 22, 21, 20, 19, 18, 17, 16, 9, 7, 5, 3, 1}
+)
+
+// hcrSortsWith reports whether section codebook sectCB is sorted with
+// priority codebook cb.
+//
+// Ported from: is_good_cb() in ~/dev/faad2/libfaad/hcr.c
+func hcrSortsWith(cb, sectCB uint8) bool {
+	if (sectCB == uint8(huffman.ZeroHCB) || sectCB > uint8(huffman.EscHCB)) && (sectCB < 16 || sectCB > 31) {
+		return false
+	}
+	if cb < uint8(huffman.EscHCB) {
+		return sectCB == cb || sectCB == cb+1
+	}
+	return sectCB == cb
+}
+
+// hcrCodewords returns the codewords of the spectral data of ics in the
+// priority order of Huffman codeword reordering: by codebook, then by
+// band, in runs of four lines per window of each window group.
+//
+// Ported from: the sorting loop of reordered_spectral_data() in ~/dev/faad2/libfaad/hcr.c
+func hcrCodewords(ics *ICStream, frameLength uint16, virtualCodebooks bool) []huffman.HCRCodeword {
+	nshort := frameLength / 8
+	var groupOffset [MaxWindowGroups]uint16
+	for g := uint8(1); g < ics.NumWindowGroups; g++ {
+		groupOffset[g] = groupOffset[g-1] + nshort*uint16(ics.WindowGroupLength[g-1])
+	}
+
+	priority := hcrPriority
+	if virtualCodebooks {
+		priority = hcrPriorityER
+	}
+
+	var codewords []huffman.HCRCodeword
+	for _, cb := range priority {
+		for sfb := uint8(0); sfb < ics.MaxSFB; sfb++ {
+			width := min(ics.SWBOffset[sfb+1], ics.SWBOffsetMax) - ics.SWBOffset[sfb]
+			for w := uint16(0); 4*w < width; w++ {
+				for g := uint8(0); g < ics.NumWindowGroups; g++ {
+					for i := uint8(0); i < ics.NumSec[g]; i++ {
+						if uint16(sfb) < ics.SectStart[g][i] || uint16(sfb) >= ics.SectEnd[g][i] {
+							continue
+						}
+						sectCB := ics.SectCB[g][i]
+						if !hcrSortsWith(cb, sectCB) {
+							continue
+						}
+
+						inc := uint16(huffman.QuadLen)
+						if sectCB >= uint8(huffman.FirstPairHCB) {
+							inc = huffman.PairLen
+						}
+						// Codewords of four lines per window of the group
+						perGroup := 4 * uint16(ics.WindowGroupLength[g]) / inc
+						bandCodewords := (ics.SectSFBOffset[g][sfb+1] - ics.SectSFBOffset[g][sfb]) / inc
+						for c := uint16(0); c < perGroup && c+w*perGroup < bandCodewords; c++ {
+							codewords = append(codewords, huffman.HCRCodeword{
+								CB:     sectCB,
+								Offset: groupOffset[g] + ics.SectSFBOffset[g][sfb] + inc*(c+w*perGroup),
+							})
+						}
+					}
+				}
+			}
+		}
+	}
+	return codewords
+}
+
+// ParseReorderedSpectralData decodes the spectral data of an ER stream
+// using Huffman codeword reordering, whose length and longest codeword
+// were read with the side info. virtualCodebooks selects the codebook
+// order of streams with section data resilience.
+//
+// Ported from: reordered_spectral_data() in ~/dev/faad2/libfaad/hcr.c
+func ParseReorderedSpectralData(r *bits.Reader, ics *ICStream, specData []int16, frameLength uint16, virtualCodebooks bool) error {
+	return huffman.ReorderedSpectralData(r, ics.LengthOfReorderedSpectralData, ics.LengthOfLongestCodeword,
+		hcrCodewords(ics, frameLength, virtualCodebooks), specData)
+}
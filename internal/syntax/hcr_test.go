@@ -0,0 +1,113 @@
+// internal/syntax/hcr_test.go
+package syntax
+
+import (
+	"testing"
+
+	"github.com/llehouerou/go-aac/internal/bits"
+	"github.com/llehouerou/go-aac/internal/huffman"
+)
+
+func TestHCRCodewords_Order(t *testing.T) {
+	// Eight short windows in groups of 3 and 5, two bands of four lines
+	ics := &ICStream{
+		WindowSequence:  EightShortSequence,
+		MaxSFB:          2,
+		NumWindowGroups: 2,
+		SWBOffsetMax:    128,
+	}
+	ics.WindowGroupLength[0], ics.WindowGroupLength[1] = 3, 5
+	ics.SWBOffset[1], ics.SWBOffset[2] = 4, 8
+	ics.SectSFBOffset[0][1], ics.SectSFBOffset[0][2] = 12, 24
+	ics.SectSFBOffset[1][1], ics.SectSFBOffset[1][2] = 20, 40
+
+	// Group 0: codebook 1 over both bands; group 1: codebook 7, then 2
+	ics.NumSec[0] = 1
+	ics.SectCB[0][0], ics.SectStart[0][0], ics.SectEnd[0][0] = 1, 0, 2
+	ics.NumSec[1] = 2
+	ics.SectCB[1][0], ics.SectStart[1][0], ics.SectEnd[1][0] = 7, 0, 1
+	ics.SectCB[1][1], ics.SectStart[1][1], ics.SectEnd[1][1] = 2, 1, 2
+
+	var want []huffman.HCRCodeword
+	// Codebook 7 first: the ten pairs of band 0 in group 1, at 3*128
+	for i := range uint16(10) {
+		want = append(want, huffman.HCRCodeword{CB: 7, Offset: 384 + 2*i})
+	}
+	// Then codebooks 1 and 2 together, band by band
+	for i := range uint16(3) {
+		want = append(want, huffman.HCRCodeword{CB: 1, Offset: 4 * i})
+	}
+	for i := range uint16(3) {
+		want = append(want, huffman.HCRCodeword{CB: 1, Offset: 12 + 4*i})
+	}
+	for i := range uint16(5) {
+		want = append(want, huffman.HCRCodeword{CB: 2, Offset: 384 + 20 + 4*i})
+	}
+
+	got := hcrCodewords(ics, 1024, false)
+	if len(got) != len(want) {
+		t.Fatalf("got %d codewords, want %d: %v", len(got), len(want), got)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("codeword %d = %+v, want %+v", i, got[i], want[i])
+		}
+	}
+}
+
+func TestParseIndividualChannelStream_HCR(t *testing.T) {
+	w := &bitWriter{}
+	w.writeBits(100, 8) // global_gain
+
+	// ics_info: long window, two bands of four lines at 44.1 kHz
+	w.writeBits(0, 1) // ics_reserved_bit
+	w.writeBits(0, 2) // window_sequence: ONLY_LONG_SEQUENCE
+	w.writeBits(0, 1) // window_shape
+	w.writeBits(2, 6) // max_sfb
+	w.writeBits(0, 1) // predictor_data_present
+
+	// section_data: codebook 1 for band 0, codebook 5 for band 1
+	w.writeBits(1, 4)
+	w.writeBits(1, 5)
+	w.writeBits(5, 4)
+	w.writeBits(1, 5)
+
+	w.writeBits(0, 1) // scale factor delta 0, band 0
+	w.writeBits(0, 1) // scale factor delta 0, band 1
+	w.writeBits(0, 1) // pulse_data_present
+	w.writeBits(0, 1) // tns_data_present
+	w.writeBits(0, 1) // gain_control_data_present
+
+	// Three segments as wide as the longest codeword, 7 bits, one per
+	// codeword: the codebook 5 pairs, then the codebook 1 quad
+	w.writeBits(21, 14) // length_of_reordered_spectral_data
+	w.writeBits(7, 6)   // length_of_longest_codeword
+
+	w.writeBits(0x18, 5) // pair (1, -1)
+	w.writeBits(0, 2)    // rest of the segment
+	w.writeBits(0, 1)    // pair (0, 0)
+	w.writeBits(0, 6)    // rest of the segment
+	w.writeBits(0x6A, 7) // quad (1, 0, -1, 0)
+
+	ele := &Element{}
+	spec := make([]int16, 1024)
+	err := ParseIndividualChannelStream(bits.NewReader(append(w.buf, 0, 0, 0, 0)), ele, &ele.ICS1, spec, &ICSConfig{
+		SFIndex:     4,
+		FrameLength: 1024,
+		ObjectType:  17,
+		Resilience:  ResilienceFlags{SpectralData: true},
+	})
+	if err != nil {
+		t.Fatalf("ParseIndividualChannelStream: %v", err)
+	}
+	if ele.ICS1.LengthOfReorderedSpectralData != 21 || ele.ICS1.LengthOfLongestCodeword != 7 {
+		t.Errorf("lengths %d and %d, want 21 and 7",
+			ele.ICS1.LengthOfReorderedSpectralData, ele.ICS1.LengthOfLongestCodeword)
+	}
+	want := []int16{1, 0, -1, 0, 1, -1, 0, 0}
+	for i, v := range want {
+		if spec[i] != v {
+			t.Fatalf("spec[:8] = %v, want %v", spec[:8], want)
+		}
+	}
+}
@@ -1,7 +1,10 @@
 // internal/syntax/ics.go
 package syntax
 
-import "github.com/llehouerou/go-aac/internal/bits"
+import (
+	"github.com/llehouerou/go-aac/internal/bits"
+	"github.com/llehouerou/go-aac/internal/tables"
+)
 
 // SideInfoConfig holds configuration for side info parsing.
 type SideInfoConfig struct {
@@ -10,6 +13,7 @@ type SideInfoConfig struct {
 	ObjectType   uint8
 	CommonWindow bool
 	ScalFlag     bool // True for scalable AAC
+	Resilience   ResilienceFlags
 }
 
 // ICSConfig holds configuration for ICS parsing.
@@ -19,6 +23,7 @@ type ICSConfig struct {
 	ObjectType   uint8
 	CommonWindow bool
 	ScalFlag     bool
+	Resilience   ResilienceFlags
 }
 
 // ParseSideInfo parses side information for an ICS.
@@ -45,8 +50,13 @@ func ParseSideInfo(r *bits.Reader, ele *Element, ics *ICStream, cfg *SideInfoCon
 		return err
 	}
 
-	// Parse scale factor data
-	if err := ParseScaleFactorData(r, ics); err != nil {
+	// Parse scale factor data, or the RVLC lengths of ER streams, whose
+	// codewords follow the tool data
+	if cfg.Resilience.ScalefactorData {
+		if err := ParseRVLCScaleFactorData(r, ics); err != nil {
+			return err
+		}
+	} else if err := ParseScaleFactorData(r, ics); err != nil {
 		return err
 	}
 
@@ -72,7 +82,24 @@ func ParseSideInfo(r *bits.Reader, ele *Element, ics *ICStream, cfg *SideInfoCon
 		// Gain control data (SSR profile only)
 		ics.GainControlDataPresent = r.Get1Bit() != 0
 		if ics.GainControlDataPresent {
-			return ErrGainControlNotSupported
+			if cfg.ObjectType != tables.ObjectTypeSSR {
+				return ErrGainControlNotSupported
+			}
+			ParseGainControlData(r, ics, &ics.SSR)
+		}
+	}
+
+	// Huffman codeword reordering sizes
+	// Ported from: side_info() ERROR_RESILIENCE section in ~/dev/faad2/libfaad/syntax.c
+	if cfg.Resilience.SpectralData {
+		ics.LengthOfReorderedSpectralData = min(uint16(r.GetBits(14)), maxReorderedSpectralDataLength)
+		ics.LengthOfLongestCodeword = min(uint8(r.GetBits(6)), maxLongestCodewordLength)
+	}
+
+	// RVLC scale factor codewords
+	if cfg.Resilience.ScalefactorData {
+		if err := DecodeRVLCScaleFactors(r, ics); err != nil {
+			return err
 		}
 	}
 
@@ -91,6 +118,7 @@ func ParseIndividualChannelStream(r *bits.Reader, ele *Element, ics *ICStream, s
 		ObjectType:   cfg.ObjectType,
 		CommonWindow: cfg.CommonWindow,
 		ScalFlag:     cfg.ScalFlag,
+		Resilience:   cfg.Resilience,
 	}
 	if err := ParseSideInfo(r, ele, ics, sideCfg); err != nil {
 		return err
@@ -101,8 +129,13 @@ func ParseIndividualChannelStream(r *bits.Reader, ele *Element, ics *ICStream, s
 		ParseTNSData(r, ics, &ics.TNS)
 	}
 
-	// Parse spectral data
-	if err := ParseSpectralData(r, ics, specData, cfg.FrameLength); err != nil {
+	// Parse spectral data, reordered with Huffman codeword reordering
+	// Ported from: individual_channel_stream() in ~/dev/faad2/libfaad/syntax.c
+	if cfg.Resilience.SpectralData {
+		if err := ParseReorderedSpectralData(r, ics, specData, cfg.FrameLength, cfg.Resilience.SectionData); err != nil {
+			return err
+		}
+	} else if err := ParseSpectralData(r, ics, specData, cfg.FrameLength); err != nil {
 		return err
 	}
 
@@ -168,4 +201,13 @@ type ICStream struct {
 	LTP  LTPInfo  // LTP data (LTP profile, first predictor)
 	LTP2 LTPInfo  // LTP data (LTP profile, second predictor for CPE)
 	Pred PredInfo // MAIN profile prediction data
+	SSR  SSRInfo  // Gain control data (SSR profile)
+
+	// Huffman codeword reordering (ER streams with spectral data
+	// resilience)
+	LengthOfReorderedSpectralData uint16 // Bits of reordered spectral data
+	LengthOfLongestCodeword       uint8  // Longest spectral codeword in bits
+
+	// RVLC scale factors (ER streams with scale factor data resilience)
+	RVLC RVLCInfo
 }
@@ -3,6 +3,7 @@ package syntax
 
 import (
 	"github.com/llehouerou/go-aac/internal/bits"
+	"github.com/llehouerou/go-aac/internal/tables"
 )
 
 // ICSInfoConfig holds configuration needed for ICS info parsing.
@@ -13,17 +14,6 @@ type ICSInfoConfig struct {
 	CommonWindow bool   // True if CPE with common window
 }
 
-// ObjectType constants.
-// Ported from: ~/dev/faad2/libfaad/neaacdec.h:85-100
-const (
-	ObjectTypeMain = 1  // AAC Main
-	ObjectTypeLC   = 2  // AAC Low Complexity
-	ObjectTypeSSR  = 3  // AAC Scalable Sample Rate
-	ObjectTypeLTP  = 4  // AAC Long Term Prediction
-	ObjectTypeSBR  = 5  // Spectral Band Replication
-	ObjectTypeLD   = 23 // AAC Low Delay
-)
-
 // ParseICSInfo parses the ics_info() element from the bitstream.
 // Ported from: ics_info() in ~/dev/faad2/libfaad/syntax.c:829-952
 func ParseICSInfo(r *bits.Reader, ics *ICStream, cfg *ICSInfoConfig) error {
@@ -36,6 +26,12 @@ func ParseICSInfo(r *bits.Reader, ics *ICStream, cfg *ICSInfoConfig) error {
 	// window_sequence (2 bits)
 	ics.WindowSequence = WindowSequence(r.GetBits(2))
 
+	// No block switching in AAC-LD
+	// Ported from: ics_info() LD_DEC check in ~/dev/faad2/libfaad/syntax.c
+	if cfg.ObjectType == tables.ObjectTypeLD && ics.WindowSequence != OnlyLongSequence {
+		return ErrLDWindowSequence
+	}
+
 	// window_shape (1 bit)
 	ics.WindowShape = r.Get1Bit()
 
@@ -60,7 +56,7 @@ func ParseICSInfo(r *bits.Reader, ics *ICStream, cfg *ICSInfoConfig) error {
 		ics.PredictorDataPresent = r.Get1Bit() != 0
 
 		if ics.PredictorDataPresent {
-			if cfg.ObjectType == ObjectTypeMain {
+			if cfg.ObjectType == tables.ObjectTypeMain {
 				// MAIN profile: MPEG-2 style prediction
 				if err := parseMainPrediction(r, ics, cfg.SFIndex); err != nil {
 					return err
@@ -70,6 +66,16 @@ func ParseICSInfo(r *bits.Reader, ics *ICStream, cfg *ICSInfoConfig) error {
 				if err := parseLTPPrediction(r, ics, cfg); err != nil {
 					return err
 				}
+			} else if !cfg.CommonWindow {
+				// ER objects: a single LTP data here; with a common window
+				// the channel pair element carries it
+				// Ported from: ics_info() ERROR_RESIL section in syntax.c
+				ics.LTP.DataPresent = r.Get1Bit() != 0
+				if ics.LTP.DataPresent {
+					if err := ParseLTPData(r, ics, &ics.LTP, cfg.FrameLength, cfg.ObjectType); err != nil {
+						return err
+					}
+				}
 			}
 		}
 	}
@@ -107,7 +113,7 @@ func parseLTPPrediction(r *bits.Reader, ics *ICStream, cfg *ICSInfoConfig) error
 	// First LTP data
 	ics.LTP.DataPresent = r.Get1Bit() != 0
 	if ics.LTP.DataPresent {
-		if err := ParseLTPData(r, ics, &ics.LTP, cfg.FrameLength); err != nil {
+		if err := ParseLTPData(r, ics, &ics.LTP, cfg.FrameLength, cfg.ObjectType); err != nil {
 			return err
 		}
 	}
@@ -116,7 +122,7 @@ func parseLTPPrediction(r *bits.Reader, ics *ICStream, cfg *ICSInfoConfig) error
 	if cfg.CommonWindow {
 		ics.LTP2.DataPresent = r.Get1Bit() != 0
 		if ics.LTP2.DataPresent {
-			if err := ParseLTPData(r, ics, &ics.LTP2, cfg.FrameLength); err != nil {
+			if err := ParseLTPData(r, ics, &ics.LTP2, cfg.FrameLength, cfg.ObjectType); err != nil {
 				return err
 			}
 		}
@@ -138,10 +144,20 @@ func maxPredSFB(sfIndex uint8) uint8 {
 }
 
 // ParseLTPData parses LTP (Long Term Prediction) data from the bitstream.
+// AAC-LD sends the lag only when ltp_lag_update is set, on 10 bits; the
+// decoder keeps the previous lag otherwise.
 // Ported from: ltp_data() in ~/dev/faad2/libfaad/syntax.c:2093-2152
-func ParseLTPData(r *bits.Reader, ics *ICStream, ltp *LTPInfo, frameLength uint16) error {
-	// ltp_lag (11 bits) - note: LD object type uses 10 bits, not handled here
-	ltp.Lag = uint16(r.GetBits(11))
+func ParseLTPData(r *bits.Reader, ics *ICStream, ltp *LTPInfo, frameLength uint16, objectType uint8) error {
+	if objectType == tables.ObjectTypeLD {
+		// ltp_lag_update (1 bit), then ltp_lag (10 bits)
+		ltp.LagUpdate = r.Get1Bit() != 0
+		if ltp.LagUpdate {
+			ltp.Lag = uint16(r.GetBits(10))
+		}
+	} else {
+		// ltp_lag (11 bits)
+		ltp.Lag = uint16(r.GetBits(11))
+	}
 
 	// Validate lag (must not exceed 2 * frameLength)
 	if ltp.Lag > frameLength<<1 {
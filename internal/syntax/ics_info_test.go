@@ -5,6 +5,7 @@ import (
 	"testing"
 
 	"github.com/llehouerou/go-aac/internal/bits"
+	"github.com/llehouerou/go-aac/internal/tables"
 )
 
 func TestParseICSInfo_LongWindow(t *testing.T) {
@@ -62,7 +63,7 @@ func TestParseICSInfo_ShortWindow(t *testing.T) {
 	cfg := &ICSInfoConfig{
 		SFIndex:     4,
 		FrameLength: 1024,
-		ObjectType:  ObjectTypeLC,
+		ObjectType:  tables.ObjectTypeLC,
 	}
 
 	err := ParseICSInfo(r, ics, cfg)
@@ -95,7 +96,7 @@ func TestParseICSInfo_ReservedBitError(t *testing.T) {
 	cfg := &ICSInfoConfig{
 		SFIndex:     4,
 		FrameLength: 1024,
-		ObjectType:  ObjectTypeLC,
+		ObjectType:  tables.ObjectTypeLC,
 	}
 
 	err := ParseICSInfo(r, ics, cfg)
@@ -120,7 +121,7 @@ func TestParseICSInfo_LongWindowWithWindowGroups(t *testing.T) {
 	cfg := &ICSInfoConfig{
 		SFIndex:     4, // 44100 Hz
 		FrameLength: 1024,
-		ObjectType:  ObjectTypeLC,
+		ObjectType:  tables.ObjectTypeLC,
 	}
 
 	err := ParseICSInfo(r, ics, cfg)
@@ -156,7 +157,7 @@ func TestParseICSInfo_ShortWindowMultipleGroups(t *testing.T) {
 	cfg := &ICSInfoConfig{
 		SFIndex:     4,
 		FrameLength: 1024,
-		ObjectType:  ObjectTypeLC,
+		ObjectType:  tables.ObjectTypeLC,
 	}
 
 	err := ParseICSInfo(r, ics, cfg)
@@ -217,7 +218,7 @@ func TestParseICSInfo_MainPrediction(t *testing.T) {
 	cfg := &ICSInfoConfig{
 		SFIndex:     4,
 		FrameLength: 1024,
-		ObjectType:  ObjectTypeMain,
+		ObjectType:  tables.ObjectTypeMain,
 	}
 
 	err := ParseICSInfo(r, ics, cfg)
@@ -277,7 +278,7 @@ func TestParseICSInfo_MainPredictionWithUsedBands(t *testing.T) {
 	cfg := &ICSInfoConfig{
 		SFIndex:     4,
 		FrameLength: 1024,
-		ObjectType:  ObjectTypeMain,
+		ObjectType:  tables.ObjectTypeMain,
 	}
 
 	err := ParseICSInfo(r, ics, cfg)
@@ -335,7 +336,7 @@ func TestParseICSInfo_LTPPrediction(t *testing.T) {
 	cfg := &ICSInfoConfig{
 		SFIndex:     4,
 		FrameLength: 1024,
-		ObjectType:  ObjectTypeLTP, // LTP profile
+		ObjectType:  tables.ObjectTypeLTP, // LTP profile
 	}
 
 	err := ParseICSInfo(r, ics, cfg)
@@ -380,7 +381,7 @@ func TestParseLTPData_LagTooLarge(t *testing.T) {
 	}
 	ltp := &LTPInfo{}
 
-	err := ParseLTPData(r, ics, ltp, 480)
+	err := ParseLTPData(r, ics, ltp, 480, tables.ObjectTypeLTP)
 	if err != ErrLTPLagTooLarge {
 		t.Errorf("expected ErrLTPLagTooLarge, got %v (lag=%d)", err, ltp.Lag)
 	}
@@ -404,7 +405,7 @@ func TestParseLTPData_ValidLag(t *testing.T) {
 	}
 	ltp := &LTPInfo{}
 
-	err := ParseLTPData(r, ics, ltp, 480)
+	err := ParseLTPData(r, ics, ltp, 480, tables.ObjectTypeLTP)
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
@@ -419,3 +420,88 @@ func TestParseLTPData_ValidLag(t *testing.T) {
 		t.Errorf("LastBand: got %d, want 10", ltp.LastBand)
 	}
 }
+
+func TestParseICSInfo_LDRejectsBlockSwitching(t *testing.T) {
+	// ics_reserved_bit: 0, window_sequence: 1 (LONG_START_SEQUENCE)
+	data := []byte{0x20, 0x00}
+	r := bits.NewReader(data)
+
+	ics := &ICStream{}
+	cfg := &ICSInfoConfig{
+		SFIndex:     3,
+		FrameLength: 512,
+		ObjectType:  tables.ObjectTypeLD,
+	}
+
+	if err := ParseICSInfo(r, ics, cfg); err != ErrLDWindowSequence {
+		t.Errorf("expected ErrLDWindowSequence, got %v", err)
+	}
+}
+
+func TestParseICSInfo_LDLTP(t *testing.T) {
+	// ics_reserved_bit: 0, window_sequence: 0, window_shape: 1 (low overlap)
+	// max_sfb: 4 (6 bits) = 0b000100
+	// predictor_data_present: 1, ltp_data_present: 1
+	// ltp_lag_update: 1, ltp_lag: 1000 (10 bits) = 0b1111101000
+	// ltp_coef: 2 (3 bits) = 0b010
+	// ltp_long_used[0..3]: 1010
+	// Bits: 0 00 1 000100 1 1 1 1111101000 010 1010
+	// Byte 0: 0001_0001 = 0x11
+	// Byte 1: 0011_1111 = 0x3F
+	// Byte 2: 1101_0000 = 0xD0
+	// Byte 3: 1010_1000 = 0xA8
+	data := []byte{0x11, 0x3F, 0xD0, 0xA8}
+	r := bits.NewReader(data)
+
+	ics := &ICStream{}
+	cfg := &ICSInfoConfig{
+		SFIndex:     3,
+		FrameLength: 512,
+		ObjectType:  tables.ObjectTypeLD,
+	}
+
+	if err := ParseICSInfo(r, ics, cfg); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !ics.LTP.DataPresent || !ics.LTP.LagUpdate {
+		t.Fatalf("LTP.DataPresent = %v, LTP.LagUpdate = %v, want both set", ics.LTP.DataPresent, ics.LTP.LagUpdate)
+	}
+	if ics.LTP.Lag != 1000 {
+		t.Errorf("LTP.Lag: got %d, want 1000", ics.LTP.Lag)
+	}
+	if ics.LTP.Coef != 2 {
+		t.Errorf("LTP.Coef: got %d, want 2", ics.LTP.Coef)
+	}
+	want := [4]bool{true, false, true, false}
+	for sfb, used := range want {
+		if ics.LTP.LongUsed[sfb] != used {
+			t.Errorf("LTP.LongUsed[%d]: got %v, want %v", sfb, ics.LTP.LongUsed[sfb], used)
+		}
+	}
+	if got := r.GetProcessedBits(); got != 30 {
+		t.Errorf("consumed %d bits, want 30", got)
+	}
+}
+
+func TestParseLTPData_LDKeepsLag(t *testing.T) {
+	// ltp_lag_update: 0, ltp_coef: 7 (3 bits), ltp_long_used[0..1]: 00
+	// Bits: 0 111 00 = 0b0111_0000
+	data := []byte{0x70}
+	r := bits.NewReader(data)
+
+	ics := &ICStream{
+		WindowSequence: OnlyLongSequence,
+		MaxSFB:         2,
+	}
+	ltp := &LTPInfo{Lag: 300}
+
+	if err := ParseLTPData(r, ics, ltp, 512, tables.ObjectTypeLD); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if ltp.LagUpdate || ltp.Lag != 300 {
+		t.Errorf("LagUpdate = %v, Lag = %d, want the previous lag 300 kept", ltp.LagUpdate, ltp.Lag)
+	}
+	if ltp.Coef != 7 {
+		t.Errorf("Coef: got %d, want 7", ltp.Coef)
+	}
+}
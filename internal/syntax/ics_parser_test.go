@@ -5,6 +5,7 @@ import (
 	"testing"
 
 	"github.com/llehouerou/go-aac/internal/bits"
+	"github.com/llehouerou/go-aac/internal/tables"
 )
 
 func TestParseSideInfo_GlobalGain(t *testing.T) {
@@ -39,7 +40,7 @@ func TestParseSideInfo_GlobalGain(t *testing.T) {
 			cfg := &SideInfoConfig{
 				SFIndex:      4, // 44.1 kHz
 				FrameLength:  1024,
-				ObjectType:   ObjectTypeLC,
+				ObjectType:   tables.ObjectTypeLC,
 				CommonWindow: true,
 				ScalFlag:     false,
 			}
@@ -78,7 +79,7 @@ func TestSideInfoConfig_Fields(t *testing.T) {
 	cfg := &SideInfoConfig{
 		SFIndex:      4,
 		FrameLength:  1024,
-		ObjectType:   ObjectTypeLC,
+		ObjectType:   tables.ObjectTypeLC,
 		CommonWindow: true,
 		ScalFlag:     false,
 	}
@@ -89,8 +90,8 @@ func TestSideInfoConfig_Fields(t *testing.T) {
 	if cfg.FrameLength != 1024 {
 		t.Errorf("FrameLength = %d, want 1024", cfg.FrameLength)
 	}
-	if cfg.ObjectType != ObjectTypeLC {
-		t.Errorf("ObjectType = %d, want %d", cfg.ObjectType, ObjectTypeLC)
+	if cfg.ObjectType != tables.ObjectTypeLC {
+		t.Errorf("ObjectType = %d, want %d", cfg.ObjectType, tables.ObjectTypeLC)
 	}
 	if !cfg.CommonWindow {
 		t.Error("CommonWindow should be true")
@@ -105,7 +106,7 @@ func TestICSConfig_Fields(t *testing.T) {
 	cfg := &ICSConfig{
 		SFIndex:      4,
 		FrameLength:  1024,
-		ObjectType:   ObjectTypeLC,
+		ObjectType:   tables.ObjectTypeLC,
 		CommonWindow: false,
 		ScalFlag:     false,
 	}
@@ -116,8 +117,8 @@ func TestICSConfig_Fields(t *testing.T) {
 	if cfg.FrameLength != 1024 {
 		t.Errorf("FrameLength = %d, want 1024", cfg.FrameLength)
 	}
-	if cfg.ObjectType != ObjectTypeLC {
-		t.Errorf("ObjectType = %d, want %d", cfg.ObjectType, ObjectTypeLC)
+	if cfg.ObjectType != tables.ObjectTypeLC {
+		t.Errorf("ObjectType = %d, want %d", cfg.ObjectType, tables.ObjectTypeLC)
 	}
 	if cfg.CommonWindow {
 		t.Error("CommonWindow should be false")
@@ -157,11 +158,11 @@ func TestERObjectStartValue(t *testing.T) {
 		isER          bool
 		tnsInSideInfo bool
 	}{
-		{ObjectTypeLC, false, true},  // LC < 17, parse TNS in side_info
-		{ObjectTypeLTP, false, true}, // LTP < 17, parse TNS in side_info
-		{17, true, false},            // ER_OBJECT_START, parse TNS after side_info
-		{19, true, false},            // ER-AAC-LC, parse TNS after side_info
-		{23, true, false},            // ER-AAC-LD, parse TNS after side_info
+		{tables.ObjectTypeLC, false, true},  // LC < 17, parse TNS in side_info
+		{tables.ObjectTypeLTP, false, true}, // LTP < 17, parse TNS in side_info
+		{17, true, false},                   // ER_OBJECT_START, parse TNS after side_info
+		{19, true, false},                   // ER-AAC-LC, parse TNS after side_info
+		{23, true, false},                   // ER-AAC-LD, parse TNS after side_info
 	}
 
 	for _, tc := range testCases {
@@ -183,7 +184,7 @@ func TestGainControlError(t *testing.T) {
 		t.Error("ErrGainControlNotSupported should not be nil")
 	}
 
-	expectedMsg := "syntax: gain control (SSR) not supported"
+	expectedMsg := "syntax: gain control data outside the SSR object type"
 	if ErrGainControlNotSupported.Error() != expectedMsg {
 		t.Errorf("Error message = %q, want %q", ErrGainControlNotSupported.Error(), expectedMsg)
 	}
@@ -226,6 +226,39 @@ func TestParsePCE_Stereo(t *testing.T) {
 	}
 }
 
+func TestParsePCE_MatrixMixdown(t *testing.T) {
+	// 5.0 PCE: front SCE (C) + CPE (L/R), back CPE (Ls/Rs), with
+	// matrix_mixdown_idx_present=1, matrix_mixdown_idx=2,
+	// pseudo_surround_enable=1:
+	// tag=0000 obj=01 sf=0100 front=0010 side=0000 back=0001 lfe=00
+	// assoc=000 cc=0000 mono=0 stereo=0 matrix=1 idx=10 pseudo=1
+	// front: 0 0000, 1 0000; back: 1 0001; pad=0000; comment_bytes=0
+	data := []byte{0x05, 0x08, 0x04, 0x00, 0x68, 0x21, 0x10, 0x00}
+
+	r := bits.NewReader(data)
+	pce, err := ParsePCE(r)
+	if err != nil {
+		t.Fatalf("ParsePCE failed: %v", err)
+	}
+
+	if !pce.MatrixMixdownIdxPresent {
+		t.Fatal("MatrixMixdownIdxPresent: got false, want true")
+	}
+	if pce.MatrixMixdownIdx != 2 {
+		t.Errorf("MatrixMixdownIdx: got %d, want 2", pce.MatrixMixdownIdx)
+	}
+	if !pce.PseudoSurroundEnable {
+		t.Error("PseudoSurroundEnable: got false, want true")
+	}
+	if pce.Channels != 5 || pce.NumFrontChannels != 3 || pce.NumBackChannels != 2 {
+		t.Errorf("channels: got %d (front %d, back %d), want 5 (3, 2)",
+			pce.Channels, pce.NumFrontChannels, pce.NumBackChannels)
+	}
+	if pce.CommentFieldBytes != 0 {
+		t.Errorf("CommentFieldBytes: got %d, want 0", pce.CommentFieldBytes)
+	}
+}
+
 func TestParsePCE_WithComment(t *testing.T) {
 	// Minimal PCE with no elements and a 5-byte comment "Hello"
 	// element_instance_tag: 4 bits = 0x0
@@ -5,13 +5,14 @@ import (
 	"testing"
 
 	"github.com/llehouerou/go-aac/internal/bits"
+	"github.com/llehouerou/go-aac/internal/tables"
 )
 
 func TestRawDataBlockConfig_Fields(t *testing.T) {
 	cfg := &RawDataBlockConfig{
 		SFIndex:              4, // 44100 Hz
 		FrameLength:          1024,
-		ObjectType:           ObjectTypeLC,
+		ObjectType:           tables.ObjectTypeLC,
 		ChannelConfiguration: 2, // Stereo
 	}
 
@@ -21,8 +22,8 @@ func TestRawDataBlockConfig_Fields(t *testing.T) {
 	if cfg.FrameLength != 1024 {
 		t.Errorf("FrameLength = %d, want 1024", cfg.FrameLength)
 	}
-	if cfg.ObjectType != ObjectTypeLC {
-		t.Errorf("ObjectType = %d, want %d", cfg.ObjectType, ObjectTypeLC)
+	if cfg.ObjectType != tables.ObjectTypeLC {
+		t.Errorf("ObjectType = %d, want %d", cfg.ObjectType, tables.ObjectTypeLC)
 	}
 	if cfg.ChannelConfiguration != 2 {
 		t.Errorf("ChannelConfiguration = %d, want 2", cfg.ChannelConfiguration)
@@ -73,7 +74,7 @@ func TestParseRawDataBlock_EmptyFrame(t *testing.T) {
 	cfg := &RawDataBlockConfig{
 		SFIndex:              4,
 		FrameLength:          1024,
-		ObjectType:           ObjectTypeLC,
+		ObjectType:           tables.ObjectTypeLC,
 		ChannelConfiguration: 2,
 	}
 	drc := &DRCInfo{}
@@ -100,7 +101,7 @@ func TestParseRawDataBlock_SCECount(t *testing.T) {
 	cfg := &RawDataBlockConfig{
 		SFIndex:              4,
 		FrameLength:          1024,
-		ObjectType:           ObjectTypeLC,
+		ObjectType:           tables.ObjectTypeLC,
 		ChannelConfiguration: 1, // Mono
 	}
 
@@ -126,7 +127,7 @@ func TestParseRawDataBlock_CPECount(t *testing.T) {
 	cfg := &RawDataBlockConfig{
 		SFIndex:              4,
 		FrameLength:          1024,
-		ObjectType:           ObjectTypeLC,
+		ObjectType:           tables.ObjectTypeLC,
 		ChannelConfiguration: 2, // Stereo
 	}
 
@@ -161,7 +162,7 @@ func TestParseRawDataBlock_CCECount(t *testing.T) {
 	cfg := &RawDataBlockConfig{
 		SFIndex:              4,
 		FrameLength:          1024,
-		ObjectType:           ObjectTypeLC,
+		ObjectType:           tables.ObjectTypeLC,
 		ChannelConfiguration: 2,
 	}
 
@@ -195,7 +196,7 @@ func TestParseRawDataBlock_DSEOnly(t *testing.T) {
 	cfg := &RawDataBlockConfig{
 		SFIndex:              4,
 		FrameLength:          1024,
-		ObjectType:           ObjectTypeLC,
+		ObjectType:           tables.ObjectTypeLC,
 		ChannelConfiguration: 2,
 	}
 	drc := &DRCInfo{}
@@ -225,7 +226,7 @@ func TestParseRawDataBlock_FILOnly(t *testing.T) {
 	cfg := &RawDataBlockConfig{
 		SFIndex:              4,
 		FrameLength:          1024,
-		ObjectType:           ObjectTypeLC,
+		ObjectType:           tables.ObjectTypeLC,
 		ChannelConfiguration: 2,
 	}
 	drc := &DRCInfo{}
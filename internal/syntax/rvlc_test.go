@@ -0,0 +1,103 @@
+// internal/syntax/rvlc_test.go
+package syntax
+
+import (
+	"testing"
+
+	"github.com/llehouerou/go-aac/internal/bits"
+)
+
+// rvlcICS returns a long window stream with five bands: two spectral, two
+// noise and a spectral one, at a global gain of 100.
+func rvlcICS() *ICStream {
+	ics := &ICStream{
+		GlobalGain:      100,
+		NumWindowGroups: 1,
+		MaxSFB:          5,
+		NoiseUsed:       true,
+	}
+	ics.SFBCB[0] = [8 * 15]uint8{1, 5, 13, 13, 1}
+	return ics
+}
+
+// writeRVLCScaleFactors writes rvlc_scale_factor_data() for rvlcICS(),
+// then the codewords of the scale factors 102, 112, 110 and of the noise
+// energies 15 and 14, with the second codeword, +7, given by cw1 and its
+// 3-bit escape codeword by esc.
+func writeRVLCScaleFactors(w *bitWriter, cw1, esc uint32) {
+	w.writeBits(0, 1)     // sf_concealment
+	w.writeBits(110, 8)   // rev_global_gain
+	w.writeBits(19+9, 9)  // length_of_rvlc_sf, with dpcm_noise_nrg
+	w.writeBits(256+5, 9) // dpcm_noise_nrg: 100 - 90 + 5
+	w.writeBits(1, 1)     // sf_escapes_present
+	w.writeBits(3, 8)     // length_of_rvlc_escapes
+	w.writeBits(256+4, 9) // dpcm_noise_last_position: 100 - 90 + 4
+	w.writeBits(0x1B, 5)  // +2
+	w.writeBits(cw1, 7)   // +7, escaped
+	w.writeBits(0x5, 3)   // noise -1
+	w.writeBits(0x9, 4)   // -2
+	w.writeBits(esc, 3)   // escape 3
+}
+
+func TestDecodeRVLCScaleFactors_Forward(t *testing.T) {
+	w := &bitWriter{}
+	writeRVLCScaleFactors(w, 0x63, 0x2)
+	r := bits.NewReader(append(w.buf, 0, 0, 0, 0))
+
+	ics := rvlcICS()
+	if err := ParseRVLCScaleFactorData(r, ics); err != nil {
+		t.Fatalf("ParseRVLCScaleFactorData: %v", err)
+	}
+	if ics.RVLC.LengthOfRVLCSF != 19 || ics.RVLC.LengthOfRVLCEscapes != 3 {
+		t.Errorf("lengths %d and %d, want 19 and 3", ics.RVLC.LengthOfRVLCSF, ics.RVLC.LengthOfRVLCEscapes)
+	}
+	if err := DecodeRVLCScaleFactors(r, ics); err != nil {
+		t.Fatalf("DecodeRVLCScaleFactors: %v", err)
+	}
+	if got := r.GetProcessedBits(); got != uint32(w.nbit) {
+		t.Errorf("read %d bits, want %d", got, w.nbit)
+	}
+
+	want := []int16{102, 112, 15, 14, 110}
+	for sfb, v := range want {
+		if ics.ScaleFactors[0][sfb] != v {
+			t.Fatalf("scale factors %v, want %v", ics.ScaleFactors[0][:5], want)
+		}
+	}
+}
+
+func TestDecodeRVLCScaleFactors_BackwardRecovery(t *testing.T) {
+	tests := []struct {
+		name    string
+		escapes uint32
+		want    []int16
+	}{
+		// The forward pass stops at the second band, whose values the
+		// backward pass recovers from rev_global_gain and
+		// dpcm_noise_last_position
+		{"backward", 0x2, []int16{102, 112, 15, 14, 110}},
+		// With a truncated escape codeword, the backward pass is not
+		// attempted
+		{"corrupted escapes", 0x7, []int16{102, 0, 0, 0, 0}},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			w := &bitWriter{}
+			writeRVLCScaleFactors(w, 0x60, tc.escapes) // invalid codeword 1100000
+			r := bits.NewReader(append(w.buf, 0, 0, 0, 0))
+
+			ics := rvlcICS()
+			if err := ParseRVLCScaleFactorData(r, ics); err != nil {
+				t.Fatalf("ParseRVLCScaleFactorData: %v", err)
+			}
+			if err := DecodeRVLCScaleFactors(r, ics); err != nil {
+				t.Fatalf("DecodeRVLCScaleFactors: %v", err)
+			}
+			for sfb, v := range tc.want {
+				if ics.ScaleFactors[0][sfb] != v {
+					t.Fatalf("scale factors %v, want %v", ics.ScaleFactors[0][:5], tc.want)
+				}
+			}
+		})
+	}
+}
@@ -71,8 +71,8 @@ func DecodeScaleFactors(r *bits.Reader, ics *ICStream) error {
 }
 
 // ParseScaleFactorData is the wrapper that matches FAAD2's scale_factor_data().
-// It's a simple wrapper around DecodeScaleFactors for now; error-resilient
-// mode (RVLC) is not supported.
+// It's a simple wrapper around DecodeScaleFactors; ER streams with scale
+// factor data resilience use ParseRVLCScaleFactorData instead.
 //
 // Ported from: scale_factor_data() in ~/dev/faad2/libfaad/syntax.c:1988-2016
 func ParseScaleFactorData(r *bits.Reader, ics *ICStream) error {
@@ -205,6 +205,66 @@ func TestDecodeScaleFactors_MixedCodebooks(t *testing.T) {
 	}
 }
 
+func TestDecodeScaleFactors_IndependentRunningValues(t *testing.T) {
+	// Interleaved spectral, noise and intensity bands. Each kind
+	// accumulates its own running value, so a delta in one kind of band
+	// must not move the others.
+	ics := &ICStream{
+		GlobalGain:      100,
+		NumWindowGroups: 1,
+		MaxSFB:          7,
+	}
+	codebooks := []huffman.Codebook{
+		1, huffman.NoiseHCB, huffman.IntensityHCB, 1,
+		huffman.NoiseHCB, huffman.IntensityHCB2, 1,
+	}
+	for sfb, cb := range codebooks {
+		ics.SFBCB[0][sfb] = uint8(cb)
+	}
+
+	// Scale factor Huffman codewords for the deltas used below
+	// (hcb_sf): 0 -> 0, -1 -> 100, +2 -> 1100, -3 -> 11010,
+	// +4 -> 111001, +5 -> 111011. The first noise band is 9-bit PCM.
+	stream := "1100" + // sfb 0 spectral: +2
+		"100000101" + // sfb 1 noise: PCM 261 - 256 = +5
+		"11010" + // sfb 2 intensity: -3
+		"100" + // sfb 3 spectral: -1
+		"111001" + // sfb 4 noise: +4
+		"111011" + // sfb 5 intensity: +5
+		"0" // sfb 6 spectral: 0
+	data := make([]byte, (len(stream)+7)/8+4)
+	for i, c := range stream {
+		if c == '1' {
+			data[i/8] |= 1 << (7 - i%8)
+		}
+	}
+	r := bits.NewReader(data)
+
+	if err := DecodeScaleFactors(r, ics); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := r.GetProcessedBits(); got != uint32(len(stream)) {
+		t.Errorf("bits consumed: got %d, want %d", got, len(stream))
+	}
+
+	// Spectral starts at global_gain, noise at global_gain - 90 and
+	// intensity position at 0
+	want := []int16{
+		102,    // 100 + 2
+		10 + 5, // (100 - 90) + 5
+		-3,     // 0 - 3
+		101,    // 102 - 1
+		15 + 4, // 15 + 4
+		-3 + 5, // -3 + 5
+		101,    // 101 + 0
+	}
+	for sfb, w := range want {
+		if got := ics.ScaleFactors[0][sfb]; got != w {
+			t.Errorf("ScaleFactors[0][%d] (codebook %d): got %d, want %d", sfb, codebooks[sfb], got, w)
+		}
+	}
+}
+
 func TestParseScaleFactorData(t *testing.T) {
 	// Verify the wrapper function works correctly.
 
@@ -6,9 +6,10 @@ import "github.com/llehouerou/go-aac/internal/bits"
 // SCEConfig holds configuration for Single Channel Element parsing.
 // Ported from: single_lfe_channel_element() parameters in ~/dev/faad2/libfaad/syntax.c:652
 type SCEConfig struct {
-	SFIndex     uint8  // Sample rate index (0-11)
-	FrameLength uint16 // Frame length (960 or 1024)
-	ObjectType  uint8  // Audio object type
+	SFIndex     uint8           // Sample rate index (0-11)
+	FrameLength uint16          // Frame length (960 or 1024)
+	ObjectType  uint8           // Audio object type
+	Resilience  ResilienceFlags // Error resilience tools (ER object types)
 }
 
 // SCEResult holds the result of parsing a Single Channel Element.
@@ -55,6 +56,7 @@ func ParseSingleChannelElement(r *bits.Reader, channel uint8, cfg *SCEConfig) (*
 		ObjectType:   cfg.ObjectType,
 		CommonWindow: false,
 		ScalFlag:     false,
+		Resilience:   cfg.Resilience,
 	}
 
 	if err := ParseIndividualChannelStream(r, &result.Element, &result.Element.ICS1, result.SpecData, icsCfg); err != nil {
@@ -74,12 +76,18 @@ func ParseSingleChannelElement(r *bits.Reader, channel uint8, cfg *SCEConfig) (*
 }
 
 // ParseLFEElement parses a Low Frequency Effects (LFE) element.
-// LFE uses the same syntax as SCE, so this is an alias for ParseSingleChannelElement.
+// LFE uses the same syntax as SCE and is parsed by ParseSingleChannelElement,
+// with Element.LFE set.
 //
 // The LFE channel is typically the ".1" in configurations like 5.1 surround.
 // It carries bass frequencies (typically below 120 Hz) for the subwoofer.
 //
 // Ported from: single_lfe_channel_element() in ~/dev/faad2/libfaad/syntax.c:652-696
 func ParseLFEElement(r *bits.Reader, channel uint8, cfg *SCEConfig) (*SCEResult, error) {
-	return ParseSingleChannelElement(r, channel, cfg)
+	res, err := ParseSingleChannelElement(r, channel, cfg)
+	if err != nil {
+		return nil, err
+	}
+	res.Element.LFE = true
+	return res, nil
 }
@@ -5,6 +5,7 @@ import (
 	"testing"
 
 	"github.com/llehouerou/go-aac/internal/bits"
+	"github.com/llehouerou/go-aac/internal/tables"
 )
 
 func TestParseSingleChannelElement_ElementTag(t *testing.T) {
@@ -37,7 +38,7 @@ func TestSCEConfig_Fields(t *testing.T) {
 	cfg := &SCEConfig{
 		SFIndex:     4,
 		FrameLength: 1024,
-		ObjectType:  ObjectTypeLC,
+		ObjectType:  tables.ObjectTypeLC,
 	}
 
 	if cfg.SFIndex != 4 {
@@ -46,8 +47,8 @@ func TestSCEConfig_Fields(t *testing.T) {
 	if cfg.FrameLength != 1024 {
 		t.Errorf("FrameLength = %d, want 1024", cfg.FrameLength)
 	}
-	if cfg.ObjectType != ObjectTypeLC {
-		t.Errorf("ObjectType = %d, want %d", cfg.ObjectType, ObjectTypeLC)
+	if cfg.ObjectType != tables.ObjectTypeLC {
+		t.Errorf("ObjectType = %d, want %d", cfg.ObjectType, tables.ObjectTypeLC)
 	}
 }
 
@@ -74,7 +74,8 @@ var Pow2FracTable = [4]float64{
 // Ported from: iquant() in ~/dev/faad2/libfaad/specrec.c:430-497
 func IQuant(q int16) (float64, error) {
 	if q < 0 {
-		if -q >= IQTableSize {
+		// -q would overflow for math.MinInt16
+		if -int32(q) >= IQTableSize {
 			return 0, ErrIQTableOverflow
 		}
 		return -IQTable[-q], nil
@@ -157,6 +157,8 @@ func TestIQuant(t *testing.T) {
 		{"negative_max", -8191, -165113.4940829452, false},
 		{"overflow_positive", 8192, 0, true},
 		{"overflow_negative", -8192, 0, true},
+		{"overflow_int16_min", -32768, 0, true},
+		{"overflow_int16_max", 32767, 0, true},
 	}
 
 	for _, tc := range tests {
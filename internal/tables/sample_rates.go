@@ -1,7 +1,5 @@
 package tables
 
-import "github.com/llehouerou/go-aac"
-
 // SampleRates maps sample rate index to actual sample rate in Hz.
 // Index 0-11 are valid; indices >= 12 are invalid.
 //
@@ -22,6 +20,59 @@ func GetSampleRate(srIndex uint8) uint32 {
 	return SampleRates[srIndex]
 }
 
+// ExplicitSRIndex is the samplingFrequencyIndex escape value: the sample
+// rate follows in the bitstream as a 24-bit samplingFrequency.
+//
+// Source: ISO/IEC 14496-3 Table 1.16 (samplingFrequencyIndex)
+const ExplicitSRIndex uint8 = 0x0f
+
+// maxExplicitSampleRate is the largest rate a 24-bit samplingFrequency
+// can carry.
+const maxExplicitSampleRate = 1<<24 - 1
+
+// sampleRateTable holds the 13 sample rates defined for
+// samplingFrequencyIndex: those of SampleRates, then 7350 Hz, which FAAD2
+// leaves out. Indices 13 and 14 are reserved.
+//
+// Source: ISO/IEC 14496-3 Table 1.16 (samplingFrequencyIndex)
+var sampleRateTable = [13]uint32{
+	96000, 88200, 64000, 48000, 44100, 32000,
+	24000, 22050, 16000, 12000, 11025, 8000,
+	7350,
+}
+
+// SampleRate returns the sample rate in Hz of a samplingFrequencyIndex
+// and whether the index is valid. For ExplicitSRIndex it returns 0 and
+// true, as the rate is coded explicitly; the reserved indices 13 and 14
+// and anything above 15 are invalid.
+func SampleRate(index uint8) (uint32, bool) {
+	switch {
+	case int(index) < len(sampleRateTable):
+		return sampleRateTable[index], true
+	case index == ExplicitSRIndex:
+		return 0, true
+	default:
+		return 0, false
+	}
+}
+
+// SampleRateIndex returns the samplingFrequencyIndex coding a sample rate
+// of hz and whether hz can be coded. Each of the 13 defined rates has its
+// own index; any other rate that fits 24 bits codes as ExplicitSRIndex
+// followed by the rate. Unlike GetSRIndex it does not round hz to the
+// nearest defined rate.
+func SampleRateIndex(hz uint32) (uint8, bool) {
+	for i, rate := range sampleRateTable {
+		if rate == hz {
+			return uint8(i), true
+		}
+	}
+	if hz == 0 || hz > maxExplicitSampleRate {
+		return 0, false
+	}
+	return ExplicitSRIndex, true
+}
+
 // GetSRIndex returns the sample rate index for a given sample rate.
 // Uses threshold-based matching as defined in the MPEG-4 AAC standard.
 // The thresholds are calculated as geometric means between adjacent rates.
@@ -102,9 +153,25 @@ var tnsSFBMax = [16][4]uint8{
 	{0, 0, 0, 0},
 }
 
+// Audio object types, the definition shared by the internal packages.
+// They mirror aac.ObjectType as plain values, which the internal packages
+// cannot import without a cycle.
+// Source: ~/dev/faad2/libfaad/neaacdec.h
+const (
+	ObjectTypeMain    uint8 = 1
+	ObjectTypeLC      uint8 = 2
+	ObjectTypeSSR     uint8 = 3
+	ObjectTypeLTP     uint8 = 4
+	ObjectTypeHEAAC   uint8 = 5
+	ObjectTypeERLC    uint8 = 17
+	ObjectTypeERLTP   uint8 = 19
+	ObjectTypeLD      uint8 = 23
+	ObjectTypeDRMERLC uint8 = 27
+)
+
 // MaxTNSSFB returns the maximum TNS scalefactor band.
 // Source: ~/dev/faad2/libfaad/common.c:87-121
-func MaxTNSSFB(srIndex uint8, objectType aac.ObjectType, isShort bool) uint8 {
+func MaxTNSSFB(srIndex uint8, objectType uint8, isShort bool) uint8 {
 	if srIndex >= 16 {
 		return 0
 	}
@@ -113,7 +180,7 @@ func MaxTNSSFB(srIndex uint8, objectType aac.ObjectType, isShort bool) uint8 {
 	if isShort {
 		i = 1
 	}
-	if objectType == aac.ObjectTypeSSR {
+	if objectType == ObjectTypeSSR {
 		i += 2
 	}
 
@@ -122,23 +189,23 @@ func MaxTNSSFB(srIndex uint8, objectType aac.ObjectType, isShort bool) uint8 {
 
 // CanDecodeOT returns true if the object type can be decoded.
 // Source: ~/dev/faad2/libfaad/common.c:124-172
-func CanDecodeOT(objectType aac.ObjectType) bool {
+func CanDecodeOT(objectType uint8) bool {
 	switch objectType {
-	case aac.ObjectTypeLC:
+	case ObjectTypeLC:
 		return true
-	case aac.ObjectTypeMain:
+	case ObjectTypeMain:
 		return true
-	case aac.ObjectTypeLTP:
+	case ObjectTypeLTP:
 		return true
-	case aac.ObjectTypeSSR:
+	case ObjectTypeSSR:
 		return false // SSR not supported
-	case aac.ObjectTypeERLC:
+	case ObjectTypeERLC:
 		return true
-	case aac.ObjectTypeERLTP:
+	case ObjectTypeERLTP:
 		return true
-	case aac.ObjectTypeLD:
+	case ObjectTypeLD:
 		return true
-	case aac.ObjectTypeDRMERLC:
+	case ObjectTypeDRMERLC:
 		return true
 	default:
 		return false
@@ -1,10 +1,6 @@
 package tables
 
-import (
-	"testing"
-
-	"github.com/llehouerou/go-aac"
-)
+import "testing"
 
 func TestGetSampleRate(t *testing.T) {
 	// Source: ~/dev/faad2/libfaad/common.c:59-71
@@ -89,6 +85,57 @@ func TestGetSRIndex(t *testing.T) {
 	}
 }
 
+func TestSampleRate(t *testing.T) {
+	// Source: ISO/IEC 14496-3 Table 1.16
+	standard := []uint32{
+		96000, 88200, 64000, 48000, 44100, 32000,
+		24000, 22050, 16000, 12000, 11025, 8000,
+		7350,
+	}
+	for i, want := range standard {
+		got, ok := SampleRate(uint8(i))
+		if !ok || got != want {
+			t.Errorf("SampleRate(%d) = %d, %v, want %d, true", i, got, ok, want)
+		}
+		index, ok := SampleRateIndex(want)
+		if !ok || index != uint8(i) {
+			t.Errorf("SampleRateIndex(%d) = %d, %v, want %d, true", want, index, ok, i)
+		}
+	}
+
+	// Reserved indices
+	for _, index := range []uint8{13, 14, 16, 255} {
+		if got, ok := SampleRate(index); ok || got != 0 {
+			t.Errorf("SampleRate(%d) = %d, %v, want 0, false", index, got, ok)
+		}
+	}
+
+	// The escape index carries no rate of its own
+	if got, ok := SampleRate(ExplicitSRIndex); !ok || got != 0 {
+		t.Errorf("SampleRate(15) = %d, %v, want 0, true", got, ok)
+	}
+}
+
+func TestSampleRateIndex_Explicit(t *testing.T) {
+	tests := []struct {
+		hz    uint32
+		index uint8
+		ok    bool
+	}{
+		{44101, ExplicitSRIndex, true}, // Not rounded like GetSRIndex
+		{1, ExplicitSRIndex, true},
+		{1<<24 - 1, ExplicitSRIndex, true},
+		{1 << 24, 0, false}, // Does not fit samplingFrequency
+		{0, 0, false},
+	}
+	for _, tt := range tests {
+		index, ok := SampleRateIndex(tt.hz)
+		if index != tt.index || ok != tt.ok {
+			t.Errorf("SampleRateIndex(%d) = %d, %v, want %d, %v", tt.hz, index, ok, tt.index, tt.ok)
+		}
+	}
+}
+
 func TestSampleRatesArray(t *testing.T) {
 	// Verify SampleRates array has exactly 12 entries matching FAAD2
 	// Source: ~/dev/faad2/libfaad/common.c:61-65
@@ -124,28 +171,28 @@ func TestMaxTNSSFB(t *testing.T) {
 	// Table columns: [Main/LC long, Main/LC short, SSR long, SSR short]
 	tests := []struct {
 		srIndex    uint8
-		objectType aac.ObjectType
+		objectType uint8
 		isShort    bool
 		expected   uint8
 	}{
 		// 96000 Hz
-		{0, aac.ObjectTypeLC, false, 31},
-		{0, aac.ObjectTypeLC, true, 9},
-		{0, aac.ObjectTypeSSR, false, 28},
-		{0, aac.ObjectTypeSSR, true, 7},
+		{0, ObjectTypeLC, false, 31},
+		{0, ObjectTypeLC, true, 9},
+		{0, ObjectTypeSSR, false, 28},
+		{0, ObjectTypeSSR, true, 7},
 		// 48000 Hz
-		{3, aac.ObjectTypeLC, false, 40},
-		{3, aac.ObjectTypeLC, true, 14},
-		{3, aac.ObjectTypeSSR, false, 26},
-		{3, aac.ObjectTypeSSR, true, 6},
+		{3, ObjectTypeLC, false, 40},
+		{3, ObjectTypeLC, true, 14},
+		{3, ObjectTypeSSR, false, 26},
+		{3, ObjectTypeSSR, true, 6},
 		// 44100 Hz
-		{4, aac.ObjectTypeLC, false, 42},
-		{4, aac.ObjectTypeLC, true, 14},
+		{4, ObjectTypeLC, false, 42},
+		{4, ObjectTypeLC, true, 14},
 		// 8000 Hz
-		{11, aac.ObjectTypeLC, false, 39},
-		{11, aac.ObjectTypeLC, true, 14},
+		{11, ObjectTypeLC, false, 39},
+		{11, ObjectTypeLC, true, 14},
 		// Invalid index returns 0
-		{16, aac.ObjectTypeLC, false, 0},
+		{16, ObjectTypeLC, false, 0},
 	}
 
 	for _, tt := range tests {
@@ -161,18 +208,18 @@ func TestCanDecodeOT(t *testing.T) {
 	// Source: ~/dev/faad2/libfaad/common.c:124-172
 	// Note: We support LC, MAIN, LTP. SSR is not supported.
 	tests := []struct {
-		objectType aac.ObjectType
+		objectType uint8
 		canDecode  bool
 	}{
-		{aac.ObjectTypeLC, true},
-		{aac.ObjectTypeMain, true},
-		{aac.ObjectTypeLTP, true},
-		{aac.ObjectTypeSSR, false},   // Not supported
-		{aac.ObjectTypeHEAAC, false}, // SBR handled separately
-		{aac.ObjectTypeERLC, true},
-		{aac.ObjectTypeERLTP, true},
-		{aac.ObjectTypeLD, true},
-		{aac.ObjectTypeDRMERLC, true},
+		{ObjectTypeLC, true},
+		{ObjectTypeMain, true},
+		{ObjectTypeLTP, true},
+		{ObjectTypeSSR, false},   // Not supported
+		{ObjectTypeHEAAC, false}, // SBR handled separately
+		{ObjectTypeERLC, true},
+		{ObjectTypeERLTP, true},
+		{ObjectTypeLD, true},
+		{ObjectTypeDRMERLC, true},
 		{100, false}, // Unknown type
 	}
 
@@ -8,10 +8,19 @@ import "errors"
 // ErrInvalidSRIndex indicates an invalid sample rate index.
 var ErrInvalidSRIndex = errors.New("tables: invalid sample rate index")
 
+// ErrNoLDBands indicates a sample rate for which AAC-LD defines no scale
+// factor bands.
+var ErrNoLDBands = errors.New("tables: no AAC-LD scale factor bands at this sample rate")
+
 // GetSWBOffset returns the SFB offset table for the given parameters.
-// For long windows (isShort=false), returns SWBOffset1024Window[srIndex].
-// For short windows (isShort=true), returns SWBOffset128Window[srIndex].
-// Returns error if srIndex >= 12.
+// For long windows (isShort=false), returns SWBOffset1024Window[srIndex],
+// or SWBOffset960Window[srIndex] when frameLength is 960.
+// For short windows (isShort=true), returns SWBOffset128Window[srIndex],
+// or SWBOffset120Window[srIndex] when frameLength is 960.
+// The AAC-LD frame lengths 512 and 480 select SWBOffset512Window and
+// SWBOffset480Window; LD has no short windows.
+// Returns error if srIndex >= 12, or ErrNoLDBands for an LD frame length
+// at a rate without LD bands.
 // Source: ~/dev/faad2/libfaad/specrec.c:221-285
 func GetSWBOffset(srIndex uint8, frameLength uint16, isShort bool) ([]uint16, error) {
 	if srIndex >= 12 {
@@ -19,17 +28,35 @@ func GetSWBOffset(srIndex uint8, frameLength uint16, isShort bool) ([]uint16, er
 	}
 
 	if isShort {
+		if frameLength == 960 {
+			return SWBOffset120Window[srIndex], nil
+		}
 		return SWBOffset128Window[srIndex], nil
 	}
 
-	// Long window - only 1024 supported for now (960 for AAC LD would need additional tables)
-	return SWBOffset1024Window[srIndex], nil
+	var offsets []uint16
+	switch frameLength {
+	case 960:
+		return SWBOffset960Window[srIndex], nil
+	case 512:
+		offsets = SWBOffset512Window[srIndex]
+	case 480:
+		offsets = SWBOffset480Window[srIndex]
+	default:
+		return SWBOffset1024Window[srIndex], nil
+	}
+	if offsets == nil {
+		return nil, ErrNoLDBands
+	}
+	return offsets, nil
 }
 
 // GetNumSWB returns the number of scale factor window bands.
-// For long windows: returns NumSWB1024Window or NumSWB960Window based on frameLength.
+// For long windows: returns NumSWB1024Window or NumSWB960Window based on
+// frameLength, or NumSWB512Window and NumSWB480Window for AAC-LD.
 // For short windows: returns NumSWB128Window.
-// Returns error if srIndex >= 12.
+// Returns error if srIndex >= 12, or ErrNoLDBands for an LD frame length
+// at a rate without LD bands.
 // Source: ~/dev/faad2/libfaad/specrec.c:66-89
 func GetNumSWB(srIndex uint8, frameLength uint16, isShort bool) (uint8, error) {
 	if srIndex >= 12 {
@@ -40,9 +67,38 @@ func GetNumSWB(srIndex uint8, frameLength uint16, isShort bool) (uint8, error) {
 		return NumSWB128Window[srIndex], nil
 	}
 
-	if frameLength == 960 {
+	var n uint8
+	switch frameLength {
+	case 960:
 		return NumSWB960Window[srIndex], nil
+	case 512:
+		n = NumSWB512Window[srIndex]
+	case 480:
+		n = NumSWB480Window[srIndex]
+	default:
+		return NumSWB1024Window[srIndex], nil
+	}
+	if n == 0 {
+		return 0, ErrNoLDBands
 	}
+	return n, nil
+}
+
+// eightShortSequence is the window_sequence value of eight short windows.
+// Source: ~/dev/faad2/libfaad/syntax.h (EIGHT_SHORT_SEQUENCE)
+const eightShortSequence uint8 = 2
 
-	return NumSWB1024Window[srIndex], nil
+// SWBOffsets returns the SFB offsets of a frame of frameLen samples (1024,
+// 960, 512 or 480) at srIndex for the window sequence windowSeq, or nil
+// when no bands are defined for them. The offsets of an
+// EIGHT_SHORT_SEQUENCE are those of one short window. The last offset
+// closes the last band, so the frame has len(offsets)-1 bands, the
+// num_swb of FAAD2.
+// Source: ~/dev/faad2/libfaad/specrec.c:221-285
+func SWBOffsets(frameLen uint16, srIndex uint8, windowSeq uint8) []uint16 {
+	offsets, err := GetSWBOffset(srIndex, frameLen, windowSeq == eightShortSequence)
+	if err != nil {
+		return nil
+	}
+	return offsets
 }
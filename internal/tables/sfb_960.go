@@ -0,0 +1,27 @@
+// Package tables contains lookup tables for AAC decoding.
+// This file contains Scalefactor Band (SFB) offset tables for 960-sample frames.
+package tables
+
+// SWBOffset960Window maps sample rate index to the SFB offset table of
+// 960-sample long windows. FAAD2 has no separate table: it walks the 1024
+// one for NumSWB960Window bands and closes the last band at the frame
+// length, which is what these tables hold.
+// Source: ~/dev/faad2/libfaad/specrec.c:312-375
+var SWBOffset960Window = truncateSWBOffsets(SWBOffset1024Window, NumSWB960Window, 960)
+
+// SWBOffset120Window maps sample rate index to the SFB offset table of
+// 120-sample short windows: the 128-sample bands, the last one closed at
+// 120.
+// Source: ~/dev/faad2/libfaad/specrec.c:376-424
+var SWBOffset120Window = truncateSWBOffsets(SWBOffset128Window, NumSWB128Window, 120)
+
+// truncateSWBOffsets returns, for each sample rate index, the first
+// numSWB[i] offsets of offsets[i] followed by end.
+func truncateSWBOffsets(offsets [12][]uint16, numSWB [12]uint8, end uint16) [12][]uint16 {
+	var out [12][]uint16
+	for i := range out {
+		n := numSWB[i]
+		out[i] = append(append(make([]uint16, 0, n+1), offsets[i][:n]...), end)
+	}
+	return out
+}
@@ -0,0 +1,101 @@
+// Package tables contains lookup tables for AAC decoding.
+// This file contains Scalefactor Band (SFB) offset tables for AAC-LD frames.
+// Ported from: ~/dev/faad2/libfaad/specrec.c
+package tables
+
+// NumSWB512Window contains the number of scale factor window bands
+// for 512-sample AAC-LD windows at each sample rate index. AAC-LD only
+// defines bands for 48 kHz down to 22.05 kHz; other rates have none.
+// Source: ~/dev/faad2/libfaad/specrec.c num_swb_512_window
+var NumSWB512Window = [12]uint8{
+	0, 0, 0, 36, 36, 37, 31, 31, 0, 0, 0, 0,
+}
+
+// NumSWB480Window contains the number of scale factor window bands
+// for 480-sample AAC-LD windows at each sample rate index.
+// Source: ~/dev/faad2/libfaad/specrec.c num_swb_480_window
+var NumSWB480Window = [12]uint8{
+	0, 0, 0, 35, 35, 37, 30, 30, 0, 0, 0, 0,
+}
+
+// SWBOffset512_48 contains SFB offsets for 48kHz/44.1kHz at 512 samples.
+// Source: ~/dev/faad2/libfaad/specrec.c swb_offset_512_48
+var SWBOffset512_48 = []uint16{
+	0, 4, 8, 12, 16, 20, 24, 28, 32, 36, 40, 44, 48, 52, 56, 60, 68, 76, 84,
+	92, 100, 112, 124, 136, 148, 164, 184, 208, 236, 268, 300, 332, 364, 396,
+	428, 460, 512,
+}
+
+// SWBOffset512_32 contains SFB offsets for 32kHz at 512 samples.
+// Source: ~/dev/faad2/libfaad/specrec.c swb_offset_512_32
+var SWBOffset512_32 = []uint16{
+	0, 4, 8, 12, 16, 20, 24, 28, 32, 36, 40, 44, 48, 52, 56, 64, 72, 80,
+	88, 96, 108, 120, 132, 144, 160, 176, 192, 212, 236, 260, 288, 320, 352,
+	384, 416, 448, 480, 512,
+}
+
+// SWBOffset512_24 contains SFB offsets for 24kHz/22.05kHz at 512 samples.
+// Source: ~/dev/faad2/libfaad/specrec.c swb_offset_512_24
+var SWBOffset512_24 = []uint16{
+	0, 4, 8, 12, 16, 20, 24, 28, 32, 36, 40, 44, 52, 60, 68, 80, 92, 104,
+	120, 140, 164, 192, 224, 256, 288, 320, 352, 384, 416, 448, 480, 512,
+}
+
+// SWBOffset480_48 contains SFB offsets for 48kHz/44.1kHz at 480 samples.
+// Source: ~/dev/faad2/libfaad/specrec.c swb_offset_480_48
+var SWBOffset480_48 = []uint16{
+	0, 4, 8, 12, 16, 20, 24, 28, 32, 36, 40, 44, 48, 52, 56, 64, 72, 80, 88,
+	96, 108, 120, 132, 144, 156, 172, 188, 212, 240, 272, 304, 336, 368, 400,
+	432, 480,
+}
+
+// SWBOffset480_32 contains SFB offsets for 32kHz at 480 samples.
+// Source: ~/dev/faad2/libfaad/specrec.c swb_offset_480_32
+var SWBOffset480_32 = []uint16{
+	0, 4, 8, 12, 16, 20, 24, 28, 32, 36, 40, 44, 48, 52, 56, 60, 64, 72, 80,
+	88, 96, 104, 112, 124, 136, 148, 164, 180, 200, 224, 256, 288, 320, 352,
+	384, 416, 448, 480,
+}
+
+// SWBOffset480_24 contains SFB offsets for 24kHz/22.05kHz at 480 samples.
+// Source: ~/dev/faad2/libfaad/specrec.c swb_offset_480_24
+var SWBOffset480_24 = []uint16{
+	0, 4, 8, 12, 16, 20, 24, 28, 32, 36, 40, 44, 52, 60, 68, 80, 92, 104, 120,
+	140, 164, 192, 224, 256, 288, 320, 352, 384, 416, 448, 480,
+}
+
+// SWBOffset512Window maps sample rate index to the 512-sample SFB offset
+// table, nil for rates without AAC-LD bands.
+// Source: ~/dev/faad2/libfaad/specrec.c swb_offset_512_window
+var SWBOffset512Window = [12][]uint16{
+	nil,             // 96000
+	nil,             // 88200
+	nil,             // 64000
+	SWBOffset512_48, // 48000
+	SWBOffset512_48, // 44100
+	SWBOffset512_32, // 32000
+	SWBOffset512_24, // 24000
+	SWBOffset512_24, // 22050
+	nil,             // 16000
+	nil,             // 12000
+	nil,             // 11025
+	nil,             // 8000
+}
+
+// SWBOffset480Window maps sample rate index to the 480-sample SFB offset
+// table, nil for rates without AAC-LD bands.
+// Source: ~/dev/faad2/libfaad/specrec.c swb_offset_480_window
+var SWBOffset480Window = [12][]uint16{
+	nil,             // 96000
+	nil,             // 88200
+	nil,             // 64000
+	SWBOffset480_48, // 48000
+	SWBOffset480_48, // 44100
+	SWBOffset480_32, // 32000
+	SWBOffset480_24, // 24000
+	SWBOffset480_24, // 22050
+	nil,             // 16000
+	nil,             // 12000
+	nil,             // 11025
+	nil,             // 8000
+}
@@ -15,6 +15,9 @@ func TestGetSWBOffset(t *testing.T) {
 		{0, 1024, true, 13},   // 96kHz short
 		{11, 1024, false, 41}, // 8kHz long
 		{11, 1024, true, 16},  // 8kHz short
+		{3, 960, false, 50},   // 48kHz long 960
+		{3, 960, true, 15},    // 48kHz short 120
+		{0, 960, false, 41},   // 96kHz long 960
 	}
 
 	for _, tt := range tests {
@@ -30,6 +33,60 @@ func TestGetSWBOffset(t *testing.T) {
 	}
 }
 
+func TestGetSWBOffset960(t *testing.T) {
+	for sr := uint8(0); sr < 12; sr++ {
+		for _, short := range []bool{false, true} {
+			end := uint16(960)
+			if short {
+				end = 120
+			}
+			offsets, err := GetSWBOffset(sr, 960, short)
+			if err != nil {
+				t.Fatalf("GetSWBOffset(%d, 960, %v) error: %v", sr, short, err)
+			}
+			numSWB, _ := GetNumSWB(sr, 960, short)
+			if len(offsets) != int(numSWB)+1 || offsets[numSWB] != end {
+				t.Errorf("GetSWBOffset(%d, 960, %v): %d offsets ending at %d, want %d ending at %d",
+					sr, short, len(offsets), offsets[len(offsets)-1], numSWB+1, end)
+			}
+			for i := 1; i < len(offsets); i++ {
+				if offsets[i] <= offsets[i-1] {
+					t.Errorf("GetSWBOffset(%d, 960, %v): offsets not increasing at %d", sr, short, i)
+					break
+				}
+			}
+		}
+	}
+}
+
+func TestGetSWBOffsetLD(t *testing.T) {
+	for _, frameLength := range []uint16{512, 480} {
+		for sr := uint8(0); sr < 12; sr++ {
+			offsets, err := GetSWBOffset(sr, frameLength, false)
+			numSWB, numErr := GetNumSWB(sr, frameLength, false)
+			if sr < 3 || sr > 7 {
+				if err != ErrNoLDBands || numErr != ErrNoLDBands {
+					t.Errorf("frame %d, sr %d: errors %v/%v, want ErrNoLDBands", frameLength, sr, err, numErr)
+				}
+				continue
+			}
+			if err != nil || numErr != nil {
+				t.Fatalf("frame %d, sr %d: unexpected errors %v/%v", frameLength, sr, err, numErr)
+			}
+			if len(offsets) != int(numSWB)+1 || offsets[numSWB] != frameLength {
+				t.Errorf("frame %d, sr %d: %d offsets ending at %d, want %d ending at %d",
+					frameLength, sr, len(offsets), offsets[len(offsets)-1], numSWB+1, frameLength)
+			}
+			for i := 1; i < len(offsets); i++ {
+				if offsets[i] <= offsets[i-1] {
+					t.Errorf("frame %d, sr %d: offsets not increasing at %d", frameLength, sr, i)
+					break
+				}
+			}
+		}
+	}
+}
+
 func TestGetSWBOffsetInvalidIndex(t *testing.T) {
 	_, err := GetSWBOffset(12, 1024, false)
 	if err == nil {
@@ -76,3 +133,53 @@ func TestGetNumSWBInvalidIndex(t *testing.T) {
 		t.Errorf("GetNumSWB(12, 1024, false) error = %v, want ErrInvalidSRIndex", err)
 	}
 }
+
+func TestSWBOffsets(t *testing.T) {
+	// Source: ~/dev/faad2/libfaad/specrec.c, the 1024 and 128 tables
+	// walked for num_swb_960_window and num_swb_128_window bands
+	tests := []struct {
+		frameLen  uint16
+		srIndex   uint8
+		windowSeq uint8
+		numSWB    int
+		known     map[int]uint16 // Band index -> offset
+	}{
+		{960, 3, 0, 49, map[int]uint16{10: 40, 30: 352, 48: 928, 49: 960}}, // 48 kHz long
+		{960, 0, 1, 40, map[int]uint16{15: 64, 39: 896, 40: 960}},          // 96 kHz long start
+		{960, 11, 3, 40, map[int]uint16{40: 960}},                          // 8 kHz long stop
+		{960, 3, 2, 14, map[int]uint16{6: 28, 13: 112, 14: 120}},           // 48 kHz short
+		{960, 0, 2, 12, map[int]uint16{11: 92, 12: 120}},                   // 96 kHz short
+		{960, 8, 2, 15, map[int]uint16{15: 120}},                           // 16 kHz short
+		{1024, 3, 0, 49, map[int]uint16{48: 928, 49: 1024}},                // 48 kHz long
+		{1024, 3, 2, 14, map[int]uint16{13: 112, 14: 128}},                 // 48 kHz short
+		{512, 3, 0, int(NumSWB512Window[3]), map[int]uint16{0: 0}},         // LD
+		{480, 5, 0, int(NumSWB480Window[5]), map[int]uint16{0: 0}},         // LD
+	}
+
+	for _, tt := range tests {
+		offsets := SWBOffsets(tt.frameLen, tt.srIndex, tt.windowSeq)
+		if len(offsets) != tt.numSWB+1 {
+			t.Errorf("SWBOffsets(%d, %d, %d): %d offsets, want %d",
+				tt.frameLen, tt.srIndex, tt.windowSeq, len(offsets), tt.numSWB+1)
+			continue
+		}
+		for band, want := range tt.known {
+			if offsets[band] != want {
+				t.Errorf("SWBOffsets(%d, %d, %d)[%d] = %d, want %d",
+					tt.frameLen, tt.srIndex, tt.windowSeq, band, offsets[band], want)
+			}
+		}
+		short := tt.windowSeq == eightShortSequence
+		if numSWB, _ := GetNumSWB(tt.srIndex, tt.frameLen, short); int(numSWB) != tt.numSWB {
+			t.Errorf("GetNumSWB(%d, %d, %v) = %d, want %d", tt.srIndex, tt.frameLen, short, numSWB, tt.numSWB)
+		}
+	}
+
+	// No bands: invalid index, or an LD rate without LD tables
+	if got := SWBOffsets(960, 12, 0); got != nil {
+		t.Errorf("SWBOffsets(960, 12, 0) = %v, want nil", got)
+	}
+	if got := SWBOffsets(512, 0, 0); got != nil {
+		t.Errorf("SWBOffsets(512, 0, 0) = %v, want nil", got)
+	}
+}
@@ -0,0 +1,105 @@
+// latm.go
+package aac
+
+import (
+	"bytes"
+	"errors"
+
+	"github.com/llehouerou/go-aac/internal/latm"
+)
+
+// initFromLOAS initializes the decoder from the StreamMuxConfig carried
+// in the first LOAS frame of data. As with ADTS, the frame is not
+// consumed: Decode parses it again.
+//
+// Ported from: NeAACDecInit() LATM handling in ~/dev/faad2/libfaad/decoder.c
+func (d *Decoder) initFromLOAS(data []byte) (InitResult, error) {
+	d.latm = latm.NewDemuxer()
+	if _, _, err := d.latm.ParseLOAS(data); err != nil {
+		return InitResult{}, latmError(err)
+	}
+	return d.initFromLATMConfig(true)
+}
+
+// InitLATM initializes the decoder for a stream of AudioMuxElements
+// without in-band configuration, as sent by RTP (RFC 6416 MP4A-LATM with
+// cpresent=0). streamMuxConfig is the StreamMuxConfig signalled out of
+// band, the SDP config parameter. Decode then takes one AudioMuxElement
+// per call.
+//
+// Streams in LOAS framing, as found in MPEG-TS, carry their configuration
+// in band and are initialized by Init instead.
+func (d *Decoder) InitLATM(streamMuxConfig []byte) (InitResult, error) {
+	if d == nil {
+		return InitResult{}, ErrNilDecoder
+	}
+	if streamMuxConfig == nil {
+		return InitResult{}, ErrNilBuffer
+	}
+
+	c, err := latm.ParseStreamMuxConfig(streamMuxConfig)
+	if err != nil {
+		return InitResult{}, latmError(err)
+	}
+	d.latm = latm.NewDemuxer()
+	d.latm.SetConfig(c)
+	return d.initFromLATMConfig(false)
+}
+
+// initFromLATMConfig configures the decoder from the AudioSpecificConfig
+// of the demuxer's StreamMuxConfig.
+func (d *Decoder) initFromLATMConfig(muxConfigPresent bool) (InitResult, error) {
+	asc := d.latm.Config().ASC
+	result, err := d.Init2(asc)
+	if err != nil {
+		return InitResult{}, err
+	}
+	d.latmHeaderPresent = true
+	d.latmMuxConfigPresent = muxConfigPresent
+	d.latmASC = asc
+	return result, nil
+}
+
+// latmPayload extracts the access unit of the LATM frame at the start of
+// buffer and returns it with the size of the frame in bytes. A frame
+// carrying a different AudioSpecificConfig reconfigures the decoder.
+func (d *Decoder) latmPayload(buffer []byte) ([]byte, uint32, error) {
+	var payloads [][]byte
+	var size int
+	var err error
+	if d.latmMuxConfigPresent {
+		payloads, size, err = d.latm.ParseLOAS(buffer)
+	} else {
+		payloads, size, err = d.latm.ParseAudioMuxElement(buffer, false)
+	}
+	if err != nil {
+		return nil, 0, latmError(err)
+	}
+	if len(payloads) != 1 {
+		// Several access units per AudioMuxElement would need several
+		// frames of output per call
+		return nil, 0, ErrLATMNotSupported
+	}
+
+	if !bytes.Equal(d.latm.Config().ASC, d.latmASC) {
+		if _, err := d.initFromLATMConfig(d.latmMuxConfigPresent); err != nil {
+			return nil, 0, err
+		}
+		d.ensureFilterBank()
+	}
+	return payloads[0], uint32(size), nil
+}
+
+// latmError maps the demuxer errors to decoder error codes.
+func latmError(err error) error {
+	switch {
+	case errors.Is(err, latm.ErrSyncNotFound):
+		return ErrLOASSyncwordNotFound
+	case errors.Is(err, latm.ErrNoConfig):
+		return ErrLATMConfigMissing
+	case errors.Is(err, latm.ErrUnsupported):
+		return ErrLATMNotSupported
+	default:
+		return ErrInputBufferTooSmall
+	}
+}
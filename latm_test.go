@@ -0,0 +1,192 @@
+// latm_test.go
+package aac
+
+import (
+	"errors"
+	"os"
+	"slices"
+	"testing"
+)
+
+// adtsPayloads splits an ADTS stream into its raw_data_blocks.
+func adtsPayloads(t *testing.T, data []byte) [][]byte {
+	t.Helper()
+	var payloads [][]byte
+	for pos := 0; pos+7 <= len(data); {
+		if data[pos] != 0xFF || data[pos+1]&0xF6 != 0xF0 {
+			t.Fatalf("no ADTS syncword at %d", pos)
+		}
+		size := int(data[pos+3]&3)<<11 | int(data[pos+4])<<3 | int(data[pos+5])>>5
+		header := 7
+		if data[pos+1]&1 == 0 {
+			header = 9
+		}
+		payloads = append(payloads, data[pos+header:pos+size])
+		pos += size
+	}
+	return payloads
+}
+
+// writeLATMPayload writes a StreamMuxConfig for asc if it is non-nil,
+// then the PayloadLengthInfo() and PayloadMux() of payload.
+func writeLATMPayload(w *adifBitWriter, asc, payload []byte) {
+	if asc != nil {
+		w.writeBits(0, 1) // audioMuxVersion
+		w.writeBits(1, 1) // allStreamsSameTimeFraming
+		w.writeBits(0, 6) // numSubFrames
+		w.writeBits(0, 4) // numProgram
+		w.writeBits(0, 3) // numLayer
+		for _, b := range asc {
+			w.writeBits(uint32(b), 8)
+		}
+		w.writeBits(0, 3)    // frameLengthType
+		w.writeBits(0xFF, 8) // latmBufferFullness
+		w.writeBits(0, 1)    // otherDataPresent
+		w.writeBits(0, 1)    // crcCheckPresent
+	}
+	n := len(payload)
+	for ; n >= 255; n -= 255 {
+		w.writeBits(255, 8)
+	}
+	w.writeBits(uint32(n), 8)
+	for _, b := range payload {
+		w.writeBits(uint32(b), 8)
+	}
+	w.byteAlign()
+}
+
+// loasStream remuxes payloads into LOAS frames, repeating the
+// StreamMuxConfig every configEvery frames.
+func loasStream(asc []byte, payloads [][]byte, configEvery int) []byte {
+	var out []byte
+	for i, p := range payloads {
+		w := &adifBitWriter{}
+		if i%configEvery == 0 {
+			w.writeBits(0, 1) // useSameStreamMux
+			writeLATMPayload(w, asc, p)
+		} else {
+			w.writeBits(1, 1)
+			writeLATMPayload(w, nil, p)
+		}
+		out = append(out, 0x56, 0xE0|byte(len(w.buf)>>8), byte(len(w.buf)))
+		out = append(out, w.buf...)
+	}
+	return out
+}
+
+// sine1kASC is the AudioSpecificConfig of testdata/sine1k.aac: mono AAC
+// LC at 44100 Hz.
+var sine1kASC = []byte{0x12, 0x08}
+
+// decodeAll decodes frames from data until it is consumed, returning
+// the samples and the header type of each frame.
+func decodeAll(t *testing.T, d *Decoder, data []byte) ([][]int16, []HeaderType) {
+	t.Helper()
+	var frames [][]int16
+	var types []HeaderType
+	for pos := 0; pos < len(data); {
+		samples, info, err := d.Decode(data[pos:])
+		if err != nil {
+			t.Fatalf("frame %d: Decode: %v", len(frames), err)
+		}
+		pos += int(info.BytesConsumed)
+		s, _ := samples.([]int16)
+		frames = append(frames, s)
+		types = append(types, info.HeaderType)
+	}
+	return frames, types
+}
+
+func TestDecode_LOAS(t *testing.T) {
+	data, err := os.ReadFile("testdata/sine1k.aac")
+	if err != nil {
+		t.Skipf("sine1k.aac not available: %v", err)
+	}
+	ref := NewDecoder()
+	if _, err := ref.Init(data); err != nil {
+		t.Fatalf("Init ADTS: %v", err)
+	}
+	want, _ := decodeAll(t, ref, data)
+	payloads := adtsPayloads(t, data)
+
+	for _, every := range []int{1, 8} {
+		loas := loasStream(sine1kASC, payloads, every)
+		d := NewDecoder()
+		result, err := d.Init(loas)
+		if err != nil {
+			t.Fatalf("config every %d: Init: %v", every, err)
+		}
+		if result.SampleRate != 44100 || result.Channels != 1 || result.BytesRead != 0 {
+			t.Errorf("config every %d: Init = %+v", every, result)
+		}
+
+		got, types := decodeAll(t, d, loas)
+		if len(got) != len(want) {
+			t.Fatalf("config every %d: %d frames, want %d", every, len(got), len(want))
+		}
+		for i := range want {
+			if types[i] != HeaderTypeLATM {
+				t.Fatalf("frame %d: HeaderType %v, want LATM", i, types[i])
+			}
+			if !slices.Equal(got[i], want[i]) {
+				t.Fatalf("config every %d: frame %d differs from the ADTS decode", every, i)
+			}
+		}
+		if d.Capabilities().HeaderType != HeaderTypeLATM {
+			t.Errorf("Capabilities().HeaderType = %v, want LATM", d.Capabilities().HeaderType)
+		}
+	}
+}
+
+func TestInitLATM_OutOfBandConfig(t *testing.T) {
+	data, err := os.ReadFile("testdata/sine1k.aac")
+	if err != nil {
+		t.Skipf("sine1k.aac not available: %v", err)
+	}
+	ref := NewDecoder()
+	if _, err := ref.Init(data); err != nil {
+		t.Fatalf("Init ADTS: %v", err)
+	}
+	want, _ := decodeAll(t, ref, data)
+
+	// The StreamMuxConfig as signalled in SDP: the config alone
+	cfg := &adifBitWriter{}
+	writeLATMPayload(cfg, sine1kASC, nil)
+	config := cfg.buf[:len(cfg.buf)-1] // drop the empty payload length
+
+	d := NewDecoder()
+	if _, err := d.InitLATM(config); err != nil {
+		t.Fatalf("InitLATM: %v", err)
+	}
+	for i, p := range adtsPayloads(t, data)[:16] {
+		w := &adifBitWriter{}
+		writeLATMPayload(w, nil, p)
+		samples, info, err := d.Decode(w.buf)
+		if err != nil {
+			t.Fatalf("frame %d: Decode: %v", i, err)
+		}
+		if info.BytesConsumed != uint32(len(w.buf)) {
+			t.Errorf("frame %d: BytesConsumed %d, want %d", i, info.BytesConsumed, len(w.buf))
+		}
+		s, _ := samples.([]int16)
+		if !slices.Equal(s, want[i]) {
+			t.Fatalf("frame %d differs from the ADTS decode", i)
+		}
+	}
+}
+
+func TestDecode_LOASErrors(t *testing.T) {
+	d := NewDecoder()
+	// useSameStreamMux set in the very first frame
+	if _, err := d.Init([]byte{0x56, 0xE0, 0x02, 0x80, 0x00}); !errors.Is(err, ErrLATMConfigMissing) {
+		t.Errorf("Init without config: got %v, want ErrLATMConfigMissing", err)
+	}
+
+	loas := loasStream(sine1kASC, [][]byte{{0x01, 0x18, 0x20, 0x07}}, 1)
+	if _, err := d.Init(loas); err != nil {
+		t.Fatalf("Init: %v", err)
+	}
+	if _, _, err := d.Decode([]byte{0xFF, 0xF1, 0x50, 0x80, 0x00}); !errors.Is(err, ErrLOASSyncwordNotFound) {
+		t.Errorf("ADTS frame on a LOAS stream: got %v, want ErrLOASSyncwordNotFound", err)
+	}
+}
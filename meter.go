@@ -0,0 +1,128 @@
+// meter.go
+package aac
+
+import "math"
+
+// Meter holds the level measurements of one frame of output
+// (FrameInfo.Meter). Levels are relative to full scale, 1.0 being the
+// largest magnitude of the output format.
+type Meter struct {
+	// Peak is the largest absolute sample of any channel. Integer output
+	// is clipped, so only float output exceeds 1.0.
+	Peak float64
+
+	// Clipped counts the samples at the limits of the integer output
+	// format, where clipping holds them, or at or beyond full scale for
+	// float output.
+	Clipped uint32
+
+	// RMS is the root mean square level of each output channel.
+	RMS []float64
+}
+
+// meterScale describes the range of the output format: full is full scale
+// and samples at or beyond lo and hi count as clipped.
+type meterScale struct {
+	full, lo, hi float64
+}
+
+// meterScale returns the range of the configured output format, as
+// clipped by the PCM conversion.
+func (d *Decoder) meterScale() meterScale {
+	switch d.config.OutputFormat {
+	case OutputFormat24Bit:
+		return meterScale{1 << 23, -(1 << 23), 1<<23 - 1}
+	case OutputFormat32Bit:
+		if depth := d.config.SourceBitDepth; depth != 0 {
+			// Left-justified: the largest value of depth bits, shifted up
+			depth = min(max(depth, 8), 32)
+			hi := float64((int64(1)<<(depth-1) - 1) << (32 - depth))
+			return meterScale{1 << 31, -(1 << 31), hi}
+		}
+		return meterScale{1 << 31, -(1 << 31), 1<<31 - 1}
+	case OutputFormatFloat, OutputFormatDouble:
+		return meterScale{1, -1, 1}
+	default:
+		return meterScale{1 << 15, -(1 << 15), 1<<15 - 1}
+	}
+}
+
+// reportMeter measures the samples info reports of the frame's output into
+// info.Meter when Config.Metering is set. Without output, such as for
+// the muted first frame, the Meter holds no channels.
+func (d *Decoder) reportMeter(samples any, info *FrameInfo) {
+	if !d.config.Metering {
+		return
+	}
+	channels := int(info.Channels)
+	n := int(info.Samples)
+	sc := d.meterScale()
+	m := &Meter{}
+	switch s := samples.(type) {
+	case []int16:
+		m = meterPCM(s[:min(n, len(s))], channels, sc)
+	case []int32:
+		m = meterPCM(s[:min(n, len(s))], channels, sc)
+	case []float32:
+		m = meterPCM(s[:min(n, len(s))], channels, sc)
+	case []float64:
+		m = meterPCM(s[:min(n, len(s))], channels, sc)
+	case [][]int16:
+		m = meterPlanarPCM(s, n/max(channels, 1), sc)
+	case [][]int32:
+		m = meterPlanarPCM(s, n/max(channels, 1), sc)
+	case [][]float32:
+		m = meterPlanarPCM(s, n/max(channels, 1), sc)
+	case [][]float64:
+		m = meterPlanarPCM(s, n/max(channels, 1), sc)
+	}
+	info.Meter = m
+}
+
+// meterPCM measures interleaved output of the given channel count.
+func meterPCM[T pcmSample](s []T, channels int, sc meterScale) *Meter {
+	if channels == 0 || len(s) == 0 {
+		return &Meter{}
+	}
+	m := &Meter{RMS: make([]float64, channels)}
+	for i, v := range s {
+		m.add(i%channels, float64(v), sc)
+	}
+	m.finish(len(s)/channels, sc)
+	return m
+}
+
+// meterPlanarPCM measures the first n samples of each channel of planar
+// output.
+func meterPlanarPCM[T pcmSample](s [][]T, n int, sc meterScale) *Meter {
+	if n == 0 {
+		return &Meter{}
+	}
+	m := &Meter{RMS: make([]float64, len(s))}
+	for ch, samples := range s {
+		for _, v := range samples[:min(n, len(samples))] {
+			m.add(ch, float64(v), sc)
+		}
+	}
+	m.finish(n, sc)
+	return m
+}
+
+// add accumulates sample x of channel ch, summing squares in RMS until
+// finish.
+func (m *Meter) add(ch int, x float64, sc meterScale) {
+	if x <= sc.lo || x >= sc.hi {
+		m.Clipped++
+	}
+	m.Peak = max(m.Peak, math.Abs(x))
+	m.RMS[ch] += x * x
+}
+
+// finish turns the accumulated sums of n samples per channel into levels
+// relative to full scale.
+func (m *Meter) finish(n int, sc meterScale) {
+	m.Peak /= sc.full
+	for ch, sum := range m.RMS {
+		m.RMS[ch] = math.Sqrt(sum/float64(n)) / sc.full
+	}
+}
@@ -0,0 +1,111 @@
+// meter_test.go
+package aac
+
+import (
+	"math"
+	"os"
+	"testing"
+)
+
+// TestReportMeter_ClippedInput converts a frame of over-range samples to
+// each output format and checks the clip counts and levels reported.
+func TestReportMeter_ClippedInput(t *testing.T) {
+	// Two channels of four samples in the 16-bit scale of the filter
+	// bank output: the left channel is over range three times, the right
+	// one never
+	sources := [][]float32{
+		{40000, -40000, 32768, 1000},
+		{16384, -16384, 16384, -16384},
+	}
+	tests := []struct {
+		format  OutputFormat
+		clipped uint32
+		peak    float64
+	}{
+		{OutputFormat16Bit, 3, 1},
+		{OutputFormat24Bit, 3, 1},
+		{OutputFormat32Bit, 3, 1},
+		{OutputFormatFloat, 3, 40000.0 / 32768},
+		{OutputFormatDouble, 3, 40000.0 / 32768},
+	}
+	for _, tt := range tests {
+		d := NewDecoder()
+		d.config.OutputFormat = tt.format
+		d.config.Metering = true
+		samples := convertPCM(sources, 4, &d.config, nil, nil)
+		info := &FrameInfo{Channels: 2, Samples: 8}
+		d.reportMeter(samples, info)
+
+		m := info.Meter
+		if m == nil {
+			t.Fatalf("format %d: no Meter", tt.format)
+		}
+		if m.Clipped != tt.clipped {
+			t.Errorf("format %d: Clipped = %d, want %d", tt.format, m.Clipped, tt.clipped)
+		}
+		if math.Abs(m.Peak-tt.peak) > 1e-4 {
+			t.Errorf("format %d: Peak = %v, want %v", tt.format, m.Peak, tt.peak)
+		}
+		if len(m.RMS) != 2 || math.Abs(m.RMS[1]-0.5) > 1e-4 {
+			t.Errorf("format %d: RMS = %v, want 0.5 for the right channel", tt.format, m.RMS)
+		}
+	}
+
+	// Planar output is measured per channel in the same way
+	d := NewDecoder()
+	d.config.Metering = true
+	info := &FrameInfo{Channels: 2, Samples: 8}
+	d.reportMeter(planarPCM(convertPCM(sources, 4, &d.config, nil, nil), 2), info)
+	if info.Meter.Clipped != 3 || math.Abs(info.Meter.RMS[1]-0.5) > 1e-4 {
+		t.Errorf("planar: Meter = %+v", info.Meter)
+	}
+
+	// Left-justified 24-bit samples in 32-bit output clip at the 24-bit
+	// limits
+	d.config.OutputFormat = OutputFormat32Bit
+	d.config.SourceBitDepth = 24
+	info = &FrameInfo{Channels: 2, Samples: 8}
+	d.reportMeter(convertPCM(sources, 4, &d.config, nil, nil), info)
+	if info.Meter.Clipped != 3 {
+		t.Errorf("SourceBitDepth 24: Clipped = %d, want 3", info.Meter.Clipped)
+	}
+}
+
+func TestDecode_Metering(t *testing.T) {
+	data, err := os.ReadFile("testdata/sine1k.aac")
+	if err != nil {
+		t.Skipf("sine1k.aac not available: %v", err)
+	}
+	for _, metering := range []bool{false, true} {
+		d := NewDecoder()
+		cfg := d.Config()
+		cfg.Metering = metering
+		d.SetConfiguration(cfg)
+		if _, err := d.Init(data); err != nil {
+			t.Fatalf("Init: %v", err)
+		}
+		for pos, frame := 0, 0; pos < len(data); frame++ {
+			_, info, err := d.Decode(data[pos:])
+			if err != nil {
+				t.Fatalf("frame %d: %v", frame, err)
+			}
+			pos += int(info.BytesConsumed)
+
+			m := info.Meter
+			switch {
+			case !metering:
+				if m != nil {
+					t.Fatalf("frame %d: Meter without Metering", frame)
+				}
+			case frame == 0:
+				// Muted first frame
+				if m == nil || m.Peak != 0 || m.RMS != nil {
+					t.Errorf("frame 0: Meter = %+v, want empty", m)
+				}
+			case m == nil || len(m.RMS) != 1 || m.Peak <= 0 || m.Peak >= 1 ||
+				m.RMS[0] <= 0 || m.RMS[0] > m.Peak || m.Clipped != 0:
+				t.Errorf("frame %d: Meter = %+v", frame, m)
+			}
+		}
+	}
+}
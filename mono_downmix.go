@@ -0,0 +1,26 @@
+// mono_downmix.go
+package aac
+
+import "github.com/llehouerou/go-aac/internal/output"
+
+// monoMixdown reports whether the frame's channels are mixed down to the
+// single output channel of Config.DownmixMono.
+func (d *Decoder) monoMixdown() bool {
+	return d.config.DownmixMono && d.config.ForceChannels == 0 && d.frChannels > 1
+}
+
+// monoMix mixes the decoded channels down to one channel with
+// output.DownmixToMono: stereo at -3 dB, and 3.0 and 5.x as the -3 dB fold
+// of the DownMatrix stereo mix. It returns nil, silence, while one of the
+// mixed channels is missing.
+func (d *Decoder) monoMix() []float32 {
+	input := d.timeOut[:d.frChannels]
+	channelMap := make([]uint8, len(input))
+	for ch := range input {
+		if input[ch] == nil && ch < 5 {
+			return nil
+		}
+		channelMap[ch] = uint8(ch)
+	}
+	return output.DownmixToMono(input, channelMap, d.frChannels, d.frameLength)
+}
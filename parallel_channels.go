@@ -0,0 +1,106 @@
+// parallel_channels.go
+package aac
+
+import "runtime"
+
+// parallelReconstructor is implemented by element decoders that
+// reconstruct the spectra of several channel elements concurrently, for
+// Config.ParallelChannels. quant, spec and windowShapePrev hold one entry
+// per channel, in element order.
+type parallelReconstructor interface {
+	ReconstructElements(elements []any, quant [][]int16, spec [][]float32, windowShapePrev []uint8, workers int) error
+}
+
+// deferredElement is an SCE, LFE (sce) or CPE (cpe) parsed with
+// Config.ParallelChannels, waiting for the channel elements after it.
+type deferredElement struct {
+	sce *sceParseResult
+	cpe *cpeParseResult
+}
+
+// deferReconstruction reports whether channel elements are collected for
+// reconstructDeferred instead of being reconstructed as they are parsed.
+func (d *Decoder) deferReconstruction() bool {
+	if !d.config.ParallelChannels {
+		return false
+	}
+	_, ok := d.elements.(parallelReconstructor)
+	return ok
+}
+
+// reconstructDeferred reconstructs the spectra of the deferred channel
+// elements concurrently, one worker per CPU, then takes them through the
+// filter bank in bitstream order. parseRawDataBlock calls it before any
+// element other than an SCE, CPE or LFE, as coupling channels and DRC
+// data apply to the channels that follow them.
+func (d *Decoder) reconstructDeferred() error {
+	if len(d.deferred) == 0 {
+		return nil
+	}
+	defer d.discardDeferred()
+
+	var elements []any
+	var quant [][]int16
+	var shapes []uint8
+	channels := uint8(0)
+	for _, e := range d.deferred {
+		if e.cpe != nil {
+			elements = append(elements, e.cpe.element)
+			quant = append(quant, e.cpe.SpecData1, e.cpe.SpecData2)
+			shapes = append(shapes, d.windowShapePrev[e.cpe.Channel1], d.windowShapePrev[e.cpe.Channel2])
+			channels = max(channels, e.cpe.Channel2+1)
+			continue
+		}
+		elements = append(elements, e.sce.element)
+		quant = append(quant, e.sce.SpecData)
+		shapes = append(shapes, d.windowShapePrev[e.sce.Channel])
+		channels = max(channels, e.sce.Channel+1)
+	}
+	if err := d.allocateChannelBuffers(channels); err != nil {
+		return err
+	}
+
+	for len(d.parallelSpec) < len(quant) {
+		d.parallelSpec = append(d.parallelSpec, nil)
+	}
+	spec := d.parallelSpec[:len(quant)]
+	for i := range spec {
+		if len(spec[i]) != int(d.frameLength) {
+			spec[i] = make([]float32, d.frameLength)
+		}
+	}
+	dec := d.elements.(parallelReconstructor)
+	if err := dec.ReconstructElements(elements, quant, spec, shapes, runtime.GOMAXPROCS(0)); err != nil {
+		return err
+	}
+
+	i := 0
+	for _, e := range d.deferred {
+		if e.cpe != nil {
+			if err := d.finishCPE(e.cpe, e.cpe.Channel1, spec[i], spec[i+1]); err != nil {
+				return err
+			}
+			i += 2
+			continue
+		}
+		if err := d.finishSCE(e.sce, e.sce.Channel, spec[i]); err != nil {
+			return err
+		}
+		i++
+	}
+	return nil
+}
+
+// discardDeferred returns the quantized coefficients of the deferred
+// elements to the pool and forgets them.
+func (d *Decoder) discardDeferred() {
+	for _, e := range d.deferred {
+		if e.cpe != nil {
+			d.putInt16(e.cpe.SpecData1)
+			d.putInt16(e.cpe.SpecData2)
+			continue
+		}
+		d.putInt16(e.sce.SpecData)
+	}
+	d.deferred = d.deferred[:0]
+}
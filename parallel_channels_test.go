@@ -0,0 +1,110 @@
+// parallel_channels_test.go
+package aac_test
+
+import (
+	"os"
+	"runtime"
+	"slices"
+	"testing"
+
+	"github.com/llehouerou/go-aac"
+)
+
+// surroundStream returns the 5.1 rewrite of sine1k.aac written by
+// writeSurround.
+func surroundStream(tb testing.TB) []byte {
+	tb.Helper()
+	data, err := os.ReadFile("testdata/sine1k.aac")
+	if err != nil {
+		tb.Skipf("sine1k.aac not available: %v", err)
+	}
+	return remuxMono(tb, data, 6, writeSurround)
+}
+
+// decodeSurround decodes every frame of stream with cfg.
+func decodeSurround(t *testing.T, stream []byte, cfg aac.Config) [][]int16 {
+	t.Helper()
+	d := aac.NewDecoder()
+	d.SetConfiguration(cfg)
+	if _, err := d.Init(stream); err != nil {
+		t.Fatalf("Init: %v", err)
+	}
+	var frames [][]int16
+	for offset := 0; offset < len(stream); {
+		samples, info, err := d.Decode(stream[offset:])
+		if err != nil {
+			t.Fatalf("frame %d: %v", len(frames), err)
+		}
+		if info.Channels != 6 {
+			t.Fatalf("frame %d: %d channels, want 6", len(frames), info.Channels)
+		}
+		offset += int(info.BytesConsumed)
+		frames = append(frames, samples.([]int16))
+	}
+	return frames
+}
+
+// TestDecode_ParallelChannels decodes a 5.1 stream with its elements
+// reconstructed concurrently. Without PNS noise the output matches
+// sequential decoding; with it, it is still reproducible.
+func TestDecode_ParallelChannels(t *testing.T) {
+	stream := surroundStream(t)
+	// Several workers even on a single CPU
+	defer runtime.GOMAXPROCS(runtime.GOMAXPROCS(4))
+
+	cfg := aac.NewDecoder().Config()
+	cfg.NoiseGenerator = silentNoise{}
+	want := decodeSurround(t, stream, cfg)
+	cfg.ParallelChannels = true
+	got := decodeSurround(t, stream, cfg)
+	for f := range want {
+		if !slices.Equal(got[f], want[f]) {
+			t.Errorf("frame %d differs from sequential decoding", f)
+		}
+	}
+
+	cfg = aac.NewDecoder().Config()
+	cfg.ParallelChannels = true
+	first := decodeSurround(t, stream, cfg)
+	second := decodeSurround(t, stream, cfg)
+	for f := range first {
+		if !slices.Equal(first[f], second[f]) {
+			t.Errorf("frame %d differs between parallel decodes", f)
+		}
+	}
+}
+
+func BenchmarkDecode_6ch_Sequential(b *testing.B) {
+	benchmarkDecodeSurround(b, false)
+}
+
+func BenchmarkDecode_6ch_Parallel(b *testing.B) {
+	benchmarkDecodeSurround(b, true)
+}
+
+// benchmarkDecodeSurround decodes the 5.1 stream of surroundStream, with
+// Config.ParallelChannels set if parallel.
+func benchmarkDecodeSurround(b *testing.B, parallel bool) {
+	stream := surroundStream(b)
+	d := aac.NewDecoder()
+	cfg := d.Config()
+	cfg.ParallelChannels = parallel
+	d.SetConfiguration(cfg)
+	if _, err := d.Init(stream); err != nil {
+		b.Fatalf("Init: %v", err)
+	}
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	offset := 0
+	for range b.N {
+		if offset >= len(stream) {
+			offset = 0
+		}
+		_, info, err := d.Decode(stream[offset:])
+		if err != nil {
+			b.Fatalf("Decode: %v", err)
+		}
+		offset += int(info.BytesConsumed)
+	}
+}
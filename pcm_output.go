@@ -0,0 +1,75 @@
+// pcm_output.go
+package aac
+
+import "github.com/llehouerou/go-aac/internal/output"
+
+// convertPCM interleaves sources, one per output channel and already
+// mixed by outputSources, into cfg.OutputFormat with the output package.
+// A nil source is output as silence. 16-bit output goes to dst when it is
+// large enough, with the noise of dither added when it is not nil, and
+// 32-bit output is left-justified to cfg.SourceBitDepth when it is set.
+//
+// Ported from: output_to_PCM() in ~/dev/faad2/libfaad/output.c:398-437
+func convertPCM(sources [][]float32, frameLen uint16, cfg *Config, dst []int16, dither func() float32) any {
+	channels := uint8(len(sources))
+	input := make([][]float32, len(sources))
+	channelMap := make([]uint8, len(sources))
+	var silence []float32
+	for ch, src := range sources {
+		if src == nil {
+			if silence == nil {
+				silence = make([]float32, frameLen)
+			}
+			src = silence
+		}
+		input[ch] = src
+		channelMap[ch] = uint8(ch)
+	}
+	total := int(frameLen) * int(channels)
+
+	switch cfg.OutputFormat {
+	case OutputFormat24Bit, OutputFormatFloat, OutputFormatDouble:
+		return output.OutputToPCM(input, channelMap, channels, frameLen, uint8(cfg.OutputFormat), false, false)
+
+	case OutputFormat32Bit:
+		if cfg.SourceBitDepth == 0 {
+			return output.OutputToPCM(input, channelMap, channels, frameLen, output.FormatInt32, false, false)
+		}
+		out := make([]int32, total)
+		output.ToPCM32BitLeftJustified(input, channelMap, channels, frameLen, false, false, cfg.SourceBitDepth, out)
+		return out
+
+	default:
+		var out []int16
+		if cap(dst) >= total {
+			out = dst[:total]
+		} else {
+			out = make([]int16, total)
+		}
+		output.ToPCM16Bit(input, channelMap, channels, frameLen, false, false, out, dither)
+		return out
+	}
+}
+
+// planarPCM splits interleaved output of convertPCM into one slice per
+// channel, as output.OutputToPCMPlanar does.
+func planarPCM(samples any, channels int) any {
+	switch s := samples.(type) {
+	case []int16:
+		return output.Deinterleave(s, channels)
+	case []int32:
+		return output.Deinterleave(s, channels)
+	case []float32:
+		return output.Deinterleave(s, channels)
+	case []float64:
+		return output.Deinterleave(s, channels)
+	default:
+		return samples
+	}
+}
+
+// packPCM packs interleaved samples into bytes in the given order, with
+// []int32 samples in 3 bytes when packed24 is set.
+func packPCM(samples any, packed24 bool, byteOrder ByteOrder) []byte {
+	return output.PackPCM(samples, packed24, uint8(byteOrder))
+}
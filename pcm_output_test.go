@@ -0,0 +1,278 @@
+// pcm_output_test.go
+package aac
+
+import (
+	"encoding/binary"
+	"math"
+	"os"
+	"slices"
+	"testing"
+
+	"github.com/llehouerou/go-aac/internal/output"
+)
+
+// newPCMOutputDecoder returns a stereo decoder in the post-reconstruction
+// state, with left samples at 1000.5 and right samples at -40000.
+func newPCMOutputDecoder(t *testing.T, format OutputFormat) *Decoder {
+	t.Helper()
+	d := NewDecoder()
+	cfg := d.Config()
+	cfg.OutputFormat = format
+	d.SetConfiguration(cfg)
+
+	d.frameLength = 1024
+	d.channelConfiguration = 2
+	d.frChannels = 2
+	if err := d.allocateChannelBuffers(2); err != nil {
+		t.Fatalf("allocateChannelBuffers: %v", err)
+	}
+	for i := range d.timeOut[0] {
+		d.timeOut[0][i] = 1000.5
+		d.timeOut[1][i] = -40000
+	}
+	return d
+}
+
+func TestGeneratePCMOutput_Formats(t *testing.T) {
+	tests := []struct {
+		format OutputFormat
+		check  func(t *testing.T, samples any)
+	}{
+		{OutputFormat16Bit, func(t *testing.T, samples any) {
+			s, ok := samples.([]int16)
+			if !ok {
+				t.Fatalf("got %T, want []int16", samples)
+			}
+			if s[0] != 1000 || s[1] != -32768 {
+				t.Errorf("got %d, %d; want 1000, -32768", s[0], s[1])
+			}
+		}},
+		{OutputFormat24Bit, func(t *testing.T, samples any) {
+			s, ok := samples.([]int32)
+			if !ok {
+				t.Fatalf("got %T, want []int32", samples)
+			}
+			if s[0] != 256128 || s[1] != -8388608 {
+				t.Errorf("got %d, %d; want 256128, -8388608", s[0], s[1])
+			}
+		}},
+		{OutputFormat32Bit, func(t *testing.T, samples any) {
+			s, ok := samples.([]int32)
+			if !ok {
+				t.Fatalf("got %T, want []int32", samples)
+			}
+			if s[0] != 65568768 || s[1] != math.MinInt32 {
+				t.Errorf("got %d, %d; want 65568768, %d", s[0], s[1], math.MinInt32)
+			}
+		}},
+		{OutputFormatFloat, func(t *testing.T, samples any) {
+			s, ok := samples.([]float32)
+			if !ok {
+				t.Fatalf("got %T, want []float32", samples)
+			}
+			if s[0] != 1000.5/32768 || s[1] != -40000.0/32768 {
+				t.Errorf("got %v, %v; want unclipped normalized samples", s[0], s[1])
+			}
+		}},
+		{OutputFormatDouble, func(t *testing.T, samples any) {
+			s, ok := samples.([]float64)
+			if !ok {
+				t.Fatalf("got %T, want []float64", samples)
+			}
+			if s[0] != 1000.5/32768 || s[1] != -40000.0/32768 {
+				t.Errorf("got %v, %v; want unclipped normalized samples", s[0], s[1])
+			}
+		}},
+	}
+
+	for _, tt := range tests {
+		d := newPCMOutputDecoder(t, tt.format)
+		samples := d.generatePCMOutput(2)
+		tt.check(t, samples)
+	}
+}
+
+func TestGeneratePCMOutput_SourceBitDepth(t *testing.T) {
+	d := newPCMOutputDecoder(t, OutputFormat32Bit)
+	d.config.SourceBitDepth = 24
+
+	s, ok := d.generatePCMOutput(2).([]int32)
+	if !ok {
+		t.Fatal("expected []int32 samples")
+	}
+	// 1000.5 * 256 = 256128 in 24 bits, left-justified
+	if s[0] != 256128<<8 || s[1] != -8388608<<8 {
+		t.Errorf("got %d, %d; want %d, %d", s[0], s[1], 256128<<8, -8388608<<8)
+	}
+}
+
+func TestGeneratePCMOutput_DownMatrix(t *testing.T) {
+	d := newForceChannelsDecoder(t, 6, 0, ForceChannelsMix)
+	d.downMatrix = true
+
+	got := firstFrame(t, d, 2)
+	// C=1000, L=2000, R=3000, Ls=4000, Rs=5000
+	left := output.DownmixMul * (2000 + 1000*output.InvSqrt2 + 4000*output.InvSqrt2)
+	right := output.DownmixMul * (3000 + 1000*output.InvSqrt2 + 5000*output.InvSqrt2)
+	if got[0] != int16(math.RoundToEven(float64(left))) || got[1] != int16(math.RoundToEven(float64(right))) {
+		t.Errorf("got %v, want [%v %v]", got, left, right)
+	}
+}
+
+func TestGeneratePCMOutput_Planar(t *testing.T) {
+	tests := []struct {
+		name     string
+		decoder  func(t *testing.T) *Decoder
+		channels uint8
+	}{
+		{"stereo", func(t *testing.T) *Decoder { return newPCMOutputDecoder(t, OutputFormat16Bit) }, 2},
+		{"5.1 downmix", func(t *testing.T) *Decoder {
+			d := newForceChannelsDecoder(t, 6, 0, ForceChannelsMix)
+			d.downMatrix = true
+			return d
+		}, 2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := tt.decoder(t)
+			interleaved, ok := d.generatePCMOutput(tt.channels).([]int16)
+			if !ok {
+				t.Fatal("expected []int16 samples")
+			}
+			d.config.Planar = true
+			planes, ok := d.generatePCMOutput(tt.channels).([][]int16)
+			if !ok {
+				t.Fatal("expected [][]int16 samples with Planar")
+			}
+			if len(planes) != int(tt.channels) {
+				t.Fatalf("got %d planes, want %d", len(planes), tt.channels)
+			}
+			for ch, plane := range planes {
+				if len(plane) != int(d.frameLength) {
+					t.Fatalf("plane %d has %d samples, want %d", ch, len(plane), d.frameLength)
+				}
+				for i, v := range plane {
+					if want := interleaved[i*int(tt.channels)+ch]; v != want {
+						t.Fatalf("plane %d sample %d = %d, want %d", ch, i, v, want)
+					}
+				}
+			}
+		})
+	}
+}
+
+func TestTrimGapless_Planar(t *testing.T) {
+	d := NewDecoder()
+	d.config.Gapless = &GaplessInfo{EncoderDelay: 1024 + 2, PaddingSamples: 3}
+	d.frameLength = 4
+
+	frame := func(base int16) [][]int16 {
+		return [][]int16{{base, base + 1, base + 2, base + 3}, {-base, -base - 1, -base - 2, -base - 3}}
+	}
+
+	// The muted first frame covers the first 4 samples of the delay
+	d.gaplessPosition = 1024 - 4
+	muted := &FrameInfo{Channels: 2}
+	d.trimGapless(frame(0), muted)
+
+	info := &FrameInfo{Channels: 2, Samples: 8}
+	got := d.trimGapless(frame(10), info).([][]int16)
+	// 2 delay samples dropped, the last 3 held back: none left
+	if info.Samples != 0 || info.TrimmedSamples != 8 || len(got[0]) != 0 || len(got[1]) != 0 {
+		t.Fatalf("first frame: got %v, Samples %d, TrimmedSamples %d", got, info.Samples, info.TrimmedSamples)
+	}
+
+	info = &FrameInfo{Channels: 2, Samples: 8}
+	got = d.trimGapless(frame(20), info).([][]int16)
+	// The 2 held samples come first, 3 of the 4 new ones are held back
+	want := [][]int16{{12, 13, 20}, {-12, -13, -20}}
+	if info.Samples != 6 || info.TrimmedSamples != 2 {
+		t.Errorf("second frame: Samples %d, TrimmedSamples %d; want 6, 2", info.Samples, info.TrimmedSamples)
+	}
+	for ch := range want {
+		if !slices.Equal(got[ch], want[ch]) {
+			t.Errorf("second frame plane %d = %v, want %v", ch, got[ch], want[ch])
+		}
+	}
+}
+
+func TestDecoder_DecodeBytes(t *testing.T) {
+	data, err := os.ReadFile("testdata/sine1k.aac")
+	if err != nil {
+		t.Skipf("sine1k.aac not available: %v", err)
+	}
+
+	tests := []struct {
+		name      string
+		format    OutputFormat
+		byteOrder ByteOrder
+		pack      func(samples any, i int) []byte
+	}{
+		{"16-bit LE", OutputFormat16Bit, ByteOrderLittleEndian, func(s any, i int) []byte {
+			return binary.LittleEndian.AppendUint16(nil, uint16(s.([]int16)[i]))
+		}},
+		{"16-bit BE", OutputFormat16Bit, ByteOrderBigEndian, func(s any, i int) []byte {
+			return binary.BigEndian.AppendUint16(nil, uint16(s.([]int16)[i]))
+		}},
+		{"24-bit BE", OutputFormat24Bit, ByteOrderBigEndian, func(s any, i int) []byte {
+			return binary.BigEndian.AppendUint32(nil, uint32(s.([]int32)[i]))[1:]
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ref := NewDecoder()
+			ref.config.OutputFormat = tt.format
+			dec := NewDecoder()
+			dec.config.OutputFormat = tt.format
+			dec.config.ByteOrder = tt.byteOrder
+			if _, err := ref.Init(data); err != nil {
+				t.Fatalf("Init: %v", err)
+			}
+			if _, err := dec.Init(data); err != nil {
+				t.Fatalf("Init: %v", err)
+			}
+
+			negative := 0
+			for pos, frames := 0, 0; pos < len(data) && frames < 8; frames++ {
+				samples, want, err := ref.Decode(data[pos:])
+				if err != nil {
+					t.Fatalf("frame %d: Decode: %v", frames, err)
+				}
+				got, info, err := dec.DecodeBytes(data[pos:])
+				if err != nil {
+					t.Fatalf("frame %d: DecodeBytes: %v", frames, err)
+				}
+				pos += int(info.BytesConsumed)
+
+				if want.Samples == 0 {
+					if got != nil {
+						t.Fatalf("frame %d: got %d bytes for a frame without samples", frames, len(got))
+					}
+					continue
+				}
+				size := len(tt.pack(samples, 0))
+				if len(got) != int(want.Samples)*size {
+					t.Fatalf("frame %d: got %d bytes, want %d", frames, len(got), int(want.Samples)*size)
+				}
+				for i := 0; i < int(want.Samples); i++ {
+					b := tt.pack(samples, i)
+					if !slices.Equal(got[i*size:(i+1)*size], b) {
+						t.Fatalf("frame %d sample %d: got % x, want % x", frames, i, got[i*size:(i+1)*size], b)
+					}
+					msb := b[0]
+					if tt.byteOrder == ByteOrderLittleEndian {
+						msb = b[size-1]
+					}
+					if msb&0x80 != 0 {
+						negative++
+					}
+				}
+			}
+			if negative == 0 {
+				t.Error("no negative samples were compared")
+			}
+		})
+	}
+}
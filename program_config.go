@@ -0,0 +1,117 @@
+// program_config.go
+package aac
+
+import "github.com/llehouerou/go-aac/internal/syntax"
+
+// channelConfigLayout returns the layout of a standard channel
+// configuration (1-7), in the form a PCE would describe it.
+func channelConfigLayout(channelConfig uint8) (syntax.ProgramConfig, bool) {
+	var p syntax.ProgramConfig
+	switch channelConfig {
+	case 1, 2, 3:
+		p.NumFrontChannels = channelConfig
+	case 4:
+		p.NumFrontChannels, p.NumBackChannels = 3, 1
+	case 5:
+		p.NumFrontChannels, p.NumBackChannels = 3, 2
+	case 6:
+		p.NumFrontChannels, p.NumBackChannels, p.NumLFEChannels = 3, 2, 1
+	case 7:
+		// 7.1 as reported by createChannelConfig
+		p.NumFrontChannels, p.NumSideChannels = 3, 2
+		p.NumBackChannels, p.NumLFEChannels = 2, 1
+	default:
+		return p, false
+	}
+	p.Channels = p.NumFrontChannels + p.NumSideChannels + p.NumBackChannels + p.NumLFEChannels
+	return p, true
+}
+
+// applyFramePCE handles a program config element sent at the start of a
+// frame. Streams may repeat their PCE periodically: a PCE repeating the
+// current layout is accepted as is, while a different layout is adopted
+// only with Config.AllowLayoutChange and rejected with ErrLayoutChanged
+// otherwise. A stream with no layout yet (channel configuration 0 and no
+// earlier PCE) takes its layout from the first PCE.
+//
+// Ported from: ID_PCE handling in raw_data_block() in ~/dev/faad2/libfaad/syntax.c:497-510
+func (d *Decoder) applyFramePCE(pce *syntax.ProgramConfig) error {
+	var current *syntax.ProgramConfig
+	if d.pceSet && d.channelConfiguration == 0 {
+		current = d.pce
+	} else if layout, ok := channelConfigLayout(d.channelConfiguration); ok {
+		current = &layout
+	}
+
+	if current != nil && !samePCELayout(current, pce) && !d.config.AllowLayoutChange {
+		return ErrLayoutChanged
+	}
+
+	d.pceSet = true
+	d.pce = pce
+	d.channelConfiguration = 0 // Layout is defined by the PCE
+	return nil
+}
+
+// pceChannelConfig fills the channel counts and positions of info from
+// the current PCE. As in FAAD2, the positions derive from the channel
+// counts alone, not from the element list: an odd front count puts the
+// center first, side channels come in pairs, and an odd back count puts
+// the back center after the back pairs. Front arrangements such as a CPE
+// followed by an SCE are thus reported center first, and a lone side SCE
+// takes both side positions, shifting the channels after it.
+//
+// FAAD2 reports a mono PCE layout as stereo when built with PS; this
+// decoder does not output PS, so it stays a center channel.
+//
+// Ported from: create_channel_config() pce_set branch in ~/dev/faad2/libfaad/decoder.c:611-678
+func (d *Decoder) pceChannelConfig(info *FrameInfo) {
+	pce := d.pce
+	if pce == nil {
+		return
+	}
+	info.NumFrontChannels = pce.NumFrontChannels
+	info.NumSideChannels = pce.NumSideChannels
+	info.NumBackChannels = pce.NumBackChannels
+	info.NumLFEChannels = pce.NumLFEChannels
+
+	ch := 0
+	put := func(pos ChannelPosition) {
+		if ch < len(info.ChannelPosition) {
+			info.ChannelPosition[ch] = pos
+		}
+		ch++
+	}
+
+	chdir := info.NumFrontChannels
+	if chdir&1 != 0 {
+		put(ChannelFrontCenter)
+		chdir--
+	}
+	for i := uint8(0); i < chdir; i += 2 {
+		put(ChannelFrontLeft)
+		put(ChannelFrontRight)
+	}
+
+	for i := uint8(0); i < info.NumSideChannels; i += 2 {
+		put(ChannelSideLeft)
+		put(ChannelSideRight)
+	}
+
+	chdir = info.NumBackChannels
+	backCenter := chdir&1 != 0
+	if backCenter {
+		chdir--
+	}
+	for i := uint8(0); i < chdir; i += 2 {
+		put(ChannelBackLeft)
+		put(ChannelBackRight)
+	}
+	if backCenter {
+		put(ChannelBackCenter)
+	}
+
+	for i := uint8(0); i < info.NumLFEChannels; i++ {
+		put(ChannelLFE)
+	}
+}
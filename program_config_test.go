@@ -0,0 +1,360 @@
+// program_config_test.go
+package aac
+
+import (
+	"math"
+	"testing"
+
+	"github.com/llehouerou/go-aac/internal/bits"
+	"github.com/llehouerou/go-aac/internal/syntax"
+)
+
+// writeFramePCE writes an ID_PCE element (LC, 44100 Hz) with the given
+// front, side and back elements (true for a CPE) and LFE count.
+func writeFramePCE(w *adifBitWriter, front, side, back []bool, lfe int) {
+	writeFramePCEMixdown(w, front, side, back, lfe, nil)
+}
+
+// pceMixdown is the matrix mixdown signalled by writeFramePCEMixdown.
+type pceMixdown struct {
+	idx    uint8
+	pseudo bool
+}
+
+// writeFramePCEMixdown is writeFramePCE with a matrix mixdown, if mixdown
+// is non-nil.
+func writeFramePCEMixdown(w *adifBitWriter, front, side, back []bool, lfe int, mixdown *pceMixdown) {
+	w.writeBits(uint32(idPCE), 3)
+	w.writeBits(0, 4) // element_instance_tag
+	w.writeBits(1, 2) // object_type (LC)
+	w.writeBits(4, 4) // sf_index (44100)
+	w.writeBits(uint32(len(front)), 4)
+	w.writeBits(uint32(len(side)), 4)
+	w.writeBits(uint32(len(back)), 4)
+	w.writeBits(uint32(lfe), 2)
+	w.writeBits(0, 3) // num_assoc_data_elements
+	w.writeBits(0, 4) // num_valid_cc_elements
+	w.writeBits(0, 2) // mono and stereo mixdown not present
+	if mixdown != nil {
+		w.writeBits(1, 1)
+		w.writeBits(uint32(mixdown.idx), 2)
+		if mixdown.pseudo {
+			w.writeBits(1, 1)
+		} else {
+			w.writeBits(0, 1)
+		}
+	} else {
+		w.writeBits(0, 1)
+	}
+	for _, elements := range [][]bool{front, side, back} {
+		for _, isCPE := range elements {
+			if isCPE {
+				w.writeBits(1, 1)
+			} else {
+				w.writeBits(0, 1)
+			}
+			w.writeBits(0, 4) // element tag
+		}
+	}
+	for i := 0; i < lfe; i++ {
+		w.writeBits(0, 4)
+	}
+	w.byteAlign()
+	w.writeBits(0, 8) // comment_field_bytes
+}
+
+// adtsPCEFrame builds a stereo ADTS frame whose raw_data_block is the
+// given elements followed by ID_END.
+func adtsPCEFrame(t *testing.T, write func(w *adifBitWriter)) []byte {
+	t.Helper()
+	w := &adifBitWriter{}
+	// Reserve the 7-byte ADTS header so byte alignment matches the frame
+	w.writeBits(0, 56)
+	write(w)
+	w.writeBits(uint32(idEND), 3)
+	payload := w.buf[7:]
+
+	header, err := BuildADTSHeader(ADTSConfig{
+		ObjectType:           ObjectTypeLC,
+		SFIndex:              4,
+		ChannelConfiguration: 2,
+		BufferFullness:       0x7FF,
+	}, len(payload))
+	if err != nil {
+		t.Fatalf("BuildADTSHeader failed: %v", err)
+	}
+	return append(header, payload...)
+}
+
+func TestDecoder_Decode_FramePCE(t *testing.T) {
+	stereo := func(w *adifBitWriter) {
+		writeFramePCE(w, []bool{true}, nil, nil, 0)
+	}
+	surround := func(w *adifBitWriter) {
+		writeFramePCE(w, []bool{false, true}, nil, []bool{true}, 1)
+	}
+	afterFill := func(w *adifBitWriter) {
+		w.writeBits(uint32(idFIL), 3)
+		w.writeBits(0, 4) // count
+		stereo(w)
+	}
+
+	tests := []struct {
+		name        string
+		write       func(w *adifBitWriter)
+		allowChange bool
+		wantErr     error
+		wantPCE     bool
+	}{
+		{"same layout refreshed", stereo, false, nil, true},
+		{"layout change rejected", surround, false, ErrLayoutChanged, false},
+		{"layout change allowed", surround, true, nil, true},
+		{"PCE after another element", afterFill, true, ErrPCENotFirst, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			frame := adtsPCEFrame(t, tt.write)
+
+			d := NewDecoder()
+			cfg := d.Config()
+			cfg.AllowLayoutChange = tt.allowChange
+			d.SetConfiguration(cfg)
+			if _, err := d.Init(frame); err != nil {
+				t.Fatalf("Init failed: %v", err)
+			}
+
+			_, info, err := d.Decode(frame)
+			if err != tt.wantErr {
+				t.Fatalf("Decode: got error %v, want %v", err, tt.wantErr)
+			}
+			if err == nil && info.BytesConsumed != uint32(len(frame)) {
+				t.Errorf("BytesConsumed: got %d, want %d", info.BytesConsumed, len(frame))
+			}
+			if d.pceSet != tt.wantPCE {
+				t.Errorf("pceSet: got %v, want %v", d.pceSet, tt.wantPCE)
+			}
+			if !tt.wantPCE && d.channelConfiguration != 2 {
+				t.Errorf("channelConfiguration: got %d, want 2 (unchanged)", d.channelConfiguration)
+			}
+		})
+	}
+}
+
+func TestDecoder_FramePCE_ChannelConfig(t *testing.T) {
+	frame := adtsPCEFrame(t, func(w *adifBitWriter) {
+		writeFramePCE(w, []bool{false, true}, []bool{true}, []bool{false}, 1)
+	})
+
+	d := NewDecoder()
+	cfg := d.Config()
+	cfg.AllowLayoutChange = true
+	d.SetConfiguration(cfg)
+	if _, err := d.Init(frame); err != nil {
+		t.Fatalf("Init failed: %v", err)
+	}
+	if _, _, err := d.Decode(frame); err != nil {
+		t.Fatalf("Decode failed: %v", err)
+	}
+
+	info := &FrameInfo{}
+	d.createChannelConfig(info)
+
+	if info.NumFrontChannels != 3 || info.NumSideChannels != 2 ||
+		info.NumBackChannels != 1 || info.NumLFEChannels != 1 {
+		t.Errorf("channel counts: got front %d side %d back %d lfe %d, want 3/2/1/1",
+			info.NumFrontChannels, info.NumSideChannels, info.NumBackChannels, info.NumLFEChannels)
+	}
+	want := []ChannelPosition{
+		ChannelFrontCenter, ChannelFrontLeft, ChannelFrontRight,
+		ChannelSideLeft, ChannelSideRight, ChannelBackCenter, ChannelLFE,
+	}
+	for i, pos := range want {
+		if info.ChannelPosition[i] != pos {
+			t.Errorf("ChannelPosition[%d]: got %d, want %d", i, info.ChannelPosition[i], pos)
+		}
+	}
+}
+
+func TestDecoder_FramePCE_MixedElements(t *testing.T) {
+	tests := []struct {
+		name              string
+		front, side, back []bool
+		lfe               int
+		want              []ChannelPosition
+		wantCounts        [4]uint8 // front, side, back, LFE
+	}{
+		{
+			name:       "front CPE then SCE, center first",
+			front:      []bool{true, false},
+			want:       []ChannelPosition{ChannelFrontCenter, ChannelFrontLeft, ChannelFrontRight},
+			wantCounts: [4]uint8{3, 0, 0, 0},
+		},
+		{
+			name:  "center and two front pairs with LFE",
+			front: []bool{false, true, true},
+			back:  []bool{true},
+			lfe:   1,
+			want: []ChannelPosition{
+				ChannelFrontCenter, ChannelFrontLeft, ChannelFrontRight,
+				ChannelFrontLeft, ChannelFrontRight,
+				ChannelBackLeft, ChannelBackRight, ChannelLFE,
+			},
+			wantCounts: [4]uint8{5, 0, 2, 1},
+		},
+		{
+			// The side SCE takes a pair of positions, as in FAAD2
+			name:  "side SCE and back center after back pair",
+			front: []bool{true},
+			side:  []bool{false},
+			back:  []bool{false, true},
+			lfe:   2,
+			want: []ChannelPosition{
+				ChannelFrontLeft, ChannelFrontRight, ChannelSideLeft, ChannelSideRight,
+				ChannelBackLeft, ChannelBackRight, ChannelBackCenter,
+				ChannelLFE, ChannelLFE,
+			},
+			wantCounts: [4]uint8{2, 1, 3, 2},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			frame := adtsPCEFrame(t, func(w *adifBitWriter) {
+				writeFramePCE(w, tt.front, tt.side, tt.back, tt.lfe)
+			})
+			d := NewDecoder()
+			cfg := d.Config()
+			cfg.AllowLayoutChange = true
+			d.SetConfiguration(cfg)
+			if _, err := d.Init(frame); err != nil {
+				t.Fatalf("Init failed: %v", err)
+			}
+			if _, _, err := d.Decode(frame); err != nil {
+				t.Fatalf("Decode failed: %v", err)
+			}
+
+			info := &FrameInfo{}
+			d.createChannelConfig(info)
+
+			counts := [4]uint8{info.NumFrontChannels, info.NumSideChannels, info.NumBackChannels, info.NumLFEChannels}
+			if counts != tt.wantCounts {
+				t.Errorf("channel counts: got %v, want %v", counts, tt.wantCounts)
+			}
+			for i, pos := range tt.want {
+				if info.ChannelPosition[i] != pos {
+					t.Errorf("ChannelPosition[%d]: got %d, want %d", i, info.ChannelPosition[i], pos)
+				}
+			}
+			if got := info.ChannelPosition[len(tt.want)]; got != ChannelUnknown {
+				t.Errorf("ChannelPosition[%d]: got %d past the layout, want ChannelUnknown", len(tt.want), got)
+			}
+		})
+	}
+}
+
+func TestChannelConfigLayout(t *testing.T) {
+	tests := []struct {
+		config   uint8
+		channels uint8
+	}{
+		{1, 1}, {2, 2}, {3, 3}, {4, 4}, {5, 5}, {6, 6}, {7, 8},
+	}
+	for _, tt := range tests {
+		layout, ok := channelConfigLayout(tt.config)
+		if !ok || layout.Channels != tt.channels {
+			t.Errorf("config %d: got %d channels (ok=%v), want %d", tt.config, layout.Channels, ok, tt.channels)
+		}
+	}
+	if _, ok := channelConfigLayout(0); ok {
+		t.Error("config 0 should have no fixed layout")
+	}
+}
+
+func TestParseProgramConfig_MatrixMixdown(t *testing.T) {
+	tests := []*pceMixdown{nil, {0, false}, {1, true}, {2, false}, {3, true}}
+	for _, mixdown := range tests {
+		w := &adifBitWriter{}
+		writeFramePCEMixdown(w, []bool{false, true}, nil, []bool{true}, 1, mixdown)
+		r := bits.NewReader(w.buf)
+		r.FlushBits(3) // element id
+
+		pce, err := parseProgramConfig(r)
+		if err != nil {
+			t.Fatalf("%+v: parseProgramConfig: %v", mixdown, err)
+		}
+		if pce.Channels != 6 {
+			t.Errorf("%+v: channels = %d, want 6", mixdown, pce.Channels)
+		}
+		if mixdown == nil {
+			if pce.MatrixMixdownIdxPresent {
+				t.Error("matrix mixdown reported present")
+			}
+			continue
+		}
+		if !pce.MatrixMixdownIdxPresent || pce.MatrixMixdownIdx != mixdown.idx ||
+			pce.PseudoSurroundEnable != mixdown.pseudo {
+			t.Errorf("got present=%v idx=%d pseudo=%v, want %+v", pce.MatrixMixdownIdxPresent,
+				pce.MatrixMixdownIdx, pce.PseudoSurroundEnable, *mixdown)
+		}
+	}
+}
+
+func TestParseProgramConfig_TooManyChannels(t *testing.T) {
+	// 15 front and 15 side CPEs make 60 channels, 3 back CPEs 66
+	cpes := make([]bool, 15)
+	for i := range cpes {
+		cpes[i] = true
+	}
+	w := &adifBitWriter{}
+	writeFramePCE(w, cpes, cpes, []bool{true, true, true}, 0)
+	r := bits.NewReader(w.buf)
+	r.FlushBits(3) // element id
+
+	if _, err := parseProgramConfig(r); err != ErrProgramConfigElement {
+		t.Errorf("66 channels: got %v, want ErrProgramConfigElement", err)
+	}
+}
+
+func TestGeneratePCMOutput_PCEMatrixMixdown(t *testing.T) {
+	// C=1000, L=2000, R=3000, Ls=4000, Rs=5000; A per matrix_mixdown_idx
+	// from ISO/IEC 13818-7
+	tests := []struct {
+		idx    uint8
+		a      float64
+		pseudo bool
+	}{
+		{0, 1 / math.Sqrt2, false},
+		{1, 0.5, false},
+		{2, 1 / (2 * math.Sqrt2), false},
+		{3, 0, false},
+		{1, 0.5, true},
+	}
+
+	for _, tt := range tests {
+		d := newForceChannelsDecoder(t, 6, 0, ForceChannelsMix)
+		d.downMatrix = true
+		d.channelConfiguration = 0
+		d.pceSet = true
+		d.pce = &syntax.ProgramConfig{
+			MatrixMixdownIdxPresent: true,
+			MatrixMixdownIdx:        tt.idx,
+			PseudoSurroundEnable:    tt.pseudo,
+		}
+
+		var left, right float64
+		if tt.pseudo {
+			mul := 1 / (1 + 1/math.Sqrt2 + 2*tt.a)
+			left = mul * (2000 + 1000/math.Sqrt2 - tt.a*9000)
+			right = mul * (3000 + 1000/math.Sqrt2 + tt.a*9000)
+		} else {
+			mul := 1 / (1 + 1/math.Sqrt2 + tt.a)
+			left = mul * (2000 + 1000/math.Sqrt2 + tt.a*4000)
+			right = mul * (3000 + 1000/math.Sqrt2 + tt.a*5000)
+		}
+
+		got := firstFrame(t, d, 2)
+		if math.Abs(float64(got[0])-left) > 1 || math.Abs(float64(got[1])-right) > 1 {
+			t.Errorf("idx %d pseudo %v: got %v, want [%.1f %.1f]", tt.idx, tt.pseudo, got, left, right)
+		}
+	}
+}
@@ -0,0 +1,89 @@
+// reference_test.go
+package aac_test
+
+import (
+	"encoding/binary"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/llehouerou/go-aac"
+)
+
+// referenceTolerance is the largest difference, in 16-bit LSBs, allowed
+// between a decoded sample and FFmpeg's. Both decoders compute in
+// float32, but with different transforms and rounding.
+const referenceTolerance = 8
+
+// readReference returns the ADTS stream name.aac of testdata/generated/dir
+// and FFmpeg's 16-bit decoding of it, name.raw, as written by
+// testdata/generate.go. The test is skipped when they have not been
+// generated.
+func readReference(t *testing.T, dir, name string) (data []byte, ref []int16) {
+	t.Helper()
+	base := filepath.Join("testdata", "generated", dir, name)
+	data, err := os.ReadFile(base + ".aac")
+	if err != nil {
+		t.Skipf("reference stream not available (go run testdata/generate.go): %v", err)
+	}
+	raw, err := os.ReadFile(base + ".raw")
+	if err != nil {
+		t.Skipf("reference PCM not available (go run testdata/generate.go): %v", err)
+	}
+	ref = make([]int16, len(raw)/2)
+	for i := range ref {
+		ref[i] = int16(binary.LittleEndian.Uint16(raw[2*i:]))
+	}
+	return data, ref
+}
+
+// compareReference decodes data with d, which must not be initialized,
+// and compares the interleaved output with ref, FFmpeg's decoding of the
+// same stream. The first frame is skipped: FAAD2 mutes it, FFmpeg
+// outputs the encoder's priming samples. It returns the FrameInfo of
+// every frame.
+func compareReference(t *testing.T, d *aac.Decoder, data []byte, ref []int16, wantChannels uint8) []*aac.FrameInfo {
+	t.Helper()
+	if _, err := d.Init(data); err != nil {
+		t.Fatalf("Init: %v", err)
+	}
+
+	var pcm []int16
+	var infos []*aac.FrameInfo
+	skip := 0
+	for frame, offset := 0, 0; offset < len(data); frame++ {
+		samples, info, err := d.Decode(data[offset:])
+		if err != nil {
+			t.Fatalf("frame %d: %v", frame, err)
+		}
+		if info.Channels != wantChannels {
+			t.Fatalf("frame %d: channels = %d, want %d", frame, info.Channels, wantChannels)
+		}
+		offset += int(info.BytesConsumed)
+		infos = append(infos, info)
+		if frame == 0 {
+			skip = len(samples.([]int16))
+		}
+		pcm = append(pcm, samples.([]int16)...)
+	}
+	if len(pcm) != len(ref) {
+		t.Fatalf("decoded %d samples, reference has %d", len(pcm), len(ref))
+	}
+
+	worst, at := 0, 0
+	for i := skip; i < len(pcm); i++ {
+		diff := int(pcm[i]) - int(ref[i])
+		if diff < 0 {
+			diff = -diff
+		}
+		if diff > worst {
+			worst, at = diff, i
+		}
+	}
+	if worst > referenceTolerance {
+		ch := at % int(wantChannels)
+		t.Errorf("channel %d sample %d: got %d, reference %d (%d LSB off, tolerance %d)",
+			ch, at/int(wantChannels), pcm[at], ref[at], worst, referenceTolerance)
+	}
+	return infos
+}
@@ -0,0 +1,100 @@
+// sample_ring.go
+package aac
+
+import "sync"
+
+// SampleRing is a bounded FIFO of interleaved int16 PCM connecting a
+// decode goroutine to a real-time audio callback.
+//
+// The decoder side calls Write, which blocks while the ring is full so
+// decoding never runs further ahead of playback than the ring's capacity.
+// The playback side calls Read, which never blocks: an audio callback
+// must return in time, so it takes what is available and handles the
+// underrun itself. Close releases a blocked writer when playback stops.
+//
+// A SampleRing is safe for concurrent use by any number of goroutines.
+type SampleRing struct {
+	mu      sync.Mutex
+	notFull sync.Cond
+	buf     []int16
+	start   int // Index of the oldest sample
+	count   int // Samples currently buffered
+	closed  bool
+}
+
+// NewSampleRing returns a ring holding up to capacity samples. Size it
+// in samples, not frames: a 100 ms stereo buffer at 44100 Hz holds 8820.
+// A capacity below 1 is raised to 1.
+func NewSampleRing(capacity int) *SampleRing {
+	s := &SampleRing{buf: make([]int16, max(capacity, 1))}
+	s.notFull.L = &s.mu
+	return s
+}
+
+// Write appends samples to the ring, blocking until all of them fit. It
+// returns ErrSampleRingClosed if the ring is closed before that; samples
+// already queued by then stay readable.
+func (s *SampleRing) Write(samples []int16) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for len(samples) > 0 {
+		for s.count == len(s.buf) && !s.closed {
+			s.notFull.Wait()
+		}
+		if s.closed {
+			return ErrSampleRingClosed
+		}
+
+		// Copy into the free space, which may wrap around the end
+		end := (s.start + s.count) % len(s.buf)
+		free := len(s.buf) - s.count
+		n := copy(s.buf[end:min(end+free, len(s.buf))], samples)
+		if n < free && n < len(samples) {
+			n += copy(s.buf[:free-n], samples[n:])
+		}
+		s.count += n
+		samples = samples[n:]
+	}
+	return nil
+}
+
+// Read moves up to len(dst) of the oldest samples into dst and returns
+// how many it moved. It does not wait for samples: a return value below
+// len(dst) is an underrun, which the caller typically fills with silence.
+func (s *SampleRing) Read(dst []int16) int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	n := min(len(dst), s.count)
+	first := copy(dst[:n], s.buf[s.start:min(s.start+n, len(s.buf))])
+	copy(dst[first:n], s.buf[:n-first])
+
+	s.start = (s.start + n) % len(s.buf)
+	s.count -= n
+	if n > 0 {
+		s.notFull.Broadcast()
+	}
+	return n
+}
+
+// Available returns the number of samples ready to Read.
+func (s *SampleRing) Available() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.count
+}
+
+// Cap returns the ring capacity in samples.
+func (s *SampleRing) Cap() int {
+	return len(s.buf)
+}
+
+// Close makes pending and future Writes fail with ErrSampleRingClosed.
+// Buffered samples can still be read. Close is idempotent.
+func (s *SampleRing) Close() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.closed = true
+	s.notFull.Broadcast()
+}
@@ -0,0 +1,153 @@
+// sample_ring_test.go
+package aac
+
+import (
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestSampleRing_WrapAround(t *testing.T) {
+	s := NewSampleRing(5)
+	if err := s.Write([]int16{1, 2, 3, 4}); err != nil {
+		t.Fatalf("Write failed: %v", err)
+	}
+
+	dst := make([]int16, 3)
+	if n := s.Read(dst); n != 3 || dst[0] != 1 || dst[2] != 3 {
+		t.Fatalf("Read: got %d %v, want 3 [1 2 3]", n, dst)
+	}
+
+	// 4 then 5, 6, 7 wrapping past the end of the buffer
+	if err := s.Write([]int16{5, 6, 7}); err != nil {
+		t.Fatalf("Write failed: %v", err)
+	}
+	if got := s.Available(); got != 4 {
+		t.Errorf("Available: got %d, want 4", got)
+	}
+
+	dst = make([]int16, 6)
+	n := s.Read(dst)
+	want := []int16{4, 5, 6, 7}
+	if n != len(want) {
+		t.Fatalf("Read: got %d samples, want %d", n, len(want))
+	}
+	for i := range want {
+		if dst[i] != want[i] {
+			t.Errorf("dst[%d]: got %d, want %d", i, dst[i], want[i])
+		}
+	}
+
+	// Empty ring underruns without blocking
+	if n := s.Read(dst); n != 0 {
+		t.Errorf("Read on empty ring: got %d, want 0", n)
+	}
+}
+
+func TestSampleRing_WriteBlocksWhenFull(t *testing.T) {
+	s := NewSampleRing(4)
+	done := make(chan error, 1)
+	go func() {
+		done <- s.Write([]int16{1, 2, 3, 4, 5, 6})
+	}()
+
+	// The writer fills the ring and waits for room for the rest
+	deadline := time.Now().Add(time.Second)
+	for s.Available() < s.Cap() {
+		if time.Now().After(deadline) {
+			t.Fatal("writer did not fill the ring")
+		}
+		time.Sleep(time.Millisecond)
+	}
+	select {
+	case err := <-done:
+		t.Fatalf("Write returned early with %v", err)
+	case <-time.After(20 * time.Millisecond):
+	}
+
+	dst := make([]int16, 2)
+	s.Read(dst)
+	if err := <-done; err != nil {
+		t.Fatalf("Write failed: %v", err)
+	}
+
+	dst = make([]int16, 4)
+	if n := s.Read(dst); n != 4 || dst[0] != 3 || dst[3] != 6 {
+		t.Errorf("Read: got %d %v, want 4 [3 4 5 6]", n, dst)
+	}
+}
+
+func TestSampleRing_CloseReleasesWriter(t *testing.T) {
+	s := NewSampleRing(2)
+	done := make(chan error, 1)
+	go func() {
+		done <- s.Write([]int16{1, 2, 3})
+	}()
+
+	for s.Available() < s.Cap() {
+		time.Sleep(time.Millisecond)
+	}
+	s.Close()
+	if err := <-done; err != ErrSampleRingClosed {
+		t.Errorf("Write after Close: got %v, want ErrSampleRingClosed", err)
+	}
+
+	// Buffered samples remain readable
+	dst := make([]int16, 4)
+	if n := s.Read(dst); n != 2 {
+		t.Errorf("Read after Close: got %d samples, want 2", n)
+	}
+	if err := s.Write([]int16{1}); err != ErrSampleRingClosed {
+		t.Errorf("Write on closed ring: got %v, want ErrSampleRingClosed", err)
+	}
+}
+
+func TestSampleRing_ConcurrentProducerConsumer(t *testing.T) {
+	const total = 200000
+	s := NewSampleRing(1000)
+
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		// Frame-sized writes, as a decode loop would produce
+		frame := make([]int16, 2048)
+		next := 0
+		for next < total {
+			n := min(len(frame), total-next)
+			for i := 0; i < n; i++ {
+				frame[i] = int16(next + i)
+			}
+			if err := s.Write(frame[:n]); err != nil {
+				t.Errorf("Write failed: %v", err)
+				return
+			}
+			next += n
+		}
+	}()
+
+	// Callback-sized reads that never block
+	dst := make([]int16, 256)
+	received := 0
+	deadline := time.Now().Add(10 * time.Second)
+	for received < total {
+		if time.Now().After(deadline) {
+			t.Fatalf("received %d of %d samples before timing out", received, total)
+		}
+		n := s.Read(dst)
+		for i := 0; i < n; i++ {
+			if dst[i] != int16(received+i) {
+				t.Fatalf("sample %d: got %d, want %d", received+i, dst[i], int16(received+i))
+			}
+		}
+		received += n
+		if n == 0 {
+			time.Sleep(10 * time.Microsecond)
+		}
+	}
+	wg.Wait()
+
+	if s.Available() != 0 {
+		t.Errorf("Available after draining: got %d, want 0", s.Available())
+	}
+}
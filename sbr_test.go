@@ -0,0 +1,92 @@
+package aac_test
+
+import (
+	"os"
+	"slices"
+	"testing"
+
+	"github.com/llehouerou/go-aac"
+)
+
+// TestDecode_SBRHeaderFill checks that frames carrying an SBR header in
+// a fill element decode to the same core output as without it.
+func TestDecode_SBRHeaderFill(t *testing.T) {
+	data, err := os.ReadFile("testdata/sine1k.aac")
+	if err != nil {
+		t.Skipf("sine1k.aac not available: %v", err)
+	}
+
+	// EXT_SBR_DATA: bs_header_flag, sbr_header() with bs_start_freq 5,
+	// bs_stop_freq 9, then zero bytes in place of sbr_data()
+	sbrFill := func(w *elementBitWriter) {
+		w.writeBits(6, 3) // ID_FIL
+		w.writeBits(6, 4) // count
+		w.writeBits(13, 4)
+		w.writeBits(1, 1)
+		w.writeBits(0, 1)  // bs_amp_res
+		w.writeBits(5, 4)  // bs_start_freq
+		w.writeBits(9, 4)  // bs_stop_freq
+		w.writeBits(0, 3)  // bs_xover_band
+		w.writeBits(0, 4)  // bs_reserved, no header_extra
+		w.writeBits(0, 27) // rest of the 6 bytes
+	}
+	withSBR := remuxMono(t, data, 1, func(w *elementBitWriter, f *monoFrame) {
+		w.copyBits(f.payload, f.sceStart, f.icsEnd)
+		sbrFill(w)
+	})
+
+	want := decodeFrames(t, remuxMono(t, data, 1, func(w *elementBitWriter, f *monoFrame) {
+		w.copyBits(f.payload, f.sceStart, f.icsEnd)
+	}), 1)
+	got := decodeFrames(t, withSBR, 1)
+	if len(got) != len(want) {
+		t.Fatalf("got %d frames, want %d", len(got), len(want))
+	}
+	for i := range want {
+		if !slices.Equal(got[i], want[i]) {
+			t.Fatalf("frame %d differs with the SBR header", i)
+		}
+	}
+}
+
+// TestDecode_SBRFillConsumed checks that a fill element with an escaped
+// count of SBR data is consumed exactly and reported as SBR presence.
+func TestDecode_SBRFillConsumed(t *testing.T) {
+	data, err := os.ReadFile("testdata/sine1k.aac")
+	if err != nil {
+		t.Skipf("sine1k.aac not available: %v", err)
+	}
+
+	// EXT_SBR_DATA_CRC of 20 bytes: bs_sbr_crc_bits, no sbr_header(),
+	// then zero bytes
+	stream := remuxMono(t, data, 1, func(w *elementBitWriter, f *monoFrame) {
+		w.copyBits(f.payload, f.sceStart, f.icsEnd)
+		w.writeBits(6, 3)  // ID_FIL
+		w.writeBits(15, 4) // count
+		w.writeBits(6, 8)  // esc_count: 15 + 6 - 1 bytes
+		w.writeBits(14, 4)
+		for range 19 {
+			w.writeBits(0, 8)
+		}
+		w.writeBits(0, 4)
+	})
+
+	d := aac.NewDecoder()
+	if _, err := d.Init(stream); err != nil {
+		t.Fatalf("Init: %v", err)
+	}
+	for pos, frame := 0, 0; pos < len(stream); frame++ {
+		frameLen := int(stream[pos+3]&3)<<11 | int(stream[pos+4])<<3 | int(stream[pos+5])>>5
+		_, info, err := d.Decode(stream[pos:])
+		if err != nil {
+			t.Fatalf("frame %d: Decode: %v", frame, err)
+		}
+		if int(info.BytesConsumed) != frameLen {
+			t.Fatalf("frame %d: consumed %d bytes, want %d", frame, info.BytesConsumed, frameLen)
+		}
+		if info.SBRPresence != aac.SBRPresent {
+			t.Errorf("frame %d: SBRPresence = %d, want SBRPresent", frame, info.SBRPresence)
+		}
+		pos += frameLen
+	}
+}
@@ -0,0 +1,32 @@
+// scalefactor_bands.go
+package aac
+
+import "github.com/llehouerou/go-aac/internal/tables"
+
+// ScaleFactorBands returns the scalefactor band boundaries of a 1024-sample
+// frame at the given sample rate: offsets[b] is the first spectral line of
+// band b and the last entry is the window length (1024 for long windows,
+// 128 for short ones).
+//
+// Only the twelve standard AAC sample rates are supported; any other rate
+// returns ErrInvalidSampleRate. The returned slice is a copy and may be
+// modified.
+//
+// Ported from: swb_offset_1024_window[] and swb_offset_128_window[] in
+// ~/dev/faad2/libfaad/specrec.c
+func ScaleFactorBands(sampleRate uint32, shortWindow bool) ([]uint16, error) {
+	srIndex := tables.GetSRIndex(sampleRate)
+	if tables.GetSampleRate(srIndex) != sampleRate {
+		return nil, ErrInvalidSampleRate
+	}
+
+	offsets, err := tables.GetSWBOffset(srIndex, 1024, shortWindow)
+	if err != nil {
+		return nil, ErrInvalidSampleRate
+	}
+	numSWB, err := tables.GetNumSWB(srIndex, 1024, shortWindow)
+	if err != nil {
+		return nil, ErrInvalidSampleRate
+	}
+	return append([]uint16(nil), offsets[:numSWB+1]...), nil
+}
@@ -0,0 +1,77 @@
+// scalefactor_bands_test.go
+package aac
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestScaleFactorBands_44100Long(t *testing.T) {
+	// swb_offset_1024_48, shared by 48000 and 44100 Hz
+	want := []uint16{
+		0, 4, 8, 12, 16, 20, 24, 28, 32, 36, 40, 48, 56, 64, 72,
+		80, 88, 96, 108, 120, 132, 144, 160, 176, 196, 216, 240, 264, 292,
+		320, 352, 384, 416, 448, 480, 512, 544, 576, 608, 640, 672, 704, 736,
+		768, 800, 832, 864, 896, 928, 1024,
+	}
+
+	got, err := ScaleFactorBands(44100, false)
+	if err != nil {
+		t.Fatalf("ScaleFactorBands: %v", err)
+	}
+	if !slices.Equal(got, want) {
+		t.Errorf("offsets:\n got %v\nwant %v", got, want)
+	}
+}
+
+func TestScaleFactorBands_44100Short(t *testing.T) {
+	want := []uint16{0, 4, 8, 12, 16, 20, 28, 36, 44, 56, 68, 80, 96, 112, 128}
+
+	got, err := ScaleFactorBands(44100, true)
+	if err != nil {
+		t.Fatalf("ScaleFactorBands: %v", err)
+	}
+	if !slices.Equal(got, want) {
+		t.Errorf("offsets:\n got %v\nwant %v", got, want)
+	}
+}
+
+func TestScaleFactorBands_AllRates(t *testing.T) {
+	for _, rate := range []uint32{96000, 88200, 64000, 48000, 44100, 32000, 24000, 22050, 16000, 12000, 11025, 8000} {
+		for _, short := range []bool{false, true} {
+			got, err := ScaleFactorBands(rate, short)
+			if err != nil {
+				t.Fatalf("%d Hz short=%v: %v", rate, short, err)
+			}
+			end := uint16(1024)
+			if short {
+				end = 128
+			}
+			if got[0] != 0 || got[len(got)-1] != end {
+				t.Errorf("%d Hz short=%v: bounds %d..%d, want 0..%d", rate, short, got[0], got[len(got)-1], end)
+			}
+			for i := 1; i < len(got); i++ {
+				if got[i] <= got[i-1] {
+					t.Errorf("%d Hz short=%v: offsets not increasing at %d", rate, short, i)
+				}
+			}
+		}
+	}
+}
+
+func TestScaleFactorBands_ReturnsCopy(t *testing.T) {
+	a, _ := ScaleFactorBands(48000, false)
+	a[1] = 999
+	b, _ := ScaleFactorBands(48000, false)
+	if b[1] != 4 {
+		t.Errorf("caller modification leaked into the table: got %d", b[1])
+	}
+}
+
+func TestScaleFactorBands_UnsupportedRate(t *testing.T) {
+	for _, rate := range []uint32{0, 44000, 7350, 192000} {
+		if _, err := ScaleFactorBands(rate, false); err != ErrInvalidSampleRate {
+			t.Errorf("%d Hz: expected ErrInvalidSampleRate, got %v", rate, err)
+		}
+	}
+}
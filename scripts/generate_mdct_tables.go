@@ -22,8 +22,9 @@ func main() {
 	fmt.Println("import \"github.com/llehouerou/go-aac/internal/fft\"")
 	fmt.Println("")
 
-	// Generate tables for AAC sizes
-	sizes := []int{2048, 256}
+	// Generate tables for AAC sizes (1024 is the AAC-LD long block; 1920,
+	// 240 and 960 are their counterparts for 960-sample frames)
+	sizes := []int{2048, 256, 1024, 1920, 240, 960}
 
 	for _, n := range sizes {
 		generateTable(n)
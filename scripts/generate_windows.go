@@ -25,6 +25,7 @@ type windowTable struct {
 	name   string
 	goName string
 	size   int
+	desc   string // what the coefficients are, "sine window" if empty
 	values []string
 }
 
@@ -34,53 +35,98 @@ func main() {
 		{name: "sine_long_1024", goName: "sineLong1024", size: 1024},
 		{name: "sine_short_128", goName: "sineShort128", size: 128},
 	}
-
-	for i := range sineTables {
-		values, err := extractTable(faad2SineWin, sineTables[i].name)
-		if err != nil {
-			fmt.Fprintf(os.Stderr, "error extracting %s: %v\n", sineTables[i].name, err)
-			os.Exit(1)
-		}
-		if len(values) != sineTables[i].size {
-			fmt.Fprintf(os.Stderr, "%s: got %d values, want %d\n", sineTables[i].name, len(values), sineTables[i].size)
-			os.Exit(1)
-		}
-		sineTables[i].values = values
+	extractTables(faad2SineWin, sineTables)
+	// Sine windows of 960-sample frames, which replace the evaluated
+	// tables of window_sine_960.go
+	sine960Tables := []windowTable{
+		{name: "sine_long_960", goName: "sineLong960", size: 960},
+		{name: "sine_short_120", goName: "sineShort120", size: 120},
 	}
+	extractTables(faad2SineWin, sine960Tables)
+	// ER AAC LD: sine and low-overlap windows, which replace the
+	// evaluated tables of window_sine_ld.go
+	ldTables := []windowTable{
+		{name: "sine_mid_512", goName: "sineMid512", size: 512},
+		{name: "sine_mid_480", goName: "sineMid480", size: 480},
+		{name: "ld_mid_512", goName: "ldMid512", size: 512, desc: "low-overlap window"},
+		{name: "ld_mid_480", goName: "ldMid480", size: 480, desc: "low-overlap window"},
+	}
+	extractTables(faad2SineWin, ldTables)
 
-	// Generate window_sine.go
-	if err := generateSineFile(sineTables); err != nil {
+	// Generate window_sine.go, window_sine_960.go and window_sine_ld.go
+	if err := generateSineFile("internal/filterbank/window_sine.go", []string{
+		"Sine window tables for IMDCT windowing.",
+		"Values extracted directly from ~/dev/faad2/libfaad/sine_win.h",
+		"to ensure bit-exact matching with FAAD2.",
+		"",
+		"Formula: w[n] = sin((π/N) * (n + 0.5)) for n = 0..N-1",
+	}, sineTables); err != nil {
 		fmt.Fprintf(os.Stderr, "error generating sine file: %v\n", err)
 		os.Exit(1)
 	}
+	if err := generateSineFile("internal/filterbank/window_sine_960.go", []string{
+		"Sine window tables of 960-sample frames.",
+		"Values extracted directly from ~/dev/faad2/libfaad/sine_win.h",
+		"to ensure bit-exact matching with FAAD2.",
+	}, sine960Tables); err != nil {
+		fmt.Fprintf(os.Stderr, "error generating sine 960 file: %v\n", err)
+		os.Exit(1)
+	}
+	if err := generateSineFile("internal/filterbank/window_sine_ld.go", []string{
+		"Sine and low-overlap window tables of the ER AAC LD filter bank.",
+		"Values extracted directly from ~/dev/faad2/libfaad/sine_win.h",
+		"to ensure bit-exact matching with FAAD2.",
+	}, ldTables); err != nil {
+		fmt.Fprintf(os.Stderr, "error generating LD sine file: %v\n", err)
+		os.Exit(1)
+	}
 
 	// Extract KBD windows from kbd_win.h
 	kbdTables := []windowTable{
 		{name: "kbd_long_1024", goName: "kbdLong1024", size: 1024},
 		{name: "kbd_short_128", goName: "kbdShort128", size: 128},
 	}
-
-	for i := range kbdTables {
-		values, err := extractTable(faad2KBDWin, kbdTables[i].name)
-		if err != nil {
-			fmt.Fprintf(os.Stderr, "error extracting %s: %v\n", kbdTables[i].name, err)
-			os.Exit(1)
-		}
-		if len(values) != kbdTables[i].size {
-			fmt.Fprintf(os.Stderr, "%s: got %d values, want %d\n", kbdTables[i].name, len(values), kbdTables[i].size)
-			os.Exit(1)
-		}
-		kbdTables[i].values = values
+	extractTables(faad2KBDWin, kbdTables)
+	// KBD windows of 960-sample frames, which replace the evaluated
+	// tables of window_kbd_960.go
+	kbd960Tables := []windowTable{
+		{name: "kbd_long_960", goName: "kbdLong960", size: 960},
+		{name: "kbd_short_120", goName: "kbdShort120", size: 120},
 	}
+	extractTables(faad2KBDWin, kbd960Tables)
 
-	// Generate window_kbd.go
-	if err := generateKBDFile(kbdTables); err != nil {
+	// Generate window_kbd.go and window_kbd_960.go
+	if err := generateKBDFile("internal/filterbank/window_kbd.go", kbdTables); err != nil {
 		fmt.Fprintf(os.Stderr, "error generating KBD file: %v\n", err)
 		os.Exit(1)
 	}
+	if err := generateKBDFile("internal/filterbank/window_kbd_960.go", kbd960Tables); err != nil {
+		fmt.Fprintf(os.Stderr, "error generating KBD 960 file: %v\n", err)
+		os.Exit(1)
+	}
 
 	fmt.Println("Generated internal/filterbank/window_sine.go")
+	fmt.Println("Generated internal/filterbank/window_sine_960.go")
+	fmt.Println("Generated internal/filterbank/window_sine_ld.go")
 	fmt.Println("Generated internal/filterbank/window_kbd.go")
+	fmt.Println("Generated internal/filterbank/window_kbd_960.go")
+}
+
+// extractTables fills in the values of tables from a FAAD2 header file,
+// exiting on error.
+func extractTables(filename string, tables []windowTable) {
+	for i := range tables {
+		values, err := extractTable(filename, tables[i].name)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "error extracting %s: %v\n", tables[i].name, err)
+			os.Exit(1)
+		}
+		if len(values) != tables[i].size {
+			fmt.Fprintf(os.Stderr, "%s: got %d values, want %d\n", tables[i].name, len(values), tables[i].size)
+			os.Exit(1)
+		}
+		tables[i].values = values
+	}
 }
 
 // extractTable extracts a window table from a FAAD2 header file
@@ -136,8 +182,8 @@ func extractTable(filename, tableName string) ([]string, error) {
 	return values, nil
 }
 
-func generateSineFile(tables []windowTable) error {
-	f, err := os.Create("internal/filterbank/window_sine.go")
+func generateSineFile(filename string, header []string, tables []windowTable) error {
+	f, err := os.Create(filename)
 	if err != nil {
 		return err
 	}
@@ -145,17 +191,19 @@ func generateSineFile(tables []windowTable) error {
 
 	fmt.Fprintln(f, "// Code generated by generate_windows.go; DO NOT EDIT.")
 	fmt.Fprintln(f, "//")
-	fmt.Fprintln(f, "// Sine window tables for IMDCT windowing.")
-	fmt.Fprintln(f, "// Values extracted directly from ~/dev/faad2/libfaad/sine_win.h")
-	fmt.Fprintln(f, "// to ensure bit-exact matching with FAAD2.")
-	fmt.Fprintln(f, "//")
-	fmt.Fprintln(f, "// Formula: w[n] = sin((π/N) * (n + 0.5)) for n = 0..N-1")
+	for _, line := range header {
+		fmt.Fprintln(f, strings.TrimRight("// "+line, " "))
+	}
 	fmt.Fprintln(f, "")
 	fmt.Fprintln(f, "package filterbank")
 	fmt.Fprintln(f, "")
 
 	for _, t := range tables {
-		fmt.Fprintf(f, "// %s contains %d sine window coefficients.\n", t.goName, t.size)
+		desc := t.desc
+		if desc == "" {
+			desc = "sine window"
+		}
+		fmt.Fprintf(f, "// %s contains %d %s coefficients.\n", t.goName, t.size, desc)
 		fmt.Fprintf(f, "var %s = [%d]float32{\n", t.goName, t.size)
 
 		for i, v := range t.values {
@@ -177,8 +225,8 @@ func generateSineFile(tables []windowTable) error {
 	return nil
 }
 
-func generateKBDFile(tables []windowTable) error {
-	f, err := os.Create("internal/filterbank/window_kbd.go")
+func generateKBDFile(filename string, tables []windowTable) error {
+	f, err := os.Create(filename)
 	if err != nil {
 		return err
 	}
@@ -0,0 +1,51 @@
+// spectrum_callback_test.go
+package aac_test
+
+import (
+	"os"
+	"testing"
+
+	"github.com/llehouerou/go-aac"
+)
+
+func TestDecode_SpectrumCallback(t *testing.T) {
+	data, err := os.ReadFile("testdata/sine1k.aac")
+	if err != nil {
+		t.Skipf("sine1k.aac not available: %v", err)
+	}
+	stereo := remuxMono(t, data, 2, func(w *elementBitWriter, f *monoFrame) {
+		writeCPE(w, f, 0, cpeLayout{})
+	})
+
+	var channels []uint8
+	var energy float64
+	d := aac.NewDecoder()
+	cfg := d.Config()
+	cfg.SpectrumCallback = func(channel uint8, spec []float64) {
+		if len(spec) != 1024 {
+			t.Errorf("channel %d: %d coefficients, want 1024", channel, len(spec))
+		}
+		channels = append(channels, channel)
+		for _, v := range spec {
+			energy += v * v
+		}
+	}
+	d.SetConfiguration(cfg)
+	if _, err := d.Init(stereo); err != nil {
+		t.Fatalf("Init: %v", err)
+	}
+	for pos, frame := 0, 0; pos < len(stereo); frame++ {
+		channels = channels[:0]
+		_, info, err := d.Decode(stereo[pos:])
+		if err != nil {
+			t.Fatalf("frame %d: %v", frame, err)
+		}
+		pos += int(info.BytesConsumed)
+		if len(channels) != 2 || channels[0] != 0 || channels[1] != 1 {
+			t.Errorf("frame %d: callback for channels %v, want [0 1]", frame, channels)
+		}
+	}
+	if energy == 0 {
+		t.Error("callback received only silent spectra")
+	}
+}
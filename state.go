@@ -0,0 +1,260 @@
+// state.go
+package aac
+
+import (
+	"encoding/binary"
+	"math"
+)
+
+// stateMagic and stateVersion start the serialized decoder state, so that
+// ImportState rejects foreign data and states of another format.
+const (
+	stateMagic   = "AACS"
+	stateVersion = 2
+)
+
+// elementStateCarrier is implemented by element decoders that can
+// serialize the state they carry across frames: the PNS noise generator,
+// the LTP history and lags, and the MAIN predictor states.
+type elementStateCarrier interface {
+	AppendState(b []byte) []byte
+	LoadState(b []byte) error
+}
+
+// ExportState serializes the state the decoder carries from one frame to
+// the next, for another decoder to resume the stream with ImportState.
+// It covers the overlap-add buffers and previous window shapes of the
+// channels and of independently switched coupling channels, the PNS noise
+// generator, the LTP history and lags, the MAIN predictor states, the
+// dither generator, the frame count and gapless position, and implicit
+// SBR detection.
+//
+// The SBR headers and the state of LATM demultiplexing are not exported,
+// nor are output held back for Config.Gapless and the last frame kept for
+// Config.ConcealErrors, so streams using them do not resume exactly.
+// ExportState returns nil for a nil decoder.
+func (d *Decoder) ExportState() []byte {
+	if d == nil {
+		return nil
+	}
+	b := append([]byte(stateMagic), stateVersion, d.objectType, d.sfIndex)
+	b = binary.LittleEndian.AppendUint16(b, d.frameLength)
+	b = binary.LittleEndian.AppendUint32(b, d.frame)
+	b = binary.LittleEndian.AppendUint64(b, d.gaplessPosition)
+	b = binary.LittleEndian.AppendUint32(b, d.dither.state)
+	b = append(b, stateBool(d.sbrPresentFlag), stateBool(d.downSampledSBR))
+
+	// Channels are allocated from 0 up
+	channels := 0
+	for channels < maxChannels && d.fbIntermed[channels] != nil {
+		channels++
+	}
+	b = append(b, uint8(channels))
+	for ch := range channels {
+		b = append(b, d.windowShapePrev[ch])
+		b = appendStateFloats(b, d.fbIntermed[ch])
+	}
+	for tag := range d.cceOverlap {
+		b = append(b, d.cceWindowShapePrev[tag])
+		b = appendStateFloats(b, d.cceOverlap[tag])
+	}
+
+	var elements []byte
+	if c, ok := d.elements.(elementStateCarrier); ok {
+		elements = c.AppendState(nil)
+	}
+	b = binary.LittleEndian.AppendUint32(b, uint32(len(elements)))
+	return append(b, elements...)
+}
+
+// ImportState restores a state serialized by ExportState, so that the
+// decoder continues the stream from the frame after the one last decoded
+// by the exporting decoder. The decoder must have been initialized for the
+// same stream, with Init or Init2, and configured alike. It returns
+// ErrInvalidState when state is malformed or was exported for another
+// object type, sample rate or frame length, and ErrChannelLimitExceeded
+// when it has more channels than Config.MaxChannels allows. The whole
+// state is checked first: on error the decoder is left unchanged.
+func (d *Decoder) ImportState(state []byte) error {
+	if d == nil {
+		return ErrNilDecoder
+	}
+	r := stateReader{b: state}
+	if string(r.bytes(len(stateMagic))) != stateMagic || r.u8() != stateVersion ||
+		r.u8() != d.objectType || r.u8() != d.sfIndex || r.u16() != d.frameLength {
+		return ErrInvalidState
+	}
+	frame := r.u32()
+	gaplessPosition := r.u64()
+	dither := r.u32()
+	sbrPresent, downSampledSBR := r.u8() != 0, r.u8() != 0
+
+	channels := int(r.u8())
+	if channels > maxChannels {
+		return ErrInvalidState
+	}
+	var shapes [maxChannels]uint8
+	overlaps := make([][]float32, channels)
+	for ch := range channels {
+		shapes[ch] = r.u8()
+		overlaps[ch] = r.floats()
+	}
+	var cceShapes [16]uint8
+	var cceOverlaps [16][]float32
+	for tag := range cceOverlaps {
+		cceShapes[tag] = r.u8()
+		cceOverlaps[tag] = r.floats()
+	}
+	elements := r.bytes(int(r.u32()))
+	if r.failed || len(r.b) != 0 {
+		return ErrInvalidState
+	}
+	for _, o := range overlaps {
+		if len(o) != int(d.frameLength) {
+			return ErrInvalidState
+		}
+	}
+	for _, o := range cceOverlaps {
+		if o != nil && len(o) != int(d.frameLength) {
+			return ErrInvalidState
+		}
+	}
+
+	if channels > int(d.channelLimit()) {
+		return ErrChannelLimitExceeded
+	}
+
+	// The element decoder state is checked as it is loaded, last: LoadState
+	// leaves the element decoder unchanged when it fails, and nothing fails
+	// after it. A decoder that has no element decoder yet keeps none.
+	if len(elements) != 0 {
+		dec := d.elements
+		if dec == nil {
+			dec = d.newElementDecoder()
+		}
+		c, ok := dec.(elementStateCarrier)
+		if !ok {
+			return ErrInvalidState
+		}
+		if err := c.LoadState(elements); err != nil {
+			return ErrInvalidState
+		}
+		d.elements = dec
+	} else if e, ok := d.elements.(interface{ Reset() }); ok {
+		e.Reset()
+	}
+
+	// Within the channel limit checked above
+	_ = d.allocateChannelBuffers(uint8(channels))
+	for ch := range maxChannels {
+		clear(d.fbIntermed[ch])
+		if ch < channels {
+			copy(d.fbIntermed[ch], overlaps[ch])
+		}
+	}
+	d.windowShapePrev = shapes
+	for tag, o := range cceOverlaps {
+		if o == nil {
+			clear(d.cceOverlap[tag])
+			continue
+		}
+		if len(d.cceTimeOut[tag]) != int(d.frameLength) {
+			d.cceTimeOut[tag] = make([]float32, d.frameLength)
+			d.cceOverlap[tag] = make([]float32, d.frameLength)
+		}
+		copy(d.cceOverlap[tag], o)
+	}
+	d.cceWindowShapePrev = cceShapes
+
+	d.frame = frame
+	d.gaplessPosition = gaplessPosition
+	d.gaplessHeld = nil
+	d.dither.state = dither
+	d.sbrPresentFlag = sbrPresent
+	d.downSampledSBR = downSampledSBR
+	d.conceal = concealState{}
+	d.postSeekResetFlag = false
+	return nil
+}
+
+// stateBool encodes a flag of the serialized state.
+func stateBool(v bool) uint8 {
+	if v {
+		return 1
+	}
+	return 0
+}
+
+// appendStateFloats appends the length of s and its samples. A nil buffer
+// is written as empty.
+func appendStateFloats(b []byte, s []float32) []byte {
+	b = binary.LittleEndian.AppendUint32(b, uint32(len(s)))
+	for _, v := range s {
+		b = binary.LittleEndian.AppendUint32(b, math.Float32bits(v))
+	}
+	return b
+}
+
+// stateReader reads the fields of a serialized state. Reading past the
+// end sets failed and returns zero values.
+type stateReader struct {
+	b      []byte
+	failed bool
+}
+
+func (r *stateReader) bytes(n int) []byte {
+	if n < 0 || n > len(r.b) {
+		r.failed = true
+		r.b = nil
+		return nil
+	}
+	v := r.b[:n]
+	r.b = r.b[n:]
+	return v
+}
+
+func (r *stateReader) u8() uint8 {
+	if v := r.bytes(1); v != nil {
+		return v[0]
+	}
+	return 0
+}
+
+func (r *stateReader) u16() uint16 {
+	if v := r.bytes(2); v != nil {
+		return binary.LittleEndian.Uint16(v)
+	}
+	return 0
+}
+
+func (r *stateReader) u32() uint32 {
+	if v := r.bytes(4); v != nil {
+		return binary.LittleEndian.Uint32(v)
+	}
+	return 0
+}
+
+func (r *stateReader) u64() uint64 {
+	if v := r.bytes(8); v != nil {
+		return binary.LittleEndian.Uint64(v)
+	}
+	return 0
+}
+
+// floats reads a buffer written by appendStateFloats, nil if empty.
+func (r *stateReader) floats() []float32 {
+	n := int(r.u32())
+	if n > len(r.b)/4 {
+		r.failed = true
+		r.b = nil
+		return nil
+	}
+	if n == 0 {
+		return nil
+	}
+	v := make([]float32, n)
+	for i := range v {
+		v[i] = math.Float32frombits(r.u32())
+	}
+	return v
+}
@@ -0,0 +1,154 @@
+// state_test.go
+package aac_test
+
+import (
+	"encoding/binary"
+	"errors"
+	"os"
+	"slices"
+	"testing"
+
+	"github.com/llehouerou/go-aac"
+)
+
+// TestDecoder_ExportImportState decodes the first frames of sine1k.aac,
+// moves the state to a fresh decoder and checks that it decodes the rest
+// of the stream bit-identically, PNS noise included.
+func TestDecoder_ExportImportState(t *testing.T) {
+	data, err := os.ReadFile("testdata/sine1k.aac")
+	if err != nil {
+		t.Skipf("sine1k.aac not available: %v", err)
+	}
+	state, b := resumeDecoding(t, data)
+
+	// Malformed states and states of another stream are rejected
+	for _, bad := range [][]byte{
+		nil,
+		[]byte("not a state"),
+		state[:len(state)-1],
+		append(slices.Clone(state), 0),
+	} {
+		if err := b.ImportState(bad); !errors.Is(err, aac.ErrInvalidState) {
+			t.Errorf("ImportState of %d bytes: %v, want ErrInvalidState", len(bad), err)
+		}
+	}
+	c := aac.NewDecoder()
+	// AAC LC, 48 kHz, mono
+	if _, err := c.Init2([]byte{0x11, 0x88}); err != nil {
+		t.Fatalf("Init2: %v", err)
+	}
+	if err := c.ImportState(state); !errors.Is(err, aac.ErrInvalidState) {
+		t.Errorf("ImportState at another sample rate: %v, want ErrInvalidState", err)
+	}
+}
+
+// TestDecoder_ExportImportState_Main resumes sine1k.aac remuxed as AAC
+// Main with prediction in every long frame, which only decodes alike with
+// the predictor states carried over.
+func TestDecoder_ExportImportState_Main(t *testing.T) {
+	data, err := os.ReadFile("testdata/sine1k.aac")
+	if err != nil {
+		t.Skipf("sine1k.aac not available: %v", err)
+	}
+	resumeDecoding(t, remuxMain(t, data, true))
+}
+
+// resumeDecoding decodes the first frames of the ADTS stream data, moves
+// the state to a fresh decoder and checks that it decodes the rest of the
+// stream bit-identically. It returns the state and the resumed decoder.
+func resumeDecoding(t *testing.T, data []byte) ([]byte, *aac.Decoder) {
+	t.Helper()
+	const resumeAt = 5
+
+	a := aac.NewDecoder()
+	if _, err := a.Init(data); err != nil {
+		t.Fatalf("Init: %v", err)
+	}
+	offset := 0
+	for f := range resumeAt {
+		_, info, err := a.Decode(data[offset:])
+		if err != nil {
+			t.Fatalf("frame %d: %v", f, err)
+		}
+		offset += int(info.BytesConsumed)
+	}
+	state := a.ExportState()
+
+	b := aac.NewDecoder()
+	if _, err := b.Init(data[offset:]); err != nil {
+		t.Fatalf("Init of resuming decoder: %v", err)
+	}
+	if err := b.ImportState(state); err != nil {
+		t.Fatalf("ImportState: %v", err)
+	}
+
+	frames := 0
+	for f := resumeAt; offset < len(data); f++ {
+		want, wantInfo, err := a.Decode(data[offset:])
+		if err != nil {
+			t.Fatalf("frame %d: %v", f, err)
+		}
+		got, gotInfo, err := b.Decode(data[offset:])
+		if err != nil {
+			t.Fatalf("frame %d, resumed: %v", f, err)
+		}
+		if gotInfo.Samples != wantInfo.Samples {
+			t.Fatalf("frame %d: resumed decoder gave %d samples, want %d", f, gotInfo.Samples, wantInfo.Samples)
+		}
+		if !slices.Equal(got.([]int16), want.([]int16)) {
+			t.Errorf("frame %d differs after resuming", f)
+		}
+		offset += int(wantInfo.BytesConsumed)
+		frames++
+	}
+	if frames == 0 {
+		t.Fatal("no frames left to resume")
+	}
+	return state, b
+}
+
+// TestDecoder_ImportState_CorruptElements imports a state whose element
+// decoder section is corrupt, which must leave the decoder unchanged.
+func TestDecoder_ImportState_CorruptElements(t *testing.T) {
+	data, err := os.ReadFile("testdata/sine1k.aac")
+	if err != nil {
+		t.Skipf("sine1k.aac not available: %v", err)
+	}
+	state, _ := resumeDecoding(t, remuxMain(t, data, true))
+
+	// The element section follows the header, the channels and the 16
+	// coupling channels, each a window shape and a float buffer, and its
+	// 32-bit length. Its ninth byte is the LTP channel count.
+	pos := 27
+	skipBuffers := func(n int) {
+		for range n {
+			pos++ // window shape
+			pos += 4 + 4*int(binary.LittleEndian.Uint32(state[pos:]))
+		}
+	}
+	channels := int(state[pos])
+	pos++
+	skipBuffers(channels)
+	skipBuffers(16)
+	start := pos + 4
+	if int(binary.LittleEndian.Uint32(state[pos:])) != len(state)-start || len(state)-start < 9 {
+		t.Fatal("element section not found")
+	}
+	corrupt := slices.Clone(state)
+	corrupt[start+8] = 0xFF
+
+	d := aac.NewDecoder()
+	if _, err := d.Init(remuxMain(t, data, true)); err != nil {
+		t.Fatalf("Init: %v", err)
+	}
+	before := d.ExportState()
+	if err := d.ImportState(corrupt); !errors.Is(err, aac.ErrInvalidState) {
+		t.Fatalf("ImportState: %v, want ErrInvalidState", err)
+	}
+	if after := d.ExportState(); !slices.Equal(after, before) {
+		t.Error("failed ImportState changed the decoder")
+	}
+	if err := d.ImportState(state); err != nil {
+		t.Errorf("ImportState of the intact state after a failure: %v", err)
+	}
+}
@@ -0,0 +1,40 @@
+// strict.go
+package aac
+
+import (
+	"errors"
+
+	"github.com/llehouerou/go-aac/internal/spectrum"
+)
+
+// strictChecker is implemented by element decoders that check parsed
+// elements for the values Config.Strict rejects.
+type strictChecker interface {
+	CheckStrict(element any) error
+}
+
+// checkStrict returns the error Config.Strict raises for a parsed
+// element, or nil when Strict is off or the element conforms.
+func (d *Decoder) checkStrict(element any) error {
+	if !d.config.Strict {
+		return nil
+	}
+	if c, ok := d.elements.(strictChecker); ok {
+		return strictError(c.CheckStrict(element))
+	}
+	return nil
+}
+
+// strictError returns the error code of an error of
+// spectrum.ElementDecoder.CheckStrict.
+func strictError(err error) error {
+	switch {
+	case errors.Is(err, spectrum.ErrTNSOrderOutOfRange):
+		return ErrTNSOrderOutOfRange
+	case errors.Is(err, spectrum.ErrScalefactorOutOfRange):
+		return ErrScalefactorOutOfRange
+	case errors.Is(err, spectrum.ErrGlobalGainMismatch):
+		return ErrGlobalGainMismatch
+	}
+	return err
+}
@@ -0,0 +1,97 @@
+// strict_test.go
+package aac
+
+import (
+	"errors"
+	"os"
+	"testing"
+)
+
+// tnsOrderFrame returns an ADTS frame of a mono SCE without spectral
+// bands whose TNS filter has the given order.
+func tnsOrderFrame(t *testing.T, order uint32) []byte {
+	t.Helper()
+	w := &adifBitWriter{}
+	w.writeBits(0, 3)   // ID_SCE
+	w.writeBits(0, 4)   // element_instance_tag
+	w.writeBits(100, 8) // global_gain
+	w.writeBits(0, 1)   // ics_reserved_bit
+	w.writeBits(0, 2)   // ONLY_LONG_SEQUENCE
+	w.writeBits(0, 1)   // window_shape
+	w.writeBits(0, 6)   // max_sfb
+	w.writeBits(0, 1)   // predictor_data_present
+	w.writeBits(0, 1)   // pulse_data_present
+	w.writeBits(1, 1)   // tns_data_present
+	w.writeBits(1, 2)   // n_filt
+	w.writeBits(1, 1)   // coef_res: 4-bit coefficients
+	w.writeBits(0, 6)   // length
+	w.writeBits(order, 5)
+	w.writeBits(0, 1) // direction
+	w.writeBits(0, 1) // coef_compress
+	for range order {
+		w.writeBits(0, 4)
+	}
+	w.writeBits(0, 1) // gain_control_data_present
+	w.writeBits(7, 3) // ID_END
+
+	header, err := BuildADTSHeader(ADTSConfig{
+		ObjectType:           ObjectTypeLC,
+		SFIndex:              4,
+		ChannelConfiguration: 1,
+		BufferFullness:       0x7FF,
+	}, len(w.buf))
+	if err != nil {
+		t.Fatalf("BuildADTSHeader: %v", err)
+	}
+	return append(header, w.buf...)
+}
+
+func TestDecode_StrictTNSOrder(t *testing.T) {
+	for _, tt := range []struct {
+		order  uint32
+		strict bool
+		want   error
+	}{
+		{20, true, nil},
+		{21, false, nil}, // Clamped to 20
+		{21, true, ErrTNSOrderOutOfRange},
+		{31, true, ErrTNSOrderOutOfRange},
+	} {
+		frame := tnsOrderFrame(t, tt.order)
+		d := NewDecoder()
+		cfg := d.Config()
+		cfg.Strict = tt.strict
+		d.SetConfiguration(cfg)
+		if _, err := d.Init(frame); err != nil {
+			t.Fatalf("Init: %v", err)
+		}
+		_, info, err := d.Decode(frame)
+		if !errors.Is(err, tt.want) {
+			t.Errorf("order %d, strict %v: Decode error %v, want %v", tt.order, tt.strict, err, tt.want)
+		}
+		if err == nil && info.Channels != 1 {
+			t.Errorf("order %d, strict %v: %d channels, want 1", tt.order, tt.strict, info.Channels)
+		}
+	}
+}
+
+func TestDecode_StrictConformantStream(t *testing.T) {
+	data, err := os.ReadFile("testdata/sine1k.aac")
+	if err != nil {
+		t.Skipf("sine1k.aac not available: %v", err)
+	}
+	d := NewDecoder()
+	cfg := d.Config()
+	cfg.Strict = true
+	d.SetConfiguration(cfg)
+	if _, err := d.Init(data); err != nil {
+		t.Fatalf("Init: %v", err)
+	}
+	for pos := 0; pos < len(data); {
+		_, info, err := d.Decode(data[pos:])
+		if err != nil {
+			t.Fatalf("offset %d: %v", pos, err)
+		}
+		pos += int(info.BytesConsumed)
+	}
+}
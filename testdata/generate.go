@@ -10,11 +10,13 @@
 //   ├── aac_lc/           # AAC-LC profile tests
 //   │   ├── 44100_16_mono/
 //   │   ├── 44100_16_stereo/
+//   │   ├── 48000_16_5.1/
 //   │   └── ...
 //   ├── he_aac/           # HE-AAC (SBR) tests
 //   │   └── ...
 //   ├── he_aac_v2/        # HE-AACv2 (SBR+PS) tests
 //   │   └── ...
+//   ├── crc/              # CRC-protected ADTS stream (fdkaac)
 //   └── real_audio/       # Real audio samples
 //       └── ...
 
@@ -49,7 +51,7 @@ var realAudioSamples = []struct {
 type TestConfig struct {
 	SampleRate  int    `json:"sample_rate"`
 	BitDepth    int    `json:"bit_depth"`    // Output bit depth (16 or 24)
-	NumChannels int    `json:"num_channels"` // 1=mono, 2=stereo
+	NumChannels int    `json:"num_channels"` // 1=mono, 2=stereo, 6=5.1
 	Profile     string `json:"profile"`      // "aac_lc", "he_aac", "he_aac_v2"
 	Bitrate     int    `json:"bitrate"`      // Target bitrate in kbps
 }
@@ -67,6 +69,7 @@ var aacLCConfigs = []TestConfig{
 	{22050, 16, 1, "aac_lc", 32},  // Low sample rate mono
 	{22050, 16, 2, "aac_lc", 64},  // Low sample rate stereo
 	{16000, 16, 1, "aac_lc", 24},  // Speech-like
+	{48000, 16, 6, "aac_lc", 384}, // 5.1 with LFE
 }
 
 // HE-AAC configurations (SBR)
@@ -113,6 +116,12 @@ func main() {
 	fmt.Println("\n=== Generating HE-AACv2 test data ===")
 	generateProfileTests(baseDir, "he_aac_v2", heAACv2Configs)
 
+	// Generate a CRC-protected stream
+	fmt.Println("\n=== Generating CRC test data ===")
+	if err := generateCRCTestCase(filepath.Join(baseDir, "crc")); err != nil {
+		fmt.Fprintf(os.Stderr, "Error generating CRC test data: %v\n", err)
+	}
+
 	// Generate real audio samples
 	fmt.Println("\n=== Generating real audio test data ===")
 	realDir := filepath.Join(baseDir, "real_audio")
@@ -158,6 +167,36 @@ func generateProfileTests(baseDir, profile string, configs []TestConfig) {
 	}
 }
 
+// generateCRCTestCase writes crc/sine1k_stereo.aac, a stereo ADTS stream
+// with crc_check, with the fdkaac command line encoder; FFmpeg's ADTS
+// muxer never protects frames.
+func generateCRCTestCase(dir string) error {
+	aacPath := filepath.Join(dir, "sine1k_stereo.aac")
+	if fileExists(aacPath) {
+		return nil
+	}
+	if _, err := exec.LookPath("fdkaac"); err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: fdkaac not available, skipping CRC test data\n")
+		return nil
+	}
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return err
+	}
+
+	cfg := TestConfig{SampleRate: 44100, BitDepth: 16, NumChannels: 2, Profile: "aac_lc", Bitrate: 128}
+	wavPath := filepath.Join(dir, "sine1k_stereo.wav")
+	if err := generateWAV(wavPath, "sine1k", cfg, cfg.SampleRate); err != nil {
+		return fmt.Errorf("generating WAV: %w", err)
+	}
+	defer os.Remove(wavPath)
+
+	// Transport format 2 is ADTS
+	cmd := exec.Command("fdkaac", "--transport-format", "2", "--adts-crc-check",
+		"--bitrate", "128", "-o", aacPath, wavPath)
+	cmd.Stderr = os.Stderr
+	return cmd.Run()
+}
+
 func checkFFmpeg() error {
 	cmd := exec.Command("ffmpeg", "-version")
 	if err := cmd.Run(); err != nil {
@@ -167,8 +206,11 @@ func checkFFmpeg() error {
 }
 
 func channelName(n int) string {
-	if n == 1 {
+	switch n {
+	case 1:
 		return "mono"
+	case 6:
+		return "5.1"
 	}
 	return "stereo"
 }
@@ -177,7 +219,7 @@ func generateTestCase(dir, audioType string, cfg TestConfig) error {
 	wavPath := filepath.Join(dir, audioType+".wav")
 	aacPath := filepath.Join(dir, audioType+".aac") // ADTS format
 	m4aPath := filepath.Join(dir, audioType+".m4a") // M4A container
-	rawPath := filepath.Join(dir, audioType+".raw") // Reference PCM
+	rawPath := filepath.Join(dir, audioType+".raw") // Reference PCM of the ADTS stream
 	jsonPath := filepath.Join(dir, audioType+".json")
 
 	// Skip if all files exist
@@ -197,8 +239,9 @@ func generateTestCase(dir, audioType string, cfg TestConfig) error {
 		return fmt.Errorf("encoding AAC: %w", err)
 	}
 
-	// Decode to raw PCM (reference output using M4A)
-	if err := decodeToRaw(m4aPath, rawPath, cfg); err != nil {
+	// Decode to raw PCM (reference output of the ADTS stream the tests
+	// decode)
+	if err := decodeToRaw(aacPath, rawPath, cfg); err != nil {
 		return fmt.Errorf("decoding to raw: %w", err)
 	}
 
@@ -287,10 +330,10 @@ func generateWAV(path, audioType string, cfg TestConfig, samples int) error {
 				sample *= envelope
 			}
 
-			// Stereo: slight phase difference for spatial effect
-			if cfg.NumChannels == 2 && ch == 1 && audioType != "silence" {
-				// Add small delay effect for right channel
-				sample *= 0.95
+			// Each channel 5% below the previous one, so that decoders
+			// mixing up channels are caught
+			if audioType != "silence" {
+				sample *= 1 - 0.05*float64(ch)
 			}
 
 			// Scale to bit depth and write
@@ -385,6 +428,10 @@ func encodeAAC(wavPath, aacPath, m4aPath string, cfg TestConfig) error {
 	}
 
 	bitrateArg := fmt.Sprintf("%dk", cfg.Bitrate)
+	if cfg.NumChannels == 6 {
+		// A plain 6-channel WAV has no channel mask
+		profileArgs = append(profileArgs, "-af", "aformat=channel_layouts=5.1")
+	}
 
 	// Encode to ADTS (.aac)
 	aacArgs := []string{"-y", "-i", wavPath, "-c:a", encoder}
@@ -433,11 +480,11 @@ func containsHelper(s, substr string) bool {
 	return false
 }
 
-func decodeToRaw(m4aPath, rawPath string, cfg TestConfig) error {
+func decodeToRaw(srcPath, rawPath string, cfg TestConfig) error {
 	// Always decode to 16-bit PCM for comparison simplicity
 	format := "s16le"
 
-	cmd := exec.Command("ffmpeg", "-y", "-i", m4aPath,
+	cmd := exec.Command("ffmpeg", "-y", "-i", srcPath,
 		"-f", format, "-acodec", "pcm_"+format, rawPath)
 	cmd.Stderr = os.Stderr
 	return cmd.Run()
@@ -483,7 +530,7 @@ func generateRealAudioTestCase(dir, name, wavSourcePath string) error {
 	}
 
 	// Decode to raw PCM (reference)
-	if err := decodeToRaw(m4aPath, rawPath, cfg); err != nil {
+	if err := decodeToRaw(aacPath, rawPath, cfg); err != nil {
 		return fmt.Errorf("decoding to raw: %w", err)
 	}
 
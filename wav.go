@@ -0,0 +1,240 @@
+// wav.go
+package aac
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"io"
+)
+
+// WAVOptions configures DecodeToWAV.
+type WAVOptions struct {
+	// OutputFormat is the sample format of the file. The zero value
+	// writes 16-bit PCM; OutputFormatFloat and OutputFormatDouble are
+	// written as IEEE float.
+	OutputFormat OutputFormat
+
+	// Config, when non-nil, configures the decoder, for instance for
+	// mixdowns or DRC. Its OutputFormat is replaced by the one above, and
+	// the samples are always interleaved, little-endian and in WAV
+	// channel order.
+	Config *Config
+}
+
+// WAV format tags and the WAVE_FORMAT_EXTENSIBLE sub-format GUID, whose
+// first two bytes hold the tag of the samples.
+const (
+	wavFormatPCM        = 0x0001
+	wavFormatFloat      = 0x0003
+	wavFormatExtensible = 0xFFFE
+)
+
+var wavSubFormatGUID = [16]byte{0, 0, 0, 0, 0x00, 0x00, 0x10, 0x00, 0x80, 0x00, 0x00, 0xAA, 0x00, 0x38, 0x9B, 0x71}
+
+// wavChannelMask maps channel positions to their WAVE_FORMAT_EXTENSIBLE
+// speaker bits.
+var wavChannelMask = map[ChannelPosition]uint32{
+	ChannelFrontLeft:   0x001,
+	ChannelFrontRight:  0x002,
+	ChannelFrontCenter: 0x004,
+	ChannelLFE:         0x008,
+	ChannelBackLeft:    0x010,
+	ChannelBackRight:   0x020,
+	ChannelBackCenter:  0x100,
+	ChannelSideLeft:    0x200,
+	ChannelSideRight:   0x400,
+}
+
+// DecodeToWAV decodes the whole ADTS or ADIF stream src and writes it to
+// w as a RIFF/WAVE file. The stream is framed as by DecodeAll, and the
+// format, sample rate and channel layout of the file are those of its
+// first frame.
+//
+// The RIFF and data sizes precede the samples. When w is an
+// io.WriteSeeker the samples are streamed and the sizes patched at the
+// end; otherwise the samples are buffered in memory and written after
+// the header. Sizes beyond 4 GiB are written as 0xFFFFFFFF.
+//
+// Files with more than two channels or more than 16 bits per sample use
+// WAVE_FORMAT_EXTENSIBLE, with a channel mask when every channel has a
+// distinct known position.
+func DecodeToWAV(src []byte, w io.Writer, opts WAVOptions) error {
+	cfg := NewDecoder().Config()
+	if opts.Config != nil {
+		cfg = *opts.Config
+	}
+	cfg.OutputFormat = opts.OutputFormat
+	if cfg.OutputFormat == 0 {
+		cfg.OutputFormat = OutputFormat16Bit
+	}
+	cfg.Planar = false
+	cfg.ByteOrder = ByteOrderLittleEndian
+	cfg.ChannelOrder = OrderWAV
+	if err := validateConfig(&cfg); err != nil {
+		return err
+	}
+
+	ws, seekable := w.(io.WriteSeeker)
+	var start int64
+	if seekable {
+		var err error
+		if start, err = ws.Seek(0, io.SeekCurrent); err != nil {
+			return err
+		}
+	}
+
+	var (
+		header   *wavHeader
+		buffered bytes.Buffer
+		dataSize uint64
+	)
+	out := io.Writer(&buffered)
+	if seekable {
+		out = w
+	}
+	err := decodeFrames(bufio.NewReader(bytes.NewReader(src)), cfg, func(samples any, info *FrameInfo) error {
+		if header == nil {
+			header = newWAVHeader(cfg.OutputFormat, info)
+			if seekable {
+				if _, err := w.Write(header.bytes(0)); err != nil {
+					return err
+				}
+			}
+		}
+		// The muted first frame returns a buffer but reports no samples
+		if info.Samples == 0 {
+			return nil
+		}
+		pcm := packPCM(samples, cfg.OutputFormat == OutputFormat24Bit, ByteOrderLittleEndian)
+		dataSize += uint64(len(pcm))
+		_, err := out.Write(pcm)
+		return err
+	})
+	if err != nil {
+		return err
+	}
+	if header == nil {
+		return ErrNoHeaderDetected
+	}
+
+	// RIFF chunks are padded to an even size
+	var pad []byte
+	if dataSize%2 == 1 {
+		pad = []byte{0}
+	}
+	if !seekable {
+		if _, err := w.Write(header.bytes(dataSize)); err != nil {
+			return err
+		}
+		if _, err := buffered.WriteTo(w); err != nil {
+			return err
+		}
+		_, err := w.Write(pad)
+		return err
+	}
+
+	if _, err := w.Write(pad); err != nil {
+		return err
+	}
+	end, err := ws.Seek(0, io.SeekCurrent)
+	if err != nil {
+		return err
+	}
+	if _, err := ws.Seek(start, io.SeekStart); err != nil {
+		return err
+	}
+	if _, err := w.Write(header.bytes(dataSize)); err != nil {
+		return err
+	}
+	_, err = ws.Seek(end, io.SeekStart)
+	return err
+}
+
+// wavHeader holds the fmt chunk fields of a WAV file.
+type wavHeader struct {
+	formatTag     uint16 // wavFormatPCM or wavFormatFloat
+	channels      uint16
+	sampleRate    uint32
+	bitsPerSample uint16
+	channelMask   uint32
+}
+
+// newWAVHeader returns the header of a file of format samples with the
+// sample rate and channel layout of info.
+func newWAVHeader(format OutputFormat, info *FrameInfo) *wavHeader {
+	h := &wavHeader{
+		formatTag:  wavFormatPCM,
+		channels:   uint16(info.Channels),
+		sampleRate: info.SampleRate,
+	}
+	switch format {
+	case OutputFormat24Bit:
+		h.bitsPerSample = 24
+	case OutputFormat32Bit:
+		h.bitsPerSample = 32
+	case OutputFormatFloat:
+		h.formatTag, h.bitsPerSample = wavFormatFloat, 32
+	case OutputFormatDouble:
+		h.formatTag, h.bitsPerSample = wavFormatFloat, 64
+	default:
+		h.bitsPerSample = 16
+	}
+
+	for _, pos := range info.ChannelPosition[:info.Channels] {
+		bit := wavChannelMask[pos]
+		if bit == 0 || h.channelMask&bit != 0 {
+			h.channelMask = 0
+			break
+		}
+		h.channelMask |= bit
+	}
+	return h
+}
+
+// bytes returns the RIFF header, fmt chunk and data chunk header of a
+// file with dataSize bytes of samples.
+func (h *wavHeader) bytes(dataSize uint64) []byte {
+	extensible := h.channels > 2 || h.bitsPerSample > 16
+	fmtSize := uint32(16)
+	if extensible {
+		fmtSize = 40
+	}
+	blockAlign := h.channels * (h.bitsPerSample / 8)
+	riffSize := 4 + 8 + uint64(fmtSize) + 8 + dataSize + dataSize%2
+
+	le := binary.LittleEndian
+	b := make([]byte, 0, 20+fmtSize+8)
+	b = append(b, "RIFF"...)
+	b = le.AppendUint32(b, clampUint32(riffSize))
+	b = append(b, "WAVE"...)
+
+	b = append(b, "fmt "...)
+	b = le.AppendUint32(b, fmtSize)
+	if extensible {
+		b = le.AppendUint16(b, wavFormatExtensible)
+	} else {
+		b = le.AppendUint16(b, h.formatTag)
+	}
+	b = le.AppendUint16(b, h.channels)
+	b = le.AppendUint32(b, h.sampleRate)
+	b = le.AppendUint32(b, h.sampleRate*uint32(blockAlign))
+	b = le.AppendUint16(b, blockAlign)
+	b = le.AppendUint16(b, h.bitsPerSample)
+	if extensible {
+		b = le.AppendUint16(b, 22) // cbSize
+		b = le.AppendUint16(b, h.bitsPerSample)
+		b = le.AppendUint32(b, h.channelMask)
+		guid := wavSubFormatGUID
+		le.PutUint16(guid[:2], h.formatTag)
+		b = append(b, guid[:]...)
+	}
+
+	b = append(b, "data"...)
+	return le.AppendUint32(b, clampUint32(dataSize))
+}
+
+// clampUint32 returns v, or 0xFFFFFFFF if v does not fit a RIFF size.
+func clampUint32(v uint64) uint32 {
+	return uint32(min(v, 0xFFFFFFFF))
+}
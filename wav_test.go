@@ -0,0 +1,147 @@
+// wav_test.go
+package aac
+
+import (
+	"bytes"
+	"encoding/binary"
+	"io"
+	"os"
+	"testing"
+)
+
+// seekBuffer is an in-memory io.WriteSeeker.
+type seekBuffer struct {
+	buf []byte
+	pos int
+}
+
+func (b *seekBuffer) Write(p []byte) (int, error) {
+	if end := b.pos + len(p); end > len(b.buf) {
+		b.buf = append(b.buf, make([]byte, end-len(b.buf))...)
+	}
+	n := copy(b.buf[b.pos:], p)
+	b.pos += n
+	return n, nil
+}
+
+func (b *seekBuffer) Seek(offset int64, whence int) (int64, error) {
+	switch whence {
+	case io.SeekCurrent:
+		offset += int64(b.pos)
+	case io.SeekEnd:
+		offset += int64(len(b.buf))
+	}
+	b.pos = int(offset)
+	return offset, nil
+}
+
+// wavFile holds the header fields of a WAV file read back by readWAV.
+type wavFile struct {
+	riffSize      uint32
+	formatTag     uint16
+	channels      uint16
+	sampleRate    uint32
+	byteRate      uint32
+	blockAlign    uint16
+	bitsPerSample uint16
+	channelMask   uint32
+	subFormat     uint16
+	data          []byte
+}
+
+func readWAV(t *testing.T, b []byte) wavFile {
+	t.Helper()
+	le := binary.LittleEndian
+	if len(b) < 44 || string(b[:4]) != "RIFF" || string(b[8:12]) != "WAVE" || string(b[12:16]) != "fmt " {
+		t.Fatalf("not a RIFF/WAVE file: % x", b[:min(len(b), 16)])
+	}
+	f := wavFile{
+		riffSize:      le.Uint32(b[4:]),
+		formatTag:     le.Uint16(b[20:]),
+		channels:      le.Uint16(b[22:]),
+		sampleRate:    le.Uint32(b[24:]),
+		byteRate:      le.Uint32(b[28:]),
+		blockAlign:    le.Uint16(b[32:]),
+		bitsPerSample: le.Uint16(b[34:]),
+	}
+	pos := 20 + int(le.Uint32(b[16:]))
+	if f.formatTag == wavFormatExtensible {
+		f.channelMask = le.Uint32(b[40:])
+		f.subFormat = le.Uint16(b[44:])
+	}
+	if string(b[pos:pos+4]) != "data" {
+		t.Fatalf("no data chunk at %d", pos)
+	}
+	size := int(le.Uint32(b[pos+4:]))
+	f.data = b[pos+8 : pos+8+size]
+	if int(f.riffSize) != len(b)-8 {
+		t.Errorf("RIFF size %d for a %d-byte file", f.riffSize, len(b))
+	}
+	return f
+}
+
+func TestDecodeToWAV(t *testing.T) {
+	data, err := os.ReadFile("testdata/sine1k.aac")
+	if err != nil {
+		t.Skipf("sine1k.aac not available: %v", err)
+	}
+	pcm, info, err := DecodeAll(data)
+	if err != nil {
+		t.Fatalf("DecodeAll: %v", err)
+	}
+
+	// 16-bit PCM, buffered for a plain writer
+	var buf bytes.Buffer
+	if err := DecodeToWAV(data, &buf, WAVOptions{}); err != nil {
+		t.Fatalf("DecodeToWAV: %v", err)
+	}
+	f := readWAV(t, buf.Bytes())
+	if f.formatTag != wavFormatPCM || f.channels != 1 || f.sampleRate != 44100 ||
+		f.bitsPerSample != 16 || f.blockAlign != 2 || f.byteRate != 88200 {
+		t.Errorf("16-bit header: %+v", f)
+	}
+	if len(f.data) != 2*len(pcm) {
+		t.Fatalf("%d data bytes, want %d", len(f.data), 2*len(pcm))
+	}
+	for i, v := range pcm {
+		if got := int16(binary.LittleEndian.Uint16(f.data[2*i:])); got != v {
+			t.Fatalf("sample %d = %d, want %d", i, got, v)
+		}
+	}
+
+	// Extensible formats, streamed to a seekable writer after a prefix
+	for _, tt := range []struct {
+		format    OutputFormat
+		bits      uint16
+		subFormat uint16
+	}{
+		{OutputFormat24Bit, 24, wavFormatPCM},
+		{OutputFormatFloat, 32, wavFormatFloat},
+		{OutputFormatDouble, 64, wavFormatFloat},
+	} {
+		sb := &seekBuffer{}
+		sb.Write([]byte("prefix"))
+		if err := DecodeToWAV(data, sb, WAVOptions{OutputFormat: tt.format}); err != nil {
+			t.Fatalf("format %d: DecodeToWAV: %v", tt.format, err)
+		}
+		if sb.pos != len(sb.buf) {
+			t.Errorf("format %d: writer left at %d of %d bytes", tt.format, sb.pos, len(sb.buf))
+		}
+		f := readWAV(t, sb.buf[len("prefix"):])
+		blockAlign := tt.bits / 8
+		if f.formatTag != wavFormatExtensible || f.subFormat != tt.subFormat || f.bitsPerSample != tt.bits ||
+			f.blockAlign != blockAlign || f.byteRate != 44100*uint32(blockAlign) {
+			t.Errorf("format %d header: %+v", tt.format, f)
+		}
+		if f.channelMask != 0x004 {
+			t.Errorf("format %d: channel mask %#x, want front center", tt.format, f.channelMask)
+		}
+		if want := int(info.TotalSamples) * int(blockAlign); len(f.data) != want {
+			t.Errorf("format %d: %d data bytes, want %d", tt.format, len(f.data), want)
+		}
+	}
+
+	if err := DecodeToWAV([]byte("not an aac stream"), &buf, WAVOptions{}); err != ErrNoHeaderDetected {
+		t.Errorf("DecodeToWAV(garbage) error = %v, want ErrNoHeaderDetected", err)
+	}
+}